@@ -17,7 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,376 +38,2493 @@ type PodSleuthSpec struct {
 	// LogAnalysis enables log analysis for running but not ready pods
 	// +optional
 	LogAnalysis *LogAnalysisConfig `json:"logAnalysis,omitempty"`
+
+	// CrashLoopTrend configures trend analysis for pods that restart repeatedly
+	// +optional
+	CrashLoopTrend *CrashLoopTrendConfig `json:"crashLoopTrend,omitempty"`
+
+	// DebugDiagnostics attaches an ephemeral debug container to failing pods to run
+	// network and disk checks. Requires the optional ephemeral container RBAC role.
+	// +optional
+	DebugDiagnostics *DebugDiagnosticsConfig `json:"debugDiagnostics,omitempty"`
+
+	// ConnectivityCheck probes the hosts found by log analysis from the operator
+	// and checks whether NetworkPolicies could be blocking them
+	// +optional
+	ConnectivityCheck *ConnectivityCheckConfig `json:"connectivityCheck,omitempty"`
+
+	// MaintenanceWindows are recurring periods during which non-ready pods are still
+	// tracked but marked as suppressed, excluded from counts and alerts and skipped
+	// for AI analysis
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// OwnershipRules map non-ready pods to the teams owning them. Rules are evaluated
+	// in order and the first matching rule sets the team of a pod.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	OwnershipRules []OwnershipRule `json:"ownershipRules,omitempty"`
+
+	// Events configures the Kubernetes Events emitted for detections and resolutions
+	// +optional
+	Events *EventsConfig `json:"events,omitempty"`
+
+	// Notifications configures sinks that are notified about detected pods
+	// +optional
+	Notifications *NotificationsConfig `json:"notifications,omitempty"`
+
+	// EventStream publishes every detection, resolution and analysis result to
+	// streaming platforms for long-term analytics
+	// +optional
+	EventStream *EventStreamConfig `json:"eventStream,omitempty"`
+
+	// IssueTracking opens Jira or GitHub issues for pods that stay non-ready, and
+	// comments on and closes them once the pods recover
+	// +optional
+	IssueTracking *IssueTrackingConfig `json:"issueTracking,omitempty"`
+
+	// GrafanaAnnotations marks non-ready windows of workloads as region annotations
+	// in Grafana
+	// +optional
+	GrafanaAnnotations *GrafanaAnnotationsConfig `json:"grafanaAnnotations,omitempty"`
+
+	// SnapshotExport periodically writes the status and the incidents resolved since the
+	// previous snapshot to object storage, for audits and long-term trend analysis
+	// +optional
+	SnapshotExport *SnapshotExportConfig `json:"snapshotExport,omitempty"`
+
+	// GitOps surfaces non-ready pods and their root cause on the Argo CD Application,
+	// Flux Kustomization or Flux HelmRelease that deploys them
+	// +optional
+	GitOps *GitOpsConfig `json:"gitOps,omitempty"`
+
+	// Remediation automatically acts on non-ready pods. Every action is recorded in
+	// status.remediations and as Events on the PodSleuth and the workload.
+	// +optional
+	Remediation *RemediationPolicy `json:"remediation,omitempty"`
+
+	// StatusLimits bounds the size of the status, which etcd limits to 1.5MB
+	// +optional
+	StatusLimits *StatusLimitsConfig `json:"statusLimits,omitempty"`
+
+	// Reports moves the full analysis of non-ready pods into PodSleuthReports
+	// +optional
+	Reports *ReportsConfig `json:"reports,omitempty"`
+
+	// SLO tracks the time to detect and recover from the incidents of each workload,
+	// and flags workloads breaching an availability objective
+	// +optional
+	SLO *SLOConfig `json:"slo,omitempty"`
+
+	// AnomalyDetection learns how much each workload usually restarts and flaps, and only
+	// escalates its transient failures when they deviate from that baseline
+	// +optional
+	AnomalyDetection *AnomalyDetectionConfig `json:"anomalyDetection,omitempty"`
+}
+
+// AnomalyDetectionConfig configures the baselines of workload restarts and non-ready
+// blips. Restarts and pods turning non-ready are counted per workload and interval, and
+// their exponentially weighted moving average (EWMA) and variance form the baseline.
+// While the count of the current interval stays within Threshold standard deviations
+// above the average, the workload's pods that have been non-ready for less than
+// MaxBlipDuration are reported as within baseline and muted like silenced pods.
+type AnomalyDetectionConfig struct {
+	// Enabled turns on anomaly detection
+	Enabled bool `json:"enabled"`
+
+	// Interval is the period restarts and blips are counted over
+	// Default: 1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// SmoothingPercent is the weight of the latest interval in the moving average
+	// Default: 10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SmoothingPercent *int32 `json:"smoothingPercent,omitempty"`
+
+	// Threshold is how many standard deviations above the average a count must be to
+	// escalate, such as "3"
+	// Default: 3
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+
+	// MinSamples is how many intervals a baseline is learned over before it is used.
+	// Until then every failure is escalated.
+	// Default: 24
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinSamples *int32 `json:"minSamples,omitempty"`
+
+	// MaxBlipDuration is how long a pod may be non-ready to count as a transient blip.
+	// Pods non-ready for longer are always escalated.
+	// Default: 5m
+	// +optional
+	MaxBlipDuration *metav1.Duration `json:"maxBlipDuration,omitempty"`
+}
+
+// SLOConfig configures the incident tracking behind MTTR and availability objectives.
+// An incident of a workload, or of a pod without owner, lasts from when its first pod
+// stopped being ready until none of its pods is non-ready. Muted pods are left out.
+type SLOConfig struct {
+	// Window is the rolling window MTTR and availability are computed over
+	// Default: 168h
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// AvailabilityObjective is the percentage of the window each workload must be free
+	// of incidents, such as "99.9". Workloads below it are breaching. Empty only tracks
+	// the incidents.
+	// +kubebuilder:validation:Pattern=`^(100(\.0+)?|[0-9]{1,2}(\.[0-9]+)?)$`
+	// +optional
+	AvailabilityObjective string `json:"availabilityObjective,omitempty"`
+}
+
+// ReportsConfig configures PodSleuthReports
+type ReportsConfig struct {
+	// Enabled writes a PodSleuthReport with the full analysis of every non-ready pod to
+	// the pod's namespace. Status entries then leave out error lines, terminations and
+	// debug check output, and name the pod's report.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// StatusLimitsConfig bounds the error lines of log analyses stored in the status.
+// Error lines left out are counted in errorLinesOmitted and served in full by the
+// dashboard's /api/cache/{namespace}/{pod} endpoint.
+type StatusLimitsConfig struct {
+	// MaxErrorLineBytesPerPod bounds the error lines stored per pod
+	// Default: 8192
+	// +kubebuilder:validation:Minimum=256
+	// +optional
+	MaxErrorLineBytesPerPod *int32 `json:"maxErrorLineBytesPerPod,omitempty"`
+
+	// MaxErrorLineBytes bounds the error lines stored across all pods
+	// Default: 262144
+	// +kubebuilder:validation:Minimum=1024
+	// +optional
+	MaxErrorLineBytes *int32 `json:"maxErrorLineBytes,omitempty"`
+
+	// OmitErrorLines leaves error lines out of the status entirely
+	// +optional
+	OmitErrorLines bool `json:"omitErrorLines,omitempty"`
+}
+
+// RemediationPolicy defines the automatic remediations of a PodSleuth.
+// Suppressed, silenced and acknowledged pods are never remediated.
+type RemediationPolicy struct {
+	// Rules are evaluated in order and the first rule matching a pod applies
+	// +optional
+	Rules []RemediationRule `json:"rules,omitempty"`
+
+	// DryRun only records and logs the actions that would be taken, with the result
+	// DryRun. The operator's --remediation-dry-run flag forces it for all PodSleuths.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// HistoryLimit is how many actions status.remediations keeps
+	// Default: 100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Budget limits how many automatic actions are taken and how many pods of a workload
+	// they touch
+	// +optional
+	Budget *RemediationBudget `json:"budget,omitempty"`
+
+	// NodeCordon cordons nodes that many pod failures are attributed to
+	// +optional
+	NodeCordon *NodeCordonPolicy `json:"nodeCordon,omitempty"`
+}
+
+// NodeCordonPolicy cordons a node when many of its pods fail because of the node, so
+// that no new pods are scheduled on it until someone investigates and uncordons it.
+// Failures are attributed to a node that is NotReady or reports DiskPressure,
+// MemoryPressure, PIDPressure or NetworkUnavailable, and otherwise only pods failing
+// for one of Reasons or evicted by the node count.
+type NodeCordonPolicy struct {
+	// MinPods is how many pods on a node must fail because of it
+	// Default: 5
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	MinPods *int32 `json:"minPods,omitempty"`
+
+	// MinNonReadyDuration is how long pods must be non-ready to count
+	// Default: 10m
+	// +optional
+	MinNonReadyDuration *metav1.Duration `json:"minNonReadyDuration,omitempty"`
+
+	// Reasons attribute pod failures to a node that reports no problem, e.g. container
+	// runtime errors
+	// Default: [CreateContainerError, RunContainerError, ContainerCannotRun]
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// NodeSelector limits cordoning to nodes with these labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// MaxCordonedNodes is how many nodes PodSleuths may have cordoned at once, counting
+	// nodes that are not uncordoned yet
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCordonedNodes *int32 `json:"maxCordonedNodes,omitempty"`
+}
+
+// RemediationBudget limits automatic remediations. Budgets count the actions of all
+// PodSleuths, so several PodSleuths cannot together exceed them. When an action would
+// exceed a budget, automatic remediation is locked out for LockoutDuration and a
+// RemediationLockedOut Warning Event is emitted. Actions approved by people are not
+// limited.
+type RemediationBudget struct {
+	// MaxActionsPerHour limits the actions taken across all namespaces and PodSleuths
+	// within an hour
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerHour *int32 `json:"maxActionsPerHour,omitempty"`
+
+	// MaxActionsPerNamespacePerHour limits the actions taken in one namespace within an
+	// hour
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerNamespacePerHour *int32 `json:"maxActionsPerNamespacePerHour,omitempty"`
+
+	// MaxWorkloadPercent limits the pods of a workload RestartPod deletes within an hour
+	// to this percentage of its replicas, and always allows one
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxWorkloadPercent *int32 `json:"maxWorkloadPercent,omitempty"`
+
+	// LockoutDuration is how long automatic remediation stops after a budget is exceeded
+	// Default: 1h
+	// +optional
+	LockoutDuration *metav1.Duration `json:"lockoutDuration,omitempty"`
+}
+
+// RemediationLockout records that automatic remediation stopped after a budget was
+// exceeded
+type RemediationLockout struct {
+	// Since is when the budget was exceeded
+	Since metav1.Time `json:"since"`
+
+	// Until is when automatic remediation resumes
+	Until metav1.Time `json:"until"`
+
+	// Reason names the exceeded budget
+	Reason string `json:"reason"`
+}
+
+// RemediationJob is a Job created for a non-ready pod. Its containers get the
+// environment variables KUBESLEUTH_PODSLEUTH, KUBESLEUTH_RULE, KUBESLEUTH_POD_NAME,
+// KUBESLEUTH_POD_NAMESPACE, KUBESLEUTH_OWNER_KIND, KUBESLEUTH_OWNER_NAME,
+// KUBESLEUTH_REASON, KUBESLEUTH_MESSAGE and KUBESLEUTH_ROOT_CAUSE.
+type RemediationJob struct {
+	// Namespace is where the Job is created. Namespaces other than the pod's must be
+	// allowed with the operator's --remediation-job-namespaces flag.
+	// Default: the namespace of the pod
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Template is the Job's metadata and spec. The Job is named after the template's
+	// name, or the rule if it has none, with a random suffix. Pods restart Never unless
+	// set, and finished Jobs are deleted after a day unless ttlSecondsAfterFinished is set.
+	// Pods run as the default ServiceAccount unless the operator's
+	// --remediation-job-service-accounts flag allows serviceAccountName.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template batchv1.JobTemplateSpec `json:"template"`
+}
+
+// MemoryIncrease raises the memory limit of repeatedly OOMKilled containers. OOMKills
+// are counted from the crash-loop trend, which must be enabled.
+type MemoryIncrease struct {
+	// Percent is how much the memory limit is raised by
+	// Default: 25
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=400
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+
+	// MaxLimit caps the raised memory limit, e.g. 4Gi
+	MaxLimit resource.Quantity `json:"maxLimit"`
+
+	// MinOOMKills is how many OOMKilled terminations of a container within the
+	// crash-loop trend window raise its limit
+	// Default: 2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinOOMKills *int32 `json:"minOOMKills,omitempty"`
+
+	// GitOpsManaged is what happens for workloads deployed by an Argo CD Application or
+	// Flux Kustomization or HelmRelease, which would revert the patch on their next sync:
+	// Notify sends the proposed change to the notification sinks instead of patching.
+	// Patch patches the workload anyway.
+	// Default: Notify
+	// +kubebuilder:validation:Enum=Notify;Patch
+	// +optional
+	GitOpsManaged string `json:"gitOpsManaged,omitempty"`
+}
+
+// RemediationRule selects non-ready pods and the action taken on them
+type RemediationRule struct {
+	// Name identifies the rule in status and Events
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Action is the remediation:
+	// RestartPod deletes the pod so that its controller recreates it. Pods without a
+	// controller are never restarted.
+	// RolloutRestart restarts the owning Deployment, StatefulSet or DaemonSet like
+	// kubectl rollout restart.
+	// Rollback rolls the owning Deployment back to its previous revision like kubectl
+	// rollout undo, only if the pod belongs to the newest revision and that revision
+	// has no ready pods.
+	// RunJob creates a Job from the rule's job template, e.g. to run a team's own
+	// remediation or diagnostics script.
+	// IncreaseMemory raises the memory limit of a container that was repeatedly
+	// OOMKilled in the owning Deployment, StatefulSet or DaemonSet.
+	// +kubebuilder:validation:Enum=RestartPod;RolloutRestart;Rollback;RunJob;IncreaseMemory
+	Action string `json:"action"`
+
+	// Reasons the pod must be non-ready for, e.g. CrashLoopBackOff or ContainerNotReady
+	// Default: [CrashLoopBackOff]
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Namespaces limits the rule to pods in these namespaces
+	// If empty, pods in all namespaces match
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// MinNonReadyDuration is how long a pod must be non-ready before the action is taken
+	// Default: 10m
+	// +optional
+	MinNonReadyDuration *metav1.Duration `json:"minNonReadyDuration,omitempty"`
+
+	// MaxActionsPerWorkload limits how often the action is taken on the pods of one
+	// workload within Window
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActionsPerWorkload *int32 `json:"maxActionsPerWorkload,omitempty"`
+
+	// Window is the period MaxActionsPerWorkload applies to
+	// Default: 1h
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// ApprovalRequired lists the action in status.pendingRemediations instead of taking
+	// it. It is taken once approved in the dashboard or by annotating the PodSleuth with
+	// kubesleuth.io/approve-remediation=<id>, and dropped by
+	// kubesleuth.io/reject-remediation=<id> or when the pod recovers.
+	// +optional
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
+
+	// Job is the Job the RunJob action creates
+	// +optional
+	Job *RemediationJob `json:"job,omitempty"`
+
+	// MemoryIncrease configures the IncreaseMemory action
+	// +optional
+	MemoryIncrease *MemoryIncrease `json:"memoryIncrease,omitempty"`
+}
+
+// GitOpsConfig defines how GitOps applications are linked to their non-ready pods.
+// Applications are found through the tracking labels and annotations Argo CD and Flux
+// set on the workloads they deploy.
+type GitOpsConfig struct {
+	// Annotate sets the kubesleuth.io/health, kubesleuth.io/summary and
+	// kubesleuth.io/root-cause annotations on the application while its pods are not
+	// ready, and removes them once they recover
+	// Default: true
+	// +optional
+	Annotate *bool `json:"annotate,omitempty"`
+
+	// Events emits PodNotReady, RootCauseIdentified and PodRecovered Events on the
+	// application, so they show up next to the degraded app in GitOps dashboards
+	// Default: true
+	// +optional
+	Events *bool `json:"events,omitempty"`
+
+	// ArgoCDNamespace is where Argo CD Applications live, unless the tracking id names
+	// the namespace of the application
+	// Default: argocd
+	// +optional
+	ArgoCDNamespace string `json:"argoCDNamespace,omitempty"`
+}
+
+// SnapshotExportConfig defines how often snapshots are exported and where.
+// Exactly one destination should be set. Secrets are read from the operator's namespace.
+type SnapshotExportConfig struct {
+	// Interval between snapshots
+	// Default: 1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Format of the snapshot: JSON (status and resolved incidents) or CSV (one row per
+	// non-ready pod or resolved incident)
+	// Default: JSON
+	// +kubebuilder:validation:Enum=JSON;CSV
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Prefix of the object keys. Snapshots are written to
+	// <prefix>YYYY/MM/DD/snapshot-<timestamp>.<json|csv>.
+	// Default: kubesleuth/<podsleuth name>/, or kubesleuth/<cluster>/<podsleuth name>/ when
+	// the operator's --cluster-name is set
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Retention deletes snapshots under the prefix older than this after each export
+	// If empty, snapshots are kept
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+
+	// S3 writes to an Amazon S3 or S3-compatible bucket (e.g. MinIO)
+	// +optional
+	S3 *S3Destination `json:"s3,omitempty"`
+
+	// GCS writes to a Google Cloud Storage bucket through its XML API with HMAC keys
+	// +optional
+	GCS *GCSDestination `json:"gcs,omitempty"`
+
+	// AzureBlob writes to an Azure Blob Storage container with a SAS token
+	// +optional
+	AzureBlob *AzureBlobDestination `json:"azureBlob,omitempty"`
+}
+
+// S3Destination is an S3 bucket accessed with an access key
+type S3Destination struct {
+	// Bucket is the bucket name
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Region of the bucket
+	// Default: us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint overrides the S3 endpoint for S3-compatible storage
+	// Default: https://s3.<region>.amazonaws.com
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ForcePathStyle addresses the bucket in the path instead of the host name, as
+	// required by most S3-compatible storage
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// AccessKeyIDSecretRef references the access key id
+	AccessKeyIDSecretRef corev1.SecretKeySelector `json:"accessKeyIDSecretRef"`
+
+	// SecretAccessKeySecretRef references the secret access key
+	SecretAccessKeySecretRef corev1.SecretKeySelector `json:"secretAccessKeySecretRef"`
+}
+
+// GCSDestination is a Google Cloud Storage bucket accessed with HMAC keys
+type GCSDestination struct {
+	// Bucket is the bucket name
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// AccessIDSecretRef references the HMAC key access id
+	AccessIDSecretRef corev1.SecretKeySelector `json:"accessIDSecretRef"`
+
+	// SecretSecretRef references the HMAC key secret
+	SecretSecretRef corev1.SecretKeySelector `json:"secretSecretRef"`
+}
+
+// AzureBlobDestination is an Azure Blob Storage container accessed with a SAS token
+type AzureBlobDestination struct {
+	// ContainerURL is the container URL (https://<account>.blob.core.windows.net/<container>)
+	// +kubebuilder:validation:Pattern=`^https?://`
+	ContainerURL string `json:"containerURL"`
+
+	// SASTokenSecretRef references a SAS token with create, write, list and delete permissions
+	SASTokenSecretRef corev1.SecretKeySelector `json:"sasTokenSecretRef"`
+}
+
+// GrafanaAnnotationsConfig posts an annotation when a workload's pods become non-ready
+// and ends it when they recover. Annotations are tagged with kubesleuth,
+// namespace:<namespace>, workload:<kind>/<name> (or pod:<name>) and reason:<reason>.
+type GrafanaAnnotationsConfig struct {
+	// URL is the Grafana base URL
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// TokenSecretRef references a service account token with the annotations:write
+	// permission, read from the operator's namespace
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+
+	// DashboardUID limits annotations to one dashboard
+	// If empty, annotations are organization-wide and shown by tag-based annotation queries
+	// +optional
+	DashboardUID string `json:"dashboardUID,omitempty"`
+
+	// Tags are added to every annotation
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// IssueTrackingConfig defines when issues are opened for persistent failures and where.
+// Issues are deduplicated through labels, so restarts and multiple replicas reuse the
+// open issue of an incident. Secrets are read from the operator's namespace.
+type IssueTrackingConfig struct {
+	// After is how long a pod or group must stay non-ready before an issue is opened
+	// Default: 30m
+	// +optional
+	After *metav1.Duration `json:"after,omitempty"`
+
+	// GroupBy correlates pods into one issue: owner (workload), namespace, incident
+	// (same reason and matched pattern) or none (one issue per pod)
+	// Default: owner
+	// +kubebuilder:validation:Enum=owner;namespace;incident;none
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+
+	// Severities limits issues to pods of these severities (critical, warning)
+	// If empty, all non-suppressed, non-silenced, unacknowledged pods are tracked
+	// +optional
+	Severities []string `json:"severities,omitempty"`
+
+	// Jira opens Jira issues
+	// +optional
+	Jira *JiraIssueConfig `json:"jira,omitempty"`
+
+	// GitHub opens GitHub issues
+	// +optional
+	GitHub *GitHubIssueConfig `json:"github,omitempty"`
+}
+
+// JiraIssueConfig configures Jira issue creation through the REST API v2
+type JiraIssueConfig struct {
+	// URL is the Jira base URL (e.g. https://example.atlassian.net)
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Project is the key of the project issues are created in
+	// +kubebuilder:validation:MinLength=1
+	Project string `json:"project"`
+
+	// IssueType is the name of the issue type
+	// Default: Task
+	// +optional
+	IssueType string `json:"issueType,omitempty"`
+
+	// Username authenticates with TokenSecretRef as API token (Jira Cloud). If empty, the
+	// token is sent as a bearer personal access token (Jira Data Center).
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// TokenSecretRef references the API token or personal access token
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+
+	// Labels are added to every issue besides the deduplication labels
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// CloseTransition is the name of the workflow transition closing an issue
+	// Default: Done
+	// +optional
+	CloseTransition string `json:"closeTransition,omitempty"`
+}
+
+// GitHubIssueConfig configures GitHub issue creation
+type GitHubIssueConfig struct {
+	// Repository is the owner/name of the repository issues are created in
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`
+	Repository string `json:"repository"`
+
+	// TokenSecretRef references a token allowed to create issues in the repository
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+
+	// APIURL is the GitHub API URL, for GitHub Enterprise Server
+	// Default: https://api.github.com
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// Labels are added to every issue besides the deduplication labels
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+}
+
+// EventStreamConfig defines where detection, resolution and analysis events are published.
+// Unlike notifications, events are not grouped or rate-limited by policies.
+// Secrets referenced by sinks are read from the operator's namespace.
+type EventStreamConfig struct {
+	// Kafka publishes events to Kafka topics
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Kafka []KafkaSink `json:"kafka,omitempty"`
+
+	// NATS publishes events as structured CloudEvents to NATS subjects
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	NATS []NATSSink `json:"nats,omitempty"`
+
+	// CloudEvents posts events as CloudEvents to HTTP sinks such as Knative brokers or
+	// Argo Events webhook event sources
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	CloudEvents []CloudEventsSink `json:"cloudEvents,omitempty"`
+
+	// Source is the CloudEvents source attribute
+	// Default: /apis/apps.ops.dev/v1alpha1/podsleuths/<name>
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// NATSSink publishes CloudEvents to a NATS subject
+type NATSSink struct {
+	// Name identifies the sink in metrics and logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL is the NATS server (nats://host:port, or tls://host:port to require TLS)
+	// +kubebuilder:validation:Pattern=`^(nats|tls)://`
+	URL string `json:"url"`
+
+	// Subject events are published to. The event type is appended as a token, e.g.
+	// "kubesleuth.events" publishes to "kubesleuth.events.detected".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^\s*>]+$`
+	Subject string `json:"subject"`
+
+	// TLS configures TLS to the server; required if the server requires TLS
+	// +optional
+	TLS *StreamTLSConfig `json:"tls,omitempty"`
+
+	// TokenSecretRef references an authentication token
+	// +optional
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// Username authenticates together with PasswordSecretRef
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the password of Username
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// Timeout bounds each publish, including connecting to the server
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// CloudEventsSink posts CloudEvents over HTTP
+type CloudEventsSink struct {
+	// Name identifies the sink in metrics and logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL is the endpoint events are posted to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Mode is the CloudEvents HTTP content mode: Binary sends the attributes as ce-*
+	// headers and the event data as body, Structured sends the whole event as JSON
+	// Default: Binary
+	// +kubebuilder:validation:Enum=Binary;Structured
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Headers are added to every request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// AuthSecretRef references a bearer token sent in the Authorization header
+	// +optional
+	AuthSecretRef *corev1.SecretKeySelector `json:"authSecretRef,omitempty"`
+
+	// MaxRetries is the number of retries on network errors, 429 and 5xx responses
+	// Default: 3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Timeout bounds each request
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// KafkaSink publishes events to a Kafka topic. Events are keyed by namespace/pod so
+// all events of a pod land on the same partition.
+type KafkaSink struct {
+	// Name identifies the sink in metrics and logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Brokers are the bootstrap brokers (host:port)
+	// +kubebuilder:validation:MinItems=1
+	Brokers []string `json:"brokers"`
+
+	// Topic is the topic events are published to
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// TLS enables TLS to the brokers
+	// +optional
+	TLS *StreamTLSConfig `json:"tls,omitempty"`
+
+	// SASL enables SASL authentication
+	// +optional
+	SASL *KafkaSASLConfig `json:"sasl,omitempty"`
+
+	// Encoding of the event values: JSON, or Avro in the Confluent wire format with the
+	// schema registered in SchemaRegistryURL
+	// Default: JSON
+	// +kubebuilder:validation:Enum=JSON;Avro
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// SchemaRegistryURL is the Confluent-compatible schema registry, required for Avro.
+	// The schema is registered under the subject "<topic>-value".
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	SchemaRegistryURL string `json:"schemaRegistryURL,omitempty"`
+
+	// Timeout bounds each publish, including connecting to the brokers
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// StreamTLSConfig configures TLS to event stream servers
+type StreamTLSConfig struct {
+	// CASecretRef references a PEM CA bundle verifying the servers
+	// If empty, the system roots are used
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables server certificate verification
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// KafkaSASLConfig configures SASL authentication to Kafka brokers
+type KafkaSASLConfig struct {
+	// Mechanism is the SASL mechanism
+	// Default: PLAIN
+	// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+	// +optional
+	Mechanism string `json:"mechanism,omitempty"`
+
+	// Username authenticates to the brokers
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+
+	// PasswordSecretRef references the password
+	PasswordSecretRef corev1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// NotificationsConfig defines where notifications about detected pods are sent.
+// Secrets referenced by sinks are read from the operator's namespace.
+type NotificationsConfig struct {
+	// Webhooks post a JSON payload to arbitrary URLs
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Webhooks []WebhookSink `json:"webhooks,omitempty"`
+
+	// Email sends HTML emails over SMTP, immediately or as periodic digests
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Email []EmailSink `json:"email,omitempty"`
+
+	// SendResolved sends a "resolved" notification with the downtime and final root cause
+	// when notified pods become ready again or disappear
+	// Default: true
+	// +optional
+	SendResolved *bool `json:"sendResolved,omitempty"`
+
+	// Policies route, group and rate-limit notifications. Each detected pod is handled
+	// by the first matching policy; pods matching no policy are not notified.
+	// If empty, every detected pod is sent to all sinks immediately.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Policies []NotificationPolicy `json:"policies,omitempty"`
+}
+
+// NotificationPolicy controls which pods notify which sinks and how often
+type NotificationPolicy struct {
+	// Name identifies the policy in notifications and logs
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	Name string `json:"name"`
+
+	// Severities limits the policy to pods of these severities (critical, warning)
+	// If empty, all severities match
+	// +optional
+	Severities []string `json:"severities,omitempty"`
+
+	// Namespaces limits the policy to pods in these namespaces
+	// If empty, all namespaces match
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Teams limits the policy to pods owned by these teams (see ownershipRules)
+	// If empty, all teams match
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// Sinks are the names of the webhook and email sinks notified by this policy
+	// If empty, all sinks are notified
+	// +optional
+	Sinks []string `json:"sinks,omitempty"`
+
+	// GroupBy combines pods into one notification: owner (same workload), namespace,
+	// incident (same failure reason and matched pattern across namespaces) or none
+	// Default: owner
+	// +kubebuilder:validation:Enum=owner;namespace;incident;none
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+
+	// Cooldown is the minimum time between two notifications of the same group.
+	// Pods detected during the cooldown are sent together once it has passed.
+	// Default: 5m
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+
+	// RepeatInterval re-sends a notification for groups that still have non-ready pods
+	// If unset, groups are not repeated
+	// +optional
+	RepeatInterval *metav1.Duration `json:"repeatInterval,omitempty"`
+}
+
+// EmailSink sends notifications as HTML emails over SMTP
+type EmailSink struct {
+	// Name identifies the sink in logs and metrics
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Host is the SMTP server host
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port is the SMTP server port
+	// Default: 587
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// TLS is how the connection is secured: StartTLS upgrades a plain connection,
+	// TLS connects with implicit TLS (usually port 465), None sends in clear text
+	// Default: StartTLS
+	// +kubebuilder:validation:Enum=StartTLS;TLS;None
+	// +optional
+	TLS string `json:"tls,omitempty"`
+
+	// InsecureSkipVerify disables verification of the server certificate
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// Username for SMTP PLAIN authentication
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references a Secret key holding the SMTP password
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// From is the sender address
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// To are the recipient addresses
+	// +kubebuilder:validation:MinItems=1
+	To []string `json:"to"`
+
+	// DigestInterval collects notifications and sends them as one digest per interval.
+	// If unset, an email is sent immediately for each reconcile that detects pods.
+	// Digests are sent at the first reconcile after the interval has elapsed.
+	// +optional
+	DigestInterval *metav1.Duration `json:"digestInterval,omitempty"`
+}
+
+// WebhookSink posts notifications to an HTTP endpoint
+type WebhookSink struct {
+	// Name identifies the sink in logs and metrics
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL is the endpoint notifications are sent to. It may contain Go template
+	// expressions with the same data as PayloadTemplate, e.g. to close an incident
+	// at a different path when .Type is "resolved".
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Method is the HTTP method
+	// Default: POST
+	// +kubebuilder:validation:Enum=POST;PUT
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Headers are additional HTTP headers sent with each request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// AuthSecretRef references a Secret key holding a credential sent in AuthHeader
+	// +optional
+	AuthSecretRef *corev1.SecretKeySelector `json:"authSecretRef,omitempty"`
+
+	// AuthHeader is the header carrying the credential
+	// Default: Authorization
+	// +optional
+	AuthHeader string `json:"authHeader,omitempty"`
+
+	// AuthPrefix is prepended to the credential, e.g. "Bearer" or "Token"
+	// Default: Bearer
+	// +optional
+	AuthPrefix string `json:"authPrefix,omitempty"`
+
+	// HMACSecretRef references a Secret key used to sign the payload with HMAC-SHA256.
+	// The signature is sent as "X-KubeSleuth-Signature: sha256=<hex>".
+	// +optional
+	HMACSecretRef *corev1.SecretKeySelector `json:"hmacSecretRef,omitempty"`
+
+	// PayloadTemplate is a Go template rendering the request body. It receives the
+	// notification (.Type, .PodSleuth, .Policy, .Group, .IncidentKey, .Pod, .Pods, .ActivePods,
+	// .Downtime, .Timestamp) and supports the json function.
+	// Default: the notification as JSON
+	// +optional
+	PayloadTemplate string `json:"payloadTemplate,omitempty"`
+
+	// MaxRetries is how often a failed delivery is retried with exponential backoff.
+	// Network errors, 429 and 5xx responses are retried.
+	// Default: 3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Timeout is the timeout of each delivery attempt
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// EventsConfig defines which Kubernetes Events are emitted
+type EventsConfig struct {
+	// Enabled emits Events on the PodSleuth when pods become non-ready or recover
+	// and when log analysis identifies a root cause
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// OnWorkloads also emits the Events on the workload owning the pod
+	// (e.g. the Deployment), so they show up in kubectl describe of the workload
+	// Default: false
+	// +optional
+	OnWorkloads bool `json:"onWorkloads,omitempty"`
+}
+
+// OwnershipRule assigns a team to pods by namespace and/or labels.
+// A rule matches when all of its specified matchers match.
+type OwnershipRule struct {
+	// Team is the name of the owning team
+	// +kubebuilder:validation:MinLength=1
+	Team string `json:"team"`
+
+	// Namespaces matches pods in any of these namespaces
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// PodSelector matches pods by their labels
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring maintenance period
+type MaintenanceWindow struct {
+	// Name identifies the window in pod status
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Schedule is a cron expression (minute hour day-of-month month day-of-week)
+	// for the start of the window, e.g. "0 2 * * 6" for Saturdays at 02:00
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts after each scheduled start
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the schedule
+	// Default: UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Namespaces limits the window to pods in these namespaces
+	// If empty, the window applies to all pods
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// ConnectivityCheckConfig defines configuration for connectivity checks of failing targets
+type ConnectivityCheckConfig struct {
+	// Enabled enables connectivity checks for hosts mentioned in connection errors
+	// Default: false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Timeout is the DNS and TCP connect timeout per target
+	// Default: 3s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxTargets bounds the number of targets checked per pod
+	// Default: 3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxTargets *int32 `json:"maxTargets,omitempty"`
+}
+
+// DebugDiagnosticsConfig defines configuration for ephemeral debug container diagnostics
+type DebugDiagnosticsConfig struct {
+	// Enabled enables ephemeral debug containers. Ephemeral containers cannot be removed
+	// from a pod, so at most one is added per pod.
+	// Default: false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image is the image of the debug container. It must provide sh, nslookup, nc and df.
+	// Default: busybox:1.36
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// MaxTargets bounds the number of hosts checked per pod
+	// Default: 5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	MaxTargets *int32 `json:"maxTargets,omitempty"`
+}
+
+// CrashLoopTrendConfig defines configuration for crash-loop trend analysis
+type CrashLoopTrendConfig struct {
+	// Enabled enables tracking of container terminations across restarts
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinRestarts is the number of restarts within the window before a trend is reported
+	// Default: 3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinRestarts *int32 `json:"minRestarts,omitempty"`
+
+	// Window is how far back terminations are aggregated
+	// Default: 2h
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// ContainerError contains detailed error information for a specific container
+type ContainerError struct {
+	// ContainerName is the name of the container
+	ContainerName string `json:"containerName"`
+
+	// Type indicates whether this is a regular container or init container
+	Type string `json:"type"` // "container" or "initContainer"
+
+	// State is the current state of the container (waiting, terminated, running)
+	State string `json:"state"`
+
+	// Reason is the error reason (CrashLoopBackOff, ImagePullBackOff, etc.)
+	Reason string `json:"reason"`
+
+	// Message is the detailed error message
+	Message string `json:"message"`
+
+	// ExitCode is the exit code if the container terminated
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// RestartCount is the number of times the container has restarted
+	RestartCount int32 `json:"restartCount"`
+
+	// Ready indicates if the container is ready
+	Ready bool `json:"ready"`
+}
+
+// PodCondition represents a pod condition status
+type PodCondition struct {
+	// Type is the type of condition
+	Type string `json:"type"`
+
+	// Status is the status of the condition (True, False, Unknown)
+	Status string `json:"status"`
+
+	// Reason is the reason for the condition status
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message describing the condition
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// LogAnalysisConfig defines configuration for log analysis
+type LogAnalysisConfig struct {
+	// Enabled enables log analysis for non-ready pods
+	Enabled bool `json:"enabled"`
+
+	// Method specifies the analysis method: "pattern" or "ai"
+	// Deprecated: Use Methods instead for multiple method support
+	// Default: "pattern"
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Methods specifies the analysis methods to run in order (e.g., ["pattern", "ai"])
+	// Methods are executed sequentially in the order specified
+	// Example: ["pattern", "ai"] runs pattern first, then AI
+	// Default: ["pattern"]
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+
+	// CacheEnabled enables caching of analysis results to avoid re-analyzing on every reconcile
+	// Results are cached per pod (keyed by UID + restart count)
+	// Cache is invalidated when pod restarts or after TTL expires
+	// Default: true
+	// +optional
+	CacheEnabled *bool `json:"cacheEnabled,omitempty"`
+
+	// CacheTTL is the duration to cache analysis results before re-analyzing
+	// Default: 5m
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
+	// NegativeCacheTTL is the duration to cache outcomes without findings, such as pods
+	// that produced no log output or whose logs could not be retrieved yet (e.g. image pull failures)
+	// A shorter TTL avoids re-fetching logs on every reconcile while picking up new output quickly
+	// Default: 1m
+	// +optional
+	NegativeCacheTTL *metav1.Duration `json:"negativeCacheTTL,omitempty"`
+
+	// LinesToAnalyze is the number of recent log lines to fetch and analyze
+	// Default: 100
+	// +optional
+	LinesToAnalyze *int32 `json:"linesToAnalyze,omitempty"`
+
+	// MaxLogBytes bounds the bytes of log output fetched per analysis. Lines beyond it
+	// are not fetched.
+	// Default: 1048576 (1MiB)
+	// +kubebuilder:validation:Minimum=1024
+	// +optional
+	MaxLogBytes *int64 `json:"maxLogBytes,omitempty"`
+
+	// MaxLineLength bounds the length of a log line in bytes. Longer lines are truncated
+	// while they are read.
+	// Default: 4096
+	// +kubebuilder:validation:Minimum=128
+	// +optional
+	MaxLineLength *int32 `json:"maxLineLength,omitempty"`
+
+	// FilterErrorsOnly if true, filters error/warning lines from the last LinesToAnalyze lines
+	// Process: 1) Fetch last LinesToAnalyze lines, 2) Filter for errors/warnings, 3) Analyze filtered lines
+	// Default: true
+	// +optional
+	FilterErrorsOnly *bool `json:"filterErrorsOnly,omitempty"`
+
+	// Patterns defines custom error patterns for pattern matching method
+	// Deprecated: Use MethodConfigs with PatternConfig instead for cleaner structure
+	// If not specified, default patterns will be used (connection errors, service unavailable, etc.)
+	// Each pattern should be a regex that matches error messages
+	// +optional
+	Patterns []ErrorPattern `json:"patterns,omitempty"`
+
+	// AIEndpoint is the URL endpoint for AI analysis (required if "ai" in methods)
+	// Deprecated: Use MethodConfigs with AIConfig instead for cleaner structure
+	// Examples:
+	//   - OpenAI: "https://api.openai.com/v1/chat/completions"
+	//   - Ollama: "http://localhost:11434/api/generate"
+	// +optional
+	AIEndpoint string `json:"aiEndpoint,omitempty"`
+
+	// AIFormat specifies the API format to use: "openai", "anthropic", "ollama", or "generic"
+	// Deprecated: Use MethodConfigs with AIConfig instead
+	// Default: "openai"
+	// +optional
+	AIFormat string `json:"aiFormat,omitempty"`
+
+	// AIModel specifies the model name to use for AI analysis
+	// Deprecated: Use MethodConfigs with AIConfig instead
+	// Examples: "gpt-4", "qwen3:8b", "claude-3-opus"
+	// +optional
+	AIModel string `json:"aiModel,omitempty"`
+
+	// AIAPIKey is the API key for AI analysis
+	// Deprecated: Use MethodConfigs with AIConfig instead
+	// Should be stored as a Kubernetes Secret reference
+	// +optional
+	AIAPIKey *corev1.SecretKeySelector `json:"aiApiKey,omitempty"`
+
+	// AIAuthHeader specifies the HTTP header name for authentication
+	// Deprecated: Use MethodConfigs with AIConfig instead
+	// Default: "Authorization"
+	// +optional
+	AIAuthHeader string `json:"aiAuthHeader,omitempty"`
+
+	// AIAuthPrefix specifies the prefix for the auth header value (e.g., "Bearer", "ApiKey")
+	// Deprecated: Use MethodConfigs with AIConfig instead
+	// Default: "Bearer"
+	// +optional
+	AIAuthPrefix string `json:"aiAuthPrefix,omitempty"`
+
+	// MethodConfigs defines method-specific configurations in order of execution
+	// This is the NEW PREFERRED way to configure log analysis methods.
+	// Each method has its own configuration block, avoiding parameter mixing.
+	// Example:
+	//   methodConfigs:
+	//     - type: "pattern"
+	//       patternConfig:
+	//         patterns: [...]
+	//     - type: "ai"
+	//       aiConfig:
+	//         endpoint: "..."
+	//         model: "..."
+	// +optional
+	MethodConfigs []MethodConfig `json:"methodConfigs,omitempty"`
+
+	// AIRateLimit limits outbound AI requests made on behalf of this PodSleuth
+	// Applied in addition to the operator-wide limits (--ai-requests-per-minute, --ai-max-concurrent-requests)
+	// Requests over the limit are skipped and reported as an AI analysis error
+	// +optional
+	AIRateLimit *AIRateLimitConfig `json:"aiRateLimit,omitempty"`
+
+	// AIQuota caps the AI requests made on behalf of this PodSleuth per hour and per day,
+	// so one PodSleuth cannot use up an AI budget shared with others
+	// Once a quota is used up, pods are analyzed without AI until the hour or day ends
+	// and the AIQuotaExhausted condition is set
+	// +optional
+	AIQuota *AIQuotaConfig `json:"aiQuota,omitempty"`
+
+	// Redaction configures masking of sensitive data in log lines
+	// Redaction is applied right after logs are fetched, so redacted lines are what
+	// is sent to AI endpoints and stored in ErrorLines
+	// Default: enabled with all built-in detectors
+	// +optional
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
+
+	// BatchAIRequests sends a single AI request for pods failing with identical logs
+	// (same error lines once timestamps, IDs and addresses are normalized) and
+	// shares the result with every pod in the group
+	// Default: true
+	// +optional
+	BatchAIRequests *bool `json:"batchAIRequests,omitempty"`
+
+	// Confidence configures pattern confidence scoring and how pattern and AI results are merged
+	// +optional
+	Confidence *ConfidenceConfig `json:"confidence,omitempty"`
+
+	// CertificateCheck inspects the TLS Secrets mounted by pods whose logs show TLS or x509 errors
+	// Default: enabled
+	// +optional
+	CertificateCheck *CertificateCheckConfig `json:"certificateCheck,omitempty"`
+
+	// ConfigCheck checks the environment variables, ConfigMaps and Secrets referenced by
+	// pods whose logs show missing configuration errors
+	// Default: enabled
+	// +optional
+	ConfigCheck *ConfigCheckConfig `json:"configCheck,omitempty"`
+}
+
+// CertificateCheckConfig defines configuration for certificate expiry detection
+type CertificateCheckConfig struct {
+	// Enabled enables inspection of mounted kubernetes.io/tls Secrets on TLS errors
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ExpiryWarning reports certificates expiring within this duration
+	// Default: 168h
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+}
+
+// ConfigCheckConfig defines the check of configuration references on configuration errors
+type ConfigCheckConfig struct {
+	// Enabled enables checking the configuration references of the analyzed container
+	// when its logs mention missing environment variables or configuration keys
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ConfidenceConfig defines confidence scoring and the merge policy for analysis results
+type ConfidenceConfig struct {
+	// MergeStrategy specifies how pattern and AI results are combined when both are available:
+	//   - "threshold": AI wins above AIPreferredAbove, pattern wins below PatternPreferredBelow,
+	//     otherwise both root causes are combined with a weighted average confidence
+	//   - "average": always combine both root causes with a weighted average confidence
+	//   - "highest": use the result with the highest weighted confidence
+	// Default: "threshold"
+	// +kubebuilder:validation:Enum=threshold;average;highest
+	// +optional
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
+
+	// AIPreferredAbove is the AI confidence above which the AI result is used alone
+	// Default: 80
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	AIPreferredAbove *int32 `json:"aiPreferredAbove,omitempty"`
+
+	// PatternPreferredBelow is the AI confidence below which the pattern result is used alone
+	// Default: 50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PatternPreferredBelow *int32 `json:"patternPreferredBelow,omitempty"`
+
+	// AIWeight is the relative weight of the AI confidence when averaging or comparing
+	// Default: 1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AIWeight *int32 `json:"aiWeight,omitempty"`
+
+	// PatternWeight is the relative weight of the pattern confidence when averaging or comparing
+	// Default: 1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PatternWeight *int32 `json:"patternWeight,omitempty"`
+
+	// PatternNoMatch is the confidence reported when error lines exist but no pattern matched
+	// Default: 30
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PatternNoMatch *int32 `json:"patternNoMatch,omitempty"`
+
+	// PatternSingleMatch is the confidence reported when a pattern matched one line
+	// Default: 50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PatternSingleMatch *int32 `json:"patternSingleMatch,omitempty"`
+
+	// PatternTwoMatches is the confidence reported when patterns matched two lines
+	// Default: 65
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PatternTwoMatches *int32 `json:"patternTwoMatches,omitempty"`
+
+	// PatternManyMatches is the confidence reported when patterns matched three or more lines
+	// Default: 80
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PatternManyMatches *int32 `json:"patternManyMatches,omitempty"`
+}
+
+// RedactionConfig defines how sensitive data is masked in log lines
+type RedactionConfig struct {
+	// Enabled enables redaction of log lines
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Detectors lists the built-in detectors to apply:
+	// "bearerToken", "jwt", "awsAccessKey", "awsSecretKey", "urlPassword", "password", "email"
+	// Default: all built-in detectors
+	// +optional
+	Detectors []string `json:"detectors,omitempty"`
+
+	// CustomPatterns defines additional regexes whose matches are redacted
+	// +optional
+	CustomPatterns []RedactionPattern `json:"customPatterns,omitempty"`
+
+	// Replacement is the text that replaces redacted data
+	// Default: "[REDACTED]"
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// RedactionPattern defines a custom regex for sensitive data
+type RedactionPattern struct {
+	// Name is a descriptive name for this pattern (e.g., "InternalCustomerID")
+	Name string `json:"name"`
+
+	// Pattern is the regex pattern to redact
+	// If the regex has capture groups, only the last group is redacted (e.g., "token=(\\S+)")
+	Pattern string `json:"pattern"`
+}
+
+// AIRateLimitConfig defines limits for outbound AI requests
+type AIRateLimitConfig struct {
+	// RequestsPerMinute is the maximum number of AI requests started per minute
+	// 0 means unlimited. Default: 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequestsPerMinute *int32 `json:"requestsPerMinute,omitempty"`
+
+	// MaxConcurrentRequests is the maximum number of AI requests in flight at the same time
+	// 0 means unlimited. Default: 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+}
+
+// AIQuotaConfig defines quotas of AI requests, counted over clock hours and days in UTC
+type AIQuotaConfig struct {
+	// MaxPerHour is the maximum number of AI requests per hour
+	// 0 means unlimited. Default: 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPerHour *int32 `json:"maxPerHour,omitempty"`
+
+	// MaxPerDay is the maximum number of AI requests per day
+	// 0 means unlimited. Default: 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPerDay *int32 `json:"maxPerDay,omitempty"`
+}
+
+// AIQuotaUsage counts the AI requests of a PodSleuth in the current quota windows
+type AIQuotaUsage struct {
+	// HourStart is the start of the current hour
+	HourStart metav1.Time `json:"hourStart"`
+
+	// HourRequests is the number of AI requests made in the current hour
+	HourRequests int32 `json:"hourRequests"`
+
+	// DayStart is the start of the current day
+	DayStart metav1.Time `json:"dayStart"`
+
+	// DayRequests is the number of AI requests made in the current day
+	DayRequests int32 `json:"dayRequests"`
+}
+
+// MethodConfig defines configuration for a specific analysis method
+type MethodConfig struct {
+	// Type specifies the analysis method type: "pattern", "ai" or "metrics"
+	// +kubebuilder:validation:Enum=pattern;ai;metrics
+	Type string `json:"type"`
+
+	// PatternConfig contains pattern-specific configuration (used when type is "pattern")
+	// +optional
+	PatternConfig *PatternConfig `json:"patternConfig,omitempty"`
+
+	// AIConfig contains AI-specific configuration (used when type is "ai")
+	// +optional
+	AIConfig *AIConfig `json:"aiConfig,omitempty"`
+
+	// AIFallbacks lists additional AI providers tried in order when the previous one
+	// fails, times out or is rate limited (used when type is "ai")
+	// Example: aiConfig points to a local Ollama, aiFallbacks to OpenAI
+	// +optional
+	AIFallbacks []AIConfig `json:"aiFallbacks,omitempty"`
+
+	// MetricsConfig contains Prometheus-specific configuration (used when type is "metrics")
+	// +optional
+	MetricsConfig *MetricsConfig `json:"metricsConfig,omitempty"`
+}
+
+// MetricsConfig defines configuration for metrics-based analysis
+type MetricsConfig struct {
+	// PrometheusURL is the base URL of the Prometheus HTTP API
+	// Example: "http://prometheus-operated.monitoring.svc:9090"
+	PrometheusURL string `json:"prometheusURL"`
+
+	// BearerTokenSecretRef references a secret (in the pod's namespace) containing a bearer token for Prometheus
+	// +optional
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// Timeout specifies the timeout for each Prometheus query
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Queries defines the PromQL queries to evaluate for the failing pod
+	// If not specified, default queries are used (restart rate, CPU throttling,
+	// memory usage vs. limit and probe failures)
+	// +optional
+	Queries []MetricQuery `json:"queries,omitempty"`
+}
+
+// MetricQuery defines a PromQL query and the threshold that turns its value into a finding
+type MetricQuery struct {
+	// Name is a descriptive name for this query (e.g., "CPUThrottling")
+	Name string `json:"name"`
+
+	// Query is the PromQL query. The placeholders {{namespace}} and {{pod}} are replaced
+	// with the failing pod's namespace and name. The highest value of the result is used
+	Query string `json:"query"`
+
+	// Threshold is the value above which the query result is reported as a finding
+	// Default: "0"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	Threshold string `json:"threshold,omitempty"`
+
+	// RootCause is the message to report when the threshold is exceeded
+	// The placeholder {{value}} is replaced with the query result
+	// +optional
+	RootCause string `json:"rootCause,omitempty"`
+}
+
+// PatternConfig defines configuration for pattern-based analysis
+type PatternConfig struct {
+	// Patterns defines custom error patterns for pattern matching
+	// If not specified, default patterns will be used
+	// +optional
+	Patterns []ErrorPattern `json:"patterns,omitempty"`
+}
+
+// AIConfig defines configuration for AI-based analysis
+type AIConfig struct {
+	// Endpoint is the URL endpoint for AI analysis
+	// Examples:
+	//   - OpenAI: "https://api.openai.com/v1/chat/completions"
+	//   - Ollama: "http://localhost:11434/api/generate"
+	Endpoint string `json:"endpoint"`
+
+	// Format specifies the API format: "openai", "anthropic", "ollama", or "generic"
+	// Default: "openai"
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Model specifies the model name to use
+	// Examples: "gpt-4", "qwen3:8b", "claude-3-opus"
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// APIKeySecretRef references a secret containing the API key
+	// +optional
+	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// APIKeySecretNamespace is the namespace of the API key secret, so one secret serves
+	// pods of every namespace. The operator must allow it with --ai-key-secret-namespaces.
+	// Default: the namespace of the analyzed pod
+	// +optional
+	APIKeySecretNamespace string `json:"apiKeySecretNamespace,omitempty"`
+
+	// AuthHeader specifies the HTTP header name for authentication
+	// Default: "Authorization"
+	// +optional
+	AuthHeader string `json:"authHeader,omitempty"`
+
+	// AuthPrefix specifies the prefix for the auth header value
+	// Default: "Bearer"
+	// +optional
+	AuthPrefix string `json:"authPrefix,omitempty"`
+
+	// Timeout specifies the timeout for the AI request
+	// Default: 60s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// ConnectTimeout specifies the timeout for establishing the connection to the AI endpoint
+	// Default: 10s
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+
+	// ReadTimeout specifies how long to wait for the AI endpoint to start responding
+	// once the request has been sent. Slow local models may need several minutes.
+	// If not set, only Timeout applies
+	// +optional
+	ReadTimeout *metav1.Duration `json:"readTimeout,omitempty"`
+
+	// ProxyURL is the HTTP(S) proxy to use for requests to the AI endpoint
+	// If not set, the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are honored
+	// Example: "http://proxy.corp.example.com:3128"
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+}
+
+// ErrorPattern defines a pattern to match error messages in logs
+type ErrorPattern struct {
+	// Name is a descriptive name for this pattern (e.g., "KafkaConnectionError")
+	Name string `json:"name"`
+
+	// Pattern is the regex pattern to match against log lines
+	Pattern string `json:"pattern"`
+
+	// RootCause is the root cause message to report when this pattern matches
+	// If empty, the matched log line will be used as the root cause
+	// +optional
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Priority determines which pattern to use if multiple patterns match
+	// Higher priority patterns are preferred. Default: 0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// PatternError contains detailed error information for a specific pattern
+type PatternError struct {
+	// PatternName is the name of the pattern
+	PatternName string `json:"patternName"`
+
+	// MatchedLine is the log line that matched the pattern
+	MatchedLine string `json:"matchedLine"`
+
+	// Reason is the reason why the pattern matched
+	Reason string `json:"reason"`
+
+	// Message is the detailed message explaining the pattern match
+	Message string `json:"message"`
+}
+
+// PatternAnalysisResult contains pattern-specific analysis results
+type PatternAnalysisResult struct {
+	// MatchedPattern is the name of the pattern that matched
+	MatchedPattern string `json:"matchedPattern,omitempty"`
+
+	// Priority is the priority of the matched pattern
+	Priority int32 `json:"priority,omitempty"`
+
+	// RootCause is the root cause from pattern matching
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) of the pattern match
+	Confidence int32 `json:"confidence,omitempty"`
+
+	// Error contains any error message if pattern analysis failed
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// AIAnalysisResult contains AI-specific analysis results
+type AIAnalysisResult struct {
+	// Model is the AI model used for analysis
+	Model string `json:"model,omitempty"`
+
+	// RootCause is the root cause identified by AI
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) from AI analysis
+	Confidence int32 `json:"confidence,omitempty"`
+
+	// Provider identifies the AI provider that produced the result (format and endpoint host)
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// FailedProviders lists providers that were tried before Provider and failed, with the error
+	// +optional
+	FailedProviders []string `json:"failedProviders,omitempty"`
+
+	// SharedFrom is the pod (namespace/name) whose logs were sent to the AI provider
+	// when this result is shared by a group of pods failing identically
+	// +optional
+	SharedFrom string `json:"sharedFrom,omitempty"`
+
+	// GroupSize is the number of pods sharing this AI result
+	// +optional
+	GroupSize int32 `json:"groupSize,omitempty"`
+
+	// Error contains any error message if AI analysis failed
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// MetricFinding is a metric query whose value exceeded its threshold
+type MetricFinding struct {
+	// Name is the name of the query
+	Name string `json:"name"`
+
+	// Value is the query result
+	Value string `json:"value"`
+
+	// RootCause is the message describing the finding
+	RootCause string `json:"rootCause"`
+}
+
+// MetricsAnalysisResult contains metrics-specific analysis results
+type MetricsAnalysisResult struct {
+	// Findings lists the queries that exceeded their thresholds
+	// +optional
+	Findings []MetricFinding `json:"findings,omitempty"`
+
+	// RootCause summarizes the findings
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) of the metrics findings
+	Confidence int32 `json:"confidence,omitempty"`
+
+	// Error contains any error message if metrics analysis failed
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// CertificateFinding describes a certificate from a mounted TLS Secret
+type CertificateFinding struct {
+	// SecretName is the name of the Secret holding the certificate
+	SecretName string `json:"secretName"`
+
+	// Key is the Secret key the certificate was read from (tls.crt or ca.crt)
+	Key string `json:"key"`
+
+	// Subject is the certificate subject
+	Subject string `json:"subject"`
+
+	// NotAfter is when the certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Expired indicates the certificate has already expired
+	Expired bool `json:"expired"`
+}
+
+// InitContainerAnalysisResult describes the init container blocking a pod's startup
+type InitContainerAnalysisResult struct {
+	// Container is the name of the blocking init container
+	Container string `json:"container"`
+
+	// Step is the position of the container in the init sequence, starting at 1
+	Step int32 `json:"step"`
+
+	// Steps is the number of init containers of the pod
+	Steps int32 `json:"steps"`
+
+	// Cause is why the container blocks: WaitingForDependency, Failing, NotStarted or Running
+	Cause string `json:"cause"`
+
+	// Dependency is the host or service the container waits for, when known
+	// +optional
+	Dependency string `json:"dependency,omitempty"`
+
+	// RootCause describes why the init sequence does not complete
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) of the root cause
+	Confidence int32 `json:"confidence,omitempty"`
 }
 
-// ContainerError contains detailed error information for a specific container
-type ContainerError struct {
-	// ContainerName is the name of the container
-	ContainerName string `json:"containerName"`
+// ConfigAnalysisResult contains the configuration references checked for a container
+type ConfigAnalysisResult struct {
+	// Container is the name of the checked container
+	Container string `json:"container"`
 
-	// Type indicates whether this is a regular container or init container
-	Type string `json:"type"` // "container" or "initContainer"
+	// Missing lists the references that do not resolve
+	// +optional
+	Missing []MissingConfigReference `json:"missing,omitempty"`
 
-	// State is the current state of the container (waiting, terminated, running)
-	State string `json:"state"`
+	// ReferencesChecked is how many referenced ConfigMaps and Secrets were checked
+	ReferencesChecked int32 `json:"referencesChecked"`
 
-	// Reason is the error reason (CrashLoopBackOff, ImagePullBackOff, etc.)
-	Reason string `json:"reason"`
+	// RootCause names the missing configuration
+	// +optional
+	RootCause string `json:"rootCause,omitempty"`
 
-	// Message is the detailed error message
-	Message string `json:"message"`
+	// Confidence is the confidence level (0-100) of the root cause
+	// +optional
+	Confidence int32 `json:"confidence,omitempty"`
 
-	// ExitCode is the exit code if the container terminated
+	// Error describes references that could not be checked
 	// +optional
-	ExitCode *int32 `json:"exitCode,omitempty"`
+	Error string `json:"error,omitempty"`
+}
 
-	// RestartCount is the number of times the container has restarted
-	RestartCount int32 `json:"restartCount"`
+// MissingConfigReference is a configuration reference that does not resolve
+type MissingConfigReference struct {
+	// Kind is ConfigMap, Secret, or Env for an environment variable the logs name
+	// but the container does not set
+	// +kubebuilder:validation:Enum=ConfigMap;Secret;Env
+	Kind string `json:"kind"`
 
-	// Ready indicates if the container is ready
-	Ready bool `json:"ready"`
+	// Name is the name of the ConfigMap or Secret, or of the environment variable
+	Name string `json:"name"`
+
+	// Key is the missing key, empty when the whole ConfigMap or Secret is missing
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// EnvVar is the environment variable referencing the key, if any
+	// +optional
+	EnvVar string `json:"envVar,omitempty"`
+
+	// MentionedInLogs is set when the logs name the variable or key
+	// +optional
+	MentionedInLogs bool `json:"mentionedInLogs,omitempty"`
 }
 
-// PodCondition represents a pod condition status
-type PodCondition struct {
-	// Type is the type of condition
+// CertificateAnalysisResult contains certificate-specific analysis results
+type CertificateAnalysisResult struct {
+	// Certificates lists certificates that are expired or expiring soon
+	// +optional
+	Certificates []CertificateFinding `json:"certificates,omitempty"`
+
+	// SecretsChecked is the number of TLS Secrets mounted by the pod that were inspected
+	SecretsChecked int32 `json:"secretsChecked"`
+
+	// RootCause summarizes the expired or expiring certificates
+	// +optional
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) of the certificate findings
+	// +optional
+	Confidence int32 `json:"confidence,omitempty"`
+
+	// Error contains any error message if certificate inspection failed
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// LogAnalysisResult contains results from log analysis
+type LogAnalysisResult struct {
+	// RootCause is the identified root cause from log analysis (merged from all methods)
+	RootCause string `json:"rootCause,omitempty"`
+
+	// Confidence is the confidence level (0-100) of the analysis (merged from all methods)
+	Confidence int32 `json:"confidence,omitempty"`
+
+	// Method used for analysis: "pattern" or "ai"
+	// Deprecated: Use Methods instead for multiple method support
+	Method string `json:"method,omitempty"`
+
+	// Methods used for analysis in execution order (e.g., ["pattern", "ai"])
+	Methods []string `json:"methods,omitempty"`
+
+	// MatchedPattern is the name of the pattern that matched (for pattern analysis)
+	// Used internally, prefer PatternResult.MatchedPattern
+	// +optional
+	MatchedPattern string `json:"matchedPattern,omitempty"`
+
+	// Priority is the priority of the matched pattern (for pattern analysis)
+	// Used internally, prefer PatternResult.Priority
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Model is the AI model used (for AI analysis)
+	// Used internally, prefer AIResult.Model
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// PatternResult contains pattern-specific analysis details
+	// +optional
+	PatternResult *PatternAnalysisResult `json:"patternResult,omitempty"`
+
+	// AIResult contains AI-specific analysis details
+	// +optional
+	AIResult *AIAnalysisResult `json:"aiResult,omitempty"`
+
+	// MetricsResult contains metrics-specific analysis details
+	// +optional
+	MetricsResult *MetricsAnalysisResult `json:"metricsResult,omitempty"`
+
+	// CertificateResult contains certificate expiry details for pods with TLS errors
+	// +optional
+	CertificateResult *CertificateAnalysisResult `json:"certificateResult,omitempty"`
+
+	// InitContainerResult describes the init container blocking the pod's startup,
+	// whose logs were analyzed instead of those of the regular containers
+	// +optional
+	InitContainerResult *InitContainerAnalysisResult `json:"initContainerResult,omitempty"`
+
+	// ConfigResult contains the configuration references that do not resolve, for pods
+	// whose logs show missing configuration errors
+	// +optional
+	ConfigResult *ConfigAnalysisResult `json:"configResult,omitempty"`
+
+	// ErrorLines contains the error lines that led to this conclusion
+	ErrorLines []string `json:"errorLines,omitempty"`
+
+	// ErrorLinesOmitted is how many error lines were left out of the status, or
+	// truncated, to bound its size
+	// +optional
+	ErrorLinesOmitted int32 `json:"errorLinesOmitted,omitempty"`
+
+	// AnalyzedAt is when the analysis was performed
+	AnalyzedAt metav1.Time `json:"analyzedAt,omitempty"`
+
+	// RefreshGeneration is the generation of the forced analysis that produced this
+	// result, from the kubesleuth.io/force-refresh-generation annotation. Clients that
+	// forced an analysis wait for a result of their generation or a later one.
+	// +optional
+	RefreshGeneration int64 `json:"refreshGeneration,omitempty"`
+
+	// CachedAt is when the result was cached (if caching is enabled)
+	// +optional
+	CachedAt metav1.Time `json:"cachedAt,omitempty"`
+
+	// CacheExpiresAt is when the cached result will expire (if caching is enabled)
+	// +optional
+	CacheExpiresAt *metav1.Time `json:"cacheExpiresAt,omitempty"`
+
+	// CacheKey identifies the pod incarnation (UID and restart count) and analysis configuration that was analyzed
+	// Used to restore the analysis cache from status after an operator restart
+	// +optional
+	CacheKey string `json:"cacheKey,omitempty"`
+}
+
+// ConnectivityResult is the result of probing one target from the operator
+type ConnectivityResult struct {
+	// Target is the checked host or host:port
+	Target string `json:"target"`
+
+	// Resolved indicates whether the host resolved to at least one address
+	Resolved bool `json:"resolved"`
+
+	// Addresses are the resolved addresses
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Reachable indicates whether a TCP connection could be established.
+	// Only set when the target has a port.
+	// +optional
+	Reachable *bool `json:"reachable,omitempty"`
+
+	// Error describes why the target could not be resolved or reached
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// BlockingNetworkPolicies lists NetworkPolicies (namespace/name) that could be
+	// blocking traffic from the pod to the target
+	// +optional
+	BlockingNetworkPolicies []string `json:"blockingNetworkPolicies,omitempty"`
+
+	// Summary describes the result in one sentence
+	Summary string `json:"summary"`
+}
+
+// DebugCheck is the result of one check run in the debug container
+type DebugCheck struct {
+	// Type is the kind of check ("dns", "tcp" or "disk")
 	Type string `json:"type"`
 
-	// Status is the status of the condition (True, False, Unknown)
-	Status string `json:"status"`
+	// Target is the checked host, host:port or mount point
+	Target string `json:"target"`
 
-	// Reason is the reason for the condition status
+	// Passed indicates whether the check succeeded
+	Passed bool `json:"passed"`
+
+	// Output is the trimmed output of the check
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
+// DebugDiagnosticsResult contains the results of ephemeral debug container diagnostics
+type DebugDiagnosticsResult struct {
+	// ContainerName is the name of the ephemeral debug container
+	ContainerName string `json:"containerName"`
+
+	// State is "Running" while checks are in progress, "Completed" or "Failed"
+	State string `json:"state"`
+
+	// Checks contains the individual check results
+	// +optional
+	Checks []DebugCheck `json:"checks,omitempty"`
+
+	// Findings summarizes the failed checks
+	// +optional
+	Findings string `json:"findings,omitempty"`
+
+	// Error describes why the diagnostics could not run
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// MeshDiagnosis describes the state of a service mesh sidecar relative to the application containers
+type MeshDiagnosis struct {
+	// Mesh is the detected service mesh ("istio" or "linkerd")
+	Mesh string `json:"mesh"`
+
+	// Sidecar is the name of the sidecar proxy container, empty if the sidecar is missing
+	// +optional
+	Sidecar string `json:"sidecar,omitempty"`
+
+	// SidecarReady indicates if the sidecar proxy is ready
+	SidecarReady bool `json:"sidecarReady"`
+
+	// ApplicationReady indicates if all application containers are ready
+	ApplicationReady bool `json:"applicationReady"`
+
+	// Issues lists detected mesh misconfigurations
+	// +optional
+	Issues []string `json:"issues,omitempty"`
+}
+
+// TerminationRecord describes a single observed container termination
+type TerminationRecord struct {
+	// FinishedAt is when the container instance terminated
+	FinishedAt metav1.Time `json:"finishedAt"`
+
+	// ExitCode is the exit code of the container instance
+	ExitCode int32 `json:"exitCode"`
+
+	// Reason is the termination reason (e.g., OOMKilled, Error)
 	// +optional
 	Reason string `json:"reason,omitempty"`
 
-	// Message is the message describing the condition
+	// RuntimeSeconds is how long the container instance ran before terminating
 	// +optional
-	Message string `json:"message,omitempty"`
+	RuntimeSeconds int32 `json:"runtimeSeconds,omitempty"`
+
+	// RootCause is the log analysis root cause for this container instance
+	// +optional
+	RootCause string `json:"rootCause,omitempty"`
 }
 
-// LogAnalysisConfig defines configuration for log analysis
-type LogAnalysisConfig struct {
-	// Enabled enables log analysis for non-ready pods
-	Enabled bool `json:"enabled"`
+// CrashLoopTrend summarizes the terminations of a repeatedly restarting container
+type CrashLoopTrend struct {
+	// ContainerName is the name of the restarting container
+	ContainerName string `json:"containerName"`
 
-	// Method specifies the analysis method: "pattern" or "ai"
-	// Deprecated: Use Methods instead for multiple method support
-	// Default: "pattern"
+	// Restarts is the number of restarts observed within the window
+	Restarts int32 `json:"restarts"`
+
+	// Window is the time span the restarts were observed in (e.g., "2h")
+	Window string `json:"window"`
+
+	// Summary is a human readable description of the trend
+	// Example: "Crashed 14 times in 2h, always exit 137 (OOMKilled) within ~30s of start"
+	Summary string `json:"summary"`
+
+	// DominantExitCode is the most frequent exit code
 	// +optional
-	Method string `json:"method,omitempty"`
+	DominantExitCode *int32 `json:"dominantExitCode,omitempty"`
 
-	// Methods specifies the analysis methods to run in order (e.g., ["pattern", "ai"])
-	// Methods are executed sequentially in the order specified
-	// Example: ["pattern", "ai"] runs pattern first, then AI
-	// Default: ["pattern"]
+	// DominantReason is the most frequent termination reason
 	// +optional
-	Methods []string `json:"methods,omitempty"`
+	DominantReason string `json:"dominantReason,omitempty"`
 
-	// CacheEnabled enables caching of analysis results to avoid re-analyzing on every reconcile
-	// Results are cached per pod (keyed by UID + restart count)
-	// Cache is invalidated when pod restarts or after TTL expires
-	// Default: true
+	// TypicalRuntimeSeconds is the median time the container ran before terminating
 	// +optional
-	CacheEnabled *bool `json:"cacheEnabled,omitempty"`
+	TypicalRuntimeSeconds int32 `json:"typicalRuntimeSeconds,omitempty"`
 
-	// CacheTTL is the duration to cache analysis results before re-analyzing
-	// Default: 5m
+	// RecurringRootCause is a log analysis root cause seen for several container instances
 	// +optional
-	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+	RecurringRootCause string `json:"recurringRootCause,omitempty"`
 
-	// LinesToAnalyze is the number of recent log lines to fetch and analyze
-	// Default: 100
+	// Terminations lists the most recent observed terminations, newest first
 	// +optional
-	LinesToAnalyze *int32 `json:"linesToAnalyze,omitempty"`
+	Terminations []TerminationRecord `json:"terminations,omitempty"`
+}
 
-	// FilterErrorsOnly if true, filters error/warning lines from the last LinesToAnalyze lines
-	// Process: 1) Fetch last LinesToAnalyze lines, 2) Filter for errors/warnings, 3) Analyze filtered lines
-	// Default: true
+// NonReadyPodInfo contains information about a non-ready pod
+type NonReadyPodInfo struct {
+	// Name is the name of the pod
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the pod
+	Namespace string `json:"namespace"`
+
+	// Cluster is the name of the cluster of the pod, set with the operator's --cluster-name
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Phase is the current phase of the pod (Pending, Running, Failed, etc.)
+	Phase string `json:"phase"`
+
+	// OwnerKind is the kind of the owning workload (Deployment, StatefulSet, DaemonSet,
+	// Job, CronJob, ReplicationController, ReplicaSet or Rollout)
+	// +optional
+	OwnerKind string `json:"ownerKind,omitempty"`
+
+	// OwnerName is the name of the owner
+	// +optional
+	OwnerName string `json:"ownerName,omitempty"`
+
+	// NodeName is the node the pod is scheduled on
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Team is the team owning the pod according to spec.ownershipRules
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// CreatedAt is when the pod was created
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// DetectedAt is when this PodSleuth first found the pod non-ready
+	// +optional
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+
+	// NotReadySince is when the pod's Ready condition last became false, or when the pod
+	// was created if it never was ready
+	// +optional
+	NotReadySince *metav1.Time `json:"notReadySince,omitempty"`
+
+	// Reason is the primary reason why the pod is not ready (from container status investigation)
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the detailed message explaining why the pod is not ready
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ContainerErrors contains detailed error information for each unready container
+	// +optional
+	ContainerErrors []ContainerError `json:"containerErrors,omitempty"`
+
+	// PodConditions contains all pod conditions for comprehensive status
+	// +optional
+	PodConditions []PodCondition `json:"podConditions,omitempty"`
+
+	// LogAnalysis contains results from log analysis if enabled
+	// +optional
+	LogAnalysis *LogAnalysisResult `json:"logAnalysis,omitempty"`
+
+	// AnalysisPending indicates a log analysis of the pod is queued or running. LogAnalysis
+	// then holds the previous analysis, if any, until the new one is done.
+	// +optional
+	AnalysisPending bool `json:"analysisPending,omitempty"`
+
+	// CrashLoopTrend aggregates terminations across restarts for repeatedly restarting pods
 	// +optional
-	FilterErrorsOnly *bool `json:"filterErrorsOnly,omitempty"`
+	CrashLoopTrend *CrashLoopTrend `json:"crashLoopTrend,omitempty"`
 
-	// Patterns defines custom error patterns for pattern matching method
-	// Deprecated: Use MethodConfigs with PatternConfig instead for cleaner structure
-	// If not specified, default patterns will be used (connection errors, service unavailable, etc.)
-	// Each pattern should be a regex that matches error messages
+	// Mesh describes the service mesh sidecar state for pods that are part of a mesh
 	// +optional
-	Patterns []ErrorPattern `json:"patterns,omitempty"`
+	Mesh *MeshDiagnosis `json:"mesh,omitempty"`
 
-	// AIEndpoint is the URL endpoint for AI analysis (required if "ai" in methods)
-	// Deprecated: Use MethodConfigs with AIConfig instead for cleaner structure
-	// Examples:
-	//   - OpenAI: "https://api.openai.com/v1/chat/completions"
-	//   - Ollama: "http://localhost:11434/api/generate"
+	// DebugDiagnostics contains the results of the ephemeral debug container checks
 	// +optional
-	AIEndpoint string `json:"aiEndpoint,omitempty"`
+	DebugDiagnostics *DebugDiagnosticsResult `json:"debugDiagnostics,omitempty"`
 
-	// AIFormat specifies the API format to use: "openai", "anthropic", "ollama", or "generic"
-	// Deprecated: Use MethodConfigs with AIConfig instead
-	// Default: "openai"
+	// Connectivity contains the connectivity checks of hosts found by log analysis
 	// +optional
-	AIFormat string `json:"aiFormat,omitempty"`
+	Connectivity []ConnectivityResult `json:"connectivity,omitempty"`
 
-	// AIModel specifies the model name to use for AI analysis
-	// Deprecated: Use MethodConfigs with AIConfig instead
-	// Examples: "gpt-4", "qwen3:8b", "claude-3-opus"
+	// Reserved is the CPU and memory the pod's requests hold reserved on its node, set
+	// once the pod is scheduled
 	// +optional
-	AIModel string `json:"aiModel,omitempty"`
+	Reserved *ReservedResources `json:"reserved,omitempty"`
 
-	// AIAPIKey is the API key for AI analysis
-	// Deprecated: Use MethodConfigs with AIConfig instead
-	// Should be stored as a Kubernetes Secret reference
+	// Suppressed indicates the pod is in an active maintenance window
 	// +optional
-	AIAPIKey *corev1.SecretKeySelector `json:"aiApiKey,omitempty"`
+	Suppressed bool `json:"suppressed,omitempty"`
 
-	// AIAuthHeader specifies the HTTP header name for authentication
-	// Deprecated: Use MethodConfigs with AIConfig instead
-	// Default: "Authorization"
+	// SuppressedBy is the name of the maintenance window suppressing the pod
 	// +optional
-	AIAuthHeader string `json:"aiAuthHeader,omitempty"`
+	SuppressedBy string `json:"suppressedBy,omitempty"`
 
-	// AIAuthPrefix specifies the prefix for the auth header value (e.g., "Bearer", "ApiKey")
-	// Deprecated: Use MethodConfigs with AIConfig instead
-	// Default: "Bearer"
+	// Silenced indicates the pod matches an active SleuthSilence
 	// +optional
-	AIAuthPrefix string `json:"aiAuthPrefix,omitempty"`
+	Silenced bool `json:"silenced,omitempty"`
 
-	// MethodConfigs defines method-specific configurations in order of execution
-	// This is the NEW PREFERRED way to configure log analysis methods.
-	// Each method has its own configuration block, avoiding parameter mixing.
-	// Example:
-	//   methodConfigs:
-	//     - type: "pattern"
-	//       patternConfig:
-	//         patterns: [...]
-	//     - type: "ai"
-	//       aiConfig:
-	//         endpoint: "..."
-	//         model: "..."
+	// SilencedBy is the name of the SleuthSilence matching the pod
 	// +optional
-	MethodConfigs []MethodConfig `json:"methodConfigs,omitempty"`
-}
+	SilencedBy string `json:"silencedBy,omitempty"`
 
-// MethodConfig defines configuration for a specific analysis method
-type MethodConfig struct {
-	// Type specifies the analysis method type: "pattern" or "ai"
-	// +kubebuilder:validation:Enum=pattern;ai
-	Type string `json:"type"`
+	// WithinBaseline indicates the pod's workload restarts and flaps no more than its
+	// learned baseline, so the pod is treated like a silenced one
+	// +optional
+	WithinBaseline bool `json:"withinBaseline,omitempty"`
 
-	// PatternConfig contains pattern-specific configuration (used when type is "pattern")
+	// Acknowledged is set while someone has acknowledged the pod from the dashboard.
+	// Acknowledged pods are treated like silenced ones until the acknowledgement expires.
 	// +optional
-	PatternConfig *PatternConfig `json:"patternConfig,omitempty"`
+	Acknowledged *PodAcknowledgement `json:"acknowledged,omitempty"`
 
-	// AIConfig contains AI-specific configuration (used when type is "ai")
+	// Report is the name of the PodSleuthReport in the pod's namespace holding the
+	// full analysis of the pod
 	// +optional
-	AIConfig *AIConfig `json:"aiConfig,omitempty"`
+	Report string `json:"report,omitempty"`
 }
 
-// PatternConfig defines configuration for pattern-based analysis
-type PatternConfig struct {
-	// Patterns defines custom error patterns for pattern matching
-	// If not specified, default patterns will be used
+// ReservedResources is the CPU and memory a pod's requests reserve on its node
+type ReservedResources struct {
+	// CPUMillicores is the requested CPU in millicores
 	// +optional
-	Patterns []ErrorPattern `json:"patterns,omitempty"`
+	CPUMillicores int64 `json:"cpuMillicores,omitempty"`
+
+	// MemoryBytes is the requested memory in bytes
+	// +optional
+	MemoryBytes int64 `json:"memoryBytes,omitempty"`
 }
 
-// AIConfig defines configuration for AI-based analysis
-type AIConfig struct {
-	// Endpoint is the URL endpoint for AI analysis
-	// Examples:
-	//   - OpenAI: "https://api.openai.com/v1/chat/completions"
-	//   - Ollama: "http://localhost:11434/api/generate"
-	Endpoint string `json:"endpoint"`
+// EvictedPodInfo contains information about a pod evicted or shut down by its node
+type EvictedPodInfo struct {
+	// Name is the name of the pod
+	Name string `json:"name"`
 
-	// Format specifies the API format: "openai", "anthropic", "ollama", or "generic"
-	// Default: "openai"
+	// Namespace is the namespace of the pod
+	Namespace string `json:"namespace"`
+
+	// Reason is the pod status reason (Evicted, NodeShutdown or Terminated)
+	Reason string `json:"reason"`
+
+	// Resource is the node resource that was under pressure (e.g., memory, ephemeral-storage)
 	// +optional
-	Format string `json:"format,omitempty"`
+	Resource string `json:"resource,omitempty"`
 
-	// Model specifies the model name to use
-	// Examples: "gpt-4", "qwen3:8b", "claude-3-opus"
+	// NodeCondition is the node condition that caused the eviction (e.g., DiskPressure)
 	// +optional
-	Model string `json:"model,omitempty"`
+	NodeCondition string `json:"nodeCondition,omitempty"`
 
-	// APIKeySecretRef references a secret containing the API key
+	// Message is the eviction message reported by the kubelet
 	// +optional
-	APIKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+	Message string `json:"message,omitempty"`
 
-	// AuthHeader specifies the HTTP header name for authentication
-	// Default: "Authorization"
+	// OwnerKind is the kind of the owner
 	// +optional
-	AuthHeader string `json:"authHeader,omitempty"`
+	OwnerKind string `json:"ownerKind,omitempty"`
 
-	// AuthPrefix specifies the prefix for the auth header value
-	// Default: "Bearer"
+	// OwnerName is the name of the owner
 	// +optional
-	AuthPrefix string `json:"authPrefix,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+}
 
-	// Timeout specifies the timeout for the AI request
-	// Default: 60s
+// EvictedPodGroup groups pods evicted or shut down on the same node
+type EvictedPodGroup struct {
+	// NodeName is the node the pods were running on
+	NodeName string `json:"nodeName"`
+
+	// Severity is "critical", "warning" or "info"
+	// +kubebuilder:validation:Enum=critical;warning;info
+	Severity string `json:"severity"`
+
+	// Count is the number of pods in the group
+	Count int32 `json:"count"`
+
+	// Resources lists the distinct node resources or conditions that caused evictions
 	// +optional
-	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+
+	// Summary is a human readable description of the group
+	Summary string `json:"summary"`
+
+	// Pods lists the evicted or shut down pods
+	Pods []EvictedPodInfo `json:"pods"`
 }
 
-// ErrorPattern defines a pattern to match error messages in logs
-type ErrorPattern struct {
-	// Name is a descriptive name for this pattern (e.g., "KafkaConnectionError")
+// HPAStatus summarizes the HorizontalPodAutoscaler targeting a workload
+type HPAStatus struct {
+	// Name is the name of the HorizontalPodAutoscaler
 	Name string `json:"name"`
 
-	// Pattern is the regex pattern to match against log lines
-	Pattern string `json:"pattern"`
-
-	// RootCause is the root cause message to report when this pattern matches
-	// If empty, the matched log line will be used as the root cause
+	// MinReplicas is the lower replica limit
 	// +optional
-	RootCause string `json:"rootCause,omitempty"`
+	MinReplicas int32 `json:"minReplicas,omitempty"`
 
-	// Priority determines which pattern to use if multiple patterns match
-	// Higher priority patterns are preferred. Default: 0
+	// MaxReplicas is the upper replica limit
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// CurrentReplicas is the current number of replicas
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// DesiredReplicas is the number of replicas the autoscaler wants
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// AtMaxReplicas indicates the autoscaler is pinned at MaxReplicas
+	AtMaxReplicas bool `json:"atMaxReplicas"`
+
+	// MetricsUnavailable indicates the autoscaler cannot fetch or compute its metrics
+	MetricsUnavailable bool `json:"metricsUnavailable"`
+
+	// Message is the message of the failing autoscaler condition
 	// +optional
-	Priority int32 `json:"priority,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
-// PatternError contains detailed error information for a specific pattern
-type PatternError struct {
-	// PatternName is the name of the pattern
-	PatternName string `json:"patternName"`
+// PodAcknowledgement records that someone is looking into a non-ready pod
+type PodAcknowledgement struct {
+	// By is who acknowledged the pod
+	By string `json:"by"`
 
-	// MatchedLine is the log line that matched the pattern
-	MatchedLine string `json:"matchedLine"`
+	// Comment explains the acknowledgement
+	// +optional
+	Comment string `json:"comment,omitempty"`
 
-	// Reason is the reason why the pattern matched
-	Reason string `json:"reason"`
+	// Until is when the acknowledgement expires
+	Until metav1.Time `json:"until"`
+}
 
-	// Message is the detailed message explaining the pattern match
-	Message string `json:"message"`
+// SLOStatus holds the incidents of the SLO window
+type SLOStatus struct {
+	// Incidents are the ongoing incidents and those resolved within the window, oldest
+	// first
+	// +optional
+	Incidents []SLOIncident `json:"incidents,omitempty"`
+
+	// Breaching lists the workloads below the availability objective, as
+	// namespace/kind/name
+	// +optional
+	Breaching []string `json:"breaching,omitempty"`
 }
 
-// PatternAnalysisResult contains pattern-specific analysis results
-type PatternAnalysisResult struct {
-	// MatchedPattern is the name of the pattern that matched
-	MatchedPattern string `json:"matchedPattern,omitempty"`
+// SLOIncident is a period in which pods of a workload were not ready
+type SLOIncident struct {
+	// Namespace is the namespace of the workload
+	Namespace string `json:"namespace"`
 
-	// Priority is the priority of the matched pattern
-	Priority int32 `json:"priority,omitempty"`
+	// Workload is the owner of the pods as kind/name, or Pod/name for a pod without owner
+	Workload string `json:"workload"`
 
-	// RootCause is the root cause from pattern matching
-	RootCause string `json:"rootCause,omitempty"`
+	// Reason is why the first pod of the incident was not ready
+	// +optional
+	Reason string `json:"reason,omitempty"`
 
-	// Confidence is the confidence level (0-100) of the pattern match
-	Confidence int32 `json:"confidence,omitempty"`
+	// FailedAt is when the first pod stopped being ready
+	FailedAt metav1.Time `json:"failedAt"`
 
-	// Error contains any error message if pattern analysis failed
+	// DetectedAt is when the PodSleuth found the first pod non-ready
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// RecoveredAt is when none of the pods was non-ready anymore, unset while the
+	// incident is ongoing
 	// +optional
-	Error string `json:"error,omitempty"`
+	RecoveredAt *metav1.Time `json:"recoveredAt,omitempty"`
 }
 
-// AIAnalysisResult contains AI-specific analysis results
-type AIAnalysisResult struct {
-	// Model is the AI model used for analysis
-	Model string `json:"model,omitempty"`
+// WorkloadBaseline is the learned restart and non-ready blip rate of a workload
+type WorkloadBaseline struct {
+	// Namespace is the namespace of the workload
+	Namespace string `json:"namespace"`
 
-	// RootCause is the root cause identified by AI
-	RootCause string `json:"rootCause,omitempty"`
+	// Workload is the owner of the pods as kind/name, or Pod/name for a pod without owner
+	Workload string `json:"workload"`
 
-	// Confidence is the confidence level (0-100) from AI analysis
-	Confidence int32 `json:"confidence,omitempty"`
+	// Samples is the number of intervals learned
+	Samples int32 `json:"samples"`
 
-	// Error contains any error message if AI analysis failed
+	// Restarts and Blips are the moving average and variance of the container restarts
+	// and of the pods turning non-ready per interval
+	Restarts BaselineRate `json:"restarts"`
+	Blips    BaselineRate `json:"blips"`
+
+	// IntervalStart is when the current interval started
+	IntervalStart metav1.Time `json:"intervalStart"`
+
+	// IntervalRestarts and IntervalBlips are the counts of the current interval
 	// +optional
-	Error string `json:"error,omitempty"`
+	IntervalRestarts int32 `json:"intervalRestarts,omitempty"`
+	// +optional
+	IntervalBlips int32 `json:"intervalBlips,omitempty"`
+
+	// Anomalous is set while the counts of the current interval deviate from the baseline
+	// +optional
+	Anomalous bool `json:"anomalous,omitempty"`
 }
 
-// LogAnalysisResult contains results from log analysis
-type LogAnalysisResult struct {
-	// RootCause is the identified root cause from log analysis (merged from all methods)
-	RootCause string `json:"rootCause,omitempty"`
+// BaselineRate is the exponentially weighted moving average and variance of a count
+type BaselineRate struct {
+	// Mean is the moving average, as a decimal number
+	Mean string `json:"mean"`
 
-	// Confidence is the confidence level (0-100) of the analysis (merged from all methods)
-	Confidence int32 `json:"confidence,omitempty"`
+	// Variance is the moving variance, as a decimal number
+	Variance string `json:"variance"`
+}
 
-	// Method used for analysis: "pattern" or "ai"
-	// Deprecated: Use Methods instead for multiple method support
-	Method string `json:"method,omitempty"`
+// WorkloadContext describes the replica and autoscaling state of a workload owning non-ready pods
+type WorkloadContext struct {
+	// Kind is the kind of the workload
+	Kind string `json:"kind"`
 
-	// Methods used for analysis in execution order (e.g., ["pattern", "ai"])
-	Methods []string `json:"methods,omitempty"`
+	// Name is the name of the workload
+	Name string `json:"name"`
 
-	// MatchedPattern is the name of the pattern that matched (for pattern analysis)
-	// Used internally, prefer PatternResult.MatchedPattern
+	// Namespace is the namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// NonReadyPods is the number of non-ready pods of the workload
+	NonReadyPods int32 `json:"nonReadyPods"`
+
+	// DesiredReplicas is the number of replicas in the workload spec
 	// +optional
-	MatchedPattern string `json:"matchedPattern,omitempty"`
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
 
-	// Priority is the priority of the matched pattern (for pattern analysis)
-	// Used internally, prefer PatternResult.Priority
+	// ReadyReplicas is the number of ready replicas
 	// +optional
-	Priority int32 `json:"priority,omitempty"`
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 
-	// Model is the AI model used (for AI analysis)
-	// Used internally, prefer AIResult.Model
+	// HPA is the autoscaler targeting the workload, if any
 	// +optional
-	Model string `json:"model,omitempty"`
+	HPA *HPAStatus `json:"hpa,omitempty"`
 
-	// PatternResult contains pattern-specific analysis details
+	// Signals are the failure reasons seen across the workload's non-ready pods (e.g. OOMKilled)
 	// +optional
-	PatternResult *PatternAnalysisResult `json:"patternResult,omitempty"`
+	Signals []string `json:"signals,omitempty"`
 
-	// AIResult contains AI-specific analysis details
+	// Summary describes the replica pressure in one sentence
 	// +optional
-	AIResult *AIAnalysisResult `json:"aiResult,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
 
-	// ErrorLines contains the error lines that led to this conclusion
-	ErrorLines []string `json:"errorLines,omitempty"`
+// PendingRemediation is a remediation action waiting for approval
+type PendingRemediation struct {
+	// ID identifies the action in approval and rejection annotations
+	ID string `json:"id"`
 
-	// AnalyzedAt is when the analysis was performed
-	AnalyzedAt metav1.Time `json:"analyzedAt,omitempty"`
+	// Rule is the name of the remediation rule
+	Rule string `json:"rule"`
 
-	// CachedAt is when the result was cached (if caching is enabled)
+	// Action is the remediation action, e.g. RestartPod or Rollback
+	Action string `json:"action"`
+
+	// Namespace of the pod
+	Namespace string `json:"namespace"`
+
+	// Pod is the name of the pod the action is proposed for
+	Pod string `json:"pod"`
+
+	// OwnerKind is the kind of the workload owning the pod
 	// +optional
-	CachedAt metav1.Time `json:"cachedAt,omitempty"`
+	OwnerKind string `json:"ownerKind,omitempty"`
 
-	// CacheExpiresAt is when the cached result will expire (if caching is enabled)
+	// OwnerName is the name of the workload owning the pod
 	// +optional
-	CacheExpiresAt *metav1.Time `json:"cacheExpiresAt,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+
+	// Reason is why the pod is not ready
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// RequestedAt is when the action was proposed
+	RequestedAt metav1.Time `json:"requestedAt"`
 }
 
-// NonReadyPodInfo contains information about a non-ready pod
-type NonReadyPodInfo struct {
-	// Name is the name of the pod
-	Name string `json:"name"`
+// RemediationRecord describes a remediation action taken on a pod
+type RemediationRecord struct {
+	// Time is when the action was taken
+	Time metav1.Time `json:"time"`
 
-	// Namespace is the namespace of the pod
+	// Rule is the name of the remediation rule
+	Rule string `json:"rule"`
+
+	// Action is the remediation action, e.g. RestartPod or Rollback
+	Action string `json:"action"`
+
+	// Namespace of the pod
 	Namespace string `json:"namespace"`
 
-	// Phase is the current phase of the pod (Pending, Running, Failed, etc.)
-	Phase string `json:"phase"`
+	// Pod is the name of the pod the action was taken for
+	Pod string `json:"pod"`
 
-	// OwnerKind is the kind of the owner (Deployment or StatefulSet)
+	// OwnerKind is the kind of the workload owning the pod
 	// +optional
 	OwnerKind string `json:"ownerKind,omitempty"`
 
-	// OwnerName is the name of the owner
+	// OwnerName is the name of the workload owning the pod
 	// +optional
 	OwnerName string `json:"ownerName,omitempty"`
 
-	// Reason is the primary reason why the pod is not ready (from container status investigation)
+	// Node is the node a CordonNode action was taken on
 	// +optional
-	Reason string `json:"reason,omitempty"`
+	Node string `json:"node,omitempty"`
 
-	// Message is the detailed message explaining why the pod is not ready
+	// Reason is why the pod was not ready when the action was taken
 	// +optional
-	Message string `json:"message,omitempty"`
+	Reason string `json:"reason,omitempty"`
 
-	// ContainerErrors contains detailed error information for each unready container
-	// +optional
-	ContainerErrors []ContainerError `json:"containerErrors,omitempty"`
+	// Result is Succeeded, Failed, Rejected, Proposed (sent to the notification sinks
+	// instead of taken) or DryRun (the action was only described)
+	Result string `json:"result"`
 
-	// PodConditions contains all pod conditions for comprehensive status
+	// Actor is who took the action: kubesleuth for automatic actions, or who approved
+	// or rejected it for rules requiring approval
 	// +optional
-	PodConditions []PodCondition `json:"podConditions,omitempty"`
+	Actor string `json:"actor,omitempty"`
 
-	// LogAnalysis contains results from log analysis if enabled
+	// Message describes the action or why it failed
 	// +optional
-	LogAnalysis *LogAnalysisResult `json:"logAnalysis,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 // PodSleuthStatus defines the observed state of PodSleuth
@@ -414,6 +2533,45 @@ type PodSleuthStatus struct {
 	// +optional
 	NonReadyPods []NonReadyPodInfo `json:"nonReadyPods,omitempty"`
 
+	// EvictedPods groups pods that were evicted or shut down by their node, per node.
+	// These pods are tracked separately from NonReadyPods.
+	// +optional
+	EvictedPods []EvictedPodGroup `json:"evictedPods,omitempty"`
+
+	// Workloads describes the replica and autoscaling state of the workloads that own non-ready pods
+	// +optional
+	Workloads []WorkloadContext `json:"workloads,omitempty"`
+
+	// ActiveMaintenanceWindows lists the maintenance windows active at the last reconcile
+	// +optional
+	ActiveMaintenanceWindows []string `json:"activeMaintenanceWindows,omitempty"`
+
+	// Remediations is the audit trail of the most recent remediation actions, oldest
+	// first, bounded by spec.remediation.historyLimit
+	// +optional
+	Remediations []RemediationRecord `json:"remediations,omitempty"`
+
+	// PendingRemediations are actions of rules requiring approval that wait for it
+	// +optional
+	PendingRemediations []PendingRemediation `json:"pendingRemediations,omitempty"`
+
+	// RemediationLockout is set while automatic remediation is locked out because a
+	// budget was exceeded
+	// +optional
+	RemediationLockout *RemediationLockout `json:"remediationLockout,omitempty"`
+
+	// SLO holds the incidents tracked by spec.slo
+	// +optional
+	SLO *SLOStatus `json:"slo,omitempty"`
+
+	// Baselines are the restart and blip baselines learned by spec.anomalyDetection
+	// +optional
+	Baselines []WorkloadBaseline `json:"baselines,omitempty"`
+
+	// AIQuota counts the AI requests against spec.logAnalysis.aiQuota
+	// +optional
+	AIQuota *AIQuotaUsage `json:"aiQuota,omitempty"`
+
 	// conditions represent the current state of the PodSleuth resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodSleuthReportSpec holds the full analysis of a non-ready pod, whose entry in the
+// PodSleuth status only keeps the summary
+type PodSleuthReportSpec struct {
+	// PodSleuth is the name of the PodSleuth that reported the pod
+	// +required
+	PodSleuth string `json:"podSleuth"`
+
+	// Pod is the non-ready pod with all error lines, terminations and debug checks
+	// +required
+	Pod NonReadyPodInfo `json:"pod"`
+
+	// UpdatedAt is when the report last changed
+	// +optional
+	UpdatedAt metav1.Time `json:"updatedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=psr
+// +kubebuilder:printcolumn:name="PodSleuth",type=string,JSONPath=`.spec.podSleuth`
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.spec.pod.name`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.pod.reason`
+// +kubebuilder:printcolumn:name="Updated",type=date,JSONPath=`.spec.updatedAt`
+
+// PodSleuthReport is the detailed analysis of a non-ready pod, kept in the pod's
+// namespace so that the PodSleuth object stays small. Reports are deleted once their
+// pod is ready again, and with their PodSleuth.
+type PodSleuthReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec is the reported pod
+	// +required
+	Spec PodSleuthReportSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// PodSleuthReportList contains a list of PodSleuthReport
+type PodSleuthReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PodSleuthReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PodSleuthReport{}, &PodSleuthReportList{})
+}
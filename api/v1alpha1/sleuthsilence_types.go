@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SleuthSilenceSpec defines which non-ready pods are silenced and until when.
+// All specified matchers must match; at least one matcher is required.
+type SleuthSilenceSpec struct {
+	// Namespace matches pods in this namespace
+	// If empty, pods in all namespaces match
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// PodRegex is a regular expression matched against the pod name
+	// +optional
+	PodRegex string `json:"podRegex,omitempty"`
+
+	// Reason matches the pod reason or the reason of any of its container errors exactly
+	// (e.g. CrashLoopBackOff, ImagePullBackOff)
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Pattern is a regular expression matched against the pod message, the log analysis
+	// root cause and the name of the matched log pattern
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// ExpiresAt is when the silence stops applying
+	// +required
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// Comment explains why the issue is silenced
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// CreatedBy records who created the silence
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.spec.podRegex`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.spec.expiresAt`
+
+// SleuthSilence silences a known issue so matching non-ready pods are reported as
+// silenced instead of failing until the silence expires
+type SleuthSilence struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines which pods are silenced
+	// +required
+	Spec SleuthSilenceSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SleuthSilenceList contains a list of SleuthSilence
+type SleuthSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SleuthSilence `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SleuthSilence{}, &SleuthSilenceList{})
+}
@@ -29,6 +29,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AIAnalysisResult) DeepCopyInto(out *AIAnalysisResult) {
 	*out = *in
+	if in.FailedProviders != nil {
+		in, out := &in.FailedProviders, &out.FailedProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIAnalysisResult.
@@ -54,6 +59,16 @@ func (in *AIConfig) DeepCopyInto(out *AIConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReadTimeout != nil {
+		in, out := &in.ReadTimeout, &out.ReadTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIConfig.
@@ -67,374 +82,2539 @@ func (in *AIConfig) DeepCopy() *AIConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerError) DeepCopyInto(out *ContainerError) {
+func (in *AIQuotaConfig) DeepCopyInto(out *AIQuotaConfig) {
 	*out = *in
-	if in.ExitCode != nil {
-		in, out := &in.ExitCode, &out.ExitCode
+	if in.MaxPerHour != nil {
+		in, out := &in.MaxPerHour, &out.MaxPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPerDay != nil {
+		in, out := &in.MaxPerDay, &out.MaxPerDay
 		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerError.
-func (in *ContainerError) DeepCopy() *ContainerError {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIQuotaConfig.
+func (in *AIQuotaConfig) DeepCopy() *AIQuotaConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerError)
+	out := new(AIQuotaConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ErrorPattern) DeepCopyInto(out *ErrorPattern) {
+func (in *AIQuotaUsage) DeepCopyInto(out *AIQuotaUsage) {
 	*out = *in
+	in.HourStart.DeepCopyInto(&out.HourStart)
+	in.DayStart.DeepCopyInto(&out.DayStart)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorPattern.
-func (in *ErrorPattern) DeepCopy() *ErrorPattern {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIQuotaUsage.
+func (in *AIQuotaUsage) DeepCopy() *AIQuotaUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ErrorPattern)
+	out := new(AIQuotaUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogAnalysisConfig) DeepCopyInto(out *LogAnalysisConfig) {
+func (in *AIRateLimitConfig) DeepCopyInto(out *AIRateLimitConfig) {
 	*out = *in
-	if in.Methods != nil {
-		in, out := &in.Methods, &out.Methods
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.CacheEnabled != nil {
-		in, out := &in.CacheEnabled, &out.CacheEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CacheTTL != nil {
-		in, out := &in.CacheTTL, &out.CacheTTL
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.LinesToAnalyze != nil {
-		in, out := &in.LinesToAnalyze, &out.LinesToAnalyze
+	if in.RequestsPerMinute != nil {
+		in, out := &in.RequestsPerMinute, &out.RequestsPerMinute
 		*out = new(int32)
 		**out = **in
 	}
-	if in.FilterErrorsOnly != nil {
-		in, out := &in.FilterErrorsOnly, &out.FilterErrorsOnly
-		*out = new(bool)
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Patterns != nil {
-		in, out := &in.Patterns, &out.Patterns
-		*out = make([]ErrorPattern, len(*in))
-		copy(*out, *in)
-	}
-	if in.AIAPIKey != nil {
-		in, out := &in.AIAPIKey, &out.AIAPIKey
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MethodConfigs != nil {
-		in, out := &in.MethodConfigs, &out.MethodConfigs
-		*out = make([]MethodConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalysisConfig.
-func (in *LogAnalysisConfig) DeepCopy() *LogAnalysisConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIRateLimitConfig.
+func (in *AIRateLimitConfig) DeepCopy() *AIRateLimitConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(LogAnalysisConfig)
+	out := new(AIRateLimitConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogAnalysisResult) DeepCopyInto(out *LogAnalysisResult) {
+func (in *AnomalyDetectionConfig) DeepCopyInto(out *AnomalyDetectionConfig) {
 	*out = *in
-	if in.Methods != nil {
-		in, out := &in.Methods, &out.Methods
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.PatternResult != nil {
-		in, out := &in.PatternResult, &out.PatternResult
-		*out = new(PatternAnalysisResult)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.AIResult != nil {
-		in, out := &in.AIResult, &out.AIResult
-		*out = new(AIAnalysisResult)
+	if in.SmoothingPercent != nil {
+		in, out := &in.SmoothingPercent, &out.SmoothingPercent
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ErrorLines != nil {
-		in, out := &in.ErrorLines, &out.ErrorLines
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(int32)
+		**out = **in
 	}
-	in.AnalyzedAt.DeepCopyInto(&out.AnalyzedAt)
-	in.CachedAt.DeepCopyInto(&out.CachedAt)
-	if in.CacheExpiresAt != nil {
-		in, out := &in.CacheExpiresAt, &out.CacheExpiresAt
-		*out = (*in).DeepCopy()
+	if in.MaxBlipDuration != nil {
+		in, out := &in.MaxBlipDuration, &out.MaxBlipDuration
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalysisResult.
-func (in *LogAnalysisResult) DeepCopy() *LogAnalysisResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnomalyDetectionConfig.
+func (in *AnomalyDetectionConfig) DeepCopy() *AnomalyDetectionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(LogAnalysisResult)
+	out := new(AnomalyDetectionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MethodConfig) DeepCopyInto(out *MethodConfig) {
+func (in *AzureBlobDestination) DeepCopyInto(out *AzureBlobDestination) {
 	*out = *in
-	if in.PatternConfig != nil {
-		in, out := &in.PatternConfig, &out.PatternConfig
-		*out = new(PatternConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AIConfig != nil {
-		in, out := &in.AIConfig, &out.AIConfig
-		*out = new(AIConfig)
-		(*in).DeepCopyInto(*out)
-	}
+	in.SASTokenSecretRef.DeepCopyInto(&out.SASTokenSecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MethodConfig.
-func (in *MethodConfig) DeepCopy() *MethodConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBlobDestination.
+func (in *AzureBlobDestination) DeepCopy() *AzureBlobDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(MethodConfig)
+	out := new(AzureBlobDestination)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NonReadyPodInfo) DeepCopyInto(out *NonReadyPodInfo) {
+func (in *BaselineRate) DeepCopyInto(out *BaselineRate) {
 	*out = *in
-	if in.ContainerErrors != nil {
-		in, out := &in.ContainerErrors, &out.ContainerErrors
-		*out = make([]ContainerError, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PodConditions != nil {
-		in, out := &in.PodConditions, &out.PodConditions
-		*out = make([]PodCondition, len(*in))
-		copy(*out, *in)
-	}
-	if in.LogAnalysis != nil {
-		in, out := &in.LogAnalysis, &out.LogAnalysis
-		*out = new(LogAnalysisResult)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NonReadyPodInfo.
-func (in *NonReadyPodInfo) DeepCopy() *NonReadyPodInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineRate.
+func (in *BaselineRate) DeepCopy() *BaselineRate {
 	if in == nil {
 		return nil
 	}
-	out := new(NonReadyPodInfo)
+	out := new(BaselineRate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PatternAnalysisResult) DeepCopyInto(out *PatternAnalysisResult) {
+func (in *CertificateAnalysisResult) DeepCopyInto(out *CertificateAnalysisResult) {
 	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateFinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternAnalysisResult.
-func (in *PatternAnalysisResult) DeepCopy() *PatternAnalysisResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAnalysisResult.
+func (in *CertificateAnalysisResult) DeepCopy() *CertificateAnalysisResult {
 	if in == nil {
 		return nil
 	}
-	out := new(PatternAnalysisResult)
+	out := new(CertificateAnalysisResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PatternConfig) DeepCopyInto(out *PatternConfig) {
+func (in *CertificateCheckConfig) DeepCopyInto(out *CertificateCheckConfig) {
 	*out = *in
-	if in.Patterns != nil {
-		in, out := &in.Patterns, &out.Patterns
-		*out = make([]ErrorPattern, len(*in))
-		copy(*out, *in)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternConfig.
-func (in *PatternConfig) DeepCopy() *PatternConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCheckConfig.
+func (in *CertificateCheckConfig) DeepCopy() *CertificateCheckConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PatternConfig)
+	out := new(CertificateCheckConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PatternError) DeepCopyInto(out *PatternError) {
+func (in *CertificateFinding) DeepCopyInto(out *CertificateFinding) {
 	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternError.
-func (in *PatternError) DeepCopy() *PatternError {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateFinding.
+func (in *CertificateFinding) DeepCopy() *CertificateFinding {
 	if in == nil {
 		return nil
 	}
-	out := new(PatternError)
+	out := new(CertificateFinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCondition) DeepCopyInto(out *PodCondition) {
+func (in *CloudEventsSink) DeepCopyInto(out *CloudEventsSink) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCondition.
-func (in *PodCondition) DeepCopy() *PodCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsSink.
+func (in *CloudEventsSink) DeepCopy() *CloudEventsSink {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCondition)
+	out := new(CloudEventsSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSleuth) DeepCopyInto(out *PodSleuth) {
+func (in *ConfidenceConfig) DeepCopyInto(out *ConfidenceConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.AIPreferredAbove != nil {
+		in, out := &in.AIPreferredAbove, &out.AIPreferredAbove
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternPreferredBelow != nil {
+		in, out := &in.PatternPreferredBelow, &out.PatternPreferredBelow
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AIWeight != nil {
+		in, out := &in.AIWeight, &out.AIWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternWeight != nil {
+		in, out := &in.PatternWeight, &out.PatternWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternNoMatch != nil {
+		in, out := &in.PatternNoMatch, &out.PatternNoMatch
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternSingleMatch != nil {
+		in, out := &in.PatternSingleMatch, &out.PatternSingleMatch
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternTwoMatches != nil {
+		in, out := &in.PatternTwoMatches, &out.PatternTwoMatches
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PatternManyMatches != nil {
+		in, out := &in.PatternManyMatches, &out.PatternManyMatches
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuth.
-func (in *PodSleuth) DeepCopy() *PodSleuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfidenceConfig.
+func (in *ConfidenceConfig) DeepCopy() *ConfidenceConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSleuth)
+	out := new(ConfidenceConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSleuth) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSleuthList) DeepCopyInto(out *PodSleuthList) {
+func (in *ConfigAnalysisResult) DeepCopyInto(out *ConfigAnalysisResult) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PodSleuth, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]MissingConfigReference, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthList.
-func (in *PodSleuthList) DeepCopy() *PodSleuthList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigAnalysisResult.
+func (in *ConfigAnalysisResult) DeepCopy() *ConfigAnalysisResult {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSleuthList)
+	out := new(ConfigAnalysisResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSleuthList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSleuthSpec) DeepCopyInto(out *PodSleuthSpec) {
+func (in *ConfigCheckConfig) DeepCopyInto(out *ConfigCheckConfig) {
 	*out = *in
-	if in.ReconcileInterval != nil {
-		in, out := &in.ReconcileInterval, &out.ReconcileInterval
-		*out = new(v1.Duration)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
 		**out = **in
 	}
-	if in.PodLabelSelector != nil {
-		in, out := &in.PodLabelSelector, &out.PodLabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LogAnalysis != nil {
-		in, out := &in.LogAnalysis, &out.LogAnalysis
-		*out = new(LogAnalysisConfig)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthSpec.
-func (in *PodSleuthSpec) DeepCopy() *PodSleuthSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCheckConfig.
+func (in *ConfigCheckConfig) DeepCopy() *ConfigCheckConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSleuthSpec)
+	out := new(ConfigCheckConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSleuthStatus) DeepCopyInto(out *PodSleuthStatus) {
+func (in *ConnectivityCheckConfig) DeepCopyInto(out *ConnectivityCheckConfig) {
 	*out = *in
-	if in.NonReadyPods != nil {
-		in, out := &in.NonReadyPods, &out.NonReadyPods
-		*out = make([]NonReadyPodInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxTargets != nil {
+		in, out := &in.MaxTargets, &out.MaxTargets
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityCheckConfig.
+func (in *ConnectivityCheckConfig) DeepCopy() *ConnectivityCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityResult) DeepCopyInto(out *ConnectivityResult) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reachable != nil {
+		in, out := &in.Reachable, &out.Reachable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockingNetworkPolicies != nil {
+		in, out := &in.BlockingNetworkPolicies, &out.BlockingNetworkPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityResult.
+func (in *ConnectivityResult) DeepCopy() *ConnectivityResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerError) DeepCopyInto(out *ContainerError) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerError.
+func (in *ContainerError) DeepCopy() *ContainerError {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopTrend) DeepCopyInto(out *CrashLoopTrend) {
+	*out = *in
+	if in.DominantExitCode != nil {
+		in, out := &in.DominantExitCode, &out.DominantExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Terminations != nil {
+		in, out := &in.Terminations, &out.Terminations
+		*out = make([]TerminationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopTrend.
+func (in *CrashLoopTrend) DeepCopy() *CrashLoopTrend {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopTrend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopTrendConfig) DeepCopyInto(out *CrashLoopTrendConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinRestarts != nil {
+		in, out := &in.MinRestarts, &out.MinRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopTrendConfig.
+func (in *CrashLoopTrendConfig) DeepCopy() *CrashLoopTrendConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopTrendConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugCheck) DeepCopyInto(out *DebugCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugCheck.
+func (in *DebugCheck) DeepCopy() *DebugCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugDiagnosticsConfig) DeepCopyInto(out *DebugDiagnosticsConfig) {
+	*out = *in
+	if in.MaxTargets != nil {
+		in, out := &in.MaxTargets, &out.MaxTargets
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugDiagnosticsConfig.
+func (in *DebugDiagnosticsConfig) DeepCopy() *DebugDiagnosticsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugDiagnosticsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugDiagnosticsResult) DeepCopyInto(out *DebugDiagnosticsResult) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]DebugCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugDiagnosticsResult.
+func (in *DebugDiagnosticsResult) DeepCopy() *DebugDiagnosticsResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugDiagnosticsResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailSink) DeepCopyInto(out *EmailSink) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DigestInterval != nil {
+		in, out := &in.DigestInterval, &out.DigestInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailSink.
+func (in *EmailSink) DeepCopy() *EmailSink {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorPattern) DeepCopyInto(out *ErrorPattern) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorPattern.
+func (in *ErrorPattern) DeepCopy() *ErrorPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventStreamConfig) DeepCopyInto(out *EventStreamConfig) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = make([]KafkaSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NATS != nil {
+		in, out := &in.NATS, &out.NATS
+		*out = make([]NATSSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = make([]CloudEventsSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventStreamConfig.
+func (in *EventStreamConfig) DeepCopy() *EventStreamConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventStreamConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventsConfig) DeepCopyInto(out *EventsConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsConfig.
+func (in *EventsConfig) DeepCopy() *EventsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictedPodGroup) DeepCopyInto(out *EvictedPodGroup) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]EvictedPodInfo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictedPodGroup.
+func (in *EvictedPodGroup) DeepCopy() *EvictedPodGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictedPodGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictedPodInfo) DeepCopyInto(out *EvictedPodInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictedPodInfo.
+func (in *EvictedPodInfo) DeepCopy() *EvictedPodInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictedPodInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSDestination) DeepCopyInto(out *GCSDestination) {
+	*out = *in
+	in.AccessIDSecretRef.DeepCopyInto(&out.AccessIDSecretRef)
+	in.SecretSecretRef.DeepCopyInto(&out.SecretSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSDestination.
+func (in *GCSDestination) DeepCopy() *GCSDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubIssueConfig) DeepCopyInto(out *GitHubIssueConfig) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubIssueConfig.
+func (in *GitHubIssueConfig) DeepCopy() *GitHubIssueConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubIssueConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsConfig) DeepCopyInto(out *GitOpsConfig) {
+	*out = *in
+	if in.Annotate != nil {
+		in, out := &in.Annotate, &out.Annotate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsConfig.
+func (in *GitOpsConfig) DeepCopy() *GitOpsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaAnnotationsConfig) DeepCopyInto(out *GrafanaAnnotationsConfig) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaAnnotationsConfig.
+func (in *GrafanaAnnotationsConfig) DeepCopy() *GrafanaAnnotationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaAnnotationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HPAStatus) DeepCopyInto(out *HPAStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPAStatus.
+func (in *HPAStatus) DeepCopy() *HPAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HPAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitContainerAnalysisResult) DeepCopyInto(out *InitContainerAnalysisResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerAnalysisResult.
+func (in *InitContainerAnalysisResult) DeepCopy() *InitContainerAnalysisResult {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainerAnalysisResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssueTrackingConfig) DeepCopyInto(out *IssueTrackingConfig) {
+	*out = *in
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Jira != nil {
+		in, out := &in.Jira, &out.Jira
+		*out = new(JiraIssueConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubIssueConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssueTrackingConfig.
+func (in *IssueTrackingConfig) DeepCopy() *IssueTrackingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IssueTrackingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JiraIssueConfig) DeepCopyInto(out *JiraIssueConfig) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraIssueConfig.
+func (in *JiraIssueConfig) DeepCopy() *JiraIssueConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JiraIssueConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASLConfig) DeepCopyInto(out *KafkaSASLConfig) {
+	*out = *in
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLConfig.
+func (in *KafkaSASLConfig) DeepCopy() *KafkaSASLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSink) DeepCopyInto(out *KafkaSink) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(StreamTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSink.
+func (in *KafkaSink) DeepCopy() *KafkaSink {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogAnalysisConfig) DeepCopyInto(out *LogAnalysisConfig) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheEnabled != nil {
+		in, out := &in.CacheEnabled, &out.CacheEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NegativeCacheTTL != nil {
+		in, out := &in.NegativeCacheTTL, &out.NegativeCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LinesToAnalyze != nil {
+		in, out := &in.LinesToAnalyze, &out.LinesToAnalyze
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxLogBytes != nil {
+		in, out := &in.MaxLogBytes, &out.MaxLogBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxLineLength != nil {
+		in, out := &in.MaxLineLength, &out.MaxLineLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FilterErrorsOnly != nil {
+		in, out := &in.FilterErrorsOnly, &out.FilterErrorsOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]ErrorPattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.AIAPIKey != nil {
+		in, out := &in.AIAPIKey, &out.AIAPIKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MethodConfigs != nil {
+		in, out := &in.MethodConfigs, &out.MethodConfigs
+		*out = make([]MethodConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AIRateLimit != nil {
+		in, out := &in.AIRateLimit, &out.AIRateLimit
+		*out = new(AIRateLimitConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIQuota != nil {
+		in, out := &in.AIQuota, &out.AIQuota
+		*out = new(AIQuotaConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redaction != nil {
+		in, out := &in.Redaction, &out.Redaction
+		*out = new(RedactionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchAIRequests != nil {
+		in, out := &in.BatchAIRequests, &out.BatchAIRequests
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Confidence != nil {
+		in, out := &in.Confidence, &out.Confidence
+		*out = new(ConfidenceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateCheck != nil {
+		in, out := &in.CertificateCheck, &out.CertificateCheck
+		*out = new(CertificateCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigCheck != nil {
+		in, out := &in.ConfigCheck, &out.ConfigCheck
+		*out = new(ConfigCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalysisConfig.
+func (in *LogAnalysisConfig) DeepCopy() *LogAnalysisConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogAnalysisConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogAnalysisResult) DeepCopyInto(out *LogAnalysisResult) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PatternResult != nil {
+		in, out := &in.PatternResult, &out.PatternResult
+		*out = new(PatternAnalysisResult)
+		**out = **in
+	}
+	if in.AIResult != nil {
+		in, out := &in.AIResult, &out.AIResult
+		*out = new(AIAnalysisResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsResult != nil {
+		in, out := &in.MetricsResult, &out.MetricsResult
+		*out = new(MetricsAnalysisResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateResult != nil {
+		in, out := &in.CertificateResult, &out.CertificateResult
+		*out = new(CertificateAnalysisResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainerResult != nil {
+		in, out := &in.InitContainerResult, &out.InitContainerResult
+		*out = new(InitContainerAnalysisResult)
+		**out = **in
+	}
+	if in.ConfigResult != nil {
+		in, out := &in.ConfigResult, &out.ConfigResult
+		*out = new(ConfigAnalysisResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ErrorLines != nil {
+		in, out := &in.ErrorLines, &out.ErrorLines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.AnalyzedAt.DeepCopyInto(&out.AnalyzedAt)
+	in.CachedAt.DeepCopyInto(&out.CachedAt)
+	if in.CacheExpiresAt != nil {
+		in, out := &in.CacheExpiresAt, &out.CacheExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalysisResult.
+func (in *LogAnalysisResult) DeepCopy() *LogAnalysisResult {
+	if in == nil {
+		return nil
+	}
+	out := new(LogAnalysisResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryIncrease) DeepCopyInto(out *MemoryIncrease) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	out.MaxLimit = in.MaxLimit.DeepCopy()
+	if in.MinOOMKills != nil {
+		in, out := &in.MinOOMKills, &out.MinOOMKills
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryIncrease.
+func (in *MemoryIncrease) DeepCopy() *MemoryIncrease {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryIncrease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshDiagnosis) DeepCopyInto(out *MeshDiagnosis) {
+	*out = *in
+	if in.Issues != nil {
+		in, out := &in.Issues, &out.Issues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDiagnosis.
+func (in *MeshDiagnosis) DeepCopy() *MeshDiagnosis {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshDiagnosis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MethodConfig) DeepCopyInto(out *MethodConfig) {
+	*out = *in
+	if in.PatternConfig != nil {
+		in, out := &in.PatternConfig, &out.PatternConfig
+		*out = new(PatternConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIConfig != nil {
+		in, out := &in.AIConfig, &out.AIConfig
+		*out = new(AIConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIFallbacks != nil {
+		in, out := &in.AIFallbacks, &out.AIFallbacks
+		*out = make([]AIConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsConfig != nil {
+		in, out := &in.MetricsConfig, &out.MetricsConfig
+		*out = new(MetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MethodConfig.
+func (in *MethodConfig) DeepCopy() *MethodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MethodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricFinding) DeepCopyInto(out *MetricFinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricFinding.
+func (in *MetricFinding) DeepCopy() *MetricFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricQuery) DeepCopyInto(out *MetricQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricQuery.
+func (in *MetricQuery) DeepCopy() *MetricQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAnalysisResult) DeepCopyInto(out *MetricsAnalysisResult) {
+	*out = *in
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]MetricFinding, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAnalysisResult.
+func (in *MetricsAnalysisResult) DeepCopy() *MetricsAnalysisResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsAnalysisResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfig) DeepCopyInto(out *MetricsConfig) {
+	*out = *in
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]MetricQuery, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfig.
+func (in *MetricsConfig) DeepCopy() *MetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MissingConfigReference) DeepCopyInto(out *MissingConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MissingConfigReference.
+func (in *MissingConfigReference) DeepCopy() *MissingConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(MissingConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSSink) DeepCopyInto(out *NATSSink) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(StreamTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSSink.
+func (in *NATSSink) DeepCopy() *NATSSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCordonPolicy) DeepCopyInto(out *NodeCordonPolicy) {
+	*out = *in
+	if in.MinPods != nil {
+		in, out := &in.MinPods, &out.MinPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinNonReadyDuration != nil {
+		in, out := &in.MinNonReadyDuration, &out.MinNonReadyDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxCordonedNodes != nil {
+		in, out := &in.MaxCordonedNodes, &out.MaxCordonedNodes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCordonPolicy.
+func (in *NodeCordonPolicy) DeepCopy() *NodeCordonPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCordonPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NonReadyPodInfo) DeepCopyInto(out *NonReadyPodInfo) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DetectedAt != nil {
+		in, out := &in.DetectedAt, &out.DetectedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NotReadySince != nil {
+		in, out := &in.NotReadySince, &out.NotReadySince
+		*out = (*in).DeepCopy()
+	}
+	if in.ContainerErrors != nil {
+		in, out := &in.ContainerErrors, &out.ContainerErrors
+		*out = make([]ContainerError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodConditions != nil {
+		in, out := &in.PodConditions, &out.PodConditions
+		*out = make([]PodCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogAnalysis != nil {
+		in, out := &in.LogAnalysis, &out.LogAnalysis
+		*out = new(LogAnalysisResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashLoopTrend != nil {
+		in, out := &in.CrashLoopTrend, &out.CrashLoopTrend
+		*out = new(CrashLoopTrend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mesh != nil {
+		in, out := &in.Mesh, &out.Mesh
+		*out = new(MeshDiagnosis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DebugDiagnostics != nil {
+		in, out := &in.DebugDiagnostics, &out.DebugDiagnostics
+		*out = new(DebugDiagnosticsResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connectivity != nil {
+		in, out := &in.Connectivity, &out.Connectivity
+		*out = make([]ConnectivityResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Reserved != nil {
+		in, out := &in.Reserved, &out.Reserved
+		*out = new(ReservedResources)
+		**out = **in
+	}
+	if in.Acknowledged != nil {
+		in, out := &in.Acknowledged, &out.Acknowledged
+		*out = new(PodAcknowledgement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NonReadyPodInfo.
+func (in *NonReadyPodInfo) DeepCopy() *NonReadyPodInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NonReadyPodInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationPolicy) DeepCopyInto(out *NotificationPolicy) {
+	*out = *in
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RepeatInterval != nil {
+		in, out := &in.RepeatInterval, &out.RepeatInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPolicy.
+func (in *NotificationPolicy) DeepCopy() *NotificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfig) DeepCopyInto(out *NotificationsConfig) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = make([]EmailSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]NotificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfig.
+func (in *NotificationsConfig) DeepCopy() *NotificationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipRule) DeepCopyInto(out *OwnershipRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipRule.
+func (in *OwnershipRule) DeepCopy() *OwnershipRule {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternAnalysisResult) DeepCopyInto(out *PatternAnalysisResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternAnalysisResult.
+func (in *PatternAnalysisResult) DeepCopy() *PatternAnalysisResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternAnalysisResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternConfig) DeepCopyInto(out *PatternConfig) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]ErrorPattern, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternConfig.
+func (in *PatternConfig) DeepCopy() *PatternConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternError) DeepCopyInto(out *PatternError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternError.
+func (in *PatternError) DeepCopy() *PatternError {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRemediation) DeepCopyInto(out *PendingRemediation) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRemediation.
+func (in *PendingRemediation) DeepCopy() *PendingRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAcknowledgement) DeepCopyInto(out *PodAcknowledgement) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAcknowledgement.
+func (in *PodAcknowledgement) DeepCopy() *PodAcknowledgement {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAcknowledgement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCondition) DeepCopyInto(out *PodCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCondition.
+func (in *PodCondition) DeepCopy() *PodCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuth) DeepCopyInto(out *PodSleuth) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuth.
+func (in *PodSleuth) DeepCopy() *PodSleuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSleuth) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthList) DeepCopyInto(out *PodSleuthList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodSleuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthList.
+func (in *PodSleuthList) DeepCopy() *PodSleuthList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSleuthList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthReport) DeepCopyInto(out *PodSleuthReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthReport.
+func (in *PodSleuthReport) DeepCopy() *PodSleuthReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSleuthReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthReportList) DeepCopyInto(out *PodSleuthReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodSleuthReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthReportList.
+func (in *PodSleuthReportList) DeepCopy() *PodSleuthReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSleuthReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthReportSpec) DeepCopyInto(out *PodSleuthReportSpec) {
+	*out = *in
+	in.Pod.DeepCopyInto(&out.Pod)
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthReportSpec.
+func (in *PodSleuthReportSpec) DeepCopy() *PodSleuthReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthSpec) DeepCopyInto(out *PodSleuthSpec) {
+	*out = *in
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PodLabelSelector != nil {
+		in, out := &in.PodLabelSelector, &out.PodLabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogAnalysis != nil {
+		in, out := &in.LogAnalysis, &out.LogAnalysis
+		*out = new(LogAnalysisConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashLoopTrend != nil {
+		in, out := &in.CrashLoopTrend, &out.CrashLoopTrend
+		*out = new(CrashLoopTrendConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DebugDiagnostics != nil {
+		in, out := &in.DebugDiagnostics, &out.DebugDiagnostics
+		*out = new(DebugDiagnosticsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectivityCheck != nil {
+		in, out := &in.ConnectivityCheck, &out.ConnectivityCheck
+		*out = new(ConnectivityCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OwnershipRules != nil {
+		in, out := &in.OwnershipRules, &out.OwnershipRules
+		*out = make([]OwnershipRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(EventsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventStream != nil {
+		in, out := &in.EventStream, &out.EventStream
+		*out = new(EventStreamConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssueTracking != nil {
+		in, out := &in.IssueTracking, &out.IssueTracking
+		*out = new(IssueTrackingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrafanaAnnotations != nil {
+		in, out := &in.GrafanaAnnotations, &out.GrafanaAnnotations
+		*out = new(GrafanaAnnotationsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotExport != nil {
+		in, out := &in.SnapshotExport, &out.SnapshotExport
+		*out = new(SnapshotExportConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatusLimits != nil {
+		in, out := &in.StatusLimits, &out.StatusLimits
+		*out = new(StatusLimitsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = new(ReportsConfig)
+		**out = **in
+	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = new(SLOConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnomalyDetection != nil {
+		in, out := &in.AnomalyDetection, &out.AnomalyDetection
+		*out = new(AnomalyDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthSpec.
+func (in *PodSleuthSpec) DeepCopy() *PodSleuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSleuthStatus) DeepCopyInto(out *PodSleuthStatus) {
+	*out = *in
+	if in.NonReadyPods != nil {
+		in, out := &in.NonReadyPods, &out.NonReadyPods
+		*out = make([]NonReadyPodInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EvictedPods != nil {
+		in, out := &in.EvictedPods, &out.EvictedPods
+		*out = make([]EvictedPodGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadContext, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActiveMaintenanceWindows != nil {
+		in, out := &in.ActiveMaintenanceWindows, &out.ActiveMaintenanceWindows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]RemediationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingRemediations != nil {
+		in, out := &in.PendingRemediations, &out.PendingRemediations
+		*out = make([]PendingRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemediationLockout != nil {
+		in, out := &in.RemediationLockout, &out.RemediationLockout
+		*out = new(RemediationLockout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = new(SLOStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Baselines != nil {
+		in, out := &in.Baselines, &out.Baselines
+		*out = make([]WorkloadBaseline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AIQuota != nil {
+		in, out := &in.AIQuota, &out.AIQuota
+		*out = new(AIQuotaUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthStatus.
+func (in *PodSleuthStatus) DeepCopy() *PodSleuthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSleuthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionConfig) DeepCopyInto(out *RedactionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Detectors != nil {
+		in, out := &in.Detectors, &out.Detectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomPatterns != nil {
+		in, out := &in.CustomPatterns, &out.CustomPatterns
+		*out = make([]RedactionPattern, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionConfig.
+func (in *RedactionConfig) DeepCopy() *RedactionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPattern) DeepCopyInto(out *RedactionPattern) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPattern.
+func (in *RedactionPattern) DeepCopy() *RedactionPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudget) DeepCopyInto(out *RemediationBudget) {
+	*out = *in
+	if in.MaxActionsPerHour != nil {
+		in, out := &in.MaxActionsPerHour, &out.MaxActionsPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxActionsPerNamespacePerHour != nil {
+		in, out := &in.MaxActionsPerNamespacePerHour, &out.MaxActionsPerNamespacePerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxWorkloadPercent != nil {
+		in, out := &in.MaxWorkloadPercent, &out.MaxWorkloadPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LockoutDuration != nil {
+		in, out := &in.LockoutDuration, &out.LockoutDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudget.
+func (in *RemediationBudget) DeepCopy() *RemediationBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationJob) DeepCopyInto(out *RemediationJob) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationJob.
+func (in *RemediationJob) DeepCopy() *RemediationJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationLockout) DeepCopyInto(out *RemediationLockout) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationLockout.
+func (in *RemediationLockout) DeepCopy() *RemediationLockout {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationLockout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationPolicy) DeepCopyInto(out *RemediationPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RemediationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RemediationBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCordon != nil {
+		in, out := &in.NodeCordon, &out.NodeCordon
+		*out = new(NodeCordonPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationPolicy.
+func (in *RemediationPolicy) DeepCopy() *RemediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRecord) DeepCopyInto(out *RemediationRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRecord.
+func (in *RemediationRecord) DeepCopy() *RemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRule) DeepCopyInto(out *RemediationRule) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinNonReadyDuration != nil {
+		in, out := &in.MinNonReadyDuration, &out.MinNonReadyDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxActionsPerWorkload != nil {
+		in, out := &in.MaxActionsPerWorkload, &out.MaxActionsPerWorkload
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RemediationJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryIncrease != nil {
+		in, out := &in.MemoryIncrease, &out.MemoryIncrease
+		*out = new(MemoryIncrease)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRule.
+func (in *RemediationRule) DeepCopy() *RemediationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportsConfig) DeepCopyInto(out *ReportsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportsConfig.
+func (in *ReportsConfig) DeepCopy() *ReportsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedResources) DeepCopyInto(out *ReservedResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedResources.
+func (in *ReservedResources) DeepCopy() *ReservedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Destination) DeepCopyInto(out *S3Destination) {
+	*out = *in
+	in.AccessKeyIDSecretRef.DeepCopyInto(&out.AccessKeyIDSecretRef)
+	in.SecretAccessKeySecretRef.DeepCopyInto(&out.SecretAccessKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Destination.
+func (in *S3Destination) DeepCopy() *S3Destination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Destination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLOConfig) DeepCopyInto(out *SLOConfig) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLOConfig.
+func (in *SLOConfig) DeepCopy() *SLOConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SLOConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLOIncident) DeepCopyInto(out *SLOIncident) {
+	*out = *in
+	in.FailedAt.DeepCopyInto(&out.FailedAt)
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+	if in.RecoveredAt != nil {
+		in, out := &in.RecoveredAt, &out.RecoveredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLOIncident.
+func (in *SLOIncident) DeepCopy() *SLOIncident {
+	if in == nil {
+		return nil
+	}
+	out := new(SLOIncident)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLOStatus) DeepCopyInto(out *SLOStatus) {
+	*out = *in
+	if in.Incidents != nil {
+		in, out := &in.Incidents, &out.Incidents
+		*out = make([]SLOIncident, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.Breaching != nil {
+		in, out := &in.Breaching, &out.Breaching
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLOStatus.
+func (in *SLOStatus) DeepCopy() *SLOStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SLOStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleuthSilence) DeepCopyInto(out *SleuthSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleuthSilence.
+func (in *SleuthSilence) DeepCopy() *SleuthSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(SleuthSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleuthSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleuthSilenceList) DeepCopyInto(out *SleuthSilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SleuthSilence, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSleuthStatus.
-func (in *PodSleuthStatus) DeepCopy() *PodSleuthStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleuthSilenceList.
+func (in *SleuthSilenceList) DeepCopy() *SleuthSilenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSleuthStatus)
+	out := new(SleuthSilenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleuthSilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleuthSilenceSpec) DeepCopyInto(out *SleuthSilenceSpec) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleuthSilenceSpec.
+func (in *SleuthSilenceSpec) DeepCopy() *SleuthSilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SleuthSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotExportConfig) DeepCopyInto(out *SnapshotExportConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlobDestination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotExportConfig.
+func (in *SnapshotExportConfig) DeepCopy() *SnapshotExportConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotExportConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusLimitsConfig) DeepCopyInto(out *StatusLimitsConfig) {
+	*out = *in
+	if in.MaxErrorLineBytesPerPod != nil {
+		in, out := &in.MaxErrorLineBytesPerPod, &out.MaxErrorLineBytesPerPod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxErrorLineBytes != nil {
+		in, out := &in.MaxErrorLineBytes, &out.MaxErrorLineBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusLimitsConfig.
+func (in *StatusLimitsConfig) DeepCopy() *StatusLimitsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusLimitsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamTLSConfig) DeepCopyInto(out *StreamTLSConfig) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamTLSConfig.
+func (in *StreamTLSConfig) DeepCopy() *StreamTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationRecord) DeepCopyInto(out *TerminationRecord) {
+	*out = *in
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationRecord.
+func (in *TerminationRecord) DeepCopy() *TerminationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSink) DeepCopyInto(out *WebhookSink) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HMACSecretRef != nil {
+		in, out := &in.HMACSecretRef, &out.HMACSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSink.
+func (in *WebhookSink) DeepCopy() *WebhookSink {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadBaseline) DeepCopyInto(out *WorkloadBaseline) {
+	*out = *in
+	out.Restarts = in.Restarts
+	out.Blips = in.Blips
+	in.IntervalStart.DeepCopyInto(&out.IntervalStart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadBaseline.
+func (in *WorkloadBaseline) DeepCopy() *WorkloadBaseline {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadBaseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadContext) DeepCopyInto(out *WorkloadContext) {
+	*out = *in
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HPAStatus)
+		**out = **in
+	}
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadContext.
+func (in *WorkloadContext) DeepCopy() *WorkloadContext {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadContext)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// analyzeConfig holds the flags of the offline analyze mode
+type analyzeConfig struct {
+	Enabled bool
+	LogFile string
+	Pod     string
+}
+
+// runAnalyze analyzes a saved log with the log analysis of the scan config, writing
+// the report like scans do
+func runAnalyze(ctx context.Context, config analyzeConfig, output scanConfig, analysisTimeout time.Duration) error {
+	if err := output.validateOutput(); err != nil {
+		return err
+	}
+	namespace, name, ok := strings.Cut(config.Pod, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf("--log-pod must be namespace/name, not %q", config.Pod)
+	}
+	spec := controller.DefaultScanSpec()
+	if output.ConfigFile != "" {
+		loaded, err := loadSpec(output.ConfigFile)
+		if err != nil {
+			return err
+		}
+		spec = *loaded
+	}
+
+	logs := io.Reader(os.Stdin)
+	if config.LogFile != "-" {
+		file, err := os.Open(config.LogFile)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		logs = file
+	}
+
+	// A cluster is only needed for the secrets of AI API keys, so analyze without one
+	// if no kubeconfig is found
+	var c client.Client
+	if restConfig, err := ctrl.GetConfig(); err == nil {
+		if c, err = client.New(restConfig, client.Options{Scheme: scheme}); err != nil {
+			return err
+		}
+	} else {
+		setupLog.Info("analyzing without a cluster", "reason", err.Error())
+	}
+
+	if analysisTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, analysisTimeout)
+		defer cancel()
+	}
+	analysis, err := controller.AnalyzeLog(ctx, c, controller.OfflinePod(namespace, name), spec.LogAnalysis, logs)
+	if err != nil {
+		return err
+	}
+	return output.writeReport(analysis, func(out io.Writer) error {
+		return writeAnalysisReport(out, analysis)
+	})
+}
+
+// writeAnalysisReport writes an offline analysis as text for a terminal
+func writeAnalysisReport(out io.Writer, analysis *controller.OfflineAnalysis) error {
+	fmt.Fprintf(out, "Read %d lines, analyzed %d\n", analysis.LinesRead, len(analysis.Lines))
+	result := analysis.Result
+	if result == nil {
+		fmt.Fprintln(out, "No line left to analyze after filtering")
+		return nil
+	}
+	fmt.Fprintf(out, "\nRoot cause:  %s\n", result.RootCause)
+	fmt.Fprintf(out, "Confidence:  %d%%\n", result.Confidence)
+	fmt.Fprintf(out, "Methods:     %s\n", strings.Join(result.Methods, ", "))
+	if result.PatternResult != nil {
+		switch {
+		case result.PatternResult.Error != "":
+			fmt.Fprintf(out, "Pattern:     %s\n", result.PatternResult.Error)
+		case result.PatternResult.MatchedPattern == "":
+			fmt.Fprintln(out, "Pattern:     no pattern matched")
+		default:
+			fmt.Fprintf(out, "Pattern:     %s (priority %d)\n", result.PatternResult.MatchedPattern, result.PatternResult.Priority)
+		}
+	}
+	if result.AIResult != nil {
+		if result.AIResult.Error != "" {
+			fmt.Fprintf(out, "AI:          %s\n", result.AIResult.Error)
+		} else {
+			fmt.Fprintf(out, "AI:          %s (%s, %d%%)\n", result.AIResult.RootCause, result.AIResult.Model, result.AIResult.Confidence)
+		}
+	}
+
+	fmt.Fprintln(out, "\nAnalyzed lines:")
+	for _, line := range analysis.Lines {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	if len(result.ErrorLines) > 0 {
+		fmt.Fprintln(out, "\nError lines:")
+		for _, line := range result.ErrorLines {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+	return nil
+}
@@ -19,7 +19,9 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -32,6 +34,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -39,7 +42,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/config"
 	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
 	"github.com/baturorkun/kubebuilder-demo-operator/internal/web"
 	// +kubebuilder:scaffold:imports
 )
@@ -66,11 +71,166 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var dashboardAddr string
+	var aiRequestsPerMinute int
+	var aiMaxConcurrentRequests int
+	var aiAllowedEndpoints string
+	var aiKeySecretNamespaces string
+	var outboundTLSMinVersion string
+	var outboundTLSCipherSuites string
+	var outboundTLSFIPS bool
+	var costPerCPUCoreHour, costPerMemoryGiBHour float64
+	var costCurrency string
+	var clusterName, hubURL string
+	var hubEnabled bool
+	var hubPushInterval time.Duration
+	var analysisCacheMaxEntries int
+	var analysisCacheMaxBytes int64
+	var remediationDryRun bool
+	var remediationJobNamespaces string
+	var remediationJobServiceAccounts bool
+	var podEventDebounce time.Duration
+	var analysisWorkers int
+	var requeueBackoffBase, requeueBackoffMax time.Duration
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var logFetchesPerSecond, logFetchesPerNodePerSecond float64
+	var analysisTimeout time.Duration
+	var sharding controller.Sharding
+	var dashboardOIDC web.OIDCConfig
+	var dashboardOIDCAllowedGroups string
+	var historyInterval, historyRetention time.Duration
+	var historyConfigMap string
+	var dashboardRefreshInterval time.Duration
+	var dashboardLocale string
+	var dashboardBasePath, dashboardCORSOrigins string
+	var dashboardChangesPerMinute int
+	var grpcAddr string
+	var oneShot scanConfig
+	var offline analyzeConfig
+	var configFile string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&configFile, "config", "",
+		"Operator configuration file of dashboard, rate limit, default pattern and AI settings, "+
+			"overriding their flags. All but the dashboard settings are reloaded when it changes.")
 	flag.StringVar(&dashboardAddr, "dashboard-bind-address", ":8082", "The address the dashboard endpoint binds to. Use 0 to disable.")
+	flag.StringVar(&grpcAddr, "grpc-bind-address", "0",
+		"The address the gRPC API binds to, e.g. :9090. Served with the dashboard and its authentication. Use 0 to disable.")
+	flag.StringVar(&dashboardOIDC.IssuerURL, "dashboard-oidc-issuer", "",
+		"OpenID Connect issuer URL users log in to the dashboard with. Empty disables OIDC login.")
+	flag.StringVar(&dashboardOIDC.ClientID, "dashboard-oidc-client-id", "", "OpenID Connect client ID of the dashboard.")
+	flag.StringVar(&dashboardOIDC.RedirectURL, "dashboard-oidc-redirect-url", "",
+		"The dashboard's login callback URL registered at the OIDC provider, e.g. https://kubesleuth.example.com/auth/callback.")
+	flag.StringVar(&dashboardOIDC.GroupsClaim, "dashboard-oidc-groups-claim", "groups",
+		"ID token claim listing the groups of a user.")
+	flag.StringVar(&dashboardOIDCAllowedGroups, "dashboard-oidc-allowed-groups", "",
+		"Comma-separated groups allowed to log in to the dashboard. Empty allows any user of the provider.")
+	flag.DurationVar(&dashboardRefreshInterval, "dashboard-refresh-interval", web.DefaultRefreshInterval,
+		"Default interval at which dashboards refresh when live updates are unavailable. Users can change or pause it.")
+	flag.StringVar(&dashboardLocale, "dashboard-locale", web.DefaultLocale,
+		"Default dashboard language, one of "+strings.Join(web.Locales(), ", ")+". Users can switch the language in the dashboard.")
+	flag.StringVar(&dashboardBasePath, "dashboard-base-path", "",
+		"URL path prefix the dashboard is served under behind a reverse proxy, e.g. /kubesleuth. Empty serves it at the root.")
+	flag.StringVar(&dashboardCORSOrigins, "dashboard-cors-allowed-origins", "",
+		"Comma-separated origins whose web pages may call the dashboard API, e.g. https://portal.example.com, or * for any origin "+
+			"without credentials. Empty disables CORS.")
+	flag.IntVar(&dashboardChangesPerMinute, "dashboard-changes-per-minute", web.DefaultChangesPerMinute,
+		"Changes, such as forced analyses or new silences, each dashboard and API client may make per minute. Use 0 to disable.")
+	flag.DurationVar(&historyInterval, "history-interval", web.DefaultHistoryInterval,
+		"Interval between samples of the non-ready pod counts kept for the dashboard history.")
+	flag.DurationVar(&historyRetention, "history-retention", web.DefaultHistoryRetention,
+		"How long samples of the non-ready pod counts are kept.")
+	flag.StringVar(&historyConfigMap, "history-configmap", "",
+		"ConfigMap in the operator namespace persisting the history across restarts. Empty keeps it in memory only.")
+	flag.IntVar(&aiRequestsPerMinute, "ai-requests-per-minute", 0,
+		"Operator-wide limit on outbound AI analysis requests per minute. 0 means unlimited.")
+	flag.IntVar(&aiMaxConcurrentRequests, "ai-max-concurrent-requests", 0,
+		"Operator-wide limit on concurrent outbound AI analysis requests. 0 means unlimited.")
+	flag.StringVar(&aiAllowedEndpoints, "ai-allowed-endpoints", "",
+		"Comma-separated AI endpoints logs may be sent to: host names such as api.openai.com, wildcards such as "+
+			"*.openai.azure.com or URL prefixes such as http://ollama.ai.svc:11434/api/. Empty allows any endpoint.")
+	flag.StringVar(&aiKeySecretNamespaces, "ai-key-secret-namespaces", "",
+		"Comma-separated namespaces AI configurations may read API key secrets from with apiKeySecretNamespace, "+
+			"for pods of any namespace. Empty only allows API key secrets in the namespace of each pod.")
+	flag.StringVar(&outboundTLSMinVersion, "outbound-tls-min-version", "",
+		"Lowest TLS version (1.0, 1.1, 1.2 or 1.3) of connections to AI endpoints and notification sinks. "+
+			"Empty keeps Go's default of 1.2.")
+	flag.StringVar(&outboundTLSCipherSuites, "outbound-tls-cipher-suites", "",
+		"Comma-separated TLS 1.2 cipher suites allowed for connections to AI endpoints and notification sinks, "+
+			"such as TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Empty keeps Go's defaults.")
+	flag.BoolVar(&outboundTLSFIPS, "outbound-tls-fips", false,
+		"Only allow FIPS 140 approved TLS versions, cipher suites and curves for connections to AI endpoints "+
+			"and notification sinks. Without GODEBUG=fips140=on this limits them to TLS 1.2.")
+	flag.Float64Var(&costPerCPUCoreHour, "cost-per-cpu-core-hour", 0,
+		"Cost of a requested CPU core per hour, pricing the capacity stuck non-ready pods hold reserved. 0 leaves CPU unpriced.")
+	flag.Float64Var(&costPerMemoryGiBHour, "cost-per-memory-gib-hour", 0,
+		"Cost of a GiB of requested memory per hour, pricing the capacity stuck non-ready pods hold reserved. 0 leaves memory unpriced.")
+	flag.StringVar(&costCurrency, "cost-currency", "USD", "Currency of --cost-per-cpu-core-hour and --cost-per-memory-gib-hour.")
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"Name of this cluster, a DNS subdomain such as prod-eu-1, stamped into the non-ready pods found, metrics, "+
+			"notifications, event streams and exports to tell the findings of several clusters apart. Required with --hub-url.")
+	flag.BoolVar(&hubEnabled, "hub", false,
+		"Run the dashboard as a hub serving the findings other clusters push to it next to its own. "+
+			"Requires the hub-token key of the kubesleuth-dashboard Secret.")
+	flag.StringVar(&hubURL, "hub-url", "",
+		"Dashboard URL of a hub to push the findings of this cluster to, e.g. https://kubesleuth.example.com. "+
+			"Empty disables pushing.")
+	flag.DurationVar(&hubPushInterval, "hub-push-interval", hub.DefaultPushInterval,
+		"Interval at which the findings of this cluster are pushed to the hub of --hub-url.")
+	flag.IntVar(&analysisCacheMaxEntries, "analysis-cache-max-entries", controller.DefaultAnalysisCacheMaxEntries,
+		"Maximum number of cached log analysis results. Least recently used entries are evicted. 0 means unlimited.")
+	flag.Int64Var(&analysisCacheMaxBytes, "analysis-cache-max-bytes", controller.DefaultAnalysisCacheMaxBytes,
+		"Approximate maximum size of the log analysis cache in bytes. 0 means unlimited.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20,
+		"Maximum sustained rate of requests to the Kubernetes API server per second.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30,
+		"Maximum burst of requests to the Kubernetes API server above --kube-api-qps.")
+	flag.Float64Var(&logFetchesPerSecond, "log-fetches-per-second", controller.DefaultLogFetchesPerSecond,
+		"Operator-wide limit on container log fetches per second. Fetches over the limit wait. 0 means unlimited.")
+	flag.Float64Var(&logFetchesPerNodePerSecond, "log-fetches-per-node-per-second",
+		controller.DefaultLogFetchesPerNodePerSecond,
+		"Limit on container log fetches per second from the pods of one node, sparing its kubelet. 0 means unlimited.")
+	flag.DurationVar(&requeueBackoffBase, "requeue-backoff-base", controller.DefaultRequeueBackoffBase,
+		"Delay before retrying a failed reconcile, doubled on every consecutive failure of a PodSleuth.")
+	flag.DurationVar(&requeueBackoffMax, "requeue-backoff-max", controller.DefaultRequeueBackoffMax,
+		"Longest delay between retries of a PodSleuth whose reconciles keep failing.")
+	flag.IntVar(&analysisWorkers, "analysis-workers", controller.DefaultAnalysisWorkers,
+		"Number of concurrent log analyses run outside the reconcile loop. 0 analyzes pods within the reconcile.")
+	flag.DurationVar(&analysisTimeout, "analysis-timeout", controller.DefaultAnalysisTimeout,
+		"Time limit of a single log analysis, including its AI requests. 0 means no limit.")
+	flag.DurationVar(&podEventDebounce, "pod-event-debounce", controller.DefaultPodEventDebounce,
+		"Delay before reconciling after a pod event, coalescing bursts of pod events into one reconcile. 0 disables it.")
+	flag.IntVar(&sharding.Shards, "shards", 1,
+		"Number of shards splitting the work between operator replicas. 1 disables sharding.")
+	flag.IntVar(&sharding.Index, "shard", 0, "The shard this replica runs, from 0 to --shards minus 1.")
+	flag.StringVar(&sharding.By, "shard-by", controller.ShardByPodSleuth,
+		"How shards split the work: podsleuth runs each PodSleuth on one shard, namespace splits the namespaces "+
+			"of every PodSleuth between shards.")
+	flag.BoolVar(&remediationDryRun, "remediation-dry-run", false,
+		"Only record the remediations PodSleuths would take, without taking them.")
+	flag.StringVar(&remediationJobNamespaces, "remediation-job-namespaces", "",
+		"Comma-separated namespaces RunJob remediations may create Jobs in besides the namespace of the failing pod. "+
+			"Empty only allows the namespace of the pod.")
+	flag.BoolVar(&remediationJobServiceAccounts, "remediation-job-service-accounts", false,
+		"Let RunJob remediation templates set serviceAccountName. Otherwise their pods run as the default "+
+			"ServiceAccount of their namespace and templates naming another one are refused.")
+	flag.BoolVar(&oneShot.Enabled, "one-shot", false,
+		"Scan the cluster of the kubeconfig once, print the report and exit, without the CRD or a deployed operator. "+
+			"Same as the scan command.")
+	flag.StringVar(&oneShot.Namespaces, "scan-namespaces", "", "Comma-separated namespaces to scan. Empty scans all namespaces.")
+	flag.StringVar(&oneShot.ConfigFile, "scan-config", "",
+		"PodSleuth manifest whose pod label selector, log analysis and ownership rules the scan uses, "+
+			"or whose log analysis the analyze command uses. "+
+			"Empty analyzes logs with the default patterns.")
+	flag.StringVar(&oneShot.Output, "scan-output", "text", "Format of the scan or analyze report: text, json or yaml.")
+	flag.StringVar(&oneShot.OutputFile, "scan-output-file", "", "File to write the scan or analyze report to. Empty prints it.")
+	flag.StringVar(&offline.LogFile, "log-file", "-",
+		"Saved log the analyze command analyzes offline, or - to read it from stdin.")
+	flag.StringVar(&offline.Pod, "log-pod", "default/offline",
+		"namespace/name of the pod the analyze command analyzes the log as, naming it in AI prompts and "+
+			"locating the secrets of AI API keys.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -94,10 +254,128 @@ func main() {
 		},
 	}
 	opts.BindFlags(flag.CommandLine)
+	// "manager scan [flags]" is the same as "manager --one-shot [flags]", and
+	// "manager analyze [flags]" analyzes a saved log offline
+	if len(os.Args) > 1 && (os.Args[1] == "scan" || os.Args[1] == "analyze") {
+		oneShot.Enabled = os.Args[1] == "scan"
+		offline.Enabled = os.Args[1] == "analyze"
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	operatorConfig := &config.Config{}
+	if configFile != "" {
+		var err error
+		if operatorConfig, err = config.Load(configFile); err != nil {
+			setupLog.Error(err, "unable to load configuration file")
+			os.Exit(1)
+		}
+	}
+	if operatorConfig.Dashboard.BindAddress != "" {
+		dashboardAddr = operatorConfig.Dashboard.BindAddress
+	}
+	if err := controller.SetDefaultPatterns(operatorConfig.DefaultPatterns); err != nil {
+		setupLog.Error(err, "invalid default patterns")
+		os.Exit(1)
+	}
+	allowedEndpoints := func(c *config.Config) []string {
+		if c.AI.AllowedEndpoints != nil {
+			return c.AI.AllowedEndpoints
+		}
+		return strings.Split(aiAllowedEndpoints, ",")
+	}
+	if err := controller.SetAIEndpointAllowlist(allowedEndpoints(operatorConfig)); err != nil {
+		setupLog.Error(err, "invalid AI endpoint allowlist")
+		os.Exit(1)
+	}
+	keySecretNamespaces := func(c *config.Config) []string {
+		if c.AI.KeySecretNamespaces != nil {
+			return c.AI.KeySecretNamespaces
+		}
+		return strings.Split(aiKeySecretNamespaces, ",")
+	}
+	controller.SetAIKeySecretNamespaces(keySecretNamespaces(operatorConfig))
+	outboundTLSPolicy := func(c *config.Config) controller.OutboundTLSPolicy {
+		policy := controller.OutboundTLSPolicy{
+			MinVersion:   outboundTLSMinVersion,
+			CipherSuites: strings.Split(outboundTLSCipherSuites, ","),
+			FIPS:         config.Or(c.OutboundTLS.FIPS, outboundTLSFIPS),
+		}
+		if c.OutboundTLS.MinVersion != "" {
+			policy.MinVersion = c.OutboundTLS.MinVersion
+		}
+		if c.OutboundTLS.CipherSuites != nil {
+			policy.CipherSuites = c.OutboundTLS.CipherSuites
+		}
+		return policy
+	}
+	if err := controller.SetOutboundTLSPolicy(outboundTLSPolicy(operatorConfig)); err != nil {
+		setupLog.Error(err, "invalid outbound TLS policy")
+		os.Exit(1)
+	}
+	resourcePrices := func(c *config.Config) controller.ResourcePrices {
+		prices := controller.ResourcePrices{
+			CPUCoreHour:   config.Or(c.Cost.CPUCoreHour, costPerCPUCoreHour),
+			MemoryGiBHour: config.Or(c.Cost.MemoryGiBHour, costPerMemoryGiBHour),
+			Currency:      costCurrency,
+		}
+		if c.Cost.Currency != "" {
+			prices.Currency = c.Cost.Currency
+		}
+		return prices
+	}
+	if err := controller.SetResourcePrices(resourcePrices(operatorConfig)); err != nil {
+		setupLog.Error(err, "invalid resource prices")
+		os.Exit(1)
+	}
+	if clusterName != "" {
+		if err := hub.ValidateClusterName(clusterName); err != nil {
+			setupLog.Error(err, "invalid --cluster-name")
+			os.Exit(1)
+		}
+		controller.SetClusterName(clusterName)
+	}
+	// The metrics are labeled with the cluster name when registered
+	if err := controller.RegisterMetrics(); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
+	if offline.Enabled {
+		if err := runAnalyze(ctrl.SetupSignalHandler(), offline, oneShot, analysisTimeout); err != nil {
+			setupLog.Error(err, "analysis failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if oneShot.Enabled {
+		if err := runScan(ctrl.SetupSignalHandler(), oneShot, analysisTimeout); err != nil {
+			setupLog.Error(err, "scan failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if sharding.Enabled() {
+		if sharding.Index < 0 || sharding.Index >= sharding.Shards {
+			setupLog.Error(nil, "--shard must be between 0 and --shards minus 1", "shard", sharding.Index, "shards", sharding.Shards)
+			os.Exit(1)
+		}
+		if sharding.By != controller.ShardByPodSleuth && sharding.By != controller.ShardByNamespace {
+			setupLog.Error(nil, "--shard-by must be podsleuth or namespace", "shard-by", sharding.By)
+			os.Exit(1)
+		}
+		setupLog.Info("running one shard", "shard", sharding.Index, "shards", sharding.Shards, "by", sharding.By)
+	}
+	// Replicas of each shard elect their own leader
+	leaderElectionID := "89fd7b87.baturorkun.com"
+	if sharding.Enabled() {
+		leaderElectionID = fmt.Sprintf("shard-%d.%s", sharding.Index, leaderElectionID)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -165,13 +443,19 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = float32(kubeAPIQPS)
+	restConfig.Burst = kubeAPIBurst
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "89fd7b87.baturorkun.com",
+		LeaderElectionID:       leaderElectionID,
+		// Every pod of the cluster is cached, so keep the cached objects small
+		Cache: cache.Options{DefaultTransform: cache.TransformStripManagedFields()},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -196,12 +480,60 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := (&controller.PodSleuthReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		K8sClient:         k8sClient,
-		OperatorStartTime: time.Now(),
-	}).SetupWithManager(mgr); err != nil {
+	reconciler := &controller.PodSleuthReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		K8sClient:               k8sClient,
+		Recorder:                mgr.GetEventRecorderFor("podsleuth-controller"),
+		AIRateLimiter:           controller.NewAIRateLimiter(int32(aiRequestsPerMinute), int32(aiMaxConcurrentRequests)),
+		AnalysisCacheMaxEntries: analysisCacheMaxEntries,
+		AnalysisCacheMaxBytes:   analysisCacheMaxBytes,
+		LogFetchLimiter:         controller.NewLogFetchLimiter(logFetchesPerSecond, logFetchesPerNodePerSecond),
+		AnalysisWorkers:         analysisWorkers,
+		AnalysisTimeout:         analysisTimeout,
+		OperatorNamespace:       os.Getenv("POD_NAMESPACE"),
+		RequeueBackoffBase:      requeueBackoffBase,
+		RequeueBackoffMax:       requeueBackoffMax,
+		PodEventDebounce:        podEventDebounce,
+		Sharding:                sharding,
+		RemediationDryRun:       remediationDryRun,
+		APIReader:               mgr.GetAPIReader(),
+		OperatorStartTime:       time.Now(),
+	}
+	// RunJob remediations stay in the namespace of the pod and its default ServiceAccount
+	// unless allowed
+	reconciler.RemediationJobServiceAccounts = remediationJobServiceAccounts
+	if remediationJobNamespaces != "" {
+		reconciler.RemediationJobNamespaces = strings.Split(remediationJobNamespaces, ",")
+	}
+	// Rate limits, default patterns, AI, outbound TLS and cost settings follow the configuration file
+	applyConfig := func(c *config.Config) {
+		reconciler.AIRateLimiter.SetLimits(config.Or(c.AI.RequestsPerMinute, int32(aiRequestsPerMinute)),
+			config.Or(c.AI.MaxConcurrentRequests, int32(aiMaxConcurrentRequests)))
+		reconciler.LogFetchLimiter.SetRates(config.Or(c.LogFetch.PerSecond, logFetchesPerSecond),
+			config.Or(c.LogFetch.PerNodePerSecond, logFetchesPerNodePerSecond))
+		if err := controller.SetDefaultPatterns(c.DefaultPatterns); err != nil {
+			setupLog.Error(err, "ignoring invalid default patterns")
+		}
+		if err := controller.SetAIEndpointAllowlist(allowedEndpoints(c)); err != nil {
+			setupLog.Error(err, "ignoring invalid AI endpoint allowlist")
+		}
+		controller.SetAIKeySecretNamespaces(keySecretNamespaces(c))
+		if err := controller.SetOutboundTLSPolicy(outboundTLSPolicy(c)); err != nil {
+			setupLog.Error(err, "ignoring invalid outbound TLS policy")
+		}
+		if err := controller.SetResourcePrices(resourcePrices(c)); err != nil {
+			setupLog.Error(err, "ignoring invalid resource prices")
+		}
+	}
+	applyConfig(operatorConfig)
+	if configFile != "" {
+		if err := mgr.Add(config.NewWatcher(configFile, operatorConfig, applyConfig)); err != nil {
+			setupLog.Error(err, "unable to watch configuration file")
+			os.Exit(1)
+		}
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PodSleuth")
 		os.Exit(1)
 	}
@@ -216,22 +548,124 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Setup signal handler once for both manager and dashboard
-	ctx := ctrl.SetupSignalHandler()
-
 	// Start dashboard web server if enabled
 	if dashboardAddr != "0" {
-		dashboardServer := web.NewServer(mgr.GetClient(), dashboardAddr)
-		go func() {
-			if err := dashboardServer.Start(ctx); err != nil {
-				setupLog.Error(err, "problem running dashboard server")
+		dashboardServer := web.NewServer(mgr.GetClient(), dashboardAddr, reconciler)
+		if operatorConfig.Dashboard.TLSCertFile != "" {
+			dashboardServer.EnableTLS(operatorConfig.Dashboard.TLSCertFile, operatorConfig.Dashboard.TLSKeyFile)
+		}
+		// Remediation approvals from the dashboard need a token, typically from a Secret
+		if token := os.Getenv("DASHBOARD_APPROVAL_TOKEN"); token != "" {
+			dashboardServer.EnableRemediationApprovals(token)
+		}
+		// Pipelines verifying deploys authenticate with their own token
+		if token := os.Getenv("DASHBOARD_DEPLOY_HOOK_TOKEN"); token != "" {
+			dashboardServer.EnableDeployHooks(token, mgr.GetAPIReader(), mgr.GetEventRecorderFor("kubesleuth-deploy-hook"), reconciler)
+		}
+		// Dashboard credentials come from the kubesleuth-dashboard Secret
+		auth := web.AuthConfig{
+			Token:      os.Getenv("DASHBOARD_AUTH_TOKEN"),
+			Username:   os.Getenv("DASHBOARD_AUTH_USERNAME"),
+			Password:   os.Getenv("DASHBOARD_AUTH_PASSWORD"),
+			SessionKey: []byte(os.Getenv("DASHBOARD_SESSION_KEY")),
+		}
+		if dashboardOIDC.IssuerURL != "" {
+			if dashboardOIDC.ClientID == "" || dashboardOIDC.RedirectURL == "" {
+				setupLog.Error(nil, "--dashboard-oidc-client-id and --dashboard-oidc-redirect-url are required with --dashboard-oidc-issuer")
+				os.Exit(1)
+			}
+			dashboardOIDC.ClientSecret = os.Getenv("DASHBOARD_OIDC_CLIENT_SECRET")
+			for _, group := range strings.Split(dashboardOIDCAllowedGroups, ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					dashboardOIDC.AllowedGroups = append(dashboardOIDC.AllowedGroups, group)
+				}
+			}
+			auth.OIDC = &dashboardOIDC
+			if len(auth.SessionKey) == 0 {
+				setupLog.Info("DASHBOARD_SESSION_KEY not set, dashboard sessions do not survive restarts or span replicas")
+			}
+		}
+		if auth.Enabled() {
+			dashboardServer.EnableAuth(auth)
+		}
+		dashboardServer.SetSharding(sharding)
+		dashboardServer.EnableLiveUpdates(mgr.GetCache())
+		dashboardServer.SetRefreshInterval(dashboardRefreshInterval)
+		if err := dashboardServer.SetLocale(dashboardLocale); err != nil {
+			setupLog.Error(err, "invalid --dashboard-locale")
+			os.Exit(1)
+		}
+		if err := dashboardServer.SetBasePath(dashboardBasePath); err != nil {
+			setupLog.Error(err, "invalid --dashboard-base-path")
+			os.Exit(1)
+		}
+		dashboardServer.SetChangeRateLimit(dashboardChangesPerMinute)
+		var corsOrigins []string
+		for _, origin := range strings.Split(dashboardCORSOrigins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				corsOrigins = append(corsOrigins, strings.TrimSuffix(origin, "/"))
 			}
-		}()
-		setupLog.Info("dashboard server started", "address", dashboardAddr)
+		}
+		if len(corsOrigins) > 0 {
+			dashboardServer.EnableCORS(corsOrigins)
+		}
+		dashboardServer.EnableLogViewer(k8sClient, reconciler.LogFetchLimiter)
+		dashboardServer.EnableOnDemandAnalysis(reconciler)
+		dashboardServer.ConfigureHistory(historyInterval, historyRetention)
+		if grpcAddr != "0" {
+			dashboardServer.EnableGRPC(grpcAddr)
+		}
+		if historyConfigMap != "" {
+			if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+				dashboardServer.PersistHistory(k8sClient, namespace, historyConfigMap)
+			} else {
+				setupLog.Info("POD_NAMESPACE not set, keeping the history in memory only")
+			}
+		}
+		dashboardServer.EnableHealthChecks(mgr.GetCache(), k8sClient.Discovery().RESTClient())
+		// Clusters pushing to the hub authenticate with a token of their own
+		if hubEnabled {
+			if os.Getenv("HUB_TOKEN") == "" {
+				setupLog.Error(nil, "--hub requires the hub-token key of the kubesleuth-dashboard Secret")
+				os.Exit(1)
+			}
+			tokens, err := hub.ParseClusterTokens(os.Getenv("HUB_TOKEN"))
+			if err != nil {
+				setupLog.Error(err, "invalid hub-token key of the kubesleuth-dashboard Secret")
+				os.Exit(1)
+			}
+			dashboardServer.EnableHub(hub.NewStore(0), tokens)
+		}
+		// The manager starts the dashboard once its cache has synced, and stops it first
+		if err := mgr.Add(dashboardServer); err != nil {
+			setupLog.Error(err, "unable to set up dashboard server")
+			os.Exit(1)
+		}
+	}
+
+	// Push the findings of this cluster to a hub
+	if hubURL != "" {
+		token := os.Getenv("HUB_TOKEN")
+		if token == "" {
+			setupLog.Error(nil, "--hub-url requires the hub-token key of the kubesleuth-dashboard Secret")
+			os.Exit(1)
+		}
+		agent, err := hub.NewAgent(mgr.GetClient(), hubURL, clusterName, token, hubPushInterval,
+			controller.OutboundTransport())
+		if err != nil {
+			setupLog.Error(err, "invalid hub settings")
+			os.Exit(1)
+		}
+		if err := mgr.Add(agent); err != nil {
+			setupLog.Error(err, "unable to set up hub agent")
+			os.Exit(1)
+		}
+	} else if hubEnabled && dashboardAddr == "0" {
+		setupLog.Info("--hub has no effect without the dashboard")
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctx); err != nil {
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// scanConfig holds the flags of the one-shot scan mode
+type scanConfig struct {
+	Enabled    bool
+	Namespaces string
+	ConfigFile string
+	Output     string
+	OutputFile string
+}
+
+// runScan scans the cluster of the kubeconfig once and writes the report
+func runScan(ctx context.Context, config scanConfig, analysisTimeout time.Duration) error {
+	if err := config.validateOutput(); err != nil {
+		return err
+	}
+	options := controller.ScanOptions{
+		Spec:            controller.DefaultScanSpec(),
+		AnalysisTimeout: analysisTimeout,
+	}
+	for _, namespace := range strings.Split(config.Namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			options.Namespaces = append(options.Namespaces, namespace)
+		}
+	}
+	if config.ConfigFile != "" {
+		spec, err := loadSpec(config.ConfigFile)
+		if err != nil {
+			return err
+		}
+		options.Spec = *spec
+	}
+
+	// Only built-in types are read, so the scan runs without the CRD
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	setupLog.Info("scanning cluster", "host", restConfig.Host, "namespaces", options.Namespaces)
+	report, err := controller.Scan(ctx, c, k8sClient, options)
+	if err != nil {
+		return err
+	}
+	return config.writeReport(report, func(out io.Writer) error {
+		return writeScanReport(out, restConfig.Host, report)
+	})
+}
+
+// validateOutput checks the report format before any work is done
+func (config scanConfig) validateOutput() error {
+	if config.Output != "text" && config.Output != "json" && config.Output != "yaml" {
+		return fmt.Errorf("--scan-output must be text, json or yaml, not %q", config.Output)
+	}
+	return nil
+}
+
+// writeReport writes a report in the configured format, as text with writeText
+func (config scanConfig) writeReport(report any, writeText func(io.Writer) error) error {
+	out := io.Writer(os.Stdout)
+	if config.OutputFile != "" {
+		file, err := os.Create(config.OutputFile)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+	switch config.Output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+	return writeText(out)
+}
+
+// loadSpec reads the spec of a PodSleuth manifest
+func loadSpec(path string) (*infrav1alpha1.PodSleuthSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var podSleuth infrav1alpha1.PodSleuth
+	if err := yaml.UnmarshalStrict(data, &podSleuth); err != nil {
+		return nil, fmt.Errorf("invalid PodSleuth manifest %s: %w", path, err)
+	}
+	return &podSleuth.Spec, nil
+}
+
+// writeScanReport writes a scan report as text for a terminal
+func writeScanReport(out io.Writer, host string, report *controller.ScanReport) error {
+	if report.Cluster != "" {
+		host = report.Cluster + " (" + host + ")"
+	}
+	fmt.Fprintf(out, "Scanned %d pods on %s at %s\n", report.PodsScanned, host, report.ScannedAt.Format(time.RFC3339))
+	fmt.Fprintf(out, "%d not ready, %d evicted or shut down\n", len(report.NonReadyPods), len(report.EvictedPods))
+
+	if len(report.NonReadyPods) > 0 {
+		fmt.Fprintln(out)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tPOD\tPHASE\tREASON\tOWNER\tROOT CAUSE")
+		for _, pod := range report.NonReadyPods {
+			owner := "-"
+			if pod.OwnerKind != "" {
+				owner = pod.OwnerKind + "/" + pod.OwnerName
+			}
+			rootCause := "-"
+			if pod.LogAnalysis != nil && pod.LogAnalysis.RootCause != "" {
+				rootCause = fmt.Sprintf("%s (%d%%)", pod.LogAnalysis.RootCause, pod.LogAnalysis.Confidence)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", pod.Namespace, pod.Name, pod.Phase, pod.Reason, owner, rootCause)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+		for _, pod := range report.NonReadyPods {
+			if pod.Message != "" {
+				fmt.Fprintf(out, "%s/%s: %s\n", pod.Namespace, pod.Name, pod.Message)
+			}
+		}
+	}
+
+	if len(report.EvictedPods) > 0 {
+		fmt.Fprintln(out)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tEVICTED POD\tREASON\tRESOURCE")
+		for _, pod := range report.EvictedPods {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pod.Namespace, pod.Name, pod.Reason, pod.Resource)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(out, "\nErrors:")
+		for _, scanErr := range report.Errors {
+			fmt.Fprintf(out, "  %s\n", scanErr)
+		}
+	}
+	return nil
+}
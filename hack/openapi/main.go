@@ -0,0 +1,406 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// openapi writes the OpenAPI document of the dashboard API and generates the Go client
+// of pkg/dashboardclient from it:
+//
+//	go run ./hack/openapi -spec openapi.json -client pkg/dashboardclient/zz_generated.client.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/web"
+)
+
+// schema is the subset of OpenAPI schemas the dashboard API document uses
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Nullable             bool               `json:"nullable"`
+	AllOf                []*schema          `json:"allOf"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description"`
+	Schema      schema `json:"schema"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"responses"`
+}
+
+type document struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// initialisms are spelled in capitals in Go names
+var initialisms = map[string]bool{
+	"AI": true, "API": true, "CPU": true, "DNS": true, "FS": true, "HPA": true, "HTTP": true, "ID": true,
+	"IP": true, "JSON": true, "OS": true, "SASL": true, "TLS": true, "TTL": true, "UID": true, "URL": true,
+}
+
+// goName turns a JSON name into an exported Go name
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		for _, word := range splitWords(part) {
+			if initialisms[strings.ToUpper(word)] {
+				b.WriteString(strings.ToUpper(word))
+				continue
+			}
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// splitWords splits a camel case name into words: podIP is pod and IP, hostIPs is host
+// and IPs, and HTTPGet is HTTP and Get
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lower, upper := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]), unicode.IsUpper(runes[i])
+		// An upper case run ends before the capital starting the next word
+		endsRun := unicode.IsUpper(runes[i-1]) && upper && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+			!(runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2])))
+		if (lower && upper) || endsRun {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// lowerName turns a JSON name into an unexported Go name
+func lowerName(name string) string {
+	exported := goName(name)
+	words := splitWords(exported)
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// generator writes the Go client of a document
+type generator struct {
+	doc *document
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// refName returns the type name of a component reference
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// isStruct reports whether a schema is generated as a struct
+func isStruct(s *schema) bool {
+	if len(s.AllOf) == 1 {
+		return isStruct(s.AllOf[0])
+	}
+	return s.Ref != "" || (s.Type == "object" && s.AdditionalProperties == nil && s.Properties != nil)
+}
+
+// goType returns the Go type of a schema
+func (g *generator) goType(s *schema) string {
+	switch {
+	case s.Ref != "":
+		return refName(s.Ref)
+	case len(s.AllOf) == 1:
+		return g.goType(s.AllOf[0])
+	}
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + g.goType(s.AdditionalProperties)
+		}
+		if s.Properties != nil {
+			return "struct {\n" + g.fields(s) + "}"
+		}
+		return "map[string]json.RawMessage"
+	}
+	// Free-form values
+	return "json.RawMessage"
+}
+
+// fields returns the fields of the struct of an object schema, in name order
+func (g *generator) fields(s *schema) string {
+	var b strings.Builder
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		property := s.Properties[name]
+		required := slices.Contains(s.Required, name)
+		typ := g.goType(property)
+		if isStruct(property) && (!required || property.Nullable) ||
+			typ == "time.Time" && property.Nullable {
+			typ = "*" + typ
+		}
+		tag := name
+		if !required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", goName(name), typ, tag)
+	}
+	return b.String()
+}
+
+// generateTypes writes a struct for each component schema
+func (g *generator) generateTypes() {
+	names := make([]string, 0, len(g.doc.Components.Schemas))
+	for name := range g.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		g.printf("// %s is the %s schema of the dashboard API\n", name, name)
+		g.printf("type %s struct {\n%s}\n\n", name, g.fields(g.doc.Components.Schemas[name]))
+	}
+}
+
+// pathSegment matches the parameters of a path
+var pathSegment = regexp.MustCompile(`\{([^}]+)\}`)
+
+// generateOperation writes the method calling an operation, skipping operations without
+// JSON response such as event streams
+func (g *generator) generateOperation(method, path string, op *operation) {
+	var response *schema
+	for status, candidate := range op.Responses {
+		if strings.HasPrefix(status, "2") && candidate.Content["application/json"].Schema != nil {
+			response = candidate.Content["application/json"].Schema
+		}
+	}
+	if response == nil {
+		return
+	}
+	name := goName(op.OperationID)
+
+	var args []string
+	var queryParams []parameter
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			args = append(args, lowerName(param.Name)+" string")
+		} else {
+			queryParams = append(queryParams, param)
+		}
+	}
+	if len(queryParams) > 0 {
+		g.printf("// %sParams are the query parameters of %s\n", name, name)
+		g.printf("type %sParams struct {\n", name)
+		for _, param := range queryParams {
+			g.printf("// %s\n%s %s\n", param.Description, goName(param.Name), g.goType(&param.Schema))
+		}
+		g.printf("}\n\n")
+		args = append(args, "params *"+name+"Params")
+	}
+	body := "nil"
+	if op.RequestBody != nil {
+		args = append(args, "body "+g.goType(op.RequestBody.Content["application/json"].Schema))
+		body = "body"
+	}
+
+	result := g.goType(response)
+	if isStruct(response) {
+		result = "*" + result
+	}
+	g.printf("// %s sends %s %s: %s\n", name, strings.ToUpper(method), path, op.Summary)
+	if op.Description != "" {
+		g.printf("//\n// %s\n", op.Description)
+	}
+	g.printf("func (c *Client) %s(ctx context.Context%s) (%s, error) {\n", name, strings.Join(append([]string{""}, args...), ", "), result)
+
+	urlPath := `"` + pathSegment.ReplaceAllStringFunc(path, func(segment string) string {
+		return `" + url.PathEscape(` + lowerName(segment[1:len(segment)-1]) + `) + "`
+	}) + `"`
+	urlPath = strings.TrimSuffix(urlPath, ` + ""`)
+
+	query := "nil"
+	if len(queryParams) > 0 {
+		query = "query"
+		g.printf("query := url.Values{}\nif params != nil {\n")
+		for _, param := range queryParams {
+			field := "params." + goName(param.Name)
+			switch param.Schema.Type {
+			case "integer":
+				g.printf("if %s != 0 {\nquery.Set(%q, strconv.Itoa(%s))\n}\n", field, param.Name, field)
+			case "boolean":
+				g.printf("if %s {\nquery.Set(%q, \"true\")\n}\n", field, param.Name)
+			default:
+				g.printf("if %s != \"\" {\nquery.Set(%q, %s)\n}\n", field, param.Name, field)
+			}
+		}
+		g.printf("}\n")
+	}
+
+	g.printf("var out %s\n", strings.TrimPrefix(result, "*"))
+	g.printf("if err := c.do(ctx, %q, %s, %s, %s, &out); err != nil {\n", strings.ToUpper(method), urlPath, query, body)
+	if isStruct(response) {
+		g.printf("return nil, err\n}\nreturn &out, nil\n}\n\n")
+	} else {
+		g.printf("return nil, err\n}\nreturn out, nil\n}\n\n")
+	}
+}
+
+// generate returns the Go client of a document
+func generate(doc *document, header, pkg string) ([]byte, error) {
+	g := &generator{doc: doc}
+	g.generateTypes()
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		for _, method := range []string{"get", "post", "put", "patch", "delete"} {
+			if op := doc.Paths[path][method]; op != nil {
+				g.generateOperation(method, path, op)
+			}
+		}
+	}
+
+	code := g.buf.String()
+	var imports []string
+	for pkg, use := range map[string]string{"context": "context.", "encoding/json": "json.", "net/url": "url.",
+		"strconv": "strconv.", "time": "time."} {
+		if strings.Contains(code, use) {
+			imports = append(imports, fmt.Sprintf("%q", pkg))
+		}
+	}
+	slices.Sort(imports)
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("\n// Code generated by hack/openapi. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n%s\n)\n\n", pkg, strings.Join(imports, "\n"))
+	out.WriteString(code)
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the client: %w", err)
+	}
+	return formatted, nil
+}
+
+func main() {
+	var specFile, clientFile, headerFile, pkg string
+	flag.StringVar(&specFile, "spec", "", "File to write the OpenAPI document to")
+	flag.StringVar(&clientFile, "client", "", "File to write the Go client to")
+	flag.StringVar(&headerFile, "header", "", "File with the license header of the Go client")
+	flag.StringVar(&pkg, "package", "dashboardclient", "Package of the Go client")
+	flag.Parse()
+
+	if err := run(specFile, clientFile, headerFile, pkg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(specFile, clientFile, headerFile, pkg string) error {
+	spec, err := web.OpenAPISpec()
+	if err != nil {
+		return err
+	}
+	if specFile != "" {
+		if err := os.WriteFile(specFile, append(spec, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	if clientFile == "" {
+		return nil
+	}
+
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return fmt.Errorf("parsing the OpenAPI document: %w", err)
+	}
+	header := ""
+	if headerFile != "" {
+		data, err := os.ReadFile(headerFile)
+		if err != nil {
+			return err
+		}
+		header = strings.TrimSpace(string(data)) + "\n"
+	}
+	client, err := generate(&doc, header, pkg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(clientFile, client, 0o644)
+}
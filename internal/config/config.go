@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the operator configuration file and reloads it on change.
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Config holds operator-level settings. Unset settings keep the values of their flags.
+type Config struct {
+	Dashboard DashboardConfig `json:"dashboard,omitempty"`
+	AI        AIConfig        `json:"ai,omitempty"`
+	LogFetch  LogFetchConfig  `json:"logFetch,omitempty"`
+	// OutboundTLS restricts the TLS connections to AI endpoints and notification sinks
+	OutboundTLS OutboundTLSConfig `json:"outboundTLS,omitempty"`
+	// Cost prices the capacity reserved by stuck non-ready pods
+	Cost CostConfig `json:"cost,omitempty"`
+	// DefaultPatterns replace the built-in error patterns, used while a PodSleuth has
+	// none of its own
+	DefaultPatterns []infrav1alpha1.ErrorPattern `json:"defaultPatterns,omitempty"`
+}
+
+// DashboardConfig configures the dashboard server. Changes take effect on restart,
+// except for renewed certificates, which are reloaded.
+type DashboardConfig struct {
+	// BindAddress overrides --dashboard-bind-address
+	BindAddress string `json:"bindAddress,omitempty"`
+	// TLSCertFile and TLSKeyFile serve the dashboard over HTTPS
+	TLSCertFile string `json:"tlsCertFile,omitempty"`
+	TLSKeyFile  string `json:"tlsKeyFile,omitempty"`
+}
+
+// AIConfig limits outbound AI requests operator-wide, and where they may go
+type AIConfig struct {
+	// RequestsPerMinute overrides --ai-requests-per-minute
+	RequestsPerMinute *int32 `json:"requestsPerMinute,omitempty"`
+	// MaxConcurrentRequests overrides --ai-max-concurrent-requests
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+	// AllowedEndpoints overrides --ai-allowed-endpoints. An empty list allows any endpoint.
+	AllowedEndpoints []string `json:"allowedEndpoints,omitempty"`
+	// KeySecretNamespaces overrides --ai-key-secret-namespaces
+	KeySecretNamespaces []string `json:"keySecretNamespaces,omitempty"`
+}
+
+// LogFetchConfig limits container log fetches
+type LogFetchConfig struct {
+	// PerSecond overrides --log-fetches-per-second
+	PerSecond *float64 `json:"perSecond,omitempty"`
+	// PerNodePerSecond overrides --log-fetches-per-node-per-second
+	PerNodePerSecond *float64 `json:"perNodePerSecond,omitempty"`
+}
+
+// OutboundTLSConfig is the TLS policy of outbound connections
+type OutboundTLSConfig struct {
+	// MinVersion overrides --outbound-tls-min-version
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites overrides --outbound-tls-cipher-suites
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// FIPS overrides --outbound-tls-fips
+	FIPS *bool `json:"fips,omitempty"`
+}
+
+// CostConfig prices reserved capacity
+type CostConfig struct {
+	// CPUCoreHour overrides --cost-per-cpu-core-hour
+	CPUCoreHour *float64 `json:"cpuCoreHour,omitempty"`
+	// MemoryGiBHour overrides --cost-per-memory-gib-hour
+	MemoryGiBHour *float64 `json:"memoryGiBHour,omitempty"`
+	// Currency overrides --cost-currency
+	Currency string `json:"currency,omitempty"`
+}
+
+// Or returns the value of an optional setting, or fallback if it is unset
+func Or[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+// Load reads and validates a configuration file. A missing file is an empty
+// configuration, so the file can be created later.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+func (c *Config) validate() error {
+	if (c.Dashboard.TLSCertFile == "") != (c.Dashboard.TLSKeyFile == "") {
+		return errors.New("dashboard.tlsCertFile and dashboard.tlsKeyFile must be set together")
+	}
+	if v := c.AI.RequestsPerMinute; v != nil && *v < 0 {
+		return errors.New("ai.requestsPerMinute must not be negative")
+	}
+	if v := c.AI.MaxConcurrentRequests; v != nil && *v < 0 {
+		return errors.New("ai.maxConcurrentRequests must not be negative")
+	}
+	if v := c.LogFetch.PerSecond; v != nil && *v < 0 {
+		return errors.New("logFetch.perSecond must not be negative")
+	}
+	if v := c.LogFetch.PerNodePerSecond; v != nil && *v < 0 {
+		return errors.New("logFetch.perNodePerSecond must not be negative")
+	}
+	if v := c.Cost.CPUCoreHour; v != nil && *v < 0 {
+		return errors.New("cost.cpuCoreHour must not be negative")
+	}
+	if v := c.Cost.MemoryGiBHour; v != nil && *v < 0 {
+		return errors.New("cost.memoryGiBHour must not be negative")
+	}
+	for _, pattern := range c.DefaultPatterns {
+		if pattern.Name == "" {
+			return errors.New("defaultPatterns need a name")
+		}
+		if _, err := regexp.Compile(pattern.Pattern); err != nil {
+			return fmt.Errorf("defaultPatterns %s: %w", pattern.Name, err)
+		}
+	}
+	return nil
+}
+
+// reloadDelay is how long the configuration file must be left unchanged to be reloaded
+const reloadDelay = 500 * time.Millisecond
+
+// Watcher reloads a configuration file when it changes. It is a manager Runnable.
+type Watcher struct {
+	path     string
+	current  *Config
+	onChange func(*Config)
+}
+
+// NewWatcher creates a watcher calling onChange with the configuration reloaded from
+// path whenever it differs from current. Invalid configurations are logged and ignored.
+func NewWatcher(path string, current *Config, onChange func(*Config)) *Watcher {
+	return &Watcher{path: path, current: current, onChange: onChange}
+}
+
+// Start watches the configuration file until ctx is done
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = watcher.Close() }()
+	// Mounted ConfigMaps are updated by swapping a symlink in their directory, so the
+	// directory is watched rather than the file
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("unable to watch configuration file: %w", err)
+	}
+
+	logger := log.Log.WithName("config")
+	logger.Info("watching configuration file", "path", w.path)
+	// Editors write files in several steps, so the file is reloaded once it settles
+	settled := time.NewTimer(time.Hour)
+	settled.Stop()
+	defer settled.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watcher.Errors:
+			logger.Error(err, "error watching configuration file")
+		case <-watcher.Events:
+			settled.Reset(reloadDelay)
+		case <-settled.C:
+			config, err := Load(w.path)
+			if err != nil {
+				logger.Error(err, "ignoring configuration change")
+				continue
+			}
+			if reflect.DeepEqual(config, w.current) {
+				continue
+			}
+			logger.Info("configuration file changed, applying it")
+			w.current = config
+			w.onChange(config)
+		}
+	}
+}
+
+// NeedLeaderElection is false: every replica applies its configuration
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Annotations on a pod that acknowledge it. The dashboard sets them; the acknowledgement
+// lasts until the RFC 3339 time in AnnotationAcknowledgedUntil.
+const (
+	AnnotationAcknowledgedBy      = "kubesleuth.io/acknowledged-by"
+	AnnotationAcknowledgedUntil   = "kubesleuth.io/acknowledged-until"
+	AnnotationAcknowledgedComment = "kubesleuth.io/acknowledged-comment"
+)
+
+// AcknowledgementAnnotations are the annotations that acknowledge a pod
+var AcknowledgementAnnotations = []string{AnnotationAcknowledgedBy, AnnotationAcknowledgedUntil, AnnotationAcknowledgedComment}
+
+// IsMuted reports whether a non-ready pod is suppressed by a maintenance window, silenced,
+// acknowledged or within its workload's baseline. Muted pods are reported, but not
+// notified or counted as failing.
+func IsMuted(pod *infrav1alpha1.NonReadyPodInfo) bool {
+	return pod.Suppressed || pod.Silenced || pod.Acknowledged != nil || pod.WithinBaseline
+}
+
+// podAcknowledgement returns the unexpired acknowledgement of a pod, nil if none
+func podAcknowledgement(pod *corev1.Pod, now time.Time) *infrav1alpha1.PodAcknowledgement {
+	until, err := time.Parse(time.RFC3339, pod.Annotations[AnnotationAcknowledgedUntil])
+	if err != nil || !until.After(now) {
+		return nil
+	}
+	by := pod.Annotations[AnnotationAcknowledgedBy]
+	if by == "" {
+		by = "unknown"
+	}
+	return &infrav1alpha1.PodAcknowledgement{
+		By:      by,
+		Comment: pod.Annotations[AnnotationAcknowledgedComment],
+		Until:   metav1.NewTime(until),
+	}
+}
+
+// acknowledgementChanged reports whether a pod was acknowledged or unacknowledged
+func acknowledgementChanged(oldPod, newPod *corev1.Pod) bool {
+	for _, annotation := range AcknowledgementAnnotations {
+		if oldPod.Annotations[annotation] != newPod.Annotations[annotation] {
+			return true
+		}
+	}
+	return false
+}
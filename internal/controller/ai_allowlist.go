@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionDegraded is the condition of PodSleuths whose spec is only partly applied
+	ConditionDegraded = "Degraded"
+	// ReasonAIEndpointNotAllowed marks PodSleuths whose AI endpoints are not allowed
+	ReasonAIEndpointNotAllowed = "AIEndpointNotAllowed"
+	// ReasonAIEndpointsAllowed clears the Degraded condition once they are
+	ReasonAIEndpointsAllowed = "AIEndpointsAllowed"
+)
+
+// ErrAIEndpointNotAllowed is returned for AI requests to endpoints outside the allowlist
+var ErrAIEndpointNotAllowed = errors.New("AI endpoint not in the operator's allowlist")
+
+// aiEndpointRule is an entry of the AI endpoint allowlist
+type aiEndpointRule struct {
+	// host is a host name, or a domain whose subdomains match if wildcard is set
+	host     string
+	wildcard bool
+	// scheme, hostPort and path match URL prefixes, if scheme is set
+	scheme   string
+	hostPort string
+	path     string
+}
+
+var (
+	// aiAllowlist holds the AI endpoints logs may be sent to (empty = any endpoint)
+	aiAllowlist    []aiEndpointRule
+	aiAllowlistMux sync.RWMutex
+)
+
+// SetAIEndpointAllowlist restricts the AI endpoints logs are sent to. Entries are host
+// names such as api.openai.com, wildcards such as *.openai.azure.com matching
+// subdomains, or URL prefixes such as http://ollama.ai.svc:11434/api/. No entries
+// allows any endpoint. Nothing changes if an entry is invalid.
+func SetAIEndpointAllowlist(entries []string) error {
+	var rules []aiEndpointRule
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "://") {
+			u, err := url.Parse(entry)
+			if err != nil || u.Host == "" {
+				return fmt.Errorf("invalid AI endpoint allowlist entry %q", entry)
+			}
+			rules = append(rules, aiEndpointRule{
+				scheme:   strings.ToLower(u.Scheme),
+				hostPort: strings.ToLower(u.Host),
+				path:     u.Path,
+			})
+			continue
+		}
+		host, wildcard := strings.CutPrefix(strings.ToLower(entry), "*.")
+		if host == "" || strings.ContainsAny(host, "/:*") {
+			return fmt.Errorf("invalid AI endpoint allowlist entry %q", entry)
+		}
+		rules = append(rules, aiEndpointRule{host: host, wildcard: wildcard})
+	}
+
+	aiAllowlistMux.Lock()
+	defer aiAllowlistMux.Unlock()
+	aiAllowlist = rules
+	return nil
+}
+
+// checkAIEndpoint returns ErrAIEndpointNotAllowed if logs may not be sent to endpoint
+func checkAIEndpoint(endpoint string) error {
+	aiAllowlistMux.RLock()
+	defer aiAllowlistMux.RUnlock()
+	if len(aiAllowlist) == 0 {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Host != "" {
+		for _, rule := range aiAllowlist {
+			if rule.matches(u) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrAIEndpointNotAllowed, endpoint)
+}
+
+func (rule aiEndpointRule) matches(u *url.URL) bool {
+	if rule.scheme != "" {
+		if !strings.EqualFold(u.Scheme, rule.scheme) || !strings.EqualFold(u.Host, rule.hostPort) {
+			return false
+		}
+		// Prefixes match whole path segments
+		return strings.HasPrefix(u.Path, rule.path) &&
+			(strings.HasSuffix(rule.path, "/") || len(u.Path) == len(rule.path) || u.Path[len(rule.path)] == '/')
+	}
+	host := strings.ToLower(u.Hostname())
+	if rule.wildcard {
+		return strings.HasSuffix(host, "."+rule.host)
+	}
+	return host == rule.host
+}
+
+// disallowedAIEndpoints returns the AI endpoints of a log analysis configuration that
+// are not in the allowlist
+func disallowedAIEndpoints(config *infrav1alpha1.LogAnalysisConfig) []string {
+	if config == nil {
+		return nil
+	}
+	var endpoints []string
+	if config.AIEndpoint != "" {
+		endpoints = append(endpoints, config.AIEndpoint)
+	}
+	for _, methodConfig := range config.MethodConfigs {
+		if methodConfig.AIConfig != nil {
+			endpoints = append(endpoints, methodConfig.AIConfig.Endpoint)
+		}
+		for _, fallback := range methodConfig.AIFallbacks {
+			endpoints = append(endpoints, fallback.Endpoint)
+		}
+	}
+	var disallowed []string
+	for _, endpoint := range endpoints {
+		if checkAIEndpoint(endpoint) != nil && !slices.Contains(disallowed, endpoint) {
+			disallowed = append(disallowed, endpoint)
+		}
+	}
+	return disallowed
+}
+
+// enforceAIEndpointAllowlist skips the AI methods of a PodSleuth if any of its AI
+// endpoints is not allowed, marking it Degraded until its endpoints are. Only the
+// in-memory spec is changed.
+func enforceAIEndpointAllowlist(podSleuth *infrav1alpha1.PodSleuth) {
+	disallowed := disallowedAIEndpoints(podSleuth.Spec.LogAnalysis)
+	if len(disallowed) == 0 {
+		if meta.FindStatusCondition(podSleuth.Status.Conditions, ConditionDegraded) != nil {
+			meta.SetStatusCondition(&podSleuth.Status.Conditions, metav1.Condition{
+				Type:               ConditionDegraded,
+				Status:             metav1.ConditionFalse,
+				Reason:             ReasonAIEndpointsAllowed,
+				Message:            "All AI endpoints are allowed",
+				ObservedGeneration: podSleuth.Generation,
+			})
+		}
+		return
+	}
+	podSleuth.Spec.LogAnalysis = withoutAIMethods(podSleuth.Spec.LogAnalysis)
+	meta.SetStatusCondition(&podSleuth.Status.Conditions, metav1.Condition{
+		Type:               ConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonAIEndpointNotAllowed,
+		Message:            "AI analysis is skipped, endpoints not in the operator's allowlist: " + strings.Join(disallowed, ", "),
+		ObservedGeneration: podSleuth.Generation,
+	})
+}
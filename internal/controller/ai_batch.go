@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// logSignatureNormalizers strip values that differ between otherwise identical
+// failures (timestamps, request IDs, pod IPs, ports, counters)
+var logSignatureNormalizers = []struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}{
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+	{regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`), "<ip>"},
+	{regexp.MustCompile(`\b(?:0x)?[0-9a-fA-F]{8,}\b`), "<hex>"},
+	{regexp.MustCompile(`\d+`), "<n>"},
+}
+
+// logSignature returns a stable signature for log lines so pods failing
+// identically map to the same value
+func logSignature(lines []string) string {
+	h := sha256.New()
+	for _, line := range lines {
+		for _, n := range logSignatureNormalizers {
+			line = n.Pattern.ReplaceAllString(line, n.Replacement)
+		}
+		h.Write([]byte(strings.TrimSpace(line)))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// aiBatch shares AI results between pods with identical failure signatures
+// during a single reconcile, so a mass outage costs one AI request per distinct
+// failure instead of one per pod. Pods analyzed while the first request of their
+// group is in flight wait for it instead of sending their own.
+type aiBatch struct {
+	mu      sync.Mutex
+	entries map[string]*aiBatchEntry
+}
+
+// aiBatchEntry is the AI request of a group of identically failing pods
+type aiBatchEntry struct {
+	// done is closed once the request completed
+	done chan struct{}
+	// result is a private copy of the successful result, shared as copies
+	result *infrav1alpha1.AIAnalysisResult
+	// podKey is the pod (namespace/name) whose logs were sent
+	podKey string
+	// groupSize counts the pods sharing the result, including podKey
+	groupSize int32
+}
+
+// newAIBatch creates an empty batch
+func newAIBatch() *aiBatch {
+	return &aiBatch{entries: make(map[string]*aiBatchEntry)}
+}
+
+// get returns a copy of the shared result for a signature, counting the pod as a
+// group member, and waits for a request in flight. Without a result, lead reports
+// whether the caller now sends the request of the group and must call put with its
+// result; it is false only if ctx ends while waiting.
+func (b *aiBatch) get(ctx context.Context, signature string) (shared *infrav1alpha1.AIAnalysisResult, lead bool) {
+	if b == nil {
+		return nil, false
+	}
+	for {
+		b.mu.Lock()
+		entry, exists := b.entries[signature]
+		if !exists {
+			b.entries[signature] = &aiBatchEntry{done: make(chan struct{})}
+			b.mu.Unlock()
+			return nil, true
+		}
+		if entry.result != nil {
+			entry.groupSize++
+			shared = entry.result.DeepCopy()
+			shared.SharedFrom, shared.GroupSize = entry.podKey, entry.groupSize
+			b.mu.Unlock()
+			return shared, false
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// put completes the request of a signature led by the caller of get. A successful
+// result is shared with the group; after a failure the next pod of the group retries
+// the request.
+func (b *aiBatch) put(signature, podKey string, result *infrav1alpha1.AIAnalysisResult) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, exists := b.entries[signature]
+	if !exists {
+		return
+	}
+	if result == nil || result.Error != "" {
+		delete(b.entries, signature)
+	} else {
+		entry.result = result.DeepCopy()
+		entry.podKey, entry.groupSize = podKey, 1
+	}
+	close(entry.done)
+}
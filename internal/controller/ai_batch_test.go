@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// TestAIBatchSharesInFlightRequest analyzes two identically failing pods on two
+// workers at once: the second waits for the first one's AI request and gets its own
+// copy of the result. Run with -race.
+func TestAIBatchSharesInFlightRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Keep the request in flight while the other worker reaches the batch
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"content": "The database at db:5432 refuses connections"}}]}`))
+	}))
+	defer server.Close()
+
+	config := &infrav1alpha1.LogAnalysisConfig{
+		Enabled: true,
+		MethodConfigs: []infrav1alpha1.MethodConfig{{
+			Type:     "ai",
+			AIConfig: &infrav1alpha1.AIConfig{Endpoint: server.URL, Format: "openai", Model: "test"},
+		}},
+	}
+	aiOpts := &aiRequestOptions{Batch: newAIBatch()}
+	lines := []string{"ERROR connection refused to db:5432"}
+
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-2"}},
+	}
+	results := make([]*infrav1alpha1.LogAnalysisResult, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = analyzeLogLines(context.Background(), nil, pod, config, lines, aiOpts)
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("AI requests = %d, want 1", got)
+	}
+	var leader, follower *infrav1alpha1.AIAnalysisResult
+	for _, result := range results {
+		if result == nil || result.AIResult == nil || result.AIResult.Error != "" {
+			t.Fatalf("missing AI result: %+v", result)
+		}
+		if result.AIResult.SharedFrom == "" {
+			leader = result.AIResult
+		} else {
+			follower = result.AIResult
+		}
+	}
+	if leader == nil || follower == nil {
+		t.Fatalf("want one sent and one shared result, got %+v and %+v", results[0].AIResult, results[1].AIResult)
+	}
+	if leader == follower {
+		t.Fatal("pods share the same result object")
+	}
+	if leader.GroupSize != 0 {
+		t.Errorf("sent result GroupSize = %d, want 0", leader.GroupSize)
+	}
+	if follower.GroupSize != 2 {
+		t.Errorf("shared result GroupSize = %d, want 2", follower.GroupSize)
+	}
+	if follower.RootCause != leader.RootCause {
+		t.Errorf("shared root cause %q, want %q", follower.RootCause, leader.RootCause)
+	}
+}
+
+func TestAIBatchRetriesFailedRequest(t *testing.T) {
+	batch := newAIBatch()
+	ctx := context.Background()
+
+	if shared, lead := batch.get(ctx, "sig"); shared != nil || !lead {
+		t.Fatalf("first get = %v, %v, want to lead", shared, lead)
+	}
+	waited := make(chan bool)
+	go func() {
+		shared, lead := batch.get(ctx, "sig")
+		waited <- shared == nil && lead
+	}()
+	batch.put("sig", "ns/a", &infrav1alpha1.AIAnalysisResult{Error: "timeout"})
+	if !<-waited {
+		t.Fatal("pod waiting on a failed request does not retry it")
+	}
+
+	result := &infrav1alpha1.AIAnalysisResult{RootCause: "cause"}
+	batch.put("sig", "ns/b", result)
+	for want := int32(2); want <= 3; want++ {
+		shared, _ := batch.get(ctx, "sig")
+		if shared == nil || shared.GroupSize != want || shared.SharedFrom != "ns/b" {
+			t.Fatalf("shared = %+v, want GroupSize %d from ns/b", shared, want)
+		}
+	}
+	if result.SharedFrom != "" || result.GroupSize != 0 {
+		t.Errorf("the sent result was changed: %+v", result)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, lead := batch.get(ctx, "other"); !lead {
+		t.Fatal("want to lead a new signature")
+	}
+	if shared, lead := batch.get(cancelled, "other"); shared != nil || lead {
+		t.Errorf("get with a cancelled context = %v, %v, want neither", shared, lead)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// aiEgressLogSize is how many outbound AI requests are kept in memory
+const aiEgressLogSize = 10000
+
+// AIEgressRecord describes an AI request that sent pod logs out of the cluster
+type AIEgressRecord struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Endpoint  string    `json:"endpoint"`
+	// Provider is the API format of the request, such as openai
+	Provider string `json:"provider"`
+	Model    string `json:"model,omitempty"`
+	// Lines is the number of log lines sent, and Bytes the size of the whole request
+	Lines int `json:"lines"`
+	Bytes int `json:"bytes"`
+	// Redacted is whether sensitive data was masked, by RedactionDetectors
+	Redacted           bool     `json:"redacted"`
+	RedactionDetectors []string `json:"redactionDetectors,omitempty"`
+	// Redactions is the number of values masked in the lines sent
+	Redactions int `json:"redactions"`
+	// StatusCode is the HTTP status returned by the endpoint, 0 if none was received
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AIEgressFilter selects AI egress records. Unset fields match any record.
+type AIEgressFilter struct {
+	Namespace string
+	Pod       string
+	Since     time.Time
+	// Limit is the maximum number of records returned, newest first
+	Limit int
+}
+
+var (
+	// aiEgressLog is a ring buffer of the latest outbound AI requests
+	aiEgressLog    = make([]AIEgressRecord, 0, aiEgressLogSize)
+	aiEgressNext   int
+	aiEgressLogMux sync.RWMutex
+
+	egressLog = log.Log.WithName("ai-egress")
+)
+
+// newAIEgressRecord describes the logs about to be sent for a pod
+func newAIEgressRecord(pod *corev1.Pod, endpoint, provider, model string, logLines []string, requestBody []byte, redaction *infrav1alpha1.RedactionConfig) AIEgressRecord {
+	record := AIEgressRecord{
+		Time:      time.Now(),
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Endpoint:  endpoint,
+		Provider:  provider,
+		Model:     model,
+		Lines:     len(logLines),
+		Bytes:     len(requestBody),
+	}
+	// The lines were redacted with this configuration when fetched, so an error here
+	// was already reported there
+	r, err := newRedactor(redaction)
+	if err == nil && r != nil && len(r.rules) > 0 {
+		record.Redacted = true
+		for _, rule := range r.rules {
+			record.RedactionDetectors = append(record.RedactionDetectors, rule.Name)
+		}
+		for _, line := range logLines {
+			record.Redactions += strings.Count(line, r.replacement)
+		}
+	}
+	return record
+}
+
+// recordAIEgress adds a sent AI request to the audit trail, and logs it so it
+// outlives the in-memory buffer
+func recordAIEgress(record AIEgressRecord) {
+	egressLog.Info("AI request sent",
+		"namespace", record.Namespace, "pod", record.Pod,
+		"endpoint", record.Endpoint, "provider", record.Provider, "model", record.Model,
+		"lines", record.Lines, "bytes", record.Bytes,
+		"redacted", record.Redacted, "redactions", record.Redactions,
+		"statusCode", record.StatusCode, "error", record.Error)
+
+	aiEgressLogMux.Lock()
+	defer aiEgressLogMux.Unlock()
+	if len(aiEgressLog) < aiEgressLogSize {
+		aiEgressLog = append(aiEgressLog, record)
+		return
+	}
+	aiEgressLog[aiEgressNext] = record
+	aiEgressNext = (aiEgressNext + 1) % aiEgressLogSize
+}
+
+// AIEgressRecords returns the recorded AI requests matching filter, newest first
+func AIEgressRecords(filter AIEgressFilter) []AIEgressRecord {
+	aiEgressLogMux.RLock()
+	defer aiEgressLogMux.RUnlock()
+	records := []AIEgressRecord{}
+	for i := range aiEgressLog {
+		// Walk back from the newest record
+		record := aiEgressLog[(aiEgressNext-1-i+2*len(aiEgressLog))%len(aiEgressLog)]
+		if !filter.Since.IsZero() && record.Time.Before(filter.Since) {
+			break
+		}
+		if filter.Namespace != "" && record.Namespace != filter.Namespace {
+			continue
+		}
+		if filter.Pod != "" && record.Pod != filter.Pod {
+			continue
+		}
+		records = append(records, record)
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
+	}
+	return records
+}
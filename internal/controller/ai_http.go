@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAITimeout is the overall timeout for an AI request
+	defaultAITimeout = 60 * time.Second
+	// defaultAIConnectTimeout is the timeout for establishing a connection to the AI endpoint
+	defaultAIConnectTimeout = 10 * time.Second
+)
+
+// aiClientOptions identifies the transport settings of a pooled AI HTTP client
+type aiClientOptions struct {
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	// ProxyURL overrides the proxy from the environment when set
+	ProxyURL string
+}
+
+var (
+	aiClients    = make(map[aiClientOptions]*http.Client)
+	aiClientsMux sync.Mutex
+)
+
+// getAIHTTPClient returns a shared HTTP client for the given options.
+// Clients are pooled so connections to AI endpoints are kept alive and reused
+// across reconciles. The overall request timeout is applied per request via
+// context, so it is not part of the client.
+func getAIHTTPClient(opts aiClientOptions) (*http.Client, error) {
+	if opts.ConnectTimeout <= 0 {
+		opts.ConnectTimeout = defaultAIConnectTimeout
+	}
+
+	aiClientsMux.Lock()
+	defer aiClientsMux.Unlock()
+
+	if httpClient, exists := aiClients[opts]; exists {
+		return httpClient, nil
+	}
+
+	// Honor HTTPS_PROXY/HTTP_PROXY/NO_PROXY unless an explicit proxy is configured
+	proxy := http.ProxyFromEnvironment
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   opts.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   opts.ConnectTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		ResponseHeaderTimeout: opts.ReadTimeout,
+		TLSClientConfig:       applyOutboundTLSPolicy(&tls.Config{}),
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+		// Redirects must not take logs to endpoints outside the allowlist
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return checkAIEndpoint(req.URL.String())
+		},
+	}
+	aiClients[opts] = httpClient
+	return httpClient, nil
+}
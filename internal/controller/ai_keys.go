@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// aiKeyCacheTTL is how long resolved AI API keys are reused, and so how long a
+// rotated key may take to be picked up
+const aiKeyCacheTTL = time.Minute
+
+// ErrAIKeySecretNamespaceNotAllowed is returned for API key secrets in a namespace
+// other than the pod's that the operator does not allow
+var ErrAIKeySecretNamespaceNotAllowed = errors.New("AI API key secret namespace not allowed by the operator")
+
+var (
+	// aiKeySecretNamespaces are the namespaces API key secrets of pods in other
+	// namespaces may be read from (empty = none)
+	aiKeySecretNamespaces    []string
+	aiKeySecretNamespacesMux sync.RWMutex
+)
+
+// SetAIKeySecretNamespaces allows AI configurations to read API key secrets from these
+// namespaces for pods of any namespace. No namespaces only allows the pod's.
+func SetAIKeySecretNamespaces(namespaces []string) {
+	var allowed []string
+	for _, namespace := range namespaces {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			allowed = append(allowed, namespace)
+		}
+	}
+	aiKeySecretNamespacesMux.Lock()
+	defer aiKeySecretNamespacesMux.Unlock()
+	aiKeySecretNamespaces = allowed
+}
+
+// aiKeySecretNamespace returns the namespace the API key secret of a pod is read from
+func aiKeySecretNamespace(secretNamespace string, pod *corev1.Pod) (string, error) {
+	if secretNamespace == "" || secretNamespace == pod.Namespace {
+		return pod.Namespace, nil
+	}
+	aiKeySecretNamespacesMux.RLock()
+	defer aiKeySecretNamespacesMux.RUnlock()
+	if !slices.Contains(aiKeySecretNamespaces, secretNamespace) {
+		return "", fmt.Errorf("%w: %s", ErrAIKeySecretNamespaceNotAllowed, secretNamespace)
+	}
+	return secretNamespace, nil
+}
+
+// aiKey is a resolved API key
+type aiKey struct {
+	value      string
+	resolvedAt time.Time
+}
+
+var (
+	// aiKeys caches resolved API keys by namespace/name/key, since pods of many
+	// namespaces share a secret
+	aiKeys    = map[string]aiKey{}
+	aiKeysMux sync.Mutex
+)
+
+// resolveAIKey returns the API key of a secret, reusing keys resolved within
+// aiKeyCacheTTL. Failed lookups are not cached.
+func resolveAIKey(ctx context.Context, k8sClient client.Client, secretRef *corev1.SecretKeySelector, namespace string) (string, error) {
+	if secretRef == nil {
+		return "", fmt.Errorf("secret reference is nil")
+	}
+	cacheKey := namespace + "/" + secretRef.Name + "/" + secretRef.Key
+	now := time.Now()
+
+	aiKeysMux.Lock()
+	cached, ok := aiKeys[cacheKey]
+	aiKeysMux.Unlock()
+	if ok && now.Sub(cached.resolvedAt) < aiKeyCacheTTL {
+		return cached.value, nil
+	}
+
+	value, err := getAPIKeyFromSecret(ctx, k8sClient, secretRef, namespace)
+	if err != nil {
+		return "", err
+	}
+	aiKeysMux.Lock()
+	defer aiKeysMux.Unlock()
+	// Drop expired keys, so keys of deleted secrets do not stay in memory
+	for key, entry := range aiKeys {
+		if now.Sub(entry.resolvedAt) >= aiKeyCacheTTL {
+			delete(aiKeys, key)
+		}
+	}
+	aiKeys[cacheKey] = aiKey{value: value, resolvedAt: now}
+	return value, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ErrAIQuotaExhausted is returned when an AI request would exceed a PodSleuth's quota
+var ErrAIQuotaExhausted = errors.New("AI request quota exhausted")
+
+const (
+	// ConditionAIQuotaExhausted is the condition of PodSleuths that used up an AI quota
+	ConditionAIQuotaExhausted = "AIQuotaExhausted"
+	// ReasonHourlyQuotaExhausted marks PodSleuths that used up their hourly AI quota
+	ReasonHourlyQuotaExhausted = "HourlyQuotaExhausted"
+	// ReasonDailyQuotaExhausted marks PodSleuths that used up their daily AI quota
+	ReasonDailyQuotaExhausted = "DailyQuotaExhausted"
+	// ReasonAIQuotaAvailable clears the condition once AI requests are allowed again
+	ReasonAIQuotaAvailable = "AIQuotaAvailable"
+)
+
+// aiQuota counts the AI requests of a PodSleuth over clock hours and days in UTC.
+// A nil quota, or a maximum of 0, means unlimited.
+type aiQuota struct {
+	mux        sync.Mutex
+	maxPerHour int32
+	maxPerDay  int32
+	usage      infrav1alpha1.AIQuotaUsage
+}
+
+// roll starts new windows once the hour or the day of the usage ended
+func (q *aiQuota) roll(now time.Time) {
+	hourStart := now.UTC().Truncate(time.Hour)
+	dayStart := now.UTC().Truncate(24 * time.Hour)
+	if !q.usage.HourStart.Time.Equal(hourStart) {
+		q.usage.HourStart, q.usage.HourRequests = metav1.NewTime(hourStart), 0
+	}
+	if !q.usage.DayStart.Time.Equal(dayStart) {
+		q.usage.DayStart, q.usage.DayRequests = metav1.NewTime(dayStart), 0
+	}
+}
+
+// exhaustedReason returns the reason of the quota used up at now, empty if none is
+func (q *aiQuota) exhaustedReason() string {
+	switch {
+	case q.maxPerDay > 0 && q.usage.DayRequests >= q.maxPerDay:
+		return ReasonDailyQuotaExhausted
+	case q.maxPerHour > 0 && q.usage.HourRequests >= q.maxPerHour:
+		return ReasonHourlyQuotaExhausted
+	}
+	return ""
+}
+
+// take counts an AI request, or returns ErrAIQuotaExhausted if a quota is used up
+func (q *aiQuota) take(now time.Time) error {
+	if q == nil {
+		return nil
+	}
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.roll(now)
+	if q.exhaustedReason() != "" {
+		return ErrAIQuotaExhausted
+	}
+	q.usage.HourRequests++
+	q.usage.DayRequests++
+	return nil
+}
+
+// state returns the usage at now and the reason of the quota used up, if any
+func (q *aiQuota) state(now time.Time) (infrav1alpha1.AIQuotaUsage, string) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.roll(now)
+	return q.usage, q.exhaustedReason()
+}
+
+// getAIQuota returns the AI quota of a PodSleuth, created with the usage stored in its
+// status so restarts do not reset it, and updated when the configured maximums change.
+// Returns nil if no quota is configured.
+func (r *PodSleuthReconciler) getAIQuota(podSleuth *infrav1alpha1.PodSleuth) *aiQuota {
+	var maxPerHour, maxPerDay int32
+	if podSleuth.Spec.LogAnalysis != nil && podSleuth.Spec.LogAnalysis.AIQuota != nil {
+		cfg := podSleuth.Spec.LogAnalysis.AIQuota
+		if cfg.MaxPerHour != nil {
+			maxPerHour = *cfg.MaxPerHour
+		}
+		if cfg.MaxPerDay != nil {
+			maxPerDay = *cfg.MaxPerDay
+		}
+	}
+
+	r.aiQuotasMux.Lock()
+	defer r.aiQuotasMux.Unlock()
+
+	if maxPerHour <= 0 && maxPerDay <= 0 {
+		delete(r.aiQuotas, podSleuth.Name)
+		return nil
+	}
+
+	if r.aiQuotas == nil {
+		r.aiQuotas = make(map[string]*aiQuota)
+	}
+
+	quota, exists := r.aiQuotas[podSleuth.Name]
+	if !exists {
+		quota = &aiQuota{}
+		if podSleuth.Status.AIQuota != nil {
+			quota.usage = *podSleuth.Status.AIQuota
+		}
+		r.aiQuotas[podSleuth.Name] = quota
+	}
+	quota.mux.Lock()
+	quota.maxPerHour, quota.maxPerDay = maxPerHour, maxPerDay
+	quota.mux.Unlock()
+
+	return quota
+}
+
+// forgetAIQuota drops the AI quota of a PodSleuth
+func (r *PodSleuthReconciler) forgetAIQuota(podSleuthName string) {
+	r.aiQuotasMux.Lock()
+	defer r.aiQuotasMux.Unlock()
+	delete(r.aiQuotas, podSleuthName)
+}
+
+// updateAIQuotaStatus stores the AI quota usage of a PodSleuth in its status and sets
+// the AIQuotaExhausted condition, with an Event when a quota is first used up
+func (r *PodSleuthReconciler) updateAIQuotaStatus(podSleuth *infrav1alpha1.PodSleuth, quota *aiQuota, now time.Time) {
+	if quota == nil {
+		podSleuth.Status.AIQuota = nil
+		meta.RemoveStatusCondition(&podSleuth.Status.Conditions, ConditionAIQuotaExhausted)
+		recordAIQuotaUsage(podSleuth.Name, nil)
+		return
+	}
+	usage, reason := quota.state(now)
+	podSleuth.Status.AIQuota = &usage
+	recordAIQuotaUsage(podSleuth.Name, &usage)
+
+	if reason == "" {
+		if meta.FindStatusCondition(podSleuth.Status.Conditions, ConditionAIQuotaExhausted) != nil {
+			meta.SetStatusCondition(&podSleuth.Status.Conditions, metav1.Condition{
+				Type:               ConditionAIQuotaExhausted,
+				Status:             metav1.ConditionFalse,
+				Reason:             ReasonAIQuotaAvailable,
+				Message:            "AI requests are within the quota",
+				ObservedGeneration: podSleuth.Generation,
+			})
+		}
+		return
+	}
+	message := fmt.Sprintf("AI analysis is skipped until %s, %d of %d AI requests per day used",
+		usage.DayStart.Add(24*time.Hour).Format(time.RFC3339), usage.DayRequests, quota.maxPerDay)
+	if reason == ReasonHourlyQuotaExhausted {
+		message = fmt.Sprintf("AI analysis is skipped until %s, %d of %d AI requests per hour used",
+			usage.HourStart.Add(time.Hour).Format(time.RFC3339), usage.HourRequests, quota.maxPerHour)
+	}
+	if r.Recorder != nil && !meta.IsStatusConditionTrue(podSleuth.Status.Conditions, ConditionAIQuotaExhausted) {
+		r.Recorder.Event(podSleuth, corev1.EventTypeWarning, ConditionAIQuotaExhausted, message)
+	}
+	meta.SetStatusCondition(&podSleuth.Status.Conditions, metav1.Condition{
+		Type:               ConditionAIQuotaExhausted,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: podSleuth.Generation,
+	})
+}
+
+// recordAIQuotaUsage replaces the AI quota series of a PodSleuth with its usage
+func recordAIQuotaUsage(podSleuthName string, usage *infrav1alpha1.AIQuotaUsage) {
+	aiQuotaRequests.DeleteLabelValues(podSleuthName, "hour")
+	aiQuotaRequests.DeleteLabelValues(podSleuthName, "day")
+	if usage == nil {
+		return
+	}
+	aiQuotaRequests.WithLabelValues(podSleuthName, "hour").Set(float64(usage.HourRequests))
+	aiQuotaRequests.WithLabelValues(podSleuthName, "day").Set(float64(usage.DayRequests))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ErrAIRateLimited is returned when an AI request is rejected by a rate limiter
+var ErrAIRateLimited = errors.New("AI request rate limit exceeded")
+
+// AIRateLimiter bounds the rate and concurrency of outbound AI requests.
+// A nil limiter, or a limit of 0, means unlimited.
+type AIRateLimiter struct {
+	mux               sync.Mutex
+	requestsPerMinute int32
+	maxConcurrent     int32
+
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// NewAIRateLimiter creates a limiter allowing requestsPerMinute requests per minute
+// and at most maxConcurrent requests in flight
+func NewAIRateLimiter(requestsPerMinute, maxConcurrent int32) *AIRateLimiter {
+	l := &AIRateLimiter{}
+	l.SetLimits(requestsPerMinute, maxConcurrent)
+	return l
+}
+
+// SetLimits changes the limits of the limiter. Requests in flight keep their slots
+// until they complete, without counting against a new concurrency limit.
+func (l *AIRateLimiter) SetLimits(requestsPerMinute, maxConcurrent int32) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if requestsPerMinute != l.requestsPerMinute {
+		l.limiter = nil
+		if requestsPerMinute > 0 {
+			// Allow a full minute's worth of requests as burst so a few failing pods
+			// are analyzed immediately, while a mass outage is spread over time
+			l.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), int(requestsPerMinute))
+		}
+	}
+	if maxConcurrent != l.maxConcurrent {
+		l.slots = nil
+		if maxConcurrent > 0 {
+			l.slots = make(chan struct{}, maxConcurrent)
+		}
+	}
+	l.requestsPerMinute, l.maxConcurrent = requestsPerMinute, maxConcurrent
+}
+
+// Acquire reserves a request slot without blocking.
+// It returns a release function that must be called when the request completes,
+// or ErrAIRateLimited if the request would exceed the configured limits.
+func (l *AIRateLimiter) Acquire(ctx context.Context) (func(), error) {
+	release, _, err := l.reserve(ctx)
+	return release, err
+}
+
+// reserve takes a concurrency slot and a rate token without blocking. release frees
+// the slot when the request completes; cancel frees it and refunds the token of a
+// request that is not sent after all.
+func (l *AIRateLimiter) reserve(ctx context.Context) (release, cancel func(), err error) {
+	if l == nil {
+		return func() {}, func() {}, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	l.mux.Lock()
+	limiter, slots := l.limiter, l.slots
+	l.mux.Unlock()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		default:
+			return nil, nil, ErrAIRateLimited
+		}
+	}
+	release = func() {
+		if slots != nil {
+			<-slots
+		}
+	}
+
+	if limiter == nil {
+		return release, release, nil
+	}
+	// Tokens are only refunded by a cancellation at the time they were reserved for
+	now := time.Now()
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+		release()
+		return nil, nil, ErrAIRateLimited
+	}
+	return release, func() {
+		reservation.CancelAt(now)
+		release()
+	}, nil
+}
+
+// matches reports whether the limiter has the given limits
+func (l *AIRateLimiter) matches(requestsPerMinute, maxConcurrent int32) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.requestsPerMinute == requestsPerMinute && l.maxConcurrent == maxConcurrent
+}
+
+// acquireAIRequest acquires a slot from every limiter in order. If any limiter rejects
+// the request, the slots and tokens already taken are given back, so a request refused
+// by a narrower limiter does not spend the budget of a wider one. cancel does the same
+// for a request that is admitted but not sent.
+func acquireAIRequest(ctx context.Context, limiters ...*AIRateLimiter) (release, cancel func(), err error) {
+	var releases, cancels []func()
+	undo := func(funcs []func()) func() {
+		return func() {
+			for i := len(funcs) - 1; i >= 0; i-- {
+				funcs[i]()
+			}
+		}
+	}
+
+	for _, l := range limiters {
+		release, cancel, err := l.reserve(ctx)
+		if err != nil {
+			undo(cancels)()
+			return nil, nil, err
+		}
+		releases = append(releases, release)
+		cancels = append(cancels, cancel)
+	}
+
+	return undo(releases), undo(cancels), nil
+}
+
+// getAIRateLimiter returns the limiter for a PodSleuth, creating or replacing it
+// when the configured limits change. Returns nil if no limits are configured.
+func (r *PodSleuthReconciler) getAIRateLimiter(podSleuth *infrav1alpha1.PodSleuth) *AIRateLimiter {
+	var requestsPerMinute, maxConcurrent int32
+	if podSleuth.Spec.LogAnalysis != nil && podSleuth.Spec.LogAnalysis.AIRateLimit != nil {
+		cfg := podSleuth.Spec.LogAnalysis.AIRateLimit
+		if cfg.RequestsPerMinute != nil {
+			requestsPerMinute = *cfg.RequestsPerMinute
+		}
+		if cfg.MaxConcurrentRequests != nil {
+			maxConcurrent = *cfg.MaxConcurrentRequests
+		}
+	}
+
+	r.aiLimitersMux.Lock()
+	defer r.aiLimitersMux.Unlock()
+
+	if requestsPerMinute <= 0 && maxConcurrent <= 0 {
+		delete(r.aiLimiters, podSleuth.Name)
+		return nil
+	}
+
+	if r.aiLimiters == nil {
+		r.aiLimiters = make(map[string]*AIRateLimiter)
+	}
+
+	limiter, exists := r.aiLimiters[podSleuth.Name]
+	if !exists || !limiter.matches(requestsPerMinute, maxConcurrent) {
+		limiter = NewAIRateLimiter(requestsPerMinute, maxConcurrent)
+		r.aiLimiters[podSleuth.Name] = limiter
+	}
+
+	return limiter
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestAcquireAIRequestRefundsRefusedRequests(t *testing.T) {
+	ctx := context.Background()
+	global := NewAIRateLimiter(3, 0)
+	noisy := NewAIRateLimiter(1, 0)
+	quiet := NewAIRateLimiter(10, 0)
+
+	release, _, err := acquireAIRequest(ctx, global, noisy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	// The noisy PodSleuth is refused without spending the operator-wide budget
+	for range 5 {
+		if _, _, err := acquireAIRequest(ctx, global, noisy); !errors.Is(err, ErrAIRateLimited) {
+			t.Fatalf("got %v, want %v", err, ErrAIRateLimited)
+		}
+	}
+	for i := range 2 {
+		if _, _, err := acquireAIRequest(ctx, global, quiet); err != nil {
+			t.Fatalf("request %d of another PodSleuth: %v", i+1, err)
+		}
+	}
+	if _, _, err := acquireAIRequest(ctx, global, quiet); !errors.Is(err, ErrAIRateLimited) {
+		t.Errorf("got %v after the operator-wide budget was spent, want %v", err, ErrAIRateLimited)
+	}
+}
+
+func TestAcquireAIRequestCancel(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewAIRateLimiter(1, 1)
+
+	_, cancel, err := acquireAIRequest(ctx, limiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A request that is not sent, e.g. over its quota, gives back its slot and token
+	cancel()
+	release, _, err := acquireAIRequest(ctx, limiter)
+	if err != nil {
+		t.Fatalf("after a cancelled request: %v", err)
+	}
+	if _, _, err := acquireAIRequest(ctx, NewAIRateLimiter(0, 0), limiter); !errors.Is(err, ErrAIRateLimited) {
+		t.Errorf("got %v with the only slot taken, want %v", err, ErrAIRateLimited)
+	}
+	release()
+}
+
+func TestAIRateLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *infrav1alpha1.LogAnalysisResult
+		want   bool
+	}{
+		{"no result", nil, false},
+		{"pattern only", &infrav1alpha1.LogAnalysisResult{RootCause: "OOM"}, false},
+		{"rate limited", &infrav1alpha1.LogAnalysisResult{AIResult: &infrav1alpha1.AIAnalysisResult{Error: fmt.Sprintf("AI analysis failed: %v", ErrAIRateLimited)}}, true},
+		{"provider error", &infrav1alpha1.LogAnalysisResult{AIResult: &infrav1alpha1.AIAnalysisResult{Error: "AI analysis failed: 500 Internal Server Error"}}, false},
+	}
+	for _, tt := range tests {
+		if got := aiRateLimited(tt.result); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
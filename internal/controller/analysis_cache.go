@@ -0,0 +1,418 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultAnalysisCacheMaxEntries is the default bound on the number of cached analyses
+	DefaultAnalysisCacheMaxEntries = 5000
+	// DefaultAnalysisCacheMaxBytes is the default bound on the approximate cache size (64 MiB)
+	DefaultAnalysisCacheMaxBytes = 64 << 20
+
+	// defaultNegativeCacheTTL is how long outcomes without log output are cached
+	defaultNegativeCacheTTL = time.Minute
+)
+
+// CachedAnalysisResult represents a cached log analysis result for a pod
+type CachedAnalysisResult struct {
+	PodSleuth    string
+	ConfigHash   string
+	PodUID       types.UID
+	PodNamespace string
+	PodName      string
+	RestartCount int32
+	Result       *infrav1alpha1.LogAnalysisResult
+	CachedAt     time.Time
+	ExpiresAt    time.Time
+	// Negative marks an outcome without log output; Result is nil
+	Negative bool
+
+	// size is the approximate memory footprint of the entry in bytes
+	size int64
+	// element is the entry's position in the LRU list
+	element *list.Element
+}
+
+// getCacheKey generates a cache key for a pod based on the PodSleuth, the analysis
+// configuration hash, the pod UID and restart count
+func getCacheKey(podSleuthName, configHash string, pod *corev1.Pod) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d/%s", podSleuthName, pod.Namespace, pod.Name, pod.UID, getMaxRestartCount(pod), configHash)
+}
+
+// getMaxRestartCount returns the highest restart count of all containers in a pod
+func getMaxRestartCount(pod *corev1.Pod) int32 {
+	maxRestartCount := int32(0)
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount > maxRestartCount {
+			maxRestartCount = cs.RestartCount
+		}
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.RestartCount > maxRestartCount {
+			maxRestartCount = cs.RestartCount
+		}
+	}
+	return maxRestartCount
+}
+
+// logAnalysisConfigHash returns a short hash of the configuration fields that affect
+// analysis results. Cache and throughput settings are excluded so tuning them does
+// not discard cached results.
+func logAnalysisConfigHash(config *infrav1alpha1.LogAnalysisConfig) string {
+	effective := config.DeepCopy()
+	effective.CacheEnabled = nil
+	effective.CacheTTL = nil
+	effective.NegativeCacheTTL = nil
+	effective.AIRateLimit = nil
+	effective.AIQuota = nil
+	effective.BatchAIRequests = nil
+
+	data, err := json.Marshal(effective)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// estimateCacheEntrySize approximates the memory used by a cached result by its JSON size
+func estimateCacheEntrySize(key string, result *infrav1alpha1.LogAnalysisResult) int64 {
+	size := int64(len(key))
+	if data, err := json.Marshal(result); err == nil {
+		size += int64(len(data))
+	}
+	return size
+}
+
+// getCachedAnalysis retrieves a cached analysis result if it exists and hasn't expired.
+// The boolean reports a cache hit; a hit with a nil result is a cached negative outcome.
+// The result is a copy, so callers may change it.
+func (r *PodSleuthReconciler) getCachedAnalysis(podSleuthName, configHash string, pod *corev1.Pod) (*infrav1alpha1.LogAnalysisResult, bool) {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	cacheKey := getCacheKey(podSleuthName, configHash, pod)
+	cached, exists := r.analysisCache[cacheKey]
+	if !exists {
+		analysisCacheMisses.Inc()
+		cacheMissCount.Add(1)
+		return nil, false
+	}
+
+	// Check if cache has expired
+	now := time.Now()
+	if now.After(cached.ExpiresAt) {
+		r.removeCacheEntryLocked(cacheKey, evictionReasonExpired)
+		analysisCacheMisses.Inc()
+		cacheMissCount.Add(1)
+		return nil, false
+	}
+
+	r.analysisCacheLRU.MoveToFront(cached.element)
+	analysisCacheHits.Inc()
+	cacheHitCount.Add(1)
+	analysisCacheEntryAge.Observe(now.Sub(cached.CachedAt).Seconds())
+	return cached.Result.DeepCopy(), true
+}
+
+// setNegativeCachedAnalysis caches that a pod produced no log output, so its logs are not
+// fetched again until the TTL expires or the pod restarts
+func (r *PodSleuthReconciler) setNegativeCachedAnalysis(podSleuthName, configHash string, pod *corev1.Pod, cacheTTL time.Duration) {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	now := time.Now()
+	r.storeCacheEntryLocked(getCacheKey(podSleuthName, configHash, pod), &CachedAnalysisResult{
+		PodSleuth:    podSleuthName,
+		ConfigHash:   configHash,
+		PodUID:       pod.UID,
+		PodNamespace: pod.Namespace,
+		PodName:      pod.Name,
+		RestartCount: getMaxRestartCount(pod),
+		CachedAt:     now,
+		ExpiresAt:    now.Add(cacheTTL),
+		Negative:     true,
+	})
+}
+
+// setCachedAnalysis stores an analysis result in the cache
+func (r *PodSleuthReconciler) setCachedAnalysis(podSleuthName, configHash string, pod *corev1.Pod, result *infrav1alpha1.LogAnalysisResult, cacheTTL time.Duration) {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	cacheKey := getCacheKey(podSleuthName, configHash, pod)
+	now := time.Now()
+	expiresAt := now.Add(cacheTTL)
+
+	// Set CachedAt and CacheExpiresAt timestamps in the result
+	result.CachedAt = metav1.NewTime(now)
+	cacheExpiresAtTime := metav1.NewTime(expiresAt)
+	result.CacheExpiresAt = &cacheExpiresAtTime
+	result.CacheKey = cacheKey
+
+	r.storeCacheEntryLocked(cacheKey, &CachedAnalysisResult{
+		PodSleuth:    podSleuthName,
+		ConfigHash:   configHash,
+		PodUID:       pod.UID,
+		PodNamespace: pod.Namespace,
+		PodName:      pod.Name,
+		RestartCount: getMaxRestartCount(pod),
+		Result:       result.DeepCopy(),
+		CachedAt:     now,
+		ExpiresAt:    expiresAt,
+	})
+}
+
+// storeCacheEntryLocked inserts or replaces a cache entry and evicts the least recently
+// used entries while the cache is over its entry or byte limit.
+// The caller must hold analysisCacheMux.
+func (r *PodSleuthReconciler) storeCacheEntryLocked(cacheKey string, entry *CachedAnalysisResult) {
+	if r.analysisCache == nil {
+		r.analysisCache = make(map[string]*CachedAnalysisResult)
+		r.analysisCacheLRU = list.New()
+	}
+
+	if _, exists := r.analysisCache[cacheKey]; exists {
+		r.removeCacheEntryLocked(cacheKey, "")
+	}
+
+	entry.size = estimateCacheEntrySize(cacheKey, entry.Result)
+	entry.element = r.analysisCacheLRU.PushFront(cacheKey)
+	r.analysisCache[cacheKey] = entry
+	r.analysisCacheSize += entry.size
+
+	for r.analysisCacheLRU.Len() > 1 && r.cacheOverLimitLocked() {
+		oldest := r.analysisCacheLRU.Back()
+		r.removeCacheEntryLocked(oldest.Value.(string), evictionReasonCapacity)
+	}
+
+	analysisCacheEntries.Set(float64(len(r.analysisCache)))
+	analysisCacheBytes.Set(float64(r.analysisCacheSize))
+}
+
+// cacheOverLimitLocked reports whether the cache exceeds its configured bounds
+func (r *PodSleuthReconciler) cacheOverLimitLocked() bool {
+	if r.AnalysisCacheMaxEntries > 0 && len(r.analysisCache) > r.AnalysisCacheMaxEntries {
+		return true
+	}
+	return r.AnalysisCacheMaxBytes > 0 && r.analysisCacheSize > r.AnalysisCacheMaxBytes
+}
+
+// removeCacheEntryLocked deletes a cache entry and records the eviction reason, if any.
+// The caller must hold analysisCacheMux.
+func (r *PodSleuthReconciler) removeCacheEntryLocked(cacheKey, reason string) {
+	entry, exists := r.analysisCache[cacheKey]
+	if !exists {
+		return
+	}
+
+	r.analysisCacheLRU.Remove(entry.element)
+	delete(r.analysisCache, cacheKey)
+	r.analysisCacheSize -= entry.size
+
+	if reason != "" {
+		analysisCacheEvictions.WithLabelValues(reason).Inc()
+	}
+	analysisCacheEntries.Set(float64(len(r.analysisCache)))
+	analysisCacheBytes.Set(float64(r.analysisCacheSize))
+}
+
+// restoreCacheFromStatus seeds the analysis cache from results persisted in the PodSleuth status.
+// This runs once per PodSleuth per operator process; entries are only restored if they have
+// not expired and the cache does not already hold a newer result for the pod.
+func (r *PodSleuthReconciler) restoreCacheFromStatus(podSleuth *infrav1alpha1.PodSleuth) {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	if r.cacheRestored == nil {
+		r.cacheRestored = make(map[string]bool)
+	}
+	if r.cacheRestored[podSleuth.Name] {
+		return
+	}
+	r.cacheRestored[podSleuth.Name] = true
+
+	now := time.Now()
+	restored := 0
+	for i := range podSleuth.Status.NonReadyPods {
+		podInfo := &podSleuth.Status.NonReadyPods[i]
+		result := podInfo.LogAnalysis
+		if result == nil || result.CacheKey == "" || result.CacheExpiresAt == nil {
+			continue
+		}
+		if now.After(result.CacheExpiresAt.Time) {
+			continue
+		}
+		if _, exists := r.analysisCache[result.CacheKey]; exists {
+			continue
+		}
+
+		entry := &CachedAnalysisResult{
+			PodNamespace: podInfo.Namespace,
+			PodName:      podInfo.Name,
+			Result:       result.DeepCopy(),
+			CachedAt:     result.CachedAt.Time,
+			ExpiresAt:    result.CacheExpiresAt.Time,
+		}
+		// Cache keys have the form podSleuth/namespace/name/uid/restartCount/configHash
+		parts := strings.Split(result.CacheKey, "/")
+		if len(parts) != 6 || parts[0] != podSleuth.Name {
+			continue
+		}
+		entry.PodSleuth = parts[0]
+		entry.PodUID = types.UID(parts[3])
+		if restartCount, err := strconv.ParseInt(parts[4], 10, 32); err == nil {
+			entry.RestartCount = int32(restartCount)
+		}
+		entry.ConfigHash = parts[5]
+		r.storeCacheEntryLocked(result.CacheKey, entry)
+		restored++
+	}
+
+	if restored > 0 {
+		log.Log.Info("restored analysis cache from status", "podSleuth", podSleuth.Name, "entries", restored)
+	}
+}
+
+// cleanupCache removes stale cache entries of a PodSleuth for pods that no longer exist or are ready,
+// and entries created with a previous analysis configuration.
+// A nil currentPods drops all entries of the PodSleuth.
+func (r *PodSleuthReconciler) cleanupCache(podSleuthName string, currentPods map[string]bool) {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	if currentPods == nil {
+		delete(r.cacheRestored, podSleuthName)
+	}
+
+	// Remove entries for pods that are no longer in the non-ready list
+	for key, entry := range r.analysisCache {
+		if entry.PodSleuth == podSleuthName && !currentPods[key] {
+			r.removeCacheEntryLocked(key, evictionReasonStale)
+		}
+	}
+}
+
+// CacheEntryInfo describes a cached analysis for the cache administration API
+type CacheEntryInfo struct {
+	Key                 string    `json:"key"`
+	PodSleuth           string    `json:"podSleuth"`
+	Namespace           string    `json:"namespace"`
+	Pod                 string    `json:"pod"`
+	PodUID              types.UID `json:"podUID"`
+	RestartCount        int32     `json:"restartCount"`
+	ConfigHash          string    `json:"configHash"`
+	CachedAt            time.Time `json:"cachedAt"`
+	ExpiresAt           time.Time `json:"expiresAt"`
+	AgeSeconds          int64     `json:"ageSeconds"`
+	TTLRemainingSeconds int64     `json:"ttlRemainingSeconds"`
+	SizeBytes           int64     `json:"sizeBytes"`
+	Negative            bool      `json:"negative"`
+}
+
+// CacheEntries returns all cached analyses, most recently used first
+func (r *PodSleuthReconciler) CacheEntries() []CacheEntryInfo {
+	r.analysisCacheMux.RLock()
+	defer r.analysisCacheMux.RUnlock()
+
+	entries := make([]CacheEntryInfo, 0, len(r.analysisCache))
+	if r.analysisCacheLRU == nil {
+		return entries
+	}
+
+	now := time.Now()
+	for e := r.analysisCacheLRU.Front(); e != nil; e = e.Next() {
+		key := e.Value.(string)
+		entry := r.analysisCache[key]
+		entries = append(entries, CacheEntryInfo{
+			Key:                 key,
+			PodSleuth:           entry.PodSleuth,
+			Namespace:           entry.PodNamespace,
+			Pod:                 entry.PodName,
+			PodUID:              entry.PodUID,
+			RestartCount:        entry.RestartCount,
+			ConfigHash:          entry.ConfigHash,
+			CachedAt:            entry.CachedAt,
+			ExpiresAt:           entry.ExpiresAt,
+			AgeSeconds:          int64(now.Sub(entry.CachedAt).Seconds()),
+			TTLRemainingSeconds: max(int64(entry.ExpiresAt.Sub(now).Seconds()), 0),
+			SizeBytes:           entry.size,
+			Negative:            entry.Negative,
+		})
+	}
+	return entries
+}
+
+// PodAnalysis is a cached analysis of a pod, with the error lines left out of status
+type PodAnalysis struct {
+	PodSleuth string                           `json:"podSleuth"`
+	ExpiresAt time.Time                        `json:"expiresAt"`
+	Result    *infrav1alpha1.LogAnalysisResult `json:"result"`
+}
+
+// PodAnalyses returns the cached analyses of a pod
+func (r *PodSleuthReconciler) PodAnalyses(namespace, name string) []PodAnalysis {
+	r.analysisCacheMux.RLock()
+	defer r.analysisCacheMux.RUnlock()
+
+	analyses := []PodAnalysis{}
+	for _, entry := range r.analysisCache {
+		if entry.PodNamespace != namespace || entry.PodName != name || entry.Result == nil {
+			continue
+		}
+		analyses = append(analyses, PodAnalysis{
+			PodSleuth: entry.PodSleuth,
+			ExpiresAt: entry.ExpiresAt,
+			Result:    entry.Result.DeepCopy(),
+		})
+	}
+	return analyses
+}
+
+// InvalidateCache removes cached analyses for a pod, or all cached analyses if
+// namespace and name are empty. Returns the number of removed entries.
+func (r *PodSleuthReconciler) InvalidateCache(namespace, name string) int {
+	r.analysisCacheMux.Lock()
+	defer r.analysisCacheMux.Unlock()
+
+	removed := 0
+	for key, entry := range r.analysisCache {
+		if namespace != "" && (entry.PodNamespace != namespace || entry.PodName != name) {
+			continue
+		}
+		r.removeCacheEntryLocked(key, "")
+		removed++
+	}
+	return removed
+}
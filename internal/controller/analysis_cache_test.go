@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// cacheTestPod returns a pod named name in the shop namespace
+func cacheTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, UID: types.UID(name + "-uid")}}
+}
+
+// cachedPods returns which of the pods have a cache entry, without touching the LRU order
+func cachedPods(r *PodSleuthReconciler, configHash string, names ...string) map[string]bool {
+	cached := map[string]bool{}
+	for _, name := range names {
+		_, cached[name] = r.analysisCache[getCacheKey("sleuth", configHash, cacheTestPod(name))]
+	}
+	return cached
+}
+
+func TestAnalysisCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	r := &PodSleuthReconciler{AnalysisCacheMaxEntries: 3}
+	for _, name := range []string{"a", "b", "c"} {
+		r.setCachedAnalysis("sleuth", "hash", cacheTestPod(name), &infrav1alpha1.LogAnalysisResult{RootCause: name}, time.Hour)
+	}
+	// Reading a makes b the least recently used entry
+	if result, hit := r.getCachedAnalysis("sleuth", "hash", cacheTestPod("a")); !hit || result.RootCause != "a" {
+		t.Fatalf("get a = %v, %v, want a hit", result, hit)
+	}
+	r.setCachedAnalysis("sleuth", "hash", cacheTestPod("d"), &infrav1alpha1.LogAnalysisResult{RootCause: "d"}, time.Hour)
+
+	want := map[string]bool{"a": true, "b": false, "c": true, "d": true}
+	if got := cachedPods(r, "hash", "a", "b", "c", "d"); !maps.Equal(got, want) {
+		t.Errorf("cached pods = %v, want %v", got, want)
+	}
+	if r.analysisCacheLRU.Len() != 3 || len(r.analysisCache) != 3 {
+		t.Errorf("LRU holds %d and map %d entries, want 3", r.analysisCacheLRU.Len(), len(r.analysisCache))
+	}
+
+	// c and a follow as the oldest entries
+	for _, name := range []string{"e", "f"} {
+		r.setCachedAnalysis("sleuth", "hash", cacheTestPod(name), &infrav1alpha1.LogAnalysisResult{RootCause: name}, time.Hour)
+	}
+	want = map[string]bool{"a": false, "c": false, "d": true, "e": true, "f": true}
+	if got := cachedPods(r, "hash", "a", "c", "d", "e", "f"); !maps.Equal(got, want) {
+		t.Errorf("cached pods = %v, want %v", got, want)
+	}
+}
+
+func TestAnalysisCacheEvictsOverByteLimit(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	small := &infrav1alpha1.LogAnalysisResult{RootCause: "small"}
+	r.setCachedAnalysis("sleuth", "hash", cacheTestPod("a"), small, time.Hour)
+	r.AnalysisCacheMaxBytes = r.analysisCacheSize * 2
+
+	r.setCachedAnalysis("sleuth", "hash", cacheTestPod("b"), &infrav1alpha1.LogAnalysisResult{RootCause: "small"}, time.Hour)
+	r.setCachedAnalysis("sleuth", "hash", cacheTestPod("c"), &infrav1alpha1.LogAnalysisResult{RootCause: "small"}, time.Hour)
+
+	want := map[string]bool{"a": false, "b": true, "c": true}
+	if got := cachedPods(r, "hash", "a", "b", "c"); !maps.Equal(got, want) {
+		t.Errorf("cached pods = %v, want %v", got, want)
+	}
+	if r.analysisCacheSize > r.AnalysisCacheMaxBytes {
+		t.Errorf("cache size %d over the limit %d", r.analysisCacheSize, r.AnalysisCacheMaxBytes)
+	}
+}
+
+func TestAnalysisCacheConfigHash(t *testing.T) {
+	config := &infrav1alpha1.LogAnalysisConfig{
+		Enabled:       true,
+		MethodConfigs: []infrav1alpha1.MethodConfig{{Type: "pattern"}},
+	}
+	hash := logAnalysisConfigHash(config)
+
+	// Tuning the cache or AI throughput keeps cached results
+	tuned := config.DeepCopy()
+	tuned.CacheTTL = &metav1.Duration{Duration: time.Hour}
+	tuned.AIQuota = &infrav1alpha1.AIQuotaConfig{}
+	if got := logAnalysisConfigHash(tuned); got != hash {
+		t.Errorf("hash changed with cache settings: %s, want %s", got, hash)
+	}
+
+	// Changing how pods are analyzed invalidates them
+	changed := config.DeepCopy()
+	changed.MethodConfigs = append(changed.MethodConfigs, infrav1alpha1.MethodConfig{Type: "ai"})
+	changedHash := logAnalysisConfigHash(changed)
+	if changedHash == hash {
+		t.Fatal("hash unchanged with another analysis method")
+	}
+
+	r := &PodSleuthReconciler{}
+	pod := cacheTestPod("a")
+	r.setCachedAnalysis("sleuth", hash, pod, &infrav1alpha1.LogAnalysisResult{RootCause: "pattern"}, time.Hour)
+	if _, hit := r.getCachedAnalysis("sleuth", hash, pod); !hit {
+		t.Error("miss with the configuration the result was cached with")
+	}
+	if result, hit := r.getCachedAnalysis("sleuth", changedHash, pod); hit {
+		t.Errorf("hit %v after the configuration changed", result)
+	}
+
+	// A restart invalidates the entry too
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", RestartCount: 1}}
+	if _, hit := r.getCachedAnalysis("sleuth", hash, pod); hit {
+		t.Error("hit after the pod restarted")
+	}
+}
+
+func TestAnalysisCacheNegativeEntryExpires(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	pod := cacheTestPod("quiet")
+
+	r.setNegativeCachedAnalysis("sleuth", "hash", pod, time.Hour)
+	if result, hit := r.getCachedAnalysis("sleuth", "hash", pod); !hit || result != nil {
+		t.Errorf("get = %v, %v, want a negative hit", result, hit)
+	}
+
+	r.setNegativeCachedAnalysis("sleuth", "hash", pod, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if result, hit := r.getCachedAnalysis("sleuth", "hash", pod); hit {
+		t.Errorf("get = %v, %v after the negative TTL, want a miss", result, hit)
+	}
+	if len(r.analysisCache) != 0 || r.analysisCacheLRU.Len() != 0 || r.analysisCacheSize != 0 {
+		t.Errorf("expired entry kept: %d entries, %d in LRU, %d bytes", len(r.analysisCache), r.analysisCacheLRU.Len(), r.analysisCacheSize)
+	}
+}
+
+func TestAnalysisCacheReturnsCopies(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	pod := cacheTestPod("a")
+	stored := &infrav1alpha1.LogAnalysisResult{RootCause: "pattern", ErrorLines: []string{"ERROR"}}
+	r.setCachedAnalysis("sleuth", "hash", pod, stored, time.Hour)
+	stored.RootCause = "changed after caching"
+
+	first, _ := r.getCachedAnalysis("sleuth", "hash", pod)
+	first.RootCause = "changed by a caller"
+	first.ErrorLines[0] = "changed"
+
+	second, hit := r.getCachedAnalysis("sleuth", "hash", pod)
+	if !hit || second.RootCause != "pattern" || second.ErrorLines[0] != "ERROR" {
+		t.Errorf("got %+v, want the result as cached", second)
+	}
+}
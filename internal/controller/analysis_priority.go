@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/heap"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// analysisPriority orders queued log analyses; lower values are analyzed first
+type analysisPriority struct {
+	// Forced analyses were requested by someone waiting for them
+	forced bool
+	// phase ranks failed and crash-looping pods before running and then pending ones
+	phase int
+	// severity ranks critical pods before warning and then muted ones
+	severity int
+	// analyzed is set for pods analyzed before, whose cached analysis expired
+	analyzed bool
+}
+
+// Phase ranks of queued analyses
+const (
+	analysisPhaseFailing = iota
+	analysisPhaseRunning
+	analysisPhasePending
+)
+
+// severityRanks ranks the severities of pods for analysis
+var severityRanks = map[string]int{severityCritical: 0, severityWarning: 1, severityInfo: 2}
+
+// analysisPriorityOf ranks the analysis of a non-ready pod: forced analyses first, then
+// failed and crash-looping pods before pending ones, critical pods before warning ones,
+// and pods never analyzed before those whose analysis expired
+func analysisPriorityOf(pod *infrav1alpha1.NonReadyPodInfo, forced, analyzed bool) analysisPriority {
+	priority := analysisPriority{forced: forced, phase: analysisPhaseRunning, severity: severityRanks[PodSeverity(pod)], analyzed: analyzed}
+	switch {
+	case pod.Phase == string(corev1.PodFailed) || isCrashLooping(pod):
+		priority.phase = analysisPhaseFailing
+	case pod.Phase == string(corev1.PodPending):
+		priority.phase = analysisPhasePending
+	}
+	return priority
+}
+
+// isCrashLooping reports whether a pod or one of its containers is in CrashLoopBackOff
+func isCrashLooping(pod *infrav1alpha1.NonReadyPodInfo) bool {
+	if pod.Reason == "CrashLoopBackOff" {
+		return true
+	}
+	for _, ce := range pod.ContainerErrors {
+		if ce.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+// before reports whether an analysis of priority p runs before one of priority other
+func (p analysisPriority) before(other analysisPriority) bool {
+	if p.forced != other.forced {
+		return p.forced
+	}
+	if p.phase != other.phase {
+		return p.phase < other.phase
+	}
+	if p.severity != other.severity {
+		return p.severity < other.severity
+	}
+	return !p.analyzed && other.analyzed
+}
+
+// analysisPriorityQueue is the storage of the log analysis workqueue, handing out the
+// analysis of the highest priority first and analyses of equal priority in the order
+// they were queued. Priorities are set with setPriority before the key is added.
+type analysisPriorityQueue struct {
+	items []queuedAnalysis
+	// seq numbers pushed keys to keep the order of equal priorities
+	seq uint64
+
+	// priorities are set by enqueueAnalysis, outside of the workqueue's lock
+	priorities    map[string]analysisPriority
+	prioritiesMux sync.Mutex
+}
+
+// queuedAnalysis is a key in the queue with its priority when pushed
+type queuedAnalysis struct {
+	key      string
+	priority analysisPriority
+	seq      uint64
+}
+
+func newAnalysisPriorityQueue() *analysisPriorityQueue {
+	return &analysisPriorityQueue{priorities: make(map[string]analysisPriority)}
+}
+
+// setPriority sets the priority of a key added next, or already queued
+func (q *analysisPriorityQueue) setPriority(key string, priority analysisPriority) {
+	q.prioritiesMux.Lock()
+	defer q.prioritiesMux.Unlock()
+	q.priorities[key] = priority
+}
+
+// priorityOf returns the priority set for a key
+func (q *analysisPriorityQueue) priorityOf(key string) analysisPriority {
+	q.prioritiesMux.Lock()
+	defer q.prioritiesMux.Unlock()
+	return q.priorities[key]
+}
+
+// Touch moves a key added again while queued to its current priority
+func (q *analysisPriorityQueue) Touch(key string) {
+	for i := range q.items {
+		if q.items[i].key == key {
+			q.items[i].priority = q.priorityOf(key)
+			heap.Fix((*analysisHeap)(&q.items), i)
+			return
+		}
+	}
+}
+
+// Push queues a key with its priority
+func (q *analysisPriorityQueue) Push(key string) {
+	q.seq++
+	heap.Push((*analysisHeap)(&q.items), queuedAnalysis{key: key, priority: q.priorityOf(key), seq: q.seq})
+}
+
+// Len returns the number of queued keys
+func (q *analysisPriorityQueue) Len() int {
+	return len(q.items)
+}
+
+// Pop returns the key of the highest priority
+func (q *analysisPriorityQueue) Pop() string {
+	key := heap.Pop((*analysisHeap)(&q.items)).(queuedAnalysis).key
+	q.prioritiesMux.Lock()
+	defer q.prioritiesMux.Unlock()
+	delete(q.priorities, key)
+	return key
+}
+
+// analysisHeap implements heap.Interface over queued analyses
+type analysisHeap []queuedAnalysis
+
+func (h analysisHeap) Len() int { return len(h) }
+func (h analysisHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority.before(h[j].priority)
+	}
+	return h[i].seq < h[j].seq
+}
+func (h analysisHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *analysisHeap) Push(x any)   { *h = append(*h, x.(queuedAnalysis)) }
+func (h *analysisHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
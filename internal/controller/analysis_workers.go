@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultAnalysisWorkers is the default number of concurrent log analyses
+	DefaultAnalysisWorkers = 4
+	// DefaultAnalysisTimeout is the default time limit of a single log analysis
+	DefaultAnalysisTimeout = 2 * time.Minute
+
+	// analysisResultDebounce coalesces the reconciles of a PodSleuth whose analyses
+	// finish in quick succession
+	analysisResultDebounce = time.Second
+)
+
+// analysisJob is a log analysis of a pod for a PodSleuth
+type analysisJob struct {
+	// Key is the analysis cache key of the pod
+	Key          string
+	PodSleuth    string
+	ConfigHash   string
+	Pod          *corev1.Pod
+	Config       *infrav1alpha1.LogAnalysisConfig
+	AIOptions    *aiRequestOptions
+	ForceRefresh bool
+	// RefreshGeneration is recorded in the result of forced analyses
+	RefreshGeneration int64
+	// Priority orders the job among the queued analyses
+	Priority analysisPriority
+
+	CacheEnabled     bool
+	CacheTTL         time.Duration
+	NegativeCacheTTL time.Duration
+}
+
+// analysisOutcome is the result of a finished analysis that was not cached, kept until
+// the next reconcile of its PodSleuth; a nil Result means the pod had no log output
+type analysisOutcome struct {
+	PodSleuth string
+	Result    *infrav1alpha1.LogAnalysisResult
+}
+
+// runAnalysisJob analyzes the logs of a pod and caches the outcome. Failed analyses
+// return a result describing the failure; a nil result means the pod had no log output.
+func (r *PodSleuthReconciler) runAnalysisJob(ctx context.Context, job *analysisJob) *infrav1alpha1.LogAnalysisResult {
+	logger := log.Log
+	pod := job.Pod
+	if job.ForceRefresh {
+		logger.Info("force refresh requested - running log analysis immediately", "pod", pod.Name, "namespace", pod.Namespace, "generation", job.RefreshGeneration)
+	}
+	if r.AnalysisTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.AnalysisTimeout)
+		defer cancel()
+	}
+
+	var result *infrav1alpha1.LogAnalysisResult
+	err := r.LogFetchLimiter.Wait(ctx, pod.Spec.NodeName)
+	if err == nil {
+		analysisStart := time.Now()
+		result, err = analyzeLogs(ctx, r.Client, r.K8sClient, pod, job.Config, job.AIOptions)
+		logAnalysisDuration.WithLabelValues(outcomeOf(err)).Observe(time.Since(analysisStart).Seconds())
+	}
+	// Failed analyses are usually transient (e.g. the container has not started yet),
+	// so they are cached with the negative TTL to retry soon
+	resultTTL := job.CacheTTL
+	if err != nil {
+		resultTTL = job.NegativeCacheTTL
+		logger.Info("log analysis failed", "pod", pod.Name, "namespace", pod.Namespace, "error", err)
+		// Create failure result so the dashboard polling detects completion
+		result = &infrav1alpha1.LogAnalysisResult{
+			RootCause:  fmt.Sprintf("Analysis Failed: %v", err),
+			Methods:    []string{"failed"},
+			AnalyzedAt: metav1.Now(),
+			Confidence: 0,
+		}
+	} else if aiRateLimited(result) {
+		// The AI analysis is retried soon, once the rate limits admit it
+		resultTTL = job.NegativeCacheTTL
+	}
+
+	if result != nil {
+		// Tells clients that forced the analysis that it finished
+		result.RefreshGeneration = job.RefreshGeneration
+		logger.Info("log analysis successful", "pod", pod.Name, "newAnalyzedAt", result.AnalyzedAt, "timestamp", result.AnalyzedAt.Time.Unix())
+		// Cache the result if caching is enabled
+		if job.CacheEnabled {
+			r.setCachedAnalysis(job.PodSleuth, job.ConfigHash, pod, result, resultTTL)
+			logger.Info("log analysis completed and cached", "pod", pod.Name, "namespace", pod.Namespace)
+		} else {
+			logger.Info("log analysis completed (no cache)", "pod", pod.Name, "namespace", pod.Namespace)
+		}
+	} else if job.CacheEnabled {
+		// No log output: remember it so logs are not fetched on every reconcile
+		r.setNegativeCachedAnalysis(job.PodSleuth, job.ConfigHash, pod, job.NegativeCacheTTL)
+	}
+	return result
+}
+
+// aiRateLimited reports whether the AI analysis of a result was refused by a rate limiter
+func aiRateLimited(result *infrav1alpha1.LogAnalysisResult) bool {
+	return result != nil && result.AIResult != nil && strings.HasSuffix(result.AIResult.Error, ErrAIRateLimited.Error())
+}
+
+// enqueueAnalysis queues a log analysis for the workers by priority, unless one of the
+// pod is already queued or running. A queued analysis of the pod moves up if the pod's
+// priority rose.
+func (r *PodSleuthReconciler) enqueueAnalysis(job *analysisJob) {
+	r.analysisJobsMux.Lock()
+	defer r.analysisJobsMux.Unlock()
+
+	// A newer forced analysis replaces a queued or running one though, whose result would
+	// not tell its clients that it finished
+	if existing, exists := r.analysisJobs[job.Key]; exists &&
+		(!job.ForceRefresh || existing.ForceRefresh && existing.RefreshGeneration >= job.RefreshGeneration) {
+		if job.Priority.before(existing.Priority) {
+			existing.Priority = job.Priority
+			r.analysisPriorities.setPriority(job.Key, job.Priority)
+			r.analysisQueue.Add(job.Key)
+		}
+		return
+	}
+	if r.analysisJobs == nil {
+		r.analysisJobs = make(map[string]*analysisJob)
+	}
+	r.analysisJobs[job.Key] = job
+	r.analysisPriorities.setPriority(job.Key, job.Priority)
+	r.analysisQueue.Add(job.Key)
+}
+
+// enqueueAnalyses queues the analyses of a reconcile, highest priority first, so that
+// idle workers do not start on whichever pod was listed first
+func (r *PodSleuthReconciler) enqueueAnalyses(jobs []*analysisJob) {
+	slices.SortStableFunc(jobs, func(a, b *analysisJob) int {
+		switch {
+		case a.Priority.before(b.Priority):
+			return -1
+		case b.Priority.before(a.Priority):
+			return 1
+		}
+		return 0
+	})
+	for _, job := range jobs {
+		r.enqueueAnalysis(job)
+	}
+}
+
+// takeAnalysisOutcome returns and forgets the outcome of a finished analysis that was not
+// cached. The boolean reports whether the analysis finished; a nil result means the pod
+// had no log output.
+func (r *PodSleuthReconciler) takeAnalysisOutcome(key string) (*infrav1alpha1.LogAnalysisResult, bool) {
+	r.analysisJobsMux.Lock()
+	defer r.analysisJobsMux.Unlock()
+
+	outcome, done := r.analysisOutcomes[key]
+	delete(r.analysisOutcomes, key)
+	return outcome.Result, done
+}
+
+// forgetAnalyses drops the queued analyses and uncached outcomes of a PodSleuth for pods
+// that are no longer non-ready. A nil currentPods drops all of the PodSleuth. Running
+// analyses finish, but their outcome is discarded.
+func (r *PodSleuthReconciler) forgetAnalyses(podSleuthName string, currentPods map[string]bool) {
+	r.analysisJobsMux.Lock()
+	defer r.analysisJobsMux.Unlock()
+
+	for key, job := range r.analysisJobs {
+		if job.PodSleuth == podSleuthName && !currentPods[key] {
+			delete(r.analysisJobs, key)
+		}
+	}
+	for key, outcome := range r.analysisOutcomes {
+		if outcome.PodSleuth == podSleuthName && !currentPods[key] {
+			delete(r.analysisOutcomes, key)
+		}
+	}
+}
+
+// runAnalysisWorkers runs AnalysisWorkers concurrent log analyses until ctx is done
+func (r *PodSleuthReconciler) runAnalysisWorkers(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range r.AnalysisWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r.processNextAnalysis(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+	r.analysisQueue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+// processNextAnalysis runs the next queued analysis and requests a reconcile of its
+// PodSleuth to write the result into the status. Returns false once the queue shut down.
+func (r *PodSleuthReconciler) processNextAnalysis(ctx context.Context) bool {
+	key, shutdown := r.analysisQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.analysisQueue.Done(key)
+
+	r.analysisJobsMux.Lock()
+	job := r.analysisJobs[key]
+	r.analysisJobsMux.Unlock()
+	if job == nil {
+		// Forgotten while queued
+		return true
+	}
+
+	result := r.runAnalysisJob(ctx, job)
+
+	r.analysisJobsMux.Lock()
+	current := r.analysisJobs[key] == job
+	if current {
+		delete(r.analysisJobs, key)
+		if !job.CacheEnabled {
+			// Cached results are found in the cache by the next reconcile
+			if r.analysisOutcomes == nil {
+				r.analysisOutcomes = make(map[string]analysisOutcome)
+			}
+			r.analysisOutcomes[key] = analysisOutcome{PodSleuth: job.PodSleuth, Result: result}
+		}
+	}
+	r.analysisJobsMux.Unlock()
+	if !current {
+		return true
+	}
+
+	podSleuth := &infrav1alpha1.PodSleuth{}
+	podSleuth.Name = job.PodSleuth
+	select {
+	case r.analysisFinished <- event.GenericEvent{Object: podSleuth}:
+	case <-ctx.Done():
+	}
+	return true
+}
+
+// analysisResultHandler enqueues the PodSleuths of finished analyses after
+// analysisResultDebounce, so that analyses finishing together are written in one reconcile
+func analysisResultHandler() handler.EventHandler {
+	return handler.Funcs{
+		GenericFunc: func(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			q.AddAfter(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)}, analysisResultDebounce)
+		},
+	}
+}
+
+// storedAnalyses returns the log analyses of a PodSleuth's status by pod, shown while
+// new analyses of the pods are pending
+func storedAnalyses(pods []infrav1alpha1.NonReadyPodInfo) map[string]*infrav1alpha1.LogAnalysisResult {
+	analyses := make(map[string]*infrav1alpha1.LogAnalysisResult, len(pods))
+	for _, pod := range pods {
+		if pod.LogAnalysis != nil {
+			analyses[pod.Namespace+"/"+pod.Name] = pod.LogAnalysis
+		}
+	}
+	return analyses
+}
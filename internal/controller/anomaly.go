@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultAnomalyInterval         = time.Hour
+	defaultAnomalySmoothingPercent = 10
+	defaultAnomalyThreshold        = 3.0
+	defaultAnomalyMinSamples       = 24
+	defaultMaxBlipDuration         = 5 * time.Minute
+	// maxQuietIntervals bounds the intervals without restarts or blips learned at once,
+	// after the operator or the PodSleuth was stopped for long
+	maxQuietIntervals = 1000
+	// minBaselineMean is the average below which a quiet workload's baseline is dropped
+	minBaselineMean = 0.001
+)
+
+// anomalySettings holds the anomaly detection configuration of a PodSleuth with defaults applied
+type anomalySettings struct {
+	Interval        time.Duration
+	Smoothing       float64
+	Threshold       float64
+	MinSamples      int32
+	MaxBlipDuration time.Duration
+}
+
+// anomalySettingsOf resolves the anomaly detection configuration of a PodSleuth
+func anomalySettingsOf(config *infrav1alpha1.AnomalyDetectionConfig) anomalySettings {
+	settings := anomalySettings{
+		Interval:        defaultAnomalyInterval,
+		Smoothing:       defaultAnomalySmoothingPercent / 100.0,
+		Threshold:       defaultAnomalyThreshold,
+		MinSamples:      defaultAnomalyMinSamples,
+		MaxBlipDuration: defaultMaxBlipDuration,
+	}
+	if config.Interval != nil && config.Interval.Duration > 0 {
+		settings.Interval = config.Interval.Duration
+	}
+	if config.SmoothingPercent != nil && *config.SmoothingPercent > 0 && *config.SmoothingPercent <= 100 {
+		settings.Smoothing = float64(*config.SmoothingPercent) / 100
+	}
+	if threshold, err := strconv.ParseFloat(config.Threshold, 64); err == nil && threshold >= 0 {
+		settings.Threshold = threshold
+	}
+	if config.MinSamples != nil && *config.MinSamples > 0 {
+		settings.MinSamples = *config.MinSamples
+	}
+	if config.MaxBlipDuration != nil && config.MaxBlipDuration.Duration > 0 {
+		settings.MaxBlipDuration = config.MaxBlipDuration.Duration
+	}
+	return settings
+}
+
+// workloadActivity counts the restarts and pods turning non-ready of a workload
+type workloadActivity struct {
+	restarts, blips int32
+}
+
+// detectAnomalies learns the restart and blip baselines of the workloads of a PodSleuth
+// in its status, and marks the transient failures of workloads within their baseline.
+// Baselines of other shards' namespaces are kept as stored.
+func (r *PodSleuthReconciler) detectAnomalies(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, pods []corev1.Pod,
+	previous, current []infrav1alpha1.NonReadyPodInfo, now time.Time) {
+	config := podSleuth.Spec.AnomalyDetection
+	if config == nil || !config.Enabled {
+		podSleuth.Status.Baselines = nil
+		r.forgetRestartCounts(podSleuth.Name)
+		return
+	}
+	settings := anomalySettingsOf(config)
+
+	activity := r.countRestarts(ctx, podSleuth.Name, pods)
+	wasNonReady := make(map[string]bool, len(previous))
+	for i := range previous {
+		wasNonReady[previous[i].Namespace+"/"+previous[i].Name] = true
+	}
+	for i := range current {
+		pod := &current[i]
+		if wasNonReady[pod.Namespace+"/"+pod.Name] {
+			continue
+		}
+		key := sloIncidentKey(pod.Namespace, podWorkload(pod.OwnerKind, pod.OwnerName, pod.Name))
+		counts := activity[key]
+		counts.blips++
+		activity[key] = counts
+	}
+
+	var baselines []infrav1alpha1.WorkloadBaseline
+	seen := map[string]bool{}
+	for _, baseline := range podSleuth.Status.Baselines {
+		if !r.Sharding.ownsNamespace(baseline.Namespace) {
+			baselines = append(baselines, baseline)
+			continue
+		}
+		key := sloIncidentKey(baseline.Namespace, baseline.Workload)
+		seen[key] = true
+		counts := activity[key]
+		learnBaseline(&baseline, settings, now)
+		baseline.IntervalRestarts += counts.restarts
+		baseline.IntervalBlips += counts.blips
+		if baseline.Samples > 0 && baseline.IntervalRestarts == 0 && baseline.IntervalBlips == 0 &&
+			parseRate(baseline.Restarts.Mean) < minBaselineMean && parseRate(baseline.Blips.Mean) < minBaselineMean {
+			continue
+		}
+		baseline.Anomalous = baseline.Samples >= settings.MinSamples &&
+			(deviates(baseline.IntervalRestarts, baseline.Restarts, settings.Threshold) ||
+				deviates(baseline.IntervalBlips, baseline.Blips, settings.Threshold))
+		baselines = append(baselines, baseline)
+	}
+	for key, counts := range activity {
+		if seen[key] || (counts.restarts == 0 && counts.blips == 0) {
+			continue
+		}
+		namespace, workload, _ := strings.Cut(key, "/")
+		baselines = append(baselines, infrav1alpha1.WorkloadBaseline{
+			Namespace: namespace, Workload: workload,
+			Restarts:      infrav1alpha1.BaselineRate{Mean: formatRate(0), Variance: formatRate(0)},
+			Blips:         infrav1alpha1.BaselineRate{Mean: formatRate(0), Variance: formatRate(0)},
+			IntervalStart: metav1.NewTime(now), IntervalRestarts: counts.restarts, IntervalBlips: counts.blips,
+		})
+	}
+	slices.SortFunc(baselines, func(a, b infrav1alpha1.WorkloadBaseline) int {
+		return strings.Compare(sloIncidentKey(a.Namespace, a.Workload), sloIncidentKey(b.Namespace, b.Workload))
+	})
+	podSleuth.Status.Baselines = baselines
+
+	withinBaseline := map[string]bool{}
+	for _, baseline := range baselines {
+		if baseline.Samples >= settings.MinSamples && !baseline.Anomalous {
+			withinBaseline[sloIncidentKey(baseline.Namespace, baseline.Workload)] = true
+		}
+	}
+	for i := range current {
+		pod := &current[i]
+		since := pod.DetectedAt
+		if pod.NotReadySince != nil && (since == nil || pod.NotReadySince.Before(since)) {
+			since = pod.NotReadySince
+		}
+		pod.WithinBaseline = withinBaseline[sloIncidentKey(pod.Namespace, podWorkload(pod.OwnerKind, pod.OwnerName, pod.Name))] &&
+			since != nil && now.Sub(since.Time) < settings.MaxBlipDuration
+	}
+}
+
+// learnBaseline folds the intervals that ended by now into the moving averages of a
+// baseline and starts the current one
+func learnBaseline(baseline *infrav1alpha1.WorkloadBaseline, settings anomalySettings, now time.Time) {
+	elapsed := int64(now.Sub(baseline.IntervalStart.Time) / settings.Interval)
+	if elapsed <= 0 {
+		return
+	}
+	restarts, blips := float64(baseline.IntervalRestarts), float64(baseline.IntervalBlips)
+	for i := int64(0); i < elapsed && i < maxQuietIntervals; i++ {
+		baseline.Restarts = updateRate(baseline.Restarts, restarts, settings.Smoothing)
+		baseline.Blips = updateRate(baseline.Blips, blips, settings.Smoothing)
+		baseline.Samples++
+		restarts, blips = 0, 0
+	}
+	baseline.IntervalStart = metav1.NewTime(baseline.IntervalStart.Add(time.Duration(elapsed) * settings.Interval))
+	baseline.IntervalRestarts, baseline.IntervalBlips = 0, 0
+}
+
+// updateRate adds a sample to an exponentially weighted moving average and variance
+func updateRate(rate infrav1alpha1.BaselineRate, sample, smoothing float64) infrav1alpha1.BaselineRate {
+	mean, variance := parseRate(rate.Mean), parseRate(rate.Variance)
+	diff := sample - mean
+	mean += smoothing * diff
+	variance = (1 - smoothing) * (variance + smoothing*diff*diff)
+	return infrav1alpha1.BaselineRate{Mean: formatRate(mean), Variance: formatRate(variance)}
+}
+
+// deviates reports whether a count is more than threshold standard deviations above
+// the moving average
+func deviates(count int32, rate infrav1alpha1.BaselineRate, threshold float64) bool {
+	mean, variance := parseRate(rate.Mean), parseRate(rate.Variance)
+	return float64(count) > mean+threshold*math.Sqrt(variance)
+}
+
+// parseRate parses a moving average or variance, 0 if invalid
+func parseRate(value string) float64 {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 {
+		return 0
+	}
+	return rate
+}
+
+// formatRate formats a moving average or variance for the status
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 4, 64)
+}
+
+// countRestarts returns the container restarts of the pods of a PodSleuth since its
+// last reconcile, by workload. Only non-ready pods are listed, so the restart count of
+// a pod not seen at the last reconcile, after the operator started or after the pod was
+// ready again, is only remembered as its baseline; the pod turning non-ready counts as
+// a blip instead.
+func (r *PodSleuthReconciler) countRestarts(ctx context.Context, podSleuthName string, pods []corev1.Pod) map[string]workloadActivity {
+	r.restartCountsMux.Lock()
+	defer r.restartCountsMux.Unlock()
+	if r.restartCounts == nil {
+		r.restartCounts = make(map[string]map[types.UID]int32)
+	}
+	previous := r.restartCounts[podSleuthName]
+	current := make(map[types.UID]int32, len(pods))
+	activity := map[string]workloadActivity{}
+	for i := range pods {
+		pod := &pods[i]
+		var restarts int32
+		for _, cs := range pod.Status.InitContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		current[pod.UID] = restarts
+		before, seen := previous[pod.UID]
+		if !seen {
+			continue
+		}
+		if increase := restarts - before; increase > 0 {
+			ownerKind, ownerName := r.getPodOwner(ctx, pod)
+			key := sloIncidentKey(pod.Namespace, podWorkload(ownerKind, ownerName, pod.Name))
+			counts := activity[key]
+			counts.restarts += increase
+			activity[key] = counts
+		}
+	}
+	r.restartCounts[podSleuthName] = current
+	return activity
+}
+
+// forgetRestartCounts drops the restart counts of a PodSleuth
+func (r *PodSleuthReconciler) forgetRestartCounts(podSleuthName string) {
+	r.restartCountsMux.Lock()
+	defer r.restartCountsMux.Unlock()
+	delete(r.restartCounts, podSleuthName)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestCountRestartsPodReadyAgain follows a pod that is ready, turns non-ready and is
+// ready again: only the restarts seen while it stays non-ready are counted
+func TestCountRestartsPodReadyAgain(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	ctx := context.Background()
+	pod := func(restarts int32) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api", UID: "api-uid"},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "api", RestartCount: restarts},
+			}},
+		}
+	}
+	const key = "shop/Pod/api"
+
+	steps := []struct {
+		name string
+		// pods are the non-ready pods listed at the reconcile
+		pods []corev1.Pod
+		want int32
+	}{
+		{"operator starts, pod non-ready", []corev1.Pod{pod(4)}, 0},
+		{"pod still non-ready, restarted", []corev1.Pod{pod(6)}, 2},
+		{"pod ready", nil, 0},
+		{"pod non-ready again after restarts while ready", []corev1.Pod{pod(9)}, 0},
+		{"pod still non-ready, restarted", []corev1.Pod{pod(10)}, 1},
+		{"pod ready again", nil, 0},
+	}
+	for _, step := range steps {
+		activity := r.countRestarts(ctx, "sleuth", step.pods)
+		if got := activity[key].restarts; got != step.want {
+			t.Errorf("%s: restarts = %d, want %d", step.name, got, step.want)
+		}
+	}
+}
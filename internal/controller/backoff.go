@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultRequeueBackoffBase is the default delay before retrying a failed reconcile
+	DefaultRequeueBackoffBase = time.Second
+	// DefaultRequeueBackoffMax is the default longest delay between retries of a
+	// PodSleuth that keeps failing
+	DefaultRequeueBackoffMax = 5 * time.Minute
+)
+
+// requeueRateLimiter retries failed reconciles of a PodSleuth after RequeueBackoffBase,
+// doubling the delay on every consecutive failure up to RequeueBackoffMax. A successful
+// reconcile resets the backoff and requeues after the PodSleuth's interval instead.
+func (r *PodSleuthReconciler) requeueRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	base, maxDelay := r.RequeueBackoffBase, r.RequeueBackoffMax
+	if base <= 0 {
+		base = DefaultRequeueBackoffBase
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRequeueBackoffMax
+	}
+	maxDelay = max(maxDelay, base)
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](base, maxDelay),
+		// Overall retry rate, as in the default controller rate limiter
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// defaultCertificateExpiryWarning is how soon a certificate must expire to be reported
+const defaultCertificateExpiryWarning = 7 * 24 * time.Hour
+
+// tlsErrorRegex matches TLS handshake and certificate verification errors of common runtimes
+var tlsErrorRegex = regexp.MustCompile(`(?i)(x509:|tls: |certificate has expired|certificate expired|certificate is not yet valid|certificate verify failed|bad certificate|handshake failure|PKIX path|SSLHandshakeException|CertificateExpiredException)`)
+
+// analyzeCertificates inspects the kubernetes.io/tls Secrets mounted by a pod when its logs
+// show TLS errors. It returns nil if the logs contain no TLS errors.
+func analyzeCertificates(ctx context.Context, k8sClient client.Client, pod *corev1.Pod, logLines []string, config *infrav1alpha1.CertificateCheckConfig) *infrav1alpha1.CertificateAnalysisResult {
+	if config != nil && config.Enabled != nil && !*config.Enabled {
+		return nil
+	}
+
+	hasTLSError := false
+	for _, line := range logLines {
+		if tlsErrorRegex.MatchString(line) {
+			hasTLSError = true
+			break
+		}
+	}
+	if !hasTLSError {
+		return nil
+	}
+
+	warning := defaultCertificateExpiryWarning
+	if config != nil && config.ExpiryWarning != nil {
+		warning = config.ExpiryWarning.Duration
+	}
+
+	logger := log.Log.WithName("log-analysis")
+	result := &infrav1alpha1.CertificateAnalysisResult{}
+	now := time.Now()
+	var errs []string
+	for _, secretName := range mountedSecretNames(pod) {
+		var secret corev1.Secret
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: pod.Namespace}, &secret); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", secretName, err))
+			continue
+		}
+		if secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+		result.SecretsChecked++
+
+		for _, key := range []string{corev1.TLSCertKey, "ca.crt"} {
+			for _, cert := range parseCertificates(secret.Data[key]) {
+				if cert.NotAfter.After(now.Add(warning)) {
+					continue
+				}
+				result.Certificates = append(result.Certificates, infrav1alpha1.CertificateFinding{
+					SecretName: secretName,
+					Key:        key,
+					Subject:    cert.Subject.String(),
+					NotAfter:   metav1.NewTime(cert.NotAfter),
+					Expired:    cert.NotAfter.Before(now),
+				})
+			}
+		}
+	}
+	if len(errs) > 0 && result.SecretsChecked == 0 {
+		result.Error = fmt.Sprintf("Failed to read mounted Secrets: %s", strings.Join(errs, "; "))
+	}
+	if len(result.Certificates) == 0 {
+		return result
+	}
+
+	// Soonest expiry first
+	sort.Slice(result.Certificates, func(i, j int) bool {
+		return result.Certificates[i].NotAfter.Before(&result.Certificates[j].NotAfter)
+	})
+
+	causes := make([]string, 0, len(result.Certificates))
+	result.Confidence = 70
+	for _, cert := range result.Certificates {
+		if cert.Expired {
+			// An expired certificate next to TLS errors is almost certainly the cause
+			result.Confidence = 95
+			causes = append(causes, fmt.Sprintf("certificate %s in Secret %s (%s) expired %s ago",
+				cert.Subject, cert.SecretName, cert.Key, formatTrendWindow(now.Sub(cert.NotAfter.Time))))
+		} else {
+			causes = append(causes, fmt.Sprintf("certificate %s in Secret %s (%s) expires in %s",
+				cert.Subject, cert.SecretName, cert.Key, formatTrendWindow(cert.NotAfter.Sub(now))))
+		}
+	}
+	result.RootCause = "TLS errors with " + strings.Join(causes, "; ")
+
+	logger.Info("certificate analysis completed", "pod", pod.Name, "namespace", pod.Namespace, "certificates", len(result.Certificates))
+	return result
+}
+
+// mountedSecretNames returns the Secrets mounted as volumes by a pod, including projected volumes
+func mountedSecretNames(pod *corev1.Pod) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(source.Secret.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// parseCertificates parses all PEM encoded certificates in data, skipping invalid blocks
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// mergeCertificateResult folds certificate findings into the log-based result.
+// An expired certificate explains TLS errors better than any log pattern, so it leads the root cause.
+func mergeCertificateResult(result *infrav1alpha1.LogAnalysisResult, certResult *infrav1alpha1.CertificateAnalysisResult, methods []string) *infrav1alpha1.LogAnalysisResult {
+	if certResult == nil || (result == nil && certResult.RootCause == "") {
+		return result
+	}
+
+	if result == nil {
+		result = &infrav1alpha1.LogAnalysisResult{
+			Methods:    methods,
+			RootCause:  certResult.RootCause,
+			Confidence: certResult.Confidence,
+		}
+	} else if certResult.RootCause != "" {
+		if result.RootCause != "" {
+			result.RootCause = fmt.Sprintf("[Certificate] %s | %s", certResult.RootCause, result.RootCause)
+		} else {
+			result.RootCause = certResult.RootCause
+		}
+		result.Confidence = max(result.Confidence, certResult.Confidence)
+	}
+
+	result.CertificateResult = certResult
+	return result
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// cloudEventsTypePrefix prefixes the event type, e.g. dev.ops.kubesleuth.pod.detected
+	cloudEventsTypePrefix = "dev.ops.kubesleuth.pod."
+	cloudEventsModeBinary = "Binary"
+)
+
+// cloudEvent is a CloudEvents 1.0 event in the structured JSON format
+type cloudEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            sleuthEvent `json:"data"`
+}
+
+// newCloudEvent wraps an event; the subject is namespace/pod
+func newCloudEvent(source string, event sleuthEvent) cloudEvent {
+	if source == "" {
+		source = "/apis/apps.ops.dev/v1alpha1/podsleuths/" + event.PodSleuth
+		if event.Cluster != "" {
+			source = "/clusters/" + event.Cluster + source
+		}
+	}
+	return cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              string(uuid.NewUUID()),
+		Source:          source,
+		Type:            cloudEventsTypePrefix + event.Type,
+		Subject:         event.Namespace + "/" + event.Pod,
+		Time:            event.Timestamp,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+}
+
+// sendCloudEvent posts a CloudEvent to an HTTP sink in binary or structured mode
+func (r *PodSleuthReconciler) sendCloudEvent(ctx context.Context, sink *infrav1alpha1.CloudEventsSink, event cloudEvent) error {
+	headers := http.Header{}
+	var body []byte
+	var err error
+	if sink.Mode == "" || sink.Mode == cloudEventsModeBinary {
+		headers.Set("Content-Type", event.DataContentType)
+		headers.Set("ce-specversion", event.SpecVersion)
+		headers.Set("ce-id", event.ID)
+		headers.Set("ce-source", event.Source)
+		headers.Set("ce-type", event.Type)
+		headers.Set("ce-subject", event.Subject)
+		headers.Set("ce-time", event.Time.UTC().Format(time.RFC3339Nano))
+		body, err = json.Marshal(event.Data)
+	} else {
+		headers.Set("Content-Type", "application/cloudevents+json")
+		body, err = json.Marshal(event)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	for name, value := range sink.Headers {
+		headers.Set(name, value)
+	}
+	if sink.AuthSecretRef != nil {
+		token, err := r.notificationSecret(ctx, sink.AuthSecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get auth token: %w", err)
+		}
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	timeout := defaultWebhookTimeout
+	if sink.Timeout != nil && sink.Timeout.Duration > 0 {
+		timeout = sink.Timeout.Duration
+	}
+	maxRetries := defaultWebhookMaxRetries
+	if sink.MaxRetries != nil {
+		maxRetries = int(*sink.MaxRetries)
+	}
+	return postWithRetries(ctx, http.MethodPost, sink.URL, headers, body, timeout, maxRetries)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "sync"
+
+// clusterLabel is the metric label of the cluster name
+const clusterLabel = "cluster"
+
+var (
+	clusterName    string
+	clusterNameMux sync.RWMutex
+)
+
+// SetClusterName names the cluster the operator runs in, so consumers aggregating several
+// clusters can tell their findings apart. The name is stamped into the non-ready pods
+// found, notifications, event streams and exports, and labels the metrics registered
+// afterwards by RegisterMetrics. Empty leaves them without a cluster.
+func SetClusterName(name string) {
+	clusterNameMux.Lock()
+	defer clusterNameMux.Unlock()
+	clusterName = name
+}
+
+// ClusterName returns the name of the cluster the operator runs in, empty if unset
+func ClusterName() string {
+	clusterNameMux.RLock()
+	defer clusterNameMux.RUnlock()
+	return clusterName
+}
+
+// clusterQualified prefixes a name with the cluster name, if one is set
+func clusterQualified(name string) string {
+	if cluster := ClusterName(); cluster != "" {
+		return cluster + "/" + name
+	}
+	return name
+}
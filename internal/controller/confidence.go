@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	mergeStrategyThreshold = "threshold"
+	mergeStrategyAverage   = "average"
+	mergeStrategyHighest   = "highest"
+)
+
+// confidenceSettings holds the effective confidence configuration with defaults applied
+type confidenceSettings struct {
+	MergeStrategy         string
+	AIPreferredAbove      int32
+	PatternPreferredBelow int32
+	AIWeight              int32
+	PatternWeight         int32
+
+	PatternNoMatch     int32
+	PatternSingleMatch int32
+	PatternTwoMatches  int32
+	PatternManyMatches int32
+}
+
+// getConfidenceSettings returns the confidence settings for a configuration, using
+// the historical defaults (80/50 thresholds, 30/50/65/80 pattern scores) for unset values
+func getConfidenceSettings(config *infrav1alpha1.ConfidenceConfig) confidenceSettings {
+	settings := confidenceSettings{
+		MergeStrategy:         mergeStrategyThreshold,
+		AIPreferredAbove:      80,
+		PatternPreferredBelow: 50,
+		AIWeight:              1,
+		PatternWeight:         1,
+		PatternNoMatch:        30,
+		PatternSingleMatch:    50,
+		PatternTwoMatches:     65,
+		PatternManyMatches:    80,
+	}
+
+	if config == nil {
+		return settings
+	}
+
+	if config.MergeStrategy != "" {
+		settings.MergeStrategy = config.MergeStrategy
+	}
+	setIfNotNil := func(dst *int32, src *int32) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setIfNotNil(&settings.AIPreferredAbove, config.AIPreferredAbove)
+	setIfNotNil(&settings.PatternPreferredBelow, config.PatternPreferredBelow)
+	setIfNotNil(&settings.AIWeight, config.AIWeight)
+	setIfNotNil(&settings.PatternWeight, config.PatternWeight)
+	setIfNotNil(&settings.PatternNoMatch, config.PatternNoMatch)
+	setIfNotNil(&settings.PatternSingleMatch, config.PatternSingleMatch)
+	setIfNotNil(&settings.PatternTwoMatches, config.PatternTwoMatches)
+	setIfNotNil(&settings.PatternManyMatches, config.PatternManyMatches)
+
+	// Both weights at zero would make averaging meaningless
+	if settings.AIWeight <= 0 && settings.PatternWeight <= 0 {
+		settings.AIWeight, settings.PatternWeight = 1, 1
+	}
+
+	return settings
+}
+
+// patternConfidence returns the confidence for a number of matched lines
+func (s confidenceSettings) patternConfidence(matchedLines int) int32 {
+	switch {
+	case matchedLines >= 3:
+		return s.PatternManyMatches
+	case matchedLines == 2:
+		return s.PatternTwoMatches
+	case matchedLines == 1:
+		return s.PatternSingleMatch
+	}
+	return s.PatternNoMatch
+}
+
+// weightedAverage combines pattern and AI confidence using the configured weights
+func (s confidenceSettings) weightedAverage(patternConfidence, aiConfidence int32) int32 {
+	total := s.PatternWeight + s.AIWeight
+	return (patternConfidence*s.PatternWeight + aiConfidence*s.AIWeight) / total
+}
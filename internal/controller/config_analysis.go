@@ -0,0 +1,335 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+var (
+	// configErrorRegex matches log lines reporting missing or unknown configuration
+	configErrorRegex = regexp.MustCompile(`(?i)(missing (required )?(env(ironment)? var(iable)?|config(uration)?|setting|option|key|property)|env(ironment)? var(iable)?s?\b.*\b(not set|not defined|is required|are required|missing|undefined|empty)|\b(is not set|must be set|is required)\b|unknown (config(uration)?|setting|option|key|property)|required (config(uration)?|key|setting|property)|KeyError|no such key|could not resolve placeholder|undefined variable|ConfigurationError)`)
+	// envVarNameRegex matches environment variable names, which contain an underscore
+	envVarNameRegex = regexp.MustCompile(`\b[A-Z][A-Z0-9]*_[A-Z0-9_]*[A-Z0-9]\b`)
+	// quotedNameRegex matches quoted and placeholder configuration names
+	quotedNameRegex = regexp.MustCompile("[\"'`]([A-Za-z_][\\w.-]*)[\"'`]|\\$\\{([\\w.-]+)(?::[^}]*)?\\}")
+)
+
+// configReference is a ConfigMap or Secret, or one of its keys, referenced by a container
+type configReference struct {
+	kind, name, key string
+	// envVar is the environment variable set from the key, if any
+	envVar string
+	// prefix is the prefix of the variables imported from a whole ConfigMap or Secret
+	prefix string
+	// envFrom is set for ConfigMaps and Secrets imported as environment variables
+	envFrom bool
+}
+
+// analyzeConfigReferences checks the environment variables, ConfigMaps and Secrets a
+// container references when its logs report missing configuration. It returns nil if
+// the logs contain no configuration errors.
+func analyzeConfigReferences(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1.Pod, containerName string, logLines []string, config *infrav1alpha1.ConfigCheckConfig) *infrav1alpha1.ConfigAnalysisResult {
+	if config != nil && config.Enabled != nil && !*config.Enabled {
+		return nil
+	}
+	container := podContainer(pod, containerName)
+	if container == nil || k8sClient == nil {
+		return nil
+	}
+
+	// Names mentioned by the configuration error lines
+	mentioned := map[string]bool{}
+	var envVars []string
+	for _, line := range logLines {
+		if !configErrorRegex.MatchString(line) {
+			continue
+		}
+		for _, name := range envVarNameRegex.FindAllString(line, -1) {
+			if !mentioned[name] {
+				envVars = append(envVars, name)
+			}
+			mentioned[name] = true
+		}
+		for _, match := range quotedNameRegex.FindAllStringSubmatch(line, -1) {
+			mentioned[match[1]+match[2]] = true
+		}
+	}
+	if len(mentioned) == 0 {
+		return nil
+	}
+
+	result := &infrav1alpha1.ConfigAnalysisResult{Container: containerName}
+	defined := map[string]bool{}
+	for _, env := range container.Env {
+		defined[env.Name] = true
+	}
+	keys := map[string]map[string]bool{}
+	var errs []string
+	// envComplete is cleared when the keys of an imported ConfigMap or Secret are unknown
+	envComplete := true
+	for _, ref := range containerConfigReferences(pod, container) {
+		cacheKey := ref.kind + "/" + ref.name
+		data, fetched := keys[cacheKey]
+		if !fetched {
+			var err error
+			data, err = configKeys(ctx, k8sClient, pod.Namespace, ref.kind, ref.name)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s %s: %v", ref.kind, ref.name, err))
+				if ref.envFrom {
+					envComplete = false
+				}
+				continue
+			}
+			keys[cacheKey] = data
+			result.ReferencesChecked++
+		}
+		switch {
+		case data == nil:
+			result.Missing = append(result.Missing, infrav1alpha1.MissingConfigReference{
+				Kind: ref.kind, Name: ref.name, EnvVar: ref.envVar,
+				MentionedInLogs: mentioned[ref.name] || mentioned[ref.envVar],
+			})
+			if ref.envFrom {
+				envComplete = false
+			}
+		case ref.envFrom:
+			for key := range data {
+				defined[ref.prefix+key] = true
+			}
+		case ref.key != "" && !data[ref.key]:
+			result.Missing = append(result.Missing, infrav1alpha1.MissingConfigReference{
+				Kind: ref.kind, Name: ref.name, Key: ref.key, EnvVar: ref.envVar,
+				MentionedInLogs: mentioned[ref.key] || mentioned[ref.envVar],
+			})
+		}
+	}
+	if envComplete {
+		for _, name := range envVars {
+			if !defined[name] && !missingEnvVar(result.Missing, name) {
+				result.Missing = append(result.Missing, infrav1alpha1.MissingConfigReference{Kind: "Env", Name: name, MentionedInLogs: true})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		result.Error = "Failed to check configuration references: " + strings.Join(errs, "; ")
+	}
+	if len(result.Missing) == 0 {
+		return result
+	}
+
+	// What the logs name first
+	slices.SortStableFunc(result.Missing, func(a, b infrav1alpha1.MissingConfigReference) int {
+		switch {
+		case a.MentionedInLogs == b.MentionedInLogs:
+			return 0
+		case a.MentionedInLogs:
+			return -1
+		}
+		return 1
+	})
+	causes := make([]string, 0, len(result.Missing))
+	result.Confidence = 70
+	for _, missing := range result.Missing {
+		if missing.Kind != "Env" {
+			// A reference that does not resolve next to configuration errors is almost
+			// certainly the cause, the more so if the logs name it
+			confidence := int32(75)
+			if missing.MentionedInLogs {
+				confidence = 90
+			}
+			result.Confidence = max(result.Confidence, confidence)
+		}
+		causes = append(causes, describeMissingConfig(missing, containerName))
+	}
+	result.RootCause = "Missing configuration: " + strings.Join(causes, "; ")
+
+	log.Log.WithName("log-analysis").Info("configuration check completed", "pod", pod.Name, "namespace", pod.Namespace, "missing", len(result.Missing))
+	return result
+}
+
+// missingEnvVar reports whether a missing reference already explains an environment variable
+func missingEnvVar(missing []infrav1alpha1.MissingConfigReference, name string) bool {
+	for _, m := range missing {
+		if m.EnvVar == name {
+			return true
+		}
+	}
+	return false
+}
+
+// describeMissingConfig describes a missing configuration reference for the root cause
+func describeMissingConfig(missing infrav1alpha1.MissingConfigReference, containerName string) string {
+	var description string
+	switch {
+	case missing.Kind == "Env":
+		return fmt.Sprintf("environment variable %s is not set in container %s", missing.Name, containerName)
+	case missing.Key != "":
+		description = fmt.Sprintf("%s %s has no key %s", missing.Kind, missing.Name, missing.Key)
+	default:
+		description = fmt.Sprintf("%s %s does not exist", missing.Kind, missing.Name)
+	}
+	if missing.EnvVar != "" {
+		description += fmt.Sprintf(" (referenced by %s)", missing.EnvVar)
+	}
+	return description
+}
+
+// podContainer returns the container or init container of a pod with the given name
+func podContainer(pod *corev1.Pod, name string) *corev1.Container {
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for i := range containers {
+			if containers[i].Name == name {
+				return &containers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// containerConfigReferences returns the ConfigMaps and Secrets, and their keys, a
+// container requires through its environment and its volume mounts. Optional
+// references are left out, since the container starts without them.
+func containerConfigReferences(pod *corev1.Pod, container *corev1.Container) []configReference {
+	var refs []configReference
+	required := func(optional *bool) bool { return optional == nil || !*optional }
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil && required(ref.Optional) {
+			refs = append(refs, configReference{kind: "ConfigMap", name: ref.Name, key: ref.Key, envVar: env.Name})
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil && required(ref.Optional) {
+			refs = append(refs, configReference{kind: "Secret", name: ref.Name, key: ref.Key, envVar: env.Name})
+		}
+	}
+	for _, envFrom := range container.EnvFrom {
+		if ref := envFrom.ConfigMapRef; ref != nil && required(ref.Optional) {
+			refs = append(refs, configReference{kind: "ConfigMap", name: ref.Name, prefix: envFrom.Prefix, envFrom: true})
+		}
+		if ref := envFrom.SecretRef; ref != nil && required(ref.Optional) {
+			refs = append(refs, configReference{kind: "Secret", name: ref.Name, prefix: envFrom.Prefix, envFrom: true})
+		}
+	}
+
+	mounted := map[string]bool{}
+	for _, mount := range container.VolumeMounts {
+		mounted[mount.Name] = true
+	}
+	addVolume := func(kind, name string, items []corev1.KeyToPath) {
+		if len(items) == 0 {
+			refs = append(refs, configReference{kind: kind, name: name})
+		}
+		for _, item := range items {
+			refs = append(refs, configReference{kind: kind, name: name, key: item.Key})
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if !mounted[volume.Name] {
+			continue
+		}
+		if source := volume.ConfigMap; source != nil && required(source.Optional) {
+			addVolume("ConfigMap", source.Name, source.Items)
+		}
+		if source := volume.Secret; source != nil && required(source.Optional) {
+			addVolume("Secret", source.SecretName, source.Items)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil && required(source.ConfigMap.Optional) {
+				addVolume("ConfigMap", source.ConfigMap.Name, source.ConfigMap.Items)
+			}
+			if source.Secret != nil && required(source.Secret.Optional) {
+				addVolume("Secret", source.Secret.Name, source.Secret.Items)
+			}
+		}
+	}
+	return refs
+}
+
+// configKeys returns the keys of a ConfigMap or Secret, or nil if it does not exist
+func configKeys(ctx context.Context, k8sClient kubernetes.Interface, namespace, kind, name string) (map[string]bool, error) {
+	keys := map[string]bool{}
+	if kind == "ConfigMap" {
+		configMap, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for key := range configMap.Data {
+			keys[key] = true
+		}
+		for key := range configMap.BinaryData {
+			keys[key] = true
+		}
+		return keys, nil
+	}
+	secret, err := k8sClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for key := range secret.Data {
+		keys[key] = true
+	}
+	return keys, nil
+}
+
+// mergeConfigResult folds the missing configuration into the log-based result. A
+// reference that does not resolve is a more concrete cause than the log excerpt, so it
+// leads the root cause.
+func mergeConfigResult(result *infrav1alpha1.LogAnalysisResult, configResult *infrav1alpha1.ConfigAnalysisResult) *infrav1alpha1.LogAnalysisResult {
+	if configResult == nil || (result == nil && configResult.RootCause == "") {
+		return result
+	}
+
+	if result == nil {
+		result = &infrav1alpha1.LogAnalysisResult{
+			RootCause:  configResult.RootCause,
+			Confidence: configResult.Confidence,
+		}
+	} else if configResult.RootCause != "" {
+		if result.RootCause != "" {
+			result.RootCause = fmt.Sprintf("[Config] %s | %s", configResult.RootCause, result.RootCause)
+		} else {
+			result.RootCause = configResult.RootCause
+		}
+		result.Confidence = max(result.Confidence, configResult.Confidence)
+	}
+
+	result.ConfigResult = configResult
+	return result
+}
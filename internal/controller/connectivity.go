@@ -0,0 +1,491 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultConnectivityTimeout    = 3 * time.Second
+	defaultConnectivityMaxTargets = 3
+	// connectivityCacheTTL is how long a probe result is reused across reconciles
+	connectivityCacheTTL = time.Minute
+)
+
+var (
+	// endpointDialRegex matches "dial tcp db.example.svc:5432"
+	endpointDialRegex = regexp.MustCompile(`dial (?:tcp|udp)[46]? ([A-Za-z0-9][A-Za-z0-9.\-]*):([0-9]{1,5})`)
+	// endpointLookupRegex matches "lookup db.example.svc on 10.96.0.10:53"
+	endpointLookupRegex = regexp.MustCompile(`lookup ([A-Za-z0-9][A-Za-z0-9.\-]*)`)
+	// endpointURLRegex matches hosts in http(s) URLs
+	endpointURLRegex = regexp.MustCompile(`(https?)://([A-Za-z0-9][A-Za-z0-9.\-]*)(?::([0-9]{1,5}))?`)
+	// endpointHostPortRegex matches "host.domain:port" and "10.0.0.1:port"
+	endpointHostPortRegex = regexp.MustCompile(`\b([A-Za-z0-9][A-Za-z0-9\-]*(?:\.[A-Za-z0-9\-]+)+):([0-9]{2,5})\b`)
+	// endpointIPRegex matches IPv4 addresses, which need no DNS lookup
+	endpointIPRegex = regexp.MustCompile(`^[0-9]{1,3}(?:\.[0-9]{1,3}){3}$`)
+)
+
+// endpoint is a host mentioned in an error, with a port if one was mentioned
+type endpoint struct {
+	Host string
+	Port string
+}
+
+func (e endpoint) String() string {
+	if e.Port == "" {
+		return e.Host
+	}
+	return e.Host + ":" + e.Port
+}
+
+func (e endpoint) isIP() bool {
+	return endpointIPRegex.MatchString(e.Host)
+}
+
+// extractEndpoints returns up to maxEndpoints distinct hosts and host:port pairs mentioned
+// in error text, in the order of how specific the match is (dial errors first)
+func extractEndpoints(text string, maxEndpoints int) []endpoint {
+	var endpoints []endpoint
+	seen := make(map[string]bool)
+	add := func(host, port string) {
+		host = strings.TrimSuffix(host, ".")
+		if host == "" || len(endpoints) >= maxEndpoints {
+			return
+		}
+		if n, err := strconv.Atoi(port); port != "" && (err != nil || n < 1 || n > 65535) {
+			return
+		}
+		ep := endpoint{Host: host, Port: port}
+		// A bare host adds nothing if it was already seen with a port
+		if seen[ep.String()] || (port == "" && seen[host+":*"]) {
+			return
+		}
+		seen[ep.String()] = true
+		seen[host+":*"] = true
+		endpoints = append(endpoints, ep)
+	}
+
+	for _, m := range endpointDialRegex.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range endpointURLRegex.FindAllStringSubmatch(text, -1) {
+		port := m[3]
+		if port == "" {
+			port = "80"
+			if m[1] == "https" {
+				port = "443"
+			}
+		}
+		add(m[2], port)
+	}
+	for _, m := range endpointHostPortRegex.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range endpointLookupRegex.FindAllStringSubmatch(text, -1) {
+		add(m[1], "")
+	}
+	return endpoints
+}
+
+// connectivityCacheEntry is a probe result reused until it expires
+type connectivityCacheEntry struct {
+	result    infrav1alpha1.ConnectivityResult
+	expiresAt time.Time
+}
+
+// checkConnectivity probes the targets found by log analysis from the operator and
+// reports NetworkPolicies that could be blocking them from the pod
+func (r *PodSleuthReconciler) checkConnectivity(ctx context.Context, pod *corev1.Pod, config *infrav1alpha1.ConnectivityCheckConfig, analysis *infrav1alpha1.LogAnalysisResult) []infrav1alpha1.ConnectivityResult {
+	if analysis == nil {
+		return nil
+	}
+
+	timeout := defaultConnectivityTimeout
+	if config.Timeout != nil && config.Timeout.Duration > 0 {
+		timeout = config.Timeout.Duration
+	}
+	maxTargets := defaultConnectivityMaxTargets
+	if config.MaxTargets != nil {
+		maxTargets = int(*config.MaxTargets)
+	}
+
+	text := strings.Join(append([]string{analysis.RootCause}, analysis.ErrorLines...), "\n")
+	var results []infrav1alpha1.ConnectivityResult
+	for _, ep := range extractEndpoints(text, maxTargets) {
+		key := fmt.Sprintf("%s/%s", pod.UID, ep)
+		if result, ok := r.getCachedConnectivity(key); ok {
+			results = append(results, result)
+			continue
+		}
+		result := r.probeEndpoint(ctx, pod, ep, timeout)
+		r.setCachedConnectivity(key, result)
+		results = append(results, result)
+	}
+	return results
+}
+
+// probeEndpoint resolves and connects to a target and evaluates the NetworkPolicies on the path
+func (r *PodSleuthReconciler) probeEndpoint(ctx context.Context, pod *corev1.Pod, ep endpoint, timeout time.Duration) infrav1alpha1.ConnectivityResult {
+	logger := log.Log
+	result := infrav1alpha1.ConnectivityResult{Target: ep.String()}
+	host := qualifyServiceHost(ep.Host, pod.Namespace)
+
+	if ep.isIP() {
+		result.Resolved = true
+		result.Addresses = []string{ep.Host}
+	} else {
+		lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+		addresses, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+		cancel()
+		if err != nil {
+			result.Error = fmt.Sprintf("lookup failed: %v", err)
+		} else {
+			result.Resolved = true
+			result.Addresses = addresses
+		}
+	}
+
+	if result.Resolved && ep.Port != "" {
+		dialer := net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, ep.Port))
+		reachable := err == nil
+		result.Reachable = &reachable
+		if err != nil {
+			result.Error = fmt.Sprintf("connect failed: %v", err)
+		} else {
+			conn.Close()
+		}
+	}
+
+	blockers, err := r.findBlockingNetworkPolicies(ctx, pod, ep, result.Addresses)
+	if err != nil {
+		logger.Info("unable to evaluate network policies", "pod", pod.Name, "namespace", pod.Namespace, "target", ep.String(), "error", err)
+	}
+	result.BlockingNetworkPolicies = blockers
+	result.Summary = summarizeConnectivity(result)
+	return result
+}
+
+// summarizeConnectivity describes a connectivity result in one sentence
+func summarizeConnectivity(result infrav1alpha1.ConnectivityResult) string {
+	var summary string
+	switch {
+	case !result.Resolved:
+		summary = fmt.Sprintf("%s does not resolve (%s)", result.Target, result.Error)
+	case result.Reachable == nil:
+		summary = fmt.Sprintf("%s resolves to %s", result.Target, strings.Join(result.Addresses, ", "))
+	case *result.Reachable:
+		summary = fmt.Sprintf("%s accepts connections from the operator", result.Target)
+	default:
+		summary = fmt.Sprintf("%s resolves but does not accept connections (%s)", result.Target, result.Error)
+	}
+	if len(result.BlockingNetworkPolicies) > 0 {
+		summary += fmt.Sprintf("; NetworkPolicy %s could be blocking traffic from the pod", strings.Join(result.BlockingNetworkPolicies, ", "))
+	}
+	return summary
+}
+
+// hasConnectivityProblem reports whether a result points at a connectivity issue
+func hasConnectivityProblem(result infrav1alpha1.ConnectivityResult) bool {
+	return !result.Resolved || (result.Reachable != nil && !*result.Reachable) || len(result.BlockingNetworkPolicies) > 0
+}
+
+// qualifyServiceHost turns a short Service name into a name the operator can resolve,
+// since the pod's DNS search path is relative to its own namespace
+func qualifyServiceHost(host, namespace string) string {
+	if endpointIPRegex.MatchString(host) || strings.Contains(host, ".") {
+		return host
+	}
+	return fmt.Sprintf("%s.%s.svc", host, namespace)
+}
+
+// serviceDestination is the set of pods behind an in-cluster Service target
+type serviceDestination struct {
+	Namespace       string
+	NamespaceLabels map[string]string
+	PodLabels       map[string]string
+	// TargetPort is the pod port traffic is sent to, 0 if unknown or named
+	TargetPort int32
+}
+
+// resolveServiceDestination returns the pods behind a target if it names a Service
+func (r *PodSleuthReconciler) resolveServiceDestination(ctx context.Context, ep endpoint, podNamespace string) *serviceDestination {
+	if ep.isIP() {
+		return nil
+	}
+	parts := strings.Split(qualifyServiceHost(ep.Host, podNamespace), ".")
+	if len(parts) < 2 || (len(parts) > 2 && parts[2] != "svc") {
+		return nil
+	}
+
+	var svc corev1.Service
+	if err := r.Get(ctx, types.NamespacedName{Name: parts[0], Namespace: parts[1]}, &svc); err != nil || len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	dest := &serviceDestination{Namespace: svc.Namespace, PodLabels: svc.Spec.Selector}
+	if port, err := strconv.Atoi(ep.Port); err == nil {
+		for _, sp := range svc.Spec.Ports {
+			if sp.Port == int32(port) {
+				dest.TargetPort = sp.TargetPort.IntVal
+				if sp.TargetPort.IntVal == 0 && sp.TargetPort.StrVal == "" {
+					dest.TargetPort = sp.Port
+				}
+			}
+		}
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: svc.Namespace}, &ns); err == nil {
+		dest.NamespaceLabels = ns.Labels
+	}
+	return dest
+}
+
+// findBlockingNetworkPolicies returns the NetworkPolicies that isolate the pod's egress or
+// the destination's ingress without a rule that allows the traffic to the target.
+// Traffic is allowed when any policy allows it, so all isolating policies are reported.
+func (r *PodSleuthReconciler) findBlockingNetworkPolicies(ctx context.Context, pod *corev1.Pod, ep endpoint, addresses []string) ([]string, error) {
+	port := int32(0)
+	if p, err := strconv.Atoi(ep.Port); err == nil {
+		port = int32(p)
+	}
+	dest := r.resolveServiceDestination(ctx, ep, pod.Namespace)
+	destPort := port
+	if dest != nil && dest.TargetPort != 0 {
+		destPort = dest.TargetPort
+	}
+
+	var blockers []string
+
+	// Egress from the pod
+	var policies networkingv1.NetworkPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, err
+	}
+	var isolating []string
+	allowed, dnsAllowed := false, false
+	for _, policy := range policies.Items {
+		if !hasPolicyType(&policy, networkingv1.PolicyTypeEgress) || !selectorMatches(&policy.Spec.PodSelector, pod.Labels) {
+			continue
+		}
+		isolating = append(isolating, policy.Namespace+"/"+policy.Name)
+		for _, rule := range policy.Spec.Egress {
+			if portAllowed(rule.Ports, 53, corev1.ProtocolUDP) {
+				dnsAllowed = true
+			}
+			if destPort != 0 && !portAllowed(rule.Ports, destPort, corev1.ProtocolTCP) {
+				continue
+			}
+			if len(rule.To) == 0 {
+				allowed = true
+			}
+			for _, peer := range rule.To {
+				if egressPeerMatches(peer, policy.Namespace, dest, addresses) {
+					allowed = true
+				}
+			}
+		}
+	}
+	// Names need DNS, so egress policies without a DNS rule block every hostname target
+	if len(isolating) > 0 && (!allowed || (!ep.isIP() && !dnsAllowed)) {
+		blockers = append(blockers, isolating...)
+	}
+
+	// Ingress to the Service's pods
+	if dest == nil {
+		return blockers, nil
+	}
+	var destPolicies networkingv1.NetworkPolicyList
+	if err := r.List(ctx, &destPolicies, client.InNamespace(dest.Namespace)); err != nil {
+		return blockers, err
+	}
+	var sourceNamespaceLabels map[string]string
+	var sourceNamespace corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.Namespace}, &sourceNamespace); err == nil {
+		sourceNamespaceLabels = sourceNamespace.Labels
+	}
+	isolating = nil
+	allowed = false
+	for _, policy := range destPolicies.Items {
+		if !hasPolicyType(&policy, networkingv1.PolicyTypeIngress) || !selectorMatches(&policy.Spec.PodSelector, dest.PodLabels) {
+			continue
+		}
+		isolating = append(isolating, policy.Namespace+"/"+policy.Name)
+		for _, rule := range policy.Spec.Ingress {
+			if destPort != 0 && !portAllowed(rule.Ports, destPort, corev1.ProtocolTCP) {
+				continue
+			}
+			if len(rule.From) == 0 {
+				allowed = true
+			}
+			for _, peer := range rule.From {
+				if peerMatches(peer, policy.Namespace, pod.Namespace, sourceNamespaceLabels, pod.Labels, []string{pod.Status.PodIP}) {
+					allowed = true
+				}
+			}
+		}
+	}
+	if len(isolating) > 0 && !allowed {
+		blockers = append(blockers, isolating...)
+	}
+	return blockers, nil
+}
+
+// egressPeerMatches reports whether an egress peer can match the target. Without a
+// resolved Service, selector peers cannot be ruled out and are assumed to match.
+func egressPeerMatches(peer networkingv1.NetworkPolicyPeer, policyNamespace string, dest *serviceDestination, addresses []string) bool {
+	if peer.IPBlock != nil {
+		return peerMatches(peer, policyNamespace, "", nil, nil, addresses)
+	}
+	if dest == nil {
+		return true
+	}
+	return peerMatches(peer, policyNamespace, dest.Namespace, dest.NamespaceLabels, dest.PodLabels, nil)
+}
+
+// peerMatches reports whether a NetworkPolicy peer selects the given pod or addresses
+func peerMatches(peer networkingv1.NetworkPolicyPeer, policyNamespace, namespace string, namespaceLabels, podLabels map[string]string, addresses []string) bool {
+	if peer.IPBlock != nil {
+		_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+		if err != nil {
+			return false
+		}
+		for _, addr := range addresses {
+			ip := net.ParseIP(addr)
+			if ip == nil || !cidr.Contains(ip) {
+				continue
+			}
+			excluded := false
+			for _, except := range peer.IPBlock.Except {
+				if _, exceptNet, err := net.ParseCIDR(except); err == nil && exceptNet.Contains(ip) {
+					excluded = true
+				}
+			}
+			if !excluded {
+				return true
+			}
+		}
+		return false
+	}
+
+	if peer.NamespaceSelector != nil {
+		// The namespace name label is set by the API server on every namespace
+		nsLabels := labels.Merge(namespaceLabels, labels.Set{"kubernetes.io/metadata.name": namespace})
+		if !selectorMatches(peer.NamespaceSelector, nsLabels) {
+			return false
+		}
+	} else if namespace != policyNamespace {
+		// A pod selector alone only selects pods in the policy's namespace
+		return false
+	}
+	if peer.PodSelector != nil {
+		return selectorMatches(peer.PodSelector, podLabels)
+	}
+	return true
+}
+
+// hasPolicyType reports whether a NetworkPolicy applies to the given direction
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		// Ingress is always implied; egress only when egress rules are present
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// portAllowed reports whether a rule's ports allow the port. Named ports cannot be
+// resolved without the destination pod spec and are assumed to match.
+func portAllowed(ports []networkingv1.NetworkPolicyPort, port int32, protocol corev1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			ruleProtocol = *p.Protocol
+		}
+		if ruleProtocol != protocol {
+			continue
+		}
+		if p.Port == nil || p.Port.StrVal != "" {
+			return true
+		}
+		if p.Port.IntVal == port || (p.EndPort != nil && port >= p.Port.IntVal && port <= *p.EndPort) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorMatches reports whether a label selector matches a set of labels
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
+
+// getCachedConnectivity returns a cached probe result that has not expired
+func (r *PodSleuthReconciler) getCachedConnectivity(key string) (infrav1alpha1.ConnectivityResult, bool) {
+	r.connectivityCacheMux.Lock()
+	defer r.connectivityCacheMux.Unlock()
+
+	entry, exists := r.connectivityCache[key]
+	if !exists || time.Now().After(entry.expiresAt) {
+		return infrav1alpha1.ConnectivityResult{}, false
+	}
+	return entry.result, true
+}
+
+// setCachedConnectivity stores a probe result and drops expired ones
+func (r *PodSleuthReconciler) setCachedConnectivity(key string, result infrav1alpha1.ConnectivityResult) {
+	r.connectivityCacheMux.Lock()
+	defer r.connectivityCacheMux.Unlock()
+
+	if r.connectivityCache == nil {
+		r.connectivityCache = make(map[string]connectivityCacheEntry)
+	}
+	now := time.Now()
+	for k, entry := range r.connectivityCache {
+		if now.After(entry.expiresAt) {
+			delete(r.connectivityCache, k)
+		}
+	}
+	r.connectivityCache[key] = connectivityCacheEntry{result: result, expiresAt: now.Add(connectivityCacheTTL)}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultCrashLoopMinRestarts = 3
+	defaultCrashLoopWindow      = 2 * time.Hour
+	// maxTerminationRecords bounds the terminations kept per container
+	maxTerminationRecords = 50
+	// maxReportedTerminations bounds the terminations included in the status
+	maxReportedTerminations = 10
+	// crashHistoryRetention is how long the history of a pod is kept after it was last seen not ready
+	crashHistoryRetention = 24 * time.Hour
+)
+
+// restartSample is the restart count of a container at a point in time
+type restartSample struct {
+	observedAt   time.Time
+	restartCount int32
+}
+
+// containerCrashHistory holds the terminations observed for a container across reconciles.
+// Kubernetes only keeps the last termination state, so the history is built up over time.
+type containerCrashHistory struct {
+	samples      []restartSample
+	terminations []infrav1alpha1.TerminationRecord
+}
+
+// podCrashHistory holds the crash history of all containers of a pod
+type podCrashHistory struct {
+	containers map[string]*containerCrashHistory
+	lastSeen   time.Time
+}
+
+// crashLoopSettings holds the effective crash-loop trend configuration with defaults applied
+type crashLoopSettings struct {
+	Enabled     bool
+	MinRestarts int32
+	Window      time.Duration
+}
+
+// getCrashLoopSettings returns the crash-loop trend settings for a configuration
+func getCrashLoopSettings(config *infrav1alpha1.CrashLoopTrendConfig) crashLoopSettings {
+	settings := crashLoopSettings{
+		Enabled:     true,
+		MinRestarts: defaultCrashLoopMinRestarts,
+		Window:      defaultCrashLoopWindow,
+	}
+	if config == nil {
+		return settings
+	}
+	if config.Enabled != nil {
+		settings.Enabled = *config.Enabled
+	}
+	if config.MinRestarts != nil && *config.MinRestarts > 0 {
+		settings.MinRestarts = *config.MinRestarts
+	}
+	if config.Window != nil && config.Window.Duration > 0 {
+		settings.Window = config.Window.Duration
+	}
+	return settings
+}
+
+// recordCrashes updates the crash history of a pod from its container statuses
+func (r *PodSleuthReconciler) recordCrashes(pod *corev1.Pod, window time.Duration) {
+	r.crashHistoryMux.Lock()
+	defer r.crashHistoryMux.Unlock()
+
+	if r.crashHistory == nil {
+		r.crashHistory = make(map[types.UID]*podCrashHistory)
+	}
+
+	history, exists := r.crashHistory[pod.UID]
+	if !exists {
+		history = &podCrashHistory{containers: make(map[string]*containerCrashHistory)}
+		r.crashHistory[pod.UID] = history
+	}
+
+	now := time.Now()
+	history.lastSeen = now
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		ch, exists := history.containers[cs.Name]
+		if !exists {
+			ch = &containerCrashHistory{}
+			history.containers[cs.Name] = ch
+		}
+
+		if n := len(ch.samples); n == 0 || ch.samples[n-1].restartCount != cs.RestartCount {
+			ch.samples = append(ch.samples, restartSample{observedAt: now, restartCount: cs.RestartCount})
+		}
+
+		if terminated := cs.LastTerminationState.Terminated; terminated != nil && !terminated.FinishedAt.IsZero() {
+			if n := len(ch.terminations); n == 0 || !ch.terminations[n-1].FinishedAt.Equal(&terminated.FinishedAt) {
+				record := infrav1alpha1.TerminationRecord{
+					FinishedAt: terminated.FinishedAt,
+					ExitCode:   terminated.ExitCode,
+					Reason:     terminated.Reason,
+				}
+				if !terminated.StartedAt.IsZero() {
+					record.RuntimeSeconds = int32(terminated.FinishedAt.Sub(terminated.StartedAt.Time).Seconds())
+				}
+				ch.terminations = append(ch.terminations, record)
+			}
+		}
+
+		// Drop data that fell out of the window, but keep the latest sample as a baseline
+		cutoff := now.Add(-window)
+		for len(ch.samples) > 1 && ch.samples[1].observedAt.Before(cutoff) {
+			ch.samples = ch.samples[1:]
+		}
+		for len(ch.terminations) > 0 && (ch.terminations[0].FinishedAt.Time.Before(cutoff) || len(ch.terminations) > maxTerminationRecords) {
+			ch.terminations = ch.terminations[1:]
+		}
+	}
+}
+
+// recordCrashRootCause attaches a log analysis root cause to the latest termination
+// of the container whose logs were analyzed, unless it already has one
+func (r *PodSleuthReconciler) recordCrashRootCause(pod *corev1.Pod, containerName, rootCause string) {
+	if rootCause == "" {
+		return
+	}
+
+	r.crashHistoryMux.Lock()
+	defer r.crashHistoryMux.Unlock()
+
+	history, exists := r.crashHistory[pod.UID]
+	if !exists {
+		return
+	}
+	ch, exists := history.containers[containerName]
+	if !exists {
+		return
+	}
+	if n := len(ch.terminations); n > 0 && ch.terminations[n-1].RootCause == "" {
+		ch.terminations[n-1].RootCause = rootCause
+	}
+}
+
+// pruneCrashHistory forgets pods that have not been seen for longer than maxAge
+func (r *PodSleuthReconciler) pruneCrashHistory(maxAge time.Duration) {
+	r.crashHistoryMux.Lock()
+	defer r.crashHistoryMux.Unlock()
+
+	cutoff := time.Now().Add(-maxAge)
+	for uid, history := range r.crashHistory {
+		if history.lastSeen.Before(cutoff) {
+			delete(r.crashHistory, uid)
+		}
+	}
+}
+
+// getCrashLoopTrend returns the trend of the pod's most frequently restarting container,
+// or nil if no container restarted at least settings.MinRestarts times within the window
+func (r *PodSleuthReconciler) getCrashLoopTrend(pod *corev1.Pod, settings crashLoopSettings) *infrav1alpha1.CrashLoopTrend {
+	r.crashHistoryMux.Lock()
+	defer r.crashHistoryMux.Unlock()
+
+	history, exists := r.crashHistory[pod.UID]
+	if !exists {
+		return nil
+	}
+
+	now := time.Now()
+	var trend *infrav1alpha1.CrashLoopTrend
+	for name, ch := range history.containers {
+		if len(ch.samples) == 0 {
+			continue
+		}
+
+		// Restarts within the window are counted from the oldest sample we still have.
+		// Before enough history exists, the pod's own restart count and age are used.
+		latest := ch.samples[len(ch.samples)-1]
+		restarts := latest.restartCount - ch.samples[0].restartCount
+		since := ch.samples[0].observedAt
+		if len(ch.samples) == 1 || pod.CreationTimestamp.Time.After(now.Add(-settings.Window)) {
+			restarts = latest.restartCount
+			since = pod.CreationTimestamp.Time
+		}
+		if restarts < settings.MinRestarts || (trend != nil && restarts <= trend.Restarts) {
+			continue
+		}
+
+		trend = summarizeTerminations(name, restarts, now.Sub(since), ch.terminations)
+	}
+	return trend
+}
+
+// summarizeTerminations builds a crash-loop trend from the observed terminations of a container
+func summarizeTerminations(containerName string, restarts int32, span time.Duration, terminations []infrav1alpha1.TerminationRecord) *infrav1alpha1.CrashLoopTrend {
+	trend := &infrav1alpha1.CrashLoopTrend{
+		ContainerName: containerName,
+		Restarts:      restarts,
+		Window:        formatTrendWindow(span),
+	}
+
+	parts := []string{fmt.Sprintf("Crashed %d times in %s", restarts, trend.Window)}
+
+	if len(terminations) > 0 {
+		// Dominant exit code and reason
+		exitCodes := make(map[int32]int)
+		reasons := make(map[int32]string)
+		for _, t := range terminations {
+			exitCodes[t.ExitCode]++
+			if t.Reason != "" {
+				reasons[t.ExitCode] = t.Reason
+			}
+		}
+		var dominant int32
+		dominantCount := 0
+		for code, count := range exitCodes {
+			if count > dominantCount || (count == dominantCount && code < dominant) {
+				dominant, dominantCount = code, count
+			}
+		}
+		trend.DominantExitCode = &dominant
+		trend.DominantReason = reasons[dominant]
+
+		exitText := fmt.Sprintf("exit %d", dominant)
+		if trend.DominantReason != "" {
+			exitText = fmt.Sprintf("exit %d (%s)", dominant, trend.DominantReason)
+		}
+		switch {
+		case dominantCount == len(terminations) && len(terminations) > 1:
+			exitText = "always " + exitText
+		case dominantCount*10 >= len(terminations)*6:
+			exitText = "mostly " + exitText
+		case len(terminations) > 1:
+			codes := make([]string, 0, len(exitCodes))
+			for code := range exitCodes {
+				codes = append(codes, fmt.Sprintf("%d", code))
+			}
+			sort.Strings(codes)
+			exitText = "exit codes vary (" + strings.Join(codes, ", ") + ")"
+		}
+
+		// Typical runtime before the crash
+		var runtimes []int32
+		for _, t := range terminations {
+			if t.RuntimeSeconds > 0 {
+				runtimes = append(runtimes, t.RuntimeSeconds)
+			}
+		}
+		if len(runtimes) > 0 {
+			sort.Slice(runtimes, func(i, j int) bool { return runtimes[i] < runtimes[j] })
+			trend.TypicalRuntimeSeconds = runtimes[len(runtimes)/2]
+			exitText += fmt.Sprintf(" within ~%s of start", formatTrendWindow(time.Duration(trend.TypicalRuntimeSeconds)*time.Second))
+		}
+		parts = append(parts, exitText)
+
+		// Root cause seen for more than one container instance
+		rootCauses := make(map[string]int)
+		for _, t := range terminations {
+			if t.RootCause != "" {
+				rootCauses[t.RootCause]++
+			}
+		}
+		bestCount := 0
+		for cause, count := range rootCauses {
+			if count < 2 {
+				continue
+			}
+			if count > bestCount || (count == bestCount && cause < trend.RecurringRootCause) {
+				trend.RecurringRootCause, bestCount = cause, count
+			}
+		}
+		if trend.RecurringRootCause != "" {
+			parts = append(parts, fmt.Sprintf("logs of %d instances show: %s", bestCount, trend.RecurringRootCause))
+		}
+
+		// Newest first, bounded
+		for i := len(terminations) - 1; i >= 0 && len(trend.Terminations) < maxReportedTerminations; i-- {
+			trend.Terminations = append(trend.Terminations, terminations[i])
+		}
+	}
+
+	trend.Summary = strings.Join(parts, ", ")
+	return trend
+}
+
+// formatTrendWindow formats a duration coarsely for humans (e.g. "2h", "45m", "30s")
+func formatTrendWindow(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Round(time.Hour).Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Round(time.Second).Seconds()))
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordCrashRootCauseOnAnalyzedContainer(t *testing.T) {
+	finished := metav1.NewTime(time.Now().Add(-time.Minute))
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", FinishedAt: finished}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-1", UID: "cart-1-uid"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", RestartCount: 4, LastTerminationState: terminated, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			{Name: "sidecar", Ready: true, RestartCount: 1, LastTerminationState: terminated},
+		}},
+	}
+	r := &PodSleuthReconciler{}
+	r.recordCrashes(pod, time.Hour)
+
+	containerName, _ := logContainer(pod, blockingInitContainer(pod))
+	r.recordCrashRootCause(pod, containerName, "Database connection refused")
+	r.recordCrashRootCause(pod, containerName, "a later analysis")
+
+	containers := r.crashHistory[pod.UID].containers
+	if got := containers["app"].terminations[0].RootCause; got != "Database connection refused" {
+		t.Errorf("app termination root cause %q, want the first analysis", got)
+	}
+	if got := containers["sidecar"].terminations[0].RootCause; got != "" {
+		t.Errorf("sidecar termination got the root cause %q of another container", got)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	debugContainerName     = "podsleuth-debug"
+	defaultDebugImage      = "busybox:1.36"
+	defaultDebugMaxTargets = 5
+	// debugDiskFullPercent is the filesystem usage at which the disk check fails
+	debugDiskFullPercent = 90
+	// maxDebugOutputLines bounds the output kept per check
+	maxDebugOutputLines = 3
+)
+
+// debugScript runs the checks passed as arguments ("dns:<host>" or "tcp:<host>:<port>")
+// followed by a disk usage check. Every check starts with a "@@ <type> <target> <ok|fail>"
+// line followed by its output.
+const debugScript = `for t in "$@"; do
+  case "$t" in
+    dns:*)
+      h="${t#dns:}"
+      if out=$(nslookup "$h" 2>&1); then r=ok; else r=fail; fi
+      echo "@@ dns $h $r"; echo "$out" | tail -n 3 ;;
+    tcp:*)
+      hp="${t#tcp:}"; h="${hp%:*}"; p="${hp##*:}"
+      if out=$(nc -z -w 3 "$h" "$p" 2>&1); then r=ok; else r=fail; fi
+      echo "@@ tcp $hp $r"; echo "$out" | tail -n 3 ;;
+  esac
+done
+echo "@@ disk df ok"
+df -P`
+
+// runDebugDiagnostics attaches an ephemeral debug container to a failing pod and reports
+// its results once it has finished. Ephemeral containers cannot be removed, so only one is
+// ever added per pod; later reconciles read its output.
+func (r *PodSleuthReconciler) runDebugDiagnostics(ctx context.Context, pod *corev1.Pod, config *infrav1alpha1.DebugDiagnosticsConfig, podInfo *infrav1alpha1.NonReadyPodInfo) *infrav1alpha1.DebugDiagnosticsResult {
+	logger := log.Log
+
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name != debugContainerName {
+			continue
+		}
+		if status.State.Terminated == nil {
+			return &infrav1alpha1.DebugDiagnosticsResult{ContainerName: debugContainerName, State: "Running"}
+		}
+		return r.collectDebugDiagnostics(ctx, pod)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == debugContainerName {
+			// Added, but the kubelet has not reported a status yet
+			return &infrav1alpha1.DebugDiagnosticsResult{ContainerName: debugContainerName, State: "Running"}
+		}
+	}
+
+	// Ephemeral containers can only run in pods that are scheduled and running
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+
+	maxTargets := defaultDebugMaxTargets
+	if config.MaxTargets != nil {
+		maxTargets = int(*config.MaxTargets)
+	}
+	image := config.Image
+	if image == "" {
+		image = defaultDebugImage
+	}
+
+	checks := extractDebugTargets(debugTargetText(podInfo), maxTargets)
+	command := append([]string{"sh", "-c", debugScript, debugContainerName}, checks...)
+
+	allowPrivilegeEscalation := false
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     debugContainerName,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Command:                  command,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		},
+	})
+
+	if err := r.SubResource("ephemeralcontainers").Update(ctx, updated); err != nil {
+		result := &infrav1alpha1.DebugDiagnosticsResult{ContainerName: debugContainerName, State: "Failed"}
+		if apierrors.IsForbidden(err) {
+			result.Error = "not permitted to add ephemeral containers: apply config/rbac/debug_diagnostics_role.yaml to enable debug diagnostics"
+		} else {
+			result.Error = fmt.Sprintf("failed to add debug container: %v", err)
+		}
+		logger.Info("unable to attach debug container", "pod", pod.Name, "namespace", pod.Namespace, "error", err)
+		return result
+	}
+
+	logger.Info("attached debug container", "pod", pod.Name, "namespace", pod.Namespace, "checks", checks)
+	return &infrav1alpha1.DebugDiagnosticsResult{ContainerName: debugContainerName, State: "Running"}
+}
+
+// collectDebugDiagnostics reads and parses the output of a finished debug container
+func (r *PodSleuthReconciler) collectDebugDiagnostics(ctx context.Context, pod *corev1.Pod) *infrav1alpha1.DebugDiagnosticsResult {
+	result := &infrav1alpha1.DebugDiagnosticsResult{ContainerName: debugContainerName, State: "Completed"}
+
+	if err := r.LogFetchLimiter.Wait(ctx, pod.Spec.NodeName); err != nil {
+		result.State = "Failed"
+		result.Error = fmt.Sprintf("failed to read debug container output: %v", err)
+		return result
+	}
+	limitBytes := int64(64 * 1024)
+	stream, err := r.K8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  debugContainerName,
+		LimitBytes: &limitBytes,
+	}).Stream(ctx)
+	if err != nil {
+		result.State = "Failed"
+		result.Error = fmt.Sprintf("failed to read debug container output: %v", err)
+		return result
+	}
+	defer stream.Close()
+
+	result.Checks = parseDebugOutput(stream)
+	result.Findings = summarizeDebugChecks(result.Checks)
+	return result
+}
+
+// debugTargetText collects the error text hosts are extracted from
+func debugTargetText(podInfo *infrav1alpha1.NonReadyPodInfo) string {
+	parts := []string{podInfo.Message}
+	for _, ce := range podInfo.ContainerErrors {
+		parts = append(parts, ce.Message)
+	}
+	if podInfo.LogAnalysis != nil {
+		parts = append(parts, podInfo.LogAnalysis.RootCause)
+		parts = append(parts, podInfo.LogAnalysis.ErrorLines...)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// extractDebugTargets returns the DNS and TCP checks for the hosts mentioned in error text.
+// Only hostnames and ports matched by strict patterns are returned, so the checks are safe
+// to pass to the debug script.
+func extractDebugTargets(text string, maxTargets int) []string {
+	var checks []string
+	seen := make(map[string]bool)
+	for _, ep := range extractEndpoints(text, maxTargets) {
+		if !ep.isIP() && !seen[ep.Host] {
+			seen[ep.Host] = true
+			checks = append(checks, "dns:"+ep.Host)
+		}
+		if ep.Port != "" {
+			checks = append(checks, "tcp:"+ep.String())
+		}
+	}
+	return checks
+}
+
+// parseDebugOutput parses the output of debugScript into checks
+func parseDebugOutput(output io.Reader) []infrav1alpha1.DebugCheck {
+	var checks []infrav1alpha1.DebugCheck
+	var lines []string
+	flush := func() {
+		if len(checks) == 0 {
+			return
+		}
+		current := &checks[len(checks)-1]
+		switch {
+		case current.Type == "disk":
+			*current = parseDiskUsage(lines)
+		case len(lines) > maxDebugOutputLines:
+			current.Output = strings.Join(lines[len(lines)-maxDebugOutputLines:], "\n")
+		default:
+			current.Output = strings.Join(lines, "\n")
+		}
+		lines = nil
+	}
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if fields := strings.Fields(line); len(fields) == 4 && fields[0] == "@@" {
+			flush()
+			checks = append(checks, infrav1alpha1.DebugCheck{Type: fields[1], Target: fields[2], Passed: fields[3] == "ok"})
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	flush()
+	return checks
+}
+
+// parseDiskUsage turns "df -P" output into a disk check that fails if any
+// filesystem is at least debugDiskFullPercent full
+func parseDiskUsage(lines []string) infrav1alpha1.DebugCheck {
+	check := infrav1alpha1.DebugCheck{Type: "disk", Target: "/", Passed: true}
+	var full []string
+	for _, line := range lines {
+		// Filesystem 1024-blocks Used Available Capacity Mounted-on
+		fields := strings.Fields(line)
+		if len(fields) < 6 || !strings.HasSuffix(fields[4], "%") {
+			continue
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(fields[4], "%"))
+		if err != nil {
+			continue
+		}
+		mount := fields[5]
+		if mount == "/" {
+			check.Output = fmt.Sprintf("/ is %d%% full", percent)
+		}
+		if percent >= debugDiskFullPercent {
+			check.Passed = false
+			full = append(full, fmt.Sprintf("%s is %d%% full", mount, percent))
+		}
+	}
+	if len(full) > 0 {
+		check.Target = strings.Fields(full[0])[0]
+		check.Output = strings.Join(full, ", ")
+	}
+	return check
+}
+
+// summarizeDebugChecks describes the failed checks in one sentence
+func summarizeDebugChecks(checks []infrav1alpha1.DebugCheck) string {
+	var findings []string
+	for _, check := range checks {
+		if check.Passed {
+			continue
+		}
+		switch check.Type {
+		case "dns":
+			findings = append(findings, fmt.Sprintf("DNS lookup of %s failed", check.Target))
+		case "tcp":
+			findings = append(findings, fmt.Sprintf("TCP connection to %s failed", check.Target))
+		case "disk":
+			findings = append(findings, "disk space low: "+check.Output)
+		}
+	}
+	return strings.Join(findings, "; ")
+}
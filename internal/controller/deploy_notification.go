@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// notificationDeploy reports the verdict of a deploy verified by a pipeline
+const notificationDeploy = "deploy"
+
+// DeployVerdict is the verdict of a deploy verified by a pipeline
+type DeployVerdict struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	// Revision identifies the deploy, such as a commit or pipeline run
+	Revision string `json:"revision,omitempty"`
+	// Verdict is passed or failed
+	Verdict string `json:"verdict"`
+	// Reason explains a failed verdict
+	Reason   string `json:"reason,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// NotifyDeployVerdict sends the verdict of a deploy to the notification sinks of
+// podSleuth, or of the first PodSleuth with notifications selecting pods labeled
+// podLabels if empty. failing are the pods of the deploy that are not ready.
+func (r *PodSleuthReconciler) NotifyDeployVerdict(ctx context.Context, podSleuth string, podLabels map[string]string,
+	verdict DeployVerdict, failing []infrav1alpha1.NonReadyPodInfo) error {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := r.List(ctx, &podSleuthList); err != nil {
+		return err
+	}
+	var notifying *infrav1alpha1.PodSleuth
+	for i := range podSleuthList.Items {
+		ps := &podSleuthList.Items[i]
+		if podSleuth != "" && ps.Name != podSleuth {
+			continue
+		}
+		if ps.Spec.Notifications == nil {
+			continue
+		}
+		if ps.Spec.PodLabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(ps.Spec.PodLabelSelector)
+			if err != nil || !selector.Matches(labels.Set(podLabels)) {
+				continue
+			}
+		}
+		notifying = ps
+		break
+	}
+	if notifying == nil {
+		if podSleuth != "" {
+			return fmt.Errorf("PodSleuth %s has no notifications or does not select the pods of %s %s/%s",
+				podSleuth, verdict.Kind, verdict.Namespace, verdict.Name)
+		}
+		return nil
+	}
+
+	n := notification{
+		Type:        notificationDeploy,
+		PodSleuth:   notifying.Name,
+		IncidentKey: fmt.Sprintf("%s/deploy/%s/%s/%s", notifying.Name, verdict.Namespace, verdict.Kind, verdict.Name),
+		Pods:        failing,
+		ActivePods:  len(failing),
+		Timestamp:   time.Now(),
+		Deploy:      &verdict,
+	}
+	if len(failing) > 0 {
+		n.Pod = failing[0]
+	}
+	log.Log.Info("notifying deploy verdict", "podSleuth", notifying.Name, "namespace", verdict.Namespace,
+		"kind", verdict.Kind, "name", verdict.Name, "verdict", verdict.Verdict)
+	r.dispatchNotifications(notifying.Name, notifying.Spec.Notifications, []notification{n})
+	return nil
+}
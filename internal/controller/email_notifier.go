@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"html/template"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultSMTPPort    = 587
+	smtpDialTimeout    = 30 * time.Second
+	emailTLSStartTLS   = "StartTLS"
+	emailTLSImplicit   = "TLS"
+	emailTLSNone       = "None"
+	maxEmailRootCauses = 500
+)
+
+// emailDigest buffers notifications of a digest sink until its interval elapses
+type emailDigest struct {
+	pending  []notification
+	lastSent time.Time
+}
+
+// emailBatch is a set of notifications sent as one email
+type emailBatch struct {
+	Sink          infrav1alpha1.EmailSink
+	PodSleuth     string
+	Cluster       string
+	Notifications []notification
+	// PodCount counts non-ready pods and ResolvedCount pods that are ready again
+	PodCount      int
+	ResolvedCount int
+	Digest        bool
+}
+
+// emailTemplate renders the HTML summary of affected pods and root causes
+var emailTemplate = template.Must(template.New("email").Funcs(template.FuncMap{
+	"rootCause": func(pod infrav1alpha1.NonReadyPodInfo) string {
+		if pod.LogAnalysis == nil || pod.LogAnalysis.RootCause == "" {
+			return "-"
+		}
+		if len(pod.LogAnalysis.RootCause) > maxEmailRootCauses {
+			return pod.LogAnalysis.RootCause[:maxEmailRootCauses] + "..."
+		}
+		return pod.LogAnalysis.RootCause
+	},
+	"timestamp": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+}).Parse(`<html><body style="font-family: sans-serif; color: #333;">
+<h2 style="margin-bottom: 4px;">{{ if .PodCount }}{{ .PodCount }} pod{{ if gt .PodCount 1 }}s{{ end }} not ready{{ if .ResolvedCount }}, {{ end }}{{ end }}{{ if .ResolvedCount }}{{ .ResolvedCount }} pod{{ if gt .ResolvedCount 1 }}s{{ end }} resolved{{ end }}</h2>
+<p style="color: #666; margin-top: 0;">{{ if .Cluster }}Cluster <strong>{{ .Cluster }}</strong> &middot; {{ end }}PodSleuth <strong>{{ .PodSleuth }}</strong>{{ if .Digest }} &middot; digest{{ end }}</p>
+<table cellpadding="6" cellspacing="0" style="border-collapse: collapse; font-size: 13px;">
+<tr style="background: #f1f3f5; text-align: left;"><th>Detected</th><th>Pod</th><th>Owner</th><th>Reason</th><th>Root cause</th></tr>
+{{ range .Notifications }}{{ $n := . }}{{ if .Deploy }}<tr style="background: {{ if eq .Deploy.Verdict "passed" }}#ebfbee{{ else }}#fff5f5{{ end }};"><td colspan="5"><strong>Deploy of {{ .Deploy.Kind }} {{ .Deploy.Namespace }}/{{ .Deploy.Name }}{{ if .Deploy.Revision }} {{ .Deploy.Revision }}{{ end }} {{ .Deploy.Verdict }}</strong> after {{ .Deploy.Duration }}{{ if .Deploy.Reason }} &middot; {{ .Deploy.Reason }}{{ end }}</td></tr>
+{{ end }}{{ if .Node }}<tr style="background: #fff4e6;"><td colspan="5"><strong>Node {{ .Node.Name }} cordoned</strong> &middot; {{ .Node.Reason }} &middot; investigate, then kubectl uncordon {{ .Node.Name }}</td></tr>
+{{ end }}{{ if .Group }}<tr style="background: #e7f1ff;"><td colspan="5"><strong>{{ .Policy }}: {{ .Group }}</strong>{{ if eq .Type "repeat" }} (still failing){{ else if eq .Type "resolved" }} (resolved){{ end }} &middot; {{ .ActivePods }} pod{{ if ne .ActivePods 1 }}s{{ end }} non-ready</td></tr>
+{{ end }}{{ range .Pods }}<tr style="border-top: 1px solid #dee2e6; vertical-align: top;">
+<td>{{ timestamp $n.Timestamp }}</td>
+<td>{{ .Namespace }}/{{ .Name }}{{ if .Team }}<br><small>team {{ .Team }}</small>{{ end }}</td>
+<td>{{ if .OwnerKind }}{{ .OwnerKind }} {{ .OwnerName }}{{ else }}-{{ end }}</td>
+<td>{{ if eq $n.Type "resolved" }}<span style="color: #2b8a3e;">&#9989; resolved{{ if $n.Downtime }} after {{ $n.Downtime }}{{ end }}</span><br>{{ end }}<strong>{{ .Reason }}</strong>{{ if .Message }}<br><small>{{ .Message }}</small>{{ end }}{{ if $n.Proposal }}<br><span style="color: #1864ab;">Proposed change: {{ $n.Proposal.Summary }}</span>{{ end }}</td>
+<td>{{ rootCause . }}</td>
+</tr>
+{{ end }}{{ end }}</table>
+</body></html>
+`))
+
+// collectEmailDigests adds notifications to the digests of a PodSleuth's digest sinks and
+// returns the digests whose interval has elapsed
+func (r *PodSleuthReconciler) collectEmailDigests(podSleuthName string, sinks []infrav1alpha1.EmailSink, notifications []notification) []emailBatch {
+	r.emailDigestsMux.Lock()
+	defer r.emailDigestsMux.Unlock()
+	if r.emailDigests == nil {
+		r.emailDigests = make(map[string]*emailDigest)
+	}
+
+	now := time.Now()
+	var due []emailBatch
+	for _, sink := range sinks {
+		if sink.DigestInterval == nil || sink.DigestInterval.Duration <= 0 {
+			continue
+		}
+		key := podSleuthName + "/" + sink.Name
+		digest, exists := r.emailDigests[key]
+		if !exists {
+			digest = &emailDigest{lastSent: now}
+			r.emailDigests[key] = digest
+		}
+		for _, n := range notifications {
+			if n.deliversTo(sink.Name) {
+				digest.pending = append(digest.pending, n)
+			}
+		}
+		if len(digest.pending) == 0 || now.Sub(digest.lastSent) < sink.DigestInterval.Duration {
+			continue
+		}
+		due = append(due, *newEmailBatch(sink, podSleuthName, digest.pending, true))
+		digest.pending = nil
+		digest.lastSent = now
+	}
+	return due
+}
+
+// newEmailBatch returns the notifications delivered to an email sink as one batch,
+// or nil if none are
+func newEmailBatch(sink infrav1alpha1.EmailSink, podSleuthName string, notifications []notification, digest bool) *emailBatch {
+	batch := &emailBatch{Sink: *sink.DeepCopy(), PodSleuth: podSleuthName, Cluster: ClusterName(), Digest: digest}
+	for _, n := range notifications {
+		if n.deliversTo(sink.Name) {
+			batch.Notifications = append(batch.Notifications, n)
+			if n.Type == notificationResolved {
+				batch.ResolvedCount += len(n.Pods)
+			} else {
+				batch.PodCount += len(n.Pods)
+			}
+		}
+	}
+	if len(batch.Notifications) == 0 {
+		return nil
+	}
+	return batch
+}
+
+// forgetEmailDigests drops the pending digests of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetEmailDigests(podSleuthName string) {
+	r.emailDigestsMux.Lock()
+	defer r.emailDigestsMux.Unlock()
+	for key := range r.emailDigests {
+		if strings.HasPrefix(key, podSleuthName+"/") {
+			delete(r.emailDigests, key)
+		}
+	}
+}
+
+// renderEmail builds the MIME message of an email batch
+func renderEmail(batch emailBatch) ([]byte, error) {
+	var body bytes.Buffer
+	if err := emailTemplate.Execute(&body, batch); err != nil {
+		return nil, fmt.Errorf("failed to render email: %w", err)
+	}
+
+	var summary []string
+	if batch.PodCount > 0 {
+		summary = append(summary, fmt.Sprintf("%d pod(s) not ready", batch.PodCount))
+	}
+	if batch.ResolvedCount > 0 {
+		summary = append(summary, fmt.Sprintf("%d pod(s) resolved", batch.ResolvedCount))
+	}
+	for _, n := range batch.Notifications {
+		if n.Deploy != nil {
+			summary = append(summary, fmt.Sprintf("deploy of %s/%s %s", n.Deploy.Namespace, n.Deploy.Name, n.Deploy.Verdict))
+		}
+	}
+	podSleuth := batch.PodSleuth
+	if batch.Cluster != "" {
+		podSleuth = batch.Cluster + "/" + podSleuth
+	}
+	subject := fmt.Sprintf("[KubeSleuth] %s (%s)", strings.Join(summary, ", "), podSleuth)
+	if batch.Digest {
+		subject = fmt.Sprintf("[KubeSleuth] Digest: %s (%s)", strings.Join(summary, ", "), podSleuth)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", batch.Sink.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(batch.Sink.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// sendEmail delivers an email batch over SMTP
+func (r *PodSleuthReconciler) sendEmail(ctx context.Context, batch emailBatch) error {
+	sink := &batch.Sink
+	message, err := renderEmail(batch)
+	if err != nil {
+		return err
+	}
+
+	var password string
+	if sink.PasswordSecretRef != nil {
+		if password, err = r.notificationSecret(ctx, sink.PasswordSecretRef); err != nil {
+			return fmt.Errorf("failed to get SMTP password: %w", err)
+		}
+	}
+
+	port := int(sink.Port)
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	addr := net.JoinHostPort(sink.Host, strconv.Itoa(port))
+	tlsConfig := applyOutboundTLSPolicy(&tls.Config{ServerName: sink.Host, InsecureSkipVerify: sink.InsecureSkipVerify}) // #nosec G402 -- opt-in per sink
+	mode := sink.TLS
+	if mode == "" {
+		mode = emailTLSStartTLS
+	}
+
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+	var conn net.Conn
+	if mode == emailTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, sink.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if mode == emailTLSStartTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+	if sink.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", sink.Username, password, sink.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	if err := c.Mail(sink.From); err != nil {
+		return fmt.Errorf("MAIL FROM failed: %w", err)
+	}
+	for _, to := range sink.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("RCPT TO %s failed: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("DATA failed: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return c.Quit()
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// eventAnalysis is published when log analysis finds a new root cause
+const eventAnalysis = "analysis"
+
+// sleuthEvent is a detection, resolution or analysis result published to event streams
+type sleuthEvent struct {
+	// Type is "detected", "resolved" or "analysis"
+	Type            string                        `json:"type"`
+	Cluster         string                        `json:"cluster,omitempty"`
+	PodSleuth       string                        `json:"podSleuth"`
+	Namespace       string                        `json:"namespace"`
+	Pod             string                        `json:"pod"`
+	OwnerKind       string                        `json:"ownerKind,omitempty"`
+	OwnerName       string                        `json:"ownerName,omitempty"`
+	Team            string                        `json:"team,omitempty"`
+	Reason          string                        `json:"reason,omitempty"`
+	Message         string                        `json:"message,omitempty"`
+	Severity        string                        `json:"severity"`
+	RootCause       string                        `json:"rootCause,omitempty"`
+	Confidence      int32                         `json:"confidence,omitempty"`
+	DowntimeSeconds int64                         `json:"downtimeSeconds,omitempty"`
+	Timestamp       time.Time                     `json:"timestamp"`
+	Details         infrav1alpha1.NonReadyPodInfo `json:"details"`
+}
+
+// newSleuthEvents converts the transitions of a reconcile into stream events
+func newSleuthEvents(podSleuthName string, transitions []podTransition, now time.Time) []sleuthEvent {
+	events := make([]sleuthEvent, 0, len(transitions))
+	for _, transition := range transitions {
+		pod := transition.Pod
+		event := sleuthEvent{
+			Cluster:   ClusterName(),
+			PodSleuth: podSleuthName,
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			OwnerKind: pod.OwnerKind,
+			OwnerName: pod.OwnerName,
+			Team:      pod.Team,
+			Reason:    pod.Reason,
+			Message:   pod.Message,
+			Severity:  PodSeverity(&pod),
+			Timestamp: now,
+			Details:   pod,
+		}
+		if pod.LogAnalysis != nil {
+			event.RootCause = pod.LogAnalysis.RootCause
+			event.Confidence = pod.LogAnalysis.Confidence
+		}
+		switch transition.Kind {
+		case transitionDetected:
+			event.Type = transitionDetected
+		case transitionRecovered:
+			event.Type = notificationResolved
+			if pod.DetectedAt != nil {
+				event.DowntimeSeconds = int64(now.Sub(pod.DetectedAt.Time).Seconds())
+			}
+		case transitionRootCause:
+			event.Type = eventAnalysis
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// publishEvents publishes the transitions of a reconcile to the configured event
+// streams in the background
+func (r *PodSleuthReconciler) publishEvents(podSleuth *infrav1alpha1.PodSleuth, transitions []podTransition) {
+	config := podSleuth.Spec.EventStream
+	if config == nil || len(transitions) == 0 || len(config.Kafka)+len(config.NATS)+len(config.CloudEvents) == 0 {
+		return
+	}
+	events := newSleuthEvents(podSleuth.Name, transitions, time.Now())
+	if len(events) == 0 {
+		return
+	}
+	go r.deliverEvents(config.DeepCopy(), events)
+}
+
+// deliverEvents publishes events to each event stream sink
+func (r *PodSleuthReconciler) deliverEvents(config *infrav1alpha1.EventStreamConfig, events []sleuthEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+	defer cancel()
+	logger := log.Log.WithName("notifications")
+
+	for i := range config.Kafka {
+		sink := &config.Kafka[i]
+		err := r.publishKafka(ctx, sink, events)
+		notificationsSent.WithLabelValues("kafka", sink.Name, outcomeOf(err)).Add(float64(len(events)))
+		if err != nil {
+			logger.Info("kafka publish failed", "sink", sink.Name, "topic", sink.Topic, "events", len(events), "error", err)
+		}
+	}
+
+	for i := range config.NATS {
+		sink := &config.NATS[i]
+		err := r.publishNATS(ctx, sink, config.Source, events)
+		notificationsSent.WithLabelValues("nats", sink.Name, outcomeOf(err)).Add(float64(len(events)))
+		if err != nil {
+			logger.Info("nats publish failed", "sink", sink.Name, "subject", sink.Subject, "events", len(events), "error", err)
+		}
+	}
+
+	for i := range config.CloudEvents {
+		sink := &config.CloudEvents[i]
+		for _, event := range events {
+			err := r.sendCloudEvent(ctx, sink, newCloudEvent(config.Source, event))
+			notificationsSent.WithLabelValues("cloudevents", sink.Name, outcomeOf(err)).Inc()
+			if err != nil {
+				logger.Info("cloudevent delivery failed", "sink", sink.Name, "type", event.Type, "pod", event.Pod, "namespace", event.Namespace, "error", err)
+			}
+		}
+	}
+}
+
+// streamTLSConfig builds the TLS configuration of an event stream sink
+func (r *PodSleuthReconciler) streamTLSConfig(ctx context.Context, config *infrav1alpha1.StreamTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify} // #nosec G402 -- opt-in per sink
+	if config.CASecretRef != nil {
+		ca, err := r.notificationSecret(ctx, config.CASecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("CA bundle contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return applyOutboundTLSPolicy(tlsConfig), nil
+}
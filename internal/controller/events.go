@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Event reasons emitted for detections and resolutions
+const (
+	eventReasonPodNotReady         = "PodNotReady"
+	eventReasonPodRecovered        = "PodRecovered"
+	eventReasonRootCauseIdentified = "RootCauseIdentified"
+)
+
+// maxEventMessageLength keeps event messages readable in kubectl describe
+const maxEventMessageLength = 512
+
+// workloadAPIVersions are the API versions of owner kinds that can receive events
+var workloadAPIVersions = map[string]string{
+	"Deployment":            "apps/v1",
+	"StatefulSet":           "apps/v1",
+	"DaemonSet":             "apps/v1",
+	"ReplicaSet":            "apps/v1",
+	"Job":                   "batch/v1",
+	"CronJob":               "batch/v1",
+	"ReplicationController": "v1",
+	"Rollout":               "argoproj.io/v1alpha1",
+}
+
+// emitTransitionEvents emits Events for pods that became non-ready, recovered, or got
+// a new root cause since the previous reconcile
+func (r *PodSleuthReconciler) emitTransitionEvents(podSleuth *infrav1alpha1.PodSleuth, transitions []podTransition) {
+	config := podSleuth.Spec.Events
+	if r.Recorder == nil || (config != nil && config.Enabled != nil && !*config.Enabled) {
+		return
+	}
+	onWorkloads := config != nil && config.OnWorkloads
+
+	for i := range transitions {
+		pod := &transitions[i].Pod
+		key := pod.Namespace + "/" + pod.Name
+
+		var eventType, reason, message string
+		switch transitions[i].Kind {
+		case transitionDetected:
+			eventType, reason, message = corev1.EventTypeWarning, eventReasonPodNotReady, describeNotReadyPod(pod)
+		case transitionRootCause:
+			eventType, reason = corev1.EventTypeWarning, eventReasonRootCauseIdentified
+			message = fmt.Sprintf("Pod %s: %s (confidence %d%%)", key, pod.LogAnalysis.RootCause, pod.LogAnalysis.Confidence)
+		case transitionRecovered:
+			eventType, reason, message = corev1.EventTypeNormal, eventReasonPodRecovered, fmt.Sprintf("Pod %s is ready or gone", key)
+		default:
+			continue
+		}
+		if len(message) > maxEventMessageLength {
+			message = message[:maxEventMessageLength-3] + "..."
+		}
+
+		r.Recorder.Event(podSleuth, eventType, reason, message)
+		if ref := workloadReference(pod); onWorkloads && ref != nil {
+			r.Recorder.Event(ref, eventType, reason, message)
+		}
+	}
+}
+
+// describeNotReadyPod returns the event message for a pod that became non-ready
+func describeNotReadyPod(pod *infrav1alpha1.NonReadyPodInfo) string {
+	message := fmt.Sprintf("Pod %s/%s is not ready (phase %s)", pod.Namespace, pod.Name, pod.Phase)
+	if pod.Reason != "" {
+		message += ": " + pod.Reason
+	}
+	if pod.Message != "" {
+		message += ": " + pod.Message
+	}
+	return message
+}
+
+// workloadReference returns a reference to the workload owning a pod, or nil if the
+// owner kind is unknown
+func workloadReference(pod *infrav1alpha1.NonReadyPodInfo) *corev1.ObjectReference {
+	apiVersion, ok := workloadAPIVersions[pod.OwnerKind]
+	if !ok || pod.OwnerName == "" {
+		return nil
+	}
+	return &corev1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       pod.OwnerKind,
+		Name:       pod.OwnerName,
+		Namespace:  pod.Namespace,
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	severityCritical = "critical"
+	severityWarning  = "warning"
+	severityInfo     = "info"
+
+	// criticalEvictionCount is the number of evictions on one node that makes the group critical
+	criticalEvictionCount = 5
+)
+
+var (
+	// evictionResourceRegex matches "The node was low on resource: ephemeral-storage."
+	evictionResourceRegex = regexp.MustCompile(`low on resource: ([A-Za-z0-9.\-/]+?)\.?(?:\s|$)`)
+	// evictionConditionRegex matches "The node had condition: [DiskPressure]."
+	evictionConditionRegex = regexp.MustCompile(`had condition: \[([A-Za-z]+)\]`)
+)
+
+// isEvictedOrShutdown reports whether a pod failed because it was evicted or its node shut down
+func isEvictedOrShutdown(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	switch pod.Status.Reason {
+	case "Evicted", "NodeShutdown":
+		return true
+	case "Terminated":
+		// Graceful node shutdown on recent Kubernetes versions
+		return strings.Contains(strings.ToLower(pod.Status.Message), "node shutdown")
+	}
+	return false
+}
+
+// newEvictedPodInfo extracts eviction details from a pod's status message
+func newEvictedPodInfo(pod *corev1.Pod, ownerKind, ownerName string) infrav1alpha1.EvictedPodInfo {
+	info := infrav1alpha1.EvictedPodInfo{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Reason:    pod.Status.Reason,
+		Message:   pod.Status.Message,
+		OwnerKind: ownerKind,
+		OwnerName: ownerName,
+	}
+	if m := evictionResourceRegex.FindStringSubmatch(pod.Status.Message); m != nil {
+		info.Resource = m[1]
+	}
+	if m := evictionConditionRegex.FindStringSubmatch(pod.Status.Message); m != nil {
+		info.NodeCondition = m[1]
+	}
+	return info
+}
+
+// evictedPod is an evicted pod together with the node it ran on
+type evictedPod struct {
+	NodeName string
+	Info     infrav1alpha1.EvictedPodInfo
+}
+
+// groupEvictedPods groups evicted pods by node and assigns each group a severity.
+// Groups are sorted by severity, then by size.
+func groupEvictedPods(pods []evictedPod) []infrav1alpha1.EvictedPodGroup {
+	groups := make(map[string]*infrav1alpha1.EvictedPodGroup)
+	var order []string
+	for _, evicted := range pods {
+		pod := evicted.Info
+		node := evicted.NodeName
+		if node == "" {
+			node = "unknown"
+		}
+		group, exists := groups[node]
+		if !exists {
+			group = &infrav1alpha1.EvictedPodGroup{NodeName: node}
+			groups[node] = group
+			order = append(order, node)
+		}
+		group.Pods = append(group.Pods, pod)
+		group.Count++
+
+		resource := pod.Resource
+		if resource == "" {
+			resource = pod.NodeCondition
+		}
+		if resource != "" && !slices.Contains(group.Resources, resource) {
+			group.Resources = append(group.Resources, resource)
+		}
+	}
+
+	result := make([]infrav1alpha1.EvictedPodGroup, 0, len(order))
+	for _, node := range order {
+		group := groups[node]
+		sort.Strings(group.Resources)
+		group.Severity, group.Summary = describeEvictedPodGroup(group)
+		result = append(result, *group)
+	}
+
+	rank := map[string]int{severityCritical: 0, severityWarning: 1, severityInfo: 2}
+	sort.SliceStable(result, func(i, j int) bool {
+		if rank[result[i].Severity] != rank[result[j].Severity] {
+			return rank[result[i].Severity] < rank[result[j].Severity]
+		}
+		return result[i].Count > result[j].Count
+	})
+	return result
+}
+
+// describeEvictedPodGroup returns the severity and summary of a node's evicted pods.
+// Resource pressure evictions are warnings, critical when many pods are affected;
+// node shutdowns are expected during maintenance and reported as info.
+func describeEvictedPodGroup(group *infrav1alpha1.EvictedPodGroup) (string, string) {
+	evicted := 0
+	for _, pod := range group.Pods {
+		if pod.Reason == "Evicted" {
+			evicted++
+		}
+	}
+	shutdown := int(group.Count) - evicted
+
+	var parts []string
+	if evicted > 0 {
+		text := fmt.Sprintf("%d pod(s) evicted", evicted)
+		if len(group.Resources) > 0 {
+			text += " due to " + strings.Join(group.Resources, ", ") + " pressure"
+		}
+		parts = append(parts, text)
+	}
+	if shutdown > 0 {
+		parts = append(parts, fmt.Sprintf("%d pod(s) terminated by node shutdown", shutdown))
+	}
+	summary := fmt.Sprintf("Node %s: %s", group.NodeName, strings.Join(parts, ", "))
+
+	switch {
+	case evicted >= criticalEvictionCount:
+		return severityCritical, summary
+	case evicted > 0:
+		return severityWarning, summary
+	}
+	return severityInfo, summary
+}
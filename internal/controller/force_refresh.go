@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// AnnotationForceRefresh makes a PodSleuth analyze all of its non-ready pods again,
+	// bypassing the analysis cache
+	AnnotationForceRefresh = "kubesleuth.io/force-refresh"
+	// AnnotationForceRefreshPods makes a PodSleuth analyze the pods it lists again, as
+	// comma-separated namespace/name
+	AnnotationForceRefreshPods = "kubesleuth.io/force-refresh-pod"
+	// AnnotationForceRefreshGeneration is the generation of the forced analyses, recorded
+	// as the refreshGeneration of their results so clients can tell when they finished
+	AnnotationForceRefreshGeneration = "kubesleuth.io/force-refresh-generation"
+)
+
+// forceRefresh is a forced analysis requested by the annotations of a PodSleuth
+type forceRefresh struct {
+	all        bool
+	pods       map[string]bool
+	generation int64
+	// annotations are the annotations requesting it, removed once it is handled
+	annotations map[string]string
+}
+
+// forceRefreshOf returns the forced analysis the annotations of a PodSleuth request
+func forceRefreshOf(annotations map[string]string) forceRefresh {
+	refresh := forceRefresh{annotations: make(map[string]string)}
+	for _, key := range []string{AnnotationForceRefresh, AnnotationForceRefreshPods, AnnotationForceRefreshGeneration} {
+		if value, exists := annotations[key]; exists {
+			refresh.annotations[key] = value
+		}
+	}
+	_, refresh.all = annotations[AnnotationForceRefresh]
+	for _, pod := range strings.Split(annotations[AnnotationForceRefreshPods], ",") {
+		if pod = strings.TrimSpace(pod); pod != "" {
+			if refresh.pods == nil {
+				refresh.pods = make(map[string]bool)
+			}
+			refresh.pods[pod] = true
+		}
+	}
+	refresh.generation, _ = strconv.ParseInt(annotations[AnnotationForceRefreshGeneration], 10, 64)
+	return refresh
+}
+
+// requested reports whether any analysis is forced
+func (f forceRefresh) requested() bool {
+	return f.all || len(f.pods) > 0
+}
+
+// forces reports whether the analysis of a pod, as namespace/name, is forced
+func (f forceRefresh) forces(podKey string) bool {
+	return f.all || f.pods[podKey]
+}
+
+// generationOf returns the generation of the forced analysis of a pod, 0 if not forced
+func (f forceRefresh) generationOf(podKey string) int64 {
+	if !f.forces(podKey) {
+		return 0
+	}
+	return f.generation
+}
+
+// clear removes the annotations that requested the forced analysis from annotations.
+// If a new request changed them since, they are all left for the next reconcile.
+func (f forceRefresh) clear(annotations map[string]string) bool {
+	for _, key := range []string{AnnotationForceRefresh, AnnotationForceRefreshPods, AnnotationForceRefreshGeneration} {
+		if annotations[key] != f.annotations[key] {
+			return false
+		}
+	}
+	changed := false
+	for key := range f.annotations {
+		delete(annotations, key)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Annotations set on GitOps applications while their pods are not ready
+const (
+	annotationGitOpsHealth    = "kubesleuth.io/health"
+	annotationGitOpsSummary   = "kubesleuth.io/summary"
+	annotationGitOpsRootCause = "kubesleuth.io/root-cause"
+)
+
+const (
+	defaultArgoCDNamespace = "argocd"
+	// gitOpsLookupTTL is how long the application of a workload is cached
+	gitOpsLookupTTL = 10 * time.Minute
+	// maxGitOpsAnnotationLength keeps annotations readable in dashboards
+	maxGitOpsAnnotationLength = 1024
+)
+
+// argoCDTrackingLabels are the labels Argo CD tracks resources with: a custom label
+// commonly configured to avoid clashes with Helm, then the default
+var argoCDTrackingLabels = []string{"argocd.argoproj.io/instance", "app.kubernetes.io/instance"}
+
+// fluxTrackingLabels are the labels Flux sets on the resources it applies
+var fluxTrackingLabels = []struct {
+	nameLabel      string
+	namespaceLabel string
+	apiVersion     string
+	kind           string
+}{
+	{"helm.toolkit.fluxcd.io/name", "helm.toolkit.fluxcd.io/namespace", "helm.toolkit.fluxcd.io/v2", "HelmRelease"},
+	{"kustomize.toolkit.fluxcd.io/name", "kustomize.toolkit.fluxcd.io/namespace", "kustomize.toolkit.fluxcd.io/v1", "Kustomization"},
+}
+
+// gitOpsApp identifies an Argo CD Application, Flux Kustomization or HelmRelease
+type gitOpsApp struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+func (a gitOpsApp) key() string {
+	return a.Kind + "/" + a.Namespace + "/" + a.Name
+}
+
+// gitOpsLookup caches the application of a workload, nil if it has none
+type gitOpsLookup struct {
+	app     *gitOpsApp
+	expires time.Time
+}
+
+// gitOpsAppState is the status last reported on an application
+type gitOpsAppState struct {
+	app       gitOpsApp
+	uid       types.UID
+	summary   string
+	rootCause string
+}
+
+// syncGitOps reports non-ready pods and their root cause on the GitOps applications
+// deploying them, and clears the report once all of an application's pods recover
+func (r *PodSleuthReconciler) syncGitOps(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo) {
+	config := podSleuth.Spec.GitOps
+	if config == nil {
+		return
+	}
+	logger := log.Log.WithName("gitops")
+	annotate := config.Annotate == nil || *config.Annotate
+	emitEvents := r.Recorder != nil && (config.Events == nil || *config.Events)
+	argoCDNamespace := config.ArgoCDNamespace
+	if argoCDNamespace == "" {
+		argoCDNamespace = defaultArgoCDNamespace
+	}
+
+	apps := make(map[string]gitOpsApp)
+	active := make(map[string][]infrav1alpha1.NonReadyPodInfo)
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) {
+			continue
+		}
+		app := r.gitOpsAppFor(ctx, pod, argoCDNamespace)
+		if app == nil {
+			continue
+		}
+		key := podSleuth.Name + "/" + app.key()
+		apps[key] = *app
+		active[key] = append(active[key], *pod)
+	}
+
+	r.gitOpsMux.Lock()
+	previous := make(map[string]*gitOpsAppState)
+	for key, state := range r.gitOpsApps {
+		if strings.HasPrefix(key, podSleuth.Name+"/") {
+			previous[key] = state
+		}
+	}
+	r.gitOpsMux.Unlock()
+
+	for key, pods := range active {
+		summary, rootCause := summarizeGitOpsPods(pods)
+		prev := previous[key]
+		if prev != nil && prev.summary == summary && prev.rootCause == rootCause {
+			continue
+		}
+		state := &gitOpsAppState{app: apps[key], summary: summary, rootCause: rootCause}
+		if prev != nil {
+			state.uid = prev.uid
+		}
+		var annotations map[string]interface{}
+		if annotate {
+			annotations = map[string]interface{}{
+				annotationGitOpsHealth:    "Degraded",
+				annotationGitOpsSummary:   summary,
+				annotationGitOpsRootCause: nil,
+			}
+			if rootCause != "" {
+				annotations[annotationGitOpsRootCause] = rootCause
+			}
+		}
+		uid, err := r.updateGitOpsApp(ctx, state.app, annotations, state.uid)
+		if err != nil {
+			logger.Info("failed to update gitops application", "application", state.app.key(), "error", err)
+			continue
+		}
+		state.uid = uid
+
+		if emitEvents {
+			ref := gitOpsAppReference(state.app, uid)
+			if prev == nil {
+				r.Recorder.Event(ref, corev1.EventTypeWarning, eventReasonPodNotReady, truncateString(summary, maxEventMessageLength))
+			}
+			if rootCause != "" && (prev == nil || prev.rootCause != rootCause) {
+				r.Recorder.Event(ref, corev1.EventTypeWarning, eventReasonRootCauseIdentified, truncateString(rootCause, maxEventMessageLength))
+			}
+		}
+		r.gitOpsMux.Lock()
+		if r.gitOpsApps == nil {
+			r.gitOpsApps = make(map[string]*gitOpsAppState)
+		}
+		r.gitOpsApps[key] = state
+		r.gitOpsMux.Unlock()
+	}
+
+	for key, prev := range previous {
+		if _, stillActive := active[key]; stillActive {
+			continue
+		}
+		var annotations map[string]interface{}
+		if annotate {
+			annotations = map[string]interface{}{
+				annotationGitOpsHealth:    nil,
+				annotationGitOpsSummary:   nil,
+				annotationGitOpsRootCause: nil,
+			}
+		}
+		uid, err := r.updateGitOpsApp(ctx, prev.app, annotations, prev.uid)
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Info("failed to clear gitops application", "application", prev.app.key(), "error", err)
+			continue
+		}
+		if emitEvents && err == nil {
+			r.Recorder.Event(gitOpsAppReference(prev.app, uid), corev1.EventTypeNormal, eventReasonPodRecovered,
+				"All pods of "+prev.app.Kind+" "+prev.app.Name+" are ready or gone")
+		}
+		r.gitOpsMux.Lock()
+		delete(r.gitOpsApps, key)
+		r.gitOpsMux.Unlock()
+	}
+}
+
+// summarizeGitOpsPods describes the non-ready pods of an application and returns the
+// first root cause found by log analysis
+func summarizeGitOpsPods(pods []infrav1alpha1.NonReadyPodInfo) (string, string) {
+	sortPods(pods)
+	reasons := make(map[string]int)
+	rootCause := ""
+	for i := range pods {
+		if pods[i].Reason != "" {
+			reasons[pods[i].Reason]++
+		}
+		if rootCause == "" && pods[i].LogAnalysis != nil {
+			rootCause = pods[i].LogAnalysis.RootCause
+		}
+	}
+	names := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		names = append(names, reason)
+	}
+	sort.Strings(names)
+	summary := fmt.Sprintf("%d pod(s) not ready", len(pods))
+	for i, reason := range names {
+		if i == 0 {
+			summary += ": "
+		} else {
+			summary += ", "
+		}
+		summary += fmt.Sprintf("%s (%d)", reason, reasons[reason])
+	}
+	return truncateString(summary, maxGitOpsAnnotationLength), truncateString(rootCause, maxGitOpsAnnotationLength)
+}
+
+// gitOpsAppFor returns the GitOps application deploying a pod's workload, or nil
+func (r *PodSleuthReconciler) gitOpsAppFor(ctx context.Context, pod *infrav1alpha1.NonReadyPodInfo, argoCDNamespace string) *gitOpsApp {
+	apiVersion, kind, name := "v1", "Pod", pod.Name
+	if ownerAPIVersion, ok := workloadAPIVersions[pod.OwnerKind]; ok && pod.OwnerName != "" {
+		apiVersion, kind, name = ownerAPIVersion, pod.OwnerKind, pod.OwnerName
+	}
+	lookupKey := pod.Namespace + "/" + kind + "/" + name
+
+	r.gitOpsMux.Lock()
+	lookup, cached := r.gitOpsLookups[lookupKey]
+	r.gitOpsMux.Unlock()
+	if cached && time.Now().Before(lookup.expires) {
+		return lookup.app
+	}
+
+	workload := &unstructured.Unstructured{}
+	workload.SetAPIVersion(apiVersion)
+	workload.SetKind(kind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: name}, workload); err != nil {
+		return nil
+	}
+	app := gitOpsAppFromMetadata(workload.GetLabels(), workload.GetAnnotations(), argoCDNamespace)
+	if app != nil {
+		// Tracking labels like app.kubernetes.io/instance are also set by plain Helm
+		// releases, so only link applications that exist
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion(app.APIVersion)
+		object.SetKind(app.Kind)
+		if err := r.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: app.Name}, object); err != nil {
+			if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return nil
+			}
+			app = nil
+		}
+	}
+
+	r.gitOpsMux.Lock()
+	if r.gitOpsLookups == nil {
+		r.gitOpsLookups = make(map[string]gitOpsLookup)
+	}
+	r.gitOpsLookups[lookupKey] = gitOpsLookup{app: app, expires: time.Now().Add(gitOpsLookupTTL)}
+	r.gitOpsMux.Unlock()
+	return app
+}
+
+// gitOpsAppFromMetadata finds the application in the tracking labels and annotations
+// of a resource. Flux labels take precedence, since Argo CD's default tracking label
+// is a common Helm label.
+func gitOpsAppFromMetadata(labels, annotations map[string]string, argoCDNamespace string) *gitOpsApp {
+	for _, tracking := range fluxTrackingLabels {
+		if name := labels[tracking.nameLabel]; name != "" && labels[tracking.namespaceLabel] != "" {
+			return &gitOpsApp{APIVersion: tracking.apiVersion, Kind: tracking.kind, Namespace: labels[tracking.namespaceLabel], Name: name}
+		}
+	}
+
+	// The tracking id has the form <application>:<group>/<kind>:<namespace>/<name>
+	appName := ""
+	if trackingID := annotations["argocd.argoproj.io/tracking-id"]; trackingID != "" {
+		appName, _, _ = strings.Cut(trackingID, ":")
+	}
+	for _, label := range argoCDTrackingLabels {
+		if appName == "" {
+			appName = labels[label]
+		}
+	}
+	if appName == "" {
+		return nil
+	}
+	// Applications outside the Argo CD namespace are tracked as <namespace>_<name>
+	namespace := argoCDNamespace
+	if ns, name, found := strings.Cut(appName, "_"); found {
+		namespace, appName = ns, name
+	}
+	return &gitOpsApp{APIVersion: "argoproj.io/v1alpha1", Kind: "Application", Namespace: namespace, Name: appName}
+}
+
+// updateGitOpsApp merge-patches the annotations of an application, or reads it if there
+// are none to patch, and returns its UID
+func (r *PodSleuthReconciler) updateGitOpsApp(ctx context.Context, app gitOpsApp, annotations map[string]interface{}, uid types.UID) (types.UID, error) {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(app.APIVersion)
+	object.SetKind(app.Kind)
+	object.SetNamespace(app.Namespace)
+	object.SetName(app.Name)
+	if annotations == nil {
+		if uid != "" {
+			return uid, nil
+		}
+		err := r.Get(ctx, client.ObjectKeyFromObject(object), object)
+		return object.GetUID(), err
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations}})
+	if err != nil {
+		return "", err
+	}
+	if err := r.Patch(ctx, object, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return "", err
+	}
+	return object.GetUID(), nil
+}
+
+// gitOpsAppReference returns the reference Events are emitted on. Argo CD lists the
+// Events of an application by UID.
+func gitOpsAppReference(app gitOpsApp, uid types.UID) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: app.APIVersion,
+		Kind:       app.Kind,
+		Namespace:  app.Namespace,
+		Name:       app.Name,
+		UID:        uid,
+	}
+}
+
+// forgetGitOpsApps drops the application state of a deleted PodSleuth. Annotations on
+// the applications are left in place.
+func (r *PodSleuthReconciler) forgetGitOpsApps(podSleuthName string) {
+	r.gitOpsMux.Lock()
+	defer r.gitOpsMux.Unlock()
+	for key := range r.gitOpsApps {
+		if strings.HasPrefix(key, podSleuthName+"/") {
+			delete(r.gitOpsApps, key)
+		}
+	}
+}
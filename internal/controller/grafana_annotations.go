@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// grafanaRegion is the annotation of one workload's non-ready window
+type grafanaRegion struct {
+	// id is the Grafana annotation id, zero until the annotation is created
+	id    int64
+	start time.Time
+	// incidentTag identifies the annotation when its id is unknown, e.g. after a restart
+	incidentTag string
+	tags        []string
+	text        string
+	pods        []infrav1alpha1.NonReadyPodInfo
+}
+
+// grafanaAnnotation is the part of a Grafana annotation the operator reads
+type grafanaAnnotation struct {
+	ID      int64 `json:"id"`
+	Time    int64 `json:"time"`
+	TimeEnd int64 `json:"timeEnd"`
+}
+
+// annotateGrafana starts a region annotation for workloads whose pods became non-ready
+// and ends the annotations of workloads that recovered. The Grafana API is called in
+// the background.
+func (r *PodSleuthReconciler) annotateGrafana(podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo) {
+	config := podSleuth.Spec.GrafanaAnnotations
+	if config == nil {
+		return
+	}
+	now := time.Now()
+
+	active := make(map[string][]infrav1alpha1.NonReadyPodInfo)
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) {
+			continue
+		}
+		key := podSleuth.Name + "/" + notificationGroupKey(groupByOwner, pod)
+		active[key] = append(active[key], *pod)
+	}
+
+	r.grafanaRegionsMux.Lock()
+	if r.grafanaRegions == nil {
+		r.grafanaRegions = make(map[string]*grafanaRegion)
+	}
+	var starts, ends []*grafanaRegion
+	for key, pods := range active {
+		if region, exists := r.grafanaRegions[key]; exists {
+			region.pods = pods
+			continue
+		}
+		region := newGrafanaRegion(podSleuth.Name, key, config.Tags, pods, now)
+		r.grafanaRegions[key] = region
+		starts = append(starts, region)
+	}
+	for key, region := range r.grafanaRegions {
+		if _, stillActive := active[key]; stillActive || !strings.HasPrefix(key, podSleuth.Name+"/") {
+			continue
+		}
+		delete(r.grafanaRegions, key)
+		ends = append(ends, region)
+	}
+	r.grafanaRegionsMux.Unlock()
+
+	if len(starts) == 0 && len(ends) == 0 {
+		return
+	}
+	go r.deliverGrafanaAnnotations(config.DeepCopy(), starts, ends, now)
+}
+
+// newGrafanaRegion describes the annotation of a workload's non-ready pods
+func newGrafanaRegion(podSleuthName, key string, extraTags []string, pods []infrav1alpha1.NonReadyPodInfo, now time.Time) *grafanaRegion {
+	sortPods(pods)
+	first := &pods[0]
+	region := &grafanaRegion{
+		start:       now,
+		incidentTag: incidentIssueLabel(podSleuthName, strings.TrimPrefix(key, podSleuthName+"/")),
+		pods:        pods,
+	}
+	for _, pod := range pods {
+		if pod.DetectedAt != nil && pod.DetectedAt.Time.Before(region.start) {
+			region.start = pod.DetectedAt.Time
+		}
+	}
+
+	subject := "Pod " + first.Namespace + "/" + first.Name
+	workloadTag := "pod:" + first.Name
+	if first.OwnerKind != "" {
+		subject = fmt.Sprintf("%s %s/%s", first.OwnerKind, first.Namespace, first.OwnerName)
+		workloadTag = "workload:" + first.OwnerKind + "/" + first.OwnerName
+	}
+	region.tags = []string{"kubesleuth", "podsleuth:" + podSleuthName, "namespace:" + first.Namespace, workloadTag, region.incidentTag}
+	if first.Cluster != "" {
+		region.tags = append(region.tags, "cluster:"+first.Cluster)
+	}
+	if first.Reason != "" {
+		region.tags = append(region.tags, "reason:"+first.Reason)
+	}
+	region.tags = append(region.tags, extraTags...)
+	region.text = fmt.Sprintf("%s not ready: %d pod(s)", subject, len(pods))
+	if first.Reason != "" {
+		region.text += ", " + first.Reason
+	}
+	return region
+}
+
+// deliverGrafanaAnnotations creates and ends region annotations
+func (r *PodSleuthReconciler) deliverGrafanaAnnotations(config *infrav1alpha1.GrafanaAnnotationsConfig, starts, ends []*grafanaRegion, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+	defer cancel()
+	logger := log.Log.WithName("notifications")
+
+	token, err := r.notificationSecret(ctx, &config.TokenSecretRef)
+	if err != nil {
+		logger.Info("grafana annotations disabled", "error", err)
+		return
+	}
+	baseURL := strings.TrimSuffix(config.URL, "/") + "/api/annotations"
+	headers := http.Header{}
+	headers.Set("Accept", "application/json")
+	headers.Set("Authorization", "Bearer "+token)
+
+	// openAnnotation finds the open annotation of a region, e.g. created before a restart
+	openAnnotation := func(region *grafanaRegion) (int64, error) {
+		query := url.Values{"tags": {region.incidentTag}, "type": {"annotation"}, "limit": {"10"}}
+		var annotations []grafanaAnnotation
+		if err := doJSONRequest(ctx, http.MethodGet, baseURL+"?"+query.Encode(), headers, nil, &annotations); err != nil {
+			return 0, err
+		}
+		for _, annotation := range annotations {
+			if annotation.TimeEnd == 0 || annotation.TimeEnd == annotation.Time {
+				return annotation.ID, nil
+			}
+		}
+		return 0, nil
+	}
+
+	for _, region := range starts {
+		id, err := openAnnotation(region)
+		if err == nil && id == 0 {
+			request := map[string]interface{}{
+				"time": region.start.UnixMilli(),
+				"tags": region.tags,
+				"text": region.text,
+			}
+			if config.DashboardUID != "" {
+				request["dashboardUID"] = config.DashboardUID
+			}
+			var result struct {
+				ID int64 `json:"id"`
+			}
+			err = doJSONRequest(ctx, http.MethodPost, baseURL, headers, request, &result)
+			id = result.ID
+		}
+		notificationsSent.WithLabelValues("grafana", "annotations", outcomeOf(err)).Inc()
+		if err != nil {
+			logger.Info("failed to create grafana annotation", "annotation", region.text, "error", err)
+			continue
+		}
+		r.grafanaRegionsMux.Lock()
+		region.id = id
+		r.grafanaRegionsMux.Unlock()
+	}
+
+	for _, region := range ends {
+		r.grafanaRegionsMux.Lock()
+		id, pods := region.id, region.pods
+		r.grafanaRegionsMux.Unlock()
+		var err error
+		if id == 0 {
+			id, err = openAnnotation(region)
+		}
+		if err == nil && id != 0 {
+			text := fmt.Sprintf("%s (resolved after %s)", region.text, now.Sub(region.start).Round(time.Second))
+			for _, pod := range pods {
+				if pod.LogAnalysis != nil && pod.LogAnalysis.RootCause != "" {
+					text += "\nRoot cause: " + truncateString(pod.LogAnalysis.RootCause, maxIssueBodyRootCause)
+					break
+				}
+			}
+			request := map[string]interface{}{"timeEnd": now.UnixMilli(), "text": text}
+			err = doJSONRequest(ctx, http.MethodPatch, baseURL+"/"+strconv.FormatInt(id, 10), headers, request, nil)
+		}
+		notificationsSent.WithLabelValues("grafana", "annotations", outcomeOf(err)).Inc()
+		if err != nil {
+			logger.Info("failed to end grafana annotation", "annotation", region.text, "error", err)
+		}
+	}
+}
+
+// forgetGrafanaRegions drops the open annotations of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetGrafanaRegions(podSleuthName string) {
+	r.grafanaRegionsMux.Lock()
+	defer r.grafanaRegionsMux.Unlock()
+	for key := range r.grafanaRegions {
+		if strings.HasPrefix(key, podSleuthName+"/") {
+			delete(r.grafanaRegions, key)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Causes of a blocked init sequence
+const (
+	initCauseWaitingForDependency = "WaitingForDependency"
+	initCauseFailing              = "Failing"
+	initCauseNotStarted           = "NotStarted"
+	initCauseRunning              = "Running"
+)
+
+// minWaitLoopLines is how many waiting or retrying lines make a log a wait loop
+const minWaitLoopLines = 3
+
+var (
+	// waitLineRegex matches the lines of a container polling for a dependency
+	waitLineRegex = regexp.MustCompile(`(?i)(waiting for|wait for|not (yet )?(ready|available|up)|retrying|will retry|trying again|connection refused|no such host|could not resolve|can't resolve|nxdomain|timed out|unreachable)`)
+	// pollingCommandRegex matches shell loops polling until a check succeeds
+	pollingCommandRegex = regexp.MustCompile(`\b(until|while)\b[\s\S]*\bsleep\b`)
+
+	// commandDependencyRegexes extract the dependency checked by common wait commands
+	commandDependencyRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`\bnslookup\s+([\w.-]+)`),
+		regexp.MustCompile(`\bnc\s+(?:-\S+\s+)*([\w.-]+)\s+(\d+)`),
+		regexp.MustCompile(`\bpg_isready\b.*?-h\s*([\w.-]+)`),
+		regexp.MustCompile(`\b(?:curl|wget)\b[^|;&]*?https?://([\w.-]+(?::\d+)?)`),
+	}
+	// logDependencyRegexes extract the dependency from waiting or connection error lines
+	logDependencyRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bwaiting for (?:service |host )?([a-z0-9][\w.-]*[a-z0-9](?::\d+)?)`),
+		regexp.MustCompile(`(?i)\b(?:dial tcp|connect to|connecting to|lookup|resolve) ([a-z0-9][\w.-]*[a-z0-9](?::\d+)?)`),
+	}
+)
+
+// initStep is the init container blocking a pod's startup
+type initStep struct {
+	container *corev1.Container
+	status    *corev1.ContainerStatus
+	// index is the position of the container in the init sequence, from 0
+	index int
+	steps int
+}
+
+// blockingInitContainer returns the first init container the pod's startup waits for:
+// an init container that has not completed, or a sidecar that has not started.
+// It returns nil once every init container is through.
+func blockingInitContainer(pod *corev1.Pod) *initStep {
+	statuses := make(map[string]*corev1.ContainerStatus, len(pod.Status.InitContainerStatuses))
+	for i := range pod.Status.InitContainerStatuses {
+		statuses[pod.Status.InitContainerStatuses[i].Name] = &pod.Status.InitContainerStatuses[i]
+	}
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		status := statuses[container.Name]
+		if status == nil {
+			return nil
+		}
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			if status.Started != nil && *status.Started {
+				continue
+			}
+		} else if status.State.Terminated != nil && status.State.Terminated.ExitCode == 0 {
+			continue
+		}
+		return &initStep{container: container, status: status, index: i, steps: len(pod.Spec.InitContainers)}
+	}
+	return nil
+}
+
+// hasLogs reports whether the blocking init container ran, so it has logs to read
+func (s *initStep) hasLogs() bool {
+	return s.status.State.Running != nil || s.status.State.Terminated != nil || s.status.LastTerminationState.Terminated != nil
+}
+
+// analyzeInitContainer explains why a blocking init container does not complete from
+// its state, its command and all of its log lines, not only error lines, since wait
+// loops log progress rather than errors
+func analyzeInitContainer(step *initStep, logLines []string, now time.Time) *infrav1alpha1.InitContainerAnalysisResult {
+	result := &infrav1alpha1.InitContainerAnalysisResult{
+		Container: step.container.Name,
+		Step:      int32(step.index + 1),
+		Steps:     int32(step.steps),
+	}
+	name := fmt.Sprintf("Init container %s (step %d of %d)", result.Container, result.Step, result.Steps)
+
+	command := strings.Join(append(append([]string{}, step.container.Command...), step.container.Args...), " ")
+	waitLines := 0
+	for _, line := range logLines {
+		if waitLineRegex.MatchString(line) {
+			waitLines++
+		}
+	}
+	polling := pollingCommandRegex.MatchString(command)
+	state := step.status.State
+	crashing := state.Terminated != nil || (state.Waiting != nil && state.Waiting.Reason == "CrashLoopBackOff")
+
+	switch {
+	case waitLines >= minWaitLoopLines || (polling && state.Running != nil):
+		result.Cause = initCauseWaitingForDependency
+		result.Dependency = initDependency(command, logLines)
+		result.Confidence = 80
+		dependency := "a dependency"
+		if result.Dependency != "" {
+			dependency = result.Dependency
+		}
+		result.RootCause = fmt.Sprintf("%s keeps waiting for %s, which never becomes available", name, dependency)
+		if state.Running != nil {
+			result.RootCause += fmt.Sprintf("; polling for %s", now.Sub(state.Running.StartedAt.Time).Round(time.Second))
+		}
+	case crashing:
+		result.Cause = initCauseFailing
+		result.Confidence = 60
+		terminated := state.Terminated
+		if terminated == nil {
+			terminated = step.status.LastTerminationState.Terminated
+		}
+		result.RootCause = name + " fails"
+		if terminated != nil {
+			result.RootCause = fmt.Sprintf("%s exits with code %d", name, terminated.ExitCode)
+			if terminated.Reason != "" {
+				result.RootCause += " (" + terminated.Reason + ")"
+			}
+		}
+		if step.status.RestartCount > 0 {
+			result.RootCause += fmt.Sprintf(", restarted %d times", step.status.RestartCount)
+		}
+	case state.Waiting != nil:
+		result.Cause = initCauseNotStarted
+		result.Confidence = 60
+		result.RootCause = fmt.Sprintf("%s cannot start: %s", name, state.Waiting.Reason)
+		if state.Waiting.Message != "" {
+			result.RootCause += ": " + state.Waiting.Message
+		}
+	default:
+		result.Cause = initCauseRunning
+		result.Confidence = 30
+		result.RootCause = name + " has not completed"
+		if state.Running != nil {
+			result.RootCause = fmt.Sprintf("%s has been running for %s without completing", name, now.Sub(state.Running.StartedAt.Time).Round(time.Second))
+		}
+	}
+	return result
+}
+
+// initDependency returns the dependency an init container waits for, from its wait
+// command or else from the most recent log line naming one
+func initDependency(command string, logLines []string) string {
+	for _, re := range commandDependencyRegexes {
+		if match := re.FindStringSubmatch(command); match != nil {
+			if len(match) > 2 {
+				return match[1] + ":" + match[2]
+			}
+			return match[1]
+		}
+	}
+	for i := len(logLines) - 1; i >= 0; i-- {
+		for _, re := range logDependencyRegexes {
+			if match := re.FindStringSubmatch(logLines[i]); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// mergeInitContainerResult adds the analysis of a blocking init container to the
+// result of its log analysis
+func mergeInitContainerResult(result *infrav1alpha1.LogAnalysisResult, initResult *infrav1alpha1.InitContainerAnalysisResult) *infrav1alpha1.LogAnalysisResult {
+	if initResult == nil {
+		return result
+	}
+	if result == nil {
+		result = &infrav1alpha1.LogAnalysisResult{
+			RootCause:  initResult.RootCause,
+			Confidence: initResult.Confidence,
+		}
+	} else if result.RootCause != "" {
+		result.RootCause = fmt.Sprintf("[Init] %s | %s", initResult.RootCause, result.RootCause)
+		result.Confidence = max(result.Confidence, initResult.Confidence)
+	} else {
+		result.RootCause = initResult.RootCause
+		result.Confidence = initResult.Confidence
+	}
+	result.InitContainerResult = initResult
+	return result
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultJiraIssueType       = "Task"
+	defaultJiraCloseTransition = "Done"
+	defaultGitHubAPIURL        = "https://api.github.com"
+)
+
+// jiraTracker manages issues through the Jira REST API v2
+type jiraTracker struct {
+	config  *infrav1alpha1.JiraIssueConfig
+	baseURL string
+	headers http.Header
+}
+
+// newJiraTracker authenticates with basic auth if a username is set, else a bearer token
+func newJiraTracker(config *infrav1alpha1.JiraIssueConfig, token string) *jiraTracker {
+	headers := http.Header{}
+	headers.Set("Accept", "application/json")
+	if config.Username != "" {
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(config.Username+":"+token)))
+	} else {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+	return &jiraTracker{config: config, baseURL: strings.TrimSuffix(config.URL, "/"), headers: headers}
+}
+
+func (t *jiraTracker) name() string { return "jira" }
+
+func (t *jiraTracker) openIssues(ctx context.Context, label string) ([]trackedIssue, error) {
+	jql := fmt.Sprintf("project = %q AND labels = %q AND statusCategory != Done", t.config.Project, label)
+	query := url.Values{"jql": {jql}, "fields": {"labels"}, "maxResults": {"100"}}
+	var result struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Labels []string `json:"labels"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := doJSONRequest(ctx, http.MethodGet, t.baseURL+"/rest/api/2/search?"+query.Encode(), t.headers, nil, &result); err != nil {
+		return nil, err
+	}
+	issues := make([]trackedIssue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		issues = append(issues, trackedIssue{ID: issue.Key, URL: t.baseURL + "/browse/" + issue.Key, Labels: issue.Fields.Labels})
+	}
+	return issues, nil
+}
+
+func (t *jiraTracker) createIssue(ctx context.Context, title, body string, labels []string) (*trackedIssue, error) {
+	issueType := t.config.IssueType
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+	request := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": t.config.Project},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     title,
+			"description": body,
+			"labels":      labels,
+		},
+	}
+	var result struct {
+		Key string `json:"key"`
+	}
+	if err := doJSONRequest(ctx, http.MethodPost, t.baseURL+"/rest/api/2/issue", t.headers, request, &result); err != nil {
+		return nil, err
+	}
+	return &trackedIssue{ID: result.Key, URL: t.baseURL + "/browse/" + result.Key, Labels: labels}, nil
+}
+
+func (t *jiraTracker) resolveIssue(ctx context.Context, issue trackedIssue, comment string) error {
+	issueURL := t.baseURL + "/rest/api/2/issue/" + url.PathEscape(issue.ID)
+	if err := doJSONRequest(ctx, http.MethodPost, issueURL+"/comment", t.headers, map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment: %w", err)
+	}
+
+	closeTransition := t.config.CloseTransition
+	if closeTransition == "" {
+		closeTransition = defaultJiraCloseTransition
+	}
+	var result struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"transitions"`
+	}
+	if err := doJSONRequest(ctx, http.MethodGet, issueURL+"/transitions", t.headers, nil, &result); err != nil {
+		return fmt.Errorf("failed to list transitions: %w", err)
+	}
+	var available []string
+	for _, transition := range result.Transitions {
+		if strings.EqualFold(transition.Name, closeTransition) {
+			request := map[string]interface{}{"transition": map[string]string{"id": transition.ID}}
+			return doJSONRequest(ctx, http.MethodPost, issueURL+"/transitions", t.headers, request, nil)
+		}
+		available = append(available, transition.Name)
+	}
+	return fmt.Errorf("transition %q not available (available: %s)", closeTransition, strings.Join(available, ", "))
+}
+
+// gitHubTracker manages issues through the GitHub REST API
+type gitHubTracker struct {
+	repoURL string
+	headers http.Header
+}
+
+func newGitHubTracker(config *infrav1alpha1.GitHubIssueConfig, token string) *gitHubTracker {
+	apiURL := config.APIURL
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+	headers := http.Header{}
+	headers.Set("Accept", "application/vnd.github+json")
+	headers.Set("Authorization", "Bearer "+token)
+	headers.Set("X-GitHub-Api-Version", "2022-11-28")
+	return &gitHubTracker{repoURL: strings.TrimSuffix(apiURL, "/") + "/repos/" + config.Repository, headers: headers}
+}
+
+func (t *gitHubTracker) name() string { return "github" }
+
+// gitHubIssue is the part of a GitHub issue the tracker needs
+type gitHubIssue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+func (t *gitHubTracker) openIssues(ctx context.Context, label string) ([]trackedIssue, error) {
+	query := url.Values{"state": {"open"}, "labels": {label}, "per_page": {"100"}}
+	var result []gitHubIssue
+	if err := doJSONRequest(ctx, http.MethodGet, t.repoURL+"/issues?"+query.Encode(), t.headers, nil, &result); err != nil {
+		return nil, err
+	}
+	issues := make([]trackedIssue, 0, len(result))
+	for _, issue := range result {
+		if issue.PullRequest != nil {
+			continue
+		}
+		tracked := trackedIssue{ID: strconv.Itoa(issue.Number), URL: issue.HTMLURL}
+		for _, label := range issue.Labels {
+			tracked.Labels = append(tracked.Labels, label.Name)
+		}
+		issues = append(issues, tracked)
+	}
+	return issues, nil
+}
+
+func (t *gitHubTracker) createIssue(ctx context.Context, title, body string, labels []string) (*trackedIssue, error) {
+	request := map[string]interface{}{"title": title, "body": body, "labels": labels}
+	var result gitHubIssue
+	if err := doJSONRequest(ctx, http.MethodPost, t.repoURL+"/issues", t.headers, request, &result); err != nil {
+		return nil, err
+	}
+	return &trackedIssue{ID: strconv.Itoa(result.Number), URL: result.HTMLURL, Labels: labels}, nil
+}
+
+func (t *gitHubTracker) resolveIssue(ctx context.Context, issue trackedIssue, comment string) error {
+	issueURL := t.repoURL + "/issues/" + issue.ID
+	if err := doJSONRequest(ctx, http.MethodPost, issueURL+"/comments", t.headers, map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment: %w", err)
+	}
+	request := map[string]string{"state": "closed", "state_reason": "completed"}
+	return doJSONRequest(ctx, http.MethodPatch, issueURL, t.headers, request, nil)
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultIssueAfter = 30 * time.Minute
+	// issueSyncInterval limits how often open issues are listed when nothing changed
+	issueSyncInterval = time.Minute
+	// apiRequestTimeout bounds one issue tracker or Grafana API request
+	apiRequestTimeout = 30 * time.Second
+
+	// issueLabel marks every issue opened by the operator; issues of one PodSleuth and
+	// one incident carry the labels returned by podSleuthIssueLabel and incidentIssueLabel
+	issueLabel            = "kubesleuth"
+	incidentIssuePrefix   = "kubesleuth-incident-"
+	maxIssueLabelLength   = 50
+	maxIssueBodyRootCause = 2000
+)
+
+// trackedIssue is an open issue found or created in an issue tracker
+type trackedIssue struct {
+	ID     string
+	URL    string
+	Labels []string
+}
+
+// issueTracker opens and resolves issues in Jira, GitHub, ...
+type issueTracker interface {
+	name() string
+	// openIssues lists open issues carrying a label
+	openIssues(ctx context.Context, label string) ([]trackedIssue, error)
+	createIssue(ctx context.Context, title, body string, labels []string) (*trackedIssue, error)
+	// resolveIssue comments on an issue and closes it
+	resolveIssue(ctx context.Context, issue trackedIssue, comment string) error
+}
+
+// issueGroup is a group of non-ready pods sharing one issue
+type issueGroup struct {
+	key   string
+	pods  []infrav1alpha1.NonReadyPodInfo
+	since time.Time
+}
+
+// issueSyncState tracks the issue synchronization of one PodSleuth
+type issueSyncState struct {
+	running bool
+	lastRun time.Time
+	// groups are the groups of the last sync by incident label, used to describe
+	// issues resolved after their pods are gone
+	groups map[string]*issueGroup
+}
+
+// podSleuthIssueLabel is the label of all issues opened for a PodSleuth, of this cluster
+// when a cluster name is set
+func podSleuthIssueLabel(podSleuthName string) string {
+	label := "kubesleuth-podsleuth-" + podSleuthName
+	if cluster := ClusterName(); cluster != "" {
+		label = "kubesleuth-cluster-" + cluster + "-podsleuth-" + podSleuthName
+	}
+	if len(label) > maxIssueLabelLength {
+		label = label[:maxIssueLabelLength]
+	}
+	return label
+}
+
+// incidentIssueLabel is the deduplication label of a group's issue
+func incidentIssueLabel(podSleuthName, groupKey string) string {
+	sum := sha256.Sum256([]byte(clusterQualified(podSleuthName) + "/" + groupKey))
+	return incidentIssuePrefix + hex.EncodeToString(sum[:8])
+}
+
+// groupIssuePods groups the tracked non-ready pods of a PodSleuth by incident label
+func groupIssuePods(podSleuthName string, config *infrav1alpha1.IssueTrackingConfig, current []infrav1alpha1.NonReadyPodInfo, now time.Time) map[string]*issueGroup {
+	groups := make(map[string]*issueGroup)
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) {
+			continue
+		}
+		if len(config.Severities) > 0 && !slices.Contains(config.Severities, PodSeverity(pod)) {
+			continue
+		}
+		key := notificationGroupKey(config.GroupBy, pod)
+		label := incidentIssueLabel(podSleuthName, key)
+		group, exists := groups[label]
+		if !exists {
+			group = &issueGroup{key: key, since: now}
+			groups[label] = group
+		}
+		group.pods = append(group.pods, *pod)
+		if pod.DetectedAt != nil && pod.DetectedAt.Time.Before(group.since) {
+			group.since = pod.DetectedAt.Time
+		}
+	}
+	for _, group := range groups {
+		sortPods(group.pods)
+	}
+	return groups
+}
+
+// syncIssues opens issues for groups non-ready longer than the configured duration and
+// resolves issues whose pods recovered. The tracker APIs are called in the background,
+// at most every issueSyncInterval unless pods changed. It returns when the next group
+// becomes persistent, or zero if none will.
+func (r *PodSleuthReconciler) syncIssues(podSleuth *infrav1alpha1.PodSleuth, transitions []podTransition, current []infrav1alpha1.NonReadyPodInfo) time.Time {
+	config := podSleuth.Spec.IssueTracking
+	if config == nil || (config.Jira == nil && config.GitHub == nil) {
+		return time.Time{}
+	}
+	now := time.Now()
+	after := defaultIssueAfter
+	if config.After != nil {
+		after = config.After.Duration
+	}
+	groups := groupIssuePods(podSleuth.Name, config, current, now)
+
+	r.issueSyncsMux.Lock()
+	defer r.issueSyncsMux.Unlock()
+	if r.issueSyncs == nil {
+		r.issueSyncs = make(map[string]*issueSyncState)
+	}
+	state, exists := r.issueSyncs[podSleuth.Name]
+	if !exists {
+		state = &issueSyncState{}
+		r.issueSyncs[podSleuth.Name] = state
+	}
+
+	var nextDue time.Time
+	becameDue := false
+	for _, group := range groups {
+		due := group.since.Add(after)
+		if due.After(now) {
+			if nextDue.IsZero() || due.Before(nextDue) {
+				nextDue = due
+			}
+		} else if due.After(state.lastRun) {
+			becameDue = true
+		}
+	}
+	if state.running || (len(transitions) == 0 && !becameDue && now.Sub(state.lastRun) < issueSyncInterval) {
+		return nextDue
+	}
+
+	// Describe resolved issues with the last known state of their groups
+	known := make(map[string]*issueGroup, len(groups)+len(state.groups))
+	for label, group := range state.groups {
+		known[label] = group
+	}
+	for label, group := range groups {
+		known[label] = group
+	}
+	state.running = true
+	go r.runIssueSync(config.DeepCopy(), podSleuth.Name, groups, known, after, now)
+	return nextDue
+}
+
+// runIssueSync reconciles the open issues of a PodSleuth in each tracker
+func (r *PodSleuthReconciler) runIssueSync(config *infrav1alpha1.IssueTrackingConfig, podSleuthName string, groups, known map[string]*issueGroup, after time.Duration, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+	defer cancel()
+	logger := log.Log.WithName("notifications")
+	defer func() {
+		r.issueSyncsMux.Lock()
+		defer r.issueSyncsMux.Unlock()
+		if state := r.issueSyncs[podSleuthName]; state != nil {
+			state.running = false
+			state.lastRun = now
+			state.groups = groups
+		}
+	}()
+
+	var trackers []issueTracker
+	if config.Jira != nil {
+		token, err := r.notificationSecret(ctx, &config.Jira.TokenSecretRef)
+		if err != nil {
+			logger.Info("jira issue tracking disabled", "podsleuth", podSleuthName, "error", err)
+		} else {
+			trackers = append(trackers, newJiraTracker(config.Jira, token))
+		}
+	}
+	if config.GitHub != nil {
+		token, err := r.notificationSecret(ctx, &config.GitHub.TokenSecretRef)
+		if err != nil {
+			logger.Info("github issue tracking disabled", "podsleuth", podSleuthName, "error", err)
+		} else {
+			trackers = append(trackers, newGitHubTracker(config.GitHub, token))
+		}
+	}
+
+	podSleuthLabel := podSleuthIssueLabel(podSleuthName)
+	for _, tracker := range trackers {
+		open, err := tracker.openIssues(ctx, podSleuthLabel)
+		issueOperations.WithLabelValues(tracker.name(), "list", outcomeOf(err)).Inc()
+		if err != nil {
+			logger.Info("failed to list open issues", "tracker", tracker.name(), "podsleuth", podSleuthName, "error", err)
+			continue
+		}
+		openByLabel := make(map[string]trackedIssue, len(open))
+		for _, issue := range open {
+			for _, label := range issue.Labels {
+				if strings.HasPrefix(label, incidentIssuePrefix) {
+					openByLabel[label] = issue
+				}
+			}
+		}
+
+		for label, group := range groups {
+			if _, exists := openByLabel[label]; exists || now.Sub(group.since) < after {
+				continue
+			}
+			labels := append([]string{issueLabel, podSleuthLabel, label}, trackerLabels(config, tracker)...)
+			title, body := describeIssue(podSleuthName, config.GroupBy, label, group)
+			issue, err := tracker.createIssue(ctx, title, body, labels)
+			issueOperations.WithLabelValues(tracker.name(), "create", outcomeOf(err)).Inc()
+			if err != nil {
+				logger.Info("failed to create issue", "tracker", tracker.name(), "podsleuth", podSleuthName, "group", group.key, "error", err)
+				continue
+			}
+			logger.Info("issue created", "tracker", tracker.name(), "podsleuth", podSleuthName, "group", group.key, "issue", issue.URL)
+		}
+
+		for label, issue := range openByLabel {
+			if _, active := groups[label]; active {
+				continue
+			}
+			err := tracker.resolveIssue(ctx, issue, describeResolution(known[label], now))
+			issueOperations.WithLabelValues(tracker.name(), "resolve", outcomeOf(err)).Inc()
+			if err != nil {
+				logger.Info("failed to resolve issue", "tracker", tracker.name(), "podsleuth", podSleuthName, "issue", issue.URL, "error", err)
+				continue
+			}
+			logger.Info("issue resolved", "tracker", tracker.name(), "podsleuth", podSleuthName, "issue", issue.URL)
+		}
+	}
+}
+
+// trackerLabels returns the extra labels configured for a tracker
+func trackerLabels(config *infrav1alpha1.IssueTrackingConfig, tracker issueTracker) []string {
+	switch tracker.(type) {
+	case *jiraTracker:
+		return config.Jira.Labels
+	case *gitHubTracker:
+		return config.GitHub.Labels
+	}
+	return nil
+}
+
+// describeIssue returns the title and body of a group's issue
+func describeIssue(podSleuthName, groupBy, label string, group *issueGroup) (string, string) {
+	first := &group.pods[0]
+	var subject string
+	switch {
+	case groupBy == groupByNamespace:
+		subject = "Namespace " + first.Namespace
+	case groupBy == groupByIncident:
+		subject = first.Reason
+	case groupBy != groupByNone && first.OwnerKind != "":
+		subject = fmt.Sprintf("%s %s/%s", first.OwnerKind, first.Namespace, first.OwnerName)
+	default:
+		subject = fmt.Sprintf("Pod %s/%s", first.Namespace, first.Name)
+	}
+	prefix := "[KubeSleuth]"
+	if cluster := ClusterName(); cluster != "" {
+		prefix = "[KubeSleuth " + cluster + "]"
+	}
+	title := fmt.Sprintf("%s %s: %d pod(s) not ready", prefix, subject, len(group.pods))
+	if first.Reason != "" && groupBy != groupByIncident {
+		title += " (" + first.Reason + ")"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "KubeSleuth (PodSleuth %s) found pods non-ready since %s.\n\n", clusterQualified(podSleuthName), group.since.UTC().Format(time.RFC3339))
+	for _, pod := range group.pods {
+		fmt.Fprintf(&body, "* %s/%s: %s", pod.Namespace, pod.Name, pod.Phase)
+		if pod.Reason != "" {
+			fmt.Fprintf(&body, ", %s", pod.Reason)
+		}
+		if pod.Message != "" {
+			fmt.Fprintf(&body, " - %s", pod.Message)
+		}
+		body.WriteString("\n")
+		if pod.LogAnalysis != nil && pod.LogAnalysis.RootCause != "" {
+			fmt.Fprintf(&body, "  Root cause (confidence %d%%): %s\n", pod.LogAnalysis.Confidence, truncateString(pod.LogAnalysis.RootCause, maxIssueBodyRootCause))
+		}
+	}
+	fmt.Fprintf(&body, "\nThis issue is managed by KubeSleuth and is closed automatically once the pods recover. Deduplication label: %s\n", label)
+	return title, body.String()
+}
+
+// describeResolution returns the closing comment of an issue
+func describeResolution(group *issueGroup, now time.Time) string {
+	if group == nil {
+		return "Resolved: the pods of this issue are ready again or gone."
+	}
+	comment := fmt.Sprintf("Resolved: all pods are ready again or gone after %s (non-ready since %s).",
+		now.Sub(group.since).Round(time.Second), group.since.UTC().Format(time.RFC3339))
+	for _, pod := range group.pods {
+		if pod.LogAnalysis != nil && pod.LogAnalysis.RootCause != "" {
+			comment += fmt.Sprintf("\n\nFinal root cause of %s/%s: %s", pod.Namespace, pod.Name, truncateString(pod.LogAnalysis.RootCause, maxIssueBodyRootCause))
+			break
+		}
+	}
+	return comment
+}
+
+// truncateString shortens s to at most n bytes
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+// forgetIssueSyncs drops the issue synchronization state of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetIssueSyncs(podSleuthName string) {
+	r.issueSyncsMux.Lock()
+	defer r.issueSyncsMux.Unlock()
+	delete(r.issueSyncs, podSleuthName)
+}
+
+// doJSONRequest sends a JSON request to an HTTP API such as an issue tracker and decodes
+// the response
+func doJSONRequest(ctx context.Context, method, url string, headers http.Header, in, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = headers.Clone()
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s returned status %d: %s", method, req.URL.Path, resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
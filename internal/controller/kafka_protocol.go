@@ -0,0 +1,515 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A minimal Kafka producer speaking the wire protocol directly: Metadata v1, Produce v3
+// with record batches (magic 2), and SASL PLAIN/SCRAM via SaslHandshake v1 and
+// SaslAuthenticate v0. These versions are supported by Kafka 1.0 and later.
+
+const (
+	kafkaAPIProduce          = 0
+	kafkaAPIMetadata         = 3
+	kafkaAPISaslHandshake    = 17
+	kafkaAPISaslAuthenticate = 36
+
+	kafkaClientID = "kubesleuth"
+	// kafkaMaxResponseSize guards against reading garbage from non-Kafka endpoints
+	kafkaMaxResponseSize = 64 << 20
+)
+
+// kafkaErrorNames names the error codes most likely returned when producing
+var kafkaErrorNames = map[int16]string{
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_OR_FOLLOWER",
+	7:  "REQUEST_TIMED_OUT",
+	10: "MESSAGE_TOO_LARGE",
+	29: "TOPIC_AUTHORIZATION_FAILED",
+	33: "UNSUPPORTED_SASL_MECHANISM",
+	58: "SASL_AUTHENTICATION_FAILED",
+}
+
+// kafkaError formats a Kafka error code
+func kafkaError(code int16) error {
+	if name, ok := kafkaErrorNames[code]; ok {
+		return fmt.Errorf("kafka error %d (%s)", code, name)
+	}
+	return fmt.Errorf("kafka error %d", code)
+}
+
+// kafkaRecord is a message to produce
+type kafkaRecord struct {
+	key       []byte
+	value     []byte
+	timestamp time.Time
+}
+
+// kafkaDialConfig holds what is needed to open an authenticated broker connection
+type kafkaDialConfig struct {
+	tls           *tls.Config
+	saslMechanism string
+	username      string
+	password      string
+	timeout       time.Duration
+}
+
+// kafkaConn is a connection to one broker
+type kafkaConn struct {
+	conn          net.Conn
+	reader        *bufio.Reader
+	correlationID int32
+}
+
+// dialKafka connects to a broker and authenticates if SASL is configured
+func dialKafka(addr string, config *kafkaDialConfig) (*kafkaConn, error) {
+	dialer := &net.Dialer{Timeout: config.timeout}
+	var conn net.Conn
+	var err error
+	if config.tls != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, config.tls)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(config.timeout))
+
+	c := &kafkaConn{conn: conn, reader: bufio.NewReader(conn)}
+	if config.saslMechanism != "" {
+		if err := c.authenticate(config.saslMechanism, config.username, config.password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("SASL %s authentication failed: %w", config.saslMechanism, err)
+		}
+	}
+	return c, nil
+}
+
+// Close closes the connection
+func (c *kafkaConn) Close() error {
+	return c.conn.Close()
+}
+
+// roundTrip sends a request and returns the response body after the correlation id
+func (c *kafkaConn) roundTrip(apiKey, apiVersion int16, body []byte) (*kafkaReader, error) {
+	c.correlationID++
+	var req []byte
+	req = binary.BigEndian.AppendUint32(req, 0) // size, set below
+	req = binary.BigEndian.AppendUint16(req, uint16(apiKey))
+	req = binary.BigEndian.AppendUint16(req, uint16(apiVersion))
+	req = binary.BigEndian.AppendUint32(req, uint32(c.correlationID))
+	req = appendKafkaString(req, kafkaClientID)
+	req = append(req, body...)
+	binary.BigEndian.PutUint32(req, uint32(len(req)-4))
+	if _, err := c.conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	var size int32
+	if err := binary.Read(c.reader, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size < 4 || size > kafkaMaxResponseSize {
+		return nil, fmt.Errorf("invalid response size %d", size)
+	}
+	resp := make([]byte, size)
+	if _, err := io.ReadFull(c.reader, resp); err != nil {
+		return nil, err
+	}
+	r := &kafkaReader{buf: resp}
+	if id := r.int32(); id != c.correlationID {
+		return nil, fmt.Errorf("unexpected correlation id %d", id)
+	}
+	return r, nil
+}
+
+// authenticate runs the SASL handshake and exchange
+func (c *kafkaConn) authenticate(mechanism, username, password string) error {
+	resp, err := c.roundTrip(kafkaAPISaslHandshake, 1, appendKafkaString(nil, mechanism))
+	if err != nil {
+		return err
+	}
+	if code := resp.int16(); code != 0 {
+		return kafkaError(code)
+	}
+
+	exchange := func(auth []byte) ([]byte, error) {
+		resp, err := c.roundTrip(kafkaAPISaslAuthenticate, 0, appendKafkaBytes(nil, auth))
+		if err != nil {
+			return nil, err
+		}
+		code := resp.int16()
+		message := resp.nullableString()
+		data := resp.bytes()
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		if code != 0 {
+			return nil, fmt.Errorf("%w: %s", kafkaError(code), message)
+		}
+		return data, nil
+	}
+
+	switch mechanism {
+	case "PLAIN":
+		_, err := exchange([]byte("\x00" + username + "\x00" + password))
+		return err
+	case "SCRAM-SHA-256":
+		return scramAuthenticate(sha256.New, username, password, exchange)
+	case "SCRAM-SHA-512":
+		return scramAuthenticate(sha512.New, username, password, exchange)
+	default:
+		return fmt.Errorf("unsupported mechanism")
+	}
+}
+
+// scramAuthenticate performs a SCRAM exchange (RFC 5802) without channel binding
+func scramAuthenticate(h func() hash.Hash, username, password string, exchange func([]byte) ([]byte, error)) error {
+	nonceBytes := make([]byte, 24)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return err
+	}
+	return scramExchange(h, username, password, base64.RawStdEncoding.EncodeToString(nonceBytes), exchange)
+}
+
+// scramExchange performs a SCRAM exchange with the client nonce
+func scramExchange(h func() hash.Hash, username, password, nonce string, exchange func([]byte) ([]byte, error)) error {
+	user := strings.NewReplacer("=", "=3D", ",", "=2C").Replace(username)
+	clientFirstBare := "n=" + user + ",r=" + nonce
+
+	serverFirst, err := exchange([]byte("n,," + clientFirstBare))
+	if err != nil {
+		return err
+	}
+	attrs := map[string]string{}
+	for _, part := range strings.Split(string(serverFirst), ",") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			attrs[key] = value
+		}
+	}
+	salt, err := base64.StdEncoding.DecodeString(attrs["s"])
+	if err != nil {
+		return fmt.Errorf("invalid SCRAM salt: %w", err)
+	}
+	iterations, err := strconv.Atoi(attrs["i"])
+	if err != nil || iterations <= 0 {
+		return fmt.Errorf("invalid SCRAM iteration count %q", attrs["i"])
+	}
+	if !strings.HasPrefix(attrs["r"], nonce) {
+		return errors.New("SCRAM server nonce does not extend the client nonce")
+	}
+
+	mac := func(key []byte, data string) []byte {
+		m := hmac.New(h, key)
+		m.Write([]byte(data))
+		return m.Sum(nil)
+	}
+	saltedPassword, err := pbkdf2.Key(h, password, salt, iterations, h().Size())
+	if err != nil {
+		return err
+	}
+	clientKey := mac(saltedPassword, "Client Key")
+	storedKey := h()
+	storedKey.Write(clientKey)
+	clientFinalWithoutProof := "c=biws,r=" + attrs["r"]
+	authMessage := clientFirstBare + "," + string(serverFirst) + "," + clientFinalWithoutProof
+	proof := mac(storedKey.Sum(nil), authMessage)
+	for i := range proof {
+		proof[i] ^= clientKey[i]
+	}
+
+	serverFinal, err := exchange([]byte(clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof)))
+	if err != nil {
+		return err
+	}
+	serverSignature := mac(mac(saltedPassword, "Server Key"), authMessage)
+	if string(serverFinal) != "v="+base64.StdEncoding.EncodeToString(serverSignature) {
+		return errors.New("SCRAM server signature mismatch")
+	}
+	return nil
+}
+
+// kafkaTopicMetadata is the partition leadership of a topic
+type kafkaTopicMetadata struct {
+	brokers map[int32]string
+	// leaders maps partition index to leader node id
+	leaders []int32
+}
+
+// metadata fetches the brokers and partition leaders of a topic
+func (c *kafkaConn) metadata(topic string) (*kafkaTopicMetadata, error) {
+	var body []byte
+	body = binary.BigEndian.AppendUint32(body, 1)
+	body = appendKafkaString(body, topic)
+	r, err := c.roundTrip(kafkaAPIMetadata, 1, body)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &kafkaTopicMetadata{brokers: map[int32]string{}}
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		nodeID := r.int32()
+		host := r.string()
+		port := r.int32()
+		r.nullableString() // rack
+		meta.brokers[nodeID] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	r.int32() // controller id
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		code := r.int16()
+		name := r.string()
+		r.int8() // is internal
+		partitions := make(map[int32]int32)
+		for p := r.int32(); p > 0 && r.err == nil; p-- {
+			r.int16() // partition error
+			index := r.int32()
+			partitions[index] = r.int32()
+			r.int32Array() // replicas
+			r.int32Array() // isr
+		}
+		if name != topic {
+			continue
+		}
+		if code != 0 {
+			return nil, fmt.Errorf("topic %s: %w", topic, kafkaError(code))
+		}
+		meta.leaders = make([]int32, len(partitions))
+		for index, leader := range partitions {
+			if int(index) < len(meta.leaders) {
+				meta.leaders[index] = leader
+			}
+		}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid metadata response: %w", r.err)
+	}
+	if len(meta.leaders) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", topic)
+	}
+	return meta, nil
+}
+
+// produce writes records to one partition and waits for all in-sync replicas
+func (c *kafkaConn) produce(topic string, partition int32, records []kafkaRecord, timeout time.Duration) error {
+	var body []byte
+	body = binary.BigEndian.AppendUint16(body, 0xffff) // null transactional id
+	body = binary.BigEndian.AppendUint16(body, 0xffff) // acks = -1
+	body = binary.BigEndian.AppendUint32(body, uint32(timeout.Milliseconds()))
+	body = binary.BigEndian.AppendUint32(body, 1)
+	body = appendKafkaString(body, topic)
+	body = binary.BigEndian.AppendUint32(body, 1)
+	body = binary.BigEndian.AppendUint32(body, uint32(partition))
+	body = appendKafkaBytes(body, encodeKafkaRecordBatch(records))
+
+	r, err := c.roundTrip(kafkaAPIProduce, 3, body)
+	if err != nil {
+		return err
+	}
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		r.string()
+		for p := r.int32(); p > 0 && r.err == nil; p-- {
+			r.int32() // partition
+			code := r.int16()
+			r.int64() // base offset
+			r.int64() // log append time
+			if code != 0 && r.err == nil {
+				return kafkaError(code)
+			}
+		}
+	}
+	return r.err
+}
+
+// encodeKafkaRecordBatch encodes records as an uncompressed record batch (magic 2)
+func encodeKafkaRecordBatch(records []kafkaRecord) []byte {
+	first := records[0].timestamp.UnixMilli()
+	maxTimestamp := first
+	var encoded []byte
+	for i, record := range records {
+		ts := record.timestamp.UnixMilli()
+		maxTimestamp = max(maxTimestamp, ts)
+		var rec []byte
+		rec = append(rec, 0) // attributes
+		rec = binary.AppendVarint(rec, ts-first)
+		rec = binary.AppendVarint(rec, int64(i))
+		rec = binary.AppendVarint(rec, int64(len(record.key)))
+		rec = append(rec, record.key...)
+		rec = binary.AppendVarint(rec, int64(len(record.value)))
+		rec = append(rec, record.value...)
+		rec = binary.AppendVarint(rec, 0) // headers
+		encoded = binary.AppendVarint(encoded, int64(len(rec)))
+		encoded = append(encoded, rec...)
+	}
+
+	// Fields covered by the CRC, from attributes to the end
+	var tail []byte
+	tail = binary.BigEndian.AppendUint16(tail, 0) // attributes
+	tail = binary.BigEndian.AppendUint32(tail, uint32(len(records)-1))
+	tail = binary.BigEndian.AppendUint64(tail, uint64(first))
+	tail = binary.BigEndian.AppendUint64(tail, uint64(maxTimestamp))
+	tail = binary.BigEndian.AppendUint64(tail, ^uint64(0)) // producer id -1
+	tail = binary.BigEndian.AppendUint16(tail, 0xffff)     // producer epoch -1
+	tail = binary.BigEndian.AppendUint32(tail, 0xffffffff) // base sequence -1
+	tail = binary.BigEndian.AppendUint32(tail, uint32(len(records)))
+	tail = append(tail, encoded...)
+
+	var batch []byte
+	batch = binary.BigEndian.AppendUint64(batch, 0) // base offset
+	batch = binary.BigEndian.AppendUint32(batch, uint32(4+1+4+len(tail)))
+	batch = binary.BigEndian.AppendUint32(batch, 0xffffffff) // partition leader epoch
+	batch = append(batch, 2)                                 // magic
+	batch = binary.BigEndian.AppendUint32(batch, crc32.Checksum(tail, crc32.MakeTable(crc32.Castagnoli)))
+	return append(batch, tail...)
+}
+
+// kafkaPartition picks the partition of a key like the Java client's default
+// partitioner (murmur2), so other producers keyed the same way agree
+func kafkaPartition(key []byte, partitions int) int32 {
+	const m, r = 0x5bd1e995, 24
+	length := len(key)
+	h := uint32(0x9747b28c) ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(key[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+	tail := key[length&^3:]
+	switch len(tail) {
+	case 3:
+		h ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(tail[0])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32((h & 0x7fffffff) % uint32(partitions))
+}
+
+// appendKafkaString appends a length-prefixed string
+func appendKafkaString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// appendKafkaBytes appends length-prefixed bytes
+func appendKafkaBytes(b, data []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+// kafkaReader decodes a response, remembering the first error
+type kafkaReader struct {
+	buf []byte
+	err error
+}
+
+func (r *kafkaReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.buf) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	data := r.buf[:n]
+	r.buf = r.buf[n:]
+	return data
+}
+
+func (r *kafkaReader) int8() int8 {
+	if b := r.take(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (r *kafkaReader) int16() int16 {
+	if b := r.take(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int32() int32 {
+	if b := r.take(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int64() int64 {
+	if b := r.take(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (r *kafkaReader) string() string {
+	return string(r.take(int(r.int16())))
+}
+
+func (r *kafkaReader) nullableString() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.take(int(n)))
+}
+
+func (r *kafkaReader) bytes() []byte {
+	n := r.int32()
+	if n < 0 {
+		return nil
+	}
+	return r.take(int(n))
+}
+
+func (r *kafkaReader) int32Array() {
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		r.int32()
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEncodeKafkaRecordBatch(t *testing.T) {
+	first := time.UnixMilli(1700000000000)
+	records := []kafkaRecord{
+		{key: []byte("k"), value: []byte("v"), timestamp: first},
+		{key: []byte("key2"), value: []byte("value-2"), timestamp: first.Add(5 * time.Millisecond)},
+	}
+	want := slices.Concat(
+		[]byte{0, 0, 0, 0, 0, 0, 0, 0},                         // base offset
+		[]byte{0, 0, 0, 0x4c},                                  // batch length: 76 bytes from the leader epoch
+		[]byte{0xff, 0xff, 0xff, 0xff},                         // partition leader epoch
+		[]byte{2},                                              // magic
+		[]byte{0xf4, 0x9e, 0xd8, 0x5b},                         // CRC-32C of the attributes to the end
+		[]byte{0, 0},                                           // attributes: no compression
+		[]byte{0, 0, 0, 1},                                     // last offset delta
+		[]byte{0, 0, 0x01, 0x8b, 0xcf, 0xe5, 0x68, 0x00},       // first timestamp
+		[]byte{0, 0, 0x01, 0x8b, 0xcf, 0xe5, 0x68, 0x05},       // max timestamp
+		[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // producer id -1
+		[]byte{0xff, 0xff},                                     // producer epoch -1
+		[]byte{0xff, 0xff, 0xff, 0xff},                         // base sequence -1
+		[]byte{0, 0, 0, 2},                                     // records
+		// length 8, attributes, timestamp delta 0, offset delta 0, key, value, no headers
+		[]byte{0x10, 0, 0, 0, 0x02, 'k', 0x02, 'v', 0},
+		// length 17, attributes, timestamp delta 5, offset delta 1, key, value, no headers
+		[]byte{0x22, 0, 0x0a, 0x02, 0x08, 'k', 'e', 'y', '2', 0x0e, 'v', 'a', 'l', 'u', 'e', '-', '2', 0},
+	)
+
+	if got := encodeKafkaRecordBatch(records); !bytes.Equal(got, want) {
+		t.Errorf("encodeKafkaRecordBatch()\n got % x\nwant % x", got, want)
+	}
+}
+
+// TestKafkaPartition checks keys against the murmur2 hashes of the Java client's
+// Utils.murmur2, whose positive value modulo the partitions is the partition
+func TestKafkaPartition(t *testing.T) {
+	tests := []struct {
+		key  string
+		hash int32
+	}{
+		{"21", -973932308},
+		{"foobar", -790332482},
+		{"a-little-bit-long-string", -985981536},
+		{"a-little-bit-longer-string", -1486304829},
+		{"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", -58897971},
+		{"abc", 479470107},
+	}
+	for _, tt := range tests {
+		positive := uint32(tt.hash) & 0x7fffffff
+		for _, partitions := range []int{1, 3, 12, 1 << 31} {
+			want := int32(positive % uint32(partitions))
+			if got := kafkaPartition([]byte(tt.key), partitions); got != want {
+				t.Errorf("kafkaPartition(%q, %d) = %d, want %d", tt.key, partitions, got, want)
+			}
+		}
+	}
+}
+
+// TestScramExchange runs the SCRAM-SHA-256 exchange of RFC 7677, section 3
+func TestScramExchange(t *testing.T) {
+	const (
+		clientNonce = "rOprNGfwEbeRWgbNEkqO"
+		clientFirst = "n,,n=user,r=rOprNGfwEbeRWgbNEkqO"
+		serverFirst = "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"
+		clientFinal = "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ="
+		serverFinal = "v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="
+	)
+	server := func(first, final string) func([]byte) ([]byte, error) {
+		step := 0
+		return func(message []byte) ([]byte, error) {
+			step++
+			switch step {
+			case 1:
+				if string(message) != clientFirst {
+					return nil, fmt.Errorf("client-first-message %q, want %q", message, clientFirst)
+				}
+				return []byte(first), nil
+			case 2:
+				if string(message) != clientFinal {
+					return nil, fmt.Errorf("client-final-message %q, want %q", message, clientFinal)
+				}
+				return []byte(final), nil
+			}
+			return nil, errors.New("unexpected message")
+		}
+	}
+
+	if err := scramExchange(sha256.New, "user", "pencil", clientNonce, server(serverFirst, serverFinal)); err != nil {
+		t.Fatalf("RFC 7677 exchange: %v", err)
+	}
+	if err := scramExchange(sha256.New, "user", "pencil", clientNonce, server(serverFirst, "v=AAAA")); err == nil {
+		t.Error("a wrong server signature is accepted")
+	}
+	if err := scramExchange(sha256.New, "user", "wrong", clientNonce, server(serverFirst, serverFinal)); err == nil {
+		t.Error("a wrong password is accepted")
+	}
+	if err := scramExchange(sha256.New, "user", "pencil", clientNonce, server("r=another,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096", serverFinal)); err == nil {
+		t.Error("a server nonce not extending the client nonce is accepted")
+	}
+}
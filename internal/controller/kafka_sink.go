@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultKafkaTimeout = 10 * time.Second
+	kafkaEncodingAvro   = "Avro"
+)
+
+// sleuthEventAvroSchema is the Avro schema of sleuthEvent; details holds the pod as JSON
+const sleuthEventAvroSchema = `{"type":"record","name":"SleuthEvent","namespace":"dev.ops.kubesleuth","fields":[` +
+	`{"name":"type","type":"string"},{"name":"podSleuth","type":"string"},` +
+	`{"name":"namespace","type":"string"},{"name":"pod","type":"string"},` +
+	`{"name":"ownerKind","type":"string"},{"name":"ownerName","type":"string"},` +
+	`{"name":"team","type":"string"},{"name":"reason","type":"string"},` +
+	`{"name":"message","type":"string"},{"name":"severity","type":"string"},` +
+	`{"name":"rootCause","type":"string"},{"name":"confidence","type":"int"},` +
+	`{"name":"downtimeSeconds","type":"long"},` +
+	`{"name":"timestamp","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+	`{"name":"details","type":"string"}]}`
+
+// schemaRegistryClient registers Avro schemas
+var schemaRegistryClient = &http.Client{Transport: outboundRoundTripper{}, Timeout: 30 * time.Second}
+
+// avroSchemaIDs caches registered schema ids by registry URL and subject
+var avroSchemaIDs sync.Map
+
+// publishKafka produces events to a Kafka sink, keyed by namespace/pod
+func (r *PodSleuthReconciler) publishKafka(ctx context.Context, sink *infrav1alpha1.KafkaSink, events []sleuthEvent) error {
+	dialConfig := &kafkaDialConfig{timeout: defaultKafkaTimeout}
+	if sink.Timeout != nil && sink.Timeout.Duration > 0 {
+		dialConfig.timeout = sink.Timeout.Duration
+	}
+	if sink.TLS != nil {
+		var err error
+		if dialConfig.tls, err = r.streamTLSConfig(ctx, sink.TLS); err != nil {
+			return err
+		}
+	}
+	if sink.SASL != nil {
+		password, err := r.notificationSecret(ctx, &sink.SASL.PasswordSecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get SASL password: %w", err)
+		}
+		dialConfig.saslMechanism = sink.SASL.Mechanism
+		if dialConfig.saslMechanism == "" {
+			dialConfig.saslMechanism = "PLAIN"
+		}
+		dialConfig.username = sink.SASL.Username
+		dialConfig.password = password
+	}
+
+	var schemaID int32
+	if sink.Encoding == kafkaEncodingAvro {
+		if sink.SchemaRegistryURL == "" {
+			return fmt.Errorf("schemaRegistryURL is required for Avro encoding")
+		}
+		var err error
+		if schemaID, err = registerAvroSchema(ctx, sink.SchemaRegistryURL, sink.Topic+"-value"); err != nil {
+			return err
+		}
+	}
+
+	records := make([]kafkaRecord, 0, len(events))
+	for _, event := range events {
+		var value []byte
+		var err error
+		if sink.Encoding == kafkaEncodingAvro {
+			value, err = encodeAvroEvent(schemaID, event)
+		} else {
+			value, err = json.Marshal(event)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		records = append(records, kafkaRecord{
+			key:       []byte(event.Namespace + "/" + event.Pod),
+			value:     value,
+			timestamp: event.Timestamp,
+		})
+	}
+
+	// Bootstrap: the first reachable broker provides the partition leaders
+	var bootstrap *kafkaConn
+	var bootstrapAddr string
+	var meta *kafkaTopicMetadata
+	var lastErr error
+	for _, addr := range sink.Brokers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		conn, err := dialKafka(addr, dialConfig)
+		if err == nil {
+			if meta, err = conn.metadata(sink.Topic); err == nil {
+				bootstrap, bootstrapAddr = conn, addr
+				break
+			}
+			conn.Close()
+		}
+		lastErr = fmt.Errorf("broker %s: %w", addr, err)
+	}
+	if bootstrap == nil {
+		return lastErr
+	}
+	conns := map[string]*kafkaConn{bootstrapAddr: bootstrap}
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	byPartition := make(map[int32][]kafkaRecord)
+	for _, record := range records {
+		partition := kafkaPartition(record.key, len(meta.leaders))
+		byPartition[partition] = append(byPartition[partition], record)
+	}
+	for partition, batch := range byPartition {
+		addr, ok := meta.brokers[meta.leaders[partition]]
+		if !ok {
+			return fmt.Errorf("partition %d of %s has no leader", partition, sink.Topic)
+		}
+		conn := conns[addr]
+		if conn == nil {
+			var err error
+			if conn, err = dialKafka(addr, dialConfig); err != nil {
+				return fmt.Errorf("leader %s: %w", addr, err)
+			}
+			conns[addr] = conn
+		}
+		if err := conn.produce(sink.Topic, partition, batch, dialConfig.timeout); err != nil {
+			return fmt.Errorf("produce to %s/%d: %w", sink.Topic, partition, err)
+		}
+	}
+	return nil
+}
+
+// registerAvroSchema registers the event schema under a subject and returns its id
+func registerAvroSchema(ctx context.Context, registryURL, subject string) (int32, error) {
+	cacheKey := registryURL + "|" + subject
+	if id, ok := avroSchemaIDs.Load(cacheKey); ok {
+		return id.(int32), nil
+	}
+
+	body, err := json.Marshal(map[string]string{"schema": sleuthEventAvroSchema})
+	if err != nil {
+		return 0, err
+	}
+	url := strings.TrimSuffix(registryURL, "/") + "/subjects/" + subject + "/versions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	resp, err := schemaRegistryClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("schema registry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("schema registry returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	var result struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("invalid schema registry response: %w", err)
+	}
+	avroSchemaIDs.Store(cacheKey, result.ID)
+	return result.ID, nil
+}
+
+// encodeAvroEvent encodes an event in the Confluent wire format: a zero magic byte,
+// the schema id and the Avro binary encoding of the record
+func encodeAvroEvent(schemaID int32, event sleuthEvent) ([]byte, error) {
+	details, err := json.Marshal(event.Details)
+	if err != nil {
+		return nil, err
+	}
+	buf := []byte{0}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(schemaID))
+	for _, s := range []string{event.Type, event.PodSleuth, event.Namespace, event.Pod, event.OwnerKind,
+		event.OwnerName, event.Team, event.Reason, event.Message, event.Severity, event.RootCause} {
+		buf = appendAvroString(buf, s)
+	}
+	buf = binary.AppendVarint(buf, int64(event.Confidence))
+	buf = binary.AppendVarint(buf, event.DowntimeSeconds)
+	buf = binary.AppendVarint(buf, event.Timestamp.UnixMilli())
+	return appendAvroString(buf, string(details)), nil
+}
+
+// appendAvroString appends an Avro string: zigzag length and UTF-8 bytes
+func appendAvroString(b []byte, s string) []byte {
+	b = binary.AppendVarint(b, int64(len(s)))
+	return append(b, s...)
+}
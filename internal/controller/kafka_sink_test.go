@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// TestEncodeAvroEvent decodes an encoded event with the registered schema, so the
+// encoding and the schema cannot drift apart
+func TestEncodeAvroEvent(t *testing.T) {
+	event := sleuthEvent{
+		Type:            "resolved",
+		PodSleuth:       "production",
+		Namespace:       "shop",
+		Pod:             "cart-7d9f-x2k4",
+		OwnerKind:       "Deployment",
+		OwnerName:       "cart",
+		Team:            "checkout",
+		Reason:          "CrashLoopBackOff",
+		Message:         "back-off 5m0s restarting failed container",
+		Severity:        "critical",
+		RootCause:       "Database connection refused",
+		Confidence:      85,
+		DowntimeSeconds: 754,
+		Timestamp:       time.UnixMilli(1700000000123),
+		Details:         infrav1alpha1.NonReadyPodInfo{Name: "cart-7d9f-x2k4", Namespace: "shop"},
+	}
+	encoded, err := encodeAvroEvent(42, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Confluent wire format: magic byte 0 and the schema id
+	if header := []byte{0, 0, 0, 0, 42}; !bytes.HasPrefix(encoded, header) {
+		t.Fatalf("header % x, want % x", encoded[:min(len(encoded), 5)], header)
+	}
+
+	var schema struct {
+		Fields []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(sleuthEventAvroSchema), &schema); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	data := encoded[5:]
+	got := map[string]interface{}{}
+	for _, field := range schema.Fields {
+		value, n := binary.Varint(data)
+		if n <= 0 {
+			t.Fatalf("field %s: invalid varint", field.Name)
+		}
+		data = data[n:]
+		if string(field.Type) != `"string"` {
+			got[field.Name] = value
+			continue
+		}
+		if value < 0 || int(value) > len(data) {
+			t.Fatalf("field %s: invalid string length %d", field.Name, value)
+		}
+		got[field.Name], data = string(data[:value]), data[value:]
+	}
+	if len(data) != 0 {
+		t.Errorf("%d bytes after the last field", len(data))
+	}
+
+	details, _ := json.Marshal(event.Details)
+	want := map[string]interface{}{
+		"type":            "resolved",
+		"podSleuth":       "production",
+		"namespace":       "shop",
+		"pod":             "cart-7d9f-x2k4",
+		"ownerKind":       "Deployment",
+		"ownerName":       "cart",
+		"team":            "checkout",
+		"reason":          "CrashLoopBackOff",
+		"message":         "back-off 5m0s restarting failed container",
+		"severity":        "critical",
+		"rootCause":       "Database connection refused",
+		"confidence":      int64(85),
+		"downtimeSeconds": int64(754),
+		"timestamp":       int64(1700000000123),
+		"details":         string(details),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded event\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestAppendAvroString(t *testing.T) {
+	// Lengths are zigzag varints: 3 is 0x06, 64 is 0x80 0x01
+	if got, want := appendAvroString(nil, "foo"), []byte{0x06, 'f', 'o', 'o'}; !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+	long := string(bytes.Repeat([]byte("a"), 64))
+	if got := appendAvroString(nil, long); !bytes.Equal(got[:2], []byte{0x80, 0x01}) || len(got) != 66 {
+		t.Errorf("64 byte string encoded as % x...", got[:2])
+	}
+	if got, want := appendAvroString(nil, ""), []byte{0x00}; !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+}
@@ -21,10 +21,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -39,6 +42,13 @@ import (
 	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
 )
 
+const (
+	// defaultMaxLogBytes bounds the log output fetched per analysis (1MiB)
+	defaultMaxLogBytes = 1 << 20
+	// defaultMaxLineLength bounds the length of a log line
+	defaultMaxLineLength = 4096
+)
+
 // DefaultPattern defines a built-in error pattern
 type DefaultPattern struct {
 	Name      string
@@ -47,8 +57,8 @@ type DefaultPattern struct {
 	Priority  int32
 }
 
-// getDefaultPatterns returns built-in error patterns
-func getDefaultPatterns() []DefaultPattern {
+// builtinPatterns returns built-in error patterns
+func builtinPatterns() []DefaultPattern {
 	patterns := []DefaultPattern{
 		{
 			Name:      "ConnectionRefused",
@@ -115,11 +125,68 @@ func getDefaultPatterns() []DefaultPattern {
 }
 
 // analyzeLogs performs log analysis using the configured method(s)
-func analyzeLogs(ctx context.Context, client client.Client, k8sClient kubernetes.Interface, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig) (*infrav1alpha1.LogAnalysisResult, error) {
+func analyzeLogs(ctx context.Context, client client.Client, k8sClient kubernetes.Interface, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, aiOpts *aiRequestOptions) (*infrav1alpha1.LogAnalysisResult, error) {
 	if config == nil || !config.Enabled {
 		return nil, nil
 	}
 
+	// While an init container blocks the pod's startup, the regular containers have not
+	// started, so the logs of the init container are analyzed instead
+	step := blockingInitContainer(pod)
+
+	// Get log lines once (shared by all methods)
+	var logLines []string
+	if step == nil || step.hasLogs() {
+		var err error
+		logLines, err = getPodLogs(ctx, k8sClient, pod, config, step)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod logs: %w", err)
+		}
+	}
+
+	if len(logLines) == 0 && step == nil {
+		// Metrics do not depend on the logs, so a pod that logged nothing is still checked
+		result := analyzeMetricsOnly(ctx, client, pod, config)
+		if result != nil {
+			result.AnalyzedAt = metav1.Now()
+		}
+		return result, nil
+	}
+
+	// Mask sensitive data before it reaches AI endpoints or the status
+	redactor, err := newRedactor(config.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure redaction: %w", err)
+	}
+	logLines = redactor.redactLines(logLines)
+
+	var result *infrav1alpha1.LogAnalysisResult
+	if step == nil {
+		result = analyzeLogLines(ctx, client, pod, config, logLines, aiOpts)
+	} else {
+		// Wait loops log progress rather than errors, so the init container's lines are
+		// only filtered once they were checked for one
+		initResult := analyzeInitContainer(step, logLines, time.Now())
+		if filterErrorsOnly(config) {
+			logLines = slices.DeleteFunc(logLines, func(line string) bool { return !isErrorLine(line) })
+		}
+		if len(logLines) > 0 {
+			result = analyzeLogLines(ctx, client, pod, config, logLines, aiOpts)
+		}
+		result = mergeInitContainerResult(result, initResult)
+	}
+
+	// Missing configuration is checked against the references of the analyzed container
+	containerName, _ := logContainer(pod, step)
+	result = mergeConfigResult(result, analyzeConfigReferences(ctx, k8sClient, pod, containerName, logLines, config.ConfigCheck))
+	if result != nil && result.AnalyzedAt.IsZero() {
+		result.AnalyzedAt = metav1.Now()
+	}
+	return result, nil
+}
+
+// analysisMethods returns the configured analysis methods in order
+func analysisMethods(config *infrav1alpha1.LogAnalysisConfig) []string {
 	// Determine methods to use (with backward compatibility)
 	var methods []string
 
@@ -138,23 +205,39 @@ func analyzeLogs(ctx context.Context, client client.Client, k8sClient kubernetes
 		// Default to pattern method
 		methods = []string{"pattern"}
 	}
+	return methods
+}
 
-	// Get log lines once (shared by all methods)
-	logLines, err := getPodLogs(ctx, k8sClient, pod, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get pod logs: %w", err)
+// analyzeMetricsOnly runs the metrics method(s) of a pod without log lines to analyze,
+// and returns nil if none is configured
+func analyzeMetricsOnly(ctx context.Context, client client.Client, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig) *infrav1alpha1.LogAnalysisResult {
+	methods := analysisMethods(config)
+	var metricsResult *infrav1alpha1.MetricsAnalysisResult
+	for i, method := range methods {
+		if method != "metrics" {
+			continue
+		}
+		var metricsConfig *infrav1alpha1.MetricsConfig
+		if len(config.MethodConfigs) > i {
+			metricsConfig = config.MethodConfigs[i].MetricsConfig
+		}
+		metricsResult = analyzeWithMetrics(ctx, client, pod, metricsConfig)
 	}
+	return mergeMetricsResult(nil, metricsResult, methods)
+}
 
-	if len(logLines) == 0 {
-		return nil, nil
-	}
+// analyzeLogLines runs the configured method(s) on the redacted log lines of a pod
+func analyzeLogLines(ctx context.Context, client client.Client, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, logLines []string, aiOpts *aiRequestOptions) *infrav1alpha1.LogAnalysisResult {
+	methods := analysisMethods(config)
 
 	logger := log.Log.WithName("log-analysis")
 	logger.Info("starting multi-method log analysis", "pod", pod.Name, "namespace", pod.Namespace, "methods", methods, "logLines", len(logLines))
 
 	var patternResult *infrav1alpha1.PatternAnalysisResult
 	var aiResult *infrav1alpha1.AIAnalysisResult
+	var metricsResult *infrav1alpha1.MetricsAnalysisResult
 	var errorLines []string
+	confidence := getConfidenceSettings(config.Confidence)
 
 	// Run each method in order
 	for i, method := range methods {
@@ -177,7 +260,7 @@ func analyzeLogs(ctx context.Context, client client.Client, k8sClient kubernetes
 				patterns = config.Patterns
 			}
 
-			result, err := analyzeWithPatterns(logLines, patterns)
+			result, err := analyzeWithPatterns(logLines, patterns, confidence)
 			if err != nil {
 				logger.Error(err, "pattern analysis failed")
 				// Store error in result for UI display
@@ -199,45 +282,64 @@ func analyzeLogs(ctx context.Context, client client.Client, k8sClient kubernetes
 		case "ai":
 			// Get AI-specific config (new structure or fallback to deprecated)
 			var aiConfig *infrav1alpha1.AIConfig
+			var aiFallbacks []infrav1alpha1.AIConfig
 			if methodConfig != nil && methodConfig.AIConfig != nil {
 				aiConfig = methodConfig.AIConfig
+				aiFallbacks = methodConfig.AIFallbacks
 			}
 
-			result, err := analyzeWithAI(ctx, client, logLines, pod, config, aiConfig)
-			if err != nil {
-				logger.Error(err, "AI analysis failed")
-				// Store error in result for UI display
-				aiResult = &infrav1alpha1.AIAnalysisResult{
-					Error: fmt.Sprintf("AI analysis failed: %v", err),
+			// Pods failing identically share a single AI request within a reconcile
+			batchEnabled := config.BatchAIRequests == nil || *config.BatchAIRequests
+			var signature string
+			if batchEnabled && aiOpts != nil && aiOpts.Batch != nil {
+				candidate := fmt.Sprintf("%d/%s", i, logSignature(logLines[:min(20, len(logLines))]))
+				shared, lead := aiOpts.Batch.get(ctx, candidate)
+				if shared != nil {
+					logger.Info("reusing AI result from identically failing pod", "pod", pod.Name, "sharedFrom", shared.SharedFrom, "groupSize", shared.GroupSize)
+					aiResult = shared
+					errorLines = append(errorLines, logLines[:min(20, len(logLines))]...)
+					continue
 				}
-			} else if result != nil {
-				aiResult = &infrav1alpha1.AIAnalysisResult{
-					Model:      result.Model,
-					RootCause:  result.RootCause,
-					Confidence: result.Confidence,
+				if lead {
+					signature = candidate
 				}
-				// Collect error lines
-				errorLines = append(errorLines, result.ErrorLines...)
-				logger.Info("AI analysis completed", "model", result.Model, "confidence", result.Confidence)
 			}
 
+			aiResult = analyzeWithAIProviders(ctx, client, logLines, pod, config, aiConfig, aiFallbacks, aiOpts)
+			if signature != "" {
+				aiOpts.Batch.put(signature, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name), aiResult)
+			}
+			if aiResult != nil && aiResult.Error == "" {
+				// AI analysis is based on the first lines sent to the provider
+				errorLines = append(errorLines, logLines[:min(20, len(logLines))]...)
+			}
+
+		case "metrics":
+			var metricsConfig *infrav1alpha1.MetricsConfig
+			if methodConfig != nil {
+				metricsConfig = methodConfig.MetricsConfig
+			}
+			metricsResult = analyzeWithMetrics(ctx, client, pod, metricsConfig)
+
 		default:
 			logger.Info("unknown analysis method, skipping", "method", method)
 		}
 	}
 
 	// Merge results from all methods
-	finalResult := mergeAnalysisResults(patternResult, aiResult, methods, errorLines)
+	finalResult := mergeAnalysisResults(patternResult, aiResult, methods, errorLines, confidence)
+	finalResult = mergeMetricsResult(finalResult, metricsResult, methods)
+	finalResult = mergeCertificateResult(finalResult, analyzeCertificates(ctx, client, pod, logLines, config.CertificateCheck), methods)
 	if finalResult != nil {
 		finalResult.AnalyzedAt = metav1.Now()
 		logger.Info("multi-method analysis completed", "methods", finalResult.Methods, "rootCause", finalResult.RootCause, "confidence", finalResult.Confidence)
 	}
 
-	return finalResult, nil
+	return finalResult
 }
 
 // mergeAnalysisResults combines results from multiple analysis methods
-func mergeAnalysisResults(patternResult *infrav1alpha1.PatternAnalysisResult, aiResult *infrav1alpha1.AIAnalysisResult, methods []string, errorLines []string) *infrav1alpha1.LogAnalysisResult {
+func mergeAnalysisResults(patternResult *infrav1alpha1.PatternAnalysisResult, aiResult *infrav1alpha1.AIAnalysisResult, methods []string, errorLines []string, confidence confidenceSettings) *infrav1alpha1.LogAnalysisResult {
 	result := &infrav1alpha1.LogAnalysisResult{
 		Methods:       methods,
 		PatternResult: patternResult,
@@ -248,22 +350,46 @@ func mergeAnalysisResults(patternResult *infrav1alpha1.PatternAnalysisResult, ai
 	// Determine primary root cause and confidence based on available results
 	if aiResult != nil && patternResult != nil {
 		// Both methods ran
-		if aiResult.Confidence > 80 {
-			// High AI confidence - use AI as primary
+		useAI := func() {
 			result.RootCause = aiResult.RootCause
 			result.Confidence = aiResult.Confidence
 			result.Method = "ai" // For backward compatibility
-		} else if aiResult.Confidence < 50 {
-			// Low AI confidence - use pattern as primary
+		}
+		usePattern := func() {
 			result.RootCause = patternResult.RootCause
 			result.Confidence = patternResult.Confidence
 			result.Method = "pattern" // For backward compatibility
-		} else {
-			// Medium AI confidence - combine both
+		}
+		combine := func() {
 			result.RootCause = fmt.Sprintf("[Pattern] %s | [AI] %s", patternResult.RootCause, aiResult.RootCause)
-			result.Confidence = (patternResult.Confidence + aiResult.Confidence) / 2
+			result.Confidence = confidence.weightedAverage(patternResult.Confidence, aiResult.Confidence)
 			result.Method = "pattern+ai" // For backward compatibility
 		}
+
+		switch {
+		case aiResult.Error != "" && patternResult.Error == "":
+			// A failed AI call has nothing to contribute
+			usePattern()
+		case patternResult.Error != "" && aiResult.Error == "":
+			useAI()
+		case confidence.MergeStrategy == mergeStrategyAverage:
+			combine()
+		case confidence.MergeStrategy == mergeStrategyHighest:
+			if aiResult.Confidence*confidence.AIWeight >= patternResult.Confidence*confidence.PatternWeight {
+				useAI()
+			} else {
+				usePattern()
+			}
+		case aiResult.Confidence > confidence.AIPreferredAbove:
+			// High AI confidence - use AI as primary
+			useAI()
+		case aiResult.Confidence < confidence.PatternPreferredBelow:
+			// Low AI confidence - use pattern as primary
+			usePattern()
+		default:
+			// Medium AI confidence - combine both
+			combine()
+		}
 	} else if aiResult != nil {
 		// Only AI ran
 		result.RootCause = aiResult.RootCause
@@ -282,6 +408,32 @@ func mergeAnalysisResults(patternResult *infrav1alpha1.PatternAnalysisResult, ai
 	return result
 }
 
+// mergeMetricsResult folds metrics findings into the log-based result.
+// Metrics corroborate log findings, so they are appended to the root cause rather than replacing it.
+func mergeMetricsResult(result *infrav1alpha1.LogAnalysisResult, metricsResult *infrav1alpha1.MetricsAnalysisResult, methods []string) *infrav1alpha1.LogAnalysisResult {
+	if metricsResult == nil {
+		return result
+	}
+
+	if result == nil {
+		result = &infrav1alpha1.LogAnalysisResult{
+			Methods:    methods,
+			RootCause:  metricsResult.RootCause,
+			Confidence: metricsResult.Confidence,
+			Method:     "metrics", // For backward compatibility
+		}
+	} else if metricsResult.RootCause != "" {
+		result.RootCause = fmt.Sprintf("%s | [Metrics] %s", result.RootCause, metricsResult.RootCause)
+		// Corroborating metrics with a higher confidence raise the overall confidence
+		if metricsResult.Confidence > result.Confidence {
+			result.Confidence = (result.Confidence + metricsResult.Confidence) / 2
+		}
+	}
+
+	result.MetricsResult = metricsResult
+	return result
+}
+
 // deduplicateLines removes duplicate lines from a slice
 func deduplicateLines(lines []string) []string {
 	seen := make(map[string]bool)
@@ -295,21 +447,21 @@ func deduplicateLines(lines []string) []string {
 	return result
 }
 
-// getPodLogs retrieves logs from a pod container
-func getPodLogs(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig) ([]string, error) {
-	// Determine which container to analyze
-	// Priority: 1) First non-ready container, 2) Container with errors (waiting/terminated), 3) First container
+// logContainer returns the container whose logs are analyzed, and whether it was chosen
+// for its error state. Priority: 1) the blocking init container, 2) the first non-ready
+// container with errors (waiting/terminated), 3) the first non-ready container, 4) the
+// first container.
+func logContainer(pod *corev1.Pod, step *initStep) (string, bool) {
+	if step != nil {
+		return step.container.Name, true
+	}
 	containerName := ""
-	var containerWithError string
-
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		// Check if container is not ready
 		if !containerStatus.Ready {
 			// Prefer containers with actual errors (waiting or terminated states)
 			if containerStatus.State.Waiting != nil || containerStatus.State.Terminated != nil {
-				if containerWithError == "" {
-					containerWithError = containerStatus.Name
-				}
+				return containerStatus.Name, true
 			}
 			// Use first non-ready container if we haven't found one yet
 			if containerName == "" {
@@ -318,25 +470,28 @@ func getPodLogs(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1
 		}
 	}
 
-	// Prefer container with errors over just non-ready
-	if containerWithError != "" {
-		containerName = containerWithError
-	}
-
 	// Fallback to first container if all are ready (shouldn't happen for non-ready pods, but just in case)
 	if containerName == "" && len(pod.Spec.Containers) > 0 {
 		containerName = pod.Spec.Containers[0].Name
 	}
+	return containerName, false
+}
 
+// getPodLogs retrieves logs from a pod container, or every line of the blocking init
+// container if step is set
+func getPodLogs(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, step *initStep) ([]string, error) {
+	containerName, withError := logContainer(pod, step)
 	if containerName == "" {
 		return nil, fmt.Errorf("no container found to analyze for pod %s/%s", pod.Namespace, pod.Name)
 	}
 
 	logger := log.Log.WithName("log-analysis")
 	logger.Info("analyzing logs", "pod", pod.Name, "namespace", pod.Namespace, "container", containerName)
-	if containerWithError != "" {
+	if step != nil {
+		logger.V(1).Info("selected blocking init container", "container", containerName, "step", step.index+1, "steps", step.steps)
+	} else if withError {
 		logger.V(1).Info("selected container with error state", "container", containerName)
-	} else if containerName != "" {
+	} else {
 		logger.V(1).Info("selected non-ready container", "container", containerName)
 	}
 
@@ -346,10 +501,26 @@ func getPodLogs(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1
 		linesToAnalyze = int64(*config.LinesToAnalyze)
 	}
 
+	maxLogBytes := int64(defaultMaxLogBytes)
+	if config.MaxLogBytes != nil {
+		maxLogBytes = *config.MaxLogBytes
+	}
+	maxLineLength := defaultMaxLineLength
+	if config.MaxLineLength != nil {
+		maxLineLength = int(*config.MaxLineLength)
+	}
+
+	// Filter for errors if configured, except for init containers checked for wait loops
+	var keep func(string) bool
+	if filterErrorsOnly(config) && step == nil {
+		keep = isErrorLine
+	}
+
 	// Get logs from Kubernetes API
 	req := k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
-		Container: containerName,
-		TailLines: &linesToAnalyze,
+		Container:  containerName,
+		TailLines:  &linesToAnalyze,
+		LimitBytes: &maxLogBytes,
 	})
 
 	logStream, err := req.Stream(ctx)
@@ -358,56 +529,132 @@ func getPodLogs(ctx context.Context, k8sClient kubernetes.Interface, pod *corev1
 	}
 	defer logStream.Close()
 
-	// Read all lines
-	var allLines []string
-	scanner := bufio.NewScanner(logStream)
-	for scanner.Scan() {
-		allLines = append(allLines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil && err != io.EOF {
+	// Lines are filtered as they are read, so only the kept lines are held in memory
+	lines, read, truncated, err := readLogLines(logStream, maxLineLength, keep)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read log stream: %w", err)
 	}
 
-	logger.Info("retrieved log lines", "totalLines", len(allLines))
+	logger.Info("retrieved log lines", "totalLines", read, "keptLines", len(lines), "truncatedLines", truncated, "filterErrorsOnly", keep != nil)
+	return lines, nil
+}
 
-	// Filter for errors if configured (default true)
-	filterErrorsOnly := true
-	if config.FilterErrorsOnly != nil {
-		filterErrorsOnly = *config.FilterErrorsOnly
-	}
+// filterErrorsOnly reports whether only error lines are analyzed (default true)
+func filterErrorsOnly(config *infrav1alpha1.LogAnalysisConfig) bool {
+	return config.FilterErrorsOnly == nil || *config.FilterErrorsOnly
+}
 
-	if filterErrorsOnly {
-		filteredLines := filterErrorLines(allLines)
-		logger.Info("filtered error lines", "originalLines", len(allLines), "errorLines", len(filteredLines))
-		return filteredLines, nil
+// ContainerLog is the tail of a container's log as the dashboard shows it
+type ContainerLog struct {
+	Lines []string `json:"lines"`
+	// ErrorLines are the indexes of the lines log analysis considers errors or warnings
+	ErrorLines []int `json:"errorLines"`
+	// TruncatedLines is how many lines were cut at the maximum line length
+	TruncatedLines int `json:"truncatedLines,omitempty"`
+}
+
+// FetchContainerLog fetches the last tailLines lines of a container's log, or of its
+// previous run, bounded like the logs log analysis reads and redacted like the lines
+// it analyzes
+func FetchContainerLog(ctx context.Context, k8sClient kubernetes.Interface, namespace, pod, container string,
+	tailLines int64, previous bool, redaction *infrav1alpha1.RedactionConfig) (*ContainerLog, error) {
+	// An invalid redaction configuration never lets unredacted lines through
+	redactor, err := newRedactor(redaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure redaction: %w", err)
+	}
+	maxLogBytes := int64(defaultMaxLogBytes)
+	logStream, err := k8sClient.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tailLines,
+		LimitBytes: &maxLogBytes,
+		Previous:   previous,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs: %w", err)
 	}
+	defer logStream.Close()
 
-	return allLines, nil
+	lines, _, truncated, err := readLogLines(logStream, defaultMaxLineLength, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log stream: %w", err)
+	}
+	lines = redactor.redactLines(lines)
+	containerLog := &ContainerLog{Lines: lines, ErrorLines: []int{}, TruncatedLines: truncated}
+	if containerLog.Lines == nil {
+		containerLog.Lines = []string{}
+	}
+	for i, line := range lines {
+		if isErrorLine(line) {
+			containerLog.ErrorLines = append(containerLog.ErrorLines, i)
+		}
+	}
+	return containerLog, nil
 }
 
-// filterErrorLines filters log lines for errors and warnings
-func filterErrorLines(lines []string) []string {
-	var errorLines []string
-	errorKeywords := []string{
-		"error", "err", "failed", "failure", "fatal", "panic",
-		"exception", "warning", "warn", "critical", "alert",
-	}
+// readLogLines reads a log stream line by line, keeping the lines keep accepts (all lines
+// if keep is nil). Lines longer than maxLineLength bytes are truncated without buffering
+// the rest of the line. Returns the kept lines, the number of lines read and how many
+// of them were truncated.
+func readLogLines(stream io.Reader, maxLineLength int, keep func(string) bool) ([]string, int, int, error) {
+	reader := bufio.NewReader(stream)
+	var lines []string
+	var line []byte
+	read, truncated, lineTruncated := 0, 0, false
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if err != nil && err != bufio.ErrBufferFull && err != io.EOF {
+			return lines, read, truncated, err
+		}
+		chunk = bytes.TrimSuffix(chunk, []byte{'\n'})
+		if room := maxLineLength - len(line); len(chunk) > room {
+			chunk = chunk[:max(room, 0)]
+			lineTruncated = true
+		}
+		line = append(line, chunk...)
+		if err == bufio.ErrBufferFull {
+			// The rest of the line is read by the next call
+			continue
+		}
 
-	for _, line := range lines {
-		lowerLine := strings.ToLower(line)
-		for _, keyword := range errorKeywords {
-			if strings.Contains(lowerLine, keyword) {
-				errorLines = append(errorLines, line)
-				break
+		if err == nil || len(line) > 0 {
+			text := strings.TrimSuffix(string(line), "\r")
+			if lineTruncated {
+				// Truncation may have split a multi-byte character
+				text = strings.ToValidUTF8(text, "")
+				truncated++
+			}
+			read++
+			if keep == nil || keep(text) {
+				lines = append(lines, text)
 			}
 		}
+		if err == io.EOF {
+			return lines, read, truncated, nil
+		}
+		line, lineTruncated = line[:0], false
 	}
+}
+
+// errorKeywords mark the log lines reporting errors and warnings
+var errorKeywords = []string{
+	"error", "err", "failed", "failure", "fatal", "panic",
+	"exception", "warning", "warn", "critical", "alert",
+}
 
-	return errorLines
+// isErrorLine reports whether a log line reports an error or warning
+func isErrorLine(line string) bool {
+	lowerLine := strings.ToLower(line)
+	for _, keyword := range errorKeywords {
+		if strings.Contains(lowerLine, keyword) {
+			return true
+		}
+	}
+	return false
 }
 
 // analyzeWithPatterns analyzes logs using pattern matching
-func analyzeWithPatterns(logLines []string, customPatterns []infrav1alpha1.ErrorPattern) (*infrav1alpha1.LogAnalysisResult, error) {
+func analyzeWithPatterns(logLines []string, customPatterns []infrav1alpha1.ErrorPattern, confidence confidenceSettings) (*infrav1alpha1.LogAnalysisResult, error) {
 	var patterns []PatternMatch
 
 	// Use custom patterns if provided, otherwise use defaults
@@ -494,7 +741,7 @@ func analyzeWithPatterns(logLines []string, customPatterns []infrav1alpha1.Error
 		if len(logLines) > 0 {
 			return &infrav1alpha1.LogAnalysisResult{
 				RootCause:      "Unknown error detected in logs",
-				Confidence:     30,
+				Confidence:     confidence.PatternNoMatch,
 				ErrorLines:     logLines[:min(10, len(logLines))], // Return first 10 lines
 				MatchedPattern: "",
 				Priority:       0,
@@ -509,17 +756,9 @@ func analyzeWithPatterns(logLines []string, customPatterns []infrav1alpha1.Error
 		rootCause = matchedLines[0] // Use first matched line as root cause
 	}
 
-	// Calculate confidence based on number of matches
-	confidence := int32(50)
-	if len(matchedLines) >= 3 {
-		confidence = 80
-	} else if len(matchedLines) >= 2 {
-		confidence = 65
-	}
-
 	return &infrav1alpha1.LogAnalysisResult{
 		RootCause:      rootCause,
-		Confidence:     confidence,
+		Confidence:     confidence.patternConfidence(len(matchedLines)), // Based on number of matches
 		ErrorLines:     matchedLines,
 		MatchedPattern: bestMatch.Name,
 		Priority:       bestMatch.Priority,
@@ -567,12 +806,95 @@ func getAPIKeyFromSecret(ctx context.Context, k8sClient client.Client, secretRef
 	return string(apiKeyBytes), nil
 }
 
+// aiRequestOptions carries reconcile-scoped state shared by AI requests
+type aiRequestOptions struct {
+	// Limiters must all admit a request before it is sent
+	Limiters []*AIRateLimiter
+	// Quota counts requests against the PodSleuth's AI quota, after the limiters admit them
+	Quota *aiQuota
+	// Batch shares results between identically failing pods
+	Batch *aiBatch
+}
+
+// analyzeWithAIProviders runs AI analysis against the primary provider and, if it fails,
+// each fallback provider in order until one succeeds
+func analyzeWithAIProviders(ctx context.Context, k8sClient client.Client, logLines []string, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, aiConfig *infrav1alpha1.AIConfig, aiFallbacks []infrav1alpha1.AIConfig, aiOpts *aiRequestOptions) *infrav1alpha1.AIAnalysisResult {
+	logger := log.Log.WithName("log-analysis")
+
+	providers := []*infrav1alpha1.AIConfig{aiConfig}
+	for i := range aiFallbacks {
+		providers = append(providers, &aiFallbacks[i])
+	}
+
+	var failedProviders []string
+	var lastErr error
+	for _, provider := range providers {
+		providerName := describeAIProvider(config, provider)
+
+		result, err := analyzeWithAI(ctx, k8sClient, logLines, pod, config, provider, aiOpts)
+		if err != nil {
+			logger.Error(err, "AI analysis failed", "provider", providerName)
+			lastErr = err
+			failedProviders = append(failedProviders, fmt.Sprintf("%s: %v", providerName, err))
+
+			// Operator-side rate limits and quotas apply to every provider, and a cancelled
+			// context fails every attempt, so trying the next provider is pointless
+			if errors.Is(err, ErrAIRateLimited) || errors.Is(err, ErrAIQuotaExhausted) || ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		if result == nil {
+			continue
+		}
+
+		logger.Info("AI analysis completed", "provider", providerName, "model", result.Model, "confidence", result.Confidence)
+		return &infrav1alpha1.AIAnalysisResult{
+			Model:           result.Model,
+			RootCause:       result.RootCause,
+			Confidence:      result.Confidence,
+			Provider:        providerName,
+			FailedProviders: failedProviders,
+		}
+	}
+
+	if lastErr == nil {
+		return nil
+	}
+
+	// Store error in result for UI display
+	return &infrav1alpha1.AIAnalysisResult{
+		Error:           fmt.Sprintf("AI analysis failed: %v", lastErr),
+		FailedProviders: failedProviders,
+	}
+}
+
+// describeAIProvider returns a short provider description such as "openai (api.openai.com)"
+func describeAIProvider(config *infrav1alpha1.LogAnalysisConfig, aiConfig *infrav1alpha1.AIConfig) string {
+	endpoint, format := config.AIEndpoint, config.AIFormat
+	if aiConfig != nil {
+		endpoint, format = aiConfig.Endpoint, aiConfig.Format
+	}
+	if format == "" {
+		format = detectAIFormat(endpoint)
+	}
+
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return fmt.Sprintf("%s (%s)", format, host)
+}
+
 // analyzeWithAI analyzes logs using AI endpoint
-func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []string, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, aiConfig *infrav1alpha1.AIConfig) (*infrav1alpha1.LogAnalysisResult, error) {
+func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []string, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, aiConfig *infrav1alpha1.AIConfig, aiOpts *aiRequestOptions) (*infrav1alpha1.LogAnalysisResult, error) {
 	// Get AI configuration (prefer new aiConfig parameter, fallback to deprecated fields)
 	var endpoint, format, model, authHeader, authPrefix string
 	var apiKeySecretRef *corev1.SecretKeySelector
-	var timeout time.Duration = 60 * time.Second // Default timeout
+	var apiKeySecretNamespace string
+	timeout := defaultAITimeout
+	var clientOpts aiClientOptions
 
 	if aiConfig != nil {
 		// Use new AIConfig structure
@@ -580,11 +902,19 @@ func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []stri
 		format = aiConfig.Format
 		model = aiConfig.Model
 		apiKeySecretRef = aiConfig.APIKeySecretRef
+		apiKeySecretNamespace = aiConfig.APIKeySecretNamespace
 		authHeader = aiConfig.AuthHeader
 		authPrefix = aiConfig.AuthPrefix
-		if aiConfig.Timeout != nil {
+		if aiConfig.Timeout != nil && aiConfig.Timeout.Duration > 0 {
 			timeout = aiConfig.Timeout.Duration
 		}
+		if aiConfig.ConnectTimeout != nil {
+			clientOpts.ConnectTimeout = aiConfig.ConnectTimeout.Duration
+		}
+		if aiConfig.ReadTimeout != nil {
+			clientOpts.ReadTimeout = aiConfig.ReadTimeout.Duration
+		}
+		clientOpts.ProxyURL = aiConfig.ProxyURL
 	} else {
 		// Fallback to deprecated fields
 		endpoint = config.AIEndpoint
@@ -598,12 +928,19 @@ func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []stri
 	if endpoint == "" {
 		return nil, fmt.Errorf("AI endpoint is required for AI analysis")
 	}
+	if err := checkAIEndpoint(endpoint); err != nil {
+		return nil, err
+	}
 
 	// Get API key if configured
 	var apiKey string
 	var err error
 	if apiKeySecretRef != nil {
-		apiKey, err = getAPIKeyFromSecret(ctx, k8sClient, apiKeySecretRef, pod.Namespace)
+		secretNamespace, err := aiKeySecretNamespace(apiKeySecretNamespace, pod)
+		if err != nil {
+			return nil, err
+		}
+		apiKey, err = resolveAIKey(ctx, k8sClient, apiKeySecretRef, secretNamespace)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get API key: %w", err)
 		}
@@ -615,8 +952,12 @@ func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []stri
 		return nil, fmt.Errorf("failed to build AI request: %w", err)
 	}
 
+	// Apply the overall timeout to the whole request, including reading the response body
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(reqCtx, "POST", endpoint, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -641,24 +982,59 @@ func analyzeWithAI(ctx context.Context, k8sClient client.Client, logLines []stri
 		req.Header.Set(authHeader, authValue)
 	}
 
-	// Make HTTP request with timeout
-	httpClient := &http.Client{
-		Timeout: timeout,
+	// Respect operator-wide and per-PodSleuth AI rate limits and the PodSleuth's quota
+	var limiters []*AIRateLimiter
+	var quota *aiQuota
+	if aiOpts != nil {
+		limiters, quota = aiOpts.Limiters, aiOpts.Quota
+	}
+	release, cancel, err := acquireAIRequest(ctx, limiters...)
+	if err != nil {
+		return nil, err
+	}
+	if err := quota.take(time.Now()); err != nil {
+		// The request is not sent, so it does not count against the rate limits
+		cancel()
+		return nil, err
 	}
+	defer release()
 
+	// Use a pooled client so connections are reused across requests
+	httpClient, err := getAIHTTPClient(clientOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	// Label metrics by API format rather than endpoint to keep cardinality low
+	provider := format
+	if provider == "" {
+		provider = detectAIFormat(endpoint)
+	}
+	requestStart := time.Now()
+
+	// Record what leaves the cluster, whatever the outcome
+	egress := newAIEgressRecord(pod, endpoint, provider, model, logLines, requestBody, config.Redaction)
 	resp, err := httpClient.Do(req)
 	if err != nil {
+		egress.Error = err.Error()
+		recordAIEgress(egress)
+		observeAIRequest(provider, requestStart, err)
 		return nil, fmt.Errorf("failed to make AI request: %w", err)
 	}
 	defer resp.Body.Close()
+	egress.StatusCode = resp.StatusCode
+	recordAIEgress(egress)
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("AI endpoint returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		err = fmt.Errorf("AI endpoint returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		observeAIRequest(provider, requestStart, err)
+		return nil, err
 	}
 
 	// Parse response
 	result, err := parseAIResponse(resp.Body, endpoint, format)
+	observeAIRequest(provider, requestStart, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse AI response: %w", err)
 	}
@@ -687,17 +1063,7 @@ Provide a concise root cause analysis. Focus on the primary issue.`, pod.Namespa
 	// Determine format: use explicit format if set, otherwise auto-detect from endpoint
 	apiFormat := format
 	if apiFormat == "" {
-		// Auto-detect based on endpoint URL
-		if strings.Contains(endpoint, "openai.com") {
-			apiFormat = "openai"
-		} else if strings.Contains(endpoint, "anthropic.com") {
-			apiFormat = "anthropic"
-		} else if strings.Contains(endpoint, "ollama") || strings.Contains(endpoint, ":11434") {
-			apiFormat = "ollama"
-		} else {
-			// Default to OpenAI format for unknown endpoints (most compatible)
-			apiFormat = "openai"
-		}
+		apiFormat = detectAIFormat(endpoint)
 	}
 
 	// Determine model: use explicit model if set, otherwise use defaults
@@ -768,6 +1134,19 @@ Provide a concise root cause analysis. Focus on the primary issue.`, pod.Namespa
 	return json.Marshal(requestBody)
 }
 
+// detectAIFormat auto-detects the API format from the endpoint URL
+func detectAIFormat(endpoint string) string {
+	if strings.Contains(endpoint, "openai.com") {
+		return "openai"
+	} else if strings.Contains(endpoint, "anthropic.com") {
+		return "anthropic"
+	} else if strings.Contains(endpoint, "ollama") || strings.Contains(endpoint, ":11434") {
+		return "ollama"
+	}
+	// Default to OpenAI format for unknown endpoints (most compatible)
+	return "openai"
+}
+
 // parseAIResponse parses the AI response based on endpoint type and format setting
 func parseAIResponse(body io.Reader, endpoint string, format string) (*infrav1alpha1.LogAnalysisResult, error) {
 	bodyBytes, err := io.ReadAll(body)
@@ -786,17 +1165,7 @@ func parseAIResponse(body io.Reader, endpoint string, format string) (*infrav1al
 	// Determine format: use explicit format if set, otherwise auto-detect from endpoint
 	apiFormat := format
 	if apiFormat == "" {
-		// Auto-detect based on endpoint URL
-		if strings.Contains(endpoint, "openai.com") {
-			apiFormat = "openai"
-		} else if strings.Contains(endpoint, "anthropic.com") {
-			apiFormat = "anthropic"
-		} else if strings.Contains(endpoint, "ollama") || strings.Contains(endpoint, ":11434") {
-			apiFormat = "ollama"
-		} else {
-			// Default to OpenAI format for unknown endpoints (most compatible)
-			apiFormat = "openai"
-		}
+		apiFormat = detectAIFormat(endpoint)
 	}
 
 	// Parse based on format
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestAnalyzeMetricsOnly(t *testing.T) {
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"value":[1700000000,"7"]}]}}`))
+	}))
+	defer prometheus.Close()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-1"}}
+	config := &infrav1alpha1.LogAnalysisConfig{
+		Enabled: true,
+		MethodConfigs: []infrav1alpha1.MethodConfig{
+			{Type: "pattern"},
+			{Type: "metrics", MetricsConfig: &infrav1alpha1.MetricsConfig{
+				PrometheusURL: prometheus.URL,
+				Queries:       []infrav1alpha1.MetricQuery{{Name: "RestartRate", Query: "restarts", Threshold: "3", RootCause: "restarted {{value}} times"}},
+			}},
+		},
+	}
+
+	result := analyzeMetricsOnly(context.Background(), nil, pod, config)
+	if result == nil || result.MetricsResult == nil {
+		t.Fatalf("got %+v, want a metrics result", result)
+	}
+	if result.RootCause != "restarted 7 times" || result.Method != "metrics" {
+		t.Errorf("got root cause %q by %q, want %q by metrics", result.RootCause, result.Method, "restarted 7 times")
+	}
+
+	// Without the metrics method there is nothing to analyze
+	config.MethodConfigs = config.MethodConfigs[:1]
+	if result := analyzeMetricsOnly(context.Background(), nil, pod, config); result != nil {
+		t.Errorf("got %+v without the metrics method, want nil", result)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultLogFetchesPerSecond is the default operator-wide rate of container log fetches
+	DefaultLogFetchesPerSecond = 20
+	// DefaultLogFetchesPerNodePerSecond is the default rate of container log fetches
+	// served by the kubelet of one node
+	DefaultLogFetchesPerNodePerSecond = 5
+)
+
+// LogFetchLimiter bounds the rate of container log fetches operator-wide and per node,
+// so that a mass failure does not flood the API server and kubelets with log requests.
+// Unlike AI requests, log fetches wait for their turn. A nil limiter, or a rate of 0,
+// means unlimited.
+type LogFetchLimiter struct {
+	perSecond        float64
+	perNodePerSecond float64
+
+	limiter *rate.Limiter
+
+	nodes map[string]*rate.Limiter
+	mux   sync.Mutex
+}
+
+// NewLogFetchLimiter creates a limiter allowing perSecond log fetches per second, and
+// perNodePerSecond log fetches per second from the pods of each node
+func NewLogFetchLimiter(perSecond, perNodePerSecond float64) *LogFetchLimiter {
+	l := &LogFetchLimiter{}
+	l.SetRates(perSecond, perNodePerSecond)
+	return l
+}
+
+// SetRates changes the rates of the limiter. Fetches already waiting keep waiting for
+// their turn at the previous rates.
+func (l *LogFetchLimiter) SetRates(perSecond, perNodePerSecond float64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if perSecond != l.perSecond {
+		l.limiter = nil
+		if perSecond > 0 {
+			l.limiter = rate.NewLimiter(rate.Limit(perSecond), burstOf(perSecond))
+		}
+	}
+	if perNodePerSecond != l.perNodePerSecond {
+		// Node limiters are created again on first use
+		l.nodes = nil
+	}
+	l.perSecond, l.perNodePerSecond = perSecond, perNodePerSecond
+}
+
+// burstOf allows a second's worth of log fetches at once
+func burstOf(perSecond float64) int {
+	return max(1, int(math.Ceil(perSecond)))
+}
+
+// Wait blocks until a log fetch from a pod on nodeName is allowed, or ctx is done.
+// Pods that are not scheduled only wait for the operator-wide limit.
+func (l *LogFetchLimiter) Wait(ctx context.Context, nodeName string) error {
+	if l == nil {
+		return nil
+	}
+	start := time.Now()
+	defer func() {
+		logFetchWait.Observe(time.Since(start).Seconds())
+	}()
+
+	limiter, node := l.limiters(nodeName)
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if node != nil {
+		return node.Wait(ctx)
+	}
+	return nil
+}
+
+// limiters returns the operator-wide limiter and the limiter of a node, creating the
+// node's on first use
+func (l *LogFetchLimiter) limiters(nodeName string) (*rate.Limiter, *rate.Limiter) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.perNodePerSecond <= 0 || nodeName == "" {
+		return l.limiter, nil
+	}
+	if l.nodes == nil {
+		l.nodes = make(map[string]*rate.Limiter)
+	}
+	node, exists := l.nodes[nodeName]
+	if !exists {
+		node = rate.NewLimiter(rate.Limit(l.perNodePerSecond), burstOf(l.perNodePerSecond))
+		l.nodes[nodeName] = node
+	}
+	return l.limiter, node
+}
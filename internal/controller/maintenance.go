@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// maxMaintenanceWindowDuration bounds how far back window starts are searched
+const maxMaintenanceWindowDuration = 31 * 24 * time.Hour
+
+// cronAliases are the supported shorthand schedules
+var cronAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronSchedule is a parsed five-field cron expression
+type cronSchedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek []bool
+	// Standard cron matches either field when both day fields are restricted
+	domRestricted, dowRestricted bool
+}
+
+// parseCronSchedule parses "minute hour day-of-month month day-of-week" with
+// support for *, lists, ranges and steps
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	if alias, ok := cronAliases[strings.TrimSpace(expr)]; ok {
+		expr = alias
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	s := &cronSchedule{}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.daysOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.daysOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is an alias for Sunday
+	s.daysOfWeek[0] = s.daysOfWeek[0] || s.daysOfWeek[7]
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return s, nil
+}
+
+// parseCronField parses one cron field into a lookup table indexed by value
+func parseCronField(field string, minValue, maxValue int) ([]bool, error) {
+	values := make([]bool, maxValue+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, stepText, found := strings.Cut(part, "/"); found {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step %q", stepText)
+			}
+			part, step = base, n
+		}
+
+		low, high := minValue, maxValue
+		if part != "*" {
+			lowText, highText, isRange := strings.Cut(part, "-")
+			var err error
+			if low, err = strconv.Atoi(lowText); err != nil {
+				return nil, fmt.Errorf("invalid value %q", lowText)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highText); err != nil {
+					return nil, fmt.Errorf("invalid value %q", highText)
+				}
+			} else if step > 1 {
+				// "5/15" means from 5 to the maximum in steps of 15
+				high = maxValue
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return nil, fmt.Errorf("value out of range %d-%d: %q", minValue, maxValue, part)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// matches reports whether the schedule fires at the given minute
+func (s *cronSchedule) matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+	dom := s.daysOfMonth[t.Day()]
+	dow := s.daysOfWeek[int(t.Weekday())]
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// maintenanceWindowActive reports whether a window started within its duration before now
+func maintenanceWindowActive(window infrav1alpha1.MaintenanceWindow, now time.Time) (bool, error) {
+	schedule, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule %q: %w", window.Schedule, err)
+	}
+	location := time.UTC
+	if window.TimeZone != "" {
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, fmt.Errorf("invalid time zone %q: %w", window.TimeZone, err)
+		}
+	}
+
+	duration := window.Duration.Duration
+	if duration > maxMaintenanceWindowDuration {
+		duration = maxMaintenanceWindowDuration
+	}
+	current := now.In(location).Truncate(time.Minute)
+	earliest := now.In(location).Add(-duration)
+	for t := current; t.After(earliest); t = t.Add(-time.Minute) {
+		if schedule.matches(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// activeMaintenanceWindows returns the windows of a PodSleuth that are active now.
+// Invalid windows are reported as errors and otherwise ignored.
+func activeMaintenanceWindows(windows []infrav1alpha1.MaintenanceWindow, now time.Time) ([]infrav1alpha1.MaintenanceWindow, []error) {
+	var active []infrav1alpha1.MaintenanceWindow
+	var errs []error
+	for _, window := range windows {
+		isActive, err := maintenanceWindowActive(window, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("maintenance window %s: %w", window.Name, err))
+			continue
+		}
+		if isActive {
+			active = append(active, window)
+		}
+	}
+	return active, errs
+}
+
+// suppressingWindow returns the name of the first active window covering a namespace
+func suppressingWindow(active []infrav1alpha1.MaintenanceWindow, namespace string) string {
+	for _, window := range active {
+		if len(window.Namespaces) == 0 || slices.Contains(window.Namespaces, namespace) {
+			return window.Name
+		}
+	}
+	return ""
+}
+
+// withoutAIMethods returns a copy of a log analysis configuration without AI methods,
+// used for suppressed pods so maintenance does not spend AI requests
+func withoutAIMethods(config *infrav1alpha1.LogAnalysisConfig) *infrav1alpha1.LogAnalysisConfig {
+	if config == nil {
+		return nil
+	}
+	stripped := config.DeepCopy()
+	stripped.MethodConfigs = slices.DeleteFunc(stripped.MethodConfigs, func(mc infrav1alpha1.MethodConfig) bool {
+		return mc.Type == "ai"
+	})
+	stripped.Methods = slices.DeleteFunc(stripped.Methods, func(m string) bool { return m == "ai" })
+	if stripped.Method == "ai" {
+		stripped.Method = "pattern"
+	}
+	// Without any method left, fall back to the default pattern method
+	if len(config.MethodConfigs) > 0 && len(stripped.MethodConfigs) == 0 {
+		stripped.MethodConfigs = []infrav1alpha1.MethodConfig{{Type: "pattern"}}
+	}
+	if len(config.Methods) > 0 && len(stripped.Methods) == 0 {
+		stripped.Methods = []string{"pattern"}
+	}
+	return stripped
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// meshSidecar describes how a service mesh injects its proxy
+type meshSidecar struct {
+	Mesh      string
+	Container string
+	// InjectAnnotation requests injection for a pod when set to one of InjectValues
+	InjectAnnotation string
+	InjectValues     []string
+}
+
+var meshSidecars = []meshSidecar{
+	{Mesh: "istio", Container: "istio-proxy", InjectAnnotation: "sidecar.istio.io/inject", InjectValues: []string{"true"}},
+	{Mesh: "linkerd", Container: "linkerd-proxy", InjectAnnotation: "linkerd.io/inject", InjectValues: []string{"enabled", "ingress"}},
+}
+
+// diagnoseMesh checks the service mesh sidecar of a pod. It returns nil for pods that
+// are not part of a mesh. When the sidecar is the failing container, reason and message
+// replace the generic pod reason. When only the application is failing, reason is empty
+// and message is a note clarifying that the sidecar is healthy.
+func diagnoseMesh(pod *corev1.Pod) (diagnosis *infrav1alpha1.MeshDiagnosis, reason, message string) {
+	for _, mesh := range meshSidecars {
+		sidecar, found := findSidecarStatus(pod, mesh.Container)
+		injectRequested := injectionRequested(pod, mesh)
+		if !found && !injectRequested {
+			continue
+		}
+
+		diagnosis = &infrav1alpha1.MeshDiagnosis{Mesh: mesh.Mesh}
+
+		// Injection was requested but the webhook did not add the proxy
+		if !found {
+			diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf(
+				"%s=%q is set but the pod has no %s container: check that the %s injector webhook is running and that the pod was created after injection was enabled",
+				mesh.InjectAnnotation, pod.Annotations[mesh.InjectAnnotation], mesh.Container, mesh.Mesh))
+			return diagnosis, "MeshSidecarMissing", diagnosis.Issues[0]
+		}
+
+		diagnosis.Sidecar = mesh.Container
+		diagnosis.SidecarReady = sidecar.Ready
+		diagnosis.ApplicationReady = true
+		var failingApps, completedApps []string
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == mesh.Container {
+				continue
+			}
+			if !cs.Ready {
+				diagnosis.ApplicationReady = false
+				if cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0 {
+					completedApps = append(completedApps, cs.Name)
+				} else {
+					failingApps = append(failingApps, cs.Name)
+				}
+			}
+		}
+
+		diagnosis.Issues = append(diagnosis.Issues, meshConfigurationIssues(pod, mesh, diagnosis)...)
+
+		switch {
+		case len(completedApps) > 0 && len(failingApps) == 0 && sidecar.State.Running != nil:
+			// Classic Job problem: the application finished but the proxy keeps running
+			diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf(
+				"application container(s) %s completed but %s is still running; the pod cannot finish until the proxy exits",
+				strings.Join(completedApps, ", "), mesh.Container))
+			return diagnosis, "MeshSidecarBlockingCompletion", diagnosis.Issues[len(diagnosis.Issues)-1]
+		case !sidecar.Ready && diagnosis.ApplicationReady:
+			return diagnosis, "MeshSidecarNotReady", fmt.Sprintf(
+				"The %s sidecar (%s) is not ready while the application container(s) are ready: %s",
+				mesh.Mesh, mesh.Container, describeContainerState(sidecar))
+		case sidecar.Ready && !diagnosis.ApplicationReady:
+			return diagnosis, "", fmt.Sprintf(
+				"The %s sidecar is healthy; the application container(s) %s are failing",
+				mesh.Mesh, strings.Join(failingApps, ", "))
+		}
+		return diagnosis, "", ""
+	}
+	return nil, "", ""
+}
+
+// meshConfigurationIssues detects common mesh misconfigurations for a pod with a sidecar
+func meshConfigurationIssues(pod *corev1.Pod, mesh meshSidecar, diagnosis *infrav1alpha1.MeshDiagnosis) []string {
+	var issues []string
+
+	if mesh.Mesh == "istio" {
+		// Without probe rewriting, kubelet HTTP probes hit the proxy and fail under STRICT mTLS
+		if pod.Annotations["sidecar.istio.io/rewriteAppHTTPProbers"] == "false" && hasHTTPProbe(pod) {
+			issues = append(issues, "sidecar.istio.io/rewriteAppHTTPProbers is \"false\": HTTP probes are sent through the proxy and fail when mTLS is STRICT")
+		}
+		// Applications that connect out at startup fail before the proxy is ready
+		if !diagnosis.ApplicationReady && diagnosis.SidecarReady &&
+			!strings.Contains(pod.Annotations["proxy.istio.io/config"], "holdApplicationUntilProxyStarts") {
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.Name != mesh.Container && cs.RestartCount > 0 {
+					issues = append(issues, "application restarted inside the mesh: if it fails on startup connections, set holdApplicationUntilProxyStarts: true in proxy.istio.io/config")
+					break
+				}
+			}
+		}
+	}
+
+	// A sidecar injected into a host network pod cannot intercept traffic
+	if pod.Spec.HostNetwork {
+		issues = append(issues, fmt.Sprintf("pod uses hostNetwork; %s traffic interception does not work for host network pods", mesh.Mesh))
+	}
+
+	return issues
+}
+
+// findSidecarStatus returns the status of a sidecar container, which is either a regular
+// container or a native sidecar (restartable init container)
+func findSidecarStatus(pod *corev1.Pod, name string) (corev1.ContainerStatus, bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == name {
+			return cs, true
+		}
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name == name {
+			return cs, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// injectionRequested reports whether the pod asks for sidecar injection
+func injectionRequested(pod *corev1.Pod, mesh meshSidecar) bool {
+	value := pod.Annotations[mesh.InjectAnnotation]
+	if value == "" && mesh.Mesh == "istio" {
+		// Istio also accepts the injection flag as a label
+		value = pod.Labels[mesh.InjectAnnotation]
+	}
+	for _, v := range mesh.InjectValues {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHTTPProbe reports whether any application container uses an HTTP probe
+func hasHTTPProbe(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		for _, probe := range []*corev1.Probe{c.ReadinessProbe, c.LivenessProbe, c.StartupProbe} {
+			if probe != nil && probe.HTTPGet != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describeContainerState returns a short description of a container's current state
+func describeContainerState(cs corev1.ContainerStatus) string {
+	switch {
+	case cs.State.Waiting != nil:
+		return strings.TrimSpace(fmt.Sprintf("waiting (%s) %s", cs.State.Waiting.Reason, cs.State.Waiting.Message))
+	case cs.State.Terminated != nil:
+		return fmt.Sprintf("terminated (%s, exit code %d)", cs.State.Terminated.Reason, cs.State.Terminated.ExitCode)
+	case cs.State.Running != nil:
+		return "running but readiness probe is failing"
+	}
+	return "unknown state"
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Cache eviction reasons
+const (
+	evictionReasonCapacity = "capacity"
+	evictionReasonExpired  = "expired"
+	evictionReasonStale    = "stale"
+)
+
+var (
+	analysisCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "podsleuth_analysis_cache_hits_total",
+		Help: "Number of log analysis cache hits",
+	})
+	analysisCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "podsleuth_analysis_cache_misses_total",
+		Help: "Number of log analysis cache misses",
+	})
+	analysisCacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "podsleuth_analysis_cache_evictions_total",
+		Help: "Number of log analysis cache entries removed, by reason",
+	}, []string{"reason"})
+	analysisCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "podsleuth_analysis_cache_entries",
+		Help: "Number of entries in the log analysis cache",
+	})
+	analysisCacheBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "podsleuth_analysis_cache_bytes",
+		Help: "Approximate size of the log analysis cache in bytes",
+	})
+	analysisCacheEntryAge = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "podsleuth_analysis_cache_hit_age_seconds",
+		Help:    "Age of log analysis cache entries when they are served",
+		Buckets: []float64{30, 60, 300, 600, 1800, 3600, 7200, 21600, 86400},
+	})
+
+	// Hits and misses since start, for the hit ratio gauge
+	cacheHitCount, cacheMissCount atomic.Int64
+	analysisCacheHitRatio         = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "kubesleuth_analysis_cache_hit_ratio",
+		Help: "Ratio of log analysis cache hits to lookups since the operator started",
+	}, func() float64 {
+		hits, misses := cacheHitCount.Load(), cacheMissCount.Load()
+		if hits+misses == 0 {
+			return 0
+		}
+		return float64(hits) / float64(hits+misses)
+	})
+
+	nonReadyPodsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_nonready_pods",
+		Help: "Number of non-ready pods found by a PodSleuth, by namespace, reason and severity",
+	}, []string{"podsleuth", "namespace", "reason", "severity"})
+	logAnalysisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubesleuth_log_analysis_duration_seconds",
+		Help:    "Duration of uncached log analyses of a pod, by outcome",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"outcome"})
+	logFetchWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kubesleuth_log_fetch_wait_seconds",
+		Help:    "Time container log fetches waited for the log fetch rate limits",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	})
+	aiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesleuth_ai_requests_total",
+		Help: "Number of AI analysis requests, by provider format and outcome",
+	}, []string{"provider", "outcome"})
+	aiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubesleuth_ai_request_duration_seconds",
+		Help:    "Latency of AI analysis requests, by provider format",
+		Buckets: []float64{0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+	}, []string{"provider"})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubesleuth_reconcile_duration_seconds",
+		Help:    "Duration of PodSleuth reconciles, by PodSleuth",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"podsleuth"})
+	notificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesleuth_notifications_total",
+		Help: "Number of notifications delivered to sinks, by sink type, sink name and outcome",
+	}, []string{"type", "sink", "outcome"})
+	issueOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesleuth_issue_operations_total",
+		Help: "Number of issue tracker operations (list, create, resolve), by tracker and outcome",
+	}, []string{"tracker", "operation", "outcome"})
+	remediationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesleuth_remediations_total",
+		Help: "Number of remediation actions taken, by PodSleuth, action and result",
+	}, []string{"podsleuth", "action", "result"})
+
+	remediationLockedOut = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_remediation_locked_out",
+		Help: "Whether automatic remediation is locked out because a budget was exceeded (1) or not (0), by PodSleuth",
+	}, []string{"podsleuth"})
+
+	sloMeanTimeToDetect = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_mttd_seconds",
+		Help: "Mean time from a workload's pods failing to their detection over the SLO window, by PodSleuth and workload",
+	}, []string{"podsleuth", "namespace", "kind", "workload"})
+	sloMeanTimeToRecover = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_mttr_seconds",
+		Help: "Mean time from a workload's pods failing to their recovery over the SLO window, by PodSleuth and workload",
+	}, []string{"podsleuth", "namespace", "kind", "workload"})
+	sloAvailability = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_availability_ratio",
+		Help: "Share of the SLO window a workload was without an incident, by PodSleuth and workload",
+	}, []string{"podsleuth", "namespace", "kind", "workload"})
+	sloBreaching = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_slo_breaching",
+		Help: "Whether a workload is below its availability objective (1) or not (0), by PodSleuth and workload",
+	}, []string{"podsleuth", "namespace", "kind", "workload"})
+	aiQuotaRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_ai_quota_requests",
+		Help: "AI requests counted against the quota of a PodSleuth in the current hour or day",
+	}, []string{"podsleuth", "window"})
+	sloNamespaceMeanTimeToRecover = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_namespace_mttr_seconds",
+		Help: "Mean time to recover of the workloads of a namespace over the SLO window, by PodSleuth",
+	}, []string{"podsleuth", "namespace"})
+
+	wastedCPUCores = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_wasted_cpu_cores",
+		Help: "CPU cores requested by pods non-ready for 10 minutes or more, by PodSleuth and namespace",
+	}, []string{"podsleuth", "namespace"})
+	wastedMemoryBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_wasted_memory_bytes",
+		Help: "Memory requested by pods non-ready for 10 minutes or more, by PodSleuth and namespace",
+	}, []string{"podsleuth", "namespace"})
+	wastedCostPerHour = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesleuth_wasted_cost_per_hour",
+		Help: "Hourly cost of the capacity requested by pods non-ready for 10 minutes or more, by PodSleuth and namespace",
+	}, []string{"podsleuth", "namespace"})
+)
+
+// Metric outcomes
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// operatorMetrics are the metrics of the operator, registered with the controller-runtime
+// registry
+var operatorMetrics = []prometheus.Collector{
+	analysisCacheHits,
+	analysisCacheMisses,
+	analysisCacheEvictions,
+	analysisCacheEntries,
+	analysisCacheBytes,
+	analysisCacheEntryAge,
+	analysisCacheHitRatio,
+	nonReadyPodsGauge,
+	logAnalysisDuration,
+	logFetchWait,
+	aiRequests,
+	aiRequestDuration,
+	reconcileDuration,
+	notificationsSent,
+	issueOperations,
+	remediationsTotal,
+	remediationLockedOut,
+	sloMeanTimeToDetect,
+	sloMeanTimeToRecover,
+	sloAvailability,
+	sloBreaching,
+	sloNamespaceMeanTimeToRecover,
+	aiQuotaRequests,
+	wastedCPUCores,
+	wastedMemoryBytes,
+	wastedCostPerHour,
+}
+
+// RegisterMetrics registers the operator's metrics with the controller-runtime registry,
+// labeled with the cluster name if one is set. Set the cluster name first: the labels of
+// registered metrics cannot change.
+func RegisterMetrics() error {
+	registerer := prometheus.Registerer(metrics.Registry)
+	if cluster := ClusterName(); cluster != "" {
+		registerer = prometheus.WrapRegistererWith(prometheus.Labels{clusterLabel: cluster}, metrics.Registry)
+	}
+	for _, collector := range operatorMetrics {
+		if err := registerer.Register(collector); err != nil {
+			return fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// outcomeOf returns the metric outcome label of an error
+func outcomeOf(err error) string {
+	if err != nil {
+		return outcomeError
+	}
+	return outcomeSuccess
+}
+
+// observeAIRequest records the outcome and latency of an AI request
+func observeAIRequest(provider string, start time.Time, err error) {
+	aiRequests.WithLabelValues(provider, outcomeOf(err)).Inc()
+	aiRequestDuration.WithLabelValues(provider).Observe(time.Since(start).Seconds())
+}
+
+// recordNonReadyPods replaces the non-ready pod series of a PodSleuth with its current findings
+func recordNonReadyPods(podSleuthName string, pods []infrav1alpha1.NonReadyPodInfo) {
+	nonReadyPodsGauge.DeletePartialMatch(prometheus.Labels{"podsleuth": podSleuthName})
+	for i := range pods {
+		reason := pods[i].Reason
+		if reason == "" {
+			reason = "Unknown"
+		}
+		nonReadyPodsGauge.WithLabelValues(podSleuthName, pods[i].Namespace, reason, PodSeverity(&pods[i])).Inc()
+	}
+}
+
+// forgetPodSleuthMetrics removes the series of a deleted PodSleuth
+func forgetPodSleuthMetrics(podSleuthName string) {
+	nonReadyPodsGauge.DeletePartialMatch(prometheus.Labels{"podsleuth": podSleuthName})
+	reconcileDuration.DeleteLabelValues(podSleuthName)
+	remediationsTotal.DeletePartialMatch(prometheus.Labels{"podsleuth": podSleuthName})
+	remediationLockedOut.DeleteLabelValues(podSleuthName)
+	recordSLOMetrics(podSleuthName, nil)
+	recordWastedCapacity(podSleuthName, nil, time.Time{})
+	recordAIQuotaUsage(podSleuthName, nil)
+}
+
+// criticalPodReasons are failures that need attention regardless of how long the pod has existed
+var criticalPodReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"OOMKilled":                  true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"InvalidImageName":           true,
+	"Error":                      true,
+}
+
+// PodSeverity classifies a non-ready pod for alerting. Suppressed, silenced and
+// acknowledged pods are informational so alerts can exclude them with severity!="info".
+func PodSeverity(pod *infrav1alpha1.NonReadyPodInfo) string {
+	if IsMuted(pod) {
+		return severityInfo
+	}
+	if pod.Phase == "Failed" || criticalPodReasons[pod.Reason] {
+		return severityCritical
+	}
+	for _, ce := range pod.ContainerErrors {
+		if criticalPodReasons[ce.Reason] {
+			return severityCritical
+		}
+	}
+	return severityWarning
+}
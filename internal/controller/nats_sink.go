@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultNATSTimeout = 10 * time.Second
+	defaultNATSPort    = "4222"
+)
+
+// natsServerInfo is the part of the server's INFO message the publisher needs
+type natsServerInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+// natsConnectOptions is the CONNECT message of the NATS client protocol
+type natsConnectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	AuthToken   string `json:"auth_token,omitempty"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+}
+
+// publishNATS publishes events as structured CloudEvents to "<subject>.<type>" using the
+// NATS client protocol, and flushes with PING so server errors are reported
+func (r *PodSleuthReconciler) publishNATS(ctx context.Context, sink *infrav1alpha1.NATSSink, source string, events []sleuthEvent) error {
+	serverURL, err := url.Parse(sink.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	host := serverURL.Hostname()
+	addr := serverURL.Host
+	if serverURL.Port() == "" {
+		addr = net.JoinHostPort(host, defaultNATSPort)
+	}
+	timeout := defaultNATSTimeout
+	if sink.Timeout != nil && sink.Timeout.Duration > 0 {
+		timeout = sink.Timeout.Duration
+	}
+
+	options := natsConnectOptions{Name: kafkaClientID, Lang: "go", Version: "1.0.0", User: sink.Username}
+	if sink.TokenSecretRef != nil {
+		if options.AuthToken, err = r.notificationSecret(ctx, sink.TokenSecretRef); err != nil {
+			return fmt.Errorf("failed to get token: %w", err)
+		}
+	}
+	if sink.PasswordSecretRef != nil {
+		if options.Pass, err = r.notificationSecret(ctx, sink.PasswordSecretRef); err != nil {
+			return fmt.Errorf("failed to get password: %w", err)
+		}
+	}
+	tlsConfig := applyOutboundTLSPolicy(&tls.Config{})
+	if sink.TLS != nil {
+		if tlsConfig, err = r.streamTLSConfig(ctx, sink.TLS); err != nil {
+			return err
+		}
+	}
+	tlsConfig.ServerName = host
+
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer func() { conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read server info: %w", err)
+	}
+	infoJSON, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("invalid server info: %w", err)
+	}
+
+	if info.TLSRequired || sink.TLS != nil || serverURL.Scheme == "tls" {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+		options.TLSRequired = true
+	}
+
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	var buf strings.Builder
+	buf.WriteString("CONNECT " + string(connect) + "\r\n")
+	for _, event := range events {
+		payload, err := json.Marshal(newCloudEvent(source, event))
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		if info.MaxPayload > 0 && int64(len(payload)) > info.MaxPayload {
+			return fmt.Errorf("event of %s/%s exceeds the server max payload of %d bytes", event.Namespace, event.Pod, info.MaxPayload)
+		}
+		fmt.Fprintf(&buf, "PUB %s.%s %d\r\n", sink.Subject, event.Type, len(payload))
+		buf.Write(payload)
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("PING\r\n")
+	if _, err := conn.Write([]byte(buf.String())); err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+
+	// Wait for PONG; authorization or parser errors arrive as -ERR before it
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read server response: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case line == "PING":
+			if _, err := conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		}
+	}
+}
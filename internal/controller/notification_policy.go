@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Notification policy grouping modes
+const (
+	groupByOwner     = "owner"
+	groupByNamespace = "namespace"
+	groupByIncident  = "incident"
+	groupByNone      = "none"
+)
+
+// defaultNotificationCooldown is the minimum time between notifications of one group
+const defaultNotificationCooldown = 5 * time.Minute
+
+// notificationGroup tracks a group of pods of one policy across reconciles
+type notificationGroup struct {
+	// pending are pods detected since the group was last notified
+	pending []infrav1alpha1.NonReadyPodInfo
+	// notified are pods already notified, by namespace/name, kept up to date until the
+	// group is resolved so the resolved notification carries their final root cause
+	notified map[string]infrav1alpha1.NonReadyPodInfo
+	lastSent time.Time
+}
+
+// policyMatches reports whether a pod is handled by a notification policy
+func policyMatches(policy *infrav1alpha1.NotificationPolicy, pod *infrav1alpha1.NonReadyPodInfo) bool {
+	if len(policy.Severities) > 0 && !slices.Contains(policy.Severities, PodSeverity(pod)) {
+		return false
+	}
+	if len(policy.Namespaces) > 0 && !slices.Contains(policy.Namespaces, pod.Namespace) {
+		return false
+	}
+	if len(policy.Teams) > 0 && !slices.Contains(policy.Teams, pod.Team) {
+		return false
+	}
+	return true
+}
+
+// matchingPolicy returns the first policy handling a pod, or nil
+func matchingPolicy(policies []infrav1alpha1.NotificationPolicy, pod *infrav1alpha1.NonReadyPodInfo) *infrav1alpha1.NotificationPolicy {
+	for i := range policies {
+		if policyMatches(&policies[i], pod) {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// notificationGroupKey returns the key grouping a pod under a policy
+func notificationGroupKey(groupBy string, pod *infrav1alpha1.NonReadyPodInfo) string {
+	switch groupBy {
+	case groupByNamespace:
+		return pod.Namespace
+	case groupByIncident:
+		// Correlate pods failing the same way, across workloads and namespaces
+		pattern := ""
+		if pod.LogAnalysis != nil {
+			pattern = pod.LogAnalysis.MatchedPattern
+			if pod.LogAnalysis.PatternResult != nil && pod.LogAnalysis.PatternResult.MatchedPattern != "" {
+				pattern = pod.LogAnalysis.PatternResult.MatchedPattern
+			}
+		}
+		return pod.Reason + "|" + pattern
+	case groupByNone:
+		return pod.Namespace + "/" + pod.Name
+	default:
+		if pod.OwnerKind == "" {
+			return pod.Namespace + "/" + pod.Name
+		}
+		return pod.Namespace + "/" + pod.OwnerKind + "/" + pod.OwnerName
+	}
+}
+
+// routeNotifications applies the notification policies of a PodSleuth. Detected pods are
+// added to their policy group; a group is notified once its cooldown has passed, and
+// re-notified every repeat interval while it still has non-ready pods. Once all notified
+// pods of a group are ready again, a resolved notification is sent right away if
+// sendResolved is set. It also returns when the next held notification becomes due, or
+// zero if none is held.
+func (r *PodSleuthReconciler) routeNotifications(podSleuthName string, policies []infrav1alpha1.NotificationPolicy, detected, current []infrav1alpha1.NonReadyPodInfo, sendResolved bool, now time.Time) ([]notification, time.Time) {
+	r.notificationGroupsMux.Lock()
+	defer r.notificationGroupsMux.Unlock()
+	if r.notificationGroups == nil {
+		r.notificationGroups = make(map[string]*notificationGroup)
+	}
+
+	groupKey := func(policy *infrav1alpha1.NotificationPolicy, pod *infrav1alpha1.NonReadyPodInfo) string {
+		return podSleuthName + "/" + policy.Name + "/" + notificationGroupKey(policy.GroupBy, pod)
+	}
+	policyByName := make(map[string]*infrav1alpha1.NotificationPolicy, len(policies))
+	for i := range policies {
+		policyByName[policies[i].Name] = &policies[i]
+	}
+
+	for i := range detected {
+		policy := matchingPolicy(policies, &detected[i])
+		if policy == nil {
+			continue
+		}
+		key := groupKey(policy, &detected[i])
+		group, exists := r.notificationGroups[key]
+		if !exists {
+			group = &notificationGroup{}
+			r.notificationGroups[key] = group
+		}
+		group.pending = append(group.pending, detected[i])
+	}
+
+	// Pods still non-ready per group, for repeats and to expire resolved groups
+	active := make(map[string][]infrav1alpha1.NonReadyPodInfo)
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) {
+			continue
+		}
+		if policy := matchingPolicy(policies, pod); policy != nil {
+			key := groupKey(policy, pod)
+			active[key] = append(active[key], *pod)
+		}
+	}
+
+	var notifications []notification
+	var nextDue time.Time
+	prefix := podSleuthName + "/"
+	for key, group := range r.notificationGroups {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		policyName, groupName, _ := strings.Cut(strings.TrimPrefix(key, prefix), "/")
+		policy := policyByName[policyName]
+		if policy == nil {
+			delete(r.notificationGroups, key)
+			continue
+		}
+		cooldown := defaultNotificationCooldown
+		if policy.Cooldown != nil {
+			cooldown = policy.Cooldown.Duration
+		}
+
+		// Pods that recovered while held by the cooldown are not notified
+		group.pending = slices.DeleteFunc(group.pending, func(pod infrav1alpha1.NonReadyPodInfo) bool {
+			return !slices.ContainsFunc(active[key], func(a infrav1alpha1.NonReadyPodInfo) bool {
+				return a.Namespace == pod.Namespace && a.Name == pod.Name
+			})
+		})
+		for _, pod := range active[key] {
+			if _, ok := group.notified[pod.Namespace+"/"+pod.Name]; ok {
+				group.notified[pod.Namespace+"/"+pod.Name] = pod
+			}
+		}
+
+		n := notification{
+			PodSleuth:   podSleuthName,
+			Policy:      policy.Name,
+			Group:       groupName,
+			IncidentKey: key,
+			Timestamp:   now,
+			sinks:       policy.Sinks,
+		}
+		if len(active[key]) == 0 {
+			if len(group.notified) > 0 && sendResolved {
+				n.Type = notificationResolved
+				for _, pod := range group.notified {
+					n.Pods = append(n.Pods, pod)
+				}
+				sortPods(n.Pods)
+				n.Pod = n.Pods[0]
+				n.setDowntime()
+				group.lastSent = now
+				notifications = append(notifications, n)
+			}
+			group.notified = nil
+
+			// Keep resolved groups until their cooldown has passed so flapping pods
+			// do not notify again immediately
+			if now.Sub(group.lastSent) >= cooldown {
+				delete(r.notificationGroups, key)
+			}
+			continue
+		}
+
+		switch {
+		case len(group.pending) > 0 && now.Sub(group.lastSent) >= cooldown:
+			n.Type = transitionDetected
+			n.Pods = group.pending
+			group.pending = nil
+		case len(group.pending) > 0:
+			// Hold new pods until the cooldown has passed
+			if due := group.lastSent.Add(cooldown); nextDue.IsZero() || due.Before(nextDue) {
+				nextDue = due
+			}
+			continue
+		case policy.RepeatInterval != nil && policy.RepeatInterval.Duration > 0 && now.Sub(group.lastSent) >= policy.RepeatInterval.Duration:
+			n.Type = notificationRepeat
+			n.Pods = active[key]
+		default:
+			continue
+		}
+		sortPods(n.Pods)
+		n.Pod = n.Pods[0]
+		n.ActivePods = len(active[key])
+		if group.notified == nil {
+			group.notified = make(map[string]infrav1alpha1.NonReadyPodInfo)
+		}
+		for _, pod := range n.Pods {
+			group.notified[pod.Namespace+"/"+pod.Name] = pod
+		}
+		group.lastSent = now
+		notifications = append(notifications, n)
+	}
+
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].Policy+"/"+notifications[i].Group < notifications[j].Policy+"/"+notifications[j].Group
+	})
+	return notifications, nextDue
+}
+
+// sortPods orders pods by namespace and name
+func sortPods(pods []infrav1alpha1.NonReadyPodInfo) {
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Namespace+"/"+pods[i].Name < pods[j].Namespace+"/"+pods[j].Name
+	})
+}
+
+// forgetNotificationGroups drops the notification groups of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetNotificationGroups(podSleuthName string) {
+	r.notificationGroupsMux.Lock()
+	defer r.notificationGroupsMux.Unlock()
+	for key := range r.notificationGroups {
+		if strings.HasPrefix(key, podSleuthName+"/") {
+			delete(r.notificationGroups, key)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// notificationDeliveryTimeout bounds the delivery of one reconcile's notifications,
+// including retries, to all sinks
+const notificationDeliveryTimeout = 5 * time.Minute
+
+// defaultSecretNamespace is used for notification Secrets when the operator namespace is unknown
+const defaultSecretNamespace = "default"
+
+// Notification types besides detected
+const (
+	// notificationRepeat is re-sent for pods that are still non-ready
+	notificationRepeat = "repeat"
+	// notificationResolved is sent when notified pods are ready again or gone
+	notificationResolved = "resolved"
+	// notificationProposal proposes a remediation for a GitOps-managed workload
+	notificationProposal = "proposal"
+	// notificationNodeCordoned asks for follow-up on a node cordoned by remediation
+	notificationNodeCordoned = "node-cordoned"
+)
+
+// notification describes a change in a PodSleuth's findings sent to notification sinks
+type notification struct {
+	// Type is the kind of change: "detected", "repeat", "resolved", "proposal",
+	// "node-cordoned" or "deploy"
+	Type      string `json:"type"`
+	PodSleuth string `json:"podSleuth"`
+	// Cluster is the name of the operator's cluster, if set
+	Cluster string `json:"cluster,omitempty"`
+	// Policy and Group identify the notification policy group, if policies are configured
+	Policy string `json:"policy,omitempty"`
+	Group  string `json:"group,omitempty"`
+	// IncidentKey is the same for the detected, repeat and resolved notifications of a
+	// pod or policy group, e.g. to deduplicate or close incidents. It starts with the
+	// cluster name, if set.
+	IncidentKey string `json:"incidentKey"`
+	// Pod is the first of Pods, kept for simple payload templates
+	Pod  infrav1alpha1.NonReadyPodInfo   `json:"pod"`
+	Pods []infrav1alpha1.NonReadyPodInfo `json:"pods"`
+	// ActivePods is the number of pods of the group that are still non-ready
+	ActivePods int `json:"activePods"`
+	// Downtime is how long the pods were non-ready, set on resolved notifications
+	Downtime        string    `json:"downtime,omitempty"`
+	DowntimeSeconds int64     `json:"downtimeSeconds,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+	// Proposal is the change proposed by a proposal notification
+	Proposal *remediationProposal `json:"proposal,omitempty"`
+	// Node is the node cordoned by a node-cordoned notification
+	Node *cordonedNode `json:"node,omitempty"`
+	// Deploy is the verdict of a deploy notification
+	Deploy *DeployVerdict `json:"deploy,omitempty"`
+
+	// sinks restricts delivery to these sink names (nil = all sinks)
+	sinks []string
+}
+
+// deliversTo reports whether a notification is sent to a sink
+func (n *notification) deliversTo(sinkName string) bool {
+	return len(n.sinks) == 0 || slices.Contains(n.sinks, sinkName)
+}
+
+// sendNotifications delivers notifications for newly detected pods to the configured
+// sinks. Delivery runs in the background so slow sinks do not block reconciles.
+// It returns when held notifications become due, or zero if none are held.
+func (r *PodSleuthReconciler) sendNotifications(podSleuth *infrav1alpha1.PodSleuth, transitions []podTransition, current []infrav1alpha1.NonReadyPodInfo) time.Time {
+	config := podSleuth.Spec.Notifications
+	if config == nil {
+		return time.Time{}
+	}
+
+	now := time.Now()
+	sendResolved := config.SendResolved == nil || *config.SendResolved
+	var detected []infrav1alpha1.NonReadyPodInfo
+	for _, transition := range transitions {
+		if transition.Kind == transitionDetected {
+			detected = append(detected, transition.Pod)
+		}
+	}
+
+	var notifications []notification
+	var nextDue time.Time
+	if len(config.Policies) > 0 {
+		notifications, nextDue = r.routeNotifications(podSleuth.Name, config.Policies, detected, current, sendResolved, now)
+	} else {
+		for _, transition := range transitions {
+			pod := transition.Pod
+			n := notification{
+				Type:        transition.Kind,
+				PodSleuth:   podSleuth.Name,
+				IncidentKey: podSleuth.Name + "/" + pod.Namespace + "/" + pod.Name,
+				Pod:         pod,
+				Pods:        []infrav1alpha1.NonReadyPodInfo{pod},
+				ActivePods:  1,
+				Timestamp:   now,
+			}
+			switch {
+			case transition.Kind == transitionDetected:
+			case transition.Kind == transitionRecovered && sendResolved:
+				n.Type = notificationResolved
+				n.ActivePods = 0
+				n.setDowntime()
+			default:
+				continue
+			}
+			notifications = append(notifications, n)
+		}
+	}
+
+	r.dispatchNotifications(podSleuth.Name, config, notifications)
+	return nextDue
+}
+
+// dispatchNotifications delivers notifications to webhooks and email sinks in the
+// background and adds them to email digests. Digest sinks are collected on every
+// reconcile so due digests go out even when nothing new was detected.
+func (r *PodSleuthReconciler) dispatchNotifications(podSleuthName string, config *infrav1alpha1.NotificationsConfig, notifications []notification) {
+	// Incidents of PodSleuths of the same name in several clusters are kept apart
+	if cluster := ClusterName(); cluster != "" {
+		for i := range notifications {
+			notifications[i].Cluster = cluster
+			notifications[i].IncidentKey = cluster + "/" + notifications[i].IncidentKey
+		}
+	}
+	emails := r.collectEmailDigests(podSleuthName, config.Email, notifications)
+	for _, sink := range config.Email {
+		if sink.DigestInterval != nil && sink.DigestInterval.Duration > 0 {
+			continue
+		}
+		if batch := newEmailBatch(sink, podSleuthName, notifications, false); batch != nil {
+			emails = append(emails, *batch)
+		}
+	}
+	if len(notifications) == 0 && len(emails) == 0 {
+		return
+	}
+
+	go r.deliverNotifications(config.DeepCopy(), notifications, emails)
+}
+
+// setDowntime sets the downtime of a resolved notification from the earliest detection
+// of its pods
+func (n *notification) setDowntime() {
+	var earliest time.Time
+	for _, pod := range n.Pods {
+		if pod.DetectedAt != nil && (earliest.IsZero() || pod.DetectedAt.Time.Before(earliest)) {
+			earliest = pod.DetectedAt.Time
+		}
+	}
+	if earliest.IsZero() {
+		return
+	}
+	downtime := n.Timestamp.Sub(earliest).Round(time.Second)
+	n.Downtime = downtime.String()
+	n.DowntimeSeconds = int64(downtime.Seconds())
+}
+
+// deliverNotifications sends each notification to its webhooks and each email batch
+func (r *PodSleuthReconciler) deliverNotifications(config *infrav1alpha1.NotificationsConfig, notifications []notification, emails []emailBatch) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+	defer cancel()
+	logger := log.Log.WithName("notifications")
+
+	for i := range config.Webhooks {
+		sink := &config.Webhooks[i]
+		for _, n := range notifications {
+			if !n.deliversTo(sink.Name) {
+				continue
+			}
+			err := r.sendWebhook(ctx, sink, n)
+			notificationsSent.WithLabelValues("webhook", sink.Name, outcomeOf(err)).Inc()
+			if err != nil {
+				logger.Info("webhook notification failed", "sink", sink.Name, "pod", n.Pod.Name, "namespace", n.Pod.Namespace, "pods", len(n.Pods), "error", err)
+			}
+		}
+	}
+
+	for _, batch := range emails {
+		err := r.sendEmail(ctx, batch)
+		notificationsSent.WithLabelValues("email", batch.Sink.Name, outcomeOf(err)).Inc()
+		if err != nil {
+			logger.Info("email notification failed", "sink", batch.Sink.Name, "notifications", len(batch.Notifications), "digest", batch.Digest, "error", err)
+		}
+	}
+}
+
+// notificationSecret reads a Secret key referenced by a notification sink from the
+// operator's namespace
+func (r *PodSleuthReconciler) notificationSecret(ctx context.Context, ref *corev1.SecretKeySelector) (string, error) {
+	namespace := r.OperatorNamespace
+	if namespace == "" {
+		namespace = defaultSecretNamespace
+	}
+	return getAPIKeyFromSecret(ctx, r.Client, ref, namespace)
+}
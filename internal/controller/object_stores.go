@@ -0,0 +1,323 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	azureAPIVersion = "2021-08-06"
+)
+
+// storedObject is an object listed in object storage
+type storedObject struct {
+	Key          string
+	LastModified time.Time
+}
+
+// objectStore writes, lists and deletes objects in a bucket or container
+type objectStore interface {
+	name() string
+	put(ctx context.Context, key, contentType string, data []byte) error
+	list(ctx context.Context, prefix string) ([]storedObject, error)
+	delete(ctx context.Context, key string) error
+}
+
+// s3Store speaks the S3 REST API with AWS Signature Version 4. It also serves Google
+// Cloud Storage through its S3-compatible XML API.
+type s3Store struct {
+	storeName string
+	// bucketURL addresses the bucket, virtual-hosted or path-style
+	bucketURL       *url.URL
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// newS3Store builds the bucket URL of an S3 or S3-compatible bucket
+func newS3Store(storeName, endpoint, region, bucket string, pathStyle bool, accessKeyID, secretAccessKey string) (*s3Store, error) {
+	if region == "" {
+		region = defaultS3Region
+	}
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	bucketURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if pathStyle {
+		bucketURL.Path += "/" + bucket
+	} else {
+		bucketURL.Host = bucket + "." + bucketURL.Host
+	}
+	return &s3Store{
+		storeName:       storeName,
+		bucketURL:       bucketURL,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+	}, nil
+}
+
+func (s *s3Store) name() string { return s.storeName }
+
+func (s *s3Store) put(ctx context.Context, key, contentType string, data []byte) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	_, err := s.do(ctx, http.MethodPut, key, nil, headers, data)
+	return err
+}
+
+func (s *s3Store) list(ctx context.Context, prefix string) ([]storedObject, error) {
+	var objects []storedObject
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		body, err := s.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("invalid list response: %w", err)
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, storedObject{Key: content.Key, LastModified: content.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (s *s3Store) delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	return err
+}
+
+// do sends a signed request for an object key, or the bucket if key is empty
+func (s *s3Store) do(ctx context.Context, method, key string, query url.Values, headers http.Header, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+
+	target := *s.bucketURL
+	target.Path += "/" + key
+	target.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, target.Path, resp.StatusCode, truncateString(string(respBody), 1024))
+	}
+	return respBody, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to a request
+func (s *s3Store) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	signed := []string{"host"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n"
+	var names []string
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		signed = append(signed, name)
+		canonicalHeaders += name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n"
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	mac := func(key []byte, data string) []byte {
+		m := hmac.New(sha256.New, key)
+		m.Write([]byte(data))
+		return m.Sum(nil)
+	}
+	signingKey := mac(mac(mac(mac([]byte("AWS4"+s.secretAccessKey), date), s.region), "s3"), "aws4_request")
+	signature := hex.EncodeToString(mac(signingKey, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query parameters sorted and with %20 for spaces, as required
+// by Signature Version 4
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, strings.ReplaceAll(url.QueryEscape(key), "+", "%20")+"="+strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// azureBlobStore speaks the Azure Blob Storage REST API with a SAS token
+type azureBlobStore struct {
+	containerURL string
+	sasToken     url.Values
+}
+
+func newAzureBlobStore(containerURL, sasToken string) (*azureBlobStore, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(sasToken), "?"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAS token: %w", err)
+	}
+	return &azureBlobStore{containerURL: strings.TrimSuffix(containerURL, "/"), sasToken: values}, nil
+}
+
+func (s *azureBlobStore) name() string { return "azureBlob" }
+
+func (s *azureBlobStore) put(ctx context.Context, key, contentType string, data []byte) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("x-ms-blob-type", "BlockBlob")
+	_, err := s.do(ctx, http.MethodPut, "/"+key, nil, headers, data)
+	return err
+}
+
+func (s *azureBlobStore) list(ctx context.Context, prefix string) ([]storedObject, error) {
+	var objects []storedObject
+	query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {prefix}}
+	for {
+		body, err := s.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Blobs []struct {
+				Name         string `xml:"Name"`
+				LastModified string `xml:"Properties>Last-Modified"`
+			} `xml:"Blobs>Blob"`
+			NextMarker string `xml:"NextMarker"`
+		}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("invalid list response: %w", err)
+		}
+		for _, blob := range result.Blobs {
+			lastModified, _ := time.Parse(time.RFC1123, blob.LastModified)
+			objects = append(objects, storedObject{Key: blob.Name, LastModified: lastModified})
+		}
+		if result.NextMarker == "" {
+			return objects, nil
+		}
+		query.Set("marker", result.NextMarker)
+	}
+}
+
+func (s *azureBlobStore) delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, "/"+key, nil, nil, nil)
+	return err
+}
+
+// do sends a request authorized by the SAS token
+func (s *azureBlobStore) do(ctx context.Context, method, path string, query url.Values, headers http.Header, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+
+	values := url.Values{}
+	for key, value := range s.sasToken {
+		values[key] = value
+	}
+	for key, value := range query {
+		values[key] = value
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.containerURL+path+"?"+values.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header[name] = value
+	}
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, req.URL.Path, resp.StatusCode, truncateString(string(respBody), 1024))
+	}
+	return respBody, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// errOffline is returned for cluster reads during offline analyses without a cluster
+var errOffline = errors.New("no cluster connection")
+
+// offlineClient fails the reads log analysis makes, for API keys in secrets, mounted
+// certificates and metrics credentials, when there is no cluster to read from
+type offlineClient struct {
+	client.Client
+}
+
+func (offlineClient) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return errOffline
+}
+
+func (offlineClient) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return errOffline
+}
+
+// OfflineAnalysis is the analysis of a saved log
+type OfflineAnalysis struct {
+	// LinesRead is the number of lines in the log
+	LinesRead int `json:"linesRead"`
+	// Lines are the lines the methods analyzed: the tail of the log, filtered and
+	// redacted like the logs of pods
+	Lines []string `json:"lines"`
+	// Result is nil if no line was left to analyze
+	Result *infrav1alpha1.LogAnalysisResult `json:"result,omitempty"`
+}
+
+// AnalyzeLog runs the analysis pipeline configured by config on a saved log, as if
+// pod had logged it, to reproduce the analysis of an incident. Log analysis runs even
+// if config does not enable it. Without a cluster, c may be nil: AI providers then need
+// no API key secret, and metrics and certificate checks report errors.
+func AnalyzeLog(ctx context.Context, c client.Client, pod *corev1.Pod, config *infrav1alpha1.LogAnalysisConfig, logs io.Reader) (*OfflineAnalysis, error) {
+	if config == nil {
+		config = &infrav1alpha1.LogAnalysisConfig{}
+	}
+	if c == nil {
+		c = offlineClient{}
+	}
+
+	maxLineLength := defaultMaxLineLength
+	if config.MaxLineLength != nil {
+		maxLineLength = int(*config.MaxLineLength)
+	}
+	lines, read, _, err := readLogLines(logs, maxLineLength, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	// Like the tail of a pod log, which is filtered after it is read
+	linesToAnalyze := 100
+	if config.LinesToAnalyze != nil {
+		linesToAnalyze = int(*config.LinesToAnalyze)
+	}
+	lines = lines[max(len(lines)-linesToAnalyze, 0):]
+	if config.FilterErrorsOnly == nil || *config.FilterErrorsOnly {
+		var errorLines []string
+		for _, line := range lines {
+			if isErrorLine(line) {
+				errorLines = append(errorLines, line)
+			}
+		}
+		lines = errorLines
+	}
+
+	redactor, err := newRedactor(config.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure redaction: %w", err)
+	}
+	analysis := &OfflineAnalysis{LinesRead: read, Lines: redactor.redactLines(lines)}
+	if analysis.Lines == nil {
+		analysis.Lines = []string{}
+	}
+	if len(analysis.Lines) > 0 {
+		analysis.Result = analyzeLogLines(ctx, c, pod, config, analysis.Lines, &aiRequestOptions{})
+	}
+	return analysis, nil
+}
+
+// OfflinePod returns the pod saved logs are analyzed as, which AI prompts name
+func OfflinePod(namespace, name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
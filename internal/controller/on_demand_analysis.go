@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ErrNoAnalyzingPodSleuth is returned when no PodSleuth with log analysis enabled
+// selects a pod to analyze on demand
+var ErrNoAnalyzingPodSleuth = errors.New("no PodSleuth with log analysis enabled selects the pod")
+
+// PodAnalysisOnDemand is the analysis of a pod requested by a user
+type PodAnalysisOnDemand struct {
+	// PodSleuth is the PodSleuth whose log analysis configuration was used
+	PodSleuth string
+	// Ready is whether the pod was ready when analyzed
+	Ready bool
+	// Result is nil if the pod had no log output
+	Result *infrav1alpha1.LogAnalysisResult
+}
+
+// AnalyzePod analyzes the logs of any pod now, ready or not, with the log analysis
+// configuration of podSleuth, or of the first PodSleuth selecting the pod if empty. The
+// analysis bypasses the workers and is neither cached nor written to status, since
+// ready pods have no entry there.
+func (r *PodSleuthReconciler) AnalyzePod(ctx context.Context, namespace, name, podSleuth string) (*PodAnalysisOnDemand, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pod); err != nil {
+		return nil, err
+	}
+
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := r.List(ctx, &podSleuthList); err != nil {
+		return nil, err
+	}
+	var analyzing *infrav1alpha1.PodSleuth
+	for i := range podSleuthList.Items {
+		ps := &podSleuthList.Items[i]
+		if podSleuth != "" && ps.Name != podSleuth {
+			continue
+		}
+		if ps.Spec.LogAnalysis == nil || !ps.Spec.LogAnalysis.Enabled {
+			continue
+		}
+		if ps.Spec.PodLabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(ps.Spec.PodLabelSelector)
+			if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+		}
+		analyzing = ps
+		break
+	}
+	if analyzing == nil {
+		return nil, fmt.Errorf("%s/%s: %w", namespace, name, ErrNoAnalyzingPodSleuth)
+	}
+
+	log.Log.Info("analyzing pod on demand", "pod", name, "namespace", namespace, "podSleuth", analyzing.Name)
+	if r.AnalysisTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.AnalysisTimeout)
+		defer cancel()
+	}
+	// On-demand analyses count against the AI limits and quota like any other
+	aiOpts := &aiRequestOptions{
+		Limiters: []*AIRateLimiter{r.AIRateLimiter, r.getAIRateLimiter(analyzing)},
+		Quota:    r.getAIQuota(analyzing),
+		Batch:    newAIBatch(),
+	}
+	if err := r.LogFetchLimiter.Wait(ctx, pod.Spec.NodeName); err != nil {
+		return nil, err
+	}
+	analysisStart := time.Now()
+	result, err := analyzeLogs(ctx, r.Client, r.K8sClient, &pod, analyzing.Spec.LogAnalysis, aiOpts)
+	logAnalysisDuration.WithLabelValues(outcomeOf(err)).Observe(time.Since(analysisStart).Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return &PodAnalysisOnDemand{PodSleuth: analyzing.Name, Ready: isPodReady(&pod), Result: result}, nil
+}
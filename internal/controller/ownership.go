@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ownershipMatcher is an ownership rule with its label selector parsed
+type ownershipMatcher struct {
+	team       string
+	namespaces []string
+	selector   labels.Selector
+}
+
+// compileOwnershipRules parses the ownership rules of a PodSleuth. Rules without
+// a matcher or with an invalid selector are reported as errors and otherwise ignored.
+func compileOwnershipRules(rules []infrav1alpha1.OwnershipRule) ([]ownershipMatcher, []error) {
+	var matchers []ownershipMatcher
+	var errs []error
+	for i, rule := range rules {
+		if len(rule.Namespaces) == 0 && rule.PodSelector == nil {
+			errs = append(errs, fmt.Errorf("ownership rule %d (%s): namespaces or podSelector is required", i, rule.Team))
+			continue
+		}
+		matcher := ownershipMatcher{team: rule.Team, namespaces: rule.Namespaces}
+		if rule.PodSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.PodSelector)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("ownership rule %d (%s): %w", i, rule.Team, err))
+				continue
+			}
+			matcher.selector = selector
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, errs
+}
+
+// teamForPod returns the team of the first ownership rule matching a pod
+func teamForPod(matchers []ownershipMatcher, pod *corev1.Pod) string {
+	for _, matcher := range matchers {
+		if len(matcher.namespaces) > 0 && !slices.Contains(matcher.namespaces, pod.Namespace) {
+			continue
+		}
+		if matcher.selector != nil && !matcher.selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		return matcher.team
+	}
+	return ""
+}
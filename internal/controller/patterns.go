@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+var (
+	// configuredPatterns replace the built-in patterns if set
+	configuredPatterns    []DefaultPattern
+	configuredPatternsMux sync.RWMutex
+)
+
+// SetDefaultPatterns replaces the built-in error patterns used while a PodSleuth has
+// none of its own, or restores them if patterns is empty. Nothing changes if a pattern
+// does not compile. Cached analyses keep their root causes until they expire.
+func SetDefaultPatterns(patterns []infrav1alpha1.ErrorPattern) error {
+	var compiled []DefaultPattern
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern %q: %w", pattern.Name, err)
+		}
+		compiled = append(compiled, DefaultPattern{
+			Name:      pattern.Name,
+			Pattern:   regex,
+			RootCause: pattern.RootCause,
+			Priority:  pattern.Priority,
+		})
+	}
+	configuredPatternsMux.Lock()
+	defer configuredPatternsMux.Unlock()
+	configuredPatterns = compiled
+	return nil
+}
+
+// getDefaultPatterns returns the configured default patterns, or the built-in ones
+func getDefaultPatterns() []DefaultPattern {
+	configuredPatternsMux.RLock()
+	defer configuredPatternsMux.RUnlock()
+	if len(configuredPatterns) > 0 {
+		return configuredPatterns
+	}
+	return builtinPatterns()
+}
+
+// DefaultPatterns returns the default error patterns, used while a PodSleuth has none
+// of its own
+func DefaultPatterns() []infrav1alpha1.ErrorPattern {
+	var patterns []infrav1alpha1.ErrorPattern
+	for _, dp := range getDefaultPatterns() {
+		patterns = append(patterns, infrav1alpha1.ErrorPattern{
+			Name:      dp.Name,
+			Pattern:   dp.Pattern.String(),
+			RootCause: dp.RootCause,
+			Priority:  dp.Priority,
+		})
+	}
+	return patterns
+}
+
+// PatternsOf returns the custom patterns the pattern method of config matches, to be
+// edited in place: those of the first "pattern" method config, or the deprecated
+// patterns field without method configs. It returns nil if the pattern method does not
+// run.
+func PatternsOf(config *infrav1alpha1.LogAnalysisConfig) *[]infrav1alpha1.ErrorPattern {
+	if config == nil {
+		return nil
+	}
+	if len(config.MethodConfigs) > 0 {
+		for i := range config.MethodConfigs {
+			methodConfig := &config.MethodConfigs[i]
+			if methodConfig.Type != "pattern" {
+				continue
+			}
+			if methodConfig.PatternConfig == nil {
+				methodConfig.PatternConfig = &infrav1alpha1.PatternConfig{}
+			}
+			return &methodConfig.PatternConfig.Patterns
+		}
+		return nil
+	}
+	// Like analyzeLogs, running only the pattern method by default
+	if len(config.Methods) > 0 && !slices.Contains(config.Methods, "pattern") {
+		return nil
+	}
+	if len(config.Methods) == 0 && config.Method != "" && config.Method != "pattern" {
+		return nil
+	}
+	return &config.Patterns
+}
+
+// MatchPatterns analyzes log lines with patterns, or the default patterns if none,
+// like the pattern method does with the default confidence settings. Invalid patterns
+// are skipped. It returns nil without log lines.
+func MatchPatterns(patterns []infrav1alpha1.ErrorPattern, logLines []string) (*infrav1alpha1.LogAnalysisResult, error) {
+	return analyzeWithPatterns(logLines, patterns, getConfidenceSettings(nil))
+}
@@ -17,38 +17,44 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
+	"container/list"
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
 )
 
-// CachedAnalysisResult represents a cached log analysis result for a pod
-type CachedAnalysisResult struct {
-	PodUID       types.UID
-	PodNamespace string
-	PodName      string
-	RestartCount int32
-	Result       *infrav1alpha1.LogAnalysisResult
-	CachedAt     time.Time
-	ExpiresAt    time.Time
-}
+// podReadyField indexes cached pods by whether they are ready ("true" or "false")
+const podReadyField = ".status.ready"
 
 // PodSleuthReconciler reconciles a PodSleuth object
 type PodSleuthReconciler struct {
@@ -56,9 +62,130 @@ type PodSleuthReconciler struct {
 	Scheme    *runtime.Scheme
 	K8sClient kubernetes.Interface
 
-	// Cache for log analysis results
-	analysisCache    map[string]*CachedAnalysisResult
-	analysisCacheMux sync.RWMutex
+	// Recorder emits Events for detections and resolutions (nil = no events)
+	Recorder record.EventRecorder
+
+	// Cache for log analysis results, bounded with LRU eviction
+	analysisCache     map[string]*CachedAnalysisResult
+	analysisCacheLRU  *list.List
+	analysisCacheSize int64
+	analysisCacheMux  sync.RWMutex
+
+	// AnalysisCacheMaxEntries bounds the number of cached analyses (0 = unlimited)
+	AnalysisCacheMaxEntries int
+	// AnalysisCacheMaxBytes bounds the approximate cache size in bytes (0 = unlimited)
+	AnalysisCacheMaxBytes int64
+
+	// AnalysisWorkers is the number of concurrent log analyses run outside the reconcile
+	// loop (0 = analyze within the reconcile)
+	AnalysisWorkers int
+	// AnalysisTimeout bounds a single log analysis (0 = no limit)
+	AnalysisTimeout time.Duration
+
+	// Log analyses queued or running on the workers and the uncached outcomes of finished
+	// ones, keyed by analysis cache key
+	analysisQueue      workqueue.TypedInterface[string]
+	analysisPriorities *analysisPriorityQueue
+	analysisJobs       map[string]*analysisJob
+	analysisOutcomes   map[string]analysisOutcome
+	analysisJobsMux    sync.Mutex
+	// analysisFinished requests reconciles of PodSleuths whose analyses finished
+	analysisFinished chan event.GenericEvent
+
+	// LogFetchLimiter is the operator-wide and per-node limit on container log fetches
+	// (nil = unlimited)
+	LogFetchLimiter *LogFetchLimiter
+
+	// AIRateLimiter is the operator-wide limit on outbound AI requests (nil = unlimited)
+	AIRateLimiter *AIRateLimiter
+
+	// PodSleuths whose status has already been loaded into the analysis cache
+	cacheRestored map[string]bool
+
+	// Container terminations observed across reconciles, keyed by pod UID
+	crashHistory    map[types.UID]*podCrashHistory
+	crashHistoryMux sync.Mutex
+
+	// Container restart counts of the pods of each PodSleuth, keyed by PodSleuth and pod UID
+	restartCounts    map[string]map[types.UID]int32
+	restartCountsMux sync.Mutex
+
+	// Per-PodSleuth AI rate limiters, keyed by PodSleuth name
+	aiLimiters    map[string]*AIRateLimiter
+	aiLimitersMux sync.Mutex
+
+	// Per-PodSleuth AI quotas, keyed by PodSleuth name
+	aiQuotas    map[string]*aiQuota
+	aiQuotasMux sync.Mutex
+
+	// Connectivity probe results reused across reconciles, keyed by pod UID and target
+	connectivityCache    map[string]connectivityCacheEntry
+	connectivityCacheMux sync.Mutex
+
+	// OperatorNamespace is where Secrets referenced by notification sinks are read
+	OperatorNamespace string
+
+	// Notification policy groups, keyed by PodSleuth, policy and group
+	notificationGroups    map[string]*notificationGroup
+	notificationGroupsMux sync.Mutex
+
+	// Pending email digests, keyed by PodSleuth and sink name
+	emailDigests    map[string]*emailDigest
+	emailDigestsMux sync.Mutex
+
+	// Issue tracker synchronization, keyed by PodSleuth
+	issueSyncs    map[string]*issueSyncState
+	issueSyncsMux sync.Mutex
+
+	// Open Grafana region annotations, keyed by PodSleuth and workload
+	grafanaRegions    map[string]*grafanaRegion
+	grafanaRegionsMux sync.Mutex
+
+	// Snapshot exports to object storage, keyed by PodSleuth
+	snapshotExports    map[string]*snapshotExportState
+	snapshotExportsMux sync.Mutex
+
+	// Applications of workloads and the status reported on them, keyed by workload and
+	// by PodSleuth and application
+	gitOpsLookups map[string]gitOpsLookup
+	gitOpsApps    map[string]*gitOpsAppState
+	gitOpsMux     sync.Mutex
+
+	// Approved remediations already taken, keyed by PodSleuth and ID, so that a failed
+	// status update does not take them again
+	approvedRemediations    map[string]time.Time
+	approvedRemediationsMux sync.Mutex
+
+	// APIReader reads PodSleuths uncached when retrying status updates that conflicted
+	// with another shard (nil = read through Client)
+	APIReader client.Reader
+
+	// Remediations taken, keyed by PodSleuth, until its status shows them, so that a
+	// failed status update does not lose them and take the actions again
+	executedRemediations    map[string][]infrav1alpha1.RemediationRecord
+	executedRemediationsMux sync.Mutex
+
+	// RequeueBackoffBase and RequeueBackoffMax bound the exponential backoff of failed
+	// reconciles (0 = defaults)
+	RequeueBackoffBase time.Duration
+	RequeueBackoffMax  time.Duration
+
+	// PodEventDebounce delays reconciles triggered by pod events to coalesce bursts
+	// (0 = no delay)
+	PodEventDebounce time.Duration
+
+	// Sharding splits PodSleuths or namespaces between operator replicas
+	Sharding Sharding
+
+	// RemediationDryRun only records the remediations every PodSleuth would take
+	RemediationDryRun bool
+
+	// RemediationJobNamespaces are the namespaces RunJob remediations may create Jobs
+	// in besides the namespace of the failing pod
+	RemediationJobNamespaces []string
+	// RemediationJobServiceAccounts lets RunJob templates choose the ServiceAccount of
+	// their pods; otherwise they run as the default ServiceAccount of their namespace
+	RemediationJobServiceAccounts bool
 
 	OperatorStartTime time.Time
 }
@@ -66,12 +193,29 @@ type PodSleuthReconciler struct {
 // +kubebuilder:rbac:groups=apps.ops.dev,resources=podsleuths,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.ops.dev,resources=podsleuths/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.ops.dev,resources=podsleuths/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.ops.dev,resources=sleuthsilences,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps.ops.dev,resources=podsleuthreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=services;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;patch
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=list
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get
+// +kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get
+// +kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;patch
+// +kubebuilder:rbac:groups=kustomize.toolkit.fluxcd.io,resources=kustomizations,verbs=get;patch
+// +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -79,37 +223,64 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Create a simple logger without controller-runtime context to avoid verbose fields
 	logger := log.Log
 
+	reconcileStart := time.Now()
+	defer func() {
+		reconcileDuration.WithLabelValues(req.Name).Observe(time.Since(reconcileStart).Seconds())
+	}()
+
 	// Fetch the PodSleuth resource
 	var podSleuth infrav1alpha1.PodSleuth
 	if err := r.Get(ctx, req.NamespacedName, &podSleuth); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Drop cached analyses and metrics of the deleted PodSleuth
+			r.cleanupCache(req.Name, nil)
+			r.forgetAnalyses(req.Name, nil)
+			forgetPodSleuthMetrics(req.Name)
+			r.forgetEmailDigests(req.Name)
+			r.forgetNotificationGroups(req.Name)
+			r.forgetIssueSyncs(req.Name)
+			r.forgetGrafanaRegions(req.Name)
+			r.forgetSnapshotExports(req.Name)
+			r.forgetGitOpsApps(req.Name)
+			r.forgetRestartCounts(req.Name)
+			r.forgetAIQuota(req.Name)
+			r.forgetExecutedRemediations(req.Name)
+			return ctrl.Result{}, nil
+		}
 		logger.Error(err, "unable to fetch PodSleuth")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{}, err
+	}
+	if !r.Sharding.ownsPodSleuth(&podSleuth) {
+		// The PodSleuth moved to another shard
+		r.cleanupCache(req.Name, nil)
+		r.forgetAnalyses(req.Name, nil)
+		forgetPodSleuthMetrics(req.Name)
+		return ctrl.Result{}, nil
 	}
+	// Status changes are patched against the status read here
+	statusBase := podSleuth.DeepCopy()
+	// Logs are never sent to AI endpoints outside the operator's allowlist
+	enforceAIEndpointAllowlist(&podSleuth)
 
 	// Check for force-refresh annotations
-	globalForceRefresh := false
-	targetForcePod := ""
-	if podSleuth.Annotations != nil {
-		if _, exists := podSleuth.Annotations["kubesleuth.io/force-refresh"]; exists {
-			globalForceRefresh = true
-			logger.Info("force-refresh annotation detected - bypassing cache for all pods")
-		}
-		if v, exists := podSleuth.Annotations["kubesleuth.io/force-refresh-pod"]; exists {
-			targetForcePod = strings.TrimSpace(v)
-			logger.Info("force-refresh annotation detected for specific pod", "pod", targetForcePod)
-		}
+	refresh := forceRefreshOf(podSleuth.Annotations)
+	if refresh.all {
+		logger.Info("force-refresh annotation detected - bypassing cache for all pods", "generation", refresh.generation)
+	} else if refresh.requested() {
+		logger.Info("force-refresh annotation detected for specific pods", "pods", podSleuth.Annotations[AnnotationForceRefreshPods], "generation", refresh.generation)
 	}
 
-	// List all pods across all namespaces
+	// List non-ready pods across all namespaces from the cache's readiness index
 	var podList corev1.PodList
-	listOptions := []client.ListOption{}
+	listOptions := []client.ListOption{client.MatchingFields{podReadyField: "false"}}
 
 	// Apply pod label selector if specified
 	if podSleuth.Spec.PodLabelSelector != nil {
 		selector, err := metav1.LabelSelectorAsSelector(podSleuth.Spec.PodLabelSelector)
 		if err != nil {
+			// Retrying cannot fix the spec; editing it triggers a new reconcile
 			logger.Error(err, "invalid pod label selector")
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcile.TerminalError(err)
 		}
 		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
 	}
@@ -118,9 +289,77 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		logger.Error(err, "unable to list pods")
 		return ctrl.Result{}, err
 	}
+	if r.Sharding.byNamespace() {
+		podList.Items = slices.DeleteFunc(podList.Items, func(pod corev1.Pod) bool {
+			return !r.Sharding.ownsNamespace(pod.Namespace)
+		})
+	}
+
+	// After an operator restart, reuse unexpired analyses stored in status instead of
+	// re-analyzing every pod (and re-sending every pod to the AI provider)
+	r.restoreCacheFromStatus(&podSleuth)
+
+	// Results are cached per PodSleuth and per effective analysis configuration,
+	// so editing patterns or switching models invalidates cached analyses immediately
+	configHash := ""
+	if podSleuth.Spec.LogAnalysis != nil {
+		configHash = logAnalysisConfigHash(podSleuth.Spec.LogAnalysis)
+	}
+
+	// AI requests must pass both the operator-wide and the per-PodSleuth limits and
+	// quota, and identical failures share one AI request within this reconcile
+	aiQuota := r.getAIQuota(&podSleuth)
+	aiOpts := &aiRequestOptions{
+		Limiters: []*AIRateLimiter{r.AIRateLimiter, r.getAIRateLimiter(&podSleuth)},
+		Quota:    aiQuota,
+		Batch:    newAIBatch(),
+	}
+
+	// While analyses run on the workers, pods keep their stored analysis
+	var previousAnalyses map[string]*infrav1alpha1.LogAnalysisResult
+	if r.AnalysisWorkers > 0 {
+		previousAnalyses = storedAnalyses(podSleuth.Status.NonReadyPods)
+	}
+
+	crashLoop := getCrashLoopSettings(podSleuth.Spec.CrashLoopTrend)
+
+	// Pods in an active maintenance window are tracked but suppressed and analyzed without AI
+	activeWindows, windowErrs := activeMaintenanceWindows(podSleuth.Spec.MaintenanceWindows, time.Now())
+	for _, err := range windowErrs {
+		logger.Info("ignoring invalid maintenance window", "podsleuth", podSleuth.Name, "error", err)
+	}
+	var activeWindowNames []string
+	for _, window := range activeWindows {
+		activeWindowNames = append(activeWindowNames, window.Name)
+	}
+	suppressedLogAnalysis := withoutAIMethods(podSleuth.Spec.LogAnalysis)
+	suppressedConfigHash := ""
+	if suppressedLogAnalysis != nil {
+		suppressedConfigHash = logAnalysisConfigHash(suppressedLogAnalysis)
+	}
+
+	// Ownership rules stamp the owning team on each non-ready pod
+	ownershipRules, ruleErrs := compileOwnershipRules(podSleuth.Spec.OwnershipRules)
+	for _, err := range ruleErrs {
+		logger.Info("ignoring invalid ownership rule", "podsleuth", podSleuth.Name, "error", err)
+	}
+
+	// Acknowledged known issues are reported as silenced until their silence expires
+	now := time.Now()
+	silences, nextSilenceExpiry, err := r.activeSilences(ctx, now)
+	if err != nil {
+		// Without silences, silenced pods would be reported and notified as failures
+		logger.Error(err, "unable to list SleuthSilences")
+		return ctrl.Result{}, err
+	}
 
 	// Filter non-ready pods and collect information
 	var nonReadyPods []infrav1alpha1.NonReadyPodInfo
+	// Analyses for the workers are queued together once all pods are known, by priority
+	var analysisJobs []*analysisJob
+	var evictedPods []evictedPod
+	// The earliest expiry of a pod acknowledgement, when the pod is reported failing again
+	var nextAcknowledgementExpiry time.Time
 	for _, pod := range podList.Items {
 		// Check if pod is ready
 		isReady := false
@@ -141,20 +380,57 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// Get owner information
 		ownerKind, ownerName := r.getPodOwner(ctx, &pod)
 
+		// Evicted and shut down pods are reported per node instead of as individual failures
+		if isEvictedOrShutdown(&pod) {
+			evictedPods = append(evictedPods, evictedPod{
+				NodeName: pod.Spec.NodeName,
+				Info:     newEvictedPodInfo(&pod, ownerKind, ownerName),
+			})
+			continue
+		}
+
+		suppressedBy := suppressingWindow(activeWindows, pod.Namespace)
+		logAnalysisConfig, podConfigHash := podSleuth.Spec.LogAnalysis, configHash
+		if suppressedBy != "" {
+			logAnalysisConfig, podConfigHash = suppressedLogAnalysis, suppressedConfigHash
+		}
+
 		// Perform comprehensive investigation
 		reason, message, containerErrors, conditions := r.investigatePodFailure(&pod)
 
+		// Tell sidecar proxy failures apart from application failures
+		meshDiagnosis, meshReason, meshMessage := diagnoseMesh(&pod)
+		if meshReason != "" {
+			reason, message = meshReason, meshMessage
+		} else if meshMessage != "" {
+			message = strings.TrimSuffix(message, ".") + ". " + meshMessage
+		}
+
+		// Track terminations across restarts for crash-loop trends
+		if crashLoop.Enabled {
+			r.recordCrashes(&pod, crashLoop.Window)
+		}
+
 		// Create NonReadyPodInfo with comprehensive investigation results
 		podInfo := infrav1alpha1.NonReadyPodInfo{
 			Name:            pod.Name,
 			Namespace:       pod.Namespace,
+			Cluster:         ClusterName(),
 			Phase:           string(pod.Status.Phase),
 			OwnerKind:       ownerKind,
 			OwnerName:       ownerName,
+			NodeName:        pod.Spec.NodeName,
+			Team:            teamForPod(ownershipRules, &pod),
+			CreatedAt:       pod.CreationTimestamp.DeepCopy(),
+			NotReadySince:   notReadySince(&pod),
+			Reserved:        podReservation(&pod),
 			Reason:          reason,
 			Message:         message,
 			ContainerErrors: containerErrors,
 			PodConditions:   conditions,
+			Mesh:            meshDiagnosis,
+			Suppressed:      suppressedBy != "",
+			SuppressedBy:    suppressedBy,
 		}
 
 		// Perform log analysis if enabled and pod is not ready
@@ -167,58 +443,57 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 					cacheTTL = podSleuth.Spec.LogAnalysis.CacheTTL.Duration
 				}
 
+				negativeCacheTTL := defaultNegativeCacheTTL
+				if podSleuth.Spec.LogAnalysis.NegativeCacheTTL != nil {
+					negativeCacheTTL = podSleuth.Spec.LogAnalysis.NegativeCacheTTL.Duration
+				}
+
 				cacheEnabled := true
 				if podSleuth.Spec.LogAnalysis.CacheEnabled != nil {
 					cacheEnabled = *podSleuth.Spec.LogAnalysis.CacheEnabled
 				}
 
 				var logAnalysisResult *infrav1alpha1.LogAnalysisResult
+				cacheHit := false
 
 				// Use global or pod-specific force refresh flag
 				podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
-				forceRefresh := globalForceRefresh || (targetForcePod != "" && targetForcePod == podKey)
-				if targetForcePod != "" {
-					logger.Info("checking force refresh for pod", "currentPod", podKey, "targetPod", targetForcePod, "match", targetForcePod == podKey, "forceRefresh", forceRefresh)
-				}
+				forceRefresh := refresh.forces(podKey)
 
 				// Try to get cached result if caching is enabled (but skip cache on first reconcile or force refresh)
 				if cacheEnabled && !forceRefresh {
-					logAnalysisResult = r.getCachedAnalysis(&pod, cacheTTL)
+					logAnalysisResult, cacheHit = r.getCachedAnalysis(podSleuth.Name, podConfigHash, &pod)
 					if logAnalysisResult != nil {
 						logger.Info("using cached log analysis", "pod", pod.Name, "namespace", pod.Namespace, "cachedAt", logAnalysisResult.CachedAt)
+					} else if cacheHit {
+						logger.V(1).Info("pod had no log output recently, skipping log analysis", "pod", pod.Name, "namespace", pod.Namespace)
 					}
 				}
 
-				if logAnalysisResult == nil {
-					if forceRefresh {
-						logger.Info("force refresh requested - running log analysis immediately", "pod", pod.Name, "namespace", pod.Namespace)
-						// Ensure at least 1 second passes to guarantee a new timestamp for the dashboard to detect
-						time.Sleep(1100 * time.Millisecond)
+				if !cacheHit {
+					job := &analysisJob{
+						Key:               getCacheKey(podSleuth.Name, podConfigHash, &pod),
+						PodSleuth:         podSleuth.Name,
+						ConfigHash:        podConfigHash,
+						Pod:               pod.DeepCopy(),
+						Config:            logAnalysisConfig,
+						AIOptions:         aiOpts,
+						ForceRefresh:      forceRefresh,
+						RefreshGeneration: refresh.generationOf(podKey),
+						Priority:          analysisPriorityOf(&podInfo, forceRefresh, previousAnalyses[podKey] != nil),
+						CacheEnabled:      cacheEnabled,
+						CacheTTL:          cacheTTL,
+						NegativeCacheTTL:  negativeCacheTTL,
 					}
-
-					result, err := analyzeLogs(ctx, r.Client, r.K8sClient, &pod, podSleuth.Spec.LogAnalysis)
-					if err != nil {
-						logger.Info("log analysis failed", "pod", pod.Name, "namespace", pod.Namespace, "error", err)
-						// Create failure result so the dashboard polling detects completion
-						result = &infrav1alpha1.LogAnalysisResult{
-							RootCause:  fmt.Sprintf("Analysis Failed: %v", err),
-							Methods:    []string{"failed"},
-							AnalyzedAt: metav1.Now(),
-							Confidence: 0,
-						}
-					}
-
-					if result != nil {
-
-						logger.Info("log analysis successful", "pod", pod.Name, "newAnalyzedAt", result.AnalyzedAt, "timestamp", result.AnalyzedAt.Time.Unix())
+					if r.AnalysisWorkers <= 0 {
+						logAnalysisResult = r.runAnalysisJob(ctx, job)
+					} else if result, done := r.takeAnalysisOutcome(job.Key); done && !forceRefresh {
 						logAnalysisResult = result
-						// Cache the result if caching is enabled
-						if cacheEnabled {
-							r.setCachedAnalysis(&pod, result, cacheTTL)
-							logger.Info("log analysis completed and cached", "pod", pod.Name, "namespace", pod.Namespace)
-						} else {
-							logger.Info("log analysis completed (no cache)", "pod", pod.Name, "namespace", pod.Namespace)
-						}
+					} else {
+						// Report the pod now and write the analysis once a worker is done
+						analysisJobs = append(analysisJobs, job)
+						podInfo.AnalysisPending = true
+						logAnalysisResult = previousAnalyses[podKey]
 					}
 				}
 
@@ -234,12 +509,61 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 							podInfo.Message = "Log analysis: " + logAnalysisResult.RootCause
 						}
 					}
-				} else {
+				} else if !podInfo.AnalysisPending {
 					logger.Info("log analysis returned no results", "pod", pod.Name, "namespace", pod.Namespace)
 				}
 			}
 		}
 
+		// Probe the hosts found by log analysis if enabled
+		if connectivity := podSleuth.Spec.ConnectivityCheck; connectivity != nil && connectivity.Enabled {
+			podInfo.Connectivity = r.checkConnectivity(ctx, &pod, connectivity, podInfo.LogAnalysis)
+			var problems []string
+			for _, result := range podInfo.Connectivity {
+				if hasConnectivityProblem(result) {
+					problems = append(problems, result.Summary)
+				}
+			}
+			if len(problems) > 0 {
+				if podInfo.Message != "" {
+					podInfo.Message = podInfo.Message + ". Connectivity: " + strings.Join(problems, "; ")
+				} else {
+					podInfo.Message = "Connectivity: " + strings.Join(problems, "; ")
+				}
+			}
+		}
+
+		// Run checks from an ephemeral debug container if enabled
+		if debug := podSleuth.Spec.DebugDiagnostics; debug != nil && debug.Enabled {
+			podInfo.DebugDiagnostics = r.runDebugDiagnostics(ctx, &pod, debug, &podInfo)
+			if podInfo.DebugDiagnostics != nil && podInfo.DebugDiagnostics.Findings != "" {
+				if podInfo.Message != "" {
+					podInfo.Message = podInfo.Message + ". Debug diagnostics: " + podInfo.DebugDiagnostics.Findings
+				} else {
+					podInfo.Message = "Debug diagnostics: " + podInfo.DebugDiagnostics.Findings
+				}
+			}
+		}
+
+		if crashLoop.Enabled {
+			if podInfo.LogAnalysis != nil {
+				containerName, _ := logContainer(&pod, blockingInitContainer(&pod))
+				r.recordCrashRootCause(&pod, containerName, podInfo.LogAnalysis.RootCause)
+			}
+			podInfo.CrashLoopTrend = r.getCrashLoopTrend(&pod, crashLoop)
+		}
+
+		if silencedBy := silencingSilence(silences, &podInfo); silencedBy != "" {
+			podInfo.Silenced = true
+			podInfo.SilencedBy = silencedBy
+		}
+		if ack := podAcknowledgement(&pod, now); ack != nil {
+			podInfo.Acknowledged = ack
+			if nextAcknowledgementExpiry.IsZero() || ack.Until.Time.Before(nextAcknowledgementExpiry) {
+				nextAcknowledgementExpiry = ack.Until.Time
+			}
+		}
+
 		nonReadyPods = append(nonReadyPods, podInfo)
 
 		// Log the non-ready pod with detailed information
@@ -255,43 +579,98 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		)
 	}
 
+	r.enqueueAnalyses(analysisJobs)
+
 	// Clean up cache for pods that are no longer in the non-ready list
 	currentPods := make(map[string]bool)
 	for _, pod := range podList.Items {
 		if !isPodReady(&pod) {
-			currentPods[getCacheKey(&pod)] = true
+			hash := configHash
+			if suppressingWindow(activeWindows, pod.Namespace) != "" {
+				hash = suppressedConfigHash
+			}
+			currentPods[getCacheKey(podSleuth.Name, hash, &pod)] = true
 		}
 	}
-	r.cleanupCache(currentPods)
+	r.cleanupCache(podSleuth.Name, currentPods)
+	r.forgetAnalyses(podSleuth.Name, currentPods)
+	r.pruneCrashHistory(crashHistoryRetention)
 
 	// Update status
-	podSleuth.Status.NonReadyPods = nonReadyPods
-	if err := r.Status().Update(ctx, &podSleuth); err != nil {
-		logger.Error(err, "unable to update PodSleuth status")
-		return ctrl.Result{}, err
+	// With namespace sharding, entries of other shards' namespaces are kept as stored
+	previousPods, foreignPods := r.Sharding.splitPods(podSleuth.Status.NonReadyPods)
+	carryDetectedAt(previousPods, nonReadyPods, metav1.NewTime(now))
+	r.detectAnomalies(ctx, &podSleuth, podList.Items, previousPods, nonReadyPods, now)
+	statusPods := nonReadyPods
+	if podSleuth.Spec.Reports != nil && podSleuth.Spec.Reports.Enabled {
+		statusPods = r.syncReports(ctx, &podSleuth, nonReadyPods, now)
+	} else {
+		r.deleteReports(ctx, podSleuth.Name)
 	}
-
-	// If force refresh was active and status update succeeded, remove the annotations
-	if globalForceRefresh || targetForcePod != "" {
+	limitErrorLines(statusPods, podSleuth.Spec.StatusLimits)
+	podSleuth.Status.NonReadyPods = r.Sharding.mergePods(statusPods, foreignPods)
+	podSleuth.Status.EvictedPods = groupEvictedPods(r.Sharding.mergeEvictedPods(evictedPods, podSleuth.Status.EvictedPods))
+	podSleuth.Status.Workloads = r.Sharding.mergeWorkloads(r.buildWorkloadContexts(ctx, nonReadyPods), podSleuth.Status.Workloads)
+	podSleuth.Status.ActiveMaintenanceWindows = activeWindowNames
+	var previouslyBreaching []string
+	if podSleuth.Status.SLO != nil {
+		previouslyBreaching = podSleuth.Status.SLO.Breaching
+	}
+	sloReport := r.updateSLO(&podSleuth, nonReadyPods, now)
+	r.updateAIQuotaStatus(&podSleuth, aiQuota, now)
+	approvalsHandled := hasRemediationApprovals(podSleuth.Annotations)
+	// Only the approvals read now are removed afterwards; those added meanwhile and
+	// those of other shards' pending remediations are left
+	handledApprovals := remediationApprovalIDs(podSleuth.Annotations)
+	for id := range r.Sharding.foreignRemediationIDs(podSleuth.Status.PendingRemediations) {
+		delete(handledApprovals, id)
+	}
+	nextRemediation := r.remediate(ctx, &podSleuth, nonReadyPods, now)
+	if statusChanged(&statusBase.Status, &podSleuth.Status) {
+		if err := r.patchStatus(ctx, &podSleuth, statusBase, now); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Info("PodSleuth status was updated by another shard, retrying")
+				return ctrl.Result{RequeueAfter: time.Second}, nil
+			}
+			logger.Error(err, "unable to update PodSleuth status")
+			return ctrl.Result{}, err
+		}
+	}
+	recordNonReadyPods(podSleuth.Name, nonReadyPods)
+	recordWastedCapacity(podSleuth.Name, nonReadyPods, now)
+	recordSLOMetrics(podSleuth.Name, sloReport)
+	r.emitSLOEvents(&podSleuth, previouslyBreaching, sloReport)
+	transitions := diffNonReadyPods(previousPods, nonReadyPods)
+	r.emitTransitionEvents(&podSleuth, transitions)
+	nextNotification := r.sendNotifications(&podSleuth, transitions, nonReadyPods)
+	r.publishEvents(&podSleuth, transitions)
+	nextIssueSync := r.syncIssues(&podSleuth, transitions, nonReadyPods)
+	r.annotateGrafana(&podSleuth, nonReadyPods)
+	nextSnapshot := r.exportSnapshot(&podSleuth, transitions)
+	r.syncGitOps(ctx, &podSleuth, nonReadyPods)
+
+	// If force refresh or remediation approvals were handled and the status update
+	// succeeded, remove the annotations
+	if refresh.requested() || approvalsHandled {
 		// Fetch latest version to avoid conflict
 		if err := r.Get(ctx, req.NamespacedName, &podSleuth); err == nil {
 			changed := false
 			if podSleuth.Annotations != nil {
-				if _, exists := podSleuth.Annotations["kubesleuth.io/force-refresh"]; exists {
-					delete(podSleuth.Annotations, "kubesleuth.io/force-refresh")
+				if refresh.clear(podSleuth.Annotations) {
 					changed = true
 				}
-				if _, exists := podSleuth.Annotations["kubesleuth.io/force-refresh-pod"]; exists {
-					delete(podSleuth.Annotations, "kubesleuth.io/force-refresh-pod")
-					changed = true
+				if approvalsHandled {
+					if removeRemediationApprovals(podSleuth.Annotations, handledApprovals) {
+						changed = true
+					}
 				}
 			}
 
 			if changed {
 				if err := r.Update(ctx, &podSleuth); err != nil {
-					logger.Error(err, "failed to remove force-refresh annotation(s) after analysis")
+					logger.Error(err, "failed to remove force-refresh or approval annotation(s) after analysis")
 				} else {
-					logger.Info("cleared force-refresh annotations after successful analysis")
+					logger.Info("cleared force-refresh or approval annotations after successful analysis")
 				}
 			}
 		}
@@ -302,10 +681,53 @@ func (r *PodSleuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if podSleuth.Spec.ReconcileInterval != nil {
 		reconcileInterval = podSleuth.Spec.ReconcileInterval.Duration
 	}
+	if nextSilenceExpiry != nil {
+		if untilExpiry := nextSilenceExpiry.Sub(now) + time.Second; untilExpiry < reconcileInterval {
+			reconcileInterval = untilExpiry
+		}
+	}
+	// Come back when notifications held by a policy cooldown, issues of persistent
+	// failures, snapshots or remediations are due, or an acknowledgement expires
+	for _, due := range []time.Time{nextNotification, nextIssueSync, nextSnapshot, nextRemediation, nextAcknowledgementExpiry} {
+		if due.IsZero() {
+			continue
+		}
+		if untilDue := time.Until(due) + time.Second; untilDue < reconcileInterval {
+			reconcileInterval = max(untilDue, time.Second)
+		}
+	}
 
 	return ctrl.Result{RequeueAfter: reconcileInterval}, nil
 }
 
+// patchStatus updates the status of a PodSleuth. A merge patch only sends the changed
+// fields, and cannot conflict since the controller is the only writer of the status,
+// unless shards split namespaces: then this shard's share is applied again onto a fresh
+// read until the update succeeds or the attempts are used up.
+func (r *PodSleuthReconciler) patchStatus(ctx context.Context, podSleuth, base *infrav1alpha1.PodSleuth, now time.Time) error {
+	if !r.Sharding.byNamespace() {
+		return r.Status().Patch(ctx, podSleuth, client.MergeFrom(base))
+	}
+	reader := client.Reader(r.Client)
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	for attempt := 1; ; attempt++ {
+		err := r.Status().Patch(ctx, podSleuth, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if !apierrors.IsConflict(err) || attempt == maxShardStatusPatches {
+			return err
+		}
+		var stored infrav1alpha1.PodSleuth
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(podSleuth), &stored); err != nil {
+			return err
+		}
+		podSleuth.Status = r.Sharding.rebaseStatus(&podSleuth.Status, &stored.Status)
+		// The records of remediations this shard took are added to those of the others
+		r.restoreExecutedRemediations(podSleuth, now)
+		base = &stored
+	}
+}
+
 // investigatePodFailure performs comprehensive investigation of why a pod is not ready
 func (r *PodSleuthReconciler) investigatePodFailure(pod *corev1.Pod) (string, string, []infrav1alpha1.ContainerError, []infrav1alpha1.PodCondition) {
 	var containerErrors []infrav1alpha1.ContainerError
@@ -460,11 +882,14 @@ func (r *PodSleuthReconciler) investigateContainerStatus(containerStatus corev1.
 	return err
 }
 
-// getPodOwner finds the owner Deployment or StatefulSet for a pod
+// getPodOwner walks the ownership chain of a pod to the workload that manages it.
+// Supported: Deployment and Argo Rollout (via ReplicaSet), StatefulSet, DaemonSet,
+// CronJob (via Job), Job, ReplicationController and standalone ReplicaSets.
 func (r *PodSleuthReconciler) getPodOwner(ctx context.Context, pod *corev1.Pod) (string, string) {
 	for _, ownerRef := range pod.OwnerReferences {
-		if ownerRef.Kind == "ReplicaSet" {
-			// Get ReplicaSet to find its owner Deployment
+		switch ownerRef.Kind {
+		case "ReplicaSet":
+			// Get ReplicaSet to find its owner Deployment or Rollout
 			var rs appsv1.ReplicaSet
 			if err := r.Get(ctx, types.NamespacedName{
 				Name:      ownerRef.Name,
@@ -478,10 +903,30 @@ func (r *PodSleuthReconciler) getPodOwner(ctx context.Context, pod *corev1.Pod)
 				if rsOwnerRef.Kind == "Deployment" {
 					return "Deployment", rsOwnerRef.Name
 				}
+				if rsOwnerRef.Kind == "Rollout" && strings.HasPrefix(rsOwnerRef.APIVersion, "argoproj.io/") {
+					return "Rollout", rsOwnerRef.Name
+				}
+			}
+			return "ReplicaSet", ownerRef.Name
+		case "Job":
+			// Get Job to find its owner CronJob
+			var job batchv1.Job
+			if err := r.Get(ctx, types.NamespacedName{
+				Name:      ownerRef.Name,
+				Namespace: pod.Namespace,
+			}, &job); err != nil {
+				continue
 			}
-		} else if ownerRef.Kind == "StatefulSet" {
-			return "StatefulSet", ownerRef.Name
-		} else if ownerRef.Kind == "Deployment" {
+
+			for _, jobOwnerRef := range job.OwnerReferences {
+				if jobOwnerRef.Kind == "CronJob" {
+					return "CronJob", jobOwnerRef.Name
+				}
+			}
+			return "Job", ownerRef.Name
+		case "StatefulSet", "DaemonSet", "ReplicationController":
+			return ownerRef.Kind, ownerRef.Name
+		case "Deployment":
 			// Direct Deployment owner (uncommon but possible)
 			return "Deployment", ownerRef.Name
 		}
@@ -492,6 +937,9 @@ func (r *PodSleuthReconciler) getPodOwner(ctx context.Context, pod *corev1.Pod)
 
 // findObjectsForPod maps pod changes to PodSleuth resources
 func (r *PodSleuthReconciler) findObjectsForPod(ctx context.Context, pod client.Object) []reconcile.Request {
+	if !r.Sharding.ownsNamespace(pod.GetNamespace()) {
+		return []reconcile.Request{}
+	}
 	var podSleuthList infrav1alpha1.PodSleuthList
 	if err := r.List(ctx, &podSleuthList); err != nil {
 		return []reconcile.Request{}
@@ -499,6 +947,9 @@ func (r *PodSleuthReconciler) findObjectsForPod(ctx context.Context, pod client.
 
 	var requests []reconcile.Request
 	for _, podSleuth := range podSleuthList.Items {
+		if !r.Sharding.ownsPodSleuth(&podSleuth) {
+			continue
+		}
 		// Check if pod matches the label selector if specified
 		if podSleuth.Spec.PodLabelSelector != nil {
 			selector, err := metav1.LabelSelectorAsSelector(podSleuth.Spec.PodLabelSelector)
@@ -520,22 +971,23 @@ func (r *PodSleuthReconciler) findObjectsForPod(ctx context.Context, pod client.
 	return requests
 }
 
-// getCacheKey generates a cache key for a pod based on UID and restart count
-func getCacheKey(pod *corev1.Pod) string {
-	// Get the highest restart count from all containers
-	maxRestartCount := int32(0)
-	for _, cs := range pod.Status.ContainerStatuses {
-		if cs.RestartCount > maxRestartCount {
-			maxRestartCount = cs.RestartCount
-		}
+// statusChanged reports whether a status differs from the stored one
+func statusChanged(stored, current *infrav1alpha1.PodSleuthStatus) bool {
+	return !jsonEqual(stored, current)
+}
+
+// jsonEqual reports whether two objects serialize the same. Objects are compared as
+// stored, since timestamps are stored with second precision.
+func jsonEqual(a, b any) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
 	}
-	for _, cs := range pod.Status.InitContainerStatuses {
-		if cs.RestartCount > maxRestartCount {
-			maxRestartCount = cs.RestartCount
-		}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
 	}
-
-	return fmt.Sprintf("%s/%s/%s/%d", pod.Namespace, pod.Name, pod.UID, maxRestartCount)
+	return bytes.Equal(aJSON, bJSON)
 }
 
 // isPodReady checks if a pod is ready
@@ -548,95 +1000,55 @@ func isPodReady(pod *corev1.Pod) bool {
 	return false
 }
 
-// getCachedAnalysis retrieves a cached analysis result if it exists and hasn't expired
-func (r *PodSleuthReconciler) getCachedAnalysis(pod *corev1.Pod, cacheTTL time.Duration) *infrav1alpha1.LogAnalysisResult {
-	r.analysisCacheMux.RLock()
-	defer r.analysisCacheMux.RUnlock()
-
-	if r.analysisCache == nil {
-		return nil
-	}
-
-	cacheKey := getCacheKey(pod)
-	cached, exists := r.analysisCache[cacheKey]
-	if !exists {
-		return nil
-	}
-
-	// Check if cache has expired
-	if time.Now().After(cached.ExpiresAt) {
-		return nil
-	}
-
-	return cached.Result
-}
-
-// setCachedAnalysis stores an analysis result in the cache
-func (r *PodSleuthReconciler) setCachedAnalysis(pod *corev1.Pod, result *infrav1alpha1.LogAnalysisResult, cacheTTL time.Duration) {
-	r.analysisCacheMux.Lock()
-	defer r.analysisCacheMux.Unlock()
-
-	if r.analysisCache == nil {
-		r.analysisCache = make(map[string]*CachedAnalysisResult)
-	}
-
-	// Get the highest restart count
-	maxRestartCount := int32(0)
-	for _, cs := range pod.Status.ContainerStatuses {
-		if cs.RestartCount > maxRestartCount {
-			maxRestartCount = cs.RestartCount
-		}
-	}
-	for _, cs := range pod.Status.InitContainerStatuses {
-		if cs.RestartCount > maxRestartCount {
-			maxRestartCount = cs.RestartCount
+// notReadySince returns when a non-ready pod's Ready condition last changed, or its
+// creation if it has none
+func notReadySince(pod *corev1.Pod) *metav1.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.DeepCopy()
 		}
 	}
-
-	cacheKey := getCacheKey(pod)
-	now := time.Now()
-	expiresAt := now.Add(cacheTTL)
-
-	// Set CachedAt and CacheExpiresAt timestamps in the result
-	result.CachedAt = metav1.NewTime(now)
-	cacheExpiresAtTime := metav1.NewTime(expiresAt)
-	result.CacheExpiresAt = &cacheExpiresAtTime
-
-	r.analysisCache[cacheKey] = &CachedAnalysisResult{
-		PodUID:       pod.UID,
-		PodNamespace: pod.Namespace,
-		PodName:      pod.Name,
-		RestartCount: maxRestartCount,
-		Result:       result,
-		CachedAt:     now,
-		ExpiresAt:    expiresAt,
-	}
+	return pod.CreationTimestamp.DeepCopy()
 }
 
-// cleanupCache removes stale cache entries for pods that no longer exist or are ready
-func (r *PodSleuthReconciler) cleanupCache(currentPods map[string]bool) {
-	r.analysisCacheMux.Lock()
-	defer r.analysisCacheMux.Unlock()
-
-	if r.analysisCache == nil {
-		return
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodSleuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index pods by readiness so that reconciles only read the non-ready ones
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podReadyField, func(obj client.Object) []string {
+		return []string{strconv.FormatBool(isPodReady(obj.(*corev1.Pod)))}
+	}); err != nil {
+		return err
 	}
-
-	// Remove entries for pods that are no longer in the non-ready list
-	for key := range r.analysisCache {
-		if !currentPods[key] {
-			delete(r.analysisCache, key)
-		}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &infrav1alpha1.PodSleuthReport{}, reportPodSleuthField, func(obj client.Object) []string {
+		return []string{obj.(*infrav1alpha1.PodSleuthReport).Spec.PodSleuth}
+	}); err != nil {
+		return err
 	}
-}
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *PodSleuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&infrav1alpha1.PodSleuth{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{RateLimiter: r.requeueRateLimiter()}).
+		For(&infrav1alpha1.PodSleuth{}, builder.WithPredicates(r.Sharding.ownedPodSleuths())).
 		Watches(
 			&corev1.Pod{},
-			handler.EnqueueRequestsFromMapFunc(r.findObjectsForPod),
+			r.podEventHandler(),
+			builder.WithPredicates(podStateChanged()),
 		).
-		Complete(r)
+		Watches(
+			&infrav1alpha1.SleuthSilence{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSilence),
+		)
+
+	// Log analyses run on workers started with the manager, which request a reconcile of
+	// their PodSleuth when done
+	if r.AnalysisWorkers > 0 {
+		r.analysisPriorities = newAnalysisPriorityQueue()
+		r.analysisQueue = workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[string]{
+			Name: "log-analysis", Queue: r.analysisPriorities})
+		r.analysisFinished = make(chan event.GenericEvent)
+		if err := mgr.Add(manager.RunnableFunc(r.runAnalysisWorkers)); err != nil {
+			return err
+		}
+		controllerBuilder = controllerBuilder.WatchesRawSource(source.Channel(r.analysisFinished, analysisResultHandler()))
+	}
+	return controllerBuilder.Complete(r)
 }
@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// defaultPrometheusTimeout is the timeout for a single Prometheus query
+const defaultPrometheusTimeout = 10 * time.Second
+
+// prometheusHTTPClient is shared by all metrics analyses; timeouts are set per query
+var prometheusHTTPClient = &http.Client{Transport: outboundRoundTripper{}}
+
+// getDefaultMetricQueries returns the built-in queries for the metrics method.
+// They rely on the metrics exposed by kube-state-metrics and the kubelet/cAdvisor.
+func getDefaultMetricQueries() []infrav1alpha1.MetricQuery {
+	return []infrav1alpha1.MetricQuery{
+		{
+			Name:      "RestartRate",
+			Query:     `sum(increase(kube_pod_container_status_restarts_total{namespace="{{namespace}}",pod="{{pod}}"}[1h]))`,
+			Threshold: "3",
+			RootCause: "Container restarted {{value}} times in the last hour",
+		},
+		{
+			Name: "CPUThrottling",
+			Query: `100 * sum(rate(container_cpu_cfs_throttled_periods_total{namespace="{{namespace}}",pod="{{pod}}"}[5m]))` +
+				` / sum(rate(container_cpu_cfs_periods_total{namespace="{{namespace}}",pod="{{pod}}"}[5m]))`,
+			Threshold: "25",
+			RootCause: "CPU throttled in {{value}}% of scheduling periods, CPU limit is likely too low",
+		},
+		{
+			Name: "MemoryNearLimit",
+			Query: `100 * max(container_memory_working_set_bytes{namespace="{{namespace}}",pod="{{pod}}",container!=""}` +
+				` / on(namespace,pod,container) kube_pod_container_resource_limits{namespace="{{namespace}}",pod="{{pod}}",resource="memory"})`,
+			Threshold: "90",
+			RootCause: "Memory usage at {{value}}% of the limit, container is at risk of being OOMKilled",
+		},
+		{
+			Name:      "ProbeFailures",
+			Query:     `sum(increase(prober_probe_total{namespace="{{namespace}}",pod="{{pod}}",result="failed"}[15m]))`,
+			Threshold: "0",
+			RootCause: "{{value}} failed liveness/readiness/startup probes in the last 15 minutes",
+		},
+	}
+}
+
+// prometheusQueryResponse is the subset of the Prometheus /api/v1/query response we use
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// analyzeWithMetrics evaluates the configured PromQL queries for a pod and reports
+// every query whose value exceeds its threshold as a finding
+func analyzeWithMetrics(ctx context.Context, k8sClient client.Client, pod *corev1.Pod, metricsConfig *infrav1alpha1.MetricsConfig) *infrav1alpha1.MetricsAnalysisResult {
+	logger := log.Log.WithName("log-analysis")
+
+	if metricsConfig == nil || metricsConfig.PrometheusURL == "" {
+		return &infrav1alpha1.MetricsAnalysisResult{Error: "Prometheus URL is required for metrics analysis"}
+	}
+
+	timeout := defaultPrometheusTimeout
+	if metricsConfig.Timeout != nil && metricsConfig.Timeout.Duration > 0 {
+		timeout = metricsConfig.Timeout.Duration
+	}
+
+	bearerToken := ""
+	if metricsConfig.BearerTokenSecretRef != nil {
+		token, err := getAPIKeyFromSecret(ctx, k8sClient, metricsConfig.BearerTokenSecretRef, pod.Namespace)
+		if err != nil {
+			return &infrav1alpha1.MetricsAnalysisResult{Error: fmt.Sprintf("Failed to get Prometheus token: %v", err)}
+		}
+		bearerToken = token
+	}
+
+	queries := metricsConfig.Queries
+	if len(queries) == 0 {
+		queries = getDefaultMetricQueries()
+	}
+
+	result := &infrav1alpha1.MetricsAnalysisResult{}
+	var queryErrors []string
+	for _, q := range queries {
+		query := strings.NewReplacer("{{namespace}}", pod.Namespace, "{{pod}}", pod.Name).Replace(q.Query)
+
+		value, found, err := queryPrometheus(ctx, metricsConfig.PrometheusURL, query, bearerToken, timeout)
+		if err != nil {
+			logger.Info("prometheus query failed", "query", q.Name, "error", err)
+			queryErrors = append(queryErrors, fmt.Sprintf("%s: %v", q.Name, err))
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		threshold := 0.0
+		if q.Threshold != "" {
+			if threshold, err = strconv.ParseFloat(q.Threshold, 64); err != nil {
+				queryErrors = append(queryErrors, fmt.Sprintf("%s: invalid threshold %q", q.Name, q.Threshold))
+				continue
+			}
+		}
+		if value <= threshold {
+			continue
+		}
+
+		formatted := strconv.FormatFloat(value, 'f', -1, 64)
+		if value != float64(int64(value)) {
+			formatted = strconv.FormatFloat(value, 'f', 1, 64)
+		}
+		rootCause := q.RootCause
+		if rootCause == "" {
+			rootCause = fmt.Sprintf("%s is {{value}} (threshold %s)", q.Name, q.Threshold)
+		}
+		result.Findings = append(result.Findings, infrav1alpha1.MetricFinding{
+			Name:      q.Name,
+			Value:     formatted,
+			RootCause: strings.ReplaceAll(rootCause, "{{value}}", formatted),
+		})
+	}
+
+	// Only report an error if no query could be evaluated at all
+	if len(queryErrors) == len(queries) {
+		result.Error = fmt.Sprintf("Metrics analysis failed: %s", strings.Join(queryErrors, "; "))
+		return result
+	}
+
+	if len(result.Findings) == 0 {
+		return result
+	}
+
+	causes := make([]string, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		causes = append(causes, f.RootCause)
+	}
+	result.RootCause = strings.Join(causes, "; ")
+	// Each additional corroborating finding raises confidence
+	result.Confidence = int32(min(50+15*len(result.Findings), 90))
+
+	logger.Info("metrics analysis completed", "pod", pod.Name, "namespace", pod.Namespace, "findings", len(result.Findings))
+	return result
+}
+
+// queryPrometheus runs an instant query and returns the highest value of the result.
+// found is false if the query returned no samples.
+func queryPrometheus(ctx context.Context, prometheusURL, query, bearerToken string, timeout time.Duration) (value float64, found bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(prometheusURL, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := prometheusHTTPClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var parsed prometheusQueryResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, false, fmt.Errorf("invalid response (status %d): %w", resp.StatusCode, err)
+	}
+	if parsed.Status != "success" {
+		return 0, false, fmt.Errorf("query failed: %s", parsed.Error)
+	}
+
+	for _, sample := range parsed.Data.Result {
+		// Instant vector samples are [timestamp, "value"]
+		if len(sample.Value) != 2 {
+			continue
+		}
+		raw, ok := sample.Value[1].(string)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(v) {
+			continue
+		}
+		if !found || v > value {
+			value = v
+			found = true
+		}
+	}
+	return value, found, nil
+}
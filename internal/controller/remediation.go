@@ -0,0 +1,370 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Remediation actions
+const (
+	remediationRestartPod     = "RestartPod"
+	remediationRolloutRestart = "RolloutRestart"
+	remediationRollback       = "Rollback"
+	remediationRunJob         = "RunJob"
+	remediationIncreaseMemory = "IncreaseMemory"
+)
+
+// Remediation results
+const (
+	remediationSucceeded = "Succeeded"
+	remediationFailed    = "Failed"
+	remediationRejected  = "Rejected"
+	remediationDryRun    = "DryRun"
+	remediationProposed  = "Proposed"
+)
+
+// remediationOperatorActor is the actor of automatic remediations
+const remediationOperatorActor = "kubesleuth"
+
+// Event reasons emitted for remediations
+const (
+	eventReasonRemediationExecuted = "RemediationExecuted"
+	eventReasonRemediationFailed   = "RemediationFailed"
+	eventReasonRemediationPending  = "RemediationPendingApproval"
+	eventReasonRemediationRejected = "RemediationRejected"
+	eventReasonRemediationDryRun   = "RemediationDryRun"
+	eventReasonRemediationProposed = "RemediationProposed"
+)
+
+const (
+	defaultRemediationMinNonReady = 10 * time.Minute
+	defaultRemediationWindow      = time.Hour
+	// defaultRemediationHistoryLimit bounds status.remediations
+	defaultRemediationHistoryLimit = 100
+	// executedRemediationRetention is how long remediations missing from the status are
+	// remembered
+	executedRemediationRetention = time.Hour
+)
+
+// errRemediationNotApplicable marks actions that do not apply to a pod, e.g. a rollback
+// of a failure that is not caused by the newest revision. They are not recorded.
+var errRemediationNotApplicable = errors.New("remediation not applicable")
+
+// errRemediationProposed marks actions that were proposed through notifications instead
+// of being taken, e.g. on GitOps-managed workloads
+var errRemediationProposed = errors.New("remediation proposed")
+
+// defaultRemediationReasons are the pod reasons a rule matches if it lists none
+var defaultRemediationReasons = []string{"CrashLoopBackOff"}
+
+// remediate takes the action of the first matching rule on each eligible pod, or
+// proposes it for approval, and records it in the status. In dry-run mode actions are
+// only described. It returns when the next pod becomes eligible, or zero.
+func (r *PodSleuthReconciler) remediate(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo, now time.Time) time.Time {
+	r.restoreExecutedRemediations(podSleuth, now)
+	policy := podSleuth.Spec.Remediation
+	if policy == nil || (len(policy.Rules) == 0 && policy.NodeCordon == nil) {
+		podSleuth.Status.PendingRemediations = nil
+		podSleuth.Status.RemediationLockout = nil
+		remediationLockedOut.DeleteLabelValues(podSleuth.Name)
+		return time.Time{}
+	}
+	dryRun := r.RemediationDryRun || policy.DryRun
+	r.resolvePendingRemediations(ctx, podSleuth, current, dryRun, now)
+
+	var nextDue time.Time
+	lockedOut := remediationLockoutActive(podSleuth, now)
+	if lockedOut {
+		nextDue = podSleuth.Status.RemediationLockout.Until.Time
+	}
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) || pod.DetectedAt == nil {
+			continue
+		}
+		rule := matchingRemediationRule(policy.Rules, pod)
+		if rule == nil {
+			continue
+		}
+
+		minNonReady := defaultRemediationMinNonReady
+		if rule.MinNonReadyDuration != nil {
+			minNonReady = rule.MinNonReadyDuration.Duration
+		}
+		if eligibleAt := pod.DetectedAt.Add(minNonReady); now.Before(eligibleAt) {
+			if nextDue.IsZero() || eligibleAt.Before(nextDue) {
+				nextDue = eligibleAt
+			}
+			continue
+		}
+
+		window := defaultRemediationWindow
+		if rule.Window != nil {
+			window = rule.Window.Duration
+		}
+		maxActions := int32(1)
+		if rule.MaxActionsPerWorkload != nil {
+			maxActions = *rule.MaxActionsPerWorkload
+		}
+		if countRemediations(podSleuth.Status.Remediations, pod, rule.Action, now.Add(-window)) >= int(maxActions) {
+			continue
+		}
+
+		if rule.ApprovalRequired && !dryRun {
+			r.proposeRemediation(podSleuth, rule, pod, now)
+			continue
+		}
+		if lockedOut {
+			continue
+		}
+		if exceeded := r.exceededRemediationBudget(ctx, policy.Budget, podSleuth, rule.Action, pod, now); exceeded != "" {
+			until := r.lockOutRemediation(podSleuth, exceeded, now)
+			lockedOut = true
+			if nextDue.IsZero() || until.Before(nextDue) {
+				nextDue = until
+			}
+			continue
+		}
+		r.runRemediation(ctx, podSleuth, rule.Name, rule.Action, pod, remediationOperatorActor, dryRun, now)
+	}
+	// Nodes are cordoned for the pods of all shards, by one of them
+	if !lockedOut && r.Sharding.ownsClusterWork() {
+		r.cordonNodes(ctx, podSleuth, podSleuth.Status.NonReadyPods, dryRun, now)
+	}
+	return nextDue
+}
+
+// runRemediation takes an action on a pod, or describes it in dry-run mode, and records
+// it unless it does not apply
+func (r *PodSleuthReconciler) runRemediation(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, ruleName, action string, pod *infrav1alpha1.NonReadyPodInfo, actor string, dryRun bool, now time.Time) {
+	message, err := r.executeRemediation(ctx, podSleuth, ruleName, action, pod, dryRun, now)
+	if errors.Is(err, errRemediationNotApplicable) {
+		return
+	}
+	record := infrav1alpha1.RemediationRecord{
+		Time:      metav1.NewTime(now),
+		Rule:      ruleName,
+		Action:    action,
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		OwnerKind: pod.OwnerKind,
+		OwnerName: pod.OwnerName,
+		Reason:    pod.Reason,
+		Actor:     actor,
+	}
+	switch {
+	case err != nil && !errors.Is(err, errRemediationProposed):
+		record.Result, record.Message = remediationFailed, err.Error()
+	case dryRun:
+		record.Result, record.Message = remediationDryRun, message
+	case err != nil:
+		record.Result, record.Message = remediationProposed, message
+	default:
+		record.Result, record.Message = remediationSucceeded, message
+	}
+	r.recordRemediation(podSleuth, record)
+}
+
+// remediationRule returns a PodSleuth's remediation rule by name, or nil
+func remediationRule(podSleuth *infrav1alpha1.PodSleuth, name string) *infrav1alpha1.RemediationRule {
+	if podSleuth.Spec.Remediation == nil {
+		return nil
+	}
+	for i := range podSleuth.Spec.Remediation.Rules {
+		if podSleuth.Spec.Remediation.Rules[i].Name == name {
+			return &podSleuth.Spec.Remediation.Rules[i]
+		}
+	}
+	return nil
+}
+
+// matchingRemediationRule returns the first rule matching a pod's namespace and reason
+func matchingRemediationRule(rules []infrav1alpha1.RemediationRule, pod *infrav1alpha1.NonReadyPodInfo) *infrav1alpha1.RemediationRule {
+	for i := range rules {
+		rule := &rules[i]
+		if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, pod.Namespace) {
+			continue
+		}
+		reasons := rule.Reasons
+		if len(reasons) == 0 {
+			reasons = defaultRemediationReasons
+		}
+		if slices.Contains(reasons, pod.Reason) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// countRemediations counts the actions taken on the pods of a pod's workload since a
+// time, or on the pod itself if it has no workload. Failed, rejected and dry-run actions
+// count too, so they are not retried or proposed again on every reconcile, and dry runs
+// show the actions rate limits would allow.
+func countRemediations(records []infrav1alpha1.RemediationRecord, pod *infrav1alpha1.NonReadyPodInfo, action string, since time.Time) int {
+	count := 0
+	for _, record := range records {
+		if record.Action != action || record.Namespace != pod.Namespace || record.Time.Time.Before(since) {
+			continue
+		}
+		sameWorkload := pod.OwnerName != "" && record.OwnerKind == pod.OwnerKind && record.OwnerName == pod.OwnerName
+		if sameWorkload || (pod.OwnerName == "" && record.Pod == pod.Name) {
+			count++
+		}
+	}
+	return count
+}
+
+// executeRemediation takes an action on a pod and describes what was done, or only
+// describes it in dry-run mode
+func (r *PodSleuthReconciler) executeRemediation(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, ruleName, action string, pod *infrav1alpha1.NonReadyPodInfo, dryRun bool, now time.Time) (string, error) {
+	switch action {
+	case remediationRestartPod:
+		if pod.OwnerKind == "" {
+			return "", fmt.Errorf("pod has no controller that would recreate it")
+		}
+		if dryRun {
+			return fmt.Sprintf("Would delete pod %s/%s so that %s %s recreates it", pod.Namespace, pod.Name, pod.OwnerKind, pod.OwnerName), nil
+		}
+		target := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name}}
+		if err := r.Delete(ctx, target); err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to delete pod: %w", err)
+		}
+		return fmt.Sprintf("Deleted pod %s/%s so that %s %s recreates it", pod.Namespace, pod.Name, pod.OwnerKind, pod.OwnerName), nil
+	case remediationRolloutRestart:
+		return r.rolloutRestart(ctx, pod, dryRun, now)
+	case remediationRollback:
+		return r.rollbackDeployment(ctx, pod, dryRun)
+	case remediationRunJob:
+		return r.runRemediationJob(ctx, podSleuth, ruleName, pod, dryRun)
+	case remediationIncreaseMemory:
+		return r.increaseMemory(ctx, podSleuth, ruleName, pod, dryRun)
+	default:
+		return "", fmt.Errorf("unsupported remediation action %q", action)
+	}
+}
+
+// recordRemediation appends a remediation to the audit trail in the status and emits
+// Events and metrics
+func (r *PodSleuthReconciler) recordRemediation(podSleuth *infrav1alpha1.PodSleuth, record infrav1alpha1.RemediationRecord) {
+	historyLimit := defaultRemediationHistoryLimit
+	if policy := podSleuth.Spec.Remediation; policy != nil && policy.HistoryLimit != nil {
+		historyLimit = int(*policy.HistoryLimit)
+	}
+	records := append(podSleuth.Status.Remediations, record)
+	if over := len(records) - historyLimit; over > 0 {
+		records = records[over:]
+	}
+	podSleuth.Status.Remediations = records
+	r.executedRemediationsMux.Lock()
+	if r.executedRemediations == nil {
+		r.executedRemediations = make(map[string][]infrav1alpha1.RemediationRecord)
+	}
+	r.executedRemediations[podSleuth.Name] = append(r.executedRemediations[podSleuth.Name], record)
+	r.executedRemediationsMux.Unlock()
+	remediationsTotal.WithLabelValues(podSleuth.Name, record.Action, record.Result).Inc()
+
+	log.Log.Info("remediation", "podsleuth", podSleuth.Name, "rule", record.Rule, "action", record.Action,
+		"pod", record.Pod, "namespace", record.Namespace, "node", record.Node, "actor", record.Actor, "result", record.Result, "message", record.Message)
+
+	eventType, reason := corev1.EventTypeNormal, eventReasonRemediationExecuted
+	switch record.Result {
+	case remediationFailed:
+		eventType, reason = corev1.EventTypeWarning, eventReasonRemediationFailed
+	case remediationRejected:
+		reason = eventReasonRemediationRejected
+	case remediationDryRun:
+		reason = eventReasonRemediationDryRun
+	case remediationProposed:
+		reason = eventReasonRemediationProposed
+	}
+	message := fmt.Sprintf("%s (rule %s) for pod %s/%s: %s", record.Action, record.Rule, record.Namespace, record.Pod, record.Message)
+	if record.Node != "" {
+		message = fmt.Sprintf("%s of node %s: %s", record.Action, record.Node, record.Message)
+	}
+	pod := infrav1alpha1.NonReadyPodInfo{Namespace: record.Namespace, OwnerKind: record.OwnerKind, OwnerName: record.OwnerName}
+	r.emitRemediationEvent(podSleuth, &pod, eventType, reason, message)
+}
+
+// restoreExecutedRemediations adds the remediations taken for a PodSleuth that its
+// status does not show yet, because the status update failed or the cache lags behind,
+// so that they are counted and persisted with the next status update. Remediations the
+// status shows are forgotten.
+func (r *PodSleuthReconciler) restoreExecutedRemediations(podSleuth *infrav1alpha1.PodSleuth, now time.Time) {
+	r.executedRemediationsMux.Lock()
+	defer r.executedRemediationsMux.Unlock()
+
+	var missing []infrav1alpha1.RemediationRecord
+	for _, record := range r.executedRemediations[podSleuth.Name] {
+		// The status stores times in seconds
+		shown := slices.ContainsFunc(podSleuth.Status.Remediations, func(stored infrav1alpha1.RemediationRecord) bool {
+			return stored.Time.Unix() == record.Time.Unix() && stored.Action == record.Action && stored.Namespace == record.Namespace &&
+				stored.Pod == record.Pod && stored.Node == record.Node && stored.Result == record.Result
+		})
+		if !shown && now.Sub(record.Time.Time) <= executedRemediationRetention {
+			missing = append(missing, record)
+		}
+	}
+	if len(missing) == 0 {
+		delete(r.executedRemediations, podSleuth.Name)
+		return
+	}
+	r.executedRemediations[podSleuth.Name] = missing
+
+	historyLimit := defaultRemediationHistoryLimit
+	if policy := podSleuth.Spec.Remediation; policy != nil && policy.HistoryLimit != nil {
+		historyLimit = int(*policy.HistoryLimit)
+	}
+	records := append(slices.Clone(podSleuth.Status.Remediations), missing...)
+	slices.SortStableFunc(records, func(a, b infrav1alpha1.RemediationRecord) int {
+		return a.Time.Time.Compare(b.Time.Time)
+	})
+	if over := len(records) - historyLimit; over > 0 {
+		records = records[over:]
+	}
+	podSleuth.Status.Remediations = records
+}
+
+// forgetExecutedRemediations drops the remembered remediations of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetExecutedRemediations(podSleuthName string) {
+	r.executedRemediationsMux.Lock()
+	defer r.executedRemediationsMux.Unlock()
+	delete(r.executedRemediations, podSleuthName)
+}
+
+// emitRemediationEvent emits an Event on the PodSleuth and the workload owning a pod
+func (r *PodSleuthReconciler) emitRemediationEvent(podSleuth *infrav1alpha1.PodSleuth, pod *infrav1alpha1.NonReadyPodInfo, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	message = truncateString(message, maxEventMessageLength)
+	r.Recorder.Event(podSleuth, eventType, reason, message)
+	if ref := workloadReference(pod); ref != nil {
+		r.Recorder.Event(ref, eventType, reason, message)
+	}
+}
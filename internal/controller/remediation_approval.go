@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Annotations on a PodSleuth that approve or reject pending remediations. Their values
+// are comma-separated pending remediation IDs. The operator removes them once handled.
+const (
+	AnnotationApproveRemediation = "kubesleuth.io/approve-remediation"
+	AnnotationRejectRemediation  = "kubesleuth.io/reject-remediation"
+	// AnnotationRemediationActor names who approved or rejected, for the audit record
+	AnnotationRemediationActor = "kubesleuth.io/remediation-actor"
+)
+
+// remediationApprovalAnnotations are removed after each reconcile that handled them
+var remediationApprovalAnnotations = []string{AnnotationApproveRemediation, AnnotationRejectRemediation, AnnotationRemediationActor}
+
+const (
+	// defaultRemediationActor is recorded when approvals do not name an actor
+	defaultRemediationActor = "annotation"
+	// approvedRemediationRetention is how long taken approvals are remembered
+	approvedRemediationRetention = time.Hour
+)
+
+// remediationIDs parses a comma-separated list of pending remediation IDs
+func remediationIDs(value string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// hasRemediationApprovals reports whether a PodSleuth carries approval annotations
+func hasRemediationApprovals(annotations map[string]string) bool {
+	for _, key := range remediationApprovalAnnotations {
+		if _, exists := annotations[key]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// remediationApprovalIDs returns the IDs a PodSleuth's annotations approve or reject
+func remediationApprovalIDs(annotations map[string]string) map[string]bool {
+	ids := remediationIDs(annotations[AnnotationApproveRemediation])
+	for id := range remediationIDs(annotations[AnnotationRejectRemediation]) {
+		ids[id] = true
+	}
+	return ids
+}
+
+// removeRemediationApprovals removes the handled IDs from the approval annotations,
+// keeping IDs added since they were read. The actor is removed with the last ID.
+// Reports whether the annotations changed.
+func removeRemediationApprovals(annotations map[string]string, handled map[string]bool) bool {
+	changed, kept := false, false
+	for _, key := range []string{AnnotationApproveRemediation, AnnotationRejectRemediation} {
+		value, exists := annotations[key]
+		if !exists {
+			continue
+		}
+		var ids []string
+		for id := range remediationIDs(value) {
+			if !handled[id] {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			delete(annotations, key)
+			changed = true
+			continue
+		}
+		kept = true
+		sort.Strings(ids)
+		if joined := strings.Join(ids, ","); joined != value {
+			annotations[key] = joined
+			changed = true
+		}
+	}
+	if _, exists := annotations[AnnotationRemediationActor]; exists && !kept {
+		delete(annotations, AnnotationRemediationActor)
+		changed = true
+	}
+	return changed
+}
+
+// resolvePendingRemediations takes approved actions and drops rejected ones and those
+// whose pod recovered
+func (r *PodSleuthReconciler) resolvePendingRemediations(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo, dryRun bool, now time.Time) {
+	approved := remediationIDs(podSleuth.Annotations[AnnotationApproveRemediation])
+	rejected := remediationIDs(podSleuth.Annotations[AnnotationRejectRemediation])
+	actor := podSleuth.Annotations[AnnotationRemediationActor]
+	if actor == "" {
+		actor = defaultRemediationActor
+	}
+
+	nonReady := make(map[string]*infrav1alpha1.NonReadyPodInfo, len(current))
+	for i := range current {
+		nonReady[current[i].Namespace+"/"+current[i].Name] = &current[i]
+	}
+
+	var pending []infrav1alpha1.PendingRemediation
+	for _, action := range podSleuth.Status.PendingRemediations {
+		if !r.Sharding.ownsNamespace(action.Namespace) {
+			// Another shard handles the pod
+			delete(approved, action.ID)
+			delete(rejected, action.ID)
+			pending = append(pending, action)
+			continue
+		}
+		pod, stillNonReady := nonReady[action.Namespace+"/"+action.Pod]
+		switch {
+		case rejected[action.ID]:
+			delete(rejected, action.ID)
+			r.recordRemediation(podSleuth, infrav1alpha1.RemediationRecord{
+				Time:      metav1.NewTime(now),
+				Rule:      action.Rule,
+				Action:    action.Action,
+				Namespace: action.Namespace,
+				Pod:       action.Pod,
+				OwnerKind: action.OwnerKind,
+				OwnerName: action.OwnerName,
+				Reason:    action.Reason,
+				Result:    remediationRejected,
+				Actor:     actor,
+				Message:   "Rejected by " + actor,
+			})
+		case !stillNonReady:
+			// The pod recovered or is gone, so the action is no longer needed
+			delete(approved, action.ID)
+		case approved[action.ID]:
+			delete(approved, action.ID)
+			if r.markRemediationApproved(podSleuth.Name+"/"+action.ID, now) {
+				r.runRemediation(ctx, podSleuth, action.Rule, action.Action, pod, actor, dryRun, now)
+			}
+		default:
+			pending = append(pending, action)
+		}
+	}
+	for id := range approved {
+		log.Log.Info("ignoring approval of unknown remediation", "podsleuth", podSleuth.Name, "id", id)
+	}
+	for id := range rejected {
+		log.Log.Info("ignoring rejection of unknown remediation", "podsleuth", podSleuth.Name, "id", id)
+	}
+	podSleuth.Status.PendingRemediations = pending
+}
+
+// markRemediationApproved remembers that an approved remediation is taken and reports
+// whether it was not taken before
+func (r *PodSleuthReconciler) markRemediationApproved(key string, now time.Time) bool {
+	r.approvedRemediationsMux.Lock()
+	defer r.approvedRemediationsMux.Unlock()
+	if r.approvedRemediations == nil {
+		r.approvedRemediations = make(map[string]time.Time)
+	}
+	for k, at := range r.approvedRemediations {
+		if now.Sub(at) > approvedRemediationRetention {
+			delete(r.approvedRemediations, k)
+		}
+	}
+	if _, taken := r.approvedRemediations[key]; taken {
+		return false
+	}
+	r.approvedRemediations[key] = now
+	return true
+}
+
+// proposeRemediation lists an action for approval, once per rule and workload
+func (r *PodSleuthReconciler) proposeRemediation(podSleuth *infrav1alpha1.PodSleuth, rule *infrav1alpha1.RemediationRule, pod *infrav1alpha1.NonReadyPodInfo, now time.Time) {
+	for _, action := range podSleuth.Status.PendingRemediations {
+		if action.Rule != rule.Name || action.Namespace != pod.Namespace {
+			continue
+		}
+		sameWorkload := pod.OwnerName != "" && action.OwnerKind == pod.OwnerKind && action.OwnerName == pod.OwnerName
+		if sameWorkload || action.Pod == pod.Name {
+			return
+		}
+	}
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%s/%s/%s/%d", podSleuth.Name, rule.Name, pod.Namespace, pod.Name, now.UnixNano())
+	action := infrav1alpha1.PendingRemediation{
+		ID:          fmt.Sprintf("%08x", h.Sum32()),
+		Rule:        rule.Name,
+		Action:      rule.Action,
+		Namespace:   pod.Namespace,
+		Pod:         pod.Name,
+		OwnerKind:   pod.OwnerKind,
+		OwnerName:   pod.OwnerName,
+		Reason:      pod.Reason,
+		RequestedAt: metav1.NewTime(now),
+	}
+	podSleuth.Status.PendingRemediations = append(podSleuth.Status.PendingRemediations, action)
+
+	log.Log.Info("remediation pending approval", "podsleuth", podSleuth.Name, "id", action.ID, "rule", action.Rule,
+		"action", action.Action, "pod", action.Pod, "namespace", action.Namespace)
+	r.emitRemediationEvent(podSleuth, pod, corev1.EventTypeNormal, eventReasonRemediationPending,
+		fmt.Sprintf("%s (rule %s) for pod %s/%s awaits approval: annotate with %s=%s", action.Action, action.Rule,
+			action.Namespace, action.Pod, AnnotationApproveRemediation, action.ID))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestRemoveRemediationApprovals(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		handled     map[string]bool
+		want        map[string]string
+		wantChanged bool
+	}{
+		{
+			name:        "all handled",
+			annotations: map[string]string{AnnotationApproveRemediation: "a1", AnnotationRejectRemediation: "b2", AnnotationRemediationActor: "alice"},
+			handled:     map[string]bool{"a1": true, "b2": true},
+			want:        map[string]string{},
+			wantChanged: true,
+		},
+		{
+			name:        "approval added after the read",
+			annotations: map[string]string{AnnotationApproveRemediation: "a1,c3", AnnotationRemediationActor: "bob"},
+			handled:     map[string]bool{"a1": true},
+			want:        map[string]string{AnnotationApproveRemediation: "c3", AnnotationRemediationActor: "bob"},
+			wantChanged: true,
+		},
+		{
+			name:        "approvals replaced after the read",
+			annotations: map[string]string{AnnotationApproveRemediation: "c3", AnnotationRejectRemediation: "d4"},
+			handled:     map[string]bool{"a1": true},
+			want:        map[string]string{AnnotationApproveRemediation: "c3", AnnotationRejectRemediation: "d4"},
+		},
+		{
+			name:        "approval of another shard",
+			annotations: map[string]string{AnnotationApproveRemediation: "a1,e5"},
+			handled:     map[string]bool{"a1": true},
+			want:        map[string]string{AnnotationApproveRemediation: "e5"},
+			wantChanged: true,
+		},
+		{
+			name:        "actor without approvals",
+			annotations: map[string]string{AnnotationRemediationActor: "alice"},
+			want:        map[string]string{},
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := removeRemediationApprovals(tt.annotations, tt.handled); changed != tt.wantChanged {
+				t.Errorf("got changed %v, want %v", changed, tt.wantChanged)
+			}
+			if !maps.Equal(tt.annotations, tt.want) {
+				t.Errorf("got annotations %v, want %v", tt.annotations, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkRemediationApprovedOnce(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	now := time.Now()
+	if !r.markRemediationApproved("production/a1", now) {
+		t.Fatal("first approval not taken")
+	}
+	// The annotation is still there when the next reconcile runs before its removal
+	if r.markRemediationApproved("production/a1", now.Add(time.Minute)) {
+		t.Error("duplicate approval taken twice")
+	}
+	if !r.markRemediationApproved("production/b2", now.Add(time.Minute)) {
+		t.Error("another approval not taken")
+	}
+	if !r.markRemediationApproved("production/a1", now.Add(approvedRemediationRetention+2*time.Minute)) {
+		t.Error("approval still remembered after the retention")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// remediationBudgetWindow is the period remediation budgets apply to
+	remediationBudgetWindow   = time.Hour
+	defaultRemediationLockout = time.Hour
+
+	eventReasonRemediationLockedOut = "RemediationLockedOut"
+)
+
+// remediationLockoutActive reports whether automatic remediation is locked out, and
+// clears an expired lockout
+func remediationLockoutActive(podSleuth *infrav1alpha1.PodSleuth, now time.Time) bool {
+	lockout := podSleuth.Status.RemediationLockout
+	if lockout != nil && !now.Before(lockout.Until.Time) {
+		log.Log.Info("remediation lockout ended", "podsleuth", podSleuth.Name, "reason", lockout.Reason)
+		podSleuth.Status.RemediationLockout = nil
+		lockout = nil
+	}
+	if lockout == nil {
+		remediationLockedOut.WithLabelValues(podSleuth.Name).Set(0)
+		return false
+	}
+	remediationLockedOut.WithLabelValues(podSleuth.Name).Set(1)
+	return true
+}
+
+// exceededRemediationBudget describes the budget an automatic action on a pod would
+// exceed, or returns "". Only automatic actions taken within the last hour count, by
+// any PodSleuth, since they all act on the same cluster.
+func (r *PodSleuthReconciler) exceededRemediationBudget(ctx context.Context, budget *infrav1alpha1.RemediationBudget, podSleuth *infrav1alpha1.PodSleuth, action string, pod *infrav1alpha1.NonReadyPodInfo, now time.Time) string {
+	if budget == nil {
+		return ""
+	}
+	since := now.Add(-remediationBudgetWindow)
+	total, inNamespace := 0, 0
+	restarted := make(map[string]bool)
+	for _, record := range r.operatorRemediations(ctx, podSleuth) {
+		if record.Time.Time.Before(since) || record.Actor != remediationOperatorActor {
+			continue
+		}
+		if record.Result != remediationSucceeded && record.Result != remediationFailed && record.Result != remediationDryRun {
+			continue
+		}
+		total++
+		if record.Namespace != pod.Namespace {
+			continue
+		}
+		inNamespace++
+		if record.Action == remediationRestartPod && record.OwnerKind == pod.OwnerKind && record.OwnerName == pod.OwnerName {
+			restarted[record.Pod] = true
+		}
+	}
+
+	if budget.MaxActionsPerHour != nil && total >= int(*budget.MaxActionsPerHour) {
+		return fmt.Sprintf("maxActionsPerHour exceeded: %d actions taken in the last hour", total)
+	}
+	if budget.MaxActionsPerNamespacePerHour != nil && inNamespace >= int(*budget.MaxActionsPerNamespacePerHour) {
+		return fmt.Sprintf("maxActionsPerNamespacePerHour exceeded: %d actions taken in namespace %s in the last hour", inNamespace, pod.Namespace)
+	}
+	if budget.MaxWorkloadPercent != nil && action == remediationRestartPod && pod.OwnerName != "" && !restarted[pod.Name] {
+		replicas, known := r.workloadReplicas(ctx, pod)
+		allowed := max(1, int(replicas)*int(*budget.MaxWorkloadPercent)/100)
+		if known && len(restarted) >= allowed {
+			return fmt.Sprintf("maxWorkloadPercent exceeded: %d of %d pods of %s %s/%s restarted in the last hour",
+				len(restarted), replicas, pod.OwnerKind, pod.Namespace, pod.OwnerName)
+		}
+	}
+	return ""
+}
+
+// operatorRemediations returns the remediations of all PodSleuths. Those of podSleuth
+// come from the object being reconciled, which holds actions not yet in the cache.
+func (r *PodSleuthReconciler) operatorRemediations(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth) []infrav1alpha1.RemediationRecord {
+	records := podSleuth.Status.Remediations
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := r.List(ctx, &podSleuthList); err != nil {
+		log.Log.Error(err, "failed to list PodSleuths, counting the remediation budget of one", "podsleuth", podSleuth.Name)
+		return records
+	}
+	for _, other := range podSleuthList.Items {
+		if other.Name != podSleuth.Name {
+			records = append(slices.Clip(records), other.Status.Remediations...)
+		}
+	}
+	return records
+}
+
+// workloadReplicas returns the desired replicas of the workload owning a pod, and
+// whether they are known
+func (r *PodSleuthReconciler) workloadReplicas(ctx context.Context, pod *infrav1alpha1.NonReadyPodInfo) (int32, bool) {
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.OwnerName}
+	replicasOf := func(replicas *int32) int32 {
+		if replicas == nil {
+			return 1
+		}
+		return *replicas
+	}
+	switch pod.OwnerKind {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, key, &deployment); err == nil {
+			return replicasOf(deployment.Spec.Replicas), true
+		}
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := r.Get(ctx, key, &statefulSet); err == nil {
+			return replicasOf(statefulSet.Spec.Replicas), true
+		}
+	case "ReplicaSet":
+		var replicaSet appsv1.ReplicaSet
+		if err := r.Get(ctx, key, &replicaSet); err == nil {
+			return replicasOf(replicaSet.Spec.Replicas), true
+		}
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := r.Get(ctx, key, &daemonSet); err == nil {
+			return daemonSet.Status.DesiredNumberScheduled, true
+		}
+	}
+	return 0, false
+}
+
+// lockOutRemediation stops automatic remediation after a budget was exceeded and
+// returns when it resumes
+func (r *PodSleuthReconciler) lockOutRemediation(podSleuth *infrav1alpha1.PodSleuth, reason string, now time.Time) time.Time {
+	duration := defaultRemediationLockout
+	if budget := podSleuth.Spec.Remediation.Budget; budget != nil && budget.LockoutDuration != nil {
+		duration = budget.LockoutDuration.Duration
+	}
+	until := now.Add(duration)
+	podSleuth.Status.RemediationLockout = &infrav1alpha1.RemediationLockout{
+		Since:  metav1.NewTime(now),
+		Until:  metav1.NewTime(until),
+		Reason: reason,
+	}
+	remediationLockedOut.WithLabelValues(podSleuth.Name).Set(1)
+
+	log.Log.Info("remediation locked out", "podsleuth", podSleuth.Name, "reason", reason, "until", until)
+	if r.Recorder != nil {
+		r.Recorder.Event(podSleuth, corev1.EventTypeWarning, eventReasonRemediationLockedOut,
+			truncateString(fmt.Sprintf("Automatic remediation stopped until %s: %s", until.UTC().Format(time.RFC3339), reason), maxEventMessageLength))
+	}
+	return until
+}
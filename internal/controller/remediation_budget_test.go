@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// budgetTestPodSleuth returns a PodSleuth that restarted a pod in each of the
+// namespaces at now
+func budgetTestPodSleuth(name string, now time.Time, namespaces ...string) *infrav1alpha1.PodSleuth {
+	podSleuth := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, namespace := range namespaces {
+		podSleuth.Status.Remediations = append(podSleuth.Status.Remediations, infrav1alpha1.RemediationRecord{
+			Time:      metav1.NewTime(now),
+			Namespace: namespace,
+			Pod:       "worker-" + namespace,
+			Action:    remediationRestartPod,
+			Actor:     remediationOperatorActor,
+			Result:    remediationSucceeded,
+		})
+	}
+	return podSleuth
+}
+
+func TestExceededRemediationBudgetCountsAllPodSleuths(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := infrav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	stale := budgetTestPodSleuth("payments", now)
+	other := budgetTestPodSleuth("shop", now, "shop", "shop")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale, other).WithStatusSubresource(stale, other).Build()
+	r := &PodSleuthReconciler{Client: c}
+
+	maxActions, maxInNamespace := int32(4), int32(2)
+	budget := &infrav1alpha1.RemediationBudget{MaxActionsPerHour: &maxActions, MaxActionsPerNamespacePerHour: &maxInNamespace}
+	pod := &infrav1alpha1.NonReadyPodInfo{Namespace: "payments", Name: "api-1"}
+
+	// The PodSleuth being reconciled holds an action the cache has not seen yet
+	payments := budgetTestPodSleuth("payments", now, "payments")
+	if exceeded := r.exceededRemediationBudget(context.Background(), budget, payments, remediationRestartPod, pod, now); exceeded != "" {
+		t.Errorf("3 actions exceed the budget: %s", exceeded)
+	}
+	payments = budgetTestPodSleuth("payments", now, "payments", "billing")
+	if exceeded := r.exceededRemediationBudget(context.Background(), budget, payments, remediationRestartPod, pod, now); exceeded == "" {
+		t.Error("4 actions of two PodSleuths do not exceed maxActionsPerHour")
+	}
+
+	// Namespace budgets count the actions of other PodSleuths in the namespace too
+	pod.Namespace = "shop"
+	payments = budgetTestPodSleuth("payments", now)
+	if exceeded := r.exceededRemediationBudget(context.Background(), budget, payments, remediationRestartPod, pod, now); exceeded == "" {
+		t.Error("2 actions of another PodSleuth in the namespace do not exceed maxActionsPerNamespacePerHour")
+	}
+}
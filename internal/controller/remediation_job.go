@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// Annotations on remediation Jobs naming what they were created for
+	annotationRemediationPodSleuth = "kubesleuth.io/podsleuth"
+	annotationRemediationRule      = "kubesleuth.io/remediation-rule"
+	annotationRemediationPod       = "kubesleuth.io/pod"
+
+	// defaultRemediationJobTTL is how long finished remediation Jobs are kept
+	defaultRemediationJobTTL = int32(24 * 60 * 60)
+	// maxRemediationJobPrefix leaves room for the random suffix in the 63 characters
+	// a Job name may have
+	maxRemediationJobPrefix = 57
+	// maxRemediationJobEnvValue bounds the root cause and message passed to Jobs
+	maxRemediationJobEnvValue = 4096
+)
+
+// runRemediationJob creates the Job of a rule for a pod
+func (r *PodSleuthReconciler) runRemediationJob(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, ruleName string, pod *infrav1alpha1.NonReadyPodInfo, dryRun bool) (string, error) {
+	rule := remediationRule(podSleuth, ruleName)
+	if rule == nil || rule.Job == nil {
+		return "", fmt.Errorf("rule %s has no job template", ruleName)
+	}
+
+	job := newRemediationJob(podSleuth.Name, rule, pod)
+	if err := r.checkRemediationJob(job, pod); err != nil {
+		return "", err
+	}
+	if dryRun {
+		return fmt.Sprintf("Would create Job %s/%s* for pod %s/%s", job.Namespace, job.GenerateName, pod.Namespace, pod.Name), nil
+	}
+	// Jobs are deleted with the PodSleuth that created them
+	if err := controllerutil.SetOwnerReference(podSleuth, job, r.Scheme); err != nil {
+		return "", fmt.Errorf("failed to set job owner: %w", err)
+	}
+	if err := r.Create(ctx, job); err != nil {
+		return "", fmt.Errorf("failed to create job: %w", err)
+	}
+	return fmt.Sprintf("Created Job %s/%s for pod %s/%s", job.Namespace, job.Name, pod.Namespace, pod.Name), nil
+}
+
+// checkRemediationJob refuses Jobs the operator's ClusterRole would let a PodSleuth
+// create with more privileges than the failing workload has: Jobs in namespaces other
+// than the pod's that are not allowed, and Jobs running as another ServiceAccount
+func (r *PodSleuthReconciler) checkRemediationJob(job *batchv1.Job, pod *infrav1alpha1.NonReadyPodInfo) error {
+	if job.Namespace != pod.Namespace && !slices.Contains(r.RemediationJobNamespaces, job.Namespace) {
+		return fmt.Errorf("remediation Jobs may not be created in namespace %s, see --remediation-job-namespaces", job.Namespace)
+	}
+	podSpec := &job.Spec.Template.Spec
+	for _, serviceAccount := range []string{podSpec.ServiceAccountName, podSpec.DeprecatedServiceAccount} {
+		if serviceAccount != "" && serviceAccount != "default" && !r.RemediationJobServiceAccounts {
+			return fmt.Errorf("remediation Jobs may not run as ServiceAccount %s, see --remediation-job-service-accounts", serviceAccount)
+		}
+	}
+	return nil
+}
+
+// newRemediationJob builds a rule's Job for a pod, with the pod's details in the
+// environment of every container
+func newRemediationJob(podSleuthName string, rule *infrav1alpha1.RemediationRule, pod *infrav1alpha1.NonReadyPodInfo) *batchv1.Job {
+	template := rule.Job.Template.DeepCopy()
+	job := &batchv1.Job{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+
+	prefix := job.Name
+	if prefix == "" {
+		prefix = rule.Name
+	}
+	if len(prefix) > maxRemediationJobPrefix-1 {
+		prefix = prefix[:maxRemediationJobPrefix-1]
+	}
+	job.Name = ""
+	job.GenerateName = prefix + "-"
+	job.Namespace = rule.Job.Namespace
+	if job.Namespace == "" {
+		job.Namespace = pod.Namespace
+	}
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	job.Annotations[annotationRemediationPodSleuth] = podSleuthName
+	job.Annotations[annotationRemediationRule] = rule.Name
+	job.Annotations[annotationRemediationPod] = pod.Namespace + "/" + pod.Name
+
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		ttl := defaultRemediationJobTTL
+		job.Spec.TTLSecondsAfterFinished = &ttl
+	}
+
+	rootCause := ""
+	if pod.LogAnalysis != nil {
+		rootCause = pod.LogAnalysis.RootCause
+	}
+	env := []corev1.EnvVar{
+		{Name: "KUBESLEUTH_PODSLEUTH", Value: podSleuthName},
+		{Name: "KUBESLEUTH_RULE", Value: rule.Name},
+		{Name: "KUBESLEUTH_POD_NAME", Value: pod.Name},
+		{Name: "KUBESLEUTH_POD_NAMESPACE", Value: pod.Namespace},
+		{Name: "KUBESLEUTH_OWNER_KIND", Value: pod.OwnerKind},
+		{Name: "KUBESLEUTH_OWNER_NAME", Value: pod.OwnerName},
+		{Name: "KUBESLEUTH_REASON", Value: pod.Reason},
+		{Name: "KUBESLEUTH_MESSAGE", Value: truncateString(pod.Message, maxRemediationJobEnvValue)},
+		{Name: "KUBESLEUTH_ROOT_CAUSE", Value: truncateString(rootCause, maxRemediationJobEnvValue)},
+	}
+	podSpec := &job.Spec.Template.Spec
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Env = append(podSpec.InitContainers[i].Env, env...)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, env...)
+	}
+	return job
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestCheckRemediationJob(t *testing.T) {
+	pod := &infrav1alpha1.NonReadyPodInfo{Namespace: "shop", Name: "cart-1", Reason: "CrashLoopBackOff"}
+	tests := []struct {
+		name            string
+		namespace       string
+		serviceAccount  string
+		allowNamespaces []string
+		allowAccounts   bool
+		wantErr         bool
+	}{
+		{name: "pod namespace"},
+		{name: "pod namespace, default ServiceAccount", serviceAccount: "default"},
+		{name: "other namespace", namespace: "kube-system", wantErr: true},
+		{name: "allowed namespace", namespace: "remediation", allowNamespaces: []string{"remediation"}},
+		{name: "namespace not on the allowlist", namespace: "kube-system", allowNamespaces: []string{"remediation"}, wantErr: true},
+		{name: "custom ServiceAccount", serviceAccount: "cluster-admin", wantErr: true},
+		{name: "allowed custom ServiceAccount", serviceAccount: "cart-fixer", allowAccounts: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &infrav1alpha1.RemediationRule{Name: "fix-cart", Action: remediationRunJob, Job: &infrav1alpha1.RemediationJob{
+				Namespace: tt.namespace,
+				Template: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					ServiceAccountName: tt.serviceAccount,
+					Containers:         []corev1.Container{{Name: "fix", Image: "busybox"}},
+				}}}},
+			}}
+			r := &PodSleuthReconciler{RemediationJobNamespaces: tt.allowNamespaces, RemediationJobServiceAccounts: tt.allowAccounts}
+			err := r.checkRemediationJob(newRemediationJob("production", rule, pod), pod)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRemediationJob(t *testing.T) {
+	pod := &infrav1alpha1.NonReadyPodInfo{Namespace: "shop", Name: "cart-1", OwnerKind: "Deployment", OwnerName: "cart",
+		LogAnalysis: &infrav1alpha1.LogAnalysisResult{RootCause: "Database connection refused"}}
+	rule := &infrav1alpha1.RemediationRule{Name: "fix-cart", Action: remediationRunJob, Job: &infrav1alpha1.RemediationJob{
+		Template: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "fix", Image: "busybox"}},
+		}}}},
+	}}
+
+	job := newRemediationJob("production", rule, pod)
+	if job.Namespace != "shop" || job.GenerateName != "fix-cart-" {
+		t.Errorf("job %s/%s*, want shop/fix-cart-*", job.Namespace, job.GenerateName)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever || job.Spec.TTLSecondsAfterFinished == nil {
+		t.Error("restart policy and TTL are not defaulted")
+	}
+	env := map[string]string{}
+	for _, v := range job.Spec.Template.Spec.Containers[0].Env {
+		env[v.Name] = v.Value
+	}
+	if env["KUBESLEUTH_POD_NAME"] != "cart-1" || env["KUBESLEUTH_ROOT_CAUSE"] != "Database connection refused" {
+		t.Errorf("environment %v does not describe the pod", env)
+	}
+	if len(rule.Job.Template.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Error("building the job changed the rule's template")
+	}
+}
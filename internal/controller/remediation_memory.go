@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultMemoryIncreasePercent = 25
+	defaultMinOOMKills           = 2
+
+	// What IncreaseMemory does for GitOps-managed workloads
+	gitOpsManagedNotify = "Notify"
+	gitOpsManagedPatch  = "Patch"
+
+	// oomKilledReason is the termination reason of containers exceeding their memory limit
+	oomKilledReason = "OOMKilled"
+)
+
+// remediationProposal is a change proposed for a GitOps-managed workload instead of
+// patching it, to be made in the repository the workload is deployed from
+type remediationProposal struct {
+	Summary string `json:"summary"`
+	// Application is the Argo CD Application or Flux Kustomization or HelmRelease
+	// deploying the workload, e.g. "Application argocd/shop"
+	Application string `json:"application"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Container   string `json:"container"`
+	Field       string `json:"field"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+}
+
+// increaseMemory raises the memory limit of a pod's repeatedly OOMKilled container in
+// its workload, or proposes the change if the workload is GitOps-managed
+func (r *PodSleuthReconciler) increaseMemory(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, ruleName string, pod *infrav1alpha1.NonReadyPodInfo, dryRun bool) (string, error) {
+	rule := remediationRule(podSleuth, ruleName)
+	if rule == nil || rule.MemoryIncrease == nil {
+		return "", fmt.Errorf("rule %s has no memoryIncrease", ruleName)
+	}
+	config := rule.MemoryIncrease
+	minKills := int32(defaultMinOOMKills)
+	if config.MinOOMKills != nil {
+		minKills = *config.MinOOMKills
+	}
+	containerName := oomKilledContainer(pod, int(minKills))
+	if containerName == "" {
+		return "", fmt.Errorf("%w: no container was OOMKilled %d times", errRemediationNotApplicable, minKills)
+	}
+
+	var workload client.Object
+	var template *corev1.PodTemplateSpec
+	switch pod.OwnerKind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		workload, template = deployment, &deployment.Spec.Template
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		workload, template = statefulSet, &statefulSet.Spec.Template
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		workload, template = daemonSet, &daemonSet.Spec.Template
+	default:
+		return "", fmt.Errorf("%w: memory of %s pods cannot be raised", errRemediationNotApplicable, pod.OwnerKind)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.OwnerName}, workload); err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", pod.OwnerKind, err)
+	}
+	base := workload.DeepCopyObject().(client.Object)
+
+	var container *corev1.Container
+	for _, containers := range [][]corev1.Container{template.Spec.Containers, template.Spec.InitContainers} {
+		for i := range containers {
+			if containers[i].Name == containerName {
+				container = &containers[i]
+			}
+		}
+	}
+	if container == nil {
+		return "", fmt.Errorf("container %s not found in %s %s", containerName, pod.OwnerKind, pod.OwnerName)
+	}
+	current := container.Resources.Limits[corev1.ResourceMemory]
+	if current.IsZero() {
+		return "", fmt.Errorf("container %s has no memory limit", containerName)
+	}
+	percent := int64(defaultMemoryIncreasePercent)
+	if config.Percent != nil {
+		percent = int64(*config.Percent)
+	}
+	raised := raisedMemoryLimit(current, percent, config.MaxLimit)
+	if raised.Cmp(current) <= 0 {
+		return "", fmt.Errorf("memory limit of container %s is already at the cap of %s", containerName, config.MaxLimit.String())
+	}
+	change := fmt.Sprintf("memory limit of container %s in %s %s/%s from %s to %s",
+		containerName, pod.OwnerKind, pod.Namespace, pod.OwnerName, current.String(), raised.String())
+
+	if config.GitOpsManaged != gitOpsManagedPatch {
+		argoCDNamespace := defaultArgoCDNamespace
+		if podSleuth.Spec.GitOps != nil && podSleuth.Spec.GitOps.ArgoCDNamespace != "" {
+			argoCDNamespace = podSleuth.Spec.GitOps.ArgoCDNamespace
+		}
+		if app := r.gitOpsAppFor(ctx, pod, argoCDNamespace); app != nil {
+			application := fmt.Sprintf("%s %s/%s", app.Kind, app.Namespace, app.Name)
+			if dryRun {
+				return fmt.Sprintf("Would propose raising the %s, since %s deploys it", change, application), nil
+			}
+			proposal := &remediationProposal{
+				Summary:     fmt.Sprintf("Raise the %s in the source of %s", change, application),
+				Application: application,
+				Kind:        pod.OwnerKind,
+				Namespace:   pod.Namespace,
+				Name:        pod.OwnerName,
+				Container:   containerName,
+				Field:       "resources.limits.memory",
+				From:        current.String(),
+				To:          raised.String(),
+			}
+			return r.sendRemediationProposal(podSleuth, pod, proposal), errRemediationProposed
+		}
+	}
+
+	if dryRun {
+		return "Would raise the " + change, nil
+	}
+	container.Resources.Limits[corev1.ResourceMemory] = raised
+	if err := r.Patch(ctx, workload, client.StrategicMergeFrom(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return "", fmt.Errorf("failed to patch %s: %w", pod.OwnerKind, err)
+	}
+	return "Raised the " + change, nil
+}
+
+// oomKilledContainer returns the container of a pod whose crash-loop trend shows at
+// least minKills OOMKilled terminations, or ""
+func oomKilledContainer(pod *infrav1alpha1.NonReadyPodInfo, minKills int) string {
+	trend := pod.CrashLoopTrend
+	if trend == nil {
+		return ""
+	}
+	kills := 0
+	for _, termination := range trend.Terminations {
+		if termination.Reason == oomKilledReason {
+			kills++
+		}
+	}
+	if kills < minKills {
+		return ""
+	}
+	return trend.ContainerName
+}
+
+// raisedMemoryLimit raises a memory limit by a percentage, rounded up to whole MiB and
+// capped at maxLimit
+func raisedMemoryLimit(current resource.Quantity, percent int64, maxLimit resource.Quantity) resource.Quantity {
+	const mebibyte = 1 << 20
+	raised := current.Value() * (100 + percent) / 100
+	raised = (raised + mebibyte - 1) / mebibyte * mebibyte
+	if raised > maxLimit.Value() {
+		raised = maxLimit.Value()
+	}
+	return *resource.NewQuantity(raised, resource.BinarySI)
+}
+
+// sendRemediationProposal notifies the notification sinks of a proposed change and
+// describes what was sent
+func (r *PodSleuthReconciler) sendRemediationProposal(podSleuth *infrav1alpha1.PodSleuth, pod *infrav1alpha1.NonReadyPodInfo, proposal *remediationProposal) string {
+	config := podSleuth.Spec.Notifications
+	if config == nil {
+		return "Proposed: " + proposal.Summary + " (no notification sinks are configured)"
+	}
+	n := notification{
+		Type:        notificationProposal,
+		PodSleuth:   podSleuth.Name,
+		IncidentKey: podSleuth.Name + "/" + pod.Namespace + "/" + pod.Name,
+		Pod:         *pod,
+		Pods:        []infrav1alpha1.NonReadyPodInfo{*pod},
+		ActivePods:  1,
+		Timestamp:   time.Now(),
+		Proposal:    proposal,
+	}
+	r.dispatchNotifications(podSleuth.Name, config, []notification{n})
+	return "Proposed to the notification sinks: " + proposal.Summary
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	remediationCordonNode = "CordonNode"
+	// nodeCordonRule is the rule name recorded for node cordons
+	nodeCordonRule = "nodeCordon"
+
+	defaultNodeCordonMinPods  = 5
+	defaultMaxCordonedNodes   = 1
+	maxCordonReasonAnnotation = 256
+
+	// Annotations on nodes cordoned by remediation
+	annotationCordonedBy   = "kubesleuth.io/cordoned-by"
+	annotationCordonReason = "kubesleuth.io/cordon-reason"
+
+	eventReasonNodeCordoned = "NodeCordoned"
+)
+
+// defaultNodeCordonReasons are pod reasons that point at the container runtime of a node
+var defaultNodeCordonReasons = []string{"CreateContainerError", "RunContainerError", "ContainerCannotRun"}
+
+// cordonedNode describes a node cordoned by remediation in notifications
+type cordonedNode struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// nodeFailures are the failing pods on a node
+type nodeFailures struct {
+	all []infrav1alpha1.NonReadyPodInfo
+	// attributed are the pods failing for one of the policy's reasons
+	attributed []infrav1alpha1.NonReadyPodInfo
+	evicted    int
+}
+
+// nodeProblem returns the condition that attributes pod failures to a node, or ""
+func nodeProblem(node *corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			if condition.Status != corev1.ConditionTrue {
+				return "NotReady"
+			}
+		case corev1.NodeDiskPressure, corev1.NodeMemoryPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+			if condition.Status == corev1.ConditionTrue {
+				return string(condition.Type)
+			}
+		}
+	}
+	return ""
+}
+
+// cordonNodes cordons the nodes that enough pod failures are attributed to, within the
+// limits of the policy and the remediation budget
+func (r *PodSleuthReconciler) cordonNodes(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo, dryRun bool, now time.Time) {
+	policy := podSleuth.Spec.Remediation.NodeCordon
+	if policy == nil {
+		return
+	}
+	logger := log.Log.WithName("remediation")
+	minPods := defaultNodeCordonMinPods
+	if policy.MinPods != nil {
+		minPods = int(*policy.MinPods)
+	}
+	minNonReady := defaultRemediationMinNonReady
+	if policy.MinNonReadyDuration != nil {
+		minNonReady = policy.MinNonReadyDuration.Duration
+	}
+	reasons := policy.Reasons
+	if len(reasons) == 0 {
+		reasons = defaultNodeCordonReasons
+	}
+
+	failures := make(map[string]*nodeFailures)
+	failuresOn := func(nodeName string) *nodeFailures {
+		if failures[nodeName] == nil {
+			failures[nodeName] = &nodeFailures{}
+		}
+		return failures[nodeName]
+	}
+	for i := range current {
+		pod := &current[i]
+		if pod.NodeName == "" || IsMuted(pod) || pod.DetectedAt == nil || now.Sub(pod.DetectedAt.Time) < minNonReady {
+			continue
+		}
+		f := failuresOn(pod.NodeName)
+		f.all = append(f.all, *pod)
+		if slices.Contains(reasons, pod.Reason) {
+			f.attributed = append(f.attributed, *pod)
+		}
+	}
+	for _, group := range podSleuth.Status.EvictedPods {
+		if group.NodeName != "unknown" {
+			failuresOn(group.NodeName).evicted += int(group.Count)
+		}
+	}
+
+	var candidates []string
+	for nodeName, f := range failures {
+		if len(f.all)+f.evicted >= minPods {
+			candidates = append(candidates, nodeName)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	sort.Strings(candidates)
+
+	maxCordoned := defaultMaxCordonedNodes
+	if policy.MaxCordonedNodes != nil {
+		maxCordoned = int(*policy.MaxCordonedNodes)
+	}
+	cordoned, err := r.countCordonedNodes(ctx)
+	if err != nil {
+		logger.Info("unable to count cordoned nodes", "error", err)
+		return
+	}
+	selector := labels.SelectorFromSet(policy.NodeSelector)
+
+	for _, nodeName := range candidates {
+		var node corev1.Node
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, &node); err != nil {
+			logger.Info("unable to get node", "node", nodeName, "error", err)
+			continue
+		}
+		if node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) || recentlyCordoned(podSleuth.Status.Remediations, nodeName, now) {
+			continue
+		}
+		f := failures[nodeName]
+		problem := nodeProblem(&node)
+		pods := f.attributed
+		if problem != "" {
+			pods = f.all
+		}
+		if len(pods)+f.evicted < minPods {
+			continue
+		}
+
+		reason := fmt.Sprintf("%d pods failing", len(pods))
+		if f.evicted > 0 {
+			reason += fmt.Sprintf(", %d evicted", f.evicted)
+		}
+		if problem != "" {
+			reason = fmt.Sprintf("node is %s, %s", problem, reason)
+		}
+		if cordoned >= maxCordoned {
+			logger.Info("not cordoning node, maxCordonedNodes reached", "podsleuth", podSleuth.Name, "node", nodeName, "reason", reason)
+			continue
+		}
+		target := infrav1alpha1.NonReadyPodInfo{OwnerKind: "Node", OwnerName: nodeName}
+		if exceeded := r.exceededRemediationBudget(ctx, podSleuth.Spec.Remediation.Budget, podSleuth, remediationCordonNode, &target, now); exceeded != "" {
+			r.lockOutRemediation(podSleuth, exceeded, now)
+			return
+		}
+
+		r.cordonNode(ctx, podSleuth, &node, problem, reason, pods, dryRun, now)
+		cordoned++
+	}
+}
+
+// recentlyCordoned reports whether a node was cordoned, or a cordon was attempted or
+// described in dry-run mode, within the last hour
+func recentlyCordoned(records []infrav1alpha1.RemediationRecord, nodeName string, now time.Time) bool {
+	since := now.Add(-remediationBudgetWindow)
+	for _, record := range records {
+		if record.Action == remediationCordonNode && record.Node == nodeName && !record.Time.Time.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// countCordonedNodes counts the nodes cordoned by remediation that are not uncordoned yet
+func (r *PodSleuthReconciler) countCordonedNodes(ctx context.Context) (int, error) {
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, node := range nodes.Items {
+		if _, exists := node.Annotations[annotationCordonedBy]; exists && node.Spec.Unschedulable {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// cordonNode cordons a node, records it and asks for follow-up through Events and the
+// notification sinks
+func (r *PodSleuthReconciler) cordonNode(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, node *corev1.Node, problem, reason string, pods []infrav1alpha1.NonReadyPodInfo, dryRun bool, now time.Time) {
+	record := infrav1alpha1.RemediationRecord{
+		Time:   metav1.NewTime(now),
+		Rule:   nodeCordonRule,
+		Action: remediationCordonNode,
+		Node:   node.Name,
+		Reason: problem,
+		Actor:  remediationOperatorActor,
+	}
+	if record.Reason == "" {
+		record.Reason = "PodFailures"
+	}
+	if dryRun {
+		record.Result, record.Message = remediationDryRun, fmt.Sprintf("Would cordon node %s: %s", node.Name, reason)
+		r.recordRemediation(podSleuth, record)
+		return
+	}
+
+	base := node.DeepCopy()
+	node.Spec.Unschedulable = true
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[annotationCordonedBy] = podSleuth.Name
+	node.Annotations[annotationCordonReason] = truncateString(reason, maxCordonReasonAnnotation)
+	if err := r.Patch(ctx, node, client.MergeFrom(base)); err != nil {
+		record.Result, record.Message = remediationFailed, fmt.Sprintf("failed to cordon node: %v", err)
+		r.recordRemediation(podSleuth, record)
+		return
+	}
+	message := fmt.Sprintf("Cordoned node %s: %s. Investigate it, then run kubectl uncordon %s", node.Name, reason, node.Name)
+	record.Result, record.Message = remediationSucceeded, message
+	r.recordRemediation(podSleuth, record)
+
+	if r.Recorder != nil {
+		r.Recorder.Event(node, corev1.EventTypeWarning, eventReasonNodeCordoned, truncateString(message, maxEventMessageLength))
+	}
+	if config := podSleuth.Spec.Notifications; config != nil {
+		n := notification{
+			Type:        notificationNodeCordoned,
+			PodSleuth:   podSleuth.Name,
+			IncidentKey: podSleuth.Name + "/node/" + node.Name,
+			Pods:        pods,
+			ActivePods:  len(pods),
+			Timestamp:   now,
+			Node:        &cordonedNode{Name: node.Name, Reason: reason},
+		}
+		if len(pods) > 0 {
+			n.Pod = pods[0]
+		}
+		r.dispatchNotifications(podSleuth.Name, config, []notification{n})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// remediationTestClient returns a fake client holding a PodSleuth restarting crash
+// looping pods right away and a crash looping pod, with funcs intercepting its calls
+func remediationTestClient(t *testing.T, funcs interceptor.Funcs) client.WithWatch {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := infrav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	podSleuth := &infrav1alpha1.PodSleuth{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: infrav1alpha1.PodSleuthSpec{Remediation: &infrav1alpha1.RemediationPolicy{Rules: []infrav1alpha1.RemediationRule{{
+			Name:                "restart",
+			Action:              remediationRestartPod,
+			MinNonReadyDuration: &metav1.Duration{},
+		}}}},
+	}
+	isController := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-1", OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "cart", UID: "cart", Controller: &isController}}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "cart"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{Name: "cart",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}}},
+	}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart", UID: "cart"}}
+	return fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(podSleuth, pod, replicaSet).
+		WithStatusSubresource(podSleuth).
+		WithIndex(&corev1.Pod{}, podReadyField, func(obj client.Object) []string {
+			return []string{strconv.FormatBool(isPodReady(obj.(*corev1.Pod)))}
+		}).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+func TestRemediationSurvivesStatusConflict(t *testing.T) {
+	deletes, patches := 0, 0
+	c := remediationTestClient(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if _, ok := obj.(*corev1.Pod); ok {
+				deletes++
+				// The pod stays crash looping, as if it were recreated under the same name
+				return nil
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			patches++
+			if patches == 1 {
+				// Another shard updated the status first
+				return apierrors.NewConflict(schema.GroupResource{Group: infrav1alpha1.GroupVersion.Group, Resource: "podsleuths"}, obj.GetName(), nil)
+			}
+			return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+		},
+	})
+	r := &PodSleuthReconciler{Client: c, Scheme: c.Scheme()}
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "production"}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletes != 1 || result.RequeueAfter != time.Second {
+		t.Fatalf("first reconcile restarted %d pods and requeued after %s, want 1 and 1s", deletes, result.RequeueAfter)
+	}
+
+	// The retry must neither restart the pod again nor lose the record of the restart
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if deletes != 1 {
+		t.Errorf("pod restarted %d times, want once", deletes)
+	}
+	var podSleuth infrav1alpha1.PodSleuth
+	if err := c.Get(context.Background(), req.NamespacedName, &podSleuth); err != nil {
+		t.Fatal(err)
+	}
+	if records := podSleuth.Status.Remediations; len(records) != 1 || records[0].Pod != "cart-1" || records[0].Result != remediationSucceeded {
+		t.Fatalf("got remediations %+v, want the one restart of cart-1", records)
+	}
+
+	// Once the status shows the restart it is counted from there
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if deletes != 1 || len(r.executedRemediations) != 0 {
+		t.Errorf("pod restarted %d times with %d remembered remediations, want once and none", deletes, len(r.executedRemediations))
+	}
+}
+
+func TestRestoreExecutedRemediations(t *testing.T) {
+	r := &PodSleuthReconciler{}
+	now := time.Now()
+	podSleuth := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	record := infrav1alpha1.RemediationRecord{Time: metav1.NewTime(now), Namespace: "shop", Pod: "cart-1",
+		Action: remediationRestartPod, Result: remediationSucceeded}
+	r.recordRemediation(podSleuth, record)
+
+	// A status read before the update does not show it yet
+	stale := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	r.restoreExecutedRemediations(stale, now)
+	if len(stale.Status.Remediations) != 1 {
+		t.Fatalf("got remediations %+v, want the restored restart", stale.Status.Remediations)
+	}
+
+	// The stored status only keeps seconds
+	stored := record
+	stored.Time = metav1.NewTime(now.Truncate(time.Second))
+	updated := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Status: infrav1alpha1.PodSleuthStatus{Remediations: []infrav1alpha1.RemediationRecord{stored}}}
+	r.restoreExecutedRemediations(updated, now)
+	if len(updated.Status.Remediations) != 1 || len(r.executedRemediations) != 0 {
+		t.Errorf("got remediations %+v with %d remembered, want the stored one and none", updated.Status.Remediations, len(r.executedRemediations))
+	}
+
+	// Remediations are not remembered forever
+	r.recordRemediation(podSleuth, record)
+	expired := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	r.restoreExecutedRemediations(expired, now.Add(executedRemediationRetention+time.Minute))
+	if len(expired.Status.Remediations) != 0 {
+		t.Errorf("got remediations %+v after the retention, want none", expired.Status.Remediations)
+	}
+}
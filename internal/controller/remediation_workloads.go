@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// restartedAtAnnotation is the pod template annotation kubectl rollout restart sets
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// revisionAnnotation holds the revision of a Deployment's ReplicaSet
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// rolloutRestart restarts the workload owning a pod by changing its pod template
+func (r *PodSleuthReconciler) rolloutRestart(ctx context.Context, pod *infrav1alpha1.NonReadyPodInfo, dryRun bool, now time.Time) (string, error) {
+	var workload client.Object
+	switch pod.OwnerKind {
+	case "Deployment":
+		workload = &appsv1.Deployment{}
+	case "StatefulSet":
+		workload = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		workload = &appsv1.DaemonSet{}
+	default:
+		return "", fmt.Errorf("%w: %s cannot be rollout-restarted", errRemediationNotApplicable, pod.OwnerKind)
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would restart %s %s/%s", pod.OwnerKind, pod.Namespace, pod.OwnerName), nil
+	}
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, now.UTC().Format(time.RFC3339))
+	workload.SetNamespace(pod.Namespace)
+	workload.SetName(pod.OwnerName)
+	if err := r.Patch(ctx, workload, client.RawPatch(types.StrategicMergePatchType, []byte(patch))); err != nil {
+		return "", fmt.Errorf("failed to restart %s %s: %w", pod.OwnerKind, pod.OwnerName, err)
+	}
+	return fmt.Sprintf("Restarted %s %s/%s", pod.OwnerKind, pod.Namespace, pod.OwnerName), nil
+}
+
+// rollbackDeployment rolls the Deployment owning a pod back to its previous revision.
+// The failure is attributed to the newest revision if the pod belongs to it and none
+// of its pods are ready.
+func (r *PodSleuthReconciler) rollbackDeployment(ctx context.Context, pod *infrav1alpha1.NonReadyPodInfo, dryRun bool) (string, error) {
+	if pod.OwnerKind != "Deployment" {
+		return "", fmt.Errorf("%w: only Deployments can be rolled back", errRemediationNotApplicable)
+	}
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.OwnerName}, &deployment); err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if deployment.Spec.Paused {
+		return "", fmt.Errorf("deployment is paused")
+	}
+
+	var podObject corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, &podObject); err != nil {
+		return "", fmt.Errorf("%w: pod is gone", errRemediationNotApplicable)
+	}
+	podReplicaSet := ""
+	if owner := metav1.GetControllerOf(&podObject); owner != nil && owner.Kind == "ReplicaSet" {
+		podReplicaSet = owner.Name
+	}
+
+	newest, previous, err := r.deploymentRevisions(ctx, &deployment)
+	if err != nil {
+		return "", err
+	}
+	if newest == nil || newest.Name != podReplicaSet || newest.Status.ReadyReplicas > 0 {
+		return "", fmt.Errorf("%w: failure is not attributed to the newest revision", errRemediationNotApplicable)
+	}
+	if previous == nil {
+		return "", fmt.Errorf("no previous revision to roll back to")
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would roll back Deployment %s/%s from revision %s to revision %s",
+			pod.Namespace, pod.OwnerName, newest.Annotations[revisionAnnotation], previous.Annotations[revisionAnnotation]), nil
+	}
+
+	// Like kubectl rollout undo, restore the previous pod template without the hash
+	// label the Deployment controller adds to it
+	template := previous.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	base := deployment.DeepCopy()
+	deployment.Spec.Template = *template
+	if err := r.Patch(ctx, &deployment, client.MergeFrom(base)); err != nil {
+		return "", fmt.Errorf("failed to roll back: %w", err)
+	}
+	return fmt.Sprintf("Rolled back Deployment %s/%s from revision %s to revision %s",
+		pod.Namespace, pod.OwnerName, newest.Annotations[revisionAnnotation], previous.Annotations[revisionAnnotation]), nil
+}
+
+// deploymentRevisions returns the newest and the previous ReplicaSet of a Deployment
+func (r *PodSleuthReconciler) deploymentRevisions(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.ReplicaSet, *appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	var replicaSets appsv1.ReplicaSetList
+	if err := r.List(ctx, &replicaSets, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+
+	var newest, previous *appsv1.ReplicaSet
+	newestRevision, previousRevision := int64(-1), int64(-1)
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case revision > newestRevision:
+			previous, previousRevision = newest, newestRevision
+			newest, newestRevision = rs, revision
+		case revision > previousRevision:
+			previous, previousRevision = rs, revision
+		}
+	}
+	return newest, previous, nil
+}
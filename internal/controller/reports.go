@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// reportPodSleuthField indexes cached PodSleuthReports by their PodSleuth
+	reportPodSleuthField = ".spec.podSleuth"
+	// maxReportName is the longest name an object may have
+	maxReportName = 253
+)
+
+// reportName returns the name of the report of a pod. A hash of the PodSleuth, namespace
+// and pod keeps names unique, since "-" may appear in both names; the readable part is
+// shortened if needed.
+func reportName(podSleuthName, namespace, podName string) string {
+	sum := sha256.Sum256([]byte(podSleuthName + "/" + namespace + "/" + podName))
+	suffix := "-" + hex.EncodeToString(sum[:5])
+	name := podSleuthName + "-" + podName
+	if len(name)+len(suffix) > maxReportName {
+		name = name[:maxReportName-len(suffix)]
+	}
+	return name + suffix
+}
+
+// syncReports writes the reports of non-ready pods and deletes those of pods that are
+// no longer non-ready. Returns the status entries of the pods, which name their report
+// and leave out what it holds. Pods whose report could not be written keep full entries.
+func (r *PodSleuthReconciler) syncReports(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, pods []infrav1alpha1.NonReadyPodInfo, now time.Time) []infrav1alpha1.NonReadyPodInfo {
+	logger := log.Log.WithName("reports")
+	var existing infrav1alpha1.PodSleuthReportList
+	if err := r.List(ctx, &existing, client.MatchingFields{reportPodSleuthField: podSleuth.Name}); err != nil {
+		logger.Info("unable to list PodSleuthReports", "podsleuth", podSleuth.Name, "error", err)
+		return pods
+	}
+	stale := make(map[types.NamespacedName]*infrav1alpha1.PodSleuthReport, len(existing.Items))
+	for i := range existing.Items {
+		if r.Sharding.ownsNamespace(existing.Items[i].Namespace) {
+			stale[client.ObjectKeyFromObject(&existing.Items[i])] = &existing.Items[i]
+		}
+	}
+
+	entries := make([]infrav1alpha1.NonReadyPodInfo, len(pods))
+	for i := range pods {
+		entries[i] = pods[i]
+		key := types.NamespacedName{Namespace: pods[i].Namespace, Name: reportName(podSleuth.Name, pods[i].Namespace, pods[i].Name)}
+		report := stale[key]
+		delete(stale, key)
+		if err := r.writeReport(ctx, podSleuth, key, report, &pods[i], now); err != nil {
+			logger.Info("unable to write PodSleuthReport", "podsleuth", podSleuth.Name, "report", key, "error", err)
+			continue
+		}
+		entries[i].Report = key.Name
+		summarizeReportedPod(&entries[i])
+	}
+
+	for _, report := range stale {
+		if err := r.Delete(ctx, report); err != nil && !apierrors.IsNotFound(err) {
+			logger.Info("unable to delete PodSleuthReport", "podsleuth", podSleuth.Name, "report", client.ObjectKeyFromObject(report), "error", err)
+		}
+	}
+	return entries
+}
+
+// writeReport creates the report of a pod, or updates it if the pod's analysis changed
+func (r *PodSleuthReconciler) writeReport(ctx context.Context, podSleuth *infrav1alpha1.PodSleuth, key types.NamespacedName, report *infrav1alpha1.PodSleuthReport, pod *infrav1alpha1.NonReadyPodInfo, now time.Time) error {
+	if report == nil {
+		report = &infrav1alpha1.PodSleuthReport{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: infrav1alpha1.PodSleuthReportSpec{
+				PodSleuth: podSleuth.Name,
+				Pod:       *pod.DeepCopy(),
+				UpdatedAt: metav1.NewTime(now),
+			},
+		}
+		// Reports are deleted with the PodSleuth that wrote them
+		if err := controllerutil.SetOwnerReference(podSleuth, report, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, report)
+	}
+	if jsonEqual(&report.Spec.Pod, pod) {
+		return nil
+	}
+	base := report.DeepCopy()
+	report.Spec.Pod = *pod.DeepCopy()
+	report.Spec.UpdatedAt = metav1.NewTime(now)
+	return r.Patch(ctx, report, client.MergeFrom(base))
+}
+
+// summarizeReportedPod leaves the error lines, terminations and debug check output its
+// report holds out of a pod's status entry
+func summarizeReportedPod(pod *infrav1alpha1.NonReadyPodInfo) {
+	if analysis := pod.LogAnalysis; analysis != nil && len(analysis.ErrorLines) > 0 {
+		analysis = analysis.DeepCopy()
+		analysis.ErrorLinesOmitted += int32(len(analysis.ErrorLines))
+		analysis.ErrorLines = nil
+		pod.LogAnalysis = analysis
+	}
+	if trend := pod.CrashLoopTrend; trend != nil && len(trend.Terminations) > 0 {
+		trend = trend.DeepCopy()
+		trend.Terminations = nil
+		pod.CrashLoopTrend = trend
+	}
+	if debug := pod.DebugDiagnostics; debug != nil && len(debug.Checks) > 0 {
+		debug = debug.DeepCopy()
+		for i := range debug.Checks {
+			debug.Checks[i].Output = ""
+		}
+		pod.DebugDiagnostics = debug
+	}
+}
+
+// deleteReports deletes the reports of a PodSleuth when it stops writing them
+func (r *PodSleuthReconciler) deleteReports(ctx context.Context, podSleuthName string) {
+	var existing infrav1alpha1.PodSleuthReportList
+	if err := r.List(ctx, &existing, client.MatchingFields{reportPodSleuthField: podSleuthName}); err != nil {
+		log.Log.WithName("reports").Info("unable to list PodSleuthReports", "podsleuth", podSleuthName, "error", err)
+		return
+	}
+	for i := range existing.Items {
+		if !r.Sharding.ownsNamespace(existing.Items[i].Namespace) {
+			continue
+		}
+		if err := r.Delete(ctx, &existing.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			log.Log.WithName("reports").Info("unable to delete PodSleuthReport", "podsleuth", podSleuthName,
+				"report", client.ObjectKeyFromObject(&existing.Items[i]), "error", err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReportName(t *testing.T) {
+	// Both join to "a-b-c"
+	if a, b := reportName("a", "shop", "b-c"), reportName("a-b", "shop", "c"); a == b {
+		t.Errorf("reports of different pods share the name %s", a)
+	}
+	if name := reportName("a", "shop", "b-c"); !strings.HasPrefix(name, "a-b-c-") {
+		t.Errorf("name %s does not start with the PodSleuth and pod", name)
+	}
+	if name := reportName("a", "shop", "b-c"); name != reportName("a", "shop", "b-c") {
+		t.Errorf("name %s is not stable", name)
+	}
+
+	long := strings.Repeat("p", 250)
+	first, second := reportName("sleuth", "shop", long+"-1"), reportName("sleuth", "shop", long+"-2")
+	if len(first) != maxReportName || first == second {
+		t.Errorf("long names %s and %s are not shortened to unique names of %d characters", first, second, maxReportName)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ScanOptions configures a one-shot scan of a cluster
+type ScanOptions struct {
+	// Spec is used like the spec of a PodSleuth: its pod label selector, log analysis
+	// and ownership rules apply. Other features of the spec are not run.
+	Spec infrav1alpha1.PodSleuthSpec
+	// Namespaces limits the scan to these namespaces; all namespaces if empty
+	Namespaces []string
+	// AnalysisTimeout limits a single log analysis. 0 means no limit.
+	AnalysisTimeout time.Duration
+}
+
+// ScanReport is the result of a one-shot scan
+type ScanReport struct {
+	Cluster      string                          `json:"cluster,omitempty"`
+	ScannedAt    metav1.Time                     `json:"scannedAt"`
+	Namespaces   []string                        `json:"namespaces,omitempty"`
+	PodsScanned  int                             `json:"podsScanned"`
+	NonReadyPods []infrav1alpha1.NonReadyPodInfo `json:"nonReadyPods,omitempty"`
+	EvictedPods  []infrav1alpha1.EvictedPodInfo  `json:"evictedPods,omitempty"`
+	Errors       []string                        `json:"errors,omitempty"`
+}
+
+// DefaultScanSpec returns the spec scans use without one of their own: log analysis
+// with the default patterns
+func DefaultScanSpec() infrav1alpha1.PodSleuthSpec {
+	return infrav1alpha1.PodSleuthSpec{
+		LogAnalysis: &infrav1alpha1.LogAnalysisConfig{Enabled: true, Method: "pattern"},
+	}
+}
+
+// Scan detects and analyzes the non-ready pods of a cluster once, without the
+// PodSleuth CRD or a running operator. It only reads from the cluster: nothing is
+// cached, stored in a status, recorded or notified. Analysis failures of single pods
+// are reported in the errors of the report instead of failing the scan.
+func Scan(ctx context.Context, c client.Client, k8sClient kubernetes.Interface, options ScanOptions) (*ScanReport, error) {
+	logger := log.FromContext(ctx)
+	// The reconciler is only used for its read-only investigation helpers
+	r := &PodSleuthReconciler{Client: c, K8sClient: k8sClient}
+
+	var listOptions []client.ListOption
+	if options.Spec.PodLabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(options.Spec.PodLabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod label selector: %w", err)
+		}
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+	var pods []corev1.Pod
+	namespaces := options.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range namespaces {
+		var podList corev1.PodList
+		if err := c.List(ctx, &podList, append(listOptions, client.InNamespace(namespace))...); err != nil {
+			return nil, fmt.Errorf("unable to list pods: %w", err)
+		}
+		pods = append(pods, podList.Items...)
+	}
+
+	ownershipRules, ruleErrs := compileOwnershipRules(options.Spec.OwnershipRules)
+	report := &ScanReport{
+		Cluster:     ClusterName(),
+		ScannedAt:   metav1.Now(),
+		Namespaces:  options.Namespaces,
+		PodsScanned: len(pods),
+	}
+	for _, err := range ruleErrs {
+		report.Errors = append(report.Errors, fmt.Sprintf("ignoring invalid ownership rule: %v", err))
+	}
+	logAnalysis := options.Spec.LogAnalysis
+	// Identical failures share one AI request within the scan
+	aiOpts := &aiRequestOptions{Batch: newAIBatch()}
+
+	for i := range pods {
+		pod := &pods[i]
+		if isPodReady(pod) {
+			continue
+		}
+		ownerKind, ownerName := r.getPodOwner(ctx, pod)
+		if isEvictedOrShutdown(pod) {
+			report.EvictedPods = append(report.EvictedPods, newEvictedPodInfo(pod, ownerKind, ownerName))
+			continue
+		}
+
+		reason, message, containerErrors, conditions := r.investigatePodFailure(pod)
+		meshDiagnosis, meshReason, meshMessage := diagnoseMesh(pod)
+		if meshReason != "" {
+			reason, message = meshReason, meshMessage
+		} else if meshMessage != "" {
+			message = strings.TrimSuffix(message, ".") + ". " + meshMessage
+		}
+		podInfo := infrav1alpha1.NonReadyPodInfo{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			Cluster:         ClusterName(),
+			Phase:           string(pod.Status.Phase),
+			OwnerKind:       ownerKind,
+			OwnerName:       ownerName,
+			NodeName:        pod.Spec.NodeName,
+			Team:            teamForPod(ownershipRules, pod),
+			CreatedAt:       pod.CreationTimestamp.DeepCopy(),
+			DetectedAt:      report.ScannedAt.DeepCopy(),
+			NotReadySince:   notReadySince(pod),
+			Reserved:        podReservation(pod),
+			Reason:          reason,
+			Message:         message,
+			ContainerErrors: containerErrors,
+			PodConditions:   conditions,
+			Mesh:            meshDiagnosis,
+		}
+
+		// Succeeded pods are already finished, like in reconciles
+		if logAnalysis != nil && logAnalysis.Enabled && pod.Status.Phase != corev1.PodSucceeded {
+			logger.Info("analyzing pod", "pod", pod.Name, "namespace", pod.Namespace)
+			analysisCtx, cancel := ctx, context.CancelFunc(func() {})
+			if options.AnalysisTimeout > 0 {
+				analysisCtx, cancel = context.WithTimeout(ctx, options.AnalysisTimeout)
+			}
+			result, err := analyzeLogs(analysisCtx, c, k8sClient, pod, logAnalysis, aiOpts)
+			cancel()
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s/%s: log analysis failed: %v", pod.Namespace, pod.Name, err))
+			}
+			podInfo.LogAnalysis = result
+		}
+		report.NonReadyPods = append(report.NonReadyPods, podInfo)
+	}
+	return report, nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// How work is split between shards
+const (
+	// ShardByPodSleuth runs each PodSleuth on one shard
+	ShardByPodSleuth = "podsleuth"
+	// ShardByNamespace runs every PodSleuth on all shards, each for the pods in its share
+	// of the namespaces
+	ShardByNamespace = "namespace"
+
+	// LabelShard assigns a PodSleuth to a shard instead of the hash of its name
+	LabelShard = "kubesleuth.io/shard"
+)
+
+// maxShardStatusPatches bounds the attempts to update a status other shards update too
+const maxShardStatusPatches = 3
+
+// Sharding splits the work of the operator between replicas, each running one shard
+type Sharding struct {
+	// Shards is the number of shards (0 or 1 = no sharding)
+	Shards int
+	// Index is the shard of this replica, from 0 to Shards-1
+	Index int
+	// By is ShardByPodSleuth or ShardByNamespace
+	By string
+}
+
+// Enabled reports whether work is split between shards
+func (s Sharding) Enabled() bool {
+	return s.Shards > 1
+}
+
+// shardOf hashes a key to a shard
+func shardOf(key string, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// PodSleuthShard returns the shard running a PodSleuth when sharding by PodSleuth
+func (s Sharding) PodSleuthShard(podSleuth client.Object) int {
+	if value, exists := podSleuth.GetLabels()[LabelShard]; exists {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 {
+			return shard % s.Shards
+		}
+	}
+	return shardOf(podSleuth.GetName(), s.Shards)
+}
+
+// ownsPodSleuth reports whether this shard runs a PodSleuth
+func (s Sharding) ownsPodSleuth(podSleuth client.Object) bool {
+	if !s.Enabled() || s.By != ShardByPodSleuth {
+		return true
+	}
+	return s.PodSleuthShard(podSleuth) == s.Index
+}
+
+// ownsNamespace reports whether this shard handles the pods of a namespace
+func (s Sharding) ownsNamespace(namespace string) bool {
+	if !s.byNamespace() {
+		return true
+	}
+	return shardOf(namespace, s.Shards) == s.Index
+}
+
+// byNamespace reports whether shards split the namespaces of every PodSleuth
+func (s Sharding) byNamespace() bool {
+	return s.Enabled() && s.By == ShardByNamespace
+}
+
+// ownsClusterWork reports whether this shard does the work of a PodSleuth that spans
+// namespaces, like cordoning nodes
+func (s Sharding) ownsClusterWork() bool {
+	return !s.byNamespace() || s.Index == 0
+}
+
+// ownedPodSleuths passes the events of the PodSleuths this shard runs, and of those
+// moved to another shard so they can be forgotten
+func (s Sharding) ownedPodSleuths() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return s.ownsPodSleuth(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return s.ownsPodSleuth(e.ObjectOld) || s.ownsPodSleuth(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return s.ownsPodSleuth(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return s.ownsPodSleuth(e.Object)
+		},
+	}
+}
+
+// splitPods splits status entries into those of this shard's namespaces and the others
+func (s Sharding) splitPods(pods []infrav1alpha1.NonReadyPodInfo) ([]infrav1alpha1.NonReadyPodInfo, []infrav1alpha1.NonReadyPodInfo) {
+	if !s.byNamespace() {
+		return pods, nil
+	}
+	var owned, foreign []infrav1alpha1.NonReadyPodInfo
+	for _, pod := range pods {
+		if s.ownsNamespace(pod.Namespace) {
+			owned = append(owned, pod)
+		} else {
+			foreign = append(foreign, pod)
+		}
+	}
+	return owned, foreign
+}
+
+// mergePods adds the entries other shards stored to this shard's entries. Entries are
+// sorted so that shards write the same status for the same pods.
+func (s Sharding) mergePods(owned, foreign []infrav1alpha1.NonReadyPodInfo) []infrav1alpha1.NonReadyPodInfo {
+	if !s.byNamespace() {
+		return owned
+	}
+	pods := append(append([]infrav1alpha1.NonReadyPodInfo{}, owned...), foreign...)
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods
+}
+
+// mergeEvictedPods adds the evicted pods other shards stored to this shard's
+func (s Sharding) mergeEvictedPods(owned []evictedPod, stored []infrav1alpha1.EvictedPodGroup) []evictedPod {
+	if !s.byNamespace() {
+		return owned
+	}
+	pods := append([]evictedPod{}, owned...)
+	for _, group := range stored {
+		for _, pod := range group.Pods {
+			if !s.ownsNamespace(pod.Namespace) {
+				pods = append(pods, evictedPod{NodeName: group.NodeName, Info: pod})
+			}
+		}
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].Info.Namespace != pods[j].Info.Namespace {
+			return pods[i].Info.Namespace < pods[j].Info.Namespace
+		}
+		return pods[i].Info.Name < pods[j].Info.Name
+	})
+	return pods
+}
+
+// mergeWorkloads adds the workloads other shards stored to this shard's
+func (s Sharding) mergeWorkloads(owned, stored []infrav1alpha1.WorkloadContext) []infrav1alpha1.WorkloadContext {
+	if !s.byNamespace() {
+		return owned
+	}
+	workloads := append([]infrav1alpha1.WorkloadContext{}, owned...)
+	for _, workload := range stored {
+		if !s.ownsNamespace(workload.Namespace) {
+			workloads = append(workloads, workload)
+		}
+	}
+	sort.SliceStable(workloads, func(i, j int) bool {
+		if workloads[i].Namespace != workloads[j].Namespace {
+			return workloads[i].Namespace < workloads[j].Namespace
+		}
+		if workloads[i].Kind != workloads[j].Kind {
+			return workloads[i].Kind < workloads[j].Kind
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+	return workloads
+}
+
+// rebaseStatus applies this shard's share of a status onto the status other shards
+// stored: their pods, evicted pods, workloads and pending remediations are taken from
+// stored, as are the remediation records, to which callers add those of this shard
+func (s Sharding) rebaseStatus(status, stored *infrav1alpha1.PodSleuthStatus) infrav1alpha1.PodSleuthStatus {
+	rebased := *status.DeepCopy()
+	owned, _ := s.splitPods(rebased.NonReadyPods)
+	_, foreign := s.splitPods(stored.NonReadyPods)
+	rebased.NonReadyPods = s.mergePods(owned, foreign)
+
+	var evicted []evictedPod
+	for _, group := range rebased.EvictedPods {
+		for _, pod := range group.Pods {
+			if s.ownsNamespace(pod.Namespace) {
+				evicted = append(evicted, evictedPod{NodeName: group.NodeName, Info: pod})
+			}
+		}
+	}
+	rebased.EvictedPods = groupEvictedPods(s.mergeEvictedPods(evicted, stored.EvictedPods))
+
+	var workloads []infrav1alpha1.WorkloadContext
+	for _, workload := range rebased.Workloads {
+		if s.ownsNamespace(workload.Namespace) {
+			workloads = append(workloads, workload)
+		}
+	}
+	rebased.Workloads = s.mergeWorkloads(workloads, stored.Workloads)
+
+	var pending []infrav1alpha1.PendingRemediation
+	for _, action := range stored.PendingRemediations {
+		if !s.ownsNamespace(action.Namespace) {
+			pending = append(pending, action)
+		}
+	}
+	for _, action := range rebased.PendingRemediations {
+		if s.ownsNamespace(action.Namespace) {
+			pending = append(pending, action)
+		}
+	}
+	rebased.PendingRemediations = pending
+
+	rebased.Remediations = slices.Clone(stored.Remediations)
+	return rebased
+}
+
+// foreignRemediationIDs returns the IDs of pending remediations other shards handle
+func (s Sharding) foreignRemediationIDs(pending []infrav1alpha1.PendingRemediation) map[string]bool {
+	ids := make(map[string]bool)
+	for _, action := range pending {
+		if !s.ownsNamespace(action.Namespace) {
+			ids[action.ID] = true
+		}
+	}
+	return ids
+}
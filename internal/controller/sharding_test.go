@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// shardingTestNamespaces returns a namespace of shard 0 and one of shard 1 of two
+// shards split by namespace
+func shardingTestNamespaces() (string, string) {
+	var namespaces [2]string
+	for i := 0; namespaces[0] == "" || namespaces[1] == ""; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		if shard := shardOf(namespace, 2); namespaces[shard] == "" {
+			namespaces[shard] = namespace
+		}
+	}
+	return namespaces[0], namespaces[1]
+}
+
+// shardingTestStatus returns a status with a pod, evicted pod, workload, pending and
+// taken remediation in a namespace
+func shardingTestStatus(namespace string, now time.Time) infrav1alpha1.PodSleuthStatus {
+	return infrav1alpha1.PodSleuthStatus{
+		NonReadyPods: []infrav1alpha1.NonReadyPodInfo{{Namespace: namespace, Name: "api-1"}},
+		EvictedPods: groupEvictedPods([]evictedPod{{NodeName: "node-1",
+			Info: infrav1alpha1.EvictedPodInfo{Namespace: namespace, Name: "batch-1"}}}),
+		Workloads:           []infrav1alpha1.WorkloadContext{{Namespace: namespace, Kind: "Deployment", Name: "api"}},
+		PendingRemediations: []infrav1alpha1.PendingRemediation{{ID: namespace, Namespace: namespace, Pod: "api-1"}},
+		Remediations: []infrav1alpha1.RemediationRecord{{Time: metav1.NewTime(now), Namespace: namespace, Pod: "api-1",
+			Action: remediationRestartPod, Result: remediationSucceeded}},
+	}
+}
+
+func TestRebaseStatus(t *testing.T) {
+	owned, foreign := shardingTestNamespaces()
+	sharding := Sharding{Shards: 2, Index: 0, By: ShardByNamespace}
+	now := time.Now()
+
+	// This shard's status still holds what the other shard stored before
+	status := shardingTestStatus(owned, now)
+	status.NonReadyPods = append(status.NonReadyPods, infrav1alpha1.NonReadyPodInfo{Namespace: foreign, Name: "stale-1"})
+	stored := shardingTestStatus(foreign, now)
+	rebased := sharding.rebaseStatus(&status, &stored)
+
+	for _, tt := range []struct {
+		field      string
+		namespaces []string
+	}{
+		{field: "nonReadyPods", namespaces: namespacesOf(rebased.NonReadyPods, func(pod infrav1alpha1.NonReadyPodInfo) string { return pod.Namespace })},
+		{field: "evictedPods", namespaces: namespacesOf(rebased.EvictedPods[0].Pods, func(pod infrav1alpha1.EvictedPodInfo) string { return pod.Namespace })},
+		{field: "workloads", namespaces: namespacesOf(rebased.Workloads, func(workload infrav1alpha1.WorkloadContext) string { return workload.Namespace })},
+		{field: "pendingRemediations", namespaces: namespacesOf(rebased.PendingRemediations, func(action infrav1alpha1.PendingRemediation) string { return action.Namespace })},
+	} {
+		if len(tt.namespaces) != 2 || !containsAll(tt.namespaces, owned, foreign) {
+			t.Errorf("got %s of namespaces %v, want one of %s and one of %s", tt.field, tt.namespaces, owned, foreign)
+		}
+	}
+	// Remediation records are those stored, this shard adds its own
+	if len(rebased.Remediations) != 1 || rebased.Remediations[0].Namespace != foreign {
+		t.Errorf("got remediations %+v, want the stored one of %s", rebased.Remediations, foreign)
+	}
+}
+
+func namespacesOf[T any](items []T, namespace func(T) string) []string {
+	var namespaces []string
+	for _, item := range items {
+		namespaces = append(namespaces, namespace(item))
+	}
+	return namespaces
+}
+
+func containsAll(values []string, wanted ...string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, value := range values {
+			found = found || value == want
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPatchStatusRetriesConflictsOfShards(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := infrav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	owned, foreign := shardingTestNamespaces()
+	now := time.Now()
+	podSleuth := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(podSleuth).WithStatusSubresource(podSleuth).Build()
+	r := &PodSleuthReconciler{Client: c, Sharding: Sharding{Shards: 2, Index: 0, By: ShardByNamespace}}
+	ctx := context.Background()
+
+	// This shard read the PodSleuth, then the other shard updated its status
+	if err := c.Get(ctx, client.ObjectKeyFromObject(podSleuth), podSleuth); err != nil {
+		t.Fatal(err)
+	}
+	base := podSleuth.DeepCopy()
+	other := podSleuth.DeepCopy()
+	other.Status = shardingTestStatus(foreign, now)
+	if err := c.Status().Update(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+
+	status := shardingTestStatus(owned, now)
+	podSleuth.Status = status
+	podSleuth.Status.Remediations = nil
+	r.recordRemediation(podSleuth, status.Remediations[0])
+	if err := r.patchStatus(ctx, podSleuth, base, now); err != nil {
+		t.Fatalf("status update did not retry the conflict: %v", err)
+	}
+
+	var stored infrav1alpha1.PodSleuth
+	if err := c.Get(ctx, client.ObjectKeyFromObject(podSleuth), &stored); err != nil {
+		t.Fatal(err)
+	}
+	pods := namespacesOf(stored.Status.NonReadyPods, func(pod infrav1alpha1.NonReadyPodInfo) string { return pod.Namespace })
+	records := namespacesOf(stored.Status.Remediations, func(record infrav1alpha1.RemediationRecord) string { return record.Namespace })
+	if len(pods) != 2 || !containsAll(pods, owned, foreign) || len(records) != 2 || !containsAll(records, owned, foreign) {
+		t.Errorf("stored pods of %v and remediations of %v, want those of both shards", pods, records)
+	}
+}
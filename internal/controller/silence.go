@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// silenceMatcher is an unexpired SleuthSilence with its regular expressions compiled
+type silenceMatcher struct {
+	name      string
+	namespace string
+	reason    string
+	podRegex  *regexp.Regexp
+	pattern   *regexp.Regexp
+	expiresAt time.Time
+}
+
+// newSilenceMatcher compiles a SleuthSilence. Silences without any matcher are rejected
+// so a single empty object cannot silence every pod in the cluster.
+func newSilenceMatcher(silence *infrav1alpha1.SleuthSilence) (*silenceMatcher, error) {
+	spec := silence.Spec
+	if spec.Namespace == "" && spec.PodRegex == "" && spec.Reason == "" && spec.Pattern == "" {
+		return nil, fmt.Errorf("at least one of namespace, podRegex, reason or pattern is required")
+	}
+
+	matcher := &silenceMatcher{
+		name:      silence.Name,
+		namespace: spec.Namespace,
+		reason:    spec.Reason,
+		expiresAt: spec.ExpiresAt.Time,
+	}
+	var err error
+	if spec.PodRegex != "" {
+		if matcher.podRegex, err = regexp.Compile(spec.PodRegex); err != nil {
+			return nil, fmt.Errorf("invalid podRegex: %w", err)
+		}
+	}
+	if spec.Pattern != "" {
+		if matcher.pattern, err = regexp.Compile(spec.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	return matcher, nil
+}
+
+// matches reports whether a non-ready pod is covered by the silence
+func (m *silenceMatcher) matches(pod *infrav1alpha1.NonReadyPodInfo) bool {
+	if m.namespace != "" && pod.Namespace != m.namespace {
+		return false
+	}
+	if m.podRegex != nil && !m.podRegex.MatchString(pod.Name) {
+		return false
+	}
+	if m.reason != "" && !podHasReason(pod, m.reason) {
+		return false
+	}
+	if m.pattern != nil {
+		texts := []string{pod.Message}
+		for _, ce := range pod.ContainerErrors {
+			texts = append(texts, ce.Message)
+		}
+		if pod.LogAnalysis != nil {
+			texts = append(texts, pod.LogAnalysis.RootCause, pod.LogAnalysis.MatchedPattern)
+			if pod.LogAnalysis.PatternResult != nil {
+				texts = append(texts, pod.LogAnalysis.PatternResult.MatchedPattern)
+			}
+		}
+		matched := false
+		for _, text := range texts {
+			if text != "" && m.pattern.MatchString(text) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// podHasReason reports whether the pod or any of its containers failed with the reason
+func podHasReason(pod *infrav1alpha1.NonReadyPodInfo, reason string) bool {
+	if pod.Reason == reason {
+		return true
+	}
+	for _, ce := range pod.ContainerErrors {
+		if ce.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// activeSilences lists the unexpired SleuthSilences. It also returns the earliest expiry,
+// so the PodSleuth is reconciled when a silence lapses. Invalid silences are skipped.
+func (r *PodSleuthReconciler) activeSilences(ctx context.Context, now time.Time) ([]*silenceMatcher, *time.Time, error) {
+	var list infrav1alpha1.SleuthSilenceList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, nil, err
+	}
+
+	var matchers []*silenceMatcher
+	var nextExpiry *time.Time
+	for i := range list.Items {
+		silence := &list.Items[i]
+		if !silence.Spec.ExpiresAt.Time.After(now) {
+			continue
+		}
+		matcher, err := newSilenceMatcher(silence)
+		if err != nil {
+			log.Log.Info("ignoring invalid SleuthSilence", "silence", silence.Name, "error", err)
+			continue
+		}
+		matchers = append(matchers, matcher)
+		if nextExpiry == nil || matcher.expiresAt.Before(*nextExpiry) {
+			nextExpiry = &matcher.expiresAt
+		}
+	}
+	return matchers, nextExpiry, nil
+}
+
+// silencingSilence returns the name of the first silence matching a non-ready pod
+func silencingSilence(silences []*silenceMatcher, pod *infrav1alpha1.NonReadyPodInfo) string {
+	for _, silence := range silences {
+		if silence.matches(pod) {
+			return silence.name
+		}
+	}
+	return ""
+}
+
+// findObjectsForSilence re-evaluates every PodSleuth when a silence changes
+func (r *PodSleuthReconciler) findObjectsForSilence(ctx context.Context, _ client.Object) []reconcile.Request {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := r.List(ctx, &podSleuthList); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(podSleuthList.Items))
+	for _, podSleuth := range podSleuthList.Items {
+		if !r.Sharding.ownsPodSleuth(&podSleuth) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{Name: podSleuth.Name},
+		})
+	}
+	return requests
+}
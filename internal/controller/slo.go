@@ -0,0 +1,373 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultSLOWindow is the rolling window of SLOs by default
+	DefaultSLOWindow = 7 * 24 * time.Hour
+	// maxSLOIncidents bounds the incidents kept in the status; the oldest resolved ones
+	// are dropped first
+	maxSLOIncidents = 500
+)
+
+// Event reasons of availability objectives
+const (
+	eventReasonSLOBreached = "SLOBreached"
+	eventReasonSLOMet      = "SLOMet"
+)
+
+// WorkloadSLO is the incident record of a workload over the SLO window
+type WorkloadSLO struct {
+	Namespace string `json:"namespace"`
+	// Workload is the owner of the pods as kind/name, or Pod/name for a pod without owner
+	Workload string `json:"workload"`
+	// Incidents counts the incidents of the window, Ongoing is set while one lasts
+	Incidents int  `json:"incidents"`
+	Ongoing   bool `json:"ongoing,omitempty"`
+	// MeanTimeToDetectSeconds is the mean time from the first pod failing to the PodSleuth
+	// finding it, and MeanTimeToRecoverSeconds the mean time from the first pod failing
+	// to the recovery, of resolved incidents
+	MeanTimeToDetectSeconds  int64 `json:"meanTimeToDetectSeconds"`
+	MeanTimeToRecoverSeconds int64 `json:"meanTimeToRecoverSeconds"`
+	// DowntimeSeconds is how long incidents lasted within the window
+	DowntimeSeconds int64 `json:"downtimeSeconds"`
+	// Availability is the percentage of the window without an incident
+	Availability float64 `json:"availability"`
+	// Breaching is set when Availability is below the objective
+	Breaching bool `json:"breaching,omitempty"`
+}
+
+// NamespaceSLO aggregates the incidents of the workloads of a namespace
+type NamespaceSLO struct {
+	Namespace                string `json:"namespace"`
+	Incidents                int    `json:"incidents"`
+	MeanTimeToDetectSeconds  int64  `json:"meanTimeToDetectSeconds"`
+	MeanTimeToRecoverSeconds int64  `json:"meanTimeToRecoverSeconds"`
+	// Workloads counts the workloads with incidents, BreachingWorkloads those below the
+	// objective
+	Workloads          int `json:"workloads"`
+	BreachingWorkloads int `json:"breachingWorkloads"`
+}
+
+// SLOReport is the MTTR and availability of the workloads of a PodSleuth over its window
+type SLOReport struct {
+	PodSleuth string `json:"podSleuth"`
+	// WindowSeconds is the rolling window, shortened to the age of the PodSleuth
+	WindowSeconds int64 `json:"windowSeconds"`
+	// AvailabilityObjective is the objective in percent, 0 if none is configured
+	AvailabilityObjective float64        `json:"availabilityObjective,omitempty"`
+	Workloads             []WorkloadSLO  `json:"workloads"`
+	Namespaces            []NamespaceSLO `json:"namespaces"`
+}
+
+// sloWindow returns the rolling window of an SLO configuration
+func sloWindow(config *infrav1alpha1.SLOConfig) time.Duration {
+	if config.Window != nil && config.Window.Duration > 0 {
+		return config.Window.Duration
+	}
+	return DefaultSLOWindow
+}
+
+// podWorkload names the workload of a pod as kind/name, or Pod/name for a pod without
+// owner
+func podWorkload(ownerKind, ownerName, podName string) string {
+	if ownerKind == "" {
+		return "Pod/" + podName
+	}
+	return ownerKind + "/" + ownerName
+}
+
+// sloIncidentKey identifies the workload of an incident
+func sloIncidentKey(namespace, workload string) string {
+	return namespace + "/" + workload
+}
+
+// trackSLOIncidents opens incidents for workloads whose pods became non-ready and
+// resolves those whose pods are all ready again. Resolved incidents that ended before
+// the window are dropped.
+func trackSLOIncidents(stored []infrav1alpha1.SLOIncident, current []infrav1alpha1.NonReadyPodInfo, window time.Duration, now metav1.Time) []infrav1alpha1.SLOIncident {
+	failing := map[string]*infrav1alpha1.SLOIncident{}
+	for i := range current {
+		pod := &current[i]
+		if IsMuted(pod) {
+			continue
+		}
+		workload := podWorkload(pod.OwnerKind, pod.OwnerName, pod.Name)
+		detectedAt := now
+		if pod.DetectedAt != nil {
+			detectedAt = *pod.DetectedAt
+		}
+		failedAt := detectedAt
+		if pod.NotReadySince != nil && pod.NotReadySince.Before(&detectedAt) {
+			failedAt = *pod.NotReadySince
+		}
+		key := sloIncidentKey(pod.Namespace, workload)
+		if seen, exists := failing[key]; exists {
+			if failedAt.Before(&seen.FailedAt) {
+				seen.FailedAt, seen.Reason = failedAt, pod.Reason
+			}
+			if detectedAt.Before(&seen.DetectedAt) {
+				seen.DetectedAt = detectedAt
+			}
+			continue
+		}
+		failing[key] = &infrav1alpha1.SLOIncident{Namespace: pod.Namespace, Workload: workload, Reason: pod.Reason,
+			FailedAt: failedAt, DetectedAt: detectedAt}
+	}
+
+	incidents := make([]infrav1alpha1.SLOIncident, 0, len(stored)+len(failing))
+	for _, incident := range stored {
+		if incident.RecoveredAt == nil {
+			key := sloIncidentKey(incident.Namespace, incident.Workload)
+			if _, ongoing := failing[key]; ongoing {
+				delete(failing, key)
+			} else {
+				incident.RecoveredAt = now.DeepCopy()
+			}
+		}
+		incidents = append(incidents, incident)
+	}
+	for _, opened := range failing {
+		incidents = append(incidents, *opened)
+	}
+	slices.SortStableFunc(incidents, func(a, b infrav1alpha1.SLOIncident) int { return a.FailedAt.Time.Compare(b.FailedAt.Time) })
+
+	cutoff := now.Add(-window)
+	incidents = slices.DeleteFunc(incidents, func(i infrav1alpha1.SLOIncident) bool {
+		return i.RecoveredAt != nil && i.RecoveredAt.Time.Before(cutoff)
+	})
+	for excess := len(incidents) - maxSLOIncidents; excess > 0; excess-- {
+		resolved := slices.IndexFunc(incidents, func(i infrav1alpha1.SLOIncident) bool { return i.RecoveredAt != nil })
+		if resolved < 0 {
+			break
+		}
+		incidents = slices.Delete(incidents, resolved, resolved+1)
+	}
+	return incidents
+}
+
+// SLOReportOf computes the MTTR and availability of the workloads of a PodSleuth from
+// the incidents in its status, or returns nil if it does not track SLOs
+func SLOReportOf(podSleuth *infrav1alpha1.PodSleuth, now time.Time) *SLOReport {
+	config := podSleuth.Spec.SLO
+	if config == nil {
+		return nil
+	}
+	start := now.Add(-sloWindow(config))
+	if created := podSleuth.CreationTimestamp.Time; created.After(start) && created.Before(now) {
+		start = created
+	}
+	window := now.Sub(start)
+	report := &SLOReport{PodSleuth: podSleuth.Name, WindowSeconds: int64(window.Seconds()),
+		Workloads: []WorkloadSLO{}, Namespaces: []NamespaceSLO{}}
+	objective, _ := strconv.ParseFloat(config.AvailabilityObjective, 64)
+	report.AvailabilityObjective = objective
+
+	type totals struct {
+		detect, recover time.Duration
+		resolved        int
+	}
+	workloads := map[string]*WorkloadSLO{}
+	workloadTotals := map[string]*totals{}
+	var keys []string
+	if podSleuth.Status.SLO != nil {
+		for _, incident := range podSleuth.Status.SLO.Incidents {
+			end := now
+			if incident.RecoveredAt != nil {
+				end = incident.RecoveredAt.Time
+			}
+			if end.Before(start) {
+				continue
+			}
+			key := sloIncidentKey(incident.Namespace, incident.Workload)
+			workload, exists := workloads[key]
+			if !exists {
+				workload = &WorkloadSLO{Namespace: incident.Namespace, Workload: incident.Workload}
+				workloads[key], workloadTotals[key] = workload, &totals{}
+				keys = append(keys, key)
+			}
+			t := workloadTotals[key]
+			workload.Incidents++
+			t.detect += incident.DetectedAt.Sub(incident.FailedAt.Time)
+			if incident.RecoveredAt == nil {
+				workload.Ongoing = true
+			} else {
+				t.recover += end.Sub(incident.FailedAt.Time)
+				t.resolved++
+			}
+			workload.DowntimeSeconds += int64(end.Sub(later(incident.FailedAt.Time, start)).Seconds())
+		}
+	}
+
+	namespaces := map[string]*NamespaceSLO{}
+	namespaceTotals := map[string]*totals{}
+	var namespaceNames []string
+	slices.Sort(keys)
+	for _, key := range keys {
+		workload, t := workloads[key], workloadTotals[key]
+		workload.MeanTimeToDetectSeconds = meanSeconds(t.detect, workload.Incidents)
+		workload.MeanTimeToRecoverSeconds = meanSeconds(t.recover, t.resolved)
+		workload.Availability = 100
+		if window > 0 {
+			workload.Availability = max(0, 100*(1-float64(workload.DowntimeSeconds)/window.Seconds()))
+		}
+		workload.Breaching = objective > 0 && workload.Availability < objective
+		report.Workloads = append(report.Workloads, *workload)
+
+		namespace, exists := namespaces[workload.Namespace]
+		if !exists {
+			namespace = &NamespaceSLO{Namespace: workload.Namespace}
+			namespaces[workload.Namespace], namespaceTotals[workload.Namespace] = namespace, &totals{}
+			namespaceNames = append(namespaceNames, workload.Namespace)
+		}
+		nt := namespaceTotals[workload.Namespace]
+		namespace.Incidents += workload.Incidents
+		namespace.Workloads++
+		if workload.Breaching {
+			namespace.BreachingWorkloads++
+		}
+		nt.detect += t.detect
+		nt.recover += t.recover
+		nt.resolved += t.resolved
+	}
+	for _, name := range namespaceNames {
+		namespace, t := namespaces[name], namespaceTotals[name]
+		namespace.MeanTimeToDetectSeconds = meanSeconds(t.detect, namespace.Incidents)
+		namespace.MeanTimeToRecoverSeconds = meanSeconds(t.recover, t.resolved)
+		report.Namespaces = append(report.Namespaces, *namespace)
+	}
+	return report
+}
+
+// later returns the later of two times
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// meanSeconds returns the mean of a total duration in seconds, 0 without samples
+func meanSeconds(total time.Duration, n int) int64 {
+	if n == 0 {
+		return 0
+	}
+	return int64((total / time.Duration(n)).Seconds())
+}
+
+// breachingWorkloads returns the workloads of a report below the objective, as
+// namespace/kind/name
+func breachingWorkloads(report *SLOReport) []string {
+	var breaching []string
+	for _, workload := range report.Workloads {
+		if workload.Breaching {
+			breaching = append(breaching, sloIncidentKey(workload.Namespace, workload.Workload))
+		}
+	}
+	return breaching
+}
+
+// updateSLO tracks the incidents of a PodSleuth's owned non-ready pods in its status and
+// returns its SLO report. Incidents of other shards' namespaces are kept as stored.
+func (r *PodSleuthReconciler) updateSLO(podSleuth *infrav1alpha1.PodSleuth, current []infrav1alpha1.NonReadyPodInfo, now time.Time) *SLOReport {
+	config := podSleuth.Spec.SLO
+	if config == nil {
+		podSleuth.Status.SLO = nil
+		return nil
+	}
+	var owned, foreign []infrav1alpha1.SLOIncident
+	if podSleuth.Status.SLO != nil {
+		for _, incident := range podSleuth.Status.SLO.Incidents {
+			if r.Sharding.byNamespace() && !r.Sharding.ownsNamespace(incident.Namespace) {
+				foreign = append(foreign, incident)
+			} else {
+				owned = append(owned, incident)
+			}
+		}
+	}
+	incidents := append(trackSLOIncidents(owned, current, sloWindow(config), metav1.NewTime(now)), foreign...)
+	slices.SortStableFunc(incidents, func(a, b infrav1alpha1.SLOIncident) int { return a.FailedAt.Time.Compare(b.FailedAt.Time) })
+
+	podSleuth.Status.SLO = &infrav1alpha1.SLOStatus{Incidents: incidents}
+	report := SLOReportOf(podSleuth, now)
+	podSleuth.Status.SLO.Breaching = breachingWorkloads(report)
+	return report
+}
+
+// emitSLOEvents emits Events for workloads that started or stopped breaching the
+// availability objective
+func (r *PodSleuthReconciler) emitSLOEvents(podSleuth *infrav1alpha1.PodSleuth, previous []string, report *SLOReport) {
+	if r.Recorder == nil || report == nil {
+		return
+	}
+	current := breachingWorkloads(report)
+	for _, workload := range report.Workloads {
+		key := sloIncidentKey(workload.Namespace, workload.Workload)
+		if workload.Breaching && !slices.Contains(previous, key) {
+			r.Recorder.Event(podSleuth, corev1.EventTypeWarning, eventReasonSLOBreached,
+				fmt.Sprintf("Workload %s is %.3f%% available over the SLO window, below the objective of %s%%",
+					key, workload.Availability, podSleuth.Spec.SLO.AvailabilityObjective))
+		}
+	}
+	for _, key := range previous {
+		if !slices.Contains(current, key) {
+			r.Recorder.Event(podSleuth, corev1.EventTypeNormal, eventReasonSLOMet,
+				fmt.Sprintf("Workload %s meets its availability objective again", key))
+		}
+	}
+}
+
+// recordSLOMetrics replaces the SLO series of a PodSleuth with its report
+func recordSLOMetrics(podSleuthName string, report *SLOReport) {
+	labels := prometheus.Labels{"podsleuth": podSleuthName}
+	sloMeanTimeToDetect.DeletePartialMatch(labels)
+	sloMeanTimeToRecover.DeletePartialMatch(labels)
+	sloAvailability.DeletePartialMatch(labels)
+	sloBreaching.DeletePartialMatch(labels)
+	sloNamespaceMeanTimeToRecover.DeletePartialMatch(labels)
+	if report == nil {
+		return
+	}
+	for _, workload := range report.Workloads {
+		kind, name, _ := strings.Cut(workload.Workload, "/")
+		sloMeanTimeToDetect.WithLabelValues(podSleuthName, workload.Namespace, kind, name).Set(float64(workload.MeanTimeToDetectSeconds))
+		sloMeanTimeToRecover.WithLabelValues(podSleuthName, workload.Namespace, kind, name).Set(float64(workload.MeanTimeToRecoverSeconds))
+		sloAvailability.WithLabelValues(podSleuthName, workload.Namespace, kind, name).Set(workload.Availability / 100)
+		breaching := 0.0
+		if workload.Breaching {
+			breaching = 1
+		}
+		sloBreaching.WithLabelValues(podSleuthName, workload.Namespace, kind, name).Set(breaching)
+	}
+	for _, namespace := range report.Namespaces {
+		sloNamespaceMeanTimeToRecover.WithLabelValues(podSleuthName, namespace.Namespace).Set(float64(namespace.MeanTimeToRecoverSeconds))
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultSnapshotInterval = time.Hour
+	snapshotFormatCSV       = "CSV"
+	// maxResolvedIncidents bounds the incidents kept while exports fail
+	maxResolvedIncidents = 10000
+)
+
+// resolvedIncident is a pod that recovered, recorded for the next snapshot
+type resolvedIncident struct {
+	Cluster         string     `json:"cluster,omitempty"`
+	Namespace       string     `json:"namespace"`
+	Pod             string     `json:"pod"`
+	OwnerKind       string     `json:"ownerKind,omitempty"`
+	OwnerName       string     `json:"ownerName,omitempty"`
+	Team            string     `json:"team,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+	Severity        string     `json:"severity"`
+	RootCause       string     `json:"rootCause,omitempty"`
+	Confidence      int32      `json:"confidence,omitempty"`
+	DetectedAt      *time.Time `json:"detectedAt,omitempty"`
+	ResolvedAt      time.Time  `json:"resolvedAt"`
+	DowntimeSeconds int64      `json:"downtimeSeconds,omitempty"`
+}
+
+// statusSnapshot is the JSON document written to object storage
+type statusSnapshot struct {
+	Cluster           string                        `json:"cluster,omitempty"`
+	PodSleuth         string                        `json:"podSleuth"`
+	GeneratedAt       time.Time                     `json:"generatedAt"`
+	Status            infrav1alpha1.PodSleuthStatus `json:"status"`
+	ResolvedIncidents []resolvedIncident            `json:"resolvedIncidents"`
+}
+
+// snapshotExportState tracks the exports of one PodSleuth
+type snapshotExportState struct {
+	running    bool
+	lastExport time.Time
+	// resolved are incidents resolved since the last successful export
+	resolved []resolvedIncident
+}
+
+// exportSnapshot records resolved incidents and, once the interval has elapsed, writes a
+// snapshot of the status to object storage in the background. It returns when the next
+// snapshot is due, or zero if exports are disabled.
+func (r *PodSleuthReconciler) exportSnapshot(podSleuth *infrav1alpha1.PodSleuth, transitions []podTransition) time.Time {
+	config := podSleuth.Spec.SnapshotExport
+	if config == nil || (config.S3 == nil && config.GCS == nil && config.AzureBlob == nil) {
+		return time.Time{}
+	}
+	now := time.Now()
+	interval := defaultSnapshotInterval
+	if config.Interval != nil && config.Interval.Duration > 0 {
+		interval = config.Interval.Duration
+	}
+
+	r.snapshotExportsMux.Lock()
+	defer r.snapshotExportsMux.Unlock()
+	if r.snapshotExports == nil {
+		r.snapshotExports = make(map[string]*snapshotExportState)
+	}
+	state, exists := r.snapshotExports[podSleuth.Name]
+	if !exists {
+		state = &snapshotExportState{}
+		r.snapshotExports[podSleuth.Name] = state
+	}
+
+	for _, transition := range transitions {
+		if transition.Kind == transitionRecovered {
+			state.resolved = append(state.resolved, newResolvedIncident(&transition.Pod, now))
+		}
+	}
+	if over := len(state.resolved) - maxResolvedIncidents; over > 0 {
+		state.resolved = state.resolved[over:]
+	}
+
+	if state.running || now.Sub(state.lastExport) < interval {
+		return state.lastExport.Add(interval)
+	}
+	snapshot := statusSnapshot{
+		Cluster:           ClusterName(),
+		PodSleuth:         podSleuth.Name,
+		GeneratedAt:       now,
+		Status:            *podSleuth.Status.DeepCopy(),
+		ResolvedIncidents: state.resolved,
+	}
+	state.resolved = nil
+	state.running = true
+	state.lastExport = now
+	go r.writeSnapshot(config.DeepCopy(), snapshot)
+	return now.Add(interval)
+}
+
+// newResolvedIncident records a recovered pod with its downtime and final root cause
+func newResolvedIncident(pod *infrav1alpha1.NonReadyPodInfo, now time.Time) resolvedIncident {
+	incident := resolvedIncident{
+		Cluster:    pod.Cluster,
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		OwnerKind:  pod.OwnerKind,
+		OwnerName:  pod.OwnerName,
+		Team:       pod.Team,
+		Reason:     pod.Reason,
+		Severity:   PodSeverity(pod),
+		ResolvedAt: now,
+	}
+	if pod.LogAnalysis != nil {
+		incident.RootCause = pod.LogAnalysis.RootCause
+		incident.Confidence = pod.LogAnalysis.Confidence
+	}
+	if pod.DetectedAt != nil {
+		detectedAt := pod.DetectedAt.Time
+		incident.DetectedAt = &detectedAt
+		incident.DowntimeSeconds = int64(now.Sub(detectedAt).Seconds())
+	}
+	return incident
+}
+
+// writeSnapshot uploads a snapshot and applies the retention. Resolved incidents of a
+// failed upload are kept for the next snapshot.
+func (r *PodSleuthReconciler) writeSnapshot(config *infrav1alpha1.SnapshotExportConfig, snapshot statusSnapshot) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+	defer cancel()
+	logger := log.Log.WithName("notifications")
+
+	err := r.uploadSnapshot(ctx, config, snapshot)
+	r.snapshotExportsMux.Lock()
+	if state := r.snapshotExports[snapshot.PodSleuth]; state != nil {
+		state.running = false
+		if err != nil {
+			state.resolved = append(snapshot.ResolvedIncidents, state.resolved...)
+		}
+	}
+	r.snapshotExportsMux.Unlock()
+	if err != nil {
+		logger.Info("snapshot export failed", "podsleuth", snapshot.PodSleuth, "error", err)
+	}
+}
+
+// uploadSnapshot encodes a snapshot, writes it and deletes snapshots past the retention
+func (r *PodSleuthReconciler) uploadSnapshot(ctx context.Context, config *infrav1alpha1.SnapshotExportConfig, snapshot statusSnapshot) error {
+	store, err := r.snapshotStore(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	contentType, extension := "application/json", "json"
+	if config.Format == snapshotFormatCSV {
+		contentType, extension = "text/csv", "csv"
+		data, err = encodeSnapshotCSV(snapshot)
+	} else {
+		data, err = json.MarshalIndent(snapshot, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	prefix := config.Prefix
+	if prefix == "" {
+		prefix = "kubesleuth/" + snapshot.PodSleuth + "/"
+		if snapshot.Cluster != "" {
+			prefix = "kubesleuth/" + snapshot.Cluster + "/" + snapshot.PodSleuth + "/"
+		}
+	}
+	generatedAt := snapshot.GeneratedAt.UTC()
+	key := fmt.Sprintf("%s%s/snapshot-%s.%s", prefix, generatedAt.Format("2006/01/02"), generatedAt.Format("20060102T150405Z"), extension)
+	err = store.put(ctx, key, contentType, data)
+	notificationsSent.WithLabelValues("snapshot", store.name(), outcomeOf(err)).Inc()
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	log.Log.WithName("notifications").Info("snapshot exported", "podsleuth", snapshot.PodSleuth, "store", store.name(), "key", key, "bytes", len(data))
+
+	if config.Retention == nil || config.Retention.Duration <= 0 {
+		return nil
+	}
+	objects, err := store.list(ctx, prefix)
+	if err != nil {
+		// The snapshot itself was written, so only report the retention failure
+		log.Log.WithName("notifications").Info("failed to list snapshots for retention", "podsleuth", snapshot.PodSleuth, "error", err)
+		return nil
+	}
+	cutoff := snapshot.GeneratedAt.Add(-config.Retention.Duration)
+	for _, object := range objects {
+		if !strings.Contains(object.Key, "/snapshot-") || object.LastModified.IsZero() || !object.LastModified.Before(cutoff) {
+			continue
+		}
+		if err := store.delete(ctx, object.Key); err != nil {
+			log.Log.WithName("notifications").Info("failed to delete expired snapshot", "key", object.Key, "error", err)
+		}
+	}
+	return nil
+}
+
+// snapshotStore returns the configured object store with its credentials
+func (r *PodSleuthReconciler) snapshotStore(ctx context.Context, config *infrav1alpha1.SnapshotExportConfig) (objectStore, error) {
+	switch {
+	case config.S3 != nil:
+		accessKeyID, err := r.notificationSecret(ctx, &config.S3.AccessKeyIDSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get S3 access key id: %w", err)
+		}
+		secretAccessKey, err := r.notificationSecret(ctx, &config.S3.SecretAccessKeySecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get S3 secret access key: %w", err)
+		}
+		return newS3Store("s3", config.S3.Endpoint, config.S3.Region, config.S3.Bucket, config.S3.ForcePathStyle, accessKeyID, secretAccessKey)
+	case config.GCS != nil:
+		accessID, err := r.notificationSecret(ctx, &config.GCS.AccessIDSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GCS HMAC access id: %w", err)
+		}
+		secret, err := r.notificationSecret(ctx, &config.GCS.SecretSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GCS HMAC secret: %w", err)
+		}
+		return newS3Store("gcs", gcsEndpoint, "auto", config.GCS.Bucket, true, accessID, secret)
+	default:
+		sasToken, err := r.notificationSecret(ctx, &config.AzureBlob.SASTokenSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Azure SAS token: %w", err)
+		}
+		return newAzureBlobStore(config.AzureBlob.ContainerURL, sasToken)
+	}
+}
+
+// encodeSnapshotCSV writes one row per non-ready pod and resolved incident
+func encodeSnapshotCSV(snapshot statusSnapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"record", "podsleuth", "namespace", "pod", "ownerKind", "ownerName", "team", "phase",
+		"reason", "severity", "rootCause", "confidence", "detectedAt", "resolvedAt", "downtimeSeconds", "cluster"})
+
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	for i := range snapshot.Status.NonReadyPods {
+		pod := &snapshot.Status.NonReadyPods[i]
+		rootCause, confidence := "", ""
+		if pod.LogAnalysis != nil {
+			rootCause, confidence = pod.LogAnalysis.RootCause, strconv.Itoa(int(pod.LogAnalysis.Confidence))
+		}
+		var detectedAt *time.Time
+		if pod.DetectedAt != nil {
+			detectedAt = &pod.DetectedAt.Time
+		}
+		_ = w.Write([]string{"nonready", snapshot.PodSleuth, pod.Namespace, pod.Name, pod.OwnerKind, pod.OwnerName, pod.Team,
+			pod.Phase, pod.Reason, PodSeverity(pod), rootCause, confidence, formatTime(detectedAt), "", "", snapshot.Cluster})
+	}
+	for _, incident := range snapshot.ResolvedIncidents {
+		resolvedAt := incident.ResolvedAt
+		_ = w.Write([]string{"resolved", snapshot.PodSleuth, incident.Namespace, incident.Pod, incident.OwnerKind, incident.OwnerName,
+			incident.Team, "", incident.Reason, incident.Severity, incident.RootCause, strconv.Itoa(int(incident.Confidence)),
+			formatTime(incident.DetectedAt), formatTime(&resolvedAt), strconv.FormatInt(incident.DowntimeSeconds, 10), snapshot.Cluster})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// forgetSnapshotExports drops the export state of a deleted PodSleuth
+func (r *PodSleuthReconciler) forgetSnapshotExports(podSleuthName string) {
+	r.snapshotExportsMux.Lock()
+	defer r.snapshotExportsMux.Unlock()
+	delete(r.snapshotExports, podSleuthName)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultMaxErrorLineBytesPerPod = 8 << 10
+	defaultMaxErrorLineBytes       = 256 << 10
+
+	// minTruncatedErrorLine is the smallest budget a line is truncated to rather
+	// than left out
+	minTruncatedErrorLine = 64
+)
+
+// limitErrorLines bounds the error lines stored in the status per pod and across all
+// pods. Trimmed analyses are copies, since analyses are shared with the analysis cache.
+func limitErrorLines(pods []infrav1alpha1.NonReadyPodInfo, limits *infrav1alpha1.StatusLimitsConfig) {
+	perPod, remaining := defaultMaxErrorLineBytesPerPod, defaultMaxErrorLineBytes
+	omit := false
+	if limits != nil {
+		if limits.MaxErrorLineBytesPerPod != nil {
+			perPod = int(*limits.MaxErrorLineBytesPerPod)
+		}
+		if limits.MaxErrorLineBytes != nil {
+			remaining = int(*limits.MaxErrorLineBytes)
+		}
+		omit = limits.OmitErrorLines
+	}
+
+	for i := range pods {
+		analysis := pods[i].LogAnalysis
+		if analysis == nil || len(analysis.ErrorLines) == 0 {
+			continue
+		}
+		budget := min(perPod, remaining)
+		if omit {
+			budget = 0
+		}
+		lines, omitted, used := trimErrorLines(analysis.ErrorLines, budget)
+		remaining -= used
+		if omitted == 0 {
+			continue
+		}
+		analysis = analysis.DeepCopy()
+		analysis.ErrorLines = lines
+		analysis.ErrorLinesOmitted += int32(omitted)
+		pods[i].LogAnalysis = analysis
+	}
+}
+
+// trimErrorLines keeps the error lines fitting in budget bytes, truncating the first line
+// that does not fit if enough of it would be left. Returns the kept lines, how many were
+// left out or truncated and the bytes used.
+func trimErrorLines(lines []string, budget int) ([]string, int, int) {
+	used := 0
+	for i, line := range lines {
+		if used+len(line) <= budget {
+			used += len(line)
+			continue
+		}
+		kept := lines[:i:i]
+		if left := budget - used; left >= minTruncatedErrorLine {
+			truncated := truncateString(line, left)
+			kept = append(kept, truncated)
+			used += len(truncated)
+		}
+		return kept, len(lines) - i, used
+	}
+	return lines, 0, used
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// tlsVersions are the TLS versions outbound connections may be limited to
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// fipsCipherSuites are the FIPS 140 approved TLS 1.2 cipher suites
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// OutboundTLSPolicy restricts the TLS connections the operator opens to AI endpoints
+// and notification sinks
+type OutboundTLSPolicy struct {
+	// MinVersion is the lowest TLS version, such as 1.2. Empty keeps Go's default.
+	MinVersion string
+	// CipherSuites are the names of the TLS 1.2 cipher suites allowed, such as
+	// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Empty keeps Go's defaults. TLS 1.3
+	// suites are not configurable.
+	CipherSuites []string
+	// FIPS only allows FIPS 140 approved versions, cipher suites and curves
+	FIPS bool
+}
+
+// outboundTLS is the resolved outbound TLS policy
+type outboundTLS struct {
+	minVersion   uint16
+	maxVersion   uint16
+	cipherSuites []uint16
+	curves       []tls.CurveID
+}
+
+var (
+	outboundTLSPolicy    outboundTLS
+	outboundTLSPolicyMux sync.RWMutex
+
+	// outboundTransport is the transport of notification sinks, rebuilt when the
+	// policy changes
+	outboundTransport    = newOutboundTransport()
+	outboundTransportMux sync.RWMutex
+)
+
+// SetOutboundTLSPolicy applies a TLS policy to the connections opened to AI endpoints
+// and notification sinks from now on. Nothing changes if the policy is invalid.
+func SetOutboundTLSPolicy(policy OutboundTLSPolicy) error {
+	var resolved outboundTLS
+	if policy.MinVersion != "" {
+		version, ok := tlsVersions[strings.TrimPrefix(policy.MinVersion, "TLS")]
+		if !ok {
+			return fmt.Errorf("invalid TLS version %q, expected 1.0, 1.1, 1.2 or 1.3", policy.MinVersion)
+		}
+		resolved.minVersion = version
+	}
+
+	for _, name := range policy.CipherSuites {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		suite := cipherSuiteByName(name)
+		if suite == nil {
+			return fmt.Errorf("unknown or insecure TLS cipher suite %q", name)
+		}
+		resolved.cipherSuites = append(resolved.cipherSuites, suite.ID)
+	}
+
+	if policy.FIPS {
+		resolved.minVersion = max(resolved.minVersion, tls.VersionTLS12)
+		if len(resolved.cipherSuites) == 0 {
+			resolved.cipherSuites = fipsCipherSuites
+		}
+		for _, suite := range resolved.cipherSuites {
+			if !slices.Contains(fipsCipherSuites, suite) {
+				return fmt.Errorf("TLS cipher suite %s is not FIPS 140 approved", tls.CipherSuiteName(suite))
+			}
+		}
+		resolved.curves = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+		// TLS 1.3 cipher suites cannot be restricted, except by Go's FIPS 140 mode
+		if !fips140.Enabled() {
+			resolved.maxVersion = tls.VersionTLS12
+		}
+	}
+	if resolved.maxVersion != 0 && resolved.minVersion > resolved.maxVersion {
+		return fmt.Errorf("TLS %s is not available in FIPS mode without GODEBUG=fips140=on", policy.MinVersion)
+	}
+
+	outboundTLSPolicyMux.Lock()
+	outboundTLSPolicy = resolved
+	outboundTLSPolicyMux.Unlock()
+
+	// Connections are only made with the new policy once pooled ones are gone
+	outboundTransportMux.Lock()
+	previous := outboundTransport
+	outboundTransport = newOutboundTransport()
+	outboundTransportMux.Unlock()
+	previous.CloseIdleConnections()
+
+	aiClientsMux.Lock()
+	defer aiClientsMux.Unlock()
+	for opts, httpClient := range aiClients {
+		httpClient.CloseIdleConnections()
+		delete(aiClients, opts)
+	}
+	return nil
+}
+
+// cipherSuiteByName returns the secure cipher suite of a name, or nil
+func cipherSuiteByName(name string) *tls.CipherSuite {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite
+		}
+	}
+	return nil
+}
+
+// applyOutboundTLSPolicy restricts an outbound TLS configuration to the policy
+func applyOutboundTLSPolicy(config *tls.Config) *tls.Config {
+	outboundTLSPolicyMux.RLock()
+	defer outboundTLSPolicyMux.RUnlock()
+	if outboundTLSPolicy.minVersion != 0 {
+		config.MinVersion = outboundTLSPolicy.minVersion
+	}
+	if outboundTLSPolicy.maxVersion != 0 {
+		config.MaxVersion = outboundTLSPolicy.maxVersion
+	}
+	if len(outboundTLSPolicy.cipherSuites) > 0 {
+		config.CipherSuites = outboundTLSPolicy.cipherSuites
+	}
+	if len(outboundTLSPolicy.curves) > 0 {
+		config.CurvePreferences = outboundTLSPolicy.curves
+	}
+	return config
+}
+
+// newOutboundTransport returns a transport like http.DefaultTransport following the
+// outbound TLS policy
+func newOutboundTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = applyOutboundTLSPolicy(&tls.Config{})
+	return transport
+}
+
+// OutboundTransport returns a transport following the outbound TLS policy, for the
+// outbound connections of other packages
+func OutboundTransport() http.RoundTripper {
+	return outboundRoundTripper{}
+}
+
+// outboundRoundTripper sends requests through the current outbound transport
+type outboundRoundTripper struct{}
+
+func (outboundRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	outboundTransportMux.RLock()
+	transport := outboundTransport
+	outboundTransportMux.RUnlock()
+	return transport.RoundTrip(req)
+}
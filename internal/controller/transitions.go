@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// Kinds of changes in the non-ready pods of a PodSleuth between reconciles
+const (
+	transitionDetected  = "detected"
+	transitionRecovered = "recovered"
+	transitionRootCause = "rootCause"
+)
+
+// podTransition is a change of one pod between the previous and current reconcile
+type podTransition struct {
+	Kind string
+	Pod  infrav1alpha1.NonReadyPodInfo
+}
+
+// diffNonReadyPods compares the non-ready pods of the previous and current reconcile.
+// Pods that became non-ready are detected, pods that left the set recovered, and pods
+// with a new log analysis root cause get a rootCause transition. Muted pods (suppressed,
+// silenced or acknowledged) are ignored; a pod that stops being muted counts as detected.
+func diffNonReadyPods(previous, current []infrav1alpha1.NonReadyPodInfo) []podTransition {
+	previousPods := make(map[string]*infrav1alpha1.NonReadyPodInfo, len(previous))
+	for i := range previous {
+		previousPods[previous[i].Namespace+"/"+previous[i].Name] = &previous[i]
+	}
+	currentPods := make(map[string]bool, len(current))
+
+	var transitions []podTransition
+	for i := range current {
+		pod := current[i]
+		key := pod.Namespace + "/" + pod.Name
+		currentPods[key] = true
+		if IsMuted(&pod) {
+			continue
+		}
+
+		before, existed := previousPods[key]
+		if !existed || IsMuted(before) {
+			transitions = append(transitions, podTransition{Kind: transitionDetected, Pod: pod})
+		}
+
+		rootCause := ""
+		if pod.LogAnalysis != nil {
+			rootCause = pod.LogAnalysis.RootCause
+		}
+		previousRootCause := ""
+		if existed && before.LogAnalysis != nil {
+			previousRootCause = before.LogAnalysis.RootCause
+		}
+		if rootCause != "" && rootCause != previousRootCause {
+			transitions = append(transitions, podTransition{Kind: transitionRootCause, Pod: pod})
+		}
+	}
+
+	for i := range previous {
+		pod := previous[i]
+		if currentPods[pod.Namespace+"/"+pod.Name] || IsMuted(&pod) {
+			continue
+		}
+		transitions = append(transitions, podTransition{Kind: transitionRecovered, Pod: pod})
+	}
+	return transitions
+}
+
+// carryDetectedAt keeps when each pod was first found non-ready across reconciles and
+// stamps newly found pods with now
+func carryDetectedAt(previous, current []infrav1alpha1.NonReadyPodInfo, now metav1.Time) {
+	detectedAt := make(map[string]*metav1.Time, len(previous))
+	for i := range previous {
+		if previous[i].DetectedAt != nil {
+			detectedAt[previous[i].Namespace+"/"+previous[i].Name] = previous[i].DetectedAt
+		}
+	}
+	for i := range current {
+		if at, ok := detectedAt[current[i].Namespace+"/"+current[i].Name]; ok {
+			current[i].DetectedAt = at.DeepCopy()
+		} else {
+			current[i].DetectedAt = now.DeepCopy()
+		}
+	}
+}
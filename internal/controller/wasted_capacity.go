@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// StuckPodDuration is how long a pod must have been non-ready for the capacity it
+// reserves to count as wasted
+const StuckPodDuration = 10 * time.Minute
+
+// bytesPerGiB converts memory prices per GiB
+const bytesPerGiB = 1 << 30
+
+// ResourcePrices are the costs of reserved capacity used to price wasted capacity
+type ResourcePrices struct {
+	// CPUCoreHour is the cost of a CPU core for an hour
+	CPUCoreHour float64
+	// MemoryGiBHour is the cost of a GiB of memory for an hour
+	MemoryGiBHour float64
+	// Currency names the unit of the costs, such as USD
+	Currency string
+}
+
+var (
+	resourcePrices    ResourcePrices
+	resourcePricesMux sync.RWMutex
+)
+
+// SetResourcePrices sets the costs of reserved capacity. Without prices wasted capacity
+// is reported in cores and bytes only.
+func SetResourcePrices(prices ResourcePrices) error {
+	if prices.CPUCoreHour < 0 || prices.MemoryGiBHour < 0 {
+		return fmt.Errorf("resource prices must not be negative")
+	}
+	resourcePricesMux.Lock()
+	defer resourcePricesMux.Unlock()
+	resourcePrices = prices
+	return nil
+}
+
+// CurrentResourcePrices returns the costs of reserved capacity
+func CurrentResourcePrices() ResourcePrices {
+	resourcePricesMux.RLock()
+	defer resourcePricesMux.RUnlock()
+	return resourcePrices
+}
+
+// priced reports whether any cost is set
+func (p ResourcePrices) priced() bool {
+	return p.CPUCoreHour > 0 || p.MemoryGiBHour > 0
+}
+
+// WastedCapacity sums the capacity reserved by stuck non-ready pods
+type WastedCapacity struct {
+	// Pods is the number of stuck pods holding capacity
+	Pods        int     `json:"pods"`
+	CPUCores    float64 `json:"cpuCores"`
+	MemoryBytes int64   `json:"memoryBytes"`
+	// CostPerHour is what the capacity costs per hour, set when prices are configured
+	CostPerHour float64 `json:"costPerHour,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+}
+
+// WastesCapacity reports whether a non-ready pod holds reserved capacity and has been
+// non-ready for at least StuckPodDuration
+func WastesCapacity(pod *infrav1alpha1.NonReadyPodInfo, now time.Time) bool {
+	if pod.Reserved == nil {
+		return false
+	}
+	since := pod.NotReadySince
+	if since == nil {
+		since = pod.DetectedAt
+	}
+	return since != nil && now.Sub(since.Time) >= StuckPodDuration
+}
+
+// Add counts the capacity a pod wastes, if any
+func (w *WastedCapacity) Add(pod *infrav1alpha1.NonReadyPodInfo, now time.Time) {
+	if !WastesCapacity(pod, now) {
+		return
+	}
+	w.Pods++
+	w.CPUCores += float64(pod.Reserved.CPUMillicores) / 1000
+	w.MemoryBytes += pod.Reserved.MemoryBytes
+}
+
+// Price sets the cost of the capacity from the configured prices
+func (w *WastedCapacity) Price(prices ResourcePrices) {
+	if !prices.priced() {
+		w.CostPerHour, w.Currency = 0, ""
+		return
+	}
+	w.CostPerHour = w.CPUCores*prices.CPUCoreHour + float64(w.MemoryBytes)/bytesPerGiB*prices.MemoryGiBHour
+	w.Currency = prices.Currency
+}
+
+// podReservation returns the CPU and memory a pod's requests reserve on its node, like
+// the scheduler counts them: the larger of its containers and sidecars together and any
+// init container, plus the pod overhead. Pods that are not scheduled or have terminated
+// reserve nothing.
+func podReservation(pod *corev1.Pod) *infrav1alpha1.ReservedResources {
+	if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+	requests := corev1.ResourceList{}
+	addRequests := func(into corev1.ResourceList, from corev1.ResourceList) {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := from[name]; ok {
+				sum := into[name]
+				sum.Add(quantity)
+				into[name] = sum
+			}
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		addRequests(requests, container.Resources.Requests)
+	}
+	// Sidecars keep running next to the containers; regular init containers run alone,
+	// next to the sidecars started before them
+	sidecars := corev1.ResourceList{}
+	initPeak := corev1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addRequests(sidecars, container.Resources.Requests)
+			continue
+		}
+		running := sidecars.DeepCopy()
+		addRequests(running, container.Resources.Requests)
+		for name, quantity := range running {
+			if peak, ok := initPeak[name]; !ok || quantity.Cmp(peak) > 0 {
+				initPeak[name] = quantity
+			}
+		}
+	}
+	addRequests(requests, sidecars)
+	for name, quantity := range initPeak {
+		if current := requests[name]; quantity.Cmp(current) > 0 {
+			requests[name] = quantity
+		}
+	}
+	addRequests(requests, pod.Spec.Overhead)
+
+	cpu, memory := requests[corev1.ResourceCPU], requests[corev1.ResourceMemory]
+	if cpu.IsZero() && memory.IsZero() {
+		return nil
+	}
+	return &infrav1alpha1.ReservedResources{CPUMillicores: cpu.MilliValue(), MemoryBytes: memory.Value()}
+}
+
+// recordWastedCapacity replaces the wasted capacity series of a PodSleuth with the
+// capacity its stuck pods reserve, by namespace
+func recordWastedCapacity(podSleuthName string, pods []infrav1alpha1.NonReadyPodInfo, now time.Time) {
+	labels := prometheus.Labels{"podsleuth": podSleuthName}
+	wastedCPUCores.DeletePartialMatch(labels)
+	wastedMemoryBytes.DeletePartialMatch(labels)
+	wastedCostPerHour.DeletePartialMatch(labels)
+	byNamespace := map[string]*WastedCapacity{}
+	for i := range pods {
+		if !WastesCapacity(&pods[i], now) {
+			continue
+		}
+		wasted, exists := byNamespace[pods[i].Namespace]
+		if !exists {
+			wasted = &WastedCapacity{}
+			byNamespace[pods[i].Namespace] = wasted
+		}
+		wasted.Add(&pods[i], now)
+	}
+	prices := CurrentResourcePrices()
+	for namespace, wasted := range byNamespace {
+		wastedCPUCores.WithLabelValues(podSleuthName, namespace).Set(wasted.CPUCores)
+		wastedMemoryBytes.WithLabelValues(podSleuthName, namespace).Set(float64(wasted.MemoryBytes))
+		if prices.priced() {
+			wasted.Price(prices)
+			wastedCostPerHour.WithLabelValues(podSleuthName, namespace).Set(wasted.CostPerHour)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultWebhookMaxRetries = 3
+	maxWebhookBackoff        = 30 * time.Second
+
+	// webhookSignatureHeader carries the HMAC-SHA256 signature of the payload
+	webhookSignatureHeader = "X-KubeSleuth-Signature"
+)
+
+// webhookHTTPClient is shared by all webhook sinks; timeouts are set per request
+var webhookHTTPClient = &http.Client{Transport: outboundRoundTripper{}}
+
+// webhookTemplateFuncs are available in payload templates
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// renderWebhookPayload renders the request body of a notification
+func renderWebhookPayload(payloadTemplate string, n notification) ([]byte, error) {
+	if payloadTemplate == "" {
+		return json.Marshal(n)
+	}
+	return renderWebhookTemplate("payload", payloadTemplate, n)
+}
+
+// renderWebhookURL renders a sink URL containing template expressions, e.g. to close
+// an alert by its incident key
+func renderWebhookURL(url string, n notification) (string, error) {
+	if !strings.Contains(url, "{{") {
+		return url, nil
+	}
+	rendered, err := renderWebhookTemplate("url", url, n)
+	return string(rendered), err
+}
+
+// renderWebhookTemplate executes a webhook template against a notification
+func renderWebhookTemplate(name, text string, n notification) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(webhookTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return nil, fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// signWebhookPayload returns the HMAC-SHA256 signature header value of a payload
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendWebhook delivers a notification to a webhook sink, retrying transient failures
+// with exponential backoff
+func (r *PodSleuthReconciler) sendWebhook(ctx context.Context, sink *infrav1alpha1.WebhookSink, n notification) error {
+	body, err := renderWebhookPayload(sink.PayloadTemplate, n)
+	if err != nil {
+		return err
+	}
+	url, err := renderWebhookURL(sink.URL, n)
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	for name, value := range sink.Headers {
+		headers.Set(name, value)
+	}
+	if sink.AuthSecretRef != nil {
+		credential, err := r.notificationSecret(ctx, sink.AuthSecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get auth credential: %w", err)
+		}
+		authHeader, authPrefix := sink.AuthHeader, sink.AuthPrefix
+		if authHeader == "" {
+			authHeader = "Authorization"
+		}
+		if authPrefix == "" {
+			authPrefix = "Bearer"
+		}
+		headers.Set(authHeader, authPrefix+" "+credential)
+	}
+	if sink.HMACSecretRef != nil {
+		secret, err := r.notificationSecret(ctx, sink.HMACSecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get HMAC secret: %w", err)
+		}
+		headers.Set(webhookSignatureHeader, signWebhookPayload(secret, body))
+	}
+
+	method := sink.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	timeout := defaultWebhookTimeout
+	if sink.Timeout != nil && sink.Timeout.Duration > 0 {
+		timeout = sink.Timeout.Duration
+	}
+	maxRetries := defaultWebhookMaxRetries
+	if sink.MaxRetries != nil {
+		maxRetries = int(*sink.MaxRetries)
+	}
+
+	return postWithRetries(ctx, method, url, headers, body, timeout, maxRetries)
+}
+
+// postWithRetries sends a request, retrying transient failures with exponential backoff
+func postWithRetries(ctx context.Context, method, url string, headers http.Header, body []byte, timeout time.Duration, maxRetries int) error {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		retryable, err := postWebhook(ctx, method, url, headers, body, timeout)
+		if err == nil || !retryable || attempt >= maxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (giving up: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxWebhookBackoff {
+			backoff = maxWebhookBackoff
+		}
+	}
+}
+
+// postWebhook sends one delivery attempt. It reports whether a failure is worth retrying.
+func postWebhook(ctx context.Context, method, url string, headers http.Header, body []byte, timeout time.Duration) (bool, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = headers.Clone()
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("endpoint returned status %d: %s", resp.StatusCode, string(respBody))
+}
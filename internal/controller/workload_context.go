@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// workloadSignals are the pod failure reasons that explain replica pressure
+var workloadSignals = []string{"OOMKilled", "CrashLoopBackOff", "ReadinessProbeFailed", "Evicted"}
+
+// buildWorkloadContexts groups non-ready pods by owner and adds the replica and
+// autoscaling state of each owner
+func (r *PodSleuthReconciler) buildWorkloadContexts(ctx context.Context, pods []infrav1alpha1.NonReadyPodInfo) []infrav1alpha1.WorkloadContext {
+	logger := log.Log
+
+	workloads := make(map[string]*infrav1alpha1.WorkloadContext)
+	var order []string
+	for _, pod := range pods {
+		// Pods in a maintenance window, silenced or acknowledged do not count towards replica pressure
+		if pod.OwnerKind == "" || IsMuted(&pod) {
+			continue
+		}
+		key := pod.Namespace + "/" + pod.OwnerKind + "/" + pod.OwnerName
+		workload, exists := workloads[key]
+		if !exists {
+			workload = &infrav1alpha1.WorkloadContext{Kind: pod.OwnerKind, Name: pod.OwnerName, Namespace: pod.Namespace}
+			workloads[key] = workload
+			order = append(order, key)
+		}
+		workload.NonReadyPods++
+		for _, signal := range podSignals(pod) {
+			if !slices.Contains(workload.Signals, signal) {
+				workload.Signals = append(workload.Signals, signal)
+			}
+		}
+	}
+
+	// HPAs are listed once per namespace
+	hpas := make(map[string][]autoscalingv2.HorizontalPodAutoscaler)
+	result := make([]infrav1alpha1.WorkloadContext, 0, len(order))
+	for _, key := range order {
+		workload := workloads[key]
+		sort.Strings(workload.Signals)
+		r.addReplicaState(ctx, workload)
+
+		namespaceHPAs, listed := hpas[workload.Namespace]
+		if !listed {
+			var list autoscalingv2.HorizontalPodAutoscalerList
+			if err := r.List(ctx, &list, client.InNamespace(workload.Namespace)); err != nil {
+				logger.Info("unable to list HorizontalPodAutoscalers", "namespace", workload.Namespace, "error", err)
+			}
+			namespaceHPAs = list.Items
+			hpas[workload.Namespace] = namespaceHPAs
+		}
+		for i := range namespaceHPAs {
+			hpa := &namespaceHPAs[i]
+			if hpa.Spec.ScaleTargetRef.Kind == workload.Kind && hpa.Spec.ScaleTargetRef.Name == workload.Name {
+				workload.HPA = newHPAStatus(hpa)
+				break
+			}
+		}
+
+		workload.Summary = summarizeWorkload(workload)
+		result = append(result, *workload)
+	}
+	return result
+}
+
+// addReplicaState sets the desired and ready replicas of Deployments and StatefulSets
+func (r *PodSleuthReconciler) addReplicaState(ctx context.Context, workload *infrav1alpha1.WorkloadContext) {
+	key := types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}
+	switch workload.Kind {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, key, &deployment); err == nil {
+			workload.DesiredReplicas = deployment.Spec.Replicas
+			workload.ReadyReplicas = deployment.Status.ReadyReplicas
+		}
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := r.Get(ctx, key, &statefulSet); err == nil {
+			workload.DesiredReplicas = statefulSet.Spec.Replicas
+			workload.ReadyReplicas = statefulSet.Status.ReadyReplicas
+		}
+	}
+}
+
+// newHPAStatus summarizes an autoscaler, detecting when it is pinned at its maximum
+// or cannot fetch the metrics it scales on
+func newHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) *infrav1alpha1.HPAStatus {
+	status := &infrav1alpha1.HPAStatus{
+		Name:            hpa.Name,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		status.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	status.AtMaxReplicas = hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas
+
+	for _, condition := range hpa.Status.Conditions {
+		switch {
+		case condition.Type == autoscalingv2.ScalingActive && condition.Status == corev1.ConditionFalse:
+			// e.g. FailedGetResourceMetric when metrics-server is missing
+			status.MetricsUnavailable = true
+			status.Message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		case condition.Type == autoscalingv2.AbleToScale && condition.Status == corev1.ConditionFalse:
+			status.Message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		case condition.Type == autoscalingv2.ScalingLimited && condition.Status == corev1.ConditionTrue && condition.Reason == "TooManyReplicas":
+			status.AtMaxReplicas = true
+		}
+	}
+	return status
+}
+
+// podSignals returns the failure reasons of a non-ready pod that explain replica pressure
+func podSignals(pod infrav1alpha1.NonReadyPodInfo) []string {
+	text := pod.Reason + " " + pod.Message
+	for _, ce := range pod.ContainerErrors {
+		text += " " + ce.Reason + " " + ce.Message
+	}
+	if pod.CrashLoopTrend != nil {
+		text += " " + pod.CrashLoopTrend.DominantReason
+	}
+
+	var signals []string
+	for _, signal := range workloadSignals {
+		if strings.Contains(text, signal) {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
+
+// summarizeWorkload describes the replica pressure of a workload
+func summarizeWorkload(workload *infrav1alpha1.WorkloadContext) string {
+	var parts []string
+
+	if hpa := workload.HPA; hpa != nil {
+		if hpa.MetricsUnavailable {
+			parts = append(parts, fmt.Sprintf("HPA %s cannot fetch metrics (%s), autoscaling is not reacting to load", hpa.Name, hpa.Message))
+		}
+		if hpa.AtMaxReplicas {
+			atMax := fmt.Sprintf("HPA at max (%d/%d replicas)", hpa.CurrentReplicas, hpa.MaxReplicas)
+			switch {
+			case slices.Contains(workload.Signals, "OOMKilled"):
+				parts = append(parts, atMax+", pods OOMKilled — likely undersized: raise memory requests/limits rather than replicas")
+			case slices.Contains(workload.Signals, "ReadinessProbeFailed"), slices.Contains(workload.Signals, "CrashLoopBackOff"):
+				parts = append(parts, atMax+" with failing pods — workload is saturated: raise maxReplicas or per-pod resources")
+			default:
+				parts = append(parts, atMax)
+			}
+		}
+	}
+
+	if workload.DesiredReplicas != nil && workload.ReadyReplicas < *workload.DesiredReplicas {
+		parts = append(parts, fmt.Sprintf("%d/%d replicas ready", workload.ReadyReplicas, *workload.DesiredReplicas))
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultPushInterval is how often agents push their findings by default
+	DefaultPushInterval = 30 * time.Second
+	// PushPath is the hub endpoint agents push their findings to
+	PushPath = "/api/hub/findings"
+	// pushTimeout bounds each push
+	pushTimeout = 30 * time.Second
+)
+
+// Agent pushes the PodSleuths of its cluster to a hub. It is a manager Runnable.
+type Agent struct {
+	reader     client.Reader
+	pushURL    string
+	cluster    string
+	token      string
+	interval   time.Duration
+	httpClient *http.Client
+}
+
+// NewAgent creates an agent pushing the PodSleuths read with reader to the hub at hubURL
+// every interval (DefaultPushInterval if 0), as cluster, authenticated with token.
+// Requests are sent through transport.
+func NewAgent(reader client.Reader, hubURL, cluster, token string, interval time.Duration, transport http.RoundTripper) (*Agent, error) {
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	parsed, err := url.Parse(hubURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid hub URL %q", hubURL)
+	}
+	if interval <= 0 {
+		interval = DefaultPushInterval
+	}
+	return &Agent{
+		reader:     reader,
+		pushURL:    strings.TrimSuffix(hubURL, "/") + PushPath,
+		cluster:    cluster,
+		token:      token,
+		interval:   interval,
+		httpClient: &http.Client{Transport: transport, Timeout: pushTimeout},
+	}, nil
+}
+
+// Start pushes the findings right away and then every interval until ctx is done
+func (a *Agent) Start(ctx context.Context) error {
+	logger := log.Log.WithName("hub-agent")
+	logger.Info("pushing findings to the hub", "url", a.pushURL, "cluster", a.cluster, "interval", a.interval)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	failing := false
+	for {
+		err := a.push(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			// Log the first failure, then only the recovery, so an unreachable hub does
+			// not flood the log
+			if !failing {
+				logger.Error(err, "unable to push findings to the hub, retrying")
+			}
+			failing = true
+		case err == nil && failing:
+			logger.Info("pushing findings to the hub again")
+			failing = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection is true: the leader pushes the PodSleuths of every shard
+func (a *Agent) NeedLeaderElection() bool {
+	return true
+}
+
+// push sends the current PodSleuths to the hub
+func (a *Agent) push(ctx context.Context) error {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := a.reader.List(ctx, &podSleuthList); err != nil {
+		return fmt.Errorf("listing PodSleuths: %w", err)
+	}
+	podSleuths := make([]infrav1alpha1.PodSleuth, len(podSleuthList.Items))
+	for i := range podSleuthList.Items {
+		podSleuths[i] = pushedPodSleuth(&podSleuthList.Items[i])
+	}
+	body, err := json.Marshal(Findings{
+		Cluster:    a.cluster,
+		PushedAt:   metav1.Now(),
+		PodSleuths: podSleuths,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.pushURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("hub returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// pushedPodSleuth returns what the hub gets of a PodSleuth: its identity, labels and
+// status. The spec stays in the cluster, as it holds the URLs and headers of
+// notification sinks, which may carry credentials.
+func pushedPodSleuth(podSleuth *infrav1alpha1.PodSleuth) infrav1alpha1.PodSleuth {
+	return infrav1alpha1.PodSleuth{
+		TypeMeta: podSleuth.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              podSleuth.Name,
+			Namespace:         podSleuth.Namespace,
+			UID:               podSleuth.UID,
+			ResourceVersion:   podSleuth.ResourceVersion,
+			Generation:        podSleuth.Generation,
+			CreationTimestamp: podSleuth.CreationTimestamp,
+			Labels:            podSleuth.Labels,
+		},
+		Status: podSleuth.Status,
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestAgentPushesStatusOnly(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := infrav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	podSleuth := &infrav1alpha1.PodSleuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "production",
+			Labels:      map[string]string{"team": "platform"},
+			Annotations: map[string]string{"kubesleuth.io/approve-remediations": "a1b2"},
+		},
+		Spec: infrav1alpha1.PodSleuthSpec{Notifications: &infrav1alpha1.NotificationsConfig{
+			Webhooks: []infrav1alpha1.WebhookSink{{Name: "ops", URL: "https://hooks.example.com/T000/B000/secret",
+				Headers: map[string]string{"Authorization": "Bearer sink-token"}}},
+		}},
+		Status: infrav1alpha1.PodSleuthStatus{NonReadyPods: []infrav1alpha1.NonReadyPodInfo{{Name: "cart-1", Namespace: "shop"}}},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(podSleuth).WithStatusSubresource(podSleuth).Build()
+	if err := reader.Status().Update(context.Background(), podSleuth); err != nil {
+		t.Fatal(err)
+	}
+
+	var body []byte
+	var authorization string
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		body = make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(body)
+	}))
+	defer hub.Close()
+
+	agent, err := NewAgent(reader, hub.URL, "prod-eu-1", "eu-token", 0, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := agent.push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if authorization != "Bearer eu-token" {
+		t.Errorf("pushed with Authorization %q", authorization)
+	}
+	for _, secret := range []string{"hooks.example.com", "sink-token", "approve-remediations"} {
+		if strings.Contains(string(body), secret) {
+			t.Errorf("push contains %q: %s", secret, body)
+		}
+	}
+	var findings Findings
+	if err := json.Unmarshal(body, &findings); err != nil {
+		t.Fatal(err)
+	}
+	if len(findings.PodSleuths) != 1 {
+		t.Fatalf("pushed %d PodSleuths, want 1", len(findings.PodSleuths))
+	}
+	pushed := findings.PodSleuths[0]
+	if findings.Cluster != "prod-eu-1" || pushed.Name != "production" || pushed.Labels["team"] != "platform" ||
+		len(pushed.Status.NonReadyPods) != 1 || pushed.ResourceVersion == "" {
+		t.Errorf("pushed %+v for cluster %q, want the identity, labels and status", pushed, findings.Cluster)
+	}
+}
+
+func TestStoreDropsSpec(t *testing.T) {
+	store := NewStore(0)
+	findings := &Findings{Cluster: "prod-eu-1", PodSleuths: []infrav1alpha1.PodSleuth{{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec:       infrav1alpha1.PodSleuthSpec{Notifications: &infrav1alpha1.NotificationsConfig{}},
+	}}}
+	if _, err := store.Put(findings); err != nil {
+		t.Fatal(err)
+	}
+	if stored := store.PodSleuths(); len(stored) != 1 || stored[0].Spec.Notifications != nil {
+		t.Errorf("stored %+v, want the PodSleuth without its spec", stored)
+	}
+}
+
+func TestParseClusterTokens(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{value: "prod-eu-1=eu-token\nprod-us-1=us-token\n", want: map[string]string{"prod-eu-1": "eu-token", "prod-us-1": "us-token"}},
+		{value: " prod-eu-1 = eu-token , prod-us-1=us-token", want: map[string]string{"prod-eu-1": "eu-token", "prod-us-1": "us-token"}},
+		{value: "shared-token", wantErr: true},
+		{value: "prod-eu-1=", wantErr: true},
+		{value: "Prod_EU=eu-token", wantErr: true},
+		{value: "prod-eu-1=a\nprod-eu-1=b", wantErr: true},
+		{value: "prod-eu-1=same\nprod-us-1=same", wantErr: true},
+		{value: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClusterTokens(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseClusterTokens(%q): got error %v, want an error: %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseClusterTokens(%q) = %v, want %v", tt.value, got, tt.want)
+			continue
+		}
+		for cluster, token := range tt.want {
+			if got[cluster] != token {
+				t.Errorf("ParseClusterTokens(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hub aggregates the findings of several clusters: agents push the PodSleuths of
+// their cluster to a hub operator, whose dashboard serves them next to its own.
+package hub
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// ClusterAnnotation names the cluster of the PodSleuths a hub received from agents
+const ClusterAnnotation = "kubesleuth.io/cluster"
+
+const (
+	// DefaultStaleAfter is how long a cluster may go without pushing before it is stale
+	DefaultStaleAfter = 2 * time.Minute
+	// forgetAfter is how long the findings of a cluster that stopped pushing are kept
+	forgetAfter = 24 * time.Hour
+)
+
+// Findings is what an agent pushes: the PodSleuths of its cluster
+type Findings struct {
+	Cluster    string                    `json:"cluster"`
+	PushedAt   metav1.Time               `json:"pushedAt"`
+	PodSleuths []infrav1alpha1.PodSleuth `json:"podSleuths"`
+}
+
+// ClusterStatus describes a cluster pushing to the hub
+type ClusterStatus struct {
+	Name string `json:"name"`
+	// LastPush is when the hub last received the findings of the cluster
+	LastPush metav1.Time `json:"lastPush"`
+	// Stale is set when the cluster stopped pushing; its last findings are still served
+	Stale        bool `json:"stale"`
+	PodSleuths   int  `json:"podSleuths"`
+	NonReadyPods int  `json:"nonReadyPods"`
+}
+
+// Change is a PodSleuth of a cluster changed by a push
+type Change struct {
+	// Key identifies the PodSleuth; see PodSleuthKey
+	Key string
+	// PodSleuth is the changed PodSleuth, nil if the cluster no longer has it
+	PodSleuth *infrav1alpha1.PodSleuth
+}
+
+// clusterFindings are the findings last received from a cluster
+type clusterFindings struct {
+	receivedAt time.Time
+	podSleuths []infrav1alpha1.PodSleuth
+}
+
+// Store holds the findings agents pushed to the hub, in memory
+type Store struct {
+	staleAfter time.Duration
+
+	mu       sync.RWMutex
+	clusters map[string]*clusterFindings
+	// version changes with every change of the findings, and started with every
+	// start of the hub, which begins without findings
+	version uint64
+	started int64
+}
+
+// NewStore creates a store considering clusters stale when they did not push for
+// staleAfter (DefaultStaleAfter if 0)
+func NewStore(staleAfter time.Duration) *Store {
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	return &Store{staleAfter: staleAfter, clusters: map[string]*clusterFindings{}, started: time.Now().UnixNano()}
+}
+
+// PodSleuthKey identifies a PodSleuth among those of all clusters: cluster/name for the
+// PodSleuths of agents, the name for those of the hub's cluster
+func PodSleuthKey(podSleuth *infrav1alpha1.PodSleuth) string {
+	if cluster := podSleuth.Annotations[ClusterAnnotation]; cluster != "" {
+		return cluster + "/" + podSleuth.Name
+	}
+	return podSleuth.Name
+}
+
+// ValidateClusterName checks that a cluster name can label findings
+func ValidateClusterName(name string) error {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster name %q: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Put replaces the findings of a cluster, returning the PodSleuths it changed
+func (s *Store) Put(findings *Findings) ([]Change, error) {
+	if err := ValidateClusterName(findings.Cluster); err != nil {
+		return nil, err
+	}
+	podSleuths := make([]infrav1alpha1.PodSleuth, len(findings.PodSleuths))
+	for i := range findings.PodSleuths {
+		podSleuth := findings.PodSleuths[i].DeepCopy()
+		// Only the status is served; agents of older versions push the spec too
+		podSleuth.ManagedFields = nil
+		podSleuth.Spec = infrav1alpha1.PodSleuthSpec{}
+		if podSleuth.Annotations == nil {
+			podSleuth.Annotations = map[string]string{}
+		}
+		podSleuth.Annotations[ClusterAnnotation] = findings.Cluster
+		podSleuths[i] = *podSleuth
+	}
+	slices.SortFunc(podSleuths, func(a, b infrav1alpha1.PodSleuth) int { return strings.Compare(a.Name, b.Name) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var previous []infrav1alpha1.PodSleuth
+	if entry := s.clusters[findings.Cluster]; entry != nil {
+		previous = entry.podSleuths
+	}
+	s.clusters[findings.Cluster] = &clusterFindings{receivedAt: time.Now(), podSleuths: podSleuths}
+	changes := diff(previous, podSleuths)
+	if len(changes) > 0 {
+		s.version++
+	}
+	s.forgetLocked()
+	return changes, nil
+}
+
+// diff returns the PodSleuths added, updated or removed between two pushes of a cluster
+func diff(previous, current []infrav1alpha1.PodSleuth) []Change {
+	versions := make(map[string]string, len(previous))
+	for _, podSleuth := range previous {
+		versions[podSleuth.Name] = podSleuth.ResourceVersion
+	}
+	var changes []Change
+	for i := range current {
+		podSleuth := &current[i]
+		version, existed := versions[podSleuth.Name]
+		delete(versions, podSleuth.Name)
+		if !existed || version != podSleuth.ResourceVersion || podSleuth.ResourceVersion == "" {
+			changes = append(changes, Change{Key: PodSleuthKey(podSleuth), PodSleuth: podSleuth})
+		}
+	}
+	for _, podSleuth := range previous {
+		if _, removed := versions[podSleuth.Name]; removed {
+			changes = append(changes, Change{Key: PodSleuthKey(&podSleuth)})
+		}
+	}
+	return changes
+}
+
+// forgetLocked drops the clusters that stopped pushing long ago. Until then they are
+// skipped by namesLocked.
+func (s *Store) forgetLocked() {
+	for name, entry := range s.clusters {
+		if time.Since(entry.receivedAt) > forgetAfter {
+			delete(s.clusters, name)
+		}
+	}
+}
+
+// PodSleuths returns the PodSleuths of all clusters, annotated with their cluster
+func (s *Store) PodSleuths() []infrav1alpha1.PodSleuth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var podSleuths []infrav1alpha1.PodSleuth
+	for _, name := range s.namesLocked() {
+		for _, podSleuth := range s.clusters[name].podSleuths {
+			podSleuths = append(podSleuths, *podSleuth.DeepCopy())
+		}
+	}
+	return podSleuths
+}
+
+// Clusters describes the clusters that pushed findings, by name
+func (s *Store) Clusters() []ClusterStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clusters := []ClusterStatus{}
+	for _, name := range s.namesLocked() {
+		entry := s.clusters[name]
+		status := ClusterStatus{
+			Name:       name,
+			LastPush:   metav1.NewTime(entry.receivedAt),
+			Stale:      time.Since(entry.receivedAt) > s.staleAfter,
+			PodSleuths: len(entry.podSleuths),
+		}
+		for _, podSleuth := range entry.podSleuths {
+			status.NonReadyPods += len(podSleuth.Status.NonReadyPods)
+		}
+		clusters = append(clusters, status)
+	}
+	return clusters
+}
+
+// Version identifies the current findings, for ETags
+func (s *Store) Version() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fmt.Sprintf("hub@%s.%d.%d", strconv.FormatInt(s.started, 36), s.version, len(s.namesLocked()))
+}
+
+// namesLocked returns the names of the clusters whose findings are kept, sorted
+func (s *Store) namesLocked() []string {
+	names := make([]string, 0, len(s.clusters))
+	for name, entry := range s.clusters {
+		if time.Since(entry.receivedAt) <= forgetAfter {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseClusterTokens parses the tokens a hub accepts pushes with, one per cluster, as
+// <cluster>=<token> entries separated by newlines or commas. Binding every token to a
+// cluster keeps one cluster from overwriting the findings of another.
+func ParseClusterTokens(value string) (map[string]string, error) {
+	tokens := map[string]string{}
+	seen := map[string]string{}
+	for _, entry := range strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == ',' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		cluster, token, found := strings.Cut(entry, "=")
+		cluster, token = strings.TrimSpace(cluster), strings.TrimSpace(token)
+		if !found || token == "" {
+			return nil, fmt.Errorf("hub token entries must be <cluster>=<token>")
+		}
+		if err := ValidateClusterName(cluster); err != nil {
+			return nil, err
+		}
+		if _, exists := tokens[cluster]; exists {
+			return nil, fmt.Errorf("cluster %q has several hub tokens", cluster)
+		}
+		if other, exists := seen[token]; exists {
+			return nil, fmt.Errorf("clusters %q and %q share a hub token", other, cluster)
+		}
+		tokens[cluster], seen[token] = token, cluster
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no hub tokens")
+	}
+	return tokens, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+const (
+	// defaultAcknowledgeDuration is used when an acknowledgement sets no duration
+	defaultAcknowledgeDuration = 4 * time.Hour
+	// maxAcknowledgeDuration bounds acknowledgements; known issues are silenced instead
+	maxAcknowledgeDuration = 7 * 24 * time.Hour
+)
+
+// acknowledgeRequest is the body of POST /api/pods/{namespace}/{name}/acknowledge
+type acknowledgeRequest struct {
+	// Duration is a Go duration such as "4h"
+	Duration string `json:"duration"`
+	Comment  string `json:"comment"`
+}
+
+// acknowledgeResponse is the response of POST and DELETE
+// /api/pods/{namespace}/{name}/acknowledge
+type acknowledgeResponse struct {
+	Success bool `json:"success"`
+	// Acknowledged is the new acknowledgement, omitted when it was withdrawn
+	Acknowledged *infrav1alpha1.PodAcknowledgement `json:"acknowledged,omitempty"`
+}
+
+// handleAcknowledge acknowledges a non-ready pod (POST) or withdraws its acknowledgement
+// (DELETE) by annotating the pod. The acknowledgement records the authenticated user.
+func (s *Server) handleAcknowledge(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqBody acknowledgeRequest
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	duration := defaultAcknowledgeDuration
+	if reqBody.Duration != "" {
+		parsed, err := time.ParseDuration(reqBody.Duration)
+		if err != nil || parsed <= 0 || parsed > maxAcknowledgeDuration {
+			http.Error(w, fmt.Sprintf("Invalid duration %q, expected up to %s", reqBody.Duration, maxAcknowledgeDuration), http.StatusBadRequest)
+			return
+		}
+		duration = parsed
+	}
+
+	detail, err := s.findReportedPod(r.Context(), namespace, name, "")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if detail == nil && r.Method == http.MethodPost {
+		http.Error(w, fmt.Sprintf("Pod %s/%s is not reported as non-ready", namespace, name), http.StatusNotFound)
+		return
+	}
+
+	var pod corev1.Pod
+	if err := s.client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("Pod %s/%s not found", namespace, name), http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error getting pod: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	by := "dashboard"
+	if id := requestIdentity(r); id != nil {
+		by = id.User
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	for _, annotation := range controller.AcknowledgementAnnotations {
+		delete(pod.Annotations, annotation)
+	}
+	until := time.Now().Add(duration).UTC().Truncate(time.Second)
+	if r.Method == http.MethodPost {
+		pod.Annotations[controller.AnnotationAcknowledgedBy] = by
+		pod.Annotations[controller.AnnotationAcknowledgedUntil] = until.Format(time.RFC3339)
+		if reqBody.Comment != "" {
+			pod.Annotations[controller.AnnotationAcknowledgedComment] = reqBody.Comment
+		}
+	}
+	if err := s.client.Patch(r.Context(), &pod, patch); err != nil {
+		http.Error(w, fmt.Sprintf("Error annotating pod: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := acknowledgeResponse{Success: true}
+	if r.Method == http.MethodPost {
+		log.Log.WithName("web").Info("pod acknowledged", "pod", namespace+"/"+name, "by", by, "until", until)
+		response.Acknowledged = &infrav1alpha1.PodAcknowledgement{By: by, Comment: reqBody.Comment, Until: metav1.NewTime(until)}
+	} else {
+		log.Log.WithName("web").Info("pod acknowledgement withdrawn", "pod", namespace+"/"+name, "by", by)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+const (
+	// analysisJobTimeout is how long an analysis job may take before it is failed
+	analysisJobTimeout = 10 * time.Minute
+	// analysisJobRetention is how long analysis jobs can be looked up
+	analysisJobRetention = time.Hour
+)
+
+// States of analysis jobs and of their pods
+const (
+	analysisQueued    = "queued"
+	analysisRunning   = "running"
+	analysisCompleted = "completed"
+	analysisFailed    = "failed"
+	// analysisResolved pods became ready, or went away, before their analysis finished
+	analysisResolved = "resolved"
+)
+
+// analysisJob is the response of POST /api/analyses and GET /api/analyses/{id}: forced
+// analyses of pods and how far they got
+type analysisJob struct {
+	ID string `json:"id"`
+	// State is queued until a PodSleuth picked the job up, running until every pod has
+	// its analysis, then completed; failed if a PodSleuth could not be told or the job
+	// timed out
+	State       string       `json:"state"`
+	CreatedAt   metav1.Time  `json:"createdAt"`
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Generation is the refreshGeneration of the forced analyses
+	Generation int64 `json:"generation"`
+	// Total and Completed count the pods and those done, completed or resolved
+	Total     int              `json:"total"`
+	Completed int              `json:"completed"`
+	Pods      []analysisJobPod `json:"pods"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// analysisJobPod is a pod of an analysis job
+type analysisJobPod struct {
+	PodSleuth string `json:"podSleuth"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// State is queued, running, completed, resolved or failed
+	State string `json:"state"`
+	// Result is the analysis, once completed
+	Result *infrav1alpha1.LogAnalysisResult `json:"result,omitempty"`
+	Error  string                           `json:"error,omitempty"`
+}
+
+// analysisJobs holds the analysis jobs of the replica serving the dashboard
+type analysisJobs struct {
+	jobs    map[string]*analysisJob
+	jobsMux sync.Mutex
+}
+
+// add keeps a copy of a new job, forgetting those past their retention
+func (a *analysisJobs) add(job *analysisJob) {
+	a.jobsMux.Lock()
+	defer a.jobsMux.Unlock()
+	if a.jobs == nil {
+		a.jobs = make(map[string]*analysisJob)
+	}
+	for id, existing := range a.jobs {
+		if time.Since(existing.CreatedAt.Time) > analysisJobRetention {
+			delete(a.jobs, id)
+		}
+	}
+	stored := *job
+	stored.Pods = append([]analysisJobPod(nil), job.Pods...)
+	a.jobs[job.ID] = &stored
+}
+
+// get returns a copy of a job, nil if unknown
+func (a *analysisJobs) get(id string) *analysisJob {
+	a.jobsMux.Lock()
+	defer a.jobsMux.Unlock()
+	job, exists := a.jobs[id]
+	if !exists || time.Since(job.CreatedAt.Time) > analysisJobRetention {
+		return nil
+	}
+	copied := *job
+	copied.Pods = append([]analysisJobPod(nil), job.Pods...)
+	return &copied
+}
+
+// finish records the end of a job once it is observed, so that it stays finished
+func (a *analysisJobs) finish(finished *analysisJob) {
+	a.jobsMux.Lock()
+	defer a.jobsMux.Unlock()
+	if job, exists := a.jobs[finished.ID]; exists && job.CompletedAt == nil {
+		*job = *finished
+		job.Pods = append([]analysisJobPod(nil), finished.Pods...)
+	}
+}
+
+// handleAnalyses starts an analysis job: POST /api/analyses with the body of
+// POST /api/force-refresh. The job is answered with 202 Accepted and tracked on
+// /api/analyses/{id}.
+func (s *Server) handleAnalyses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var reqBody forceRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, fmt.Sprintf("Invalid analysis request: %v", err), http.StatusBadRequest)
+		return
+	}
+	pods, err := forceRefreshPods(reqBody)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	refresh, err := s.forceRefresh(r.Context(), strings.TrimSpace(reqBody.PodSleuth), pods)
+	if errors.Is(err, errForceRefreshTargetNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	job := &analysisJob{
+		ID:         randomString(),
+		State:      analysisQueued,
+		CreatedAt:  metav1.Now(),
+		Generation: refresh.Generation,
+		Pods:       []analysisJobPod{},
+	}
+	for _, result := range refresh.Results {
+		for _, pod := range result.Pods {
+			namespace, name, _ := strings.Cut(pod, "/")
+			jobPod := analysisJobPod{PodSleuth: result.PodSleuth, Namespace: namespace, Name: name, State: analysisQueued}
+			if !result.Success {
+				jobPod.State = analysisFailed
+				jobPod.Error = result.Error
+			}
+			job.Pods = append(job.Pods, jobPod)
+		}
+	}
+	s.analyses.add(job)
+	log.Log.WithName("web").Info("analysis job started", "id", job.ID, "pods", len(job.Pods), "generation", job.Generation)
+
+	if err := s.updateAnalysisJob(r, job); err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Location", s.basePath+"/api/analyses/"+job.ID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleAnalysis reports the progress of an analysis job: GET /api/analyses/{id}
+func (s *Server) handleAnalysis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := strings.Trim(r.URL.Path[len("/api/analyses/"):], "/")
+	job := s.analyses.get(id)
+	if job == nil {
+		http.Error(w, fmt.Sprintf("Analysis job %q not found", id), http.StatusNotFound)
+		return
+	}
+	if err := s.updateAnalysisJob(r, job); err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, r, job)
+}
+
+// updateAnalysisJob fills in the progress of a job from the status of its PodSleuths
+func (s *Server) updateAnalysisJob(r *http.Request, job *analysisJob) error {
+	if job.CompletedAt != nil {
+		return nil
+	}
+	podSleuths := make(map[string]*infrav1alpha1.PodSleuth)
+	for _, pod := range job.Pods {
+		if _, fetched := podSleuths[pod.PodSleuth]; fetched || pod.State == analysisFailed {
+			continue
+		}
+		var podSleuth infrav1alpha1.PodSleuth
+		if err := s.client.Get(r.Context(), client.ObjectKey{Name: pod.PodSleuth}, &podSleuth); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			// Deleted PodSleuths report no pods
+		}
+		podSleuths[pod.PodSleuth] = &podSleuth
+	}
+
+	generation := strconv.FormatInt(job.Generation, 10)
+	job.Total, job.Completed = len(job.Pods), 0
+	failed, running := false, false
+	for i := range job.Pods {
+		pod := &job.Pods[i]
+		if pod.State != analysisFailed {
+			pod.State, pod.Result = analysisJobPodState(podSleuths[pod.PodSleuth], pod.Namespace, pod.Name, generation, job.Generation)
+		}
+		switch pod.State {
+		case analysisCompleted, analysisResolved:
+			job.Completed++
+		case analysisFailed:
+			failed = true
+		case analysisRunning:
+			running = true
+		}
+	}
+
+	switch {
+	case failed:
+		job.State = analysisFailed
+		job.Error = "a PodSleuth could not be told to analyze its pods"
+	case job.Completed == job.Total:
+		job.State = analysisCompleted
+	case time.Since(job.CreatedAt.Time) > analysisJobTimeout:
+		job.State = analysisFailed
+		job.Error = fmt.Sprintf("analyses did not finish within %s", analysisJobTimeout)
+	case running:
+		job.State = analysisRunning
+	default:
+		job.State = analysisQueued
+	}
+	if job.State == analysisCompleted || job.State == analysisFailed {
+		now := metav1.Now()
+		job.CompletedAt = &now
+		s.analyses.finish(job)
+	}
+	return nil
+}
+
+// analysisJobPodState returns the state of a pod of an analysis job of generation, and
+// its analysis once completed
+func analysisJobPodState(podSleuth *infrav1alpha1.PodSleuth, namespace, name, generation string, generationValue int64) (string, *infrav1alpha1.LogAnalysisResult) {
+	for i := range podSleuth.Status.NonReadyPods {
+		pod := &podSleuth.Status.NonReadyPods[i]
+		if pod.Namespace != namespace || pod.Name != name {
+			continue
+		}
+		if pod.LogAnalysis != nil && pod.LogAnalysis.RefreshGeneration >= generationValue {
+			return analysisCompleted, pod.LogAnalysis
+		}
+		// The PodSleuth has not reconciled since the job started
+		if podSleuth.Annotations[controller.AnnotationForceRefreshGeneration] == generation {
+			return analysisQueued, nil
+		}
+		return analysisRunning, nil
+	}
+	return analysisResolved, nil
+}
+
+// PodAnalyzer analyzes the logs of any pod on demand, ready or not
+type PodAnalyzer interface {
+	AnalyzePod(ctx context.Context, namespace, name, podSleuth string) (*controller.PodAnalysisOnDemand, error)
+}
+
+// EnableOnDemandAnalysis lets users analyze any pod they name on
+// /api/pods/{namespace}/{name}/analyze, including ready pods that misbehave or pods that
+// recovered before a reconcile reported them
+func (s *Server) EnableOnDemandAnalysis(analyzer PodAnalyzer) {
+	s.analyzer = analyzer
+}
+
+// onDemandAnalysis is the response of POST /api/pods/{namespace}/{name}/analyze
+type onDemandAnalysis struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	// PodSleuth is the PodSleuth whose log analysis configuration was used
+	PodSleuth string `json:"podSleuth"`
+	// Ready is whether the pod was ready when analyzed
+	Ready bool `json:"ready"`
+	// Result is the analysis, omitted if the pod had no log output
+	Result *infrav1alpha1.LogAnalysisResult `json:"result,omitempty"`
+}
+
+// handleAnalyzePod analyzes a pod now and returns the analysis, with the log analysis
+// configuration of the PodSleuth named with ?podSleuth=, or else the first selecting it
+func (s *Server) handleAnalyzePod(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// The analysis reads the logs of ready pods too and spends AI budget
+	if !s.auth.Enabled() {
+		http.Error(w, "On-demand analysis requires dashboard authentication", http.StatusForbidden)
+		return
+	}
+	if s.analyzer == nil {
+		http.Error(w, "On-demand analysis is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	analysis, err := s.analyzer.AnalyzePod(r.Context(), namespace, name, r.URL.Query().Get("podSleuth"))
+	switch {
+	case apierrors.IsNotFound(err):
+		http.Error(w, fmt.Sprintf("Pod %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	case errors.Is(err, controller.ErrNoAnalyzingPodSleuth):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Error analyzing pod: %v", err), http.StatusInternalServerError)
+		return
+	}
+	log.Log.WithName("web").Info("pod analyzed on demand", "namespace", namespace, "pod", name, "podSleuth", analysis.PodSleuth, "ready", analysis.Ready)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(onDemandAnalysis{
+		Namespace: namespace,
+		Pod:       name,
+		PodSleuth: analysis.PodSleuth,
+		Ready:     analysis.Ready,
+		Result:    analysis.Result,
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// countingAnalyzer counts the pods it is asked to analyze
+type countingAnalyzer struct {
+	analyzed int
+}
+
+func (a *countingAnalyzer) AnalyzePod(ctx context.Context, namespace, name, podSleuth string) (*controller.PodAnalysisOnDemand, error) {
+	a.analyzed++
+	return &controller.PodAnalysisOnDemand{PodSleuth: "production", Ready: true, Result: &infrav1alpha1.LogAnalysisResult{RootCause: "slow queries"}}, nil
+}
+
+func TestHandleAnalyzePodRequiresAuthentication(t *testing.T) {
+	analyzer := &countingAnalyzer{}
+	s := &Server{}
+	s.EnableOnDemandAnalysis(analyzer)
+
+	recorder := httptest.NewRecorder()
+	s.handleGetPod(recorder, httptest.NewRequest(http.MethodPost, "/api/pods/shop/cart-1/analyze", nil))
+	if recorder.Code != http.StatusForbidden || analyzer.analyzed != 0 {
+		t.Errorf("without authentication: got %d after %d analyses, want %d before any", recorder.Code, analyzer.analyzed, http.StatusForbidden)
+	}
+
+	s.EnableAuth(AuthConfig{Token: "api-token"})
+	recorder = httptest.NewRecorder()
+	s.handleGetPod(recorder, httptest.NewRequest(http.MethodPost, "/api/pods/shop/cart-1/analyze", nil))
+	if recorder.Code != http.StatusOK || analyzer.analyzed != 1 {
+		t.Errorf("with authentication: got %d after %d analyses, want %d after one", recorder.Code, analyzer.analyzed, http.StatusOK)
+	}
+}
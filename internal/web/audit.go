@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// DefaultChangesPerMinute is the default number of changes, such as forced analyses or
+// new silences, each client may make per minute
+const DefaultChangesPerMinute = 30
+
+// defaultAIRequestListLimit is the default number of AI requests listed by /api/audit/ai-requests
+const defaultAIRequestListLimit = 1000
+
+// clientLimiterIdle is how long the rate limiter of a client making no changes is kept
+const clientLimiterIdle = 10 * time.Minute
+
+// auditLog records who changed what through the dashboard and its APIs
+var auditLog = log.Log.WithName("web").WithName("audit")
+
+// SetChangeRateLimit limits the changes each client may make per minute, by user when
+// authenticated and by address otherwise. Forced analyses in particular analyze every
+// non-ready pod again. A limit of 0 means unlimited.
+func (s *Server) SetChangeRateLimit(perMinute int) {
+	if perMinute <= 0 {
+		s.changeLimiters = nil
+		return
+	}
+	s.changeLimiters = &clientLimiters{perMinute: perMinute}
+}
+
+// clientLimiters limits the rate of changes of each client
+type clientLimiters struct {
+	perMinute int
+
+	clients    map[string]*clientLimiter
+	pruned     time.Time
+	clientsMux sync.Mutex
+}
+
+// clientLimiter is the rate limiter of one client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// allow reports whether client may make a change now, or else how long it has to wait.
+// Clients may make a third of a minute's changes at once. A nil limiter allows all.
+func (c *clientLimiters) allow(client string) (bool, time.Duration) {
+	if c == nil {
+		return true, 0
+	}
+	now := time.Now()
+	c.clientsMux.Lock()
+	defer c.clientsMux.Unlock()
+
+	if c.clients == nil {
+		c.clients = make(map[string]*clientLimiter)
+	}
+	// Forget clients that went away
+	if now.Sub(c.pruned) > clientLimiterIdle {
+		for key, limiter := range c.clients {
+			if now.Sub(limiter.lastSeen) > clientLimiterIdle {
+				delete(c.clients, key)
+			}
+		}
+		c.pruned = now
+	}
+	limiter, exists := c.clients[client]
+	if !exists {
+		limiter = &clientLimiter{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(c.perMinute)), max(1, c.perMinute/3))}
+		c.clients[client] = limiter
+	}
+	limiter.lastSeen = now
+
+	reservation := limiter.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// isChange reports whether a request changes state, rather than reading it
+func isChange(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	// Agents pushing the findings of their cluster change nothing on users' behalf
+	if strings.HasPrefix(r.URL.Path, "/api/hub/") {
+		return false
+	}
+	return strings.HasPrefix(r.URL.Path, "/api/")
+}
+
+// clientOf identifies the client of a request for rate limiting: the authenticated user,
+// or the remote address
+func clientOf(id *identity, remoteAddr string) string {
+	if id != nil {
+		return "user:" + id.User
+	}
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return "address:" + host
+	}
+	return "address:" + remoteAddr
+}
+
+// auditChanges rate limits and logs the API requests changing state, with their
+// authenticated user and outcome
+func (s *Server) auditChanges(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isChange(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		id := requestIdentity(r)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		if allowed, retryAfter := s.changeLimiters.allow(clientOf(id, r.RemoteAddr)); allowed {
+			next.ServeHTTP(recorder, r)
+		} else {
+			recorder.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(recorder, "Too many changes, retry later", http.StatusTooManyRequests)
+		}
+		auditChange(id, r.RemoteAddr, r.Method+" "+r.URL.Path, strconv.Itoa(recorder.status), time.Since(start))
+	})
+}
+
+// auditChange logs a change of a client. Anonymous clients are logged by address.
+func auditChange(id *identity, remoteAddr, request, outcome string, duration time.Duration) {
+	values := []interface{}{"request", request, "outcome", outcome, "remoteAddr", remoteAddr, "duration", duration.String()}
+	if id != nil {
+		values = append(values, "user", id.User, "authMethod", id.Method)
+	}
+	auditLog.Info("dashboard change", values...)
+}
+
+// statusRecorder records the status code of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap gives http.ResponseController access to the wrapped response
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// outboundAIRequests is the response of GET /api/audit/ai-requests
+type outboundAIRequests struct {
+	Count    int                         `json:"count"`
+	Requests []controller.AIEgressRecord `json:"requests"`
+}
+
+// handleAIRequests lists the AI requests that sent pod logs out of the cluster, newest
+// first, so security can verify what left the cluster and when: /api/audit/ai-requests
+func (s *Server) handleAIRequests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	filter := controller.AIEgressFilter{Namespace: query.Get("namespace"), Pod: query.Get("pod")}
+	limit, err := queryInt(query.Get("limit"), defaultAIRequestListLimit)
+	if err != nil || limit < 1 {
+		http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+		return
+	}
+	filter.Limit = limit
+	if value := query.Get("since"); value != "" {
+		// A time, or a duration back from now
+		if since, err := time.Parse(time.RFC3339, value); err == nil {
+			filter.Since = since
+		} else if ago, err := time.ParseDuration(value); err == nil && ago > 0 {
+			filter.Since = time.Now().Add(-ago)
+		} else {
+			http.Error(w, "since must be an RFC 3339 time or a positive duration such as 1h", http.StatusBadRequest)
+			return
+		}
+	}
+
+	requests := controller.AIEgressRecords(filter)
+	writeJSON(w, r, outboundAIRequests{Count: len(requests), Requests: requests})
+}
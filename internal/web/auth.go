@@ -0,0 +1,516 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// sessionCookie holds the signed session of a user logged in with OIDC
+	sessionCookie = "kubesleuth_session"
+	// loginCookie holds the state and nonce of a login in progress
+	loginCookie = "kubesleuth_login"
+	// sessionDuration is how long a login lasts
+	sessionDuration = 8 * time.Hour
+	// loginDuration is how long a user has to complete a login at the identity provider
+	loginDuration = 10 * time.Minute
+)
+
+// oidcHTTPClient talks to the identity provider
+var oidcHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// AuthConfig configures how the dashboard and its API authenticate requests. Any of
+// the methods may be combined; with none configured the dashboard is open.
+type AuthConfig struct {
+	// Token is a static bearer token for API clients
+	Token string
+	// Username and Password enable HTTP basic authentication
+	Username string
+	Password string
+	// OIDC enables login with an OpenID Connect provider (nil = disabled)
+	OIDC *OIDCConfig
+	// SessionKey signs session cookies. Replicas serving the same dashboard must share
+	// it; a random key is used if empty.
+	SessionKey []byte
+}
+
+// OIDCConfig configures login with an OpenID Connect provider
+type OIDCConfig struct {
+	// IssuerURL is the issuer whose discovery document is read
+	IssuerURL string
+	ClientID  string
+	// ClientSecret authenticates the dashboard at the token endpoint
+	ClientSecret string
+	// RedirectURL is the dashboard's /auth/callback URL registered at the provider
+	RedirectURL string
+	// GroupsClaim is the ID token claim listing the user's groups (default "groups")
+	GroupsClaim string
+	// AllowedGroups limits login to members of these groups (empty = any user)
+	AllowedGroups []string
+}
+
+// Enabled reports whether any authentication method is configured
+func (c *AuthConfig) Enabled() bool {
+	return c != nil && (c.Token != "" || c.Username != "" || c.OIDC != nil)
+}
+
+// identity is the authenticated user of a request
+type identity struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+	// Method is "token", "basic" or "oidc"
+	Method string `json:"method"`
+	// Expires is when an OIDC session ends, in Unix seconds
+	Expires int64 `json:"exp,omitempty"`
+}
+
+// identityKey is the request context key of the authenticated identity
+type identityKey struct{}
+
+// requestIdentity returns the authenticated user of a request, nil if none
+func requestIdentity(r *http.Request) *identity {
+	id, _ := r.Context().Value(identityKey{}).(*identity)
+	return id
+}
+
+// oidcProvider is the discovered configuration of an OpenID Connect provider
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// EnableAuth requires requests to the dashboard and its API to authenticate
+func (s *Server) EnableAuth(config AuthConfig) {
+	if len(config.SessionKey) == 0 {
+		config.SessionKey = make([]byte, 32)
+		_, _ = rand.Read(config.SessionKey)
+	}
+	if config.OIDC != nil && config.OIDC.GroupsClaim == "" {
+		config.OIDC.GroupsClaim = "groups"
+	}
+	s.auth = &config
+}
+
+// authenticate lets requests through that carry a valid session, bearer token or basic
+// credentials. Browsers are sent to the OIDC login, or asked for basic credentials.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if !s.auth.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/auth/") || r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if id := s.authenticateRequest(r); id != nil {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, id)))
+			return
+		}
+		// The approval token authorizes the approval endpoint, which checks it itself,
+		// likewise the deploy hook token deploy hooks and the hub token the findings
+		// pushed by agents
+		if tokenBypass(r, "/api/remediations/", s.approvalToken) || tokenBypass(r, "/api/hooks/", s.deployHookToken) ||
+			tokenBypass(r, "/api/hub/", slices.Collect(maps.Values(s.hubTokens))...) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if s.auth.OIDC != nil && r.Method == http.MethodGet && !strings.HasPrefix(r.URL.Path, "/api/") {
+			http.Redirect(w, r, s.basePath+"/auth/login?next="+url.QueryEscape(s.basePath+r.URL.RequestURI()), http.StatusFound)
+			return
+		}
+		if s.auth.Username != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="KubeSleuth", charset="UTF-8"`)
+		}
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// authenticateRequest returns the identity a request authenticates as, nil if none
+func (s *Server) authenticateRequest(r *http.Request) *identity {
+	if cookie, err := r.Cookie(sessionCookie); err == nil && s.auth.OIDC != nil {
+		var session identity
+		if s.verifyCookie(cookie.Value, &session) && time.Now().Unix() < session.Expires {
+			return &session
+		}
+	}
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && s.auth.Token != "" {
+		if tokenEqual(token, s.auth.Token) {
+			return &identity{User: "token", Method: "token"}
+		}
+	}
+	if username, password, ok := r.BasicAuth(); ok && s.auth.Username != "" {
+		if tokenEqual(username, s.auth.Username) && tokenEqual(password, s.auth.Password) {
+			return &identity{User: username, Method: "basic"}
+		}
+	}
+	return nil
+}
+
+// tokenBypass reports whether a request to a path under prefix carries one of tokens as
+// bearer token. The path is cleaned first, so dot segments cannot lead out of the prefix.
+func tokenBypass(r *http.Request, prefix string, tokens ...string) bool {
+	if !strings.HasPrefix(path.Clean(r.URL.Path)+"/", prefix) {
+		return false
+	}
+	bearer, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	matched := false
+	for _, token := range tokens {
+		if token != "" && found && tokenEqual(bearer, token) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// tokenEqual compares secrets in constant time
+func tokenEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// signCookie encodes a value as a cookie signed with the session key
+func (s *Server) signCookie(value interface{}) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, s.auth.SessionKey)
+	mac.Write([]byte(encoded))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// verifyCookie decodes a cookie signed with the session key into value
+func (s *Server) verifyCookie(cookie string, value interface{}) bool {
+	encoded, signature, found := strings.Cut(cookie, ".")
+	if !found {
+		return false
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, s.auth.SessionKey)
+	mac.Write([]byte(encoded))
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(payload, value) == nil
+}
+
+// setCookie sets an HTTP-only cookie, secure when the dashboard is served over HTTPS
+func (s *Server) setCookie(w http.ResponseWriter, name, value string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     s.basePath + "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.auth.OIDC.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// loginState is the state of a login in progress
+type loginState struct {
+	State   string `json:"state"`
+	Nonce   string `json:"nonce"`
+	Next    string `json:"next"`
+	Expires int64  `json:"exp"`
+}
+
+// randomString returns a random URL-safe string
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// handleLogin sends the browser to the OIDC provider: /auth/login?next=/path
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.auth.Enabled() || s.auth.OIDC == nil {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	provider, err := s.oidcProvider(r.Context())
+	if err != nil {
+		log.Log.WithName("web").Error(err, "unable to discover OIDC provider", "issuer", s.auth.OIDC.IssuerURL)
+		http.Error(w, "Identity provider unavailable", http.StatusBadGateway)
+		return
+	}
+
+	// Only redirect back to paths of the dashboard
+	next := r.URL.Query().Get("next")
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") {
+		next = s.basePath + "/"
+	}
+	login := loginState{State: randomString(), Nonce: randomString(), Next: next, Expires: time.Now().Add(loginDuration).Unix()}
+	cookie, err := s.signCookie(login)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.setCookie(w, loginCookie, cookie, loginDuration)
+
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {s.auth.OIDC.ClientID},
+		"redirect_uri":  {s.auth.OIDC.RedirectURL},
+		"scope":         {"openid profile email"},
+		"state":         {login.State},
+		"nonce":         {login.Nonce},
+	}
+	separator := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	http.Redirect(w, r, provider.AuthorizationEndpoint+separator+query.Encode(), http.StatusFound)
+}
+
+// handleCallback completes an OIDC login: /auth/callback?code=...&state=...
+func (s *Server) handleCallback(w http.ResponseWriter, r *http.Request) {
+	if !s.auth.Enabled() || s.auth.OIDC == nil {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	logger := log.Log.WithName("web")
+
+	var login loginState
+	cookie, err := r.Cookie(loginCookie)
+	if err != nil || !s.verifyCookie(cookie.Value, &login) || time.Now().Unix() > login.Expires ||
+		!tokenEqual(r.URL.Query().Get("state"), login.State) {
+		http.Error(w, "Login expired or invalid, please retry", http.StatusBadRequest)
+		return
+	}
+	s.setCookie(w, loginCookie, "", -time.Second)
+	if errCode := r.URL.Query().Get("error"); errCode != "" {
+		http.Error(w, "Login failed: "+errCode, http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := s.exchangeCode(r.Context(), r.URL.Query().Get("code"))
+	if err == nil {
+		err = s.validateClaims(claims, login.Nonce)
+	}
+	if err != nil {
+		logger.Info("OIDC login failed", "error", err)
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	session := identity{User: claimString(claims, "email"), Method: "oidc", Expires: time.Now().Add(sessionDuration).Unix()}
+	if session.User == "" {
+		session.User = claimString(claims, "sub")
+	}
+	groups := claimStrings(claims, s.auth.OIDC.GroupsClaim)
+	if allowed := s.auth.OIDC.AllowedGroups; len(allowed) > 0 {
+		// Only the groups that matter are kept, so the cookie stays small
+		groups = slices.DeleteFunc(groups, func(group string) bool { return !slices.Contains(allowed, group) })
+		if len(groups) == 0 {
+			logger.Info("OIDC login denied, user is in none of the allowed groups", "user", session.User)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+	session.Groups = groups
+
+	value, err := s.signCookie(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.setCookie(w, sessionCookie, value, sessionDuration)
+	logger.Info("dashboard login", "user", session.User)
+	http.Redirect(w, r, login.Next, http.StatusFound)
+}
+
+// handleLogout ends an OIDC session
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if s.auth.Enabled() && s.auth.OIDC != nil {
+		s.setCookie(w, sessionCookie, "", -time.Second)
+	}
+	http.Redirect(w, r, s.basePath+"/", http.StatusFound)
+}
+
+// whoami is the response of GET /api/whoami
+type whoami struct {
+	AuthEnabled bool `json:"authEnabled"`
+	// User, Groups and Method describe the authenticated user, omitted for anonymous
+	// requests
+	User   string   `json:"user,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Method is "token", "basic" or "oidc"
+	Method string `json:"method,omitempty"`
+}
+
+// handleWhoami returns the authenticated user of the request, for the dashboard header
+func (s *Server) handleWhoami(w http.ResponseWriter, r *http.Request) {
+	response := whoami{AuthEnabled: s.auth.Enabled()}
+	if id := requestIdentity(r); id != nil {
+		response.User = id.User
+		response.Groups = id.Groups
+		response.Method = id.Method
+	}
+	writeJSON(w, r, response)
+}
+
+// oidcProvider discovers the provider's endpoints once
+func (s *Server) oidcProvider(ctx context.Context) (*oidcProvider, error) {
+	s.oidcMux.Lock()
+	defer s.oidcMux.Unlock()
+	if s.oidc != nil {
+		return s.oidc, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(s.auth.OIDC.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery returned %s", resp.Status)
+	}
+	var provider oidcProvider
+	if err := json.NewDecoder(resp.Body).Decode(&provider); err != nil {
+		return nil, err
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" {
+		return nil, errors.New("discovery document lacks authorization or token endpoint")
+	}
+	s.oidc = &provider
+	return s.oidc, nil
+}
+
+// exchangeCode redeems an authorization code and returns the claims of the ID token
+func (s *Server) exchangeCode(ctx context.Context, code string) (map[string]interface{}, error) {
+	if code == "" {
+		return nil, errors.New("missing authorization code")
+	}
+	provider, err := s.oidcProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {s.auth.OIDC.RedirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(s.auth.OIDC.ClientID), url.QueryEscape(s.auth.OIDC.ClientSecret))
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, err
+	}
+
+	// The ID token comes straight from the token endpoint over TLS, which OpenID Connect
+	// Core (3.1.3.7) accepts in place of checking its signature
+	parts := strings.Split(tokens.IDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token: %w", err)
+	}
+	return claims, nil
+}
+
+// validateClaims checks the issuer, audience, expiry and nonce of an ID token
+func (s *Server) validateClaims(claims map[string]interface{}, nonce string) error {
+	if issuer := claimString(claims, "iss"); strings.TrimSuffix(issuer, "/") != strings.TrimSuffix(s.auth.OIDC.IssuerURL, "/") {
+		return fmt.Errorf("unexpected issuer %q", issuer)
+	}
+	if !slices.Contains(claimStrings(claims, "aud"), s.auth.OIDC.ClientID) {
+		return errors.New("ID token is not for this client")
+	}
+	if exp, ok := claims["exp"].(float64); !ok || time.Now().Unix() > int64(exp) {
+		return errors.New("ID token expired")
+	}
+	if !tokenEqual(claimString(claims, "nonce"), nonce) {
+		return errors.New("nonce mismatch")
+	}
+	return nil
+}
+
+// claimString returns a string claim, empty if missing
+func claimString(claims map[string]interface{}, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// claimStrings returns a claim holding a string or a list of strings
+func claimStrings(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
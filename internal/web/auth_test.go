@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newAuthTestServer returns a server with every authentication method and token
+// bypass configured
+func newAuthTestServer() *Server {
+	s := &Server{approvalToken: "approval-token", deployHookToken: "deploy-token", hubTokens: map[string]string{"prod-eu-1": "hub-token"}}
+	s.EnableAuth(AuthConfig{
+		Token:      "api-token",
+		Username:   "admin",
+		Password:   "s3cret",
+		OIDC:       &OIDCConfig{IssuerURL: "https://idp.example.com", ClientID: "kubesleuth", RedirectURL: "https://kubesleuth.example.com/auth/callback"},
+		SessionKey: []byte("0123456789abcdef0123456789abcdef"),
+	})
+	return s
+}
+
+// serveAuthenticated sends a request through the authentication middleware and returns
+// the response status and the user the handler saw, "-" for an unauthenticated request
+// that was let through
+func serveAuthenticated(s *Server, r *http.Request) (int, string) {
+	user := ""
+	handler := s.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = "-"
+		if id := requestIdentity(r); id != nil {
+			user = id.User
+		}
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	return recorder.Code, user
+}
+
+func TestAuthenticateSessionCookie(t *testing.T) {
+	s := newAuthTestServer()
+	valid, err := s.signCookie(identity{User: "jane@example.com", Method: "oidc", Expires: time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := s.signCookie(identity{User: "jane@example.com", Method: "oidc", Expires: time.Now().Add(-time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The payload claims another user, with the signature of the valid session
+	encoded, signature, _ := strings.Cut(valid, ".")
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"user":"admin@example.com","method":"oidc","exp":4102444800}`)) + "." + signature
+	other := newAuthTestServer()
+	other.auth.SessionKey = []byte("another session key of 32 bytes!")
+	foreign, err := other.signCookie(identity{User: "jane@example.com", Method: "oidc", Expires: time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		cookie   string
+		wantCode int
+		wantUser string
+	}{
+		{"valid session", valid, http.StatusOK, "jane@example.com"},
+		{"expired session", expired, http.StatusUnauthorized, ""},
+		{"tampered payload", tampered, http.StatusUnauthorized, ""},
+		{"tampered signature", encoded + ".c2lnbmF0dXJl", http.StatusUnauthorized, ""},
+		{"unsigned session", encoded, http.StatusUnauthorized, ""},
+		{"signed with another key", foreign, http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/podsleuths", nil)
+			r.AddCookie(&http.Cookie{Name: sessionCookie, Value: tt.cookie})
+			code, user := serveAuthenticated(s, r)
+			if code != tt.wantCode || user != tt.wantUser {
+				t.Errorf("got %d as %q, want %d as %q", code, user, tt.wantCode, tt.wantUser)
+			}
+		})
+	}
+
+	// Browsers without a valid session are sent to the login
+	r := httptest.NewRequest(http.MethodGet, "/pods", nil)
+	r.AddCookie(&http.Cookie{Name: sessionCookie, Value: expired})
+	if code, _ := serveAuthenticated(s, r); code != http.StatusFound {
+		t.Errorf("page with an expired session: got %d, want a redirect to the login", code)
+	}
+}
+
+func TestAuthenticateCredentials(t *testing.T) {
+	s := newAuthTestServer()
+	tests := []struct {
+		name          string
+		authorization string
+		username      string
+		password      string
+		wantCode      int
+		wantUser      string
+	}{
+		{name: "bearer token", authorization: "Bearer api-token", wantCode: http.StatusOK, wantUser: "token"},
+		{name: "wrong bearer token", authorization: "Bearer api-token2", wantCode: http.StatusUnauthorized},
+		{name: "bearer token prefix", authorization: "Bearer api", wantCode: http.StatusUnauthorized},
+		{name: "empty bearer token", authorization: "Bearer ", wantCode: http.StatusUnauthorized},
+		{name: "token without scheme", authorization: "api-token", wantCode: http.StatusUnauthorized},
+		{name: "basic credentials", username: "admin", password: "s3cret", wantCode: http.StatusOK, wantUser: "admin"},
+		{name: "wrong password", username: "admin", password: "s3cre", wantCode: http.StatusUnauthorized},
+		{name: "wrong username", username: "root", password: "s3cret", wantCode: http.StatusUnauthorized},
+		{name: "empty password", username: "admin", wantCode: http.StatusUnauthorized},
+		{name: "no credentials", wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/podsleuths", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.username != "" {
+				r.SetBasicAuth(tt.username, tt.password)
+			}
+			code, user := serveAuthenticated(s, r)
+			if code != tt.wantCode || user != tt.wantUser {
+				t.Errorf("got %d as %q, want %d as %q", code, user, tt.wantCode, tt.wantUser)
+			}
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/podsleuths", nil)
+	r.SetBasicAuth("admin", "wrong")
+	recorder := httptest.NewRecorder()
+	s.authenticate(http.NotFoundHandler()).ServeHTTP(recorder, r)
+	if recorder.Header().Get("WWW-Authenticate") == "" {
+		t.Error("rejected request is not asked for basic credentials")
+	}
+}
+
+func TestAuthenticateTokenBypasses(t *testing.T) {
+	s := newAuthTestServer()
+	tests := []struct {
+		token string
+		path  string
+		// wantPass is whether the request reaches the handler, unauthenticated
+		wantPass bool
+	}{
+		{"approval-token", "/api/remediations/shop/cart/approve", true},
+		{"approval-token", "/api/podsleuths", false},
+		{"approval-token", "/api/hooks/deploy", false},
+		{"approval-token", "/api/hub/findings", false},
+		{"approval-token", "/api/remediations/../podsleuths", false},
+		{"deploy-token", "/api/hooks/deploy", true},
+		{"deploy-token", "/api/remediations/shop/cart/approve", false},
+		{"deploy-token", "/api/hub/findings", false},
+		{"deploy-token", "/api/pods/shop/cart-1/logs", false},
+		{"deploy-token", "/api/hooks/../pods/shop/cart-1/logs", false},
+		{"hub-token", "/api/hub/findings", true},
+		{"hub-token", "/api/hooks/deploy", false},
+		{"hub-token", "/api/remediations/shop/cart/approve", false},
+		{"hub-token", "/api/force-refresh", false},
+		{"hub-token", "/api/hub/%2e%2e/force-refresh", false},
+		{"wrong-token", "/api/hub/findings", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			code, user := serveAuthenticated(s, r)
+			if tt.wantPass && (code != http.StatusOK || user != "-") {
+				t.Errorf("got %d as %q, want to pass without an identity", code, user)
+			}
+			if !tt.wantPass && code != http.StatusUnauthorized {
+				t.Errorf("got %d, want %d", code, http.StatusUnauthorized)
+			}
+		})
+	}
+
+	// Bypasses of disabled features are closed
+	s.hubTokens = nil
+	r := httptest.NewRequest(http.MethodPost, "/api/hub/findings", nil)
+	r.Header.Set("Authorization", "Bearer ")
+	if code, _ := serveAuthenticated(s, r); code != http.StatusUnauthorized {
+		t.Errorf("empty token with hub disabled: got %d, want %d", code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
+)
+
+const (
+	// liveEventBuffer is how many events a slow client may fall behind before it is
+	// disconnected to reload
+	liveEventBuffer = 64
+	// liveKeepAlive is how often idle streams send a comment so proxies keep them open
+	liveKeepAlive = 30 * time.Second
+)
+
+// liveEvent is a server-sent event pushed to dashboards
+type liveEvent struct {
+	// Name is "podsleuth" for PodSleuth changes and "analysis" for finished analyses
+	Name string
+	// PodSleuth is the changed PodSleuth, nil if it was deleted
+	PodSleuth *infrav1alpha1.PodSleuth
+	// Data is the payload of other events
+	Data interface{}
+}
+
+// podSleuthEvent is the payload of a "podsleuth" event
+type podSleuthEvent struct {
+	Type      string                   `json:"type"`
+	Name      string                   `json:"name"`
+	PodSleuth *infrav1alpha1.PodSleuth `json:"podSleuth,omitempty"`
+}
+
+// analysisEvent is the payload of an "analysis" event
+type analysisEvent struct {
+	PodSleuth  string      `json:"podSleuth"`
+	Namespace  string      `json:"namespace"`
+	Pod        string      `json:"pod"`
+	RootCause  string      `json:"rootCause,omitempty"`
+	AnalyzedAt metav1.Time `json:"analyzedAt"`
+	// RefreshGeneration is the generation of the forced analysis, 0 for others
+	RefreshGeneration int64 `json:"refreshGeneration,omitempty"`
+	// team owns the pod, for streams limited to one team
+	team string
+}
+
+// liveUpdates fans PodSleuth changes out to the dashboards streaming them
+type liveUpdates struct {
+	mu          sync.Mutex
+	subscribers map[chan liveEvent]struct{}
+}
+
+// subscribe registers a dashboard stream
+func (l *liveUpdates) subscribe() chan liveEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch := make(chan liveEvent, liveEventBuffer)
+	if l.subscribers == nil {
+		l.subscribers = make(map[chan liveEvent]struct{})
+	}
+	l.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes a dashboard stream
+func (l *liveUpdates) unsubscribe(ch chan liveEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.subscribers[ch]; exists {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish sends an event to every stream. Streams too slow to take it are closed, so
+// their dashboard reconnects and reloads instead of missing changes.
+func (l *liveUpdates) publish(event liveEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// EnableLiveUpdates streams PodSleuth changes from the operator's informer cache to
+// dashboards on /api/events
+func (s *Server) EnableLiveUpdates(informers cache.Informers) {
+	s.informers = informers
+}
+
+// watchPodSleuths publishes the changes of PodSleuths and the analyses they finish
+func (s *Server) watchPodSleuths(ctx context.Context) error {
+	informer, err := s.informers.GetInformer(ctx, &infrav1alpha1.PodSleuth{})
+	if err != nil {
+		return err
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if podSleuth, ok := obj.(*infrav1alpha1.PodSleuth); ok {
+				s.live.publish(liveEvent{Name: "podsleuth", PodSleuth: podSleuth})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPodSleuth, ok := oldObj.(*infrav1alpha1.PodSleuth)
+			if !ok {
+				return
+			}
+			podSleuth, ok := newObj.(*infrav1alpha1.PodSleuth)
+			if !ok {
+				return
+			}
+			s.live.publish(liveEvent{Name: "podsleuth", PodSleuth: podSleuth})
+			for _, finished := range finishedAnalyses(oldPodSleuth, podSleuth) {
+				s.live.publish(liveEvent{Name: "analysis", Data: finished})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if podSleuth, ok := obj.(*infrav1alpha1.PodSleuth); ok {
+				s.live.publish(liveEvent{Name: "podsleuth", Data: podSleuthEvent{Type: "deleted", Name: podSleuth.Name}})
+			}
+		},
+	})
+	return err
+}
+
+// finishedAnalyses returns the pods whose analysis was written by an update of a PodSleuth
+func finishedAnalyses(old, updated *infrav1alpha1.PodSleuth) []analysisEvent {
+	previous := make(map[string]*infrav1alpha1.NonReadyPodInfo, len(old.Status.NonReadyPods))
+	for i := range old.Status.NonReadyPods {
+		pod := &old.Status.NonReadyPods[i]
+		previous[pod.Namespace+"/"+pod.Name] = pod
+	}
+
+	var finished []analysisEvent
+	for _, pod := range updated.Status.NonReadyPods {
+		if pod.LogAnalysis == nil || pod.AnalysisPending {
+			continue
+		}
+		before := previous[pod.Namespace+"/"+pod.Name]
+		if before != nil && before.LogAnalysis != nil && !before.AnalysisPending &&
+			before.LogAnalysis.AnalyzedAt.Equal(&pod.LogAnalysis.AnalyzedAt) &&
+			before.LogAnalysis.RefreshGeneration == pod.LogAnalysis.RefreshGeneration {
+			continue
+		}
+		finished = append(finished, analysisEvent{
+			PodSleuth:         updated.Name,
+			Namespace:         pod.Namespace,
+			Pod:               pod.Name,
+			RootCause:         pod.LogAnalysis.RootCause,
+			AnalyzedAt:        pod.LogAnalysis.AnalyzedAt,
+			RefreshGeneration: pod.LogAnalysis.RefreshGeneration,
+			team:              pod.Team,
+		})
+	}
+	return finished
+}
+
+// handleEvents streams PodSleuth changes as server-sent events. "podsleuth" events carry
+// the changed PodSleuth or its deletion; "analysis" events announce finished log
+// analyses. ?team= limits both to one team's pods.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.informers == nil {
+		http.Error(w, "Live updates not available", http.StatusServiceUnavailable)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	// Keep reverse proxies such as nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	team := r.URL.Query().Get("team")
+	events := s.live.subscribe()
+	defer s.live.unsubscribe(events)
+	keepAlive := time.NewTicker(liveKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		case event, open := <-events:
+			if !open {
+				// Fell behind; the dashboard reloads when it reconnects
+				return
+			}
+			data, send := liveEventData(event, team)
+			if !send {
+				continue
+			}
+			payload, err := json.Marshal(data)
+			if err != nil {
+				log.Log.WithName("web").Error(err, "unable to encode live update", "event", event.Name)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, payload)
+		}
+		flusher.Flush()
+	}
+}
+
+// liveEventData returns the payload of an event for a stream limited to a team, "" for
+// all, and whether the stream gets the event
+func liveEventData(event liveEvent, team string) (interface{}, bool) {
+	if event.PodSleuth != nil {
+		podSleuth := event.PodSleuth
+		if team != "" {
+			podSleuth = podSleuth.DeepCopy()
+			filterStatusByTeam(&podSleuth.Status, team)
+		}
+		return podSleuthEvent{Type: "updated", Name: hub.PodSleuthKey(podSleuth), PodSleuth: podSleuth}, true
+	}
+	if analysis, ok := event.Data.(analysisEvent); ok && team != "" && analysis.team != team {
+		return nil, false
+	}
+	return event.Data, true
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestLiveEventDataByTeam(t *testing.T) {
+	old := &infrav1alpha1.PodSleuth{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	updated := old.DeepCopy()
+	for _, pod := range []struct{ name, team string }{{"cart-1", "checkout"}, {"ledger-1", "payments"}} {
+		updated.Status.NonReadyPods = append(updated.Status.NonReadyPods, infrav1alpha1.NonReadyPodInfo{
+			Name:        pod.name,
+			Namespace:   "shop",
+			Team:        pod.team,
+			LogAnalysis: &infrav1alpha1.LogAnalysisResult{RootCause: pod.name + " crashed", AnalyzedAt: metav1.Now()},
+		})
+	}
+
+	analyses := finishedAnalyses(old, updated)
+	if len(analyses) != 2 {
+		t.Fatalf("got %d finished analyses, want 2", len(analyses))
+	}
+	for _, team := range []string{"", "checkout", "payments"} {
+		var pods []string
+		for _, analysis := range analyses {
+			if data, send := liveEventData(liveEvent{Name: "analysis", Data: analysis}, team); send {
+				pods = append(pods, data.(analysisEvent).Pod)
+			}
+		}
+		data, _ := liveEventData(liveEvent{Name: "podsleuth", PodSleuth: updated}, team)
+		status := data.(podSleuthEvent).PodSleuth.Status
+
+		want := map[string][]string{"": {"cart-1", "ledger-1"}, "checkout": {"cart-1"}, "payments": {"ledger-1"}}[team]
+		if len(pods) != len(want) || len(status.NonReadyPods) != len(want) {
+			t.Errorf("team %q: analyses of %v and %d pods, want %v", team, pods, len(status.NonReadyPods), want)
+			continue
+		}
+		for i := range want {
+			if pods[i] != want[i] || status.NonReadyPods[i].Name != want[i] {
+				t.Errorf("team %q: analyses of %v, want %v", team, pods, want)
+			}
+		}
+	}
+	if len(updated.Status.NonReadyPods) != 2 {
+		t.Error("filtering a stream changed the published PodSleuth")
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+	"github.com/baturorkun/kubebuilder-demo-operator/pkg/grpcapi"
+)
+
+// EnableGRPC serves the Findings gRPC service of pkg/grpcapi on address, next to the
+// REST API and behind the same authentication
+func (s *Server) EnableGRPC(address string) {
+	s.grpcAddress = address
+}
+
+// serveGRPC serves the gRPC API until ctx is done, over TLS with tlsConfig unless nil,
+// so credentials are not sent in the clear when the dashboard serves HTTPS
+func (s *Server) serveGRPC(ctx context.Context, tlsConfig *tls.Config) error {
+	listener, err := net.Listen("tcp", s.grpcAddress)
+	if err != nil {
+		return err
+	}
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(append(options,
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := s.authenticateRPC(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if info.FullMethod == grpcapi.Findings_TriggerAnalysis_FullMethodName {
+				return s.auditRPC(ctx, info.FullMethod, req, handler)
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if _, err := s.authenticateRPC(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)...)
+	grpcapi.RegisterFindingsServer(server, &findingsService{server: s, shutdown: ctx.Done()})
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	log.Log.WithName("web").Info("Starting gRPC server", "address", s.grpcAddress, "tls", tlsConfig != nil)
+	return server.Serve(listener)
+}
+
+// authenticateRPC checks the authorization metadata of a gRPC call like the
+// Authorization header of an API request: a bearer token or basic credentials
+func (s *Server) authenticateRPC(ctx context.Context) (context.Context, error) {
+	if !s.auth.Enabled() {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := s.authenticateRequest(&http.Request{Header: http.Header{"Authorization": md.Get("authorization")}})
+	if id == nil {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	return context.WithValue(ctx, identityKey{}, id), nil
+}
+
+// auditRPC rate limits and logs a call changing state, like auditChanges API requests
+func (s *Server) auditRPC(ctx context.Context, method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	id, _ := ctx.Value(identityKey{}).(*identity)
+	remoteAddr := ""
+	if p, found := peer.FromContext(ctx); found {
+		remoteAddr = p.Addr.String()
+	}
+
+	var response interface{}
+	var err error
+	if allowed, retryAfter := s.changeLimiters.allow(clientOf(id, remoteAddr)); allowed {
+		response, err = handler(ctx, req)
+	} else {
+		err = status.Errorf(codes.ResourceExhausted, "too many changes, retry in %s", retryAfter.Round(time.Second))
+	}
+	auditChange(id, remoteAddr, method, status.Code(err).String(), time.Since(start))
+	return response, err
+}
+
+// findingsService implements the Findings gRPC service with the data of the REST API
+type findingsService struct {
+	grpcapi.UnimplementedFindingsServer
+	server *Server
+	// shutdown is closed when the server stops, ending watches
+	shutdown <-chan struct{}
+}
+
+// ListFindings lists non-ready pods like GET /api/pods
+func (f *findingsService) ListFindings(ctx context.Context, req *grpcapi.ListFindingsRequest) (*grpcapi.ListFindingsResponse, error) {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"namespace": req.Namespace, "phase": req.Phase, "reason": req.Reason, "owner": req.Owner, "team": req.Team,
+		"podSleuth": req.PodSleuth, "severity": req.Severity, "q": req.Query, "sort": req.Sort,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if req.Limit != 0 {
+		query.Set("limit", strconv.Itoa(int(req.Limit)))
+	}
+	if req.Offset != 0 {
+		query.Set("offset", strconv.Itoa(int(req.Offset)))
+	}
+
+	list, err := f.server.listPods(ctx, query)
+	if errors.Is(err, errInvalidPodListQuery) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing PodSleuths: %v", err)
+	}
+
+	response := &grpcapi.ListFindingsResponse{Total: int32(list.Total)}
+	for i := range list.Items {
+		response.Findings = append(response.Findings, newFinding(list.Items[i].PodSleuth, &list.Items[i].NonReadyPodInfo))
+	}
+	if list.Next != nil {
+		response.NextOffset = int32(*list.Next)
+	}
+	return response, nil
+}
+
+// GetPod returns a non-ready pod in full like GET /api/pods/{namespace}/{name}
+func (f *findingsService) GetPod(ctx context.Context, req *grpcapi.GetPodRequest) (*grpcapi.Finding, error) {
+	if req.Namespace == "" || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace and name are required")
+	}
+	detail, err := f.server.findReportedPod(ctx, req.Namespace, req.Name, req.PodSleuth)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing PodSleuths: %v", err)
+	}
+	if detail == nil {
+		return nil, status.Errorf(codes.NotFound, "pod %s/%s is not reported as non-ready", req.Namespace, req.Name)
+	}
+	f.server.completePodDetail(ctx, detail)
+	return newFinding(detail.PodSleuth, &detail.Pod), nil
+}
+
+// TriggerAnalysis analyzes a pod, or all non-ready pods, again like POST /api/force-refresh
+func (f *findingsService) TriggerAnalysis(ctx context.Context, req *grpcapi.TriggerAnalysisRequest) (*grpcapi.TriggerAnalysisResponse, error) {
+	var pods []string
+	if req.Namespace != "" || req.Name != "" {
+		if req.Namespace == "" || req.Name == "" {
+			return nil, status.Error(codes.InvalidArgument, "namespace and name must be set together")
+		}
+		pods = append(pods, req.Namespace+"/"+req.Name)
+	}
+	response, err := f.server.forceRefresh(ctx, "", pods)
+	if errors.Is(err, errForceRefreshTargetNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing PodSleuths: %v", err)
+	}
+	return &grpcapi.TriggerAnalysisResponse{PodSleuths: int32(response.Count)}, nil
+}
+
+// WatchFindings sends the current findings, then their changes. PodSleuth changes come
+// from the live updates of the dashboard, or are polled for when they are unavailable.
+func (f *findingsService) WatchFindings(req *grpcapi.WatchFindingsRequest, stream grpcapi.Findings_WatchFindingsServer) error {
+	ctx := stream.Context()
+	watch := &findingsWatch{stream: stream, team: req.Team, findings: map[string]map[string]*grpcapi.Finding{}}
+
+	var events chan liveEvent
+	var poll <-chan time.Time
+	if f.server.informers != nil {
+		// Subscribed before listing, so no change is missed in between
+		events = f.server.live.subscribe()
+		defer f.server.live.unsubscribe(events)
+	} else {
+		interval := f.server.refreshInterval
+		if interval <= 0 {
+			interval = DefaultRefreshInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	if err := watch.syncAll(ctx, f.server); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-f.shutdown:
+			return nil
+		case <-poll:
+			if err := watch.syncAll(ctx, f.server); err != nil {
+				return err
+			}
+		case event, open := <-events:
+			if !open {
+				return status.Error(codes.Unavailable, "watch fell behind, start it again")
+			}
+			var err error
+			switch {
+			case event.PodSleuth != nil:
+				err = watch.sync(event.PodSleuth.Name, event.PodSleuth.Status.NonReadyPods)
+			case event.Name == "podsleuth":
+				if deleted, ok := event.Data.(podSleuthEvent); ok {
+					err = watch.sync(deleted.Name, nil)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// findingsWatch tracks the findings a watch has sent
+type findingsWatch struct {
+	stream grpcapi.Findings_WatchFindingsServer
+	team   string
+	// findings are the findings sent by PodSleuth and namespace/name
+	findings map[string]map[string]*grpcapi.Finding
+}
+
+// syncAll lists all PodSleuths and sends the changes of their findings
+func (w *findingsWatch) syncAll(ctx context.Context, s *Server) error {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := s.client.List(ctx, &podSleuthList); err != nil {
+		return status.Errorf(codes.Internal, "listing PodSleuths: %v", err)
+	}
+	listed := make(map[string]bool, len(podSleuthList.Items))
+	for _, podSleuth := range podSleuthList.Items {
+		listed[podSleuth.Name] = true
+		if err := w.sync(podSleuth.Name, podSleuth.Status.NonReadyPods); err != nil {
+			return err
+		}
+	}
+	for name := range w.findings {
+		if !listed[name] {
+			if err := w.sync(name, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sync sends the findings of a PodSleuth that were added, updated or resolved since the
+// last sync
+func (w *findingsWatch) sync(podSleuth string, pods []infrav1alpha1.NonReadyPodInfo) error {
+	previous := w.findings[podSleuth]
+	current := make(map[string]*grpcapi.Finding, len(pods))
+	for i := range pods {
+		if w.team != "" && pods[i].Team != w.team {
+			continue
+		}
+		key := pods[i].Namespace + "/" + pods[i].Name
+		finding := newFinding(podSleuth, &pods[i])
+		current[key] = finding
+
+		sent, known := previous[key]
+		eventType := grpcapi.FindingEvent_ADDED
+		if known {
+			if proto.Equal(sent, finding) {
+				continue
+			}
+			eventType = grpcapi.FindingEvent_UPDATED
+		}
+		if err := w.stream.Send(&grpcapi.FindingEvent{Type: eventType, Finding: finding}); err != nil {
+			return err
+		}
+	}
+	for key, finding := range previous {
+		if _, found := current[key]; !found {
+			if err := w.stream.Send(&grpcapi.FindingEvent{Type: grpcapi.FindingEvent_RESOLVED, Finding: finding}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(current) == 0 {
+		delete(w.findings, podSleuth)
+	} else {
+		w.findings[podSleuth] = current
+	}
+	return nil
+}
+
+// newFinding converts a non-ready pod reported by a PodSleuth to a gRPC finding
+func newFinding(podSleuth string, pod *infrav1alpha1.NonReadyPodInfo) *grpcapi.Finding {
+	finding := &grpcapi.Finding{
+		PodSleuth:       podSleuth,
+		Namespace:       pod.Namespace,
+		Name:            pod.Name,
+		Severity:        controller.PodSeverity(pod),
+		Phase:           pod.Phase,
+		Reason:          pod.Reason,
+		Message:         pod.Message,
+		OwnerKind:       pod.OwnerKind,
+		OwnerName:       pod.OwnerName,
+		NodeName:        pod.NodeName,
+		Team:            pod.Team,
+		AnalysisPending: pod.AnalysisPending,
+		Suppressed:      pod.Suppressed,
+		Silenced:        pod.Silenced,
+		Acknowledged:    pod.Acknowledged != nil,
+	}
+	if pod.CreatedAt != nil {
+		finding.CreatedAt = timestamppb.New(pod.CreatedAt.Time)
+	}
+	if pod.DetectedAt != nil {
+		finding.DetectedAt = timestamppb.New(pod.DetectedAt.Time)
+	}
+	for _, containerError := range pod.ContainerErrors {
+		container := &grpcapi.ContainerFinding{
+			Name:         containerError.ContainerName,
+			Type:         containerError.Type,
+			State:        containerError.State,
+			Reason:       containerError.Reason,
+			Message:      containerError.Message,
+			RestartCount: containerError.RestartCount,
+		}
+		if containerError.ExitCode != nil {
+			container.ExitCode = *containerError.ExitCode
+		}
+		finding.Containers = append(finding.Containers, container)
+	}
+	if pod.LogAnalysis != nil {
+		finding.RootCause = pod.LogAnalysis.RootCause
+		if !pod.LogAnalysis.AnalyzedAt.IsZero() {
+			finding.AnalyzedAt = timestamppb.New(pod.LogAnalysis.AnalyzedAt.Time)
+		}
+	}
+	details, err := json.Marshal(pod)
+	if err != nil {
+		log.Log.WithName("web").Error(err, "unable to encode pod details", "pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	}
+	finding.Details = details
+	return finding
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// readinessCheckTimeout bounds each check of /readyz
+const readinessCheckTimeout = 3 * time.Second
+
+// EnableHealthChecks makes /readyz check that the informer cache the dashboard reads
+// from has synced and that the API server is reachable through apiServer. While the API
+// server is unreachable the dashboard is unready, but keeps serving cached data.
+func (s *Server) EnableHealthChecks(informers cache.Informers, apiServer rest.Interface) {
+	s.cacheSync = informers
+	s.apiServer = apiServer
+}
+
+// NeedLeaderElection lets every replica serve the dashboard, not just the leader. The
+// manager starts it once the informer cache has synced and stops it before the cache.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// handleHealthz reports that the dashboard server is serving, for liveness probes
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, "ok")
+}
+
+// handleReadyz reports whether the dashboard can serve current data, for readiness
+// probes. Failing checks are listed, with ?verbose passing ones too; their errors are
+// logged rather than shown to unauthenticated callers.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	checks := []struct {
+		name  string
+		check func(context.Context) error
+	}{
+		{"informers", s.checkCacheSync},
+		{"apiserver", s.checkAPIServer},
+	}
+
+	var report strings.Builder
+	ready := true
+	for _, c := range checks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		err := c.check(ctx)
+		cancel()
+		if err != nil {
+			ready = false
+			log.Log.WithName("web").V(1).Info("readiness check failed", "check", c.name, "error", err.Error())
+			fmt.Fprintf(&report, "[-]%s failed\n", c.name)
+		} else if r.URL.Query().Has("verbose") {
+			fmt.Fprintf(&report, "[+]%s ok\n", c.name)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, report.String()+"readyz check failed")
+		return
+	}
+	fmt.Fprint(w, report.String()+"ok")
+}
+
+// checkCacheSync fails until the informer cache has synced
+func (s *Server) checkCacheSync(ctx context.Context) error {
+	if s.cacheSync == nil {
+		return nil
+	}
+	if !s.cacheSync.WaitForCacheSync(ctx) {
+		return errors.New("informer cache not synced")
+	}
+	return nil
+}
+
+// checkAPIServer fails while the API server is unreachable or not ready
+func (s *Server) checkAPIServer(ctx context.Context) error {
+	if s.apiServer == nil {
+		return nil
+	}
+	return s.apiServer.Get().AbsPath("/readyz").Do(ctx).Error()
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
+)
+
+const (
+	// DefaultHistoryInterval is the default interval between samples of the non-ready pod counts
+	DefaultHistoryInterval = time.Minute
+	// DefaultHistoryRetention is the default time samples are kept
+	DefaultHistoryRetention = 24 * time.Hour
+
+	// historySaveInterval is how often the history is written to its ConfigMap
+	historySaveInterval = 5 * time.Minute
+	// historyConfigMapKey holds the gzipped JSON samples in the ConfigMap
+	historyConfigMapKey = "samples.json.gz"
+	// incidentsConfigMapKey holds the gzipped JSON incidents in the ConfigMap
+	incidentsConfigMapKey = "incidents.json.gz"
+	// maxIncidents bounds the incidents kept; the oldest resolved ones are dropped first
+	maxIncidents = 500
+	// maxHistoryConfigMapBytes keeps the ConfigMap below the 1MiB object size limit
+	maxHistoryConfigMapBytes = 900 * 1024
+)
+
+// historySample is a sample of the non-ready pod counts
+type historySample struct {
+	Time time.Time `json:"time"`
+	// Total is the number of non-ready pods that are neither suppressed, silenced nor
+	// acknowledged
+	Total        int            `json:"total"`
+	Suppressed   int            `json:"suppressed,omitempty"`
+	Silenced     int            `json:"silenced,omitempty"`
+	Acknowledged int            `json:"acknowledged,omitempty"`
+	BySeverity   map[string]int `json:"bySeverity,omitempty"`
+	ByNamespace  map[string]int `json:"byNamespace,omitempty"`
+}
+
+// incident is a period in which pods of a workload, or a pod without owner, were not
+// ready
+type incident struct {
+	// Cluster is the cluster of the workload in hub mode, empty for the hub's own
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	// Workload is the owner of the pods as kind/name, or Pod/name for a pod without owner
+	Workload string `json:"workload"`
+	// Reason is why the first pod of the incident was not ready
+	Reason string `json:"reason,omitempty"`
+	// Pods is the largest number of pods that were not ready at once
+	Pods  int       `json:"pods"`
+	Start time.Time `json:"start"`
+	// End is when the last pod was ready again, nil while the incident is ongoing
+	End *time.Time `json:"end,omitempty"`
+}
+
+// history is a ring buffer of the samples of the retention period, with the incidents
+// seen while sampling
+type history struct {
+	mu sync.Mutex
+	// samples holds up to retention/interval samples; the oldest is at start
+	samples []historySample
+	start   int
+	count   int
+	// incidents are ordered by start
+	incidents []incident
+}
+
+// historySettings configures how the history is sampled and kept
+type historySettings struct {
+	interval  time.Duration
+	retention time.Duration
+	// k8sClient, namespace and configMap persist the history (nil = memory only)
+	k8sClient kubernetes.Interface
+	namespace string
+	configMap string
+}
+
+// ConfigureHistory sets how often the non-ready pod counts are sampled for /api/history
+// and the stats trends, and how long samples are kept
+func (s *Server) ConfigureHistory(interval, retention time.Duration) {
+	s.historySettings.interval = interval
+	s.historySettings.retention = retention
+}
+
+// PersistHistory keeps the history in a ConfigMap, so it survives restarts. Replicas may
+// share the ConfigMap; a write losing a race with another replica is skipped.
+func (s *Server) PersistHistory(k8sClient kubernetes.Interface, namespace, name string) {
+	s.historySettings.k8sClient = k8sClient
+	s.historySettings.namespace = namespace
+	s.historySettings.configMap = name
+}
+
+// historyConfig returns the history settings with defaults applied
+func (s *Server) historyConfig() historySettings {
+	settings := s.historySettings
+	if settings.interval <= 0 {
+		settings.interval = DefaultHistoryInterval
+	}
+	if settings.retention <= 0 {
+		settings.retention = DefaultHistoryRetention
+	}
+	return settings
+}
+
+// add records a sample, overwriting the oldest once capacity samples are held
+func (h *history) add(sample historySample, capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) != capacity {
+		// First use, or the capacity changed
+		samples := h.samplesLocked()
+		h.samples = make([]historySample, capacity)
+		h.start, h.count = 0, 0
+		for _, existing := range samples[max(0, len(samples)-capacity):] {
+			h.appendLocked(existing)
+		}
+	}
+	h.appendLocked(sample)
+}
+
+// appendLocked adds a sample after the newest, overwriting the oldest when full
+func (h *history) appendLocked(sample historySample) {
+	if h.count < len(h.samples) {
+		h.samples[(h.start+h.count)%len(h.samples)] = sample
+		h.count++
+		return
+	}
+	h.samples[h.start] = sample
+	h.start = (h.start + 1) % len(h.samples)
+}
+
+// since returns the samples taken at or after a time, oldest first
+func (h *history) since(t time.Time) []historySample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := []historySample{}
+	for _, sample := range h.samplesLocked() {
+		if !sample.Time.Before(t) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// samplesLocked returns all samples, oldest first
+func (h *history) samplesLocked() []historySample {
+	samples := make([]historySample, 0, h.count)
+	for i := 0; i < h.count; i++ {
+		samples = append(samples, h.samples[(h.start+i)%len(h.samples)])
+	}
+	return samples
+}
+
+// trackIncidents opens incidents for workloads with pods that became non-ready, and
+// resolves those whose pods are all ready again. Muted pods are left out.
+func (h *history) trackIncidents(podSleuths []infrav1alpha1.PodSleuth, now time.Time, retention time.Duration) {
+	current := map[string]*incident{}
+	for i := range podSleuths {
+		cluster := podSleuths[i].Annotations[hub.ClusterAnnotation]
+		for _, pod := range podSleuths[i].Status.NonReadyPods {
+			if controller.IsMuted(&pod) {
+				continue
+			}
+			workload := "Pod/" + pod.Name
+			if pod.OwnerKind != "" {
+				workload = pod.OwnerKind + "/" + pod.OwnerName
+			}
+			key := cluster + "|" + pod.Namespace + "/" + workload
+			start := now
+			if pod.DetectedAt != nil {
+				start = pod.DetectedAt.Time
+			}
+			if seen, exists := current[key]; exists {
+				seen.Pods++
+				if start.Before(seen.Start) {
+					seen.Start, seen.Reason = start, pod.Reason
+				}
+				continue
+			}
+			current[key] = &incident{Cluster: cluster, Namespace: pod.Namespace, Workload: workload, Reason: pod.Reason, Pods: 1, Start: start}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := range h.incidents {
+		open := &h.incidents[i]
+		if open.End != nil {
+			continue
+		}
+		key := open.Cluster + "|" + open.Namespace + "/" + open.Workload
+		if seen, exists := current[key]; exists {
+			open.Pods = max(open.Pods, seen.Pods)
+			delete(current, key)
+			continue
+		}
+		end := now
+		open.End = &end
+	}
+	for _, opened := range current {
+		h.incidents = append(h.incidents, *opened)
+	}
+	slices.SortStableFunc(h.incidents, func(a, b incident) int { return a.Start.Compare(b.Start) })
+
+	// Forget incidents resolved before the retention period, then the oldest resolved
+	cutoff := now.Add(-retention)
+	h.incidents = slices.DeleteFunc(h.incidents, func(i incident) bool { return i.End != nil && i.End.Before(cutoff) })
+	for excess := len(h.incidents) - maxIncidents; excess > 0; excess-- {
+		resolved := slices.IndexFunc(h.incidents, func(i incident) bool { return i.End != nil })
+		if resolved < 0 {
+			break
+		}
+		h.incidents = slices.Delete(h.incidents, resolved, resolved+1)
+	}
+}
+
+// incidentsSince returns the incidents ongoing at or after a time, ordered by start
+func (h *history) incidentsSince(t time.Time) []incident {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	incidents := []incident{}
+	for _, i := range h.incidents {
+		if i.End == nil || !i.End.Before(t) {
+			incidents = append(incidents, i)
+		}
+	}
+	return incidents
+}
+
+// trend returns the change of the total from the start of a window until now, false if
+// there are no samples in the window
+func (h *history) trend(window time.Duration, now time.Time, total int) (statsTrend, bool) {
+	trend := statsTrend{Window: fmt.Sprintf("%dh", int(window.Hours())), Peak: total}
+	samples := h.since(now.Add(-window))
+	if len(samples) == 0 {
+		return trend, false
+	}
+	trend.From, trend.Since = samples[0].Total, samples[0].Time
+	for _, sample := range samples {
+		trend.Peak = max(trend.Peak, sample.Total)
+	}
+	trend.Change = total - trend.From
+	return trend, true
+}
+
+// newHistorySample samples the counts of the non-ready pods of PodSleuths
+func newHistorySample(podSleuths []infrav1alpha1.PodSleuth, now time.Time) historySample {
+	stats := computeStats(podSleuths, "")
+	return historySample{
+		Time:         now,
+		Total:        stats.Total,
+		Suppressed:   stats.Suppressed,
+		Silenced:     stats.Silenced,
+		Acknowledged: stats.Acknowledged,
+		BySeverity:   stats.BySeverity,
+		ByNamespace:  stats.ByNamespace,
+	}
+}
+
+// recordHistory samples the non-ready pod counts until ctx is done, loading and saving
+// the history from and to its ConfigMap if it is persisted
+func (s *Server) recordHistory(ctx context.Context) {
+	logger := log.Log.WithName("web")
+	settings := s.historyConfig()
+	capacity := int(settings.retention/settings.interval) + 1
+
+	if settings.k8sClient != nil {
+		samples, incidents, err := s.loadHistory(ctx, settings)
+		if err != nil {
+			logger.Error(err, "unable to load history", "configMap", settings.namespace+"/"+settings.configMap)
+		}
+		cutoff := time.Now().Add(-settings.retention)
+		for _, sample := range samples {
+			if !sample.Time.Before(cutoff) {
+				s.history.add(sample, capacity)
+			}
+		}
+		s.history.mu.Lock()
+		s.history.incidents = incidents
+		s.history.mu.Unlock()
+	}
+
+	ticker := time.NewTicker(settings.interval)
+	defer ticker.Stop()
+	lastSaved := time.Now()
+	for {
+		podSleuthList, _, err := s.listPodSleuths(ctx)
+		if err != nil {
+			logger.V(1).Info("unable to sample history", "error", err)
+		} else {
+			now := time.Now()
+			s.history.add(newHistorySample(podSleuthList.Items, now), capacity)
+			s.history.trackIncidents(podSleuthList.Items, now, settings.retention)
+		}
+
+		if settings.k8sClient != nil && time.Since(lastSaved) >= historySaveInterval {
+			if err := s.saveHistory(ctx, settings); err != nil {
+				logger.Info("unable to save history", "configMap", settings.namespace+"/"+settings.configMap, "error", err)
+			}
+			lastSaved = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			if settings.k8sClient != nil {
+				saveCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := s.saveHistory(saveCtx, settings); err != nil {
+					logger.Info("unable to save history", "configMap", settings.namespace+"/"+settings.configMap, "error", err)
+				}
+				cancel()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadHistory reads the samples and incidents of the history ConfigMap
+func (s *Server) loadHistory(ctx context.Context, settings historySettings) ([]historySample, []incident, error) {
+	configMap, err := settings.k8sClient.CoreV1().ConfigMaps(settings.namespace).Get(ctx, settings.configMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var samples []historySample
+	var incidents []incident
+	if err := gunzipJSON(configMap.BinaryData[historyConfigMapKey], &samples); err != nil {
+		return nil, nil, err
+	}
+	if err := gunzipJSON(configMap.BinaryData[incidentsConfigMapKey], &incidents); err != nil {
+		return samples, nil, err
+	}
+	return samples, incidents, nil
+}
+
+// gunzipJSON decodes gzipped JSON, leaving v unchanged if data is empty
+func gunzipJSON(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decompressed, v)
+}
+
+// gzipJSON encodes v as gzipped JSON
+func gzipJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(v); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// saveHistory writes the samples and incidents to the history ConfigMap, dropping the
+// oldest samples if they do not fit
+func (s *Server) saveHistory(ctx context.Context, settings historySettings) error {
+	incidentData, err := gzipJSON(s.history.incidentsSince(time.Time{}))
+	if err != nil {
+		return err
+	}
+	samples := s.history.since(time.Time{})
+	var sampleData []byte
+	for {
+		if sampleData, err = gzipJSON(samples); err != nil {
+			return err
+		}
+		if len(sampleData)+len(incidentData) <= maxHistoryConfigMapBytes || len(samples) <= 1 {
+			break
+		}
+		samples = samples[len(samples)/2:]
+	}
+
+	configMaps := settings.k8sClient.CoreV1().ConfigMaps(settings.namespace)
+	configMap, err := configMaps.Get(ctx, settings.configMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      settings.configMap,
+				Namespace: settings.namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "kubesleuth"},
+			},
+			BinaryData: map[string][]byte{historyConfigMapKey: sampleData, incidentsConfigMapKey: incidentData},
+		}
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.BinaryData == nil {
+		configMap.BinaryData = map[string][]byte{}
+	}
+	configMap.BinaryData[historyConfigMapKey] = sampleData
+	configMap.BinaryData[incidentsConfigMapKey] = incidentData
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		// Another replica saved the same counts
+		return nil
+	}
+	return err
+}
+
+// historyResponse is the response of GET /api/history
+type historyResponse struct {
+	Interval  string          `json:"interval"`
+	Retention string          `json:"retention"`
+	Range     string          `json:"range"`
+	Samples   []historySample `json:"samples"`
+	Incidents []incident      `json:"incidents"`
+}
+
+// handleHistory returns the sampled non-ready pod counts of a time range, oldest first,
+// and the incidents ongoing in it: /api/history?range=24h (default 24h, at most the
+// retention)
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	settings := s.historyConfig()
+	timeRange := DefaultHistoryRetention
+	if value := r.URL.Query().Get("range"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "range must be a positive duration such as 1h or 24h", http.StatusBadRequest)
+			return
+		}
+		timeRange = parsed
+	}
+	timeRange = min(timeRange, settings.retention)
+
+	writeJSON(w, r, historyResponse{
+		Interval:  settings.interval.String(),
+		Retention: settings.retention.String(),
+		Range:     timeRange.String(),
+		Samples:   s.history.since(time.Now().Add(-timeRange)),
+		Incidents: s.history.incidentsSince(time.Now().Add(-timeRange)),
+	})
+}
@@ -0,0 +1,491 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+const (
+	// defaultDeployHookTimeout is how long a deploy hook waits for the rollout by default
+	defaultDeployHookTimeout = 5 * time.Minute
+	// maxDeployHookTimeout bounds the timeout a pipeline may ask for
+	maxDeployHookTimeout = 15 * time.Minute
+	// deployHookPollInterval is how often a deploy hook checks the rollout
+	deployHookPollInterval = 2 * time.Second
+	// maxDeployHookAnalyses bounds the failing pods analyzed for a verdict
+	maxDeployHookAnalyses = 5
+	// deploymentRevisionAnnotation is the revision of a Deployment and its ReplicaSets
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// Verdicts of deploy hooks
+const (
+	deployPassed = "passed"
+	deployFailed = "failed"
+)
+
+// failingWaitReasons are container waiting reasons that fail a rollout right away,
+// rather than at the timeout
+var failingWaitReasons = []string{"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError"}
+
+// DeployNotifier sends the verdicts of deploys to the notification sinks of a PodSleuth
+type DeployNotifier interface {
+	NotifyDeployVerdict(ctx context.Context, podSleuth string, podLabels map[string]string,
+		verdict controller.DeployVerdict, failing []infrav1alpha1.NonReadyPodInfo) error
+}
+
+// EnableDeployHooks serves POST /api/hooks/deploy for pipelines sending token as
+// bearer token, or authenticated like the dashboard. Workloads are read uncached with
+// reader, verdicts recorded as Events on them with recorder and sent to notification
+// sinks with notifier.
+func (s *Server) EnableDeployHooks(token string, reader client.Reader, recorder record.EventRecorder, notifier DeployNotifier) {
+	s.deployHookToken = token
+	s.workloadReader = reader
+	s.deployRecorder = recorder
+	s.deployNotifier = notifier
+}
+
+// deployHookRequest is the body of POST /api/hooks/deploy
+type deployHookRequest struct {
+	Namespace string `json:"namespace"`
+	// Kind is Deployment (default), StatefulSet or DaemonSet
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name"`
+	// Timeout is how long to wait for the rollout, as a Go duration (default 5m, at most 15m)
+	Timeout string `json:"timeout,omitempty"`
+	// PodSleuth is the PodSleuth whose log analysis configuration analyzes failing pods
+	// and whose notification sinks get the verdict
+	PodSleuth string `json:"podSleuth,omitempty"`
+	// Revision identifies the deploy in the recorded Event, such as a commit or pipeline run
+	Revision string `json:"revision,omitempty"`
+}
+
+// deployHookResponse is the response of POST /api/hooks/deploy
+type deployHookResponse struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Revision  string `json:"revision,omitempty"`
+	// Verdict is passed when the rollout completed with every pod ready, failed otherwise
+	Verdict string `json:"verdict"`
+	// Reason explains a failed verdict
+	Reason   string `json:"reason,omitempty"`
+	Duration string `json:"duration"`
+	// Pods are the pods of the revision being rolled out
+	Pods []deployHookPod `json:"pods"`
+}
+
+// deployHookPod is a pod of a verified workload
+type deployHookPod struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	// Reason is why the pod is not ready
+	Reason string `json:"reason,omitempty"`
+	// Analysis is the log analysis of a pod that is not ready, when on-demand analysis is
+	// enabled
+	Analysis *infrav1alpha1.LogAnalysisResult `json:"analysis,omitempty"`
+}
+
+// rollout is the state of the rollout of a workload
+type rollout struct {
+	complete bool
+	selector *metav1.LabelSelector
+	// revisionLabel and revision select the pods of the revision being rolled out, so
+	// pods of the previous revision neither fail nor pass the deploy. revision is empty
+	// until the workload's controller created the new revision.
+	revisionLabel string
+	revision      string
+	// podLabels are the labels of the workload's pod template
+	podLabels map[string]string
+	object    client.Object
+}
+
+// handleDeployHook verifies a deploy: POST /api/hooks/deploy waits until the workload
+// rolled out or fails, analyzes its failing pods and answers with a verdict, which
+// pipelines use as a deployment gate. Pods crash looping or failing to pull their image
+// fail the deploy before the timeout.
+func (s *Server) handleDeployHook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.deployHookToken == "" {
+		http.Error(w, "Deploy hooks are not enabled", http.StatusNotFound)
+		return
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if requestIdentity(r) == nil && !tokenEqual(token, s.deployHookToken) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var reqBody deployHookRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if reqBody.Kind == "" {
+		reqBody.Kind = "Deployment"
+	}
+	if reqBody.Namespace == "" || reqBody.Name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	if !slices.Contains([]string{"Deployment", "StatefulSet", "DaemonSet"}, reqBody.Kind) {
+		http.Error(w, fmt.Sprintf("Unsupported kind %q, expected Deployment, StatefulSet or DaemonSet", reqBody.Kind), http.StatusBadRequest)
+		return
+	}
+	timeout := defaultDeployHookTimeout
+	if reqBody.Timeout != "" {
+		parsed, err := time.ParseDuration(reqBody.Timeout)
+		if err != nil || parsed <= 0 || parsed > maxDeployHookTimeout {
+			http.Error(w, fmt.Sprintf("Invalid timeout %q, expected up to %s", reqBody.Timeout, maxDeployHookTimeout), http.StatusBadRequest)
+			return
+		}
+		timeout = parsed
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	response := deployHookResponse{Namespace: reqBody.Namespace, Kind: reqBody.Kind, Name: reqBody.Name, Revision: reqBody.Revision, Pods: []deployHookPod{}}
+	log.Log.WithName("web").Info("verifying deploy", "namespace", reqBody.Namespace, "kind", reqBody.Kind, "name", reqBody.Name, "revision", reqBody.Revision, "timeout", timeout)
+
+	var state *rollout
+	var pods []corev1.Pod
+	for {
+		var err error
+		state, err = s.rolloutOf(ctx, reqBody.Kind, reqBody.Namespace, reqBody.Name)
+		if err == nil {
+			pods, err = s.workloadPods(ctx, reqBody.Namespace, state)
+		}
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("%s %s/%s not found", reqBody.Kind, reqBody.Namespace, reqBody.Name), http.StatusNotFound)
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			http.Error(w, fmt.Sprintf("Error reading %s: %v", reqBody.Kind, err), http.StatusInternalServerError)
+			return
+		}
+		if err == nil && state.complete && allReady(pods) {
+			response.Verdict = deployPassed
+			break
+		}
+		if failing := failingPod(pods); err == nil && failing != "" {
+			response.Verdict, response.Reason = deployFailed, failing
+			break
+		}
+		if ctx.Err() != nil || time.Until(start.Add(timeout)) < deployHookPollInterval {
+			response.Verdict, response.Reason = deployFailed, fmt.Sprintf("rollout did not complete within %s", timeout)
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(deployHookPollInterval):
+		}
+	}
+	if r.Context().Err() != nil {
+		// The pipeline went away
+		return
+	}
+
+	// Failing pods are analyzed with a fresh context, as the rollout may have used it up
+	analyzed := 0
+	var failing []infrav1alpha1.NonReadyPodInfo
+	for _, pod := range pods {
+		entry := deployHookPod{Name: pod.Name, Ready: isReady(&pod)}
+		if !entry.Ready {
+			entry.Reason = podProblem(&pod)
+			if s.analyzer != nil && response.Verdict == deployFailed && analyzed < maxDeployHookAnalyses {
+				analyzed++
+				if analysis, err := s.analyzer.AnalyzePod(context.WithoutCancel(r.Context()), pod.Namespace, pod.Name, reqBody.PodSleuth); err == nil {
+					entry.Analysis = analysis.Result
+				} else {
+					log.Log.WithName("web").Info("could not analyze pod of deploy", "namespace", pod.Namespace, "pod", pod.Name, "error", err.Error())
+				}
+			}
+			failing = append(failing, infrav1alpha1.NonReadyPodInfo{
+				Name:        pod.Name,
+				Namespace:   pod.Namespace,
+				Phase:       string(pod.Status.Phase),
+				OwnerKind:   reqBody.Kind,
+				OwnerName:   reqBody.Name,
+				NodeName:    pod.Spec.NodeName,
+				Reason:      entry.Reason,
+				LogAnalysis: entry.Analysis,
+			})
+		}
+		response.Pods = append(response.Pods, entry)
+	}
+	response.Duration = time.Since(start).Round(time.Second).String()
+	s.recordDeployVerdict(state, &response)
+	s.notifyDeployVerdict(context.WithoutCancel(r.Context()), reqBody.PodSleuth, state, &response, failing)
+	log.Log.WithName("web").Info("deploy verified", "namespace", reqBody.Namespace, "kind", reqBody.Kind, "name", reqBody.Name, "revision", reqBody.Revision, "verdict", response.Verdict, "reason", response.Reason)
+
+	writeJSON(w, r, response)
+}
+
+// rolloutOf reads the rollout state of a workload
+func (s *Server) rolloutOf(ctx context.Context, kind, namespace, name string) (*rollout, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	switch kind {
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := s.workloadReader.Get(ctx, key, &statefulSet); err != nil {
+			return nil, err
+		}
+		status, replicas := statefulSet.Status, replicasOf(statefulSet.Spec.Replicas)
+		state := &rollout{
+			complete: status.ObservedGeneration >= statefulSet.Generation && status.UpdatedReplicas == replicas && status.ReadyReplicas == replicas &&
+				(status.UpdateRevision == "" || status.CurrentRevision == status.UpdateRevision),
+			selector:      statefulSet.Spec.Selector,
+			revisionLabel: appsv1.ControllerRevisionHashLabelKey,
+			podLabels:     statefulSet.Spec.Template.Labels,
+			object:        &statefulSet,
+		}
+		// Pods of a StatefulSet are labeled with the name of their revision
+		if status.ObservedGeneration >= statefulSet.Generation {
+			state.revision = status.UpdateRevision
+		}
+		return state, nil
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := s.workloadReader.Get(ctx, key, &daemonSet); err != nil {
+			return nil, err
+		}
+		status := daemonSet.Status
+		state := &rollout{
+			complete: status.ObservedGeneration >= daemonSet.Generation && status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+				status.NumberAvailable == status.DesiredNumberScheduled,
+			selector:      daemonSet.Spec.Selector,
+			revisionLabel: appsv1.DefaultDaemonSetUniqueLabelKey,
+			podLabels:     daemonSet.Spec.Template.Labels,
+			object:        &daemonSet,
+		}
+		if status.ObservedGeneration >= daemonSet.Generation {
+			revision, err := s.daemonSetRevision(ctx, &daemonSet)
+			if err != nil {
+				return nil, err
+			}
+			state.revision = revision
+		}
+		return state, nil
+	default:
+		var deployment appsv1.Deployment
+		if err := s.workloadReader.Get(ctx, key, &deployment); err != nil {
+			return nil, err
+		}
+		status, replicas := deployment.Status, replicasOf(deployment.Spec.Replicas)
+		state := &rollout{
+			// Old pods must be gone too
+			complete: status.ObservedGeneration >= deployment.Generation && status.UpdatedReplicas == replicas &&
+				status.Replicas == replicas && status.AvailableReplicas == replicas,
+			selector:      deployment.Spec.Selector,
+			revisionLabel: appsv1.DefaultDeploymentUniqueLabelKey,
+			podLabels:     deployment.Spec.Template.Labels,
+			object:        &deployment,
+		}
+		if status.ObservedGeneration >= deployment.Generation {
+			revision, err := s.deploymentRevision(ctx, &deployment)
+			if err != nil {
+				return nil, err
+			}
+			state.revision = revision
+		}
+		return state, nil
+	}
+}
+
+// deploymentRevision returns the pod-template-hash of the ReplicaSet of a Deployment's
+// current revision, empty if it does not exist yet
+func (s *Server) deploymentRevision(ctx context.Context, deployment *appsv1.Deployment) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	var replicaSets appsv1.ReplicaSetList
+	if err := s.workloadReader.List(ctx, &replicaSets, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", err
+	}
+	revision := deployment.Annotations[deploymentRevisionAnnotation]
+	for i := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[i]
+		if metav1.IsControlledBy(replicaSet, deployment) && revision != "" && replicaSet.Annotations[deploymentRevisionAnnotation] == revision {
+			return replicaSet.Labels[appsv1.DefaultDeploymentUniqueLabelKey], nil
+		}
+	}
+	return "", nil
+}
+
+// daemonSetRevision returns the controller-revision-hash of the newest revision of a
+// DaemonSet, empty if it has none yet
+func (s *Server) daemonSetRevision(ctx context.Context, daemonSet *appsv1.DaemonSet) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	var revisions appsv1.ControllerRevisionList
+	if err := s.workloadReader.List(ctx, &revisions, client.InNamespace(daemonSet.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", err
+	}
+	var newest *appsv1.ControllerRevision
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if metav1.IsControlledBy(revision, daemonSet) && (newest == nil || revision.Revision > newest.Revision) {
+			newest = revision
+		}
+	}
+	if newest == nil {
+		return "", nil
+	}
+	return newest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// replicasOf returns the desired replicas of a workload, 1 if unset
+func replicasOf(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// workloadPods lists the pods of the revision a workload rolls out that are not being
+// deleted, none until the revision exists
+func (s *Server) workloadPods(ctx context.Context, namespace string, state *rollout) ([]corev1.Pod, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(state.selector)
+	if err != nil {
+		return nil, err
+	}
+	var podList corev1.PodList
+	if err := s.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(podList.Items, func(pod corev1.Pod) bool {
+		return pod.DeletionTimestamp != nil || state.revision == "" || pod.Labels[state.revisionLabel] != state.revision
+	}), nil
+}
+
+// allReady reports whether every pod is ready
+func allReady(pods []corev1.Pod) bool {
+	for i := range pods {
+		if !isReady(&pods[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isReady reports whether a pod is ready
+func isReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// failingPod describes the first pod that will not become ready without a change, empty
+// if none
+func failingPod(pods []corev1.Pod) string {
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodFailed {
+			return fmt.Sprintf("pod %s failed", pod.Name)
+		}
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if status.State.Waiting != nil && slices.Contains(failingWaitReasons, status.State.Waiting.Reason) {
+				return fmt.Sprintf("pod %s: container %s is in %s", pod.Name, status.Name, status.State.Waiting.Reason)
+			}
+		}
+	}
+	return ""
+}
+
+// podProblem describes why a pod is not ready
+func podProblem(pod *corev1.Pod) string {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		switch {
+		case status.State.Waiting != nil && status.State.Waiting.Reason != "":
+			return status.Name + ": " + status.State.Waiting.Reason
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("%s: %s (exit code %d)", status.Name, status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+		case !status.Ready && status.State.Running != nil:
+			return status.Name + ": not ready"
+		}
+	}
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	return string(pod.Status.Phase)
+}
+
+// recordDeployVerdict records the verdict of a deploy as an Event on its workload
+func (s *Server) recordDeployVerdict(state *rollout, response *deployHookResponse) {
+	if s.deployRecorder == nil || state == nil {
+		return
+	}
+	revision := ""
+	if response.Revision != "" {
+		revision = " " + response.Revision
+	}
+	if response.Verdict == deployPassed {
+		s.deployRecorder.Eventf(state.object, corev1.EventTypeNormal, "DeployVerified", "Deploy%s verified after %s", revision, response.Duration)
+		return
+	}
+	s.deployRecorder.Eventf(state.object, corev1.EventTypeWarning, "DeployVerificationFailed", "Deploy%s failed verification: %s", revision, response.Reason)
+}
+
+// notifyDeployVerdict sends the verdict of a deploy to the notification sinks of the
+// PodSleuth named in the request, or else of the first PodSleuth selecting its pods
+func (s *Server) notifyDeployVerdict(ctx context.Context, podSleuth string, state *rollout, response *deployHookResponse,
+	failing []infrav1alpha1.NonReadyPodInfo) {
+	if s.deployNotifier == nil || state == nil {
+		return
+	}
+	verdict := controller.DeployVerdict{
+		Namespace: response.Namespace,
+		Kind:      response.Kind,
+		Name:      response.Name,
+		Revision:  response.Revision,
+		Verdict:   response.Verdict,
+		Reason:    response.Reason,
+		Duration:  response.Duration,
+	}
+	if err := s.deployNotifier.NotifyDeployVerdict(ctx, podSleuth, state.podLabels, verdict, failing); err != nil {
+		log.Log.WithName("web").Info("could not notify deploy verdict", "namespace", response.Namespace, "kind", response.Kind,
+			"name", response.Name, "error", err.Error())
+	}
+}
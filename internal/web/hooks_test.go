@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// recordingNotifier records the deploy verdicts sent to notification sinks
+type recordingNotifier struct {
+	verdicts []controller.DeployVerdict
+	failing  [][]infrav1alpha1.NonReadyPodInfo
+}
+
+func (n *recordingNotifier) NotifyDeployVerdict(ctx context.Context, podSleuth string, podLabels map[string]string,
+	verdict controller.DeployVerdict, failing []infrav1alpha1.NonReadyPodInfo) error {
+	n.verdicts = append(n.verdicts, verdict)
+	n.failing = append(n.failing, failing)
+	return nil
+}
+
+// hookTestPod returns a pod of the web workload with the revision label, crash looping
+// unless ready
+func hookTestPod(name, revisionLabel, revision string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name,
+		Labels: map[string]string{"app": "web", revisionLabel: revision}}}
+	if ready {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		return pod
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "web",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}}
+	return pod
+}
+
+// hookTestControlledBy makes owner the controller of object
+func hookTestControlledBy(object metav1.Object, owner client.Object, kind string) {
+	isController := true
+	object.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: owner.GetName(), UID: owner.GetUID(), Controller: &isController}})
+}
+
+func TestDeployHookVerdictsOnlyCountTheNewRevision(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	template := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}}
+	one := int32(1)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", UID: types.UID("deployment"),
+			Annotations: map[string]string{deploymentRevisionAnnotation: "2"}},
+		Spec:   appsv1.DeploymentSpec{Replicas: &one, Selector: selector, Template: template},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	var replicaSets []client.Object
+	for revision, hash := range map[string]string{"1": "old", "2": "new"} {
+		replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-" + hash,
+			Labels:      map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: hash},
+			Annotations: map[string]string{deploymentRevisionAnnotation: revision}}}
+		hookTestControlledBy(replicaSet, deployment, "Deployment")
+		replicaSets = append(replicaSets, replicaSet)
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", UID: types.UID("statefulset")},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &one, Selector: selector, Template: template},
+		Status: appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1,
+			CurrentRevision: "web-new", UpdateRevision: "web-new"},
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", UID: types.UID("daemonset")},
+		Spec:       appsv1.DaemonSetSpec{Selector: selector, Template: template},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberAvailable: 1},
+	}
+	var revisions []client.Object
+	for revision, hash := range map[int64]string{1: "old", 2: "new"} {
+		controllerRevision := &appsv1.ControllerRevision{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-" + hash,
+			Labels: map[string]string{"app": "web", appsv1.DefaultDaemonSetUniqueLabelKey: hash}}, Revision: revision}
+		hookTestControlledBy(controllerRevision, daemonSet, "DaemonSet")
+		revisions = append(revisions, controllerRevision)
+	}
+
+	tests := []struct {
+		name        string
+		kind        string
+		workload    []client.Object
+		label       string
+		oldReady    bool
+		newReady    bool
+		wantVerdict string
+	}{
+		{name: "old Deployment pod crash looping", kind: "Deployment", workload: append([]client.Object{deployment}, replicaSets...),
+			label: appsv1.DefaultDeploymentUniqueLabelKey, newReady: true, wantVerdict: deployPassed},
+		{name: "new Deployment pod crash looping", kind: "Deployment", workload: append([]client.Object{deployment}, replicaSets...),
+			label: appsv1.DefaultDeploymentUniqueLabelKey, oldReady: true, wantVerdict: deployFailed},
+		{name: "old StatefulSet pod crash looping", kind: "StatefulSet", workload: []client.Object{statefulSet},
+			label: appsv1.ControllerRevisionHashLabelKey, newReady: true, wantVerdict: deployPassed},
+		{name: "new StatefulSet pod crash looping", kind: "StatefulSet", workload: []client.Object{statefulSet},
+			label: appsv1.ControllerRevisionHashLabelKey, oldReady: true, wantVerdict: deployFailed},
+		{name: "old DaemonSet pod crash looping", kind: "DaemonSet", workload: append([]client.Object{daemonSet}, revisions...),
+			label: appsv1.DefaultDaemonSetUniqueLabelKey, newReady: true, wantVerdict: deployPassed},
+		{name: "new DaemonSet pod crash looping", kind: "DaemonSet", workload: append([]client.Object{daemonSet}, revisions...),
+			label: appsv1.DefaultDaemonSetUniqueLabelKey, oldReady: true, wantVerdict: deployFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRevision := "new"
+			if tt.kind == "StatefulSet" {
+				newRevision = "web-new"
+			}
+			objects := append([]client.Object{
+				hookTestPod("web-old", tt.label, "old", tt.oldReady),
+				hookTestPod("web-new", tt.label, newRevision, tt.newReady),
+			}, tt.workload...)
+			c := fake.NewClientBuilder().WithScheme(hookTestScheme(t)).WithObjects(objects...).Build()
+			notifier := &recordingNotifier{}
+			s := &Server{client: c}
+			s.EnableDeployHooks("deploy-token", c, nil, notifier)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/hooks/deploy",
+				strings.NewReader(`{"namespace":"shop","kind":"`+tt.kind+`","name":"web","revision":"abc123"}`))
+			req.Header.Set("Authorization", "Bearer deploy-token")
+			recorder := httptest.NewRecorder()
+			s.handleDeployHook(recorder, req)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("got %d: %s", recorder.Code, recorder.Body)
+			}
+			var response deployHookResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+
+			if response.Verdict != tt.wantVerdict {
+				t.Errorf("got verdict %q (%s), want %q", response.Verdict, response.Reason, tt.wantVerdict)
+			}
+			if len(response.Pods) != 1 || response.Pods[0].Name != "web-new" {
+				t.Errorf("got pods %+v, want only web-new", response.Pods)
+			}
+			if len(notifier.verdicts) != 1 || notifier.verdicts[0].Verdict != tt.wantVerdict || notifier.verdicts[0].Revision != "abc123" {
+				t.Fatalf("notified %+v, want one %s verdict of abc123", notifier.verdicts, tt.wantVerdict)
+			}
+			if failing := notifier.failing[0]; tt.wantVerdict == deployFailed && (len(failing) != 1 || failing[0].Name != "web-new") {
+				t.Errorf("notified failing pods %+v, want only web-new", failing)
+			}
+		})
+	}
+}
+
+func hookTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
+)
+
+// maxHubPushBytes bounds the findings a cluster may push at once
+const maxHubPushBytes = 64 << 20
+
+// EnableHub serves the findings agents of other clusters push to POST /api/hub/findings
+// next to the PodSleuths of this cluster. tokens maps each cluster to the bearer token
+// its agent pushes with; see hub.ParseClusterTokens.
+func (s *Server) EnableHub(store *hub.Store, tokens map[string]string) {
+	s.hub = store
+	s.hubTokens = tokens
+}
+
+// hubCluster returns the cluster a hub token belongs to, or "". All tokens are compared,
+// so the time taken does not tell which one matched.
+func (s *Server) hubCluster(token string) string {
+	cluster := ""
+	for name, clusterToken := range s.hubTokens {
+		if tokenEqual(token, clusterToken) {
+			cluster = name
+		}
+	}
+	return cluster
+}
+
+// hubPushResult is the response of POST /api/hub/findings
+type hubPushResult struct {
+	Cluster    string `json:"cluster"`
+	PodSleuths int    `json:"podSleuths"`
+	// Changed is the number of PodSleuths added, updated or removed by the push
+	Changed int `json:"changed"`
+}
+
+// clusterList is the response of GET /api/clusters
+type clusterList struct {
+	// Local is the name of the hub's own cluster
+	Local    string              `json:"local,omitempty"`
+	Clusters []hub.ClusterStatus `json:"clusters"`
+}
+
+// handleHubFindings receives the PodSleuths an agent pushes: POST /api/hub/findings
+func (s *Server) handleHubFindings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.hub == nil || len(s.hubTokens) == 0 {
+		http.Error(w, "Hub mode is not enabled", http.StatusNotFound)
+		return
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	cluster := s.hubCluster(token)
+	if !found || cluster == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var findings hub.Findings
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHubPushBytes)).Decode(&findings); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Findings too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Invalid findings: %v", err), http.StatusBadRequest)
+		return
+	}
+	if findings.Cluster != cluster {
+		http.Error(w, fmt.Sprintf("The token is not the hub token of cluster %q", findings.Cluster), http.StatusForbidden)
+		return
+	}
+	if findings.Cluster != "" && findings.Cluster == controller.ClusterName() {
+		http.Error(w, fmt.Sprintf("Cluster %q is the hub's own cluster", findings.Cluster), http.StatusConflict)
+		return
+	}
+	changes, err := s.hub.Put(&findings)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, change := range changes {
+		if change.PodSleuth != nil {
+			s.live.publish(liveEvent{Name: "podsleuth", PodSleuth: change.PodSleuth})
+		} else {
+			s.live.publish(liveEvent{Name: "podsleuth", Data: podSleuthEvent{Type: "deleted", Name: change.Key}})
+		}
+	}
+	if len(changes) > 0 {
+		log.Log.WithName("web").WithName("hub").V(1).Info("findings received", "cluster", findings.Cluster,
+			"podSleuths", len(findings.PodSleuths), "changed", len(changes))
+	}
+
+	writeJSON(w, r, hubPushResult{Cluster: findings.Cluster, PodSleuths: len(findings.PodSleuths), Changed: len(changes)})
+}
+
+// handleClusters lists the clusters pushing findings to the hub: GET /api/clusters
+func (s *Server) handleClusters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.hub == nil {
+		http.Error(w, "Hub mode is not enabled", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, r, clusterList{Local: controller.ClusterName(), Clusters: s.hub.Clusters()})
+}
+
+// listPodSleuths returns the PodSleuths of this cluster followed, in hub mode, by those
+// of the clusters pushing to it, and a version identifying them
+func (s *Server) listPodSleuths(ctx context.Context) (*infrav1alpha1.PodSleuthList, string, error) {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := s.client.List(ctx, &podSleuthList); err != nil {
+		return nil, "", err
+	}
+	version := listVersion(&podSleuthList)
+	if s.hub != nil {
+		podSleuthList.Items = append(podSleuthList.Items, s.hub.PodSleuths()...)
+		version += s.hub.Version()
+	}
+	return &podSleuthList, version, nil
+}
+
+// clusterOf returns the cluster of a PodSleuth: the cluster that pushed it, or this one
+func (s *Server) clusterOf(podSleuth *infrav1alpha1.PodSleuth) string {
+	if cluster := podSleuth.Annotations[hub.ClusterAnnotation]; cluster != "" {
+		return cluster
+	}
+	return controller.ClusterName()
+}
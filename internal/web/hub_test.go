@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
+)
+
+func TestHubFindingsBindTokensToClusters(t *testing.T) {
+	store := hub.NewStore(0)
+	s := &Server{}
+	s.EnableHub(store, map[string]string{"prod-eu-1": "eu-token", "prod-us-1": "us-token"})
+
+	tests := []struct {
+		token   string
+		cluster string
+		want    int
+	}{
+		{token: "eu-token", cluster: "prod-eu-1", want: http.StatusOK},
+		{token: "eu-token", cluster: "prod-us-1", want: http.StatusForbidden},
+		{token: "us-token", cluster: "prod-eu-1", want: http.StatusForbidden},
+		{token: "other-token", cluster: "prod-eu-1", want: http.StatusUnauthorized},
+		{token: "", cluster: "prod-eu-1", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		body := `{"cluster":"` + tt.cluster + `","podSleuths":[{"metadata":{"name":"production"}}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/hub/findings", strings.NewReader(body))
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		recorder := httptest.NewRecorder()
+		s.handleHubFindings(recorder, req)
+		if recorder.Code != tt.want {
+			t.Errorf("push for %s with %q: got %d, want %d", tt.cluster, tt.token, recorder.Code, tt.want)
+		}
+	}
+
+	// Only the push with the token of prod-eu-1 reached the store
+	clusters := store.Clusters()
+	if len(clusters) != 1 || clusters[0].Name != "prod-eu-1" {
+		t.Errorf("got clusters %+v, want only prod-eu-1", clusters)
+	}
+}
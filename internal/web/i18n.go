@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the dashboard language unless the operator is configured otherwise
+const DefaultLocale = "en"
+
+// localeCookie remembers the language a user picked in the dashboard
+const localeCookie = "kubesleuth-locale"
+
+// messageCatalog holds the messages of the dashboard in one language by key. Messages
+// may hold {name} placeholders, which the dashboard fills in.
+type messageCatalog map[string]string
+
+// message returns the message of key, or the key itself if the catalog has none
+func (c messageCatalog) message(key string) string {
+	if message, exists := c[key]; exists {
+		return message
+	}
+	return key
+}
+
+// messageCatalogs holds the catalogs of locales/ by locale. Messages missing from a
+// translation are taken from the default locale.
+var messageCatalogs = loadMessageCatalogs()
+
+// loadMessageCatalogs reads the embedded message catalogs
+func loadMessageCatalogs() map[string]messageCatalog {
+	catalogs := make(map[string]messageCatalog)
+	files, err := fs.Glob(dashboardFiles, "locales/*.json")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		content, err := dashboardFiles.ReadFile(file)
+		if err != nil {
+			panic(err)
+		}
+		var catalog messageCatalog
+		if err := json.Unmarshal(content, &catalog); err != nil {
+			panic(fmt.Errorf("invalid message catalog %s: %w", file, err))
+		}
+		catalogs[strings.TrimSuffix(path.Base(file), ".json")] = catalog
+	}
+	defaults, exists := catalogs[DefaultLocale]
+	if !exists {
+		panic("no message catalog for the default locale " + DefaultLocale)
+	}
+	for _, catalog := range catalogs {
+		for key, message := range defaults {
+			if _, exists := catalog[key]; !exists {
+				catalog[key] = message
+			}
+		}
+	}
+	return catalogs
+}
+
+// Locales returns the languages the dashboard is available in
+func Locales() []string {
+	locales := make([]string, 0, len(messageCatalogs))
+	for locale := range messageCatalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// SetLocale sets the dashboard language of users who have not picked one in the
+// dashboard. It fails for languages the dashboard is not available in.
+func (s *Server) SetLocale(locale string) error {
+	if _, exists := messageCatalogs[locale]; !exists {
+		return fmt.Errorf("unsupported dashboard locale %q, supported are %s", locale, strings.Join(Locales(), ", "))
+	}
+	s.locale = locale
+	return nil
+}
+
+// requestLocale returns the language to serve a request in: the one the user picked, else
+// the configured default
+func (s *Server) requestLocale(r *http.Request) string {
+	if cookie, err := r.Cookie(localeCookie); err == nil {
+		if _, exists := messageCatalogs[cookie.Value]; exists {
+			return cookie.Value
+		}
+	}
+	if s.locale != "" {
+		return s.locale
+	}
+	return DefaultLocale
+}
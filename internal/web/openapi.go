@@ -0,0 +1,630 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
+)
+
+// apiParameter is a path or query parameter of an API operation
+type apiParameter struct {
+	Name string
+	// In is "path" or "query"
+	In string
+	// Type is "string", "integer" or "boolean"
+	Type        string
+	Description string
+}
+
+// pathParameter is a path parameter; they are all strings
+func pathParameter(name, description string) apiParameter {
+	return apiParameter{Name: name, In: "path", Type: "string", Description: description}
+}
+
+// queryParameter is an optional query parameter
+func queryParameter(name, typ, description string) apiParameter {
+	return apiParameter{Name: name, In: "query", Type: typ, Description: description}
+}
+
+// apiOperation is an endpoint of the dashboard API described by /api/openapi.json
+type apiOperation struct {
+	Method string
+	Path   string
+	// ID names the operation, and the method of the generated Go client
+	ID          string
+	Summary     string
+	Description string
+	Parameters  []apiParameter
+	// Request is a value of the JSON request body type, nil for operations without body
+	Request interface{}
+	// Response is a value of the JSON response type, nil for other responses
+	Response interface{}
+	// Status is the status code of a successful response (default 200)
+	Status int
+	// ContentType is the media type of a response that is not JSON
+	ContentType string
+}
+
+var (
+	podNamespaceParameter = pathParameter("namespace", "Namespace of the pod")
+	podNameParameter      = pathParameter("name", "Name of the pod")
+	teamParameter         = queryParameter("team", "string", "Only include the PodSleuths of this team")
+)
+
+// apiOperations are the operations of the dashboard API. Handlers that change their
+// parameters or payloads update them here, the generated client follows with
+// `make generate`.
+var apiOperations = []apiOperation{
+	{
+		Method: http.MethodGet, Path: "/api/podsleuths", ID: "listPodSleuths",
+		Summary:    "List PodSleuths",
+		Parameters: []apiParameter{teamParameter},
+		Response:   infrav1alpha1.PodSleuthList{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/podsleuths/{name}", ID: "getPodSleuth",
+		Summary:    "Get a PodSleuth",
+		Parameters: []apiParameter{pathParameter("name", "Name of the PodSleuth")},
+		Response:   infrav1alpha1.PodSleuth{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/podsleuths/{name}/patterns", ID: "listPatterns",
+		Summary:     "List the error patterns of a PodSleuth",
+		Description: "Lists the built-in patterns with defaults set while the PodSleuth has none of its own. Fails with 409 when it does not run the pattern method.",
+		Parameters:  []apiParameter{pathParameter("name", "Name of the PodSleuth")},
+		Response:    patternList{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/podsleuths/{name}/patterns", ID: "createPattern",
+		Summary:     "Add an error pattern to a PodSleuth",
+		Description: "The first pattern of a PodSleuth replaces the built-in patterns. Fails with 409 when a pattern of the name exists.",
+		Parameters:  []apiParameter{pathParameter("name", "Name of the PodSleuth")},
+		Request:     infrav1alpha1.ErrorPattern{},
+		Response:    patternList{},
+		Status:      http.StatusCreated,
+	},
+	{
+		Method: http.MethodPut, Path: "/api/podsleuths/{name}/patterns/{pattern}", ID: "updatePattern",
+		Summary: "Replace an error pattern of a PodSleuth",
+		Parameters: []apiParameter{pathParameter("name", "Name of the PodSleuth"),
+			pathParameter("pattern", "Name of the pattern")},
+		Request:  infrav1alpha1.ErrorPattern{},
+		Response: patternList{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/podsleuths/{name}/patterns/{pattern}", ID: "deletePattern",
+		Summary: "Remove an error pattern of a PodSleuth",
+		Parameters: []apiParameter{pathParameter("name", "Name of the PodSleuth"),
+			pathParameter("pattern", "Name of the pattern")},
+		Response: patternList{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/patterns/test", ID: "testPatterns",
+		Summary:     "Test error patterns against pasted log lines",
+		Description: "Reports invalid patterns, the pattern each line matches and what the pattern method would report. Without logs it only validates the patterns.",
+		Request:     patternTestRequest{},
+		Response:    patternTestResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/pods", ID: "listPods",
+		Summary:     "List the non-ready pods of all PodSleuths one page at a time",
+		Description: "Filters combine; q searches names, reasons, messages and root causes.",
+		Parameters: []apiParameter{
+			queryParameter("cluster", "string", "Only pods of this cluster, in hub mode"),
+			queryParameter("namespace", "string", "Only pods in this namespace"),
+			queryParameter("phase", "string", "Only pods in this phase"),
+			queryParameter("reason", "string", "Only pods with this reason, of the pod or a container"),
+			queryParameter("owner", "string", "Only pods of this owner, as name or kind/name"),
+			teamParameter,
+			queryParameter("podSleuth", "string", "Only pods reported by this PodSleuth"),
+			queryParameter("severity", "string", "Only pods of this severity: critical, warning or info"),
+			queryParameter("q", "string", "Search text"),
+			queryParameter("sort", "string", "name (default), namespace, duration, age or severity, reversed with a leading -"),
+			queryParameter("limit", "integer", "Page size (default 100, at most 1000)"),
+			queryParameter("offset", "integer", "Index of the first pod of the page"),
+		},
+		Response: podList{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/pods/{namespace}/{name}", ID: "getPod",
+		Summary: "Get everything known about a non-ready pod",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter,
+			queryParameter("podSleuth", "string", "PodSleuth reporting the pod, when several do")},
+		Response: podDetail{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/pods/{namespace}/{name}/logs", ID: "getPodLogs",
+		Summary: "Get the redacted log of a container of a non-ready pod (requires dashboard authentication)",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter,
+			queryParameter("container", "string", "Container (default: the blocking init container or the first failing container)"),
+			queryParameter("tail", "integer", "Number of lines (default 500, at most 5000)"),
+			queryParameter("previous", "boolean", "Get the log of the run before the last restart")},
+		Response: podLogs{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/pods/{namespace}/{name}/events", ID: "listPodEvents",
+		Summary:    "List the most recent events of a non-ready pod, newest first",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter},
+		Response:   podEvents{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/pods/{namespace}/{name}/acknowledge", ID: "acknowledgePod",
+		Summary:    "Acknowledge a non-ready pod",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter},
+		Request:    acknowledgeRequest{},
+		Response:   acknowledgeResponse{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/pods/{namespace}/{name}/acknowledge", ID: "unacknowledgePod",
+		Summary:    "Withdraw the acknowledgement of a pod",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter},
+		Response:   acknowledgeResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/pods/{namespace}/{name}/analyze", ID: "analyzePod",
+		Summary: "Analyze the logs of any pod now, ready or not (requires dashboard authentication)",
+		Description: "Uses the log analysis configuration of podSleuth, or of the first PodSleuth with log analysis enabled selecting the pod. " +
+			"The analysis is returned, not cached or written to status. Fails with 422 when no PodSleuth can analyze the pod.",
+		Parameters: []apiParameter{podNamespaceParameter, podNameParameter,
+			queryParameter("podSleuth", "string", "PodSleuth whose log analysis configuration to use")},
+		Response: onDemandAnalysis{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/reports/{namespace}/{name}", ID: "getReport",
+		Summary: "Get a PodSleuthReport",
+		Parameters: []apiParameter{pathParameter("namespace", "Namespace of the report"),
+			pathParameter("name", "Name of the report")},
+		Response: infrav1alpha1.PodSleuthReport{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/stats", ID: "getStats",
+		Summary:    "Aggregate the non-ready pods of all PodSleuths",
+		Parameters: []apiParameter{teamParameter},
+		Response:   podStats{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/history", ID: "getHistory",
+		Summary: "Get the sampled non-ready pod counts and incidents of a time range",
+		Parameters: []apiParameter{queryParameter("range", "string",
+			"Go duration such as 1h (default 24h, at most the retention)")},
+		Response: historyResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/shard", ID: "getShard",
+		Summary:  "Describe the shard of the replica serving the request",
+		Response: shardInfo{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/force-refresh", ID: "forceRefresh",
+		Summary: "Analyze non-ready pods again, bypassing the analysis cache",
+		Description: "Refreshes the listed pods, or the single pod of podName and podNamespace, and all non-ready pods otherwise, " +
+			"with the PodSleuth reporting them, or only podSleuth if set. Results of the forced analyses carry the returned generation " +
+			"as logAnalysis.refreshGeneration once they finished. Fails with 404 when no PodSleuth matches.",
+		Request:  forceRefreshRequest{},
+		Response: forceRefreshResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/analyses", ID: "startAnalysis",
+		Summary: "Start an analysis job analyzing non-ready pods again",
+		Description: "Takes the request of forceRefresh. The job starts queued, is running once the PodSleuths picked it up " +
+			"and completed when every pod has its analysis, or failed. Jobs are kept for an hour by the replica that started them.",
+		Request:  forceRefreshRequest{},
+		Response: analysisJob{},
+		Status:   http.StatusAccepted,
+	},
+	{
+		Method: http.MethodGet, Path: "/api/analyses/{id}", ID: "getAnalysis",
+		Summary:    "Get the progress of an analysis job, with the analyses of its completed pods",
+		Parameters: []apiParameter{pathParameter("id", "ID of the analysis job")},
+		Response:   analysisJob{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/hooks/deploy", ID: "verifyDeploy",
+		Summary: "Verify a deploy for a deployment gate",
+		Description: "Waits until the workload rolled out with every pod ready, or a pod crash loops or fails to pull its image, " +
+			"analyzes failing pods and returns the verdict, also recorded as an Event on the workload. " +
+			"Requires the deploy hook token as bearer token, or dashboard authentication.",
+		Request:  deployHookRequest{},
+		Response: deployHookResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/cache", ID: "listCache",
+		Summary:  "List the cached analyses of this shard",
+		Response: cacheList{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/cache", ID: "flushCache",
+		Summary:  "Flush the analysis cache (requires dashboard authentication)",
+		Response: cacheFlushResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/cache/{namespace}/{pod}", ID: "getPodCache",
+		Summary: "Get the cached analyses of a pod",
+		Parameters: []apiParameter{pathParameter("namespace", "Namespace of the pod"),
+			pathParameter("pod", "Name of the pod")},
+		Response: podCache{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/cache/{namespace}/{pod}", ID: "flushPodCache",
+		Summary: "Flush the cached analyses of a pod (requires dashboard authentication)",
+		Parameters: []apiParameter{pathParameter("namespace", "Namespace of the pod"),
+			pathParameter("pod", "Name of the pod")},
+		Response: cacheFlushResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/silences", ID: "listSilences",
+		Summary:  "List SleuthSilences",
+		Response: infrav1alpha1.SleuthSilenceList{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/silences", ID: "createSilence",
+		Summary:  "Create a SleuthSilence",
+		Request:  createSilenceRequest{},
+		Response: silenceCreated{},
+		Status:   http.StatusCreated,
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/silences/{name}", ID: "deleteSilence",
+		Summary:    "Delete a SleuthSilence",
+		Parameters: []apiParameter{pathParameter("name", "Name of the SleuthSilence")},
+		Response:   silenceDeleted{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/remediations/{podSleuth}/{id}/approve", ID: "approveRemediation",
+		Summary:     "Approve a pending remediation",
+		Description: "Requires the remediation approval token as bearer token.",
+		Parameters: []apiParameter{pathParameter("podSleuth", "Name of the PodSleuth"),
+			pathParameter("id", "ID of the pending remediation")},
+		Request:  remediationDecisionRequest{},
+		Response: remediationDecision{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/remediations/{podSleuth}/{id}/reject", ID: "rejectRemediation",
+		Summary:     "Reject a pending remediation",
+		Description: "Requires the remediation approval token as bearer token.",
+		Parameters: []apiParameter{pathParameter("podSleuth", "Name of the PodSleuth"),
+			pathParameter("id", "ID of the pending remediation")},
+		Request:  remediationDecisionRequest{},
+		Response: remediationDecision{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/events", ID: "streamEvents",
+		Summary: "Stream live updates as server-sent events",
+		Description: "\"podsleuth\" events carry a PodSleuthEvent when a PodSleuth changes, " +
+			"\"analysis\" events an AnalysisEvent when an analysis finishes.",
+		Parameters:  []apiParameter{teamParameter},
+		ContentType: "text/event-stream",
+	},
+	{
+		Method: http.MethodGet, Path: "/api/audit/ai-requests", ID: "listAIRequests",
+		Summary: "List the AI requests that sent pod logs out of the cluster, newest first",
+		Description: "Each request records its pod, endpoint, model, the log lines and bytes sent and the redactions applied. " +
+			"Replicas keep their latest 10000 requests in memory, and also log them as \"AI request sent\" by the ai-egress logger.",
+		Parameters: []apiParameter{
+			queryParameter("namespace", "string", "Only requests for pods in this namespace"),
+			queryParameter("pod", "string", "Only requests for pods of this name"),
+			queryParameter("since", "string", "Only requests after this RFC 3339 time, or within this Go duration such as 24h"),
+			queryParameter("limit", "integer", "Maximum number of requests (default 1000)"),
+		},
+		Response: outboundAIRequests{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/hub/findings", ID: "pushFindings",
+		Summary: "Push the PodSleuths of a cluster to the hub",
+		Description: "Replaces the findings last pushed by the cluster. Only served in hub mode, " +
+			"and requires the hub token of the cluster as bearer token.",
+		Request:  hub.Findings{},
+		Response: hubPushResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/clusters", ID: "listClusters",
+		Summary:     "List the clusters pushing findings to the hub",
+		Description: "Only served in hub mode. Stale clusters stopped pushing; their last findings are still served.",
+		Response:    clusterList{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/slo", ID: "getSLO",
+		Summary: "Report the MTTR and availability of workloads",
+		Description: "One report per PodSleuth with spec.slo set, computed from the incidents in its status over its rolling window. " +
+			"Availability is in percent.",
+		Parameters: []apiParameter{
+			queryParameter("podSleuth", "string", "Only the report of this PodSleuth"),
+			queryParameter("namespace", "string", "Only workloads in this namespace"),
+			queryParameter("cluster", "string", "Only PodSleuths of this cluster, in hub mode"),
+			queryParameter("breaching", "boolean", "Only workloads below the availability objective"),
+		},
+		Response: []podSleuthSLO{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/whoami", ID: "whoami",
+		Summary:  "Get the authenticated user of the request",
+		Response: whoami{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/openapi.json", ID: "getOpenAPI",
+		Summary:  "Get this OpenAPI document",
+		Response: map[string]interface{}{},
+	},
+}
+
+// apiEventPayloads are the payloads of the server-sent events of /api/events
+var apiEventPayloads = []interface{}{podSleuthEvent{}, analysisEvent{}}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	metaTimeType      = reflect.TypeFor[metav1.Time]()
+	metaDurationType  = reflect.TypeFor[metav1.Duration]()
+	quantityType      = reflect.TypeFor[resource.Quantity]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// openAPISchemas derives the schemas of the API payloads from their Go types, as
+// encoding/json marshals them. Named structs become components.
+type openAPISchemas struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// schema returns the schema of a type
+func (g *openAPISchemas) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType, metaTimeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case metaDurationType:
+		return map[string]interface{}{"type": "string", "description": "Go duration such as 1h30m"}
+	case quantityType:
+		return map[string]interface{}{"type": "string", "description": "Kubernetes quantity such as 512Mi"}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		// Marshaled in a format of its own
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.ref(t)
+	}
+	return map[string]interface{}{}
+}
+
+// ref returns a reference to the component of a named struct, adding it if needed
+func (g *openAPISchemas) ref(t reflect.Type) map[string]interface{} {
+	name, known := g.names[t]
+	if !known {
+		name = strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+		if _, taken := g.components[name]; taken {
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		}
+		g.names[t] = name
+		// Registered before its fields, which may refer back to it
+		g.components[name] = nil
+		g.components[name] = g.object(t)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// object returns the schema of the JSON object a struct marshals to
+func (g *openAPISchemas) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	g.addFields(t, properties, &required)
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds the fields of a struct, including those of embedded structs, to the
+// properties of an object. Fields marshaled even when empty are required.
+func (g *openAPISchemas) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.schema(field.Type)
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,") || strings.Contains(","+options+",", ",omitzero,")
+		if !omitEmpty {
+			*required = append(*required, name)
+			if field.Type.Kind() == reflect.Pointer || field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Map ||
+				field.Type == metaTimeType {
+				// A nil value, or a zero metav1.Time, is marshaled as null
+				if _, isRef := schema["$ref"]; isRef {
+					schema = map[string]interface{}{"allOf": []interface{}{schema}}
+				}
+				schema["nullable"] = true
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+// OpenAPISpec returns the OpenAPI 3 document of the dashboard API, which the dashboard
+// serves on /api/openapi.json and hack/openapi generates the Go client from
+func OpenAPISpec() ([]byte, error) {
+	schemas := &openAPISchemas{components: map[string]interface{}{}, names: map[reflect.Type]string{}}
+	errorResponse := map[string]interface{}{
+		"description": "Error message",
+		"content": map[string]interface{}{
+			"text/plain": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		},
+	}
+
+	paths := map[string]map[string]interface{}{}
+	for _, op := range apiOperations {
+		operation := map[string]interface{}{"operationId": op.ID, "summary": op.Summary}
+		if op.Description != "" {
+			operation["description"] = op.Description
+		}
+		if len(op.Parameters) > 0 {
+			parameters := make([]interface{}, 0, len(op.Parameters))
+			for _, param := range op.Parameters {
+				parameters = append(parameters, map[string]interface{}{
+					"name":        param.Name,
+					"in":          param.In,
+					"required":    param.In == "path",
+					"description": param.Description,
+					"schema":      map[string]interface{}{"type": param.Type},
+				})
+			}
+			operation["parameters"] = parameters
+		}
+		if op.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.schema(reflect.TypeOf(op.Request))},
+				},
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := map[string]interface{}{"description": http.StatusText(status)}
+		switch {
+		case op.Response != nil:
+			response["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemas.schema(reflect.TypeOf(op.Response))},
+			}
+		case op.ContentType != "":
+			response["content"] = map[string]interface{}{
+				op.ContentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+			}
+		}
+		operation["responses"] = map[string]interface{}{strconv.Itoa(status): response, "default": errorResponse}
+
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]interface{}{}
+		}
+		paths[op.Path][strings.ToLower(op.Method)] = operation
+	}
+	for _, payload := range apiEventPayloads {
+		schemas.schema(reflect.TypeOf(payload))
+	}
+
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "KubeSleuth dashboard API",
+			"version":     infrav1alpha1.GroupVersion.Version,
+			"description": "The API behind the KubeSleuth dashboard. Errors are returned as plain text.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerToken": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"basicAuth":   map[string]interface{}{"type": "http", "scheme": "basic"},
+				"session":     map[string]interface{}{"type": "apiKey", "in": "cookie", "name": sessionCookie},
+			},
+		},
+		// Any method the dashboard is configured with, or none when authentication is
+		// disabled
+		"security": []interface{}{
+			map[string]interface{}{"bearerToken": []string{}},
+			map[string]interface{}{"basicAuth": []string{}},
+			map[string]interface{}{"session": []string{}},
+			map[string]interface{}{},
+		},
+	}
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling OpenAPI document: %w", err)
+	}
+	return data, nil
+}
+
+// openAPISpec is the OpenAPI document served, built on first request
+var openAPISpec = sync.OnceValues(OpenAPISpec)
+
+// handleOpenAPI serves the OpenAPI document of the dashboard API
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	spec, err := openAPISpec()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if writeNotModified(w, r, etag("c:"+string(spec))) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	writeBody(w, r, spec)
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+const (
+	// maxPatternTestLines bounds the pasted log lines of POST /api/patterns/test
+	maxPatternTestLines = 5000
+	// maxPatternTestBytes bounds the body of POST /api/patterns/test
+	maxPatternTestBytes = 4 << 20
+	// maxPatternBytes bounds the body creating or updating a pattern
+	maxPatternBytes = 64 << 10
+)
+
+var (
+	// errPatternsUnavailable is returned when a PodSleuth does not run the pattern method
+	errPatternsUnavailable = errors.New("does not run the pattern method of log analysis")
+	errPatternNotFound     = errors.New("pattern not found")
+	errPatternExists       = errors.New("a pattern with this name already exists")
+)
+
+// patternList is the response of the pattern endpoints of a PodSleuth
+type patternList struct {
+	PodSleuth string `json:"podSleuth"`
+	// Defaults is whether the PodSleuth has no patterns of its own and matches the
+	// built-in ones, listed in Patterns. Its first pattern replaces them all.
+	Defaults bool                         `json:"defaults"`
+	Patterns []infrav1alpha1.ErrorPattern `json:"patterns"`
+}
+
+// patternTestRequest is the body of POST /api/patterns/test
+type patternTestRequest struct {
+	// Patterns are the patterns to test, the built-in ones if empty
+	Patterns []infrav1alpha1.ErrorPattern `json:"patterns,omitempty"`
+	// Logs are pasted log lines
+	Logs string `json:"logs,omitempty"`
+}
+
+// patternTestResponse is the response of POST /api/patterns/test
+type patternTestResponse struct {
+	// Errors are the invalid patterns, which the pattern method skips
+	Errors []patternValidationError `json:"errors,omitempty"`
+	// Lines are the log lines with the pattern each matched, by priority
+	Lines []patternTestLine `json:"lines"`
+	// Result is what the pattern method would report, omitted without log lines
+	Result *infrav1alpha1.LogAnalysisResult `json:"result,omitempty"`
+}
+
+// patternValidationError is an invalid pattern
+type patternValidationError struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// patternTestLine is a log line tested against patterns
+type patternTestLine struct {
+	Line string `json:"line"`
+	// Pattern is the name of the pattern matching the line, empty if none does
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// validatePattern checks that a pattern has a name and compiles like the pattern
+// method compiles it
+func validatePattern(pattern infrav1alpha1.ErrorPattern) error {
+	if strings.TrimSpace(pattern.Name) == "" {
+		return errors.New("pattern name required")
+	}
+	if pattern.Pattern == "" {
+		return errors.New("regular expression required")
+	}
+	if _, err := regexp.Compile(pattern.Pattern); err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+	return nil
+}
+
+// handlePatterns manages the error patterns of a PodSleuth: GET and POST
+// /api/podsleuths/{name}/patterns list and add them, PUT and DELETE
+// /api/podsleuths/{name}/patterns/{pattern} replace and remove one. Changes update the
+// PodSleuth spec, which invalidates its cached analyses and reconciles it right away.
+func (s *Server) handlePatterns(w http.ResponseWriter, r *http.Request, podSleuth, pattern string) {
+	var err error
+	var list *patternList
+	status := http.StatusOK
+	switch {
+	case pattern == "" && r.Method == http.MethodGet:
+		list, err = s.listPatterns(r.Context(), podSleuth)
+	case pattern == "" && r.Method == http.MethodPost:
+		var created infrav1alpha1.ErrorPattern
+		if !decodePattern(w, r, &created) {
+			return
+		}
+		status = http.StatusCreated
+		list, err = s.updatePatterns(r.Context(), podSleuth, func(patterns *[]infrav1alpha1.ErrorPattern) error {
+			if slices.ContainsFunc(*patterns, func(p infrav1alpha1.ErrorPattern) bool { return p.Name == created.Name }) {
+				return errPatternExists
+			}
+			*patterns = append(*patterns, created)
+			return nil
+		})
+		if err == nil {
+			log.Log.Info("pattern created", "podSleuth", podSleuth, "pattern", created.Name)
+		}
+	case pattern != "" && r.Method == http.MethodPut:
+		var replaced infrav1alpha1.ErrorPattern
+		if !decodePattern(w, r, &replaced) {
+			return
+		}
+		list, err = s.updatePatterns(r.Context(), podSleuth, func(patterns *[]infrav1alpha1.ErrorPattern) error {
+			i := slices.IndexFunc(*patterns, func(p infrav1alpha1.ErrorPattern) bool { return p.Name == pattern })
+			if i < 0 {
+				return errPatternNotFound
+			}
+			if replaced.Name != pattern && slices.ContainsFunc(*patterns, func(p infrav1alpha1.ErrorPattern) bool { return p.Name == replaced.Name }) {
+				return errPatternExists
+			}
+			(*patterns)[i] = replaced
+			return nil
+		})
+		if err == nil {
+			log.Log.Info("pattern updated", "podSleuth", podSleuth, "pattern", pattern, "name", replaced.Name)
+		}
+	case pattern != "" && r.Method == http.MethodDelete:
+		list, err = s.updatePatterns(r.Context(), podSleuth, func(patterns *[]infrav1alpha1.ErrorPattern) error {
+			i := slices.IndexFunc(*patterns, func(p infrav1alpha1.ErrorPattern) bool { return p.Name == pattern })
+			if i < 0 {
+				return errPatternNotFound
+			}
+			*patterns = slices.Delete(*patterns, i, i+1)
+			return nil
+		})
+		if err == nil {
+			log.Log.Info("pattern deleted", "podSleuth", podSleuth, "pattern", pattern)
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		http.Error(w, fmt.Sprintf("PodSleuth %s not found", podSleuth), http.StatusNotFound)
+	case errors.Is(err, errPatternNotFound):
+		http.Error(w, fmt.Sprintf("Pattern %s not found", pattern), http.StatusNotFound)
+	case errors.Is(err, errPatternExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, errPatternsUnavailable):
+		http.Error(w, fmt.Sprintf("PodSleuth %s %v", podSleuth, err), http.StatusConflict)
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Error updating PodSleuth: %v", err), http.StatusInternalServerError)
+	case r.Method == http.MethodGet:
+		writeJSON(w, r, list)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(list)
+	}
+}
+
+// decodePattern reads and validates the pattern of a request, answering invalid ones
+func decodePattern(w http.ResponseWriter, r *http.Request, pattern *infrav1alpha1.ErrorPattern) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPatternBytes)).Decode(pattern); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Pattern too large", http.StatusRequestEntityTooLarge)
+			return false
+		}
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return false
+	}
+	pattern.Name = strings.TrimSpace(pattern.Name)
+	if err := validatePattern(*pattern); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// listPatterns returns the patterns a PodSleuth matches
+func (s *Server) listPatterns(ctx context.Context, name string) (*patternList, error) {
+	var podSleuth infrav1alpha1.PodSleuth
+	if err := s.client.Get(ctx, client.ObjectKey{Name: name}, &podSleuth); err != nil {
+		return nil, err
+	}
+	patterns := controller.PatternsOf(podSleuth.Spec.LogAnalysis)
+	if patterns == nil {
+		return nil, errPatternsUnavailable
+	}
+	return patternListOf(name, *patterns), nil
+}
+
+// updatePatterns changes the patterns of a PodSleuth with update, retrying on conflicts
+func (s *Server) updatePatterns(ctx context.Context, name string, update func(*[]infrav1alpha1.ErrorPattern) error) (*patternList, error) {
+	var list *patternList
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var podSleuth infrav1alpha1.PodSleuth
+		if err := s.client.Get(ctx, client.ObjectKey{Name: name}, &podSleuth); err != nil {
+			return err
+		}
+		patterns := controller.PatternsOf(podSleuth.Spec.LogAnalysis)
+		if patterns == nil {
+			return errPatternsUnavailable
+		}
+		if err := update(patterns); err != nil {
+			return err
+		}
+		if err := s.client.Update(ctx, &podSleuth); err != nil {
+			return err
+		}
+		list = patternListOf(name, *patterns)
+		return nil
+	})
+	return list, err
+}
+
+// patternListOf lists the patterns of a PodSleuth, or the built-in ones if it has none
+func patternListOf(podSleuth string, patterns []infrav1alpha1.ErrorPattern) *patternList {
+	if len(patterns) == 0 {
+		return &patternList{PodSleuth: podSleuth, Defaults: true, Patterns: controller.DefaultPatterns()}
+	}
+	return &patternList{PodSleuth: podSleuth, Patterns: patterns}
+}
+
+// handleTestPatterns tests patterns against pasted log lines: POST /api/patterns/test.
+// It also validates patterns without log lines, for editors checking them as typed.
+func (s *Server) handleTestPatterns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var reqBody patternTestRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPatternTestBytes)).Decode(&reqBody); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("At most %d MiB of log lines can be tested", maxPatternTestBytes>>20), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	var logLines []string
+	for _, line := range strings.Split(strings.ReplaceAll(reqBody.Logs, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			logLines = append(logLines, line)
+		}
+	}
+	if len(logLines) > maxPatternTestLines {
+		http.Error(w, fmt.Sprintf("At most %d log lines can be tested", maxPatternTestLines), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	response := patternTestResponse{Lines: []patternTestLine{}}
+	patterns := reqBody.Patterns
+	if len(patterns) == 0 {
+		patterns = controller.DefaultPatterns()
+	}
+	type compiledPattern struct {
+		name     string
+		regex    *regexp.Regexp
+		priority int32
+	}
+	var compiled []compiledPattern
+	for _, pattern := range patterns {
+		if err := validatePattern(pattern); err != nil {
+			response.Errors = append(response.Errors, patternValidationError{Name: pattern.Name, Error: err.Error()})
+			continue
+		}
+		compiled = append(compiled, compiledPattern{pattern.Name, regexp.MustCompile(pattern.Pattern), pattern.Priority})
+	}
+	// Like the pattern method, fall back to the built-in patterns if none is valid
+	if len(compiled) == 0 {
+		for _, pattern := range controller.DefaultPatterns() {
+			compiled = append(compiled, compiledPattern{pattern.Name, regexp.MustCompile(pattern.Pattern), pattern.Priority})
+		}
+	}
+	// Lines match the pattern of highest priority, like in the pattern method
+	sort.SliceStable(compiled, func(i, j int) bool { return compiled[i].priority > compiled[j].priority })
+	for _, line := range logLines {
+		tested := patternTestLine{Line: line}
+		for _, pattern := range compiled {
+			if pattern.regex.MatchString(line) {
+				tested.Pattern = pattern.name
+				break
+			}
+		}
+		response.Lines = append(response.Lines, tested)
+	}
+
+	result, err := controller.MatchPatterns(reqBody.Patterns, logLines)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error matching patterns: %v", err), http.StatusInternalServerError)
+		return
+	}
+	response.Result = result
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+func TestHandleTestPatternsBodyLimit(t *testing.T) {
+	s := &Server{}
+	body, _ := json.Marshal(patternTestRequest{Logs: strings.Repeat("x", maxPatternTestBytes)})
+	recorder := httptest.NewRecorder()
+	s.handleTestPatterns(recorder, httptest.NewRequest(http.MethodPost, "/api/patterns/test", strings.NewReader(string(body))))
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body: got %d, want %d", recorder.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	body, _ = json.Marshal(patternTestRequest{Logs: "ERROR connection refused\nstarting server"})
+	recorder = httptest.NewRecorder()
+	s.handleTestPatterns(recorder, httptest.NewRequest(http.MethodPost, "/api/patterns/test", strings.NewReader(string(body))))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("small body: got %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+}
+
+func TestDecodePatternBodyLimit(t *testing.T) {
+	body, _ := json.Marshal(infrav1alpha1.ErrorPattern{Name: "huge", Pattern: strings.Repeat("a", maxPatternBytes)})
+	recorder := httptest.NewRecorder()
+	var pattern infrav1alpha1.ErrorPattern
+	if decodePattern(recorder, httptest.NewRequest(http.MethodPost, "/api/podsleuths/p/patterns", strings.NewReader(string(body))), &pattern) {
+		t.Fatal("oversized pattern decoded")
+	}
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got %d, want %d", recorder.Code, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -0,0 +1,539 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// podDetail is the response of GET /api/pods/{namespace}/{name}
+type podDetail struct {
+	// PodSleuth is the PodSleuth reporting the pod
+	PodSleuth string `json:"podSleuth"`
+	// Pod is the pod with everything known about it, including what the status leaves
+	// out for its report or to bound its size
+	Pod infrav1alpha1.NonReadyPodInfo `json:"pod"`
+
+	// redaction is the redaction configuration of the PodSleuth's log analysis
+	redaction *infrav1alpha1.RedactionConfig
+}
+
+const (
+	// defaultLogTailLines is how many lines of a log the log viewer shows by default
+	// and maxLogTailLines the most it shows
+	defaultLogTailLines = 500
+	maxLogTailLines     = 5000
+	// maxPodEvents is how many of the most recent events of a pod are returned
+	maxPodEvents = 50
+)
+
+// EnableLogViewer serves the logs of non-ready pods on /api/pods/{namespace}/{name}/logs,
+// fetched with k8sClient as fast as limiter allows, and their events on
+// /api/pods/{namespace}/{name}/events
+func (s *Server) EnableLogViewer(k8sClient kubernetes.Interface, limiter *controller.LogFetchLimiter) {
+	s.k8sClient = k8sClient
+	s.logFetchLimiter = limiter
+}
+
+// handleGetPod returns one non-ready pod in full: /api/pods/{namespace}/{name}. A pod
+// reported by several PodSleuths is returned as seen by the first, or by the one named
+// with ?podSleuth=. /api/pods/{namespace}/{name}/logs returns its logs,
+// /api/pods/{namespace}/{name}/events its events, and .../acknowledge acknowledges it.
+// .../analyze analyzes any pod on demand; see handleAnalyzePod.
+func (s *Server) handleGetPod(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path[len("/api/pods/"):], "/"), "/")
+	subresource := ""
+	if len(parts) == 3 && (parts[2] == "logs" || parts[2] == "events" || parts[2] == "acknowledge" || parts[2] == "analyze") {
+		subresource, parts = parts[2], parts[:2]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "Expected /api/pods/{namespace}/{name}[/logs|/events|/acknowledge|/analyze]", http.StatusBadRequest)
+		return
+	}
+	namespace, name := parts[0], parts[1]
+	switch subresource {
+	case "acknowledge":
+		s.handleAcknowledge(w, r, namespace, name)
+		return
+	case "analyze":
+		// Any pod may be analyzed, not only the reported ones
+		s.handleAnalyzePod(w, r, namespace, name)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	detail, err := s.findReportedPod(r.Context(), namespace, name, r.URL.Query().Get("podSleuth"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if detail == nil {
+		http.Error(w, fmt.Sprintf("Pod %s/%s is not reported as non-ready", namespace, name), http.StatusNotFound)
+		return
+	}
+
+	switch subresource {
+	case "logs":
+		s.handlePodLogs(w, r, detail)
+		return
+	case "events":
+		s.handlePodEvents(w, r, detail)
+		return
+	}
+
+	s.completePodDetail(r.Context(), detail)
+
+	writeJSON(w, r, detail)
+}
+
+// findReportedPod returns a pod as reported by the first PodSleuth, or by podSleuthName,
+// nil if no PodSleuth reports it
+func (s *Server) findReportedPod(ctx context.Context, namespace, name, podSleuthName string) (*podDetail, error) {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := s.client.List(ctx, &podSleuthList); err != nil {
+		return nil, err
+	}
+	for _, podSleuth := range podSleuthList.Items {
+		if podSleuthName != "" && podSleuth.Name != podSleuthName {
+			continue
+		}
+		for _, pod := range podSleuth.Status.NonReadyPods {
+			if pod.Namespace == namespace && pod.Name == name {
+				detail := &podDetail{PodSleuth: podSleuth.Name, Pod: pod}
+				if podSleuth.Spec.LogAnalysis != nil {
+					detail.redaction = podSleuth.Spec.LogAnalysis.Redaction
+				}
+				return detail, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// podLogs is the response of GET /api/pods/{namespace}/{name}/logs
+type podLogs struct {
+	Namespace  string   `json:"namespace"`
+	Pod        string   `json:"pod"`
+	Container  string   `json:"container"`
+	Containers []string `json:"containers"`
+	Previous   bool     `json:"previous"`
+	*controller.ContainerLog
+}
+
+// handlePodLogs returns the log of a container of a non-ready pod:
+// ?container= (default: the blocking init container or the first failing container), ?tail= lines (default 500) and
+// ?previous=true for the run before the last restart. Only pods reported by a PodSleuth
+// are served, so the dashboard does not expose the logs of every pod in the cluster, and
+// only to authenticated users. Lines are redacted like those log analysis reads.
+func (s *Server) handlePodLogs(w http.ResponseWriter, r *http.Request, detail *podDetail) {
+	if !s.auth.Enabled() {
+		http.Error(w, "Log viewer requires dashboard authentication", http.StatusForbidden)
+		return
+	}
+	if s.k8sClient == nil {
+		http.Error(w, "Log viewer not available", http.StatusServiceUnavailable)
+		return
+	}
+	query := r.URL.Query()
+
+	var pod corev1.Pod
+	if err := s.client.Get(r.Context(), client.ObjectKey{Namespace: detail.Pod.Namespace, Name: detail.Pod.Name}, &pod); err != nil {
+		http.Error(w, fmt.Sprintf("Error getting pod: %v", err), http.StatusNotFound)
+		return
+	}
+	var containers []string
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+
+	container := query.Get("container")
+	if container == "" {
+		if analysis := detail.Pod.LogAnalysis; analysis != nil && analysis.InitContainerResult != nil {
+			container = analysis.InitContainerResult.Container
+		} else if len(detail.Pod.ContainerErrors) > 0 {
+			container = detail.Pod.ContainerErrors[0].ContainerName
+		} else if len(pod.Spec.Containers) > 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+	}
+	if !slices.Contains(containers, container) {
+		http.Error(w, fmt.Sprintf("Pod %s/%s has no container %q", pod.Namespace, pod.Name, container), http.StatusBadRequest)
+		return
+	}
+
+	tailLines := int64(defaultLogTailLines)
+	if tail := query.Get("tail"); tail != "" {
+		parsed, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil || parsed < 1 {
+			http.Error(w, "tail must be a positive number of lines", http.StatusBadRequest)
+			return
+		}
+		tailLines = min(parsed, maxLogTailLines)
+	}
+	previous := query.Get("previous") == "true"
+
+	if err := s.logFetchLimiter.Wait(r.Context(), pod.Spec.NodeName); err != nil {
+		http.Error(w, "Log fetch rate limit wait aborted", http.StatusServiceUnavailable)
+		return
+	}
+	containerLog, err := controller.FetchContainerLog(r.Context(), s.k8sClient, pod.Namespace, pod.Name, container, tailLines, previous, detail.redaction)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting logs: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, r, podLogs{
+		Namespace:    pod.Namespace,
+		Pod:          pod.Name,
+		Container:    container,
+		Containers:   containers,
+		Previous:     previous,
+		ContainerLog: containerLog,
+	})
+}
+
+// completePodDetail fills in what the status left out of a pod: the error lines,
+// terminations and debug checks of its report, or else the error lines of its cached
+// analysis
+func (s *Server) completePodDetail(ctx context.Context, detail *podDetail) {
+	if detail.Pod.Report != "" {
+		var report infrav1alpha1.PodSleuthReport
+		err := s.client.Get(ctx, client.ObjectKey{Namespace: detail.Pod.Namespace, Name: detail.Pod.Report}, &report)
+		if err == nil {
+			// The report's copy of the pod does not name the report
+			report.Spec.Pod.Report = detail.Pod.Report
+			detail.Pod = report.Spec.Pod
+			return
+		}
+		// The report may have been deleted with a recovered pod; use the status instead
+		log.Log.WithName("web").V(1).Info("unable to get pod report", "namespace", detail.Pod.Namespace,
+			"report", detail.Pod.Report, "error", err)
+	}
+
+	analysis := detail.Pod.LogAnalysis
+	if analysis == nil || analysis.ErrorLinesOmitted == 0 || s.cache == nil {
+		return
+	}
+	for _, cached := range s.cache.PodAnalyses(detail.Pod.Namespace, detail.Pod.Name) {
+		if cached.PodSleuth == detail.PodSleuth && cached.Result.AnalyzedAt.Equal(&analysis.AnalyzedAt) &&
+			cached.Result.ErrorLinesOmitted < analysis.ErrorLinesOmitted {
+			detail.Pod.LogAnalysis = cached.Result
+			return
+		}
+	}
+}
+
+// podEvent is a Kubernetes event of a pod
+type podEvent struct {
+	Type      string      `json:"type"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Count     int32       `json:"count"`
+	Source    string      `json:"source,omitempty"`
+	FirstSeen metav1.Time `json:"firstSeen"`
+	LastSeen  metav1.Time `json:"lastSeen"`
+}
+
+// podEvents is the response of GET /api/pods/{namespace}/{name}/events
+type podEvents struct {
+	Namespace string     `json:"namespace"`
+	Pod       string     `json:"pod"`
+	Events    []podEvent `json:"events"`
+}
+
+// handlePodEvents returns the most recent events of a non-ready pod, newest first.
+// Events often explain scheduling and volume mount failures better than its statuses.
+func (s *Server) handlePodEvents(w http.ResponseWriter, r *http.Request, detail *podDetail) {
+	if s.k8sClient == nil {
+		http.Error(w, "Pod events not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Events are read from the API server rather than cached, since the operator does
+	// not watch events
+	selector := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": detail.Pod.Name,
+	}.AsSelector().String()
+	eventList, err := s.k8sClient.CoreV1().Events(detail.Pod.Namespace).List(r.Context(), metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing events: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	events := make([]podEvent, 0, len(eventList.Items))
+	for _, event := range eventList.Items {
+		events = append(events, newPodEvent(&event))
+	}
+	slices.SortFunc(events, func(a, b podEvent) int {
+		return b.LastSeen.Compare(a.LastSeen.Time)
+	})
+	if len(events) > maxPodEvents {
+		events = events[:maxPodEvents]
+	}
+
+	writeJSON(w, r, podEvents{
+		Namespace: detail.Pod.Namespace,
+		Pod:       detail.Pod.Name,
+		Events:    events,
+	})
+}
+
+// newPodEvent summarizes an event, whether recorded by the legacy or the events.k8s.io API
+func newPodEvent(event *corev1.Event) podEvent {
+	summary := podEvent{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Count:     max(event.Count, 1),
+		Source:    event.Source.Component,
+		FirstSeen: event.FirstTimestamp,
+		LastSeen:  event.LastTimestamp,
+	}
+	if summary.Source == "" {
+		summary.Source = event.ReportingController
+	}
+	if summary.FirstSeen.IsZero() {
+		summary.FirstSeen = metav1.NewTime(event.EventTime.Time)
+	}
+	if event.Series != nil {
+		summary.Count = max(event.Series.Count, 1)
+		summary.LastSeen = metav1.NewTime(event.Series.LastObservedTime.Time)
+	}
+	if summary.LastSeen.IsZero() {
+		summary.LastSeen = summary.FirstSeen
+	}
+	return summary
+}
+
+const (
+	// defaultPodListLimit is the page size of /api/pods and maxPodListLimit the largest allowed
+	defaultPodListLimit = 100
+	maxPodListLimit     = 1000
+)
+
+// severityRanks orders severities for sorting, most severe first
+var severityRanks = map[string]int{"critical": 0, "warning": 1, "info": 2}
+
+// podListItem is a non-ready pod listed by /api/pods
+type podListItem struct {
+	PodSleuth string `json:"podSleuth"`
+	Severity  string `json:"severity"`
+	infrav1alpha1.NonReadyPodInfo
+}
+
+// podList is the response of GET /api/pods
+type podList struct {
+	Items []podListItem `json:"items"`
+	// Total is the number of pods matching the filters, on all pages
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	// Next is the offset of the next page, omitted on the last page
+	Next *int `json:"next,omitempty"`
+}
+
+// handleListPods returns the non-ready pods of all PodSleuths one page at a time, so
+// clients need not download every PodSleuth. Filters: ?namespace=, ?phase=, ?reason=
+// (of the pod or a container), ?owner= (name or kind/name), ?team=, ?podSleuth=,
+// ?severity=, ?cluster= in hub mode and ?q= searching names, reasons, messages and root causes. ?sort= is
+// name (default), namespace, duration (longest first) or severity (most severe
+// first), reversed with a leading "-". ?limit= (default 100, at most 1000) and ?offset=
+// select the page.
+func (s *Server) handleListPods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	list, err := s.listPods(r.Context(), r.URL.Query())
+	if errors.Is(err, errInvalidPodListQuery) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, r, list)
+}
+
+// errInvalidPodListQuery is returned for pod list parameters out of range
+var errInvalidPodListQuery = errors.New("invalid pod list query")
+
+// listPods returns the page of non-ready pods selected by the parameters of /api/pods
+func (s *Server) listPods(ctx context.Context, query url.Values) (*podList, error) {
+	limit, err := queryInt(query.Get("limit"), defaultPodListLimit)
+	if err != nil || limit < 1 {
+		return nil, fmt.Errorf("%w: limit must be a positive number", errInvalidPodListQuery)
+	}
+	limit = min(limit, maxPodListLimit)
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", errInvalidPodListQuery)
+	}
+	sortKey := query.Get("sort")
+	descending := strings.HasPrefix(sortKey, "-")
+	compare, known := podListOrders[strings.TrimPrefix(sortKey, "-")]
+	if !known {
+		return nil, fmt.Errorf("%w: sort must be name, namespace, duration, age or severity, optionally prefixed with -", errInvalidPodListQuery)
+	}
+
+	podSleuthList, _, err := s.listPodSleuths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []podListItem{}
+	for _, podSleuth := range podSleuthList.Items {
+		cluster := s.clusterOf(&podSleuth)
+		for i := range podSleuth.Status.NonReadyPods {
+			pod := &podSleuth.Status.NonReadyPods[i]
+			item := podListItem{PodSleuth: podSleuth.Name, Severity: controller.PodSeverity(pod), NonReadyPodInfo: *pod}
+			item.Cluster = cluster
+			if matchesPodFilters(&item, query) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b podListItem) int {
+		order := compare(&a, &b)
+		if order == 0 {
+			order = strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+		}
+		if descending {
+			return -order
+		}
+		return order
+	})
+
+	list := podList{Total: len(items), Offset: offset, Limit: limit}
+	end := min(offset+limit, len(items))
+	if offset < end {
+		list.Items = items[offset:end]
+	} else {
+		list.Items = []podListItem{}
+	}
+	if end < len(items) {
+		list.Next = &end
+	}
+	return &list, nil
+}
+
+// podListOrders compare pods by the sort keys of /api/pods
+var podListOrders = map[string]func(a, b *podListItem) int{
+	"":     func(a, b *podListItem) int { return strings.Compare(a.Name, b.Name) },
+	"name": func(a, b *podListItem) int { return strings.Compare(a.Name, b.Name) },
+	"namespace": func(a, b *podListItem) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	},
+	"duration": func(a, b *podListItem) int {
+		// Pods detected first have been non-ready longest
+		return compareOldestFirst(a.DetectedAt, b.DetectedAt)
+	},
+	"age": func(a, b *podListItem) int {
+		return compareOldestFirst(a.CreatedAt, b.CreatedAt)
+	},
+	"severity": func(a, b *podListItem) int {
+		return severityRanks[a.Severity] - severityRanks[b.Severity]
+	},
+}
+
+// compareOldestFirst orders earlier times first and missing times last
+func compareOldestFirst(a, b *metav1.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(b.Time)
+}
+
+// matchesPodFilters reports whether a pod matches the filter parameters of /api/pods
+func matchesPodFilters(item *podListItem, query url.Values) bool {
+	if cluster := query.Get("cluster"); cluster != "" && item.Cluster != cluster {
+		return false
+	}
+	if namespace := query.Get("namespace"); namespace != "" && item.Namespace != namespace {
+		return false
+	}
+	if phase := query.Get("phase"); phase != "" && item.Phase != phase {
+		return false
+	}
+	if team := query.Get("team"); team != "" && item.Team != team {
+		return false
+	}
+	if podSleuth := query.Get("podSleuth"); podSleuth != "" && item.PodSleuth != podSleuth {
+		return false
+	}
+	if severity := query.Get("severity"); severity != "" && item.Severity != severity {
+		return false
+	}
+	if owner := query.Get("owner"); owner != "" && item.OwnerName != owner && item.OwnerKind+"/"+item.OwnerName != owner {
+		return false
+	}
+	if reason := query.Get("reason"); reason != "" && item.Reason != reason &&
+		!slices.ContainsFunc(item.ContainerErrors, func(ce infrav1alpha1.ContainerError) bool { return ce.Reason == reason }) {
+		return false
+	}
+	if text := strings.ToLower(query.Get("q")); text != "" {
+		searched := []string{item.Name, item.Namespace, item.OwnerName, item.Reason, item.Message}
+		if item.LogAnalysis != nil {
+			searched = append(searched, item.LogAnalysis.RootCause)
+		}
+		if !slices.ContainsFunc(searched, func(value string) bool { return strings.Contains(strings.ToLower(value), text) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// queryInt parses an integer query parameter, returning def if it is empty
+func queryInt(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// corsMaxAge is how long browsers may cache the answer to a CORS preflight request, in
+// seconds
+const corsMaxAge = "600"
+
+// SetBasePath serves the dashboard under a URL path prefix such as /kubesleuth, for
+// ingresses routing a path of a shared host to it. Requests without the prefix are
+// served too, for ingresses that strip it.
+func (s *Server) SetBasePath(basePath string) error {
+	basePath = strings.TrimSuffix(strings.TrimSpace(basePath), "/")
+	if basePath != "" && (!strings.HasPrefix(basePath, "/") || strings.ContainsAny(basePath, "?#") || strings.Contains(basePath, "//")) {
+		return fmt.Errorf("invalid base path %q: expected a URL path such as /kubesleuth", basePath)
+	}
+	s.basePath = basePath
+	return nil
+}
+
+// EnableCORS lets web pages of other origins, such as internal portals embedding
+// dashboard data, call the API. Origins are matched exactly, like
+// https://portal.example.com; "*" allows any origin, but without browser credentials.
+func (s *Server) EnableCORS(origins []string) {
+	s.corsOrigins = origins
+}
+
+// withBasePath strips the base path from requests, and sends requests of the base path
+// itself to the dashboard
+func (s *Server) withBasePath(next http.Handler) http.Handler {
+	if s.basePath == "" {
+		return next
+	}
+	stripped := http.StripPrefix(s.basePath, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == s.basePath:
+			http.Redirect(w, r, s.basePath+"/", http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, s.basePath+"/"):
+			stripped.ServeHTTP(w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// cors answers CORS preflight requests for the API and allows the listed origins to
+// read API responses. Preflight requests carry no credentials, so they are answered
+// before authentication.
+func (s *Server) cors(next http.Handler) http.Handler {
+	if len(s.corsOrigins) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		switch {
+		case slices.Contains(s.corsOrigins, origin):
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		case slices.Contains(s.corsOrigins, "*"):
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		default:
+			// Browsers keep the response from the page
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-None-Match")
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// errRemediationNotPending is returned when the ID is not a pending remediation
+var errRemediationNotPending = errors.New("remediation not pending")
+
+// remediationDecisionRequest is the optional body of an approve or reject request
+type remediationDecisionRequest struct {
+	Actor string `json:"actor"`
+}
+
+// remediationDecision is the response of an approve or reject request
+type remediationDecision struct {
+	Success bool   `json:"success"`
+	ID      string `json:"id"`
+	// Decision is "approve" or "reject"
+	Decision string `json:"decision"`
+}
+
+// EnableRemediationApprovals lets the dashboard approve and reject pending remediations
+// for requests that send the token as a bearer token
+func (s *Server) EnableRemediationApprovals(token string) {
+	s.approvalToken = token
+}
+
+// handleRemediation approves or rejects a pending remediation:
+// POST /api/remediations/{podsleuth}/{id}/{approve|reject}
+func (s *Server) handleRemediation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.approvalToken == "" {
+		http.Error(w, "Dashboard approvals are disabled; annotate the PodSleuth with "+controller.AnnotationApproveRemediation+" instead", http.StatusForbidden)
+		return
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.approvalToken)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path[len("/api/remediations/"):], "/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || (parts[2] != "approve" && parts[2] != "reject") {
+		http.Error(w, "Expected /api/remediations/{podsleuth}/{id}/{approve|reject}", http.StatusBadRequest)
+		return
+	}
+	name, id, decision := parts[0], parts[1], parts[2]
+	annotation := controller.AnnotationApproveRemediation
+	if decision == "reject" {
+		annotation = controller.AnnotationRejectRemediation
+	}
+
+	var reqBody remediationDecisionRequest
+	_ = json.NewDecoder(r.Body).Decode(&reqBody) // best-effort; the actor is optional
+	actor := "dashboard"
+	if a := strings.TrimSpace(reqBody.Actor); a != "" {
+		actor = "dashboard:" + a
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var podSleuth infrav1alpha1.PodSleuth
+		if err := s.client.Get(r.Context(), client.ObjectKey{Name: name}, &podSleuth); err != nil {
+			return err
+		}
+		pending := false
+		for _, action := range podSleuth.Status.PendingRemediations {
+			if action.ID == id {
+				pending = true
+				break
+			}
+		}
+		if !pending {
+			return errRemediationNotPending
+		}
+		if podSleuth.Annotations == nil {
+			podSleuth.Annotations = make(map[string]string)
+		}
+		ids := podSleuth.Annotations[annotation]
+		if ids != "" {
+			ids += ","
+		}
+		podSleuth.Annotations[annotation] = ids + id
+		podSleuth.Annotations[controller.AnnotationRemediationActor] = actor
+		return s.client.Update(r.Context(), &podSleuth)
+	})
+	switch {
+	case errors.Is(err, errRemediationNotPending) || apierrors.IsNotFound(err):
+		http.Error(w, "Pending remediation not found", http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Error updating PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	log.Log.Info("remediation decision", "podSleuth", name, "id", id, "decision", decision, "actor", actor)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(remediationDecision{
+		Success:  true,
+		ID:       id,
+		Decision: decision,
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// minGzipBytes is the smallest response worth compressing
+const minGzipBytes = 1024
+
+// gzipWriters reuses gzip writers, which are costly to allocate for every response
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// writeJSON writes v as the JSON response of a request. Responses to GET requests carry
+// an ETag of their content, and requests already holding it are answered with 304 Not
+// Modified. Dashboards polling every few seconds then only download what changed.
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	writeVersionedJSON(w, r, "", v)
+}
+
+// writeVersionedJSON is writeJSON for responses built only from the objects version
+// identifies, such as their resourceVersions: requests holding its ETag are answered
+// without encoding v.
+func writeVersionedJSON(w http.ResponseWriter, r *http.Request, version string, v interface{}) {
+	cacheable := r.Method == http.MethodGet || r.Method == http.MethodHead
+	if cacheable && version != "" {
+		// Filters such as ?team= change the response of the same objects
+		if writeNotModified(w, r, etag("v:"+version+"?"+r.URL.RawQuery)) {
+			return
+		}
+	}
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(v); err != nil {
+		http.Error(w, fmt.Sprintf("Error encoding response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if cacheable && version == "" {
+		if writeNotModified(w, r, etag("c:"+body.String())) {
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	writeBody(w, r, body.Bytes())
+}
+
+// writeNotModified sets the ETag of the response and answers with 304 Not Modified if
+// the request's If-None-Match holds it. Browsers revalidate cached responses on every
+// use, and shared caches keep none since responses depend on the user.
+func writeNotModified(w http.ResponseWriter, r *http.Request, tag string) bool {
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !etagMatches(r.Header.Get("If-None-Match"), tag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// writeBody writes a response body with its Content-Length, gzipped for clients
+// accepting it
+func writeBody(w http.ResponseWriter, r *http.Request, body []byte) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if len(body) >= minGzipBytes && acceptsGzip(r) {
+		var compressed bytes.Buffer
+		writer := gzipWriters.Get().(*gzip.Writer)
+		writer.Reset(&compressed)
+		_, err := writer.Write(body)
+		if err == nil {
+			err = writer.Close()
+		}
+		gzipWriters.Put(writer)
+		if err == nil {
+			w.Header().Set("Content-Encoding", "gzip")
+			body = compressed.Bytes()
+		}
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(body)
+}
+
+// etag returns a weak ETag of key. Weak tags stay valid for the same content encoded
+// differently, gzipped or not.
+func etag(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header holds tag, compared weakly
+func etagMatches(ifNoneMatch, tag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsGzip reports whether the client accepts gzipped responses
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(encoding, ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		// gzip;q=0 refuses gzip
+		if q, found := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); found {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// listVersion identifies the items of a list by their names and resourceVersions, which
+// change whenever the items do
+func listVersion(list runtime.Object) string {
+	var version strings.Builder
+	_ = meta.EachListItem(list, func(item runtime.Object) error {
+		if object, ok := item.(metav1.Object); ok {
+			fmt.Fprintf(&version, "%s/%s@%s;", object.GetNamespace(), object.GetName(), object.GetResourceVersion())
+		}
+		return nil
+	})
+	return version.String()
+}
+
+// objectVersion identifies an object by its name and resourceVersion
+func objectVersion(object metav1.Object) string {
+	return object.GetNamespace() + "/" + object.GetName() + "@" + object.GetResourceVersion()
+}
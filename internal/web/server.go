@@ -18,53 +18,203 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/hub"
 )
 
+// CacheAdmin gives the dashboard access to the operator's analysis cache
+type CacheAdmin interface {
+	CacheEntries() []controller.CacheEntryInfo
+	PodAnalyses(namespace, name string) []controller.PodAnalysis
+	InvalidateCache(namespace, name string) int
+}
+
 // Server handles web dashboard requests
 type Server struct {
 	client client.Client
 	port   string
+	cache  CacheAdmin
+	// approvalToken authorizes remediation approvals (empty = approvals disabled)
+	approvalToken string
+	// sharding is the shard of the operator replica serving the dashboard
+	sharding controller.Sharding
+	// informers streams PodSleuth changes to live dashboards (nil = live updates disabled)
+	informers cache.Informers
+	live      liveUpdates
+	// k8sClient fetches pod logs for the log viewer (nil = log viewer disabled)
+	k8sClient       kubernetes.Interface
+	logFetchLimiter *controller.LogFetchLimiter
+	// history holds the sampled non-ready pod counts of /api/history and stats trends
+	history         history
+	historySettings historySettings
+	// refreshInterval is how often dashboards poll by default when live updates are down
+	refreshInterval time.Duration
+	// locale is the dashboard language of users who have not picked one
+	locale string
+	// basePath is the URL path prefix the dashboard is served under (empty = root)
+	basePath string
+	// corsOrigins are the origins allowed to call the API from browsers (empty = none)
+	corsOrigins []string
+	// changeLimiters limits the rate of changes of each client (nil = unlimited)
+	changeLimiters *clientLimiters
+	// analyses are the analysis jobs of /api/analyses
+	analyses analysisJobs
+	// analyzer analyzes pods on demand (nil = on-demand analysis disabled)
+	analyzer PodAnalyzer
+	// deployHookToken authorizes deploy hooks (empty = deploy hooks disabled)
+	deployHookToken string
+	workloadReader  client.Reader
+	deployRecorder  record.EventRecorder
+	deployNotifier  DeployNotifier
+	// cacheSync and apiServer are checked by /readyz (nil = not checked)
+	cacheSync cache.Informers
+	apiServer rest.Interface
+	// grpcAddress is where the gRPC API is served (empty = disabled)
+	grpcAddress string
+	// tlsCertFile and tlsKeyFile serve the dashboard over HTTPS (empty = HTTP)
+	tlsCertFile, tlsKeyFile string
+	// hub holds the findings pushed by the agents of other clusters, authenticated with
+	// the token of their cluster in hubTokens (nil = hub mode disabled)
+	hub       *hub.Store
+	hubTokens map[string]string
+	// auth authenticates dashboard and API requests (nil = open dashboard)
+	auth *AuthConfig
+	// oidc is the discovered OIDC provider, filled on first login
+	oidc    *oidcProvider
+	oidcMux sync.Mutex
 }
 
 // NewServer creates a new web server
-func NewServer(client client.Client, port string) *Server {
+func NewServer(client client.Client, port string, cache CacheAdmin) *Server {
 	return &Server{
 		client: client,
 		port:   port,
+		cache:  cache,
 	}
 }
 
-// Start starts the HTTP server
+// EnableTLS serves the dashboard over HTTPS with the certificate and key in the given
+// files, reloaded when they change
+func (s *Server) EnableTLS(certFile, keyFile string) {
+	s.tlsCertFile, s.tlsKeyFile = certFile, keyFile
+}
+
+// Start serves the dashboard until ctx is done, then waits for in-flight requests. The
+// server is a manager Runnable; see NeedLeaderElection.
 func (s *Server) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 
 	// Dashboard HTML
 	mux.HandleFunc("/", s.handleDashboard)
+	mux.HandleFunc("/static/", s.handleStatic)
 
 	// API endpoints
 	mux.HandleFunc("/api/podsleuths", s.handleListPodSleuths)
 	mux.HandleFunc("/api/podsleuths/", s.handleGetPodSleuth)
+	mux.HandleFunc("/api/pods", s.handleListPods)
+	mux.HandleFunc("/api/pods/", s.handleGetPod)
+	mux.HandleFunc("/api/reports/", s.handleGetReport)
+	mux.HandleFunc("/api/stats", s.handleStats)
+	mux.HandleFunc("/api/history", s.handleHistory)
+	mux.HandleFunc("/api/shard", s.handleShard)
 	mux.HandleFunc("/api/force-refresh", s.handleForceRefresh) // Restored for manual analysis trigger
+	mux.HandleFunc("/api/analyses", s.handleAnalyses)
+	mux.HandleFunc("/api/patterns/test", s.handleTestPatterns)
+	mux.HandleFunc("/api/hooks/deploy", s.handleDeployHook)
+	mux.HandleFunc("/api/analyses/", s.handleAnalysis)
+	mux.HandleFunc("/api/cache", s.handleCache)
+	mux.HandleFunc("/api/cache/", s.handleCachePod)
+	mux.HandleFunc("/api/silences", s.handleSilences)
+	mux.HandleFunc("/api/silences/", s.handleSilence)
+	mux.HandleFunc("/api/remediations/", s.handleRemediation)
+	mux.HandleFunc("/api/events", s.handleEvents)
+	mux.HandleFunc("/api/audit/ai-requests", s.handleAIRequests)
+	mux.HandleFunc("/api/hub/findings", s.handleHubFindings)
+	mux.HandleFunc("/api/clusters", s.handleClusters)
+	mux.HandleFunc("/api/slo", s.handleSLO)
+	mux.HandleFunc("/api/whoami", s.handleWhoami)
+	mux.HandleFunc("/api/openapi.json", s.handleOpenAPI)
+
+	// Probe endpoints, served without authentication
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+
+	// Login endpoints
+	mux.HandleFunc("/auth/login", s.handleLogin)
+	mux.HandleFunc("/auth/callback", s.handleCallback)
+	mux.HandleFunc("/auth/logout", s.handleLogout)
 
 	server := &http.Server{
 		Addr:    s.port,
-		Handler: mux,
+		Handler: s.withBasePath(s.cors(s.authenticate(s.auditChanges(mux)))),
+		// Live update streams end when the operator shuts down
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
 	logger := log.Log.WithName("web")
 	logger.Info("Starting dashboard server", "port", s.port)
+	if !s.auth.Enabled() {
+		logger.Info("WARNING: dashboard authentication is disabled, anyone reaching the dashboard can read and change PodSleuth state")
+	}
+
+	if s.informers != nil {
+		if err := s.watchPodSleuths(ctx); err != nil {
+			// Dashboards fall back to polling
+			logger.Error(err, "unable to watch PodSleuths for live updates")
+			s.informers = nil
+		}
+	}
 
+	go s.recordHistory(ctx)
+
+	// The HTTPS and gRPC servers share the certificate, and pick up renewed certificates
+	// without a restart
+	var tlsConfig *tls.Config
+	if s.tlsCertFile != "" {
+		certWatcher, err := certwatcher.New(s.tlsCertFile, s.tlsKeyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load dashboard certificate: %w", err)
+		}
+		go func() {
+			if err := certWatcher.Start(ctx); err != nil {
+				logger.Error(err, "dashboard certificate watcher failed")
+			}
+		}()
+		tlsConfig = &tls.Config{GetCertificate: certWatcher.GetCertificate, MinVersion: tls.VersionTLS12}
+	}
+
+	if s.grpcAddress != "" {
+		go func() {
+			if err := s.serveGRPC(ctx, tlsConfig); err != nil {
+				logger.Error(err, "gRPC server failed")
+			}
+		}()
+	}
+
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -73,50 +223,84 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	serve := server.ListenAndServe
+	if tlsConfig != nil {
+		server.TLSConfig = tlsConfig
+		serve = func() error { return server.ListenAndServeTLS("", "") }
+	}
+
+	if err := serve(); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("dashboard server error: %w", err)
 	}
 
+	// Requests read the manager's cache, which is stopped once this returns
+	<-shutdownDone
 	return nil
 }
 
-// handleDashboard serves the HTML dashboard
-func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
-	// Prevent browser caching - always serve fresh dashboard
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate, max-age=0")
-	w.Header().Set("Pragma", "no-cache")
-	w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	fmt.Fprint(w, dashboardHTML)
-}
-
 // handleListPodSleuths returns all PodSleuth resources as JSON
 func (s *Server) handleListPodSleuths(w http.ResponseWriter, r *http.Request) {
-	// Prevent browser caching for API calls
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate, max-age=0")
-	w.Header().Set("Pragma", "no-cache")
-	w.Header().Set("Expires", "0")
-	w.Header().Set("Content-Type", "application/json")
-
-	var podSleuthList infrav1alpha1.PodSleuthList
-	if err := s.client.List(r.Context(), &podSleuthList); err != nil {
+	// In hub mode the PodSleuths of other clusters follow those of this one
+	podSleuthList, version, err := s.listPodSleuths(r.Context())
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
 		return
 	}
+	// Dashboards poll the list, and mostly download it only when a PodSleuth changed
 
-	// Prevent caching of API responses
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(podSleuthList)
+	// Limit the result to one team's pods: ?team=payments
+	if team := r.URL.Query().Get("team"); team != "" {
+		for i := range podSleuthList.Items {
+			filterStatusByTeam(&podSleuthList.Items[i].Status, team)
+		}
+	}
+
+	writeVersionedJSON(w, r, version, podSleuthList)
 }
 
-// handleGetPodSleuth returns a specific PodSleuth resource as JSON
+// filterStatusByTeam keeps only the non-ready pods of a team and the workloads owning them
+func filterStatusByTeam(status *infrav1alpha1.PodSleuthStatus, team string) {
+	owners := make(map[string]bool)
+	pods := status.NonReadyPods[:0]
+	for _, pod := range status.NonReadyPods {
+		if pod.Team != team {
+			continue
+		}
+		pods = append(pods, pod)
+		owners[pod.Namespace+"/"+pod.OwnerKind+"/"+pod.OwnerName] = true
+	}
+	status.NonReadyPods = pods
+
+	workloads := status.Workloads[:0]
+	for _, workload := range status.Workloads {
+		if owners[workload.Namespace+"/"+workload.Kind+"/"+workload.Name] {
+			workloads = append(workloads, workload)
+		}
+	}
+	status.Workloads = workloads
+}
+
+// handleGetPodSleuth returns a specific PodSleuth resource as JSON. Its patterns are
+// served on /api/podsleuths/{name}/patterns; see handlePatterns.
 func (s *Server) handleGetPodSleuth(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Path[len("/api/podsleuths/"):]
 	if name == "" {
 		http.Error(w, "PodSleuth name required", http.StatusBadRequest)
 		return
 	}
+	if podSleuth, rest, found := strings.Cut(name, "/"); found {
+		parts := strings.Split(strings.Trim(rest, "/"), "/")
+		if podSleuth == "" || parts[0] != "patterns" || len(parts) > 2 {
+			http.Error(w, "Expected /api/podsleuths/{name}[/patterns[/{pattern}]]", http.StatusBadRequest)
+			return
+		}
+		pattern := ""
+		if len(parts) == 2 {
+			pattern = parts[1]
+		}
+		s.handlePatterns(w, r, podSleuth, pattern)
+		return
+	}
 
 	var podSleuth infrav1alpha1.PodSleuth
 	if err := s.client.Get(r.Context(), client.ObjectKey{Name: name}, &podSleuth); err != nil {
@@ -124,20 +308,118 @@ func (s *Server) handleGetPodSleuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Prevent caching of API responses
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(podSleuth)
+	writeVersionedJSON(w, r, objectVersion(&podSleuth), podSleuth)
+}
+
+// SetSharding tells the dashboard which shard the replica serving it runs
+func (s *Server) SetSharding(sharding controller.Sharding) {
+	s.sharding = sharding
+}
+
+// shardInfo is the response of GET /api/shard
+type shardInfo struct {
+	Shards int `json:"shards"`
+	Index  int `json:"index"`
+	// By is how PodSleuths are sharded, omitted without sharding
+	By string `json:"by,omitempty"`
+	// PodSleuths maps each PodSleuth to the shard running it, when sharding by PodSleuth
+	PodSleuths map[string]int `json:"podSleuths,omitempty"`
+}
+
+// handleShard describes the shard of the replica serving the dashboard. PodSleuth
+// statuses cover all shards, while the analysis cache only holds this shard's analyses.
+func (s *Server) handleShard(w http.ResponseWriter, r *http.Request) {
+	response := shardInfo{
+		Shards: max(s.sharding.Shards, 1),
+		Index:  s.sharding.Index,
+	}
+	if s.sharding.Enabled() {
+		response.By = s.sharding.By
+		if s.sharding.By == controller.ShardByPodSleuth {
+			var podSleuthList infrav1alpha1.PodSleuthList
+			if err := s.client.List(r.Context(), &podSleuthList); err != nil {
+				http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+				return
+			}
+			// The shard running each PodSleuth
+			podSleuths := make(map[string]int, len(podSleuthList.Items))
+			for i := range podSleuthList.Items {
+				podSleuths[podSleuthList.Items[i].Name] = s.sharding.PodSleuthShard(&podSleuthList.Items[i])
+			}
+			response.PodSleuths = podSleuths
+		}
+	}
+
+	writeJSON(w, r, response)
 }
 
-// handleForceRefresh forces cache refresh by adding annotation to PodSleuths
-// Accepts optional JSON body: {"podName":"...","podNamespace":"..."}
-// If provided, only that pod will bypass cache; otherwise all pods are refreshed.
+// handleGetReport returns a PodSleuthReport as JSON: /api/reports/{namespace}/{name}
+func (s *Server) handleGetReport(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path[len("/api/reports/"):], "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "Expected /api/reports/{namespace}/{name}", http.StatusBadRequest)
+		return
+	}
+
+	var report infrav1alpha1.PodSleuthReport
+	if err := s.client.Get(r.Context(), client.ObjectKey{Namespace: parts[0], Name: parts[1]}, &report); err != nil {
+		http.Error(w, fmt.Sprintf("Error getting PodSleuthReport: %v", err), http.StatusNotFound)
+		return
+	}
+
+	writeVersionedJSON(w, r, objectVersion(&report), report)
+}
+
+// forceRefreshRequest is the optional body of POST /api/force-refresh. Without pods, all
+// non-ready pods are analyzed again.
 type forceRefreshRequest struct {
-	PodName      string `json:"podName"`
-	PodNamespace string `json:"podNamespace"`
+	// PodSleuth limits the refresh to one PodSleuth
+	PodSleuth string `json:"podSleuth,omitempty"`
+	// Pods are the pods to analyze again
+	Pods []podReference `json:"pods,omitempty"`
+	// PodName and PodNamespace select a single pod, like Pods
+	PodName      string `json:"podName,omitempty"`
+	PodNamespace string `json:"podNamespace,omitempty"`
+}
+
+// podReference names a pod
+type podReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// forceRefreshResponse is the response of POST /api/force-refresh
+type forceRefreshResponse struct {
+	// Success is whether every PodSleuth was told to analyze again
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// Count is how many PodSleuths were annotated
+	Count int `json:"count"`
+	// TargetPod is the refreshed pod as namespace/name when a single pod is
+	TargetPod string `json:"targetPod"`
+	// Generation identifies the forced analyses: their results have a refreshGeneration
+	// of at least it once they finished
+	Generation int64 `json:"generation"`
+	// Results are the outcomes of the PodSleuths reporting the pods, or of all
+	// PodSleuths when all pods are refreshed
+	Results []forceRefreshResult `json:"results"`
+}
+
+// forceRefreshResult is the outcome of a forced analysis for one PodSleuth
+type forceRefreshResult struct {
+	PodSleuth string `json:"podSleuth"`
+	// Pods are the pods the PodSleuth analyzes again as namespace/name: all of its
+	// non-ready pods when no pods were given
+	Pods    []string `json:"pods,omitempty"`
+	Success bool     `json:"success"`
+	Error   string   `json:"error,omitempty"`
 }
 
+// errForceRefreshTargetNotFound is returned when no PodSleuth matches a forced analysis
+var errForceRefreshTargetNotFound = errors.New("not found")
+
+// handleForceRefresh makes PodSleuths analyze non-ready pods again, bypassing the
+// analysis cache
 func (s *Server) handleForceRefresh(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -146,47 +428,219 @@ func (s *Server) handleForceRefresh(w http.ResponseWriter, r *http.Request) {
 
 	var reqBody forceRefreshRequest
 	_ = json.NewDecoder(r.Body).Decode(&reqBody) // best-effort; ignore errors for empty body
-	targetPod := ""
-	if reqBody.PodName != "" && reqBody.PodNamespace != "" {
-		targetPod = fmt.Sprintf("%s/%s", strings.TrimSpace(reqBody.PodNamespace), strings.TrimSpace(reqBody.PodName))
+	pods, err := forceRefreshPods(reqBody)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	var podSleuthList infrav1alpha1.PodSleuthList
-	if err := s.client.List(r.Context(), &podSleuthList); err != nil {
+	response, err := s.forceRefresh(r.Context(), strings.TrimSpace(reqBody.PodSleuth), pods)
+	if errors.Is(err, errForceRefreshTargetNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	log.Log.Info("force-refresh request received", "targetPod", targetPod)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// forceRefreshPods returns the pods of a forced analysis request as namespace/name
+func forceRefreshPods(reqBody forceRefreshRequest) ([]string, error) {
+	var pods []string
+	if reqBody.PodName != "" && reqBody.PodNamespace != "" {
+		reqBody.Pods = append(reqBody.Pods, podReference{Namespace: reqBody.PodNamespace, Name: reqBody.PodName})
+	}
+	for _, pod := range reqBody.Pods {
+		namespace, name := strings.TrimSpace(pod.Namespace), strings.TrimSpace(pod.Name)
+		if namespace == "" || name == "" || strings.ContainsAny(namespace+name, "/,") {
+			return nil, errors.New("pods need a namespace and a name")
+		}
+		pods = append(pods, namespace+"/"+name)
+	}
+	return pods, nil
+}
 
-	updatedCount := 0
+// forceRefresh annotates PodSleuths to analyze pods (namespace/name) again, or all of
+// their non-ready pods if none are given. Only the PodSleuths reporting the pods are
+// annotated, or the one named podSleuth if set.
+func (s *Server) forceRefresh(ctx context.Context, podSleuth string, pods []string) (*forceRefreshResponse, error) {
+	var podSleuthList infrav1alpha1.PodSleuthList
+	if err := s.client.List(ctx, &podSleuthList); err != nil {
+		return nil, err
+	}
+
+	log.Log.Info("force-refresh request received", "podSleuth", podSleuth, "pods", pods)
+
+	response := &forceRefreshResponse{Generation: time.Now().UnixMilli(), Results: []forceRefreshResult{}}
+	if len(pods) == 1 {
+		response.TargetPod = pods[0]
+	}
+	generation := strconv.FormatInt(response.Generation, 10)
 	for i := range podSleuthList.Items {
 		ps := &podSleuthList.Items[i]
+		if podSleuth != "" && ps.Name != podSleuth {
+			continue
+		}
 
+		patch := client.MergeFrom(ps.DeepCopy())
 		if ps.Annotations == nil {
 			ps.Annotations = make(map[string]string)
 		}
-
-		if targetPod != "" {
-			ps.Annotations["kubesleuth.io/force-refresh-pod"] = targetPod
+		result := forceRefreshResult{PodSleuth: ps.Name}
+		if len(pods) == 0 {
+			ps.Annotations[controller.AnnotationForceRefresh] = time.Now().Format(time.RFC3339)
+			result.Pods = reportedPods(ps, nil)
 		} else {
-			ps.Annotations["kubesleuth.io/force-refresh"] = time.Now().Format(time.RFC3339)
+			result.Pods = reportedPods(ps, pods)
+			if len(result.Pods) == 0 {
+				continue
+			}
+			// Pods of earlier requests not analyzed yet are analyzed with these
+			targets := result.Pods
+			for _, pending := range strings.Split(ps.Annotations[controller.AnnotationForceRefreshPods], ",") {
+				if pending != "" && !slices.Contains(targets, pending) {
+					targets = append(targets, pending)
+				}
+			}
+			ps.Annotations[controller.AnnotationForceRefreshPods] = strings.Join(targets, ",")
 		}
+		ps.Annotations[controller.AnnotationForceRefreshGeneration] = generation
 
-		if err := s.client.Update(r.Context(), ps); err != nil {
+		if err := s.client.Patch(ctx, ps, patch); err != nil {
 			log.Log.Error(err, "Failed to update PodSleuth with force-refresh annotation", "name", ps.Name)
-			continue
+			result.Error = err.Error()
+		} else {
+			result.Success = true
+			response.Count++
+			log.Log.Info("force-refresh annotation applied", "podSleuth", ps.Name, "pods", result.Pods, "generation", generation)
 		}
-		updatedCount++
+		response.Results = append(response.Results, result)
+	}
 
-		log.Log.Info("force-refresh annotation applied", "podSleuth", ps.Name, "targetPod", targetPod)
+	if len(response.Results) == 0 && (podSleuth != "" || len(pods) > 0) {
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("PodSleuth %s %w", podSleuth, errForceRefreshTargetNotFound)
+		}
+		return nil, fmt.Errorf("pods %s %w among the non-ready pods of PodSleuths", strings.Join(pods, ", "), errForceRefreshTargetNotFound)
+	}
+	response.Success = response.Count == len(response.Results)
+	response.Message = fmt.Sprintf("Force refresh triggered for %d PodSleuth resources", response.Count)
+	return response, nil
+}
+
+// reportedPods returns the pods (namespace/name) a PodSleuth reports as non-ready, of
+// pods or all of them if nil
+func reportedPods(podSleuth *infrav1alpha1.PodSleuth, pods []string) []string {
+	var reported []string
+	for _, pod := range podSleuth.Status.NonReadyPods {
+		key := pod.Namespace + "/" + pod.Name
+		if (pods == nil || slices.Contains(pods, key)) && !slices.Contains(reported, key) {
+			reported = append(reported, key)
+		}
+	}
+	return reported
+}
+
+// cacheList is the response of GET /api/cache
+type cacheList struct {
+	Count   int                         `json:"count"`
+	Entries []controller.CacheEntryInfo `json:"entries"`
+}
+
+// podCache is the response of GET /api/cache/{namespace}/{pod}
+type podCache struct {
+	Namespace string                   `json:"namespace"`
+	Pod       string                   `json:"pod"`
+	Analyses  []controller.PodAnalysis `json:"analyses"`
+}
+
+// cacheFlushRequiresAuth is the error of cache flushes while dashboard authentication
+// is disabled: a flush forces the re-analysis of every pod and spends AI budget
+const cacheFlushRequiresAuth = "Flushing the analysis cache requires dashboard authentication"
+
+// cacheFlushResult is the response of DELETE /api/cache and /api/cache/{namespace}/{pod}
+type cacheFlushResult struct {
+	Success bool `json:"success"`
+	// Removed is how many cached analyses were flushed
+	Removed   int    `json:"removed"`
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+}
+
+// handleCache lists cached analyses (GET) or flushes the whole cache (DELETE)
+func (s *Server) handleCache(w http.ResponseWriter, r *http.Request) {
+	if s.cache == nil {
+		http.Error(w, "Analysis cache not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		entries := s.cache.CacheEntries()
+		writeJSON(w, r, cacheList{
+			Count:   len(entries),
+			Entries: entries,
+		})
+	case http.MethodDelete:
+		if !s.auth.Enabled() {
+			http.Error(w, cacheFlushRequiresAuth, http.StatusForbidden)
+			return
+		}
+		removed := s.cache.InvalidateCache("", "")
+		log.Log.Info("analysis cache flushed", "entries", removed)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cacheFlushResult{
+			Success: true,
+			Removed: removed,
+		})
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleCachePod returns the cached analyses of a single pod, including the error lines
+// left out of status (GET), or flushes them (DELETE): /api/cache/{namespace}/{pod}
+func (s *Server) handleCachePod(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.cache == nil {
+		http.Error(w, "Analysis cache not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path[len("/api/cache/"):], "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "Expected /api/cache/{namespace}/{pod}", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		writeJSON(w, r, podCache{
+			Namespace: parts[0],
+			Pod:       parts[1],
+			Analyses:  s.cache.PodAnalyses(parts[0], parts[1]),
+		})
+		return
+	}
+
+	if !s.auth.Enabled() {
+		http.Error(w, cacheFlushRequiresAuth, http.StatusForbidden)
+		return
 	}
+	removed := s.cache.InvalidateCache(parts[0], parts[1])
+	log.Log.Info("analysis cache flushed for pod", "namespace", parts[0], "pod", parts[1], "entries", removed)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success":   true,
-		"message":   fmt.Sprintf("Force refresh triggered for %d PodSleuth resources", updatedCount),
-		"count":     updatedCount,
-		"targetPod": targetPod,
+	json.NewEncoder(w).Encode(cacheFlushResult{
+		Success:   true,
+		Removed:   removed,
+		Namespace: parts[0],
+		Pod:       parts[1],
 	})
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// countingCache counts the flushes of the analysis cache
+type countingCache struct {
+	flushes int
+}
+
+func (c *countingCache) CacheEntries() []controller.CacheEntryInfo { return nil }
+
+func (c *countingCache) PodAnalyses(namespace, name string) []controller.PodAnalysis { return nil }
+
+func (c *countingCache) InvalidateCache(namespace, name string) int {
+	c.flushes++
+	return 1
+}
+
+func TestCacheFlushRequiresAuthentication(t *testing.T) {
+	for _, path := range []string{"/api/cache", "/api/cache/shop/cart-1"} {
+		cache := &countingCache{}
+		s := &Server{cache: cache}
+		handler := s.handleCache
+		if path != "/api/cache" {
+			handler = s.handleCachePod
+		}
+
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodDelete, path, nil))
+		if recorder.Code != http.StatusForbidden || cache.flushes != 0 {
+			t.Errorf("DELETE %s without authentication: got %d after %d flushes, want %d before any", path, recorder.Code, cache.flushes, http.StatusForbidden)
+		}
+		recorder = httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusOK {
+			t.Errorf("GET %s without authentication: got %d, want %d", path, recorder.Code, http.StatusOK)
+		}
+
+		s.EnableAuth(AuthConfig{Token: "api-token"})
+		recorder = httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodDelete, path, nil))
+		if recorder.Code != http.StatusOK || cache.flushes != 1 {
+			t.Errorf("DELETE %s with authentication: got %d after %d flushes, want %d after one", path, recorder.Code, cache.flushes, http.StatusOK)
+		}
+	}
+}
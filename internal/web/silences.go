@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+)
+
+// defaultSilenceDuration is used when a silence request sets neither duration nor expiresAt
+const defaultSilenceDuration = 24 * time.Hour
+
+// createSilenceRequest is the body of POST /api/silences
+type createSilenceRequest struct {
+	Namespace string `json:"namespace"`
+	PodRegex  string `json:"podRegex"`
+	Reason    string `json:"reason"`
+	Pattern   string `json:"pattern"`
+	// Duration is a Go duration such as "24h", ignored when ExpiresAt is set
+	Duration  string       `json:"duration"`
+	ExpiresAt *metav1.Time `json:"expiresAt"`
+	Comment   string       `json:"comment"`
+	CreatedBy string       `json:"createdBy"`
+}
+
+// silenceCreated is the response of POST /api/silences
+type silenceCreated struct {
+	Success bool                         `json:"success"`
+	Silence *infrav1alpha1.SleuthSilence `json:"silence"`
+}
+
+// silenceDeleted is the response of DELETE /api/silences/{name}
+type silenceDeleted struct {
+	Success bool   `json:"success"`
+	Name    string `json:"name"`
+}
+
+// handleSilences lists SleuthSilences (GET) or creates one (POST)
+func (s *Server) handleSilences(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var silenceList infrav1alpha1.SleuthSilenceList
+		if err := s.client.List(r.Context(), &silenceList); err != nil {
+			http.Error(w, fmt.Sprintf("Error listing SleuthSilence: %v", err), http.StatusInternalServerError)
+			return
+		}
+		writeVersionedJSON(w, r, listVersion(&silenceList), silenceList)
+	case http.MethodPost:
+		s.createSilence(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// createSilence validates a silence request and creates the SleuthSilence
+func (s *Server) createSilence(w http.ResponseWriter, r *http.Request) {
+	var reqBody createSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	spec := infrav1alpha1.SleuthSilenceSpec{
+		Namespace: strings.TrimSpace(reqBody.Namespace),
+		PodRegex:  strings.TrimSpace(reqBody.PodRegex),
+		Reason:    strings.TrimSpace(reqBody.Reason),
+		Pattern:   strings.TrimSpace(reqBody.Pattern),
+		Comment:   reqBody.Comment,
+		CreatedBy: reqBody.CreatedBy,
+	}
+	if spec.Namespace == "" && spec.PodRegex == "" && spec.Reason == "" && spec.Pattern == "" {
+		http.Error(w, "At least one of namespace, podRegex, reason or pattern is required", http.StatusBadRequest)
+		return
+	}
+	for field, expr := range map[string]string{"podRegex": spec.PodRegex, "pattern": spec.Pattern} {
+		if _, err := regexp.Compile(expr); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid %s: %v", field, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case reqBody.ExpiresAt != nil:
+		spec.ExpiresAt = *reqBody.ExpiresAt
+	case reqBody.Duration != "":
+		duration, err := time.ParseDuration(reqBody.Duration)
+		if err != nil || duration <= 0 {
+			http.Error(w, fmt.Sprintf("Invalid duration %q", reqBody.Duration), http.StatusBadRequest)
+			return
+		}
+		spec.ExpiresAt = metav1.NewTime(time.Now().Add(duration))
+	default:
+		spec.ExpiresAt = metav1.NewTime(time.Now().Add(defaultSilenceDuration))
+	}
+	if !spec.ExpiresAt.After(time.Now()) {
+		http.Error(w, "expiresAt must be in the future", http.StatusBadRequest)
+		return
+	}
+
+	silence := &infrav1alpha1.SleuthSilence{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "silence-"},
+		Spec:       spec,
+	}
+	if err := s.client.Create(r.Context(), silence); err != nil {
+		http.Error(w, fmt.Sprintf("Error creating SleuthSilence: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	log.Log.Info("silence created", "silence", silence.Name, "namespace", spec.Namespace,
+		"podRegex", spec.PodRegex, "reason", spec.Reason, "expiresAt", spec.ExpiresAt.Time)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(silenceCreated{
+		Success: true,
+		Silence: silence,
+	})
+}
+
+// handleSilence deletes a SleuthSilence: DELETE /api/silences/{name}
+func (s *Server) handleSilence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.Trim(r.URL.Path[len("/api/silences/"):], "/")
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "Expected /api/silences/{name}", http.StatusBadRequest)
+		return
+	}
+
+	silence := &infrav1alpha1.SleuthSilence{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := s.client.Delete(r.Context(), silence); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, "SleuthSilence not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error deleting SleuthSilence: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	log.Log.Info("silence deleted", "silence", name)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(silenceDeleted{
+		Success: true,
+		Name:    name,
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// podSleuthSLO is the SLO report of a PodSleuth along with the cluster it runs in
+type podSleuthSLO struct {
+	Cluster string `json:"cluster,omitempty"`
+	*controller.SLOReport
+}
+
+// handleSLO reports the MTTR and availability of the workloads of the PodSleuths
+// tracking SLOs: GET /api/slo, filtered by ?podSleuth=, ?namespace=, ?cluster= and
+// ?breaching=true
+func (s *Server) handleSLO(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	podSleuthList, _, err := s.listPodSleuths(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	podSleuthName, namespace, cluster := query.Get("podSleuth"), query.Get("namespace"), query.Get("cluster")
+	breachingOnly := query.Get("breaching") == "true"
+
+	now := time.Now()
+	reports := []podSleuthSLO{}
+	for i := range podSleuthList.Items {
+		podSleuth := &podSleuthList.Items[i]
+		if podSleuthName != "" && podSleuth.Name != podSleuthName {
+			continue
+		}
+		if cluster != "" && s.clusterOf(podSleuth) != cluster {
+			continue
+		}
+		report := controller.SLOReportOf(podSleuth, now)
+		if report == nil {
+			continue
+		}
+		report.Workloads = slices.DeleteFunc(report.Workloads, func(workload controller.WorkloadSLO) bool {
+			return (namespace != "" && workload.Namespace != namespace) || (breachingOnly && !workload.Breaching)
+		})
+		report.Namespaces = slices.DeleteFunc(report.Namespaces, func(ns controller.NamespaceSLO) bool {
+			return (namespace != "" && ns.Namespace != namespace) || (breachingOnly && ns.BreachingWorkloads == 0)
+		})
+		reports = append(reports, podSleuthSLO{Cluster: s.clusterOf(podSleuth), SLOReport: report})
+	}
+
+	writeJSON(w, r, reports)
+}
@@ -0,0 +1,2811 @@
+// The messages of the dashboard language are served with the page; see the locales/
+// catalogs of the web package
+const locale = document.documentElement.lang || 'en';
+// basePath prefixes the dashboard's URLs when it is served under a path, like /kubesleuth
+const basePath = document.body.dataset.basePath || '';
+const pluralRules = new Intl.PluralRules(locale);
+
+// t returns the message of key in the dashboard language with its {name} placeholders
+// filled from params
+function t(key, params) {
+    return fillMessage(messages[key] !== undefined ? messages[key] : key, params);
+}
+
+// tn returns the plural form of a message that fits count, e.g. key.one or key.other.
+// Its {count} placeholder is filled with count.
+function tn(key, count, params) {
+    const form = key + '.' + pluralRules.select(count);
+    return t(messages[form] !== undefined ? form : key + '.other', Object.assign({ count: count }, params));
+}
+
+// tHtml returns a message as HTML: the message is escaped, its params must already be
+function tHtml(key, params) {
+    return fillMessage(escapeHtml(messages[key] !== undefined ? messages[key] : key), params);
+}
+
+function fillMessage(message, params) {
+    return message.replace(/\{(\w+)\}/g, (placeholder, name) =>
+        params && params[name] !== undefined ? String(params[name]) : placeholder);
+}
+
+// formatDateTime renders a timestamp as a date and time of the dashboard language
+function formatDateTime(timestamp) {
+    return new Date(timestamp).toLocaleString(locale);
+}
+
+// onLocaleChange switches the dashboard language, which the server remembers in a cookie
+function onLocaleChange() {
+    const code = document.getElementById('localeSelect').value;
+    document.cookie = 'kubesleuth-locale=' + encodeURIComponent(code) + '; path=/; max-age=31536000; SameSite=Lax';
+    window.location.reload();
+}
+
+let podSleuths = new Map(); // PodSleuths by podSleuthKey, as loaded or streamed
+// In hub mode the PodSleuths pushed by other clusters are annotated with their cluster;
+// their pods are shown read-only, next to those of this cluster
+const clusterAnnotation = 'kubesleuth.io/cluster';
+const localCluster = document.body.dataset.clusterName || '';
+let multiCluster = false;
+let allPods = [];
+let evictedGroups = [];
+let pendingRemediations = []; // Actions of rules with approvalRequired, with their PodSleuth
+let workloadContexts = {}; // Replica/HPA context keyed like getOwnerGroupKey
+let filteredPods = [];
+let expandedRows = new Set(); // Track which rows are expanded
+// The view is kept in the URL (?q=&cluster=&ns=&phase=&severity=&reason=&team=&group=&pod=) so
+// links to it can be shared; the team, grouping and expanded pod are also remembered
+const urlState = new URLSearchParams(window.location.search);
+let lastExpandedPodKey = urlState.get('pod') || localStorage.getItem('lastExpandedPod') || '';
+// On-call engineers pin their team via ?team= or the team filter, which is remembered
+let selectedTeam = urlState.get('team') || localStorage.getItem('teamFilter') || '';
+const noTeam = '~none';
+// Pods can be grouped by workload or namespace; groups start collapsed to one row each
+let groupBy = urlState.has('group') ? urlState.get('group') : (localStorage.getItem('groupBy') || '');
+const expandedGroups = new Set();
+
+function matchesTeam(pod) {
+    if (!selectedTeam) return true;
+    if (selectedTeam === noTeam) return !pod.team;
+    return pod.team === selectedTeam;
+}
+
+// isMuted mirrors the operator: suppressed, silenced, acknowledged and within-baseline
+// pods are not counted or notified
+function isMuted(pod) {
+    return pod.suppressed || pod.silenced || !!pod.acknowledged || pod.withinBaseline;
+}
+
+function getPodKey(pod) {
+    return (pod.remote ? pod.cluster + ':' : '') + pod.namespace + '/' + pod.name;
+}
+
+function clusterOf(podSleuth) {
+    return (podSleuth.metadata.annotations || {})[clusterAnnotation] || '';
+}
+
+// podSleuthKey mirrors hub.PodSleuthKey: cluster/name for the PodSleuths of other clusters
+function podSleuthKey(podSleuth) {
+    const cluster = clusterOf(podSleuth);
+    return cluster ? cluster + '/' + podSleuth.metadata.name : podSleuth.metadata.name;
+}
+
+async function loadData(retryCount = 0) {
+    const maxRetries = 5;
+    const retryDelay = 2000; // 2 seconds
+    const refreshBtn = document.getElementById('refreshBtn');
+    const loading = document.getElementById('loading');
+    const errorDiv = document.getElementById('error');
+    const tableContainer = document.getElementById('tableContainer');
+    const emptyState = document.getElementById('emptyState');
+
+    refreshBtn.disabled = true;
+    loading.style.display = 'block';
+    errorDiv.style.display = 'none';
+    // Don't hide table if we are just retrying to avoid flicker
+    if (retryCount === 0) {
+        tableContainer.style.display = 'none';
+        emptyState.style.display = 'none';
+    }
+
+    try {
+        const response = await fetch(basePath + '/api/podsleuths');
+        if (!response.ok) {
+            throw new Error(t('error.server', { status: response.status, text: response.statusText }));
+        }
+        const data = await response.json();
+        podSleuths = new Map();
+        if (data.items && Array.isArray(data.items)) {
+            data.items.forEach(podSleuth => podSleuths.set(podSleuthKey(podSleuth), podSleuth));
+        }
+        renderPodSleuths();
+    } catch (error) {
+        console.error("Attempt " + (retryCount + 1) + " failed:", error);
+
+        if (retryCount < maxRetries) {
+            loading.textContent = t('loading.retry', { attempt: retryCount + 1, max: maxRetries });
+            setTimeout(() => loadData(retryCount + 1), retryDelay);
+        } else {
+            loading.style.display = 'none';
+            loading.textContent = t('common.loading');
+            errorDiv.style.display = 'block';
+            errorDiv.textContent = t('error.loadData', { error: error.message });
+        }
+    } finally {
+        if (retryCount >= maxRetries || loading.style.display === 'none') {
+            refreshBtn.disabled = false;
+        }
+    }
+}
+
+// renderPodSleuths aggregates the pods of all PodSleuths and renders them
+function renderPodSleuths() {
+    const loading = document.getElementById('loading');
+    if (pausedChanges) {
+        pausedChanges = false;
+        updateRefreshControls();
+    }
+
+    // Aggregate all non-ready pods from all PodSleuth resources
+    allPods = [];
+    evictedGroups = [];
+    pendingRemediations = [];
+    workloadContexts = {};
+    const clusters = new Set();
+    podSleuths.forEach(podSleuth => {
+        const cluster = clusterOf(podSleuth);
+        if (cluster) clusters.add(cluster);
+        if (podSleuth.status && podSleuth.status.nonReadyPods && Array.isArray(podSleuth.status.nonReadyPods)) {
+            allPods = allPods.concat(cluster ?
+                podSleuth.status.nonReadyPods.map(p => Object.assign({}, p, { cluster: cluster, remote: true })) :
+                podSleuth.status.nonReadyPods);
+        }
+        if (podSleuth.status && podSleuth.status.evictedPods && Array.isArray(podSleuth.status.evictedPods)) {
+            evictedGroups = evictedGroups.concat(cluster ?
+                podSleuth.status.evictedPods.map(g => Object.assign({ cluster: cluster }, g)) :
+                podSleuth.status.evictedPods);
+        }
+        // Remediations are approved on the cluster that runs them
+        if (!cluster && podSleuth.status && Array.isArray(podSleuth.status.pendingRemediations)) {
+            podSleuth.status.pendingRemediations.forEach(a => {
+                pendingRemediations.push(Object.assign({ podSleuth: podSleuth.metadata.name }, a));
+            });
+        }
+        if (podSleuth.status && Array.isArray(podSleuth.status.workloads)) {
+            podSleuth.status.workloads.forEach(w => {
+                workloadContexts[(cluster ? cluster + '|' : '') + w.namespace + '/' + w.kind + '/' + w.name] = w;
+            });
+        }
+    });
+
+    // The cluster column and filter are shown once other clusters push to this one
+    const wasMultiCluster = multiCluster;
+    multiCluster = clusters.size > 0;
+    if (multiCluster) {
+        allPods.forEach(p => { if (!p.remote) p.cluster = p.cluster || localCluster || t('cluster.local'); });
+    }
+    if (multiCluster !== wasMultiCluster) {
+        renderTableHeader();
+    }
+    updateClusterFilter();
+
+    // Sort pods by name alphabetically
+    allPods.sort((a, b) => a.name.localeCompare(b.name));
+    notifyNewCriticalPods();
+
+    updateTeamFilter();
+    updateStats();
+    updateNamespaceFilter();
+    updateReasonFilter();
+    filterTable();
+    renderEvictedGroups();
+    renderPendingRemediations();
+    updateLastUpdate();
+
+    loading.style.display = 'none';
+    updateEmptyState();
+}
+
+// updateEmptyState shows the table, or why it is empty
+function updateEmptyState() {
+    const tableContainer = document.getElementById('tableContainer');
+    const emptyState = document.getElementById('emptyState');
+    if (filteredPods.length === 0) {
+        emptyState.querySelector('p').textContent = t(allPods.length === 0 ? 'table.empty' : 'table.noMatches');
+        emptyState.style.display = 'block';
+        tableContainer.style.display = 'none';
+    } else {
+        tableContainer.style.display = 'block';
+        emptyState.style.display = 'none';
+    }
+}
+
+function renderEvictedGroups() {
+    const container = document.getElementById('evictedContainer');
+    const groupsDiv = document.getElementById('evictedGroups');
+    if (evictedGroups.length === 0) {
+        container.style.display = 'none';
+        groupsDiv.innerHTML = '';
+        return;
+    }
+    const colors = {
+        critical: { bg: '#f8d7da', border: '#dc3545', text: '#721c24' },
+        warning: { bg: '#fff3cd', border: '#ffc107', text: '#856404' },
+        info: { bg: '#d1ecf1', border: '#17a2b8', text: '#0c5460' }
+    };
+    let html = '';
+    evictedGroups.forEach(group => {
+        const c = colors[group.severity] || colors.info;
+        html += '<details style="background: ' + c.bg + '; border-left: 4px solid ' + c.border + '; border-radius: 4px; padding: 10px 12px; margin-bottom: 8px; color: ' + c.text + ';">';
+        html += '<summary style="cursor: pointer;"><strong>' + escapeHtml(t('severity.' + group.severity).toUpperCase()) + '</strong> ' + escapeHtml(group.summary) + '</summary>';
+        (group.pods || []).forEach(p => {
+            let line = p.namespace + '/' + p.name;
+            if (p.ownerKind) line += ' (' + p.ownerKind + ' ' + p.ownerName + ')';
+            if (p.message) line += ' • ' + p.message;
+            html += '<div style="font-size: 12px; font-family: monospace; margin-top: 4px;">• ' + escapeHtml(line) + '</div>';
+        });
+        html += '</details>';
+    });
+    groupsDiv.innerHTML = html;
+    container.style.display = 'block';
+}
+
+function renderPendingRemediations() {
+    const container = document.getElementById('pendingContainer');
+    const listDiv = document.getElementById('pendingRemediations');
+    if (pendingRemediations.length === 0) {
+        container.style.display = 'none';
+        listDiv.innerHTML = '';
+        return;
+    }
+    let html = '';
+    pendingRemediations.forEach(a => {
+        let target = a.namespace + '/' + a.pod;
+        if (a.ownerKind) target += ' (' + a.ownerKind + ' ' + a.ownerName + ')';
+        html += '<div style="background: #fff3cd; border-left: 4px solid #ffc107; border-radius: 4px; padding: 10px 12px; margin-bottom: 8px; color: #856404; display: flex; align-items: center; gap: 10px; flex-wrap: wrap;">';
+        html += '<span><strong>' + escapeHtml(a.action) + '</strong> ' + escapeHtml(target);
+        html += ' • ' + escapeHtml(t('remediations.rule', { rule: a.rule }));
+        if (a.reason) html += ' • ' + escapeHtml(a.reason);
+        html += ' • ' + escapeHtml(t('remediations.requested', { time: formatDateTime(a.requestedAt) })) + '</span>';
+        html += '<button onclick="decideRemediation(this, \'approve\')" data-podsleuth="' + escapeHtml(a.podSleuth) + '" data-id="' + escapeHtml(a.id) + '" class="refresh-btn" style="background: #28a745; font-size: 12px; padding: 6px 12px;">' + escapeHtml(t('remediations.approve')) + '</button>';
+        html += '<button onclick="decideRemediation(this, \'reject\')" data-podsleuth="' + escapeHtml(a.podSleuth) + '" data-id="' + escapeHtml(a.id) + '" class="refresh-btn" style="background: #6c757d; font-size: 12px; padding: 6px 12px;">' + escapeHtml(t('remediations.reject')) + '</button>';
+        html += '<span class="remediation-status" style="font-size: 12px;"></span>';
+        html += '</div>';
+    });
+    listDiv.innerHTML = html;
+    container.style.display = 'block';
+}
+
+// decideRemediation approves or rejects a pending remediation. The approval token
+// is asked for once and kept for the browser session.
+async function decideRemediation(btn, decision) {
+    const statusSpan = btn.parentElement.querySelector('.remediation-status');
+    if (decision === 'approve' && !confirm(t('remediations.confirm'))) return;
+    let token = sessionStorage.getItem('approvalToken');
+    if (!token) {
+        token = prompt(t('remediations.tokenPrompt'));
+        if (!token) return;
+    }
+    let actor = localStorage.getItem('approvalActor');
+    if (actor === null) {
+        actor = prompt(t('remediations.actorPrompt')) || '';
+        localStorage.setItem('approvalActor', actor);
+    }
+    try {
+        const response = await fetch(basePath + '/api/remediations/' + encodeURIComponent(btn.dataset.podsleuth) + '/' + encodeURIComponent(btn.dataset.id) + '/' + decision, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json', 'Authorization': 'Bearer ' + token },
+            body: JSON.stringify({ actor: actor })
+        });
+        if (response.status === 401) sessionStorage.removeItem('approvalToken');
+        if (!response.ok) throw new Error(await response.text());
+        sessionStorage.setItem('approvalToken', token);
+        if (statusSpan) { statusSpan.textContent = t(decision === 'approve' ? 'remediations.approved' : 'remediations.rejected'); statusSpan.style.color = '#28a745'; }
+        setTimeout(() => loadData(), 3000);
+    } catch (error) {
+        console.error('Error deciding remediation:', error);
+        if (statusSpan) { statusSpan.textContent = t('common.failed', { error: error.message }); statusSpan.style.color = '#dc3545'; }
+    }
+}
+
+// The cards show the operator's aggregates and how the count changed in the last hour.
+// Pods of no team are counted in the browser, which the stats API cannot select.
+let statsRequest = 0;
+
+async function updateStats() {
+    if (selectedTeam === noTeam) {
+        updateStatsLocally();
+        return;
+    }
+    const request = ++statsRequest;
+    try {
+        const response = await fetch(basePath + '/api/stats' + (selectedTeam ? '?team=' + encodeURIComponent(selectedTeam) : ''));
+        if (!response.ok) throw new Error(response.statusText);
+        const stats = await response.json();
+        if (request !== statsRequest) return;
+        showStats(stats.total, stats.suppressed, stats.silenced, stats.acknowledged, stats.withinBaseline, stats.namespaces, stats.deployments);
+        showWastedCapacity(stats.wasted);
+        const hour = (stats.trends || []).find(t => t.window === '1h');
+        const trend = document.getElementById('totalPodsTrend');
+        if (hour && hour.change !== 0) {
+            trend.textContent = (hour.change > 0 ? '▲ ' : '▼ ') + t('stats.changeLastHour', { count: Math.abs(hour.change) });
+            trend.className = 'stat-trend ' + (hour.change > 0 ? 'trend-up' : 'trend-down');
+        } else {
+            trend.textContent = hour ? t('stats.noChangeLastHour') : '';
+            trend.className = 'stat-trend';
+        }
+    } catch (error) {
+        console.error('Error loading stats:', error);
+        if (request === statsRequest) updateStatsLocally();
+    }
+}
+
+function updateStatsLocally() {
+    // Pods in a maintenance window, silenced or acknowledged are listed but not counted
+    const teamPods = allPods.filter(matchesTeam);
+    const activePods = teamPods.filter(p => !isMuted(p));
+    const namespaces = new Set(activePods.map(p => p.namespace));
+    const deployments = new Set(activePods.filter(p => p.ownerKind === 'Deployment').map(p => p.namespace + '/' + p.ownerName));
+    const suppressedCount = teamPods.filter(p => p.suppressed).length;
+    const silencedCount = teamPods.filter(p => p.silenced && !p.suppressed).length;
+    const acknowledgedCount = teamPods.filter(p => p.acknowledged && !p.silenced && !p.suppressed).length;
+    const withinBaselineCount = teamPods.filter(p => p.withinBaseline && !p.acknowledged && !p.silenced && !p.suppressed).length;
+    showStats(activePods.length, suppressedCount, silencedCount, acknowledgedCount, withinBaselineCount, namespaces.size, deployments.size);
+    document.getElementById('totalPodsTrend').textContent = '';
+    // Prices are only known to the operator, so the cost is left out
+    const wasted = { pods: 0, cpuCores: 0, memoryBytes: 0 };
+    teamPods.filter(wastesCapacity).forEach(p => {
+        wasted.pods++;
+        wasted.cpuCores += (p.reserved.cpuMillicores || 0) / 1000;
+        wasted.memoryBytes += p.reserved.memoryBytes || 0;
+    });
+    showWastedCapacity(wasted);
+}
+
+// wastesCapacity mirrors the operator: a pod holding requested capacity that has been
+// non-ready for 10 minutes or more wastes it
+const stuckPodMs = 10 * 60 * 1000;
+function wastesCapacity(pod) {
+    const since = pod.notReadySince || pod.detectedAt;
+    return !!pod.reserved && !!since && Date.now() - new Date(since).getTime() >= stuckPodMs;
+}
+
+function showWastedCapacity(wasted) {
+    const value = document.getElementById('wastedCapacity');
+    const cost = document.getElementById('wastedCost');
+    if (!wasted || wasted.pods === 0) {
+        value.textContent = '0';
+        cost.textContent = '';
+        return;
+    }
+    const gib = wasted.memoryBytes / (1 << 30);
+    value.textContent = t('stats.wastedValue', {
+        cores: wasted.cpuCores.toLocaleString(locale, { maximumFractionDigits: 1 }),
+        memory: gib.toLocaleString(locale, { maximumFractionDigits: 1 }),
+    });
+    let text = t('stats.wastedPods', { count: wasted.pods });
+    if (wasted.costPerHour) {
+        const monthly = (wasted.costPerHour * 730).toLocaleString(locale, { maximumFractionDigits: 0 });
+        text += ' · ' + t('stats.wastedCost', { cost: monthly, currency: wasted.currency || '' });
+    }
+    cost.textContent = text;
+}
+
+function showStats(total, suppressedCount, silencedCount, acknowledgedCount, withinBaselineCount, namespaces, deployments) {
+    let totalText = String(total);
+    if (suppressedCount > 0) totalText += ' ' + t('stats.inMaintenance', { count: suppressedCount });
+    if (silencedCount > 0) totalText += ' ' + t('stats.silenced', { count: silencedCount });
+    if (acknowledgedCount > 0) totalText += ' ' + t('stats.acknowledged', { count: acknowledgedCount });
+    if (withinBaselineCount > 0) totalText += ' ' + t('stats.withinBaseline', { count: withinBaselineCount });
+    document.getElementById('totalPods').textContent = totalText;
+    document.getElementById('totalNamespaces').textContent = namespaces;
+    document.getElementById('totalDeployments').textContent = deployments;
+}
+
+function updateClusterFilter() {
+    const select = document.getElementById('clusterFilter');
+    select.style.display = multiCluster ? '' : 'none';
+    fillFilterOptions(select, t('filters.allClusters'), allPods.map(p => p.cluster).filter(Boolean));
+}
+
+function updateNamespaceFilter() {
+    fillFilterOptions(document.getElementById('namespaceFilter'), t('filters.allNamespaces'), allPods.map(p => p.namespace));
+}
+
+function updateReasonFilter() {
+    fillFilterOptions(document.getElementById('reasonFilter'), t('filters.allReasons'), allPods.map(p => p.reason).filter(Boolean));
+}
+
+// fillFilterOptions rebuilds the options of a filter from values. The selected value is
+// kept even when no pod has it anymore, so a shared link shows that nothing matches.
+function fillFilterOptions(select, allLabel, values) {
+    const currentValue = select.dataset.value || select.value;
+    delete select.dataset.value;
+    const options = [...new Set(values)].sort();
+    if (currentValue && !options.includes(currentValue)) {
+        options.push(currentValue);
+    }
+
+    select.innerHTML = '';
+    select.appendChild(new Option(allLabel, ''));
+    options.forEach(value => select.appendChild(new Option(value, value)));
+    select.value = currentValue;
+}
+
+function updateTeamFilter() {
+    const teams = [...new Set(allPods.filter(p => p.team).map(p => p.team))].sort();
+    const select = document.getElementById('teamFilter');
+    // Team controls are only shown when ownership rules assign teams
+    const hasTeams = teams.length > 0 || selectedTeam;
+    select.style.display = hasTeams ? '' : 'none';
+    document.getElementById('groupByTeamLabel').style.display = hasTeams ? 'flex' : 'none';
+
+    select.innerHTML = '';
+    select.appendChild(new Option(t('filters.allTeams'), ''));
+    teams.forEach(team => {
+        const option = document.createElement('option');
+        option.value = team;
+        option.textContent = team;
+        select.appendChild(option);
+    });
+    const unassigned = document.createElement('option');
+    unassigned.value = noTeam;
+    unassigned.textContent = t('filters.unassigned');
+    select.appendChild(unassigned);
+
+    if (selectedTeam && selectedTeam !== noTeam && !teams.includes(selectedTeam)) {
+        // Keep a pinned team selectable even when it currently has no failing pods
+        const option = document.createElement('option');
+        option.value = selectedTeam;
+        option.textContent = selectedTeam;
+        select.appendChild(option);
+    }
+    select.value = selectedTeam;
+}
+
+// onFilterChange starts over on the first page when the user changes the view
+function onFilterChange() {
+    tablePage = 0;
+    filterTable();
+}
+
+function onTeamChange() {
+    tablePage = 0;
+    selectedTeam = document.getElementById('teamFilter').value;
+    localStorage.setItem('teamFilter', selectedTeam);
+    criticalPodKeys = null; // The critical pods of another team are not new
+    updateStats();
+    filterTable();
+}
+
+// restoreUrlState sets the filters from the URL. Namespaces and reasons are kept until
+// their options are filled from the loaded pods.
+function restoreUrlState() {
+    document.getElementById('search').value = urlState.get('q') || '';
+    document.getElementById('phaseFilter').value = urlState.get('phase') || '';
+    document.getElementById('severityFilter').value = urlState.get('severity') || '';
+    document.getElementById('clusterFilter').dataset.value = urlState.get('cluster') || '';
+    document.getElementById('namespaceFilter').dataset.value = urlState.get('ns') || '';
+    document.getElementById('reasonFilter').dataset.value = urlState.get('reason') || '';
+    document.getElementById('groupBy').value = groupBy;
+}
+
+// syncUrlState writes the current view to the URL without adding a history entry
+function syncUrlState() {
+    const params = new URLSearchParams();
+    const set = (name, value) => { if (value) params.set(name, value); };
+    set('q', document.getElementById('search').value);
+    set('cluster', document.getElementById('clusterFilter').value);
+    set('ns', document.getElementById('namespaceFilter').value);
+    set('phase', document.getElementById('phaseFilter').value);
+    set('severity', document.getElementById('severityFilter').value);
+    set('reason', document.getElementById('reasonFilter').value);
+    set('team', selectedTeam);
+    set('group', groupBy);
+    set('pod', lastExpandedPodKey);
+    const query = params.toString();
+    window.history.replaceState(null, '', window.location.pathname + (query ? '?' + query : '') + window.location.hash);
+}
+
+// podSeverity mirrors the operator's severity of a pod (controller.PodSeverity)
+const criticalPodReasons = new Set(['CrashLoopBackOff', 'OOMKilled', 'ImagePullBackOff', 'ErrImagePull',
+    'CreateContainerConfigError', 'CreateContainerError', 'InvalidImageName', 'Error']);
+
+function podSeverity(pod) {
+    if (isMuted(pod)) return 'info';
+    if (pod.phase === 'Failed' || criticalPodReasons.has(pod.reason)) return 'critical';
+    if ((pod.containerErrors || []).some(ce => criticalPodReasons.has(ce.reason))) return 'critical';
+    return 'warning';
+}
+
+function getTeamGroupKey(pod) {
+    return pod.team || '~ Unassigned';
+}
+
+function onGroupByChange() {
+    tablePage = 0;
+    groupBy = document.getElementById('groupBy').value;
+    localStorage.setItem('groupBy', groupBy);
+    expandedGroups.clear();
+    filterTable();
+}
+
+// getGroupKey returns the key of the workload or namespace group of a pod, or '' when
+// pods are not grouped
+function getGroupKey(pod) {
+    switch (groupBy) {
+    case 'workload':
+        return getOwnerGroupKey(pod);
+    case 'namespace':
+        return (pod.remote ? pod.cluster + '|' : '') + pod.namespace;
+    }
+    return '';
+}
+
+function filterTable() {
+    const searchTerm = document.getElementById('search').value.toLowerCase();
+    const clusterFilter = multiCluster ? document.getElementById('clusterFilter').value : '';
+    const namespaceFilter = document.getElementById('namespaceFilter').value;
+    const phaseFilter = document.getElementById('phaseFilter').value;
+    const severityFilter = document.getElementById('severityFilter').value;
+    const reasonFilter = document.getElementById('reasonFilter').value;
+
+    filteredPods = allPods.filter(pod => {
+        const matchesSearch = !searchTerm ||
+            pod.name.toLowerCase().includes(searchTerm) ||
+            pod.namespace.toLowerCase().includes(searchTerm) ||
+            (pod.ownerName && pod.ownerName.toLowerCase().includes(searchTerm)) ||
+            (pod.cluster && pod.cluster.toLowerCase().includes(searchTerm));
+
+        const matchesCluster = !clusterFilter || pod.cluster === clusterFilter;
+        const matchesNamespace = !namespaceFilter || pod.namespace === namespaceFilter;
+        const matchesPhase = !phaseFilter || pod.phase === phaseFilter;
+        const matchesSeverity = !severityFilter || podSeverity(pod) === severityFilter;
+        const matchesReason = !reasonFilter || pod.reason === reasonFilter ||
+            (pod.containerErrors || []).some(ce => ce.reason === reasonFilter);
+
+        return matchesSearch && matchesCluster && matchesNamespace && matchesPhase && matchesSeverity && matchesReason && matchesTeam(pod);
+    });
+
+    // Keep pods of the same team and workload together when grouping
+    const groupByTeam = document.getElementById('groupByTeam').checked;
+    filteredPods.sort((a, b) =>
+        (groupByTeam ? getTeamGroupKey(a).localeCompare(getTeamGroupKey(b)) : 0) ||
+        getGroupKey(a).localeCompare(getGroupKey(b)) ||
+        comparePods(a, b));
+
+    renderTable();
+    syncUrlState();
+    if (document.getElementById('loading').style.display === 'none') {
+        updateEmptyState();
+    }
+}
+
+function getOwnerGroupKey(pod) {
+    if (!pod.ownerKind) {
+        return '~ No owner';
+    }
+    return (pod.remote ? pod.cluster + '|' : '') + pod.namespace + '/' + pod.ownerKind + '/' + pod.ownerName;
+}
+
+function renderTable() {
+    // Save currently expanded rows before re-rendering
+    const currentlyExpanded = new Set(expandedRows);
+    let autoExpandIndex = null;
+
+    const tbody = document.getElementById('podsTableBody');
+    tbody.innerHTML = '';
+    const groupByTeam = document.getElementById('groupByTeam').checked;
+    let currentGroup = null;
+    let currentTeam = null;
+
+    const pageSize = tablePrefs.pageSize;
+    const pageCount = pageSize ? Math.max(1, Math.ceil(filteredPods.length / pageSize)) : 1;
+    if (showExpandedPodPage && filteredPods.length > 0) {
+        showExpandedPodPage = false;
+        const expandedIndex = filteredPods.findIndex(pod => getPodKey(pod) === lastExpandedPodKey);
+        if (expandedIndex >= 0 && pageSize) {
+            tablePage = Math.floor(expandedIndex / pageSize);
+        }
+    }
+    tablePage = Math.min(tablePage, pageCount - 1);
+    const firstIndex = pageSize ? tablePage * pageSize : 0;
+    const lastIndex = pageSize ? firstIndex + pageSize : filteredPods.length;
+    renderPagination(pageCount);
+
+    filteredPods.forEach((pod, index) => {
+        if (index < firstIndex || index >= lastIndex) return;
+        if (groupByTeam && getTeamGroupKey(pod) !== currentTeam) {
+            currentTeam = getTeamGroupKey(pod);
+            currentGroup = null;
+            const teamSize = filteredPods.filter(p => getTeamGroupKey(p) === currentTeam).length;
+            const teamRow = tbody.insertRow();
+            teamRow.className = 'team-group-row';
+            const teamCell = teamRow.insertCell(0);
+            teamCell.colSpan = tableWidth();
+            teamCell.textContent = '👥 ' + (pod.team || t('filters.unassigned')) + ' (' + tn('count.pods', teamSize) + ')';
+        }
+        // Groups are keyed within their team, so each can be collapsed on its own
+        const groupKey = groupBy ? (groupByTeam ? getTeamGroupKey(pod) + '|' : '') + getGroupKey(pod) : '';
+        if (groupKey && groupKey !== currentGroup) {
+            currentGroup = groupKey;
+            const groupPods = filteredPods.filter(p => (groupByTeam ? getTeamGroupKey(p) + '|' : '') + getGroupKey(p) === groupKey);
+            renderGroupRow(tbody, groupKey, groupPods);
+        }
+        const hasDetails = (pod.containerErrors && pod.containerErrors.length > 0) ||
+                          (pod.podConditions && pod.podConditions.length > 0) ||
+                          (pod.logAnalysis && pod.logAnalysis.rootCause);
+        const podKey = getPodKey(pod);
+        if (lastExpandedPodKey && lastExpandedPodKey === podKey) {
+            autoExpandIndex = index;
+        }
+
+        // Always show expand icon if log analysis is present (it's important)
+        const hasLogAnalysis = pod.logAnalysis && pod.logAnalysis.rootCause;
+
+        // Main row - make expandable if has details or log analysis
+        const row = tbody.insertRow();
+        const isExpandable = hasDetails || hasLogAnalysis;
+        row.className = isExpandable ? 'expandable-row' : '';
+        if (pod.suppressed || pod.silenced || pod.withinBaseline) {
+            row.classList.add('suppressed-row');
+        } else if (pod.acknowledged) {
+            row.classList.add('acknowledged-row');
+        }
+        row.onclick = isExpandable ? () => toggleDetails(index) : null;
+        markGroupMember(row, groupKey);
+
+        // Expand icon - always show if log analysis is present
+        const expandCell = row.insertCell(0);
+        if (hasDetails || hasLogAnalysis) {
+            const icon = document.createElement('span');
+            icon.className = 'expand-icon';
+            icon.textContent = '▶';
+            icon.id = 'expand-icon-' + index;
+            expandCell.appendChild(icon);
+        } else {
+            expandCell.textContent = '';
+        }
+
+        visibleColumns().forEach(column => column.render(row.insertCell(), pod, index));
+
+        const actionsCell = row.insertCell();
+        actionsCell.className = 'row-actions';
+        actionsCell.onclick = e => e.stopPropagation();
+        actionsCell.innerHTML = renderRowActions(pod);
+        actionsCell.appendChild(renderKubectlMenu(pod));
+
+        // Details row - show if has details or log analysis
+        if (hasDetails || hasLogAnalysis) {
+            const detailsRow = tbody.insertRow();
+            detailsRow.className = 'details-row';
+            detailsRow.id = 'details-' + index;
+            const detailsCell = detailsRow.insertCell(0);
+            detailsCell.colSpan = tableWidth();
+            detailsCell.innerHTML = renderDetails(pod);
+            markGroupMember(detailsRow, groupKey);
+        }
+    });
+
+    // Restore expanded state after re-rendering
+    currentlyExpanded.forEach(index => {
+        const detailsRow = document.getElementById('details-' + index);
+        const icon = document.getElementById('expand-icon-' + index);
+        if (detailsRow && icon) {
+            detailsRow.classList.add('expanded');
+            icon.textContent = '▼';
+            loadPodDetails(index);
+        }
+    });
+
+    // Auto-expand saved pod after reload/refresh
+    if (autoExpandIndex !== null) {
+        const detailsRow = document.getElementById('details-' + autoExpandIndex);
+        const icon = document.getElementById('expand-icon-' + autoExpandIndex);
+        if (detailsRow && icon) {
+            if (detailsRow.dataset.group && !expandedGroups.has(detailsRow.dataset.group)) {
+                toggleGroup(detailsRow.dataset.group);
+            }
+            detailsRow.classList.add('expanded');
+            icon.textContent = '▼';
+            expandedRows.add(autoExpandIndex);
+            loadPodDetails(autoExpandIndex);
+            setTimeout(() => {
+                detailsRow.scrollIntoView({ behavior: 'smooth', block: 'center' });
+            }, 300);
+        }
+    }
+}
+
+// tableColumns are the columns of the pod table in their default order. Users may show,
+// hide and reorder them; the expand and actions columns stay first and last. Columns with
+// a sortValue sort the table when their header is clicked, first in descending order if
+// descendingFirst is set.
+const severityRanks = { info: 0, warning: 1, critical: 2 };
+const tableColumns = [
+    { id: 'cluster', label: t('columns.cluster'), render: (cell, pod) => { cell.textContent = pod.cluster || '-'; }, sortValue: pod => pod.cluster || '' },
+    { id: 'name', label: t('columns.name'), render: (cell, pod) => { cell.textContent = pod.name; }, sortValue: pod => pod.name },
+    { id: 'namespace', label: t('columns.namespace'), render: renderNamespaceCell, sortValue: pod => pod.namespace },
+    { id: 'phase', label: t('columns.phase'), render: renderPhaseCell, sortValue: pod => pod.phase },
+    { id: 'severity', label: t('columns.severity'), hidden: true, render: renderSeverityCell, sortValue: pod => severityRanks[podSeverity(pod)], descendingFirst: true },
+    { id: 'owner', label: t('columns.owner'), render: renderOwnerCell, sortValue: pod => pod.ownerKind ? pod.ownerKind + '/' + pod.ownerName : '' },
+    { id: 'team', label: t('columns.team'), hidden: true, render: (cell, pod) => { cell.textContent = pod.team || '-'; }, sortValue: pod => pod.team || '' },
+    { id: 'reason', label: t('columns.reason'), render: renderReasonCell, sortValue: pod => pod.reason || '' },
+    { id: 'restarts', label: t('columns.restarts'), hidden: true, render: (cell, pod) => { cell.textContent = String(podRestarts(pod)); }, sortValue: podRestarts, descendingFirst: true },
+    { id: 'node', label: t('columns.node'), hidden: true, render: (cell, pod) => { cell.textContent = pod.nodeName || '-'; }, sortValue: pod => pod.nodeName || '' },
+    { id: 'age', label: t('columns.age'), render: (cell, pod) => renderSinceCell(cell, pod.createdAt, 'columns.createdAt'), sortValue: pod => sinceMs(pod.createdAt), descendingFirst: true },
+    { id: 'nonReady', label: t('columns.nonReady'), render: (cell, pod) => renderSinceCell(cell, pod.detectedAt, 'columns.nonReadySince'), sortValue: pod => sinceMs(pod.detectedAt), descendingFirst: true },
+    { id: 'message', label: t('columns.message'), render: renderMessageCell },
+];
+const pageSizes = [25, 50, 100, 250, 0]; // 0 shows all pods on one page
+let tablePrefs = loadTablePrefs();
+let tablePage = 0;
+let showExpandedPodPage = true; // Open the page of the pod expanded from the URL or last visit
+
+// loadTablePrefs reads the column order and visibility and the page size this browser
+// saved. Columns added since are appended in their default state.
+function loadTablePrefs() {
+    let saved = {};
+    try {
+        saved = JSON.parse(localStorage.getItem('tablePrefs')) || {};
+    } catch (error) {
+        console.error('Ignoring invalid table preferences:', error);
+    }
+    const columns = (saved.columns || []).filter(c => tableColumns.some(t => t.id === c.id));
+    tableColumns.forEach(t => {
+        if (!columns.some(c => c.id === t.id)) {
+            columns.push({ id: t.id, visible: !t.hidden });
+        }
+    });
+    const sortable = saved.sort && tableColumns.some(t => t.id === saved.sort.column && t.sortValue);
+    return {
+        columns: columns,
+        pageSize: pageSizes.includes(saved.pageSize) ? saved.pageSize : 0,
+        sort: sortable ? saved.sort : { column: 'name', descending: false },
+    };
+}
+
+// onSortColumn sorts the table by a column, or reverses the order if it is sorted by it
+function onSortColumn(id) {
+    const column = tableColumns.find(t => t.id === id);
+    if (tablePrefs.sort.column === id) {
+        tablePrefs.sort.descending = !tablePrefs.sort.descending;
+    } else {
+        tablePrefs.sort = { column: id, descending: !!column.descendingFirst };
+    }
+    localStorage.setItem('tablePrefs', JSON.stringify(tablePrefs));
+    tablePage = 0;
+    renderTableHeader();
+    filterTable();
+}
+
+// comparePods orders pods by the sorted column, then by namespace and name
+function comparePods(a, b) {
+    const column = tableColumns.find(t => t.id === tablePrefs.sort.column);
+    const va = column.sortValue(a);
+    const vb = column.sortValue(b);
+    let order = typeof va === 'number' ? va - vb : String(va).localeCompare(String(vb));
+    if (tablePrefs.sort.descending) order = -order;
+    return order || getPodKey(a).localeCompare(getPodKey(b));
+}
+
+function saveTablePrefs() {
+    localStorage.setItem('tablePrefs', JSON.stringify(tablePrefs));
+    renderColumnSettings();
+    renderTableHeader();
+    renderTable();
+}
+
+function resetTablePrefs() {
+    localStorage.removeItem('tablePrefs');
+    tablePrefs = loadTablePrefs();
+    saveTablePrefs();
+}
+
+// visibleColumns leaves out the cluster column unless other clusters push to this one
+function visibleColumns() {
+    return tablePrefs.columns.filter(c => c.visible && (multiCluster || c.id !== 'cluster')).map(c => tableColumns.find(t => t.id === c.id));
+}
+
+function isColumnVisible(id) {
+    return tablePrefs.columns.some(c => c.id === id && c.visible);
+}
+
+// tableWidth is the number of columns rows spanning the table cover
+function tableWidth() {
+    return visibleColumns().length + 2;
+}
+
+function renderTableHeader() {
+    const header = document.getElementById('podsTableHeader');
+    header.innerHTML = '<th style="width: 30px;"></th>';
+    visibleColumns().forEach(column => {
+        const th = document.createElement('th');
+        th.textContent = column.label;
+        if (column.sortValue) {
+            th.className = 'sortable';
+            th.title = t('columns.sortBy', { column: column.label });
+            th.onclick = () => onSortColumn(column.id);
+            if (tablePrefs.sort.column === column.id) {
+                th.textContent += tablePrefs.sort.descending ? ' ▼' : ' ▲';
+            }
+        }
+        header.appendChild(th);
+    });
+    const actions = document.createElement('th');
+    actions.style.width = '1%';
+    header.appendChild(actions);
+}
+
+// renderColumnSettings lists the columns with a visibility checkbox and buttons moving
+// them up and down
+function renderColumnSettings() {
+    const list = document.getElementById('columnList');
+    list.innerHTML = '';
+    tablePrefs.columns.forEach((pref, i) => {
+        const column = tableColumns.find(t => t.id === pref.id);
+        const item = document.createElement('div');
+        item.className = 'column-setting';
+        const label = document.createElement('label');
+        const checkbox = document.createElement('input');
+        checkbox.type = 'checkbox';
+        checkbox.checked = pref.visible;
+        checkbox.onchange = () => {
+            pref.visible = checkbox.checked;
+            saveTablePrefs();
+        };
+        label.appendChild(checkbox);
+        label.appendChild(document.createTextNode(' ' + column.label));
+        item.appendChild(label);
+        [['↑', -1], ['↓', 1]].forEach(([arrow, step]) => {
+            const move = document.createElement('button');
+            move.className = 'column-move';
+            move.textContent = arrow;
+            move.disabled = i + step < 0 || i + step >= tablePrefs.columns.length;
+            move.onclick = () => {
+                const columns = tablePrefs.columns;
+                [columns[i], columns[i + step]] = [columns[i + step], columns[i]];
+                saveTablePrefs();
+            };
+            item.appendChild(move);
+        });
+        list.appendChild(item);
+    });
+    document.getElementById('pageSize').value = String(tablePrefs.pageSize);
+}
+
+function onPageSizeChange() {
+    tablePrefs.pageSize = parseInt(document.getElementById('pageSize').value, 10) || 0;
+    tablePage = 0;
+    saveTablePrefs();
+}
+
+function goToPage(page) {
+    tablePage = page;
+    renderTable();
+    document.getElementById('podsTable').scrollIntoView({ behavior: 'smooth', block: 'start' });
+}
+
+function renderPagination(pageCount) {
+    const pagination = document.getElementById('pagination');
+    pagination.innerHTML = '';
+    pagination.style.display = pageCount > 1 ? 'flex' : 'none';
+    if (pageCount <= 1) return;
+    const button = (text, page) => {
+        const btn = document.createElement('button');
+        btn.className = 'refresh-btn';
+        btn.textContent = text;
+        btn.disabled = page < 0 || page >= pageCount;
+        btn.onclick = () => goToPage(page);
+        pagination.appendChild(btn);
+    };
+    button('‹ ' + t('pagination.previous'), tablePage - 1);
+    const info = document.createElement('span');
+    info.textContent = t('pagination.page', { page: tablePage + 1, pages: pageCount }) + ' · ' + tn('count.pods', filteredPods.length);
+    pagination.appendChild(info);
+    button(t('pagination.next') + ' ›', tablePage + 1);
+}
+
+function renderNamespaceCell(cell, pod) {
+    cell.textContent = pod.namespace;
+    if (pod.team && !document.getElementById('groupByTeam').checked && !isColumnVisible('team')) {
+        const teamBadge = document.createElement('span');
+        teamBadge.className = 'badge badge-team';
+        teamBadge.textContent = pod.team;
+        cell.appendChild(teamBadge);
+    }
+}
+
+function renderPhaseCell(cell, pod) {
+    const statusContainer = document.createElement('span');
+    statusContainer.className = 'status-cell';
+    const statusIndicator = document.createElement('span');
+    statusIndicator.className = 'status-indicator status-' + pod.phase.toLowerCase();
+    const phaseText = document.createTextNode(pod.phase);
+    statusContainer.appendChild(statusIndicator);
+    statusContainer.appendChild(phaseText);
+    cell.appendChild(statusContainer);
+    if (pod.suppressed) {
+        const maintenanceBadge = document.createElement('span');
+        maintenanceBadge.className = 'badge badge-maintenance';
+        maintenanceBadge.textContent = '🔧 ' + pod.suppressedBy;
+        maintenanceBadge.title = t('badge.maintenanceTitle', { window: pod.suppressedBy });
+        cell.appendChild(document.createElement('br'));
+        cell.appendChild(maintenanceBadge);
+    }
+    if (pod.silenced) {
+        const silencedBadge = document.createElement('span');
+        silencedBadge.className = 'badge badge-silenced';
+        silencedBadge.textContent = '🔕 ' + t('badge.silenced');
+        silencedBadge.title = t('badge.silencedTitle', { silence: pod.silencedBy });
+        cell.appendChild(document.createElement('br'));
+        cell.appendChild(silencedBadge);
+    }
+    if (pod.acknowledged) {
+        const ackBadge = document.createElement('span');
+        ackBadge.className = 'badge badge-acknowledged';
+        ackBadge.textContent = '✋ ' + pod.acknowledged.by;
+        ackBadge.title = t('badge.acknowledgedTitle', { by: pod.acknowledged.by, until: formatDateTime(pod.acknowledged.until) }) +
+            (pod.acknowledged.comment ? ': ' + pod.acknowledged.comment : '');
+        cell.appendChild(document.createElement('br'));
+        cell.appendChild(ackBadge);
+    }
+    if (pod.withinBaseline) {
+        const baselineBadge = document.createElement('span');
+        baselineBadge.className = 'badge badge-baseline';
+        baselineBadge.textContent = '〰 ' + t('badge.withinBaseline');
+        baselineBadge.title = t('badge.withinBaselineTitle');
+        cell.appendChild(document.createElement('br'));
+        cell.appendChild(baselineBadge);
+    }
+    if (pod.analysisPending) {
+        const pendingBadge = document.createElement('span');
+        pendingBadge.className = 'badge badge-pending';
+        pendingBadge.textContent = '⏳ ' + t('badge.analyzing');
+        pendingBadge.title = t('badge.analyzingTitle');
+        cell.appendChild(document.createElement('br'));
+        cell.appendChild(pendingBadge);
+    }
+}
+
+function renderSeverityCell(cell, pod) {
+    const severity = podSeverity(pod);
+    const badge = document.createElement('span');
+    badge.className = 'badge badge-severity-' + severity;
+    badge.textContent = t('severity.' + severity);
+    cell.appendChild(badge);
+}
+
+function renderOwnerCell(cell, pod) {
+    if (pod.ownerKind && pod.ownerName) {
+        const badge = document.createElement('span');
+        badge.className = 'badge badge-' + pod.ownerKind.toLowerCase();
+        badge.textContent = pod.ownerKind + ': ' + pod.ownerName;
+        cell.appendChild(badge);
+    } else {
+        cell.textContent = '-';
+    }
+}
+
+function renderReasonCell(cell, pod) {
+    if (pod.reason) {
+        const badge = document.createElement('span');
+        badge.className = 'badge badge-error';
+        badge.textContent = pod.reason;
+        cell.appendChild(badge);
+    } else {
+        cell.textContent = '-';
+    }
+}
+
+// podRestarts sums the restarts of the pod's failing containers
+function podRestarts(pod) {
+    const restarts = new Map();
+    (pod.containerErrors || []).forEach(ce => {
+        restarts.set(ce.containerName, Math.max(restarts.get(ce.containerName) || 0, ce.restartCount || 0));
+    });
+    return [...restarts.values()].reduce((sum, n) => sum + n, 0);
+}
+
+// sinceMs returns how long ago a timestamp was, -1 if there is none
+function sinceMs(timestamp) {
+    return timestamp ? Date.now() - new Date(timestamp).getTime() : -1;
+}
+
+// durationUnits are the units of formatDuration and formatAge, largest first
+const durationUnits = [['duration.days', 86400], ['duration.hours', 3600], ['duration.minutes', 60], ['duration.seconds', 1]];
+
+// formatDuration renders a duration with its two largest units, e.g. "3d 4h" or "20s"
+function formatDuration(ms) {
+    let seconds = Math.max(0, Math.floor(ms / 1000));
+    const parts = [];
+    for (const [unit, size] of durationUnits) {
+        if (seconds >= size || (size === 1 && parts.length === 0)) {
+            parts.push(t(unit, { n: Math.floor(seconds / size) }));
+            seconds %= size;
+        }
+        if (parts.length === 2 || (parts.length === 1 && seconds === 0)) break;
+    }
+    return parts.join(' ');
+}
+
+// renderSinceCell shows how long ago a timestamp was, with the exact time on hover. The
+// text is kept current by refreshRelativeTimes.
+function renderSinceCell(cell, timestamp, titleKey) {
+    if (!timestamp) {
+        cell.textContent = '-';
+        return;
+    }
+    cell.className = 'since-cell';
+    cell.dataset.since = timestamp;
+    cell.textContent = formatDuration(sinceMs(timestamp));
+    cell.title = t(titleKey, { time: formatDateTime(timestamp) });
+}
+
+function refreshRelativeTimes() {
+    document.querySelectorAll('#podsTableBody [data-since]').forEach(cell => {
+        cell.textContent = formatDuration(sinceMs(cell.dataset.since));
+    });
+}
+
+function renderMessageCell(cell, pod, index) {
+    cell.style.cssText = 'vertical-align: top; padding: 8px;';
+
+    // Extract and highlight log analysis message if present
+    let displayMessage = pod.message || '-';
+    let logAnalysisMessage = '';
+
+    // Check for log analysis in multiple ways (handle both camelCase and PascalCase)
+    if (pod.logAnalysis) {
+        logAnalysisMessage = pod.logAnalysis.rootCause || pod.logAnalysis.RootCause || '';
+    }
+
+    // Extract log analysis from message if it was appended by controller
+    // The controller appends ". Log analysis: ..." to the message
+    // We want to show both separately: log analysis prominently, then original Kubernetes message
+    let originalKubernetesMessage = displayMessage;
+    if (displayMessage && typeof displayMessage === 'string' && displayMessage.includes('Log analysis:')) {
+        const parts = displayMessage.split('Log analysis:');
+        if (parts.length > 1) {
+            // If we don't have logAnalysis from object, use the one from message
+            if (!logAnalysisMessage || logAnalysisMessage === '') {
+                logAnalysisMessage = parts[1].trim();
+            }
+            // Get the original Kubernetes message (before log analysis was appended)
+            originalKubernetesMessage = parts[0].trim();
+            // Remove trailing period and space if present
+            if (originalKubernetesMessage.endsWith('.')) {
+                originalKubernetesMessage = originalKubernetesMessage.slice(0, -1).trim();
+            }
+        }
+    }
+
+    // Build message cell - show original Kubernetes message first, then log analysis
+    cell.innerHTML = '';
+
+    // First line: Original Kubernetes status message (always show if exists)
+    if (originalKubernetesMessage && originalKubernetesMessage !== '-' && originalKubernetesMessage !== null && originalKubernetesMessage !== '') {
+        const msgLine = document.createElement('div');
+        msgLine.style.cssText = 'font-size: 12px; color: #666; line-height: 1.4; margin-bottom: 4px;';
+        let msgText = originalKubernetesMessage;
+        if (msgText.length > 100) {
+            msgText = msgText.substring(0, 100) + '...';
+        }
+        msgLine.textContent = msgText;
+        cell.appendChild(msgLine);
+    } else if (!logAnalysisMessage || logAnalysisMessage === '') {
+        // No log analysis - show message or default
+        if (displayMessage && displayMessage !== '-') {
+            cell.textContent = displayMessage.length > 100 ? displayMessage.substring(0, 100) + '...' : displayMessage;
+        } else {
+            cell.textContent = '-';
+            cell.style.cssText = '';
+        }
+    }
+
+    // Second line: Log analysis clickable link (if present)
+    if (pod.logAnalysis && (pod.logAnalysis.patternResult || pod.logAnalysis.aiResult || pod.logAnalysis.metricsResult || pod.logAnalysis.certificateResult || pod.logAnalysis.initContainerResult || pod.logAnalysis.configResult)) {
+        const logAnalysisLink = document.createElement('div');
+        logAnalysisLink.style.cssText = 'margin-top: 8px; padding: 8px; background: #fff3cd; border-left: 3px solid #ffc107; border-radius: 4px; cursor: pointer; transition: background 0.2s;';
+        logAnalysisLink.onmouseover = function() { this.style.background = '#ffe69c'; };
+        logAnalysisLink.onmouseout = function() { this.style.background = '#fff3cd'; };
+        logAnalysisLink.onclick = function(e) {
+            e.stopPropagation();
+            toggleDetails(index);
+            // Scroll to details after a short delay
+            setTimeout(() => {
+                const detailsRow = document.getElementById('details-' + index);
+                if (detailsRow && detailsRow.classList.contains('expanded')) {
+                    detailsRow.scrollIntoView({ behavior: 'smooth', block: 'nearest' });
+                }
+            }, 100);
+        };
+
+        // Build summary
+        let summaryParts = [];
+        if (pod.logAnalysis.patternResult && pod.logAnalysis.patternResult.rootCause) {
+            summaryParts.push(t('analysis.summaryPattern', { pattern: pod.logAnalysis.patternResult.matchedPattern }));
+        }
+        if (pod.logAnalysis.aiResult && pod.logAnalysis.aiResult.rootCause) {
+            summaryParts.push(t('analysis.summaryAI', { model: pod.logAnalysis.aiResult.model }));
+        }
+        if (pod.logAnalysis.metricsResult && pod.logAnalysis.metricsResult.findings) {
+            summaryParts.push(tn('analysis.summaryMetrics', pod.logAnalysis.metricsResult.findings.length));
+        }
+        if (pod.logAnalysis.certificateResult && pod.logAnalysis.certificateResult.certificates) {
+            summaryParts.push(t('analysis.summaryCertificates', { count: pod.logAnalysis.certificateResult.certificates.length }));
+        }
+        if (pod.logAnalysis.initContainerResult) {
+            summaryParts.push(t('analysis.summaryInit', pod.logAnalysis.initContainerResult));
+        }
+        if (pod.logAnalysis.configResult && pod.logAnalysis.configResult.missing) {
+            summaryParts.push(t('analysis.summaryConfig', { count: pod.logAnalysis.configResult.missing.length }));
+        }
+
+        logAnalysisLink.innerHTML = '<div style="display: flex; align-items: center; gap: 8px;">' +
+            '<span style="font-size: 16px;">🔍</span>' +
+            '<div style="flex: 1;">' +
+            '<strong style="color: #856404; font-size: 13px;">' + escapeHtml(t('analysis.found')) + '</strong>' +
+            (summaryParts.length > 0 ? '<div style="font-size: 11px; color: #856404; margin-top: 2px;">(' + escapeHtml(summaryParts.join(' • ')) + ')</div>' : '') +
+            '</div>' +
+            '</div>';
+
+        cell.appendChild(logAnalysisLink);
+    }
+}
+
+// renderGroupRow adds the header row of a workload or namespace group, which collapses
+// and expands the pods of the group
+function renderGroupRow(tbody, groupKey, groupPods) {
+    const pod = groupPods[0];
+    const expanded = expandedGroups.has(groupKey);
+    const groupRow = tbody.insertRow();
+    groupRow.className = 'group-row ' + (groupBy === 'namespace' ? 'namespace-group-row' : 'owner-group-row');
+    groupRow.dataset.groupHeader = groupKey;
+    groupRow.onclick = () => toggleGroup(groupKey);
+    const groupCell = groupRow.insertCell(0);
+    groupCell.colSpan = tableWidth();
+
+    const icon = document.createElement('span');
+    icon.className = 'expand-icon';
+    icon.textContent = expanded ? '▼' : '▶';
+    groupCell.appendChild(icon);
+
+    const title = document.createElement('span');
+    const counts = document.createElement('span');
+    counts.className = 'group-counts';
+    let workload = null;
+    if (groupBy === 'namespace') {
+        const workloads = new Set(groupPods.filter(p => p.ownerKind).map(getOwnerGroupKey));
+        title.textContent = '📁 ' + (pod.remote ? pod.cluster + ' / ' : '') + pod.namespace;
+        counts.textContent = workloads.size
+            ? t('group.podsInWorkloads', { pods: tn('count.pods', groupPods.length), workloads: tn('count.workloads', workloads.size) })
+            : tn('count.pods', groupPods.length);
+    } else {
+        workload = workloadContexts[getOwnerGroupKey(pod)];
+        title.textContent = pod.ownerKind ? pod.ownerKind + ' ' + (pod.remote ? pod.cluster + ' / ' : '') + pod.namespace + '/' + pod.ownerName : t('group.noOwner');
+        if (workload && workload.desiredReplicas) {
+            // Replicas the workload wants but does not have ready, which includes pods not
+            // created yet, e.g. while a rollout is stuck
+            const unhealthy = Math.max(workload.desiredReplicas - workload.readyReplicas, groupPods.length);
+            counts.textContent = t('group.replicasUnhealthy', { unhealthy: unhealthy, desired: workload.desiredReplicas });
+        } else {
+            counts.textContent = tn('count.pods', groupPods.length);
+        }
+    }
+    groupCell.appendChild(title);
+    groupCell.appendChild(counts);
+
+    const reasons = [...new Set(groupPods.map(p => p.reason).filter(Boolean))];
+    if (reasons.length > 0) {
+        const reasonBadges = document.createElement('span');
+        reasonBadges.className = 'group-reasons';
+        reasons.slice(0, 3).forEach(reason => {
+            const badge = document.createElement('span');
+            badge.className = 'badge badge-error';
+            badge.textContent = reason + ' ×' + groupPods.filter(p => p.reason === reason).length;
+            reasonBadges.appendChild(badge);
+        });
+        if (reasons.length > 3) {
+            reasonBadges.appendChild(document.createTextNode(' ' + t('group.moreReasons', { count: reasons.length - 3 })));
+        }
+        groupCell.appendChild(reasonBadges);
+    }
+
+    if (workload && workload.summary) {
+        const summary = document.createElement('div');
+        summary.style.cssText = 'font-weight: 400; font-size: 12px; color: ' + (workload.hpa && (workload.hpa.atMaxReplicas || workload.hpa.metricsUnavailable) ? '#721c24' : '#666') + '; margin-top: 2px;';
+        summary.textContent = '📊 ' + workload.summary;
+        groupCell.appendChild(summary);
+    }
+}
+
+// markGroupMember ties a pod or details row to its group, hiding it while the group is collapsed
+function markGroupMember(row, groupKey) {
+    if (!groupKey) return;
+    row.dataset.group = groupKey;
+    if (!expandedGroups.has(groupKey)) {
+        row.classList.add('group-collapsed');
+    }
+}
+
+function toggleGroup(groupKey) {
+    const expanded = !expandedGroups.has(groupKey);
+    if (expanded) {
+        expandedGroups.add(groupKey);
+    } else {
+        expandedGroups.delete(groupKey);
+    }
+    document.querySelectorAll('#podsTableBody tr').forEach(row => {
+        if (row.dataset.group === groupKey) {
+            row.classList.toggle('group-collapsed', !expanded);
+        } else if (row.dataset.groupHeader === groupKey) {
+            row.querySelector('.expand-icon').textContent = expanded ? '▼' : '▶';
+        }
+    });
+}
+
+// Replaces the details of a pod with its full details, which hold the error lines,
+// terminations and debug check output the status leaves out for its report or size
+async function loadPodDetails(index) {
+    const pod = filteredPods[index];
+    // Only the operator of a pod's cluster can read its events and full report
+    if (!pod || pod.remote) return;
+    loadPodEvents(pod);
+    if (!pod.report && !(pod.logAnalysis && pod.logAnalysis.errorLinesOmitted)) return;
+    try {
+        const response = await fetch(basePath + '/api/pods/' + encodeURIComponent(pod.namespace) + '/' + encodeURIComponent(pod.name));
+        if (!response.ok) return;
+        const detail = await response.json();
+        const detailsRow = document.getElementById('details-' + index);
+        if (detailsRow && filteredPods[index] === pod) {
+            detailsRow.cells[0].innerHTML = renderDetails(detail.pod);
+        }
+    } catch (error) {
+        console.error('Error loading pod details:', error);
+    }
+}
+
+function toggleDetails(index) {
+    const detailsRow = document.getElementById('details-' + index);
+    const icon = document.getElementById('expand-icon-' + index);
+    const pod = filteredPods[index];
+    const podKey = pod ? getPodKey(pod) : '';
+
+    if (detailsRow.classList.contains('expanded')) {
+        // Closing details
+        detailsRow.classList.remove('expanded');
+        icon.textContent = '▶';
+        expandedRows.delete(index);
+        if (podKey && lastExpandedPodKey === podKey) {
+            lastExpandedPodKey = '';
+            localStorage.removeItem('lastExpandedPod');
+            syncUrlState();
+        }
+    } else {
+        // Opening details - FIRST COLLAPSE ALL OTHERS (Mutual Exclusion)
+        expandedRows.forEach(prevIndex => {
+            if (prevIndex !== index) {
+                const prevRow = document.getElementById('details-' + prevIndex);
+                const prevIcon = document.getElementById('expand-icon-' + prevIndex);
+                if (prevRow) prevRow.classList.remove('expanded');
+                if (prevIcon) prevIcon.textContent = '▶';
+            }
+        });
+        expandedRows.clear();
+
+        detailsRow.classList.add('expanded');
+        icon.textContent = '▼';
+        expandedRows.add(index);
+        loadPodDetails(index);
+        if (podKey) {
+            lastExpandedPodKey = podKey;
+            localStorage.setItem('lastExpandedPod', podKey);
+            syncUrlState();
+        }
+    }
+}
+
+
+
+function renderDetails(pod) {
+    let html = '<div class="details-content">';
+
+    // Pod Name Header
+    html += '<h3 style="margin-top: 0; margin-bottom: 20px; color: #333; border-bottom: 2px solid #eee; padding-bottom: 10px; display: flex; align-items: center; gap: 10px;">';
+    html += '<span style="font-size: 24px;">📦</span> ' + escapeHtml(t('details.pod', { name: pod.name })) + ' <small style="color: #666; font-weight: normal; font-size: 14px;">(' + escapeHtml(pod.namespace) + ')</small>';
+    html += '</h3>';
+
+    // Silence: acknowledge a known issue until it expires
+    html += '<div class="details-section">';
+    html += '<h4>🔕 ' + escapeHtml(t('details.silence')) + '</h4>';
+    if (pod.silenced && pod.remote) {
+        html += '<div class="container-error-detail">' + tHtml('details.silencedBy', { silence: '<strong>' + escapeHtml(pod.silencedBy) + '</strong>' }) + '</div>';
+    } else if (pod.silenced) {
+        html += '<div class="container-error-detail">' + tHtml('details.silencedBy', { silence: '<strong>' + escapeHtml(pod.silencedBy) + '</strong>' }) + '</div>';
+        html += '<button onclick="removeSilence(this)" data-silence-name="' + escapeHtml(pod.silencedBy) + '" class="refresh-btn" style="background: #6c757d; font-size: 12px; padding: 6px 12px; margin-top: 8px;">' + escapeHtml(t('details.removeSilence')) + '</button>';
+    } else if (!pod.remote) {
+        html += '<button onclick="silencePod(this)" data-pod-name="' + escapeHtml(pod.name) + '" data-pod-namespace="' + escapeHtml(pod.namespace) + '" data-owner-kind="' + escapeHtml(pod.ownerKind || '') + '" data-owner-name="' + escapeHtml(pod.ownerName || '') + '" data-reason="' + escapeHtml(pod.reason || '') + '" class="refresh-btn" style="background: #6f42c1; font-size: 12px; padding: 6px 12px;">' + escapeHtml(t('details.silenceThis')) + '</button>';
+    }
+    html += '<span class="silence-status" style="margin-left: 8px; font-size: 12px; color: #666;"></span>';
+    html += '</div>';
+
+    if (pod.acknowledged) {
+        html += '<div class="details-section">';
+        html += '<h4>✋ ' + escapeHtml(t('details.acknowledged')) + '</h4>';
+        html += '<div class="container-error-detail">' + tHtml('details.acknowledgedBy', { by: '<strong>' + escapeHtml(pod.acknowledged.by) + '</strong>', until: escapeHtml(formatDateTime(pod.acknowledged.until)) }) +
+            (pod.acknowledged.comment ? ': ' + escapeHtml(pod.acknowledged.comment) : '') + '</div>';
+        html += '</div>';
+    }
+
+    // Container Errors
+    if (pod.containerErrors && pod.containerErrors.length > 0) {
+        html += '<div class="details-section">';
+        html += '<h4>' + escapeHtml(t('details.containerErrors', { count: pod.containerErrors.length })) + '</h4>';
+        pod.containerErrors.forEach(err => {
+            html += '<div class="container-error">';
+            html += '<div class="container-error-header">';
+            html += err.containerName + ' (' + err.type + ')';
+            if (err.state) {
+                html += ' - ' + escapeHtml(t('details.state')) + ': ' + err.state;
+            }
+            html += '</div>';
+            if (err.reason) {
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.reason')) + ':</strong> ' + err.reason + '</div>';
+            }
+            if (err.message) {
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.message')) + ':</strong> ' + err.message + '</div>';
+            }
+            if (err.exitCode !== null && err.exitCode !== undefined) {
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.exitCode')) + ':</strong> ' + err.exitCode + '</div>';
+            }
+            if (err.restartCount !== null && err.restartCount !== undefined) {
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.restartCount')) + ':</strong> ' + err.restartCount + '</div>';
+            }
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.ready')) + ':</strong> ' + escapeHtml(t(err.ready ? 'common.yes' : 'common.no')) + '</div>';
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    // Pod Conditions
+    if (pod.podConditions && pod.podConditions.length > 0) {
+        html += '<div class="details-section">';
+        html += '<h4>' + escapeHtml(t('details.podConditions')) + '</h4>';
+        pod.podConditions.forEach(condition => {
+            const statusClass = 'condition-' + condition.status.toLowerCase();
+            html += '<span class="pod-condition ' + statusClass + '">';
+            html += condition.type + ': ' + condition.status;
+            if (condition.reason) {
+                html += ' (' + condition.reason + ')';
+            }
+            html += '</span>';
+        });
+        html += '</div>';
+    }
+
+    // Service mesh sidecar diagnosis
+    if (pod.mesh) {
+        const mesh = pod.mesh;
+        html += '<div class="details-section">';
+        html += '<h4>🕸️ ' + escapeHtml(t('details.serviceMesh', { mesh: mesh.mesh })) + '</h4>';
+        html += '<div class="container-error">';
+        if (mesh.sidecar) {
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('mesh.sidecar')) + ':</strong> ' + escapeHtml(mesh.sidecar) + ' ' + (mesh.sidecarReady ? '✅ ' + escapeHtml(t('mesh.ready')) : '❌ ' + escapeHtml(t('mesh.notReady'))) + '</div>';
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('mesh.application')) + ':</strong> ' + (mesh.applicationReady ? '✅ ' + escapeHtml(t('mesh.ready')) : '❌ ' + escapeHtml(t('mesh.notReady'))) + '</div>';
+        } else {
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('mesh.sidecar')) + ':</strong> ❌ ' + escapeHtml(t('mesh.notInjected')) + '</div>';
+        }
+        if (mesh.issues && mesh.issues.length > 0) {
+            mesh.issues.forEach(issue => {
+                html += '<div class="container-error-detail">⚠️ ' + escapeHtml(issue) + '</div>';
+            });
+        }
+        html += '</div>';
+        html += '</div>';
+    }
+
+    // Connectivity checks of hosts found by log analysis
+    if (pod.connectivity && pod.connectivity.length > 0) {
+        html += '<div class="details-section">';
+        html += '<h4>🔌 ' + escapeHtml(t('details.connectivity')) + '</h4>';
+        html += '<div class="container-error">';
+        pod.connectivity.forEach(result => {
+            const ok = result.resolved && result.reachable !== false && !(result.blockingNetworkPolicies && result.blockingNetworkPolicies.length);
+            html += '<div class="container-error-detail">' + (ok ? '✅ ' : '❌ ') + escapeHtml(result.summary) + '</div>';
+        });
+        html += '</div>';
+        html += '</div>';
+    }
+
+    // Ephemeral debug container checks
+    if (pod.debugDiagnostics) {
+        const debug = pod.debugDiagnostics;
+        html += '<div class="details-section">';
+        html += '<h4>🔧 ' + escapeHtml(t('details.debugDiagnostics', { state: debug.state })) + '</h4>';
+        html += '<div class="container-error">';
+        if (debug.error) {
+            html += '<div class="container-error-detail">❌ ' + escapeHtml(debug.error) + '</div>';
+        } else if (debug.state === 'Running') {
+            html += '<div class="container-error-detail">' + escapeHtml(t('details.debugRunning', { container: debug.containerName })) + '</div>';
+        }
+        if (debug.findings) {
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.findings')) + ':</strong> ' + escapeHtml(debug.findings) + '</div>';
+        }
+        if (debug.checks && debug.checks.length > 0) {
+            debug.checks.forEach(check => {
+                let line = (check.passed ? '✅ ' : '❌ ') + check.type.toUpperCase() + ' ' + check.target;
+                if (check.output) line += ' • ' + check.output;
+                html += '<div class="container-error-detail" style="font-size: 12px; font-family: monospace;">' + escapeHtml(line) + '</div>';
+            });
+        }
+        html += '</div>';
+        html += '</div>';
+    }
+
+    // Crash-loop trend across restarts
+    if (pod.crashLoopTrend) {
+        const trend = pod.crashLoopTrend;
+        html += '<div class="details-section" style="border-top: 3px solid #dc3545; padding-top: 16px; margin-top: 16px;">';
+        html += '<h4 style="color: #721c24; font-size: 16px; margin-bottom: 12px;">📉 ' + escapeHtml(t('details.crashLoopTrend')) + '</h4>';
+        html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px;">';
+        html += '<div class="container-error-detail" style="font-size: 15px; color: #721c24; font-weight: 700; margin-bottom: 8px;">' + escapeHtml(trend.summary) + '</div>';
+        html += '<div class="container-error-detail"><strong>' + escapeHtml(t('details.container')) + ':</strong> ' + escapeHtml(trend.containerName) + '</div>';
+        if (trend.terminations && trend.terminations.length > 0) {
+            html += '<div class="container-error-detail" style="margin-top: 8px;"><strong>' + escapeHtml(t('details.recentTerminations')) + ':</strong></div>';
+            trend.terminations.forEach(termination => {
+                let line = formatDateTime(termination.finishedAt) + ' • ' + t('details.exit', { code: termination.exitCode });
+                if (termination.reason) line += ' (' + termination.reason + ')';
+                if (termination.runtimeSeconds) line += ' • ' + t('details.ran', { duration: formatDuration(termination.runtimeSeconds * 1000) });
+                if (termination.rootCause) line += ' • ' + termination.rootCause;
+                html += '<div class="container-error-detail" style="font-size: 12px; font-family: monospace;">• ' + escapeHtml(line) + '</div>';
+            });
+        }
+        html += '</div>';
+        html += '</div>';
+    }
+
+    // Log Analysis - Always Visible in Details
+    if (pod.logAnalysis && (pod.logAnalysis.patternResult || pod.logAnalysis.aiResult || pod.logAnalysis.metricsResult || pod.logAnalysis.certificateResult || pod.logAnalysis.initContainerResult || pod.logAnalysis.configResult)) {
+        html += '<div class="details-section" style="border-top: 3px solid #ffc107; padding-top: 16px; margin-top: 16px;">';
+        html += '<h4 style="color: #856404; font-size: 16px; margin-bottom: 12px;">🔍 ' + escapeHtml(t('analysis.results')) + '</h4>';
+
+        // Common Log Analysis Information (MOVED TO TOP)
+        html += '<div class="details-section" style="background: #f8f9fa; padding: 12px; border-radius: 4px; margin-bottom: 16px;">';
+
+        if (pod.logAnalysis.methods && pod.logAnalysis.methods.length > 0) {
+            html += '<div class="container-error-detail" style="margin-bottom: 4px;"><strong>' + escapeHtml(t('analysis.methods')) + ':</strong> ' + pod.logAnalysis.methods.join(', ') + '</div>';
+        }
+
+        if (pod.logAnalysis.analyzedAt) {
+            const analyzedDate = new Date(pod.logAnalysis.analyzedAt);
+            let cachedIcon = '';
+            if (pod.logAnalysis.cachedAt || pod.logAnalysis.cacheExpiresAt) {
+                cachedIcon = ' <span title="' + escapeHtml(t('analysis.cachedTitle')) + '" style="color: #28a745; font-weight: 600; font-size: 12px; margin-left: 8px;">' + escapeHtml(t('analysis.cached')) + ' ✓</span>';
+            }
+            html += '<div class="container-error-detail" style="margin-bottom: 4px;"><strong>' + escapeHtml(t('analysis.analyzedAt')) + ':</strong> ' + escapeHtml(formatDateTime(analyzedDate)) + cachedIcon + '</div>';
+        }
+
+        // Show cache expiration with countdown if available
+        if (pod.logAnalysis.cacheExpiresAt) {
+            const expiresDate = new Date(pod.logAnalysis.cacheExpiresAt);
+            const now = new Date();
+            const timeRemaining = expiresDate - now;
+
+            let timeRemainingText = '';
+            if (timeRemaining > 0) {
+                timeRemainingText = ' <span style="color: #28a745;">(' + escapeHtml(t('analysis.remaining', { duration: formatDuration(timeRemaining) })) + ')</span>';
+            } else {
+                timeRemainingText = ' <span style="color: #dc3545;">(' + escapeHtml(t('analysis.expired')) + ')</span>';
+            }
+
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.cacheValidUntil')) + ':</strong> ' + escapeHtml(formatDateTime(expiresDate)) + timeRemainingText + ' <span style="color: #28a745; font-weight: 600;">✓</span></div>';
+        } else {
+            // Fallback: Show cached timestamp with note to upgrade
+            if (pod.logAnalysis.cachedAt) {
+                const cachedDate = new Date(pod.logAnalysis.cachedAt);
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.cachedAt')) + ':</strong> ' + escapeHtml(formatDateTime(cachedDate)) + ' <span style="color: #28a745; font-weight: 600;">✓</span></div>';
+            }
+        }
+
+        // Error lines, which the status may leave out for the pod's report
+        if (pod.logAnalysis.errorLines && pod.logAnalysis.errorLines.length > 0) {
+            html += '<div class="container-error-detail" style="margin-top: 8px;"><strong>' + escapeHtml(t('analysis.errorLines')) + ':</strong></div>';
+            pod.logAnalysis.errorLines.forEach(line => {
+                html += '<div class="container-error-detail" style="font-size: 12px; font-family: monospace;">' + escapeHtml(line) + '</div>';
+            });
+        }
+        if (pod.logAnalysis.errorLinesOmitted) {
+            html += '<div class="container-error-detail" style="font-size: 12px; color: #666;">' + escapeHtml(tn('analysis.errorLinesOmitted', pod.logAnalysis.errorLinesOmitted)) + '</div>';
+        }
+
+        // Add "Run Analysis Again" button
+        html += '<div style="margin-top: 12px;' + (pod.remote ? ' display: none;' : '') + '">';
+        html += '<button onclick="runAnalysisAgain(this)" data-pod-name="' + pod.name + '" data-pod-namespace="' + pod.namespace + '" class="refresh-btn" style="background: #17a2b8; font-size: 12px; padding: 6px 12px;">' + escapeHtml(t('analysis.runAgain')) + '</button>';
+        html += '<span class="run-analysis-status" style="margin-left: 8px; font-size: 12px; color: #666;"></span>';
+        html += '</div>';
+
+        html += '</div>';
+
+        // Pattern Analysis
+        if (pod.logAnalysis.patternResult) {
+            html += '<div class="details-section" style="border-top: 2px solid #17a2b8; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #0c5460; font-size: 16px; margin-bottom: 12px;">🔍 ' + escapeHtml(t('analysis.pattern')) + '</h4>';
+
+            if (pod.logAnalysis.patternResult.error) {
+                html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px;">';
+                html += '<div style="display: flex; align-items: center; gap: 8px; margin-bottom: 8px;">';
+                html += '<span style="font-size: 24px;">⚠️</span>';
+                html += '<strong style="color: #721c24; font-size: 16px;">' + escapeHtml(t('analysis.patternFailed')) + '</strong>';
+                html += '</div>';
+                html += '<div class="container-error-detail" style="font-size: 14px; color: #721c24; font-family: monospace; background: #fff; padding: 8px; border-radius: 4px;">' + escapeHtml(pod.logAnalysis.patternResult.error) + '</div>';
+                html += '</div>';
+            } else {
+                html += '<div class="container-error" style="background: #d1ecf1; border-left: 4px solid #17a2b8; padding: 12px;">';
+
+                if (pod.logAnalysis.patternResult.rootCause) {
+                    html += '<div class="container-error-detail" style="font-size: 15px; color: #0c5460; font-weight: 700; margin-bottom: 8px;">' + escapeHtml(pod.logAnalysis.patternResult.rootCause) + '</div>';
+                }
+
+                if (pod.logAnalysis.patternResult.matchedPattern) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.matchedPattern')) + ':</strong> ' + escapeHtml(pod.logAnalysis.patternResult.matchedPattern) + '</div>';
+                }
+
+                if (pod.logAnalysis.patternResult.confidence !== null && pod.logAnalysis.patternResult.confidence !== undefined) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.confidence')) + ':</strong> ' + pod.logAnalysis.patternResult.confidence + '%</div>';
+                }
+
+                if (pod.logAnalysis.patternResult.priority !== null && pod.logAnalysis.patternResult.priority !== undefined) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.priority')) + ':</strong> ' + pod.logAnalysis.patternResult.priority + '</div>';
+                }
+
+                html += '</div>';
+            }
+
+            html += '</div>';
+        }
+
+        // AI Analysis
+        if (pod.logAnalysis.aiResult) {
+            html += '<div class="details-section" style="border-top: 2px solid #6f42c1; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #4c2a85; font-size: 16px; margin-bottom: 12px;">🤖 ' + escapeHtml(t('analysis.ai')) + '</h4>';
+
+            if (pod.logAnalysis.aiResult.error) {
+                html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px; animation: pulse 2s ease-in-out infinite;">';
+                html += '<div style="display: flex; align-items: center; gap: 8px; margin-bottom: 8px;">';
+                html += '<span style="font-size: 24px;">❌</span>';
+                html += '<strong style="color: #721c24; font-size: 16px;">' + escapeHtml(t('analysis.aiFailed')) + '</strong>';
+                html += '</div>';
+                html += '<div class="container-error-detail" style="font-size: 14px; color: #721c24; font-family: monospace; background: #fff; padding: 8px; border-radius: 4px; white-space: pre-wrap;">' + escapeHtml(pod.logAnalysis.aiResult.error) + '</div>';
+                if (pod.logAnalysis.aiResult.failedProviders && pod.logAnalysis.aiResult.failedProviders.length > 1) {
+                    html += '<div class="container-error-detail" style="margin-top: 8px;"><strong>' + escapeHtml(t('analysis.providersTried')) + ':</strong></div>';
+                    pod.logAnalysis.aiResult.failedProviders.forEach(p => {
+                        html += '<div class="container-error-detail" style="font-size: 12px; color: #721c24; font-family: monospace;">• ' + escapeHtml(p) + '</div>';
+                    });
+                }
+                html += '<div style="margin-top: 8px; padding: 8px; background: #fff3cd; border-radius: 4px; font-size: 12px; color: #856404;">';
+                html += '💡 <strong>' + escapeHtml(t('analysis.tip')) + ':</strong> ' + escapeHtml(t('analysis.aiTip'));
+                html += '</div>';
+                html += '</div>';
+            } else {
+                html += '<div class="container-error" style="background: #e7e3f4; border-left: 4px solid #6f42c1; padding: 12px;">';
+
+                if (pod.logAnalysis.aiResult.rootCause) {
+                    html += '<div class="container-error-detail" style="font-size: 15px; color: #4c2a85; font-weight: 700; margin-bottom: 8px;">' + escapeHtml(pod.logAnalysis.aiResult.rootCause) + '</div>';
+                }
+
+                if (pod.logAnalysis.aiResult.model) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.model')) + ':</strong> ' + escapeHtml(pod.logAnalysis.aiResult.model) + '</div>';
+                }
+
+                if (pod.logAnalysis.aiResult.provider) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.provider')) + ':</strong> ' + escapeHtml(pod.logAnalysis.aiResult.provider) + '</div>';
+                }
+
+                if (pod.logAnalysis.aiResult.groupSize > 1) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.sharedResult')) + ':</strong> ' + escapeHtml(t('analysis.sharedResultText', { count: pod.logAnalysis.aiResult.groupSize, pod: pod.logAnalysis.aiResult.sharedFrom })) + '</div>';
+                }
+
+                if (pod.logAnalysis.aiResult.confidence !== null && pod.logAnalysis.aiResult.confidence !== undefined) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.confidence')) + ':</strong> ' + pod.logAnalysis.aiResult.confidence + '%</div>';
+                }
+
+                if (pod.logAnalysis.aiResult.failedProviders && pod.logAnalysis.aiResult.failedProviders.length > 0) {
+                    html += '<div class="container-error-detail" style="margin-top: 6px;"><strong>' + escapeHtml(t('analysis.fallbackUsed')) + ':</strong> ' + escapeHtml(tn('analysis.providersFailedFirst', pod.logAnalysis.aiResult.failedProviders.length)) + '</div>';
+                    pod.logAnalysis.aiResult.failedProviders.forEach(p => {
+                        html += '<div class="container-error-detail" style="font-size: 12px; color: #666; font-family: monospace;">• ' + escapeHtml(p) + '</div>';
+                    });
+                }
+
+                html += '</div>';
+            }
+
+            html += '</div>';
+        }
+
+        // Metrics Analysis
+        if (pod.logAnalysis.metricsResult) {
+            html += '<div class="details-section" style="border-top: 2px solid #e6522c; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #a33a1c; font-size: 16px; margin-bottom: 12px;">📈 ' + escapeHtml(t('analysis.metrics')) + '</h4>';
+
+            if (pod.logAnalysis.metricsResult.error) {
+                html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px;">';
+                html += '<strong style="color: #721c24;">' + escapeHtml(t('analysis.metricsFailed')) + '</strong>';
+                html += '<div class="container-error-detail" style="font-size: 14px; color: #721c24; font-family: monospace; background: #fff; padding: 8px; border-radius: 4px; margin-top: 8px; white-space: pre-wrap;">' + escapeHtml(pod.logAnalysis.metricsResult.error) + '</div>';
+                html += '</div>';
+            } else if (pod.logAnalysis.metricsResult.findings && pod.logAnalysis.metricsResult.findings.length > 0) {
+                html += '<div class="container-error" style="background: #fbe9e4; border-left: 4px solid #e6522c; padding: 12px;">';
+                pod.logAnalysis.metricsResult.findings.forEach(f => {
+                    html += '<div class="container-error-detail" style="margin-bottom: 4px;"><strong>' + escapeHtml(f.name) + ' (' + escapeHtml(f.value) + '):</strong> ' + escapeHtml(f.rootCause) + '</div>';
+                });
+                if (pod.logAnalysis.metricsResult.confidence) {
+                    html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.confidence')) + ':</strong> ' + pod.logAnalysis.metricsResult.confidence + '%</div>';
+                }
+                html += '</div>';
+            } else {
+                html += '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('analysis.noMetricExceeded')) + '</div>';
+            }
+
+            html += '</div>';
+        }
+
+        // Init Container Analysis
+        if (pod.logAnalysis.initContainerResult) {
+            const init = pod.logAnalysis.initContainerResult;
+            html += '<div class="details-section" style="border-top: 2px solid #fd7e14; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #a04d05; font-size: 16px; margin-bottom: 12px;">🧱 ' + escapeHtml(t('analysis.initContainer')) + '</h4>';
+            html += '<div class="container-error" style="background: #fff4e6; border-left: 4px solid #fd7e14; padding: 12px;">';
+            html += '<div class="container-error-header" style="color: #a04d05; margin-bottom: 8px;">' + escapeHtml(t('analysis.initStep', init)) + '</div>';
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.initCause')) + ':</strong> ' + escapeHtml(init.cause) + '</div>';
+            if (init.dependency) {
+                html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.initDependency')) + ':</strong> ' + escapeHtml(init.dependency) + '</div>';
+            }
+            if (init.rootCause) {
+                html += '<div class="container-error-detail" style="margin-top: 8px;">' + escapeHtml(init.rootCause) + '</div>';
+            }
+            html += '</div>';
+            html += '</div>';
+        }
+
+        // Configuration Check
+        if (pod.logAnalysis.configResult) {
+            const config = pod.logAnalysis.configResult;
+            html += '<div class="details-section" style="border-top: 2px solid #20c997; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #13795b; font-size: 16px; margin-bottom: 12px;">🧩 ' + escapeHtml(t('analysis.config')) + '</h4>';
+
+            if (config.missing && config.missing.length > 0) {
+                html += '<div class="container-error" style="background: #e6f7f1; border-left: 4px solid #20c997; padding: 12px;">';
+                config.missing.forEach(m => {
+                    let text = t(m.kind === 'Env' ? 'analysis.configMissingEnv' : (m.key ? 'analysis.configMissingKey' : 'analysis.configMissingObject'), m);
+                    const notes = [];
+                    if (m.envVar) {
+                        notes.push(t('analysis.configReferencedBy', m));
+                    }
+                    if (m.mentionedInLogs) {
+                        notes.push(t('analysis.configMentioned'));
+                    }
+                    if (notes.length > 0) {
+                        text += ' (' + notes.join(', ') + ')';
+                    }
+                    html += '<div class="container-error-detail" style="margin-bottom: 4px;">❌ ' + escapeHtml(text) + '</div>';
+                });
+                html += '</div>';
+            } else if (!config.error) {
+                html += '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('analysis.noConfigMissing', { count: config.referencesChecked || 0 })) + '</div>';
+            }
+            if (config.error) {
+                html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px; margin-top: 8px;">';
+                html += '<div class="container-error-detail" style="color: #721c24;">' + escapeHtml(config.error) + '</div>';
+                html += '</div>';
+            }
+
+            html += '</div>';
+        }
+
+        // Certificate Analysis
+        if (pod.logAnalysis.certificateResult) {
+            const certs = pod.logAnalysis.certificateResult;
+            html += '<div class="details-section" style="border-top: 2px solid #6f42c1; padding-top: 12px; margin-top: 12px;">';
+            html += '<h4 style="color: #4b2c85; font-size: 16px; margin-bottom: 12px;">🔐 ' + escapeHtml(t('analysis.certificates')) + '</h4>';
+
+            if (certs.error) {
+                html += '<div class="container-error" style="background: #f8d7da; border-left: 4px solid #dc3545; padding: 12px;">';
+                html += '<div class="container-error-detail" style="color: #721c24;">' + escapeHtml(certs.error) + '</div>';
+                html += '</div>';
+            } else if (certs.certificates && certs.certificates.length > 0) {
+                html += '<div class="container-error" style="background: #efe8fa; border-left: 4px solid #6f42c1; padding: 12px;">';
+                certs.certificates.forEach(c => {
+                    const when = t(c.expired ? 'analysis.certificateExpired' : 'analysis.certificateExpires', { time: formatDateTime(c.notAfter) });
+                    html += '<div class="container-error-detail" style="margin-bottom: 4px;">' + (c.expired ? '❌ ' : '⚠️ ') + escapeHtml(when) + ': <strong>' + escapeHtml(c.subject) + '</strong> (' + escapeHtml(t('analysis.certificateSecret', { secret: c.secretName, key: c.key })) + ')</div>';
+                });
+                html += '</div>';
+            } else {
+                html += '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('analysis.noCertificateExpiring', { count: certs.secretsChecked || 0 })) + '</div>';
+            }
+
+            html += '</div>';
+        }
+
+        html += '</div>';
+    }
+
+    if (pod.remote) {
+        html += '<div class="details-section"><div class="container-error-detail" style="color: #666;">' +
+            escapeHtml(t('details.remoteCluster', { cluster: pod.cluster })) + '</div></div>';
+        html += '</div>';
+        return html;
+    }
+
+    // Kubernetes events, loaded when the details are opened
+    html += '<div class="details-section">';
+    html += '<h4>📅 ' + escapeHtml(t('details.events')) + '</h4>';
+    html += '<div class="pod-events" data-pod-key="' + escapeHtml(pod.namespace + '/' + pod.name) + '">' + renderPodEvents(podEvents.get(pod.namespace + '/' + pod.name)) + '</div>';
+    html += '</div>';
+
+    // Log viewer
+    const logKey = escapeHtml(pod.namespace + '/' + pod.name);
+    html += '<div class="details-section">';
+    html += '<h4>📜 ' + escapeHtml(t('details.logs')) + '</h4>';
+    html += '<div class="log-controls" data-pod-key="' + logKey + '">';
+    html += '<select class="log-container">';
+    (pod.containerErrors || []).forEach(err => {
+        html += '<option value="' + escapeHtml(err.containerName) + '">' + escapeHtml(err.containerName) + '</option>';
+    });
+    html += '</select>';
+    html += '<label>' + escapeHtml(t('logs.lines')) + ' <input type="number" class="log-tail" value="500" min="1" max="5000"></label>';
+    html += '<label><input type="checkbox" class="log-previous"> ' + escapeHtml(t('logs.previousRun')) + '</label>';
+    html += '<button onclick="loadPodLogs(this)" data-pod-name="' + escapeHtml(pod.name) + '" data-pod-namespace="' + escapeHtml(pod.namespace) + '" class="refresh-btn" style="font-size: 12px; padding: 6px 12px;">' + escapeHtml(t('logs.show')) + '</button>';
+    html += '<span class="log-status"></span>';
+    html += '</div>';
+    html += '<pre class="log-viewer" style="display: none;"></pre>';
+    html += '</div>';
+
+    html += '</div>';
+    return html;
+}
+
+// Events of pods by pod key, kept across re-renders of the table
+const podEvents = new Map();
+
+function renderPodEvents(entry) {
+    if (!entry) return '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('common.loading')) + '</div>';
+    if (entry.error) return '<div class="container-error-detail" style="color: #721c24;">' + escapeHtml(entry.error) + '</div>';
+    if (entry.events.length === 0) return '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('events.none')) + '</div>';
+    let html = '<table class="events-table"><tr>';
+    ['events.type', 'events.reason', 'events.age', 'events.count', 'events.from', 'events.message'].forEach(key => {
+        html += '<th>' + escapeHtml(t(key)) + '</th>';
+    });
+    html += '</tr>';
+    entry.events.forEach(e => {
+        html += '<tr class="' + (e.type === 'Warning' ? 'event-warning' : '') + '">';
+        html += '<td>' + escapeHtml(e.type) + '</td>';
+        html += '<td>' + escapeHtml(e.reason) + '</td>';
+        html += '<td title="' + escapeHtml(formatDateTime(e.lastSeen)) + '">' + escapeHtml(formatAge(e.lastSeen)) + '</td>';
+        html += '<td>' + e.count + '</td>';
+        html += '<td>' + escapeHtml(e.source || '') + '</td>';
+        html += '<td>' + escapeHtml(e.message) + '</td>';
+        html += '</tr>';
+    });
+    return html + '</table>';
+}
+
+// formatAge renders how long ago a timestamp was in its largest unit, e.g. "5m"
+function formatAge(timestamp) {
+    const seconds = Math.max(0, Math.floor((Date.now() - new Date(timestamp).getTime()) / 1000));
+    const [unit, size] = durationUnits.find(([, size]) => seconds >= size) || durationUnits[durationUnits.length - 1];
+    return t(unit, { n: Math.floor(seconds / size) });
+}
+
+// Loads the events of a pod into its details, at most every 30 seconds
+async function loadPodEvents(pod) {
+    const podKey = pod.namespace + '/' + pod.name;
+    const cached = podEvents.get(podKey);
+    if (cached && Date.now() - cached.loadedAt < 30000) return;
+    let entry;
+    try {
+        const response = await fetch(basePath + '/api/pods/' + encodeURIComponent(pod.namespace) + '/' + encodeURIComponent(pod.name) + '/events');
+        if (response.ok) {
+            entry = { events: (await response.json()).events || [], loadedAt: Date.now() };
+        } else {
+            entry = { error: t('events.loadFailed', { error: (await response.text()).trim() }), loadedAt: Date.now() };
+        }
+    } catch (error) {
+        entry = { error: t('events.loadFailed', { error: error.message }), loadedAt: Date.now() };
+    }
+    podEvents.set(podKey, entry);
+    document.querySelectorAll('.pod-events').forEach(el => {
+        if (el.dataset.podKey === podKey) el.innerHTML = renderPodEvents(entry);
+    });
+}
+
+// Shows a container's log in the details panel. Lines log analysis reported are marked
+// as matched, other error and warning lines as errors.
+async function loadPodLogs(btn) {
+    const d = btn.dataset;
+    const controls = btn.parentElement;
+    const viewer = controls.parentElement.querySelector('.log-viewer');
+    const status = controls.querySelector('.log-status');
+    const containerSelect = controls.querySelector('.log-container');
+    const params = new URLSearchParams();
+    if (containerSelect.value) params.set('container', containerSelect.value);
+    params.set('tail', controls.querySelector('.log-tail').value || '500');
+    if (controls.querySelector('.log-previous').checked) params.set('previous', 'true');
+
+    btn.disabled = true;
+    status.textContent = t('common.loading');
+    try {
+        const response = await fetch(basePath + '/api/pods/' + encodeURIComponent(d.podNamespace) + '/' + encodeURIComponent(d.podName) + '/logs?' + params);
+        if (!response.ok) {
+            status.textContent = t('common.error', { error: (await response.text()).trim() });
+            return;
+        }
+        const logs = await response.json();
+
+        // Offer every container of the pod once they are known
+        const known = Array.from(containerSelect.options).map(o => o.value);
+        (logs.containers || []).forEach(name => {
+            if (!known.includes(name)) containerSelect.add(new Option(name, name));
+        });
+        containerSelect.value = logs.container;
+
+        const pod = allPods.find(p => p.namespace === logs.namespace && p.name === logs.pod);
+        const matched = new Set(((pod && pod.logAnalysis && pod.logAnalysis.errorLines) || []).map(l => l.trim()));
+        const errorLines = new Set(logs.errorLines || []);
+        viewer.innerHTML = logs.lines.map((line, i) => {
+            let cls = '';
+            if (matched.has(line.trim())) cls = 'log-matched';
+            else if (errorLines.has(i)) cls = 'log-error';
+            return '<span class="' + cls + '">' + escapeHtml(line) + '</span>';
+        }).join('\n');
+        viewer.style.display = 'block';
+        viewer.scrollTop = viewer.scrollHeight;
+        status.textContent = tn('logs.status', logs.lines.length, { container: logs.container }) +
+            (logs.previous ? ' (' + t('logs.previousRun') + ')' : '') +
+            (logs.truncatedLines ? ', ' + t('logs.truncated', { count: logs.truncatedLines }) : '');
+    } catch (error) {
+        status.textContent = t('common.error', { error: error.message });
+    } finally {
+        btn.disabled = false;
+    }
+}
+
+function escapeHtml(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML;
+}
+
+async function runAnalysisAgain(btn) {
+    const originalText = btn.textContent;
+    const podName = btn.dataset.podName;
+    const podNamespace = btn.dataset.podNamespace;
+    const podKey = podNamespace + '/' + podName;
+    const statusSpan = btn.parentElement.querySelector('.run-analysis-status');
+
+    btn.disabled = true;
+    btn.innerHTML = '<span class="analysis-spinner"></span>' + escapeHtml(t('analysis.queued'));
+    if (statusSpan) { statusSpan.textContent = ''; statusSpan.style.color = '#666'; }
+
+    // Blur the details content to indicate activity
+    const detailsContent = btn.closest('.details-content');
+    if (detailsContent) {
+        detailsContent.style.transition = 'filter 0.3s';
+        detailsContent.style.filter = 'blur(2px)';
+        detailsContent.style.pointerEvents = 'none';
+    }
+
+    // showProgress shows the state of the analysis job on the button
+    const showProgress = job => {
+        const label = job.state === 'queued' ? t('analysis.queued') : t('analysis.running');
+        btn.innerHTML = '<span class="analysis-spinner"></span>' + escapeHtml(label);
+        if (statusSpan && job.total > 1) {
+            statusSpan.textContent = t('analysis.progress', { completed: job.completed, total: job.total });
+        }
+    };
+
+    try {
+        // Start an analysis job for the pod, bypassing the analysis cache
+        const response = await fetch(basePath + '/api/analyses', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({ pods: [{ namespace: podNamespace, name: podName }] }),
+        });
+        if (!response.ok) {
+            throw new Error(t('analysis.triggerFailed'));
+        }
+        let job = await response.json();
+
+        // Follow the job until the operator finished or gave up on it
+        const pollInterval = 1500;
+        while (job.state === 'queued' || job.state === 'running') {
+            showProgress(job);
+            await new Promise(resolve => setTimeout(resolve, pollInterval));
+            const jobResponse = await fetch(basePath + '/api/analyses/' + encodeURIComponent(job.id));
+            if (!jobResponse.ok) {
+                throw new Error(t('error.server', { status: jobResponse.status, text: jobResponse.statusText }));
+            }
+            job = await jobResponse.json();
+        }
+        if (job.state === 'failed') {
+            throw new Error(job.error || t('analysis.triggerFailed'));
+        }
+
+        const pod = job.pods.find(p => p.namespace === podNamespace && p.name === podName);
+        notifyAnalysisDone(podKey, pod && pod.result ? pod.result.rootCause : '');
+        if (detailsContent) {
+            detailsContent.style.filter = '';
+            detailsContent.style.pointerEvents = '';
+        }
+        await loadData();
+    } catch (error) {
+        console.error('Error running analysis:', error);
+        if (detailsContent) {
+            detailsContent.style.filter = '';
+            detailsContent.style.pointerEvents = '';
+        }
+        btn.style.background = '#dc3545';
+        btn.textContent = t('common.failedShort');
+        if (statusSpan) {
+            statusSpan.textContent = t('common.error', { error: error.message });
+            statusSpan.style.color = '#dc3545';
+        }
+        setTimeout(() => {
+            btn.disabled = false;
+            btn.textContent = originalText;
+            btn.style.background = '#17a2b8';
+            if (statusSpan) { statusSpan.textContent = ''; statusSpan.style.color = '#666'; }
+        }, 3000);
+    }
+}
+
+// Pattern editor: the error patterns of a PodSleuth, edited through the API
+let patternList = null; // Patterns of the selected PodSleuth, as last loaded
+let editingPattern = ''; // Name of the pattern in the form, empty when adding one
+let patternValidateTimer = null;
+
+function onPatternsToggle() {
+    if (!document.getElementById('patternsPanel').open) return;
+    const select = document.getElementById('patternsPodSleuth');
+    const selected = select.value;
+    select.innerHTML = '';
+    [...podSleuths.keys()].sort().forEach(name => {
+        const option = document.createElement('option');
+        option.value = name;
+        option.textContent = name;
+        select.appendChild(option);
+    });
+    if (selected && podSleuths.has(selected)) select.value = selected;
+    loadPatterns();
+}
+
+async function loadPatterns() {
+    const name = document.getElementById('patternsPodSleuth').value;
+    const status = document.getElementById('patternsStatus');
+    const listDiv = document.getElementById('patternsList');
+    patternList = null;
+    listDiv.innerHTML = '';
+    resetPatternForm();
+    if (!name) {
+        status.textContent = t('patterns.noPodSleuths');
+        return;
+    }
+    try {
+        const response = await fetch(basePath + '/api/podsleuths/' + encodeURIComponent(name) + '/patterns');
+        if (!response.ok) throw new Error((await response.text()).trim() || response.statusText);
+        renderPatterns(await response.json());
+    } catch (error) {
+        status.textContent = t('common.error', { error: error.message });
+    }
+}
+
+function renderPatterns(list) {
+    patternList = list;
+    document.getElementById('patternsStatus').textContent = list.defaults ? t('patterns.defaults') : tn('patterns.count', list.patterns.length);
+    let html = '<table class="pattern-table"><thead><tr><th>' + escapeHtml(t('patterns.name')) + '</th><th>' + escapeHtml(t('patterns.regex')) + '</th><th>' +
+        escapeHtml(t('patterns.rootCause')) + '</th><th>' + escapeHtml(t('patterns.priority')) + '</th><th></th></tr></thead><tbody>';
+    list.patterns.forEach(pattern => {
+        html += '<tr><td>' + escapeHtml(pattern.name) + '</td><td><code>' + escapeHtml(pattern.pattern) + '</code></td><td>' + escapeHtml(pattern.rootCause || '') + '</td><td>' + (pattern.priority || 0) + '</td><td>';
+        if (!list.defaults) {
+            html += '<button class="refresh-btn" style="font-size: 12px; padding: 4px 10px;" onclick="editPattern(this)" data-pattern="' + escapeHtml(pattern.name) + '">' + escapeHtml(t('patterns.edit')) + '</button> ';
+            html += '<button class="refresh-btn" style="font-size: 12px; padding: 4px 10px; background: #dc3545;" onclick="deletePattern(this)" data-pattern="' + escapeHtml(pattern.name) + '">' + escapeHtml(t('patterns.delete')) + '</button>';
+        }
+        html += '</td></tr>';
+    });
+    html += '</tbody></table>';
+    document.getElementById('patternsList').innerHTML = html;
+}
+
+function editPattern(btn) {
+    const pattern = patternList.patterns.find(p => p.name === btn.dataset.pattern);
+    if (!pattern) return;
+    editingPattern = pattern.name;
+    document.getElementById('patternName').value = pattern.name;
+    document.getElementById('patternRegex').value = pattern.pattern;
+    document.getElementById('patternRootCause').value = pattern.rootCause || '';
+    document.getElementById('patternPriority').value = pattern.priority || '';
+    document.getElementById('patternSaveBtn').textContent = t('patterns.save');
+    document.getElementById('patternRegexError').textContent = '';
+}
+
+function resetPatternForm() {
+    editingPattern = '';
+    ['patternName', 'patternRegex', 'patternRootCause', 'patternPriority'].forEach(id => { document.getElementById(id).value = ''; });
+    document.getElementById('patternSaveBtn').textContent = t('patterns.add');
+    document.getElementById('patternRegexError').textContent = '';
+}
+
+// formPattern returns the pattern in the form
+function formPattern() {
+    return {
+        name: document.getElementById('patternName').value.trim(),
+        pattern: document.getElementById('patternRegex').value,
+        rootCause: document.getElementById('patternRootCause').value.trim(),
+        priority: parseInt(document.getElementById('patternPriority').value, 10) || 0,
+    };
+}
+
+// onPatternRegexInput validates the regular expression as typed, with the operator's
+// regular expression syntax rather than the browser's
+function onPatternRegexInput() {
+    clearTimeout(patternValidateTimer);
+    patternValidateTimer = setTimeout(async () => {
+        const errorDiv = document.getElementById('patternRegexError');
+        const pattern = formPattern();
+        if (!pattern.pattern) {
+            errorDiv.textContent = '';
+            return;
+        }
+        try {
+            const response = await fetch(basePath + '/api/patterns/test', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ patterns: [{ ...pattern, name: pattern.name || '-' }] }),
+            });
+            if (!response.ok) return;
+            const result = await response.json();
+            errorDiv.textContent = result.errors && result.errors.length > 0 ? result.errors[0].error : '';
+        } catch (error) {
+            console.error('Pattern validation error', error);
+        }
+    }, 300);
+}
+
+async function savePattern(btn) {
+    const name = document.getElementById('patternsPodSleuth').value;
+    const pattern = formPattern();
+    if (!name || !pattern.name || !pattern.pattern) {
+        document.getElementById('patternRegexError').textContent = t('patterns.missing');
+        return;
+    }
+    if (!editingPattern && patternList && patternList.defaults && !confirm(t('patterns.replaceDefaults'))) return;
+
+    btn.disabled = true;
+    try {
+        let url = basePath + '/api/podsleuths/' + encodeURIComponent(name) + '/patterns';
+        if (editingPattern) url += '/' + encodeURIComponent(editingPattern);
+        const response = await fetch(url, {
+            method: editingPattern ? 'PUT' : 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(pattern),
+        });
+        if (!response.ok) throw new Error((await response.text()).trim() || response.statusText);
+        renderPatterns(await response.json());
+        resetPatternForm();
+    } catch (error) {
+        document.getElementById('patternRegexError').textContent = t('common.error', { error: error.message });
+    } finally {
+        btn.disabled = false;
+    }
+}
+
+async function deletePattern(btn) {
+    const name = document.getElementById('patternsPodSleuth').value;
+    const pattern = btn.dataset.pattern;
+    if (!confirm(t('patterns.deleteConfirm', { pattern }))) return;
+    btn.disabled = true;
+    try {
+        const response = await fetch(basePath + '/api/podsleuths/' + encodeURIComponent(name) + '/patterns/' + encodeURIComponent(pattern), { method: 'DELETE' });
+        if (!response.ok) throw new Error((await response.text()).trim() || response.statusText);
+        renderPatterns(await response.json());
+    } catch (error) {
+        btn.disabled = false;
+        document.getElementById('patternsStatus').textContent = t('common.error', { error: error.message });
+    }
+}
+
+// testPatterns matches pasted logs against the patterns, with the pattern in the form
+// as it would be saved
+async function testPatterns(btn) {
+    const status = document.getElementById('patternTestStatus');
+    const resultDiv = document.getElementById('patternTestResult');
+    let patterns = patternList && !patternList.defaults ? patternList.patterns.slice() : [];
+    const pattern = formPattern();
+    if (pattern.name && pattern.pattern) {
+        patterns = patterns.filter(p => p.name !== (editingPattern || pattern.name));
+        patterns.push(pattern);
+    }
+
+    btn.disabled = true;
+    status.textContent = '';
+    resultDiv.innerHTML = '';
+    try {
+        const response = await fetch(basePath + '/api/patterns/test', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ patterns, logs: document.getElementById('patternTestLogs').value }),
+        });
+        if (!response.ok) throw new Error((await response.text()).trim() || response.statusText);
+        const result = await response.json();
+        let html = '';
+        (result.errors || []).forEach(error => {
+            html += '<div class="container-error-detail" style="color: #dc3545;">' + escapeHtml(error.name + ': ' + error.error) + '</div>';
+        });
+        if (result.result) {
+            html += '<div class="container-error-detail"><strong>' + escapeHtml(t('patterns.rootCauseResult')) + ':</strong> ' + escapeHtml(result.result.rootCause || '') +
+                (result.result.matchedPattern ? ' (' + escapeHtml(result.result.matchedPattern) + ')' : '') + '</div>';
+        }
+        result.lines.forEach(line => {
+            html += '<div class="pattern-test-line' + (line.pattern ? ' matched' : '') + '">' +
+                (line.pattern ? '<span class="pattern-test-name">' + escapeHtml(line.pattern) + '</span>' : '') + escapeHtml(line.line) + '</div>';
+        });
+        status.textContent = tn('patterns.matchedLines', result.lines.filter(line => line.pattern).length);
+        resultDiv.innerHTML = html;
+    } catch (error) {
+        status.textContent = t('common.error', { error: error.message });
+    } finally {
+        btn.disabled = false;
+    }
+}
+
+// analyzePod analyzes any pod the user names, including ready pods, and shows the result
+async function analyzePod(btn) {
+    const namespace = document.getElementById('analyzeNamespace').value.trim();
+    const name = document.getElementById('analyzeName').value.trim();
+    const status = document.getElementById('analyzeStatus');
+    const resultDiv = document.getElementById('analyzeResult');
+    if (!namespace || !name) {
+        status.textContent = t('analyzePod.missing');
+        return;
+    }
+
+    btn.disabled = true;
+    status.innerHTML = '<span class="analysis-spinner" style="border-color: rgba(0, 0, 0, 0.2); border-top-color: #17a2b8;"></span>' + escapeHtml(t('analysis.running'));
+    resultDiv.innerHTML = '';
+    try {
+        const response = await fetch(basePath + '/api/pods/' + encodeURIComponent(namespace) + '/' + encodeURIComponent(name) + '/analyze', { method: 'POST' });
+        if (!response.ok) {
+            throw new Error((await response.text()).trim() || response.statusText);
+        }
+        const analysis = await response.json();
+        status.textContent = t(analysis.ready ? 'analyzePod.doneReady' : 'analyzePod.doneNotReady', { podSleuth: analysis.podSleuth });
+        resultDiv.innerHTML = renderOnDemandAnalysis(analysis.result);
+    } catch (error) {
+        status.textContent = t('common.error', { error: error.message });
+    } finally {
+        btn.disabled = false;
+    }
+}
+
+// renderOnDemandAnalysis renders the result of an on-demand analysis
+function renderOnDemandAnalysis(result) {
+    if (!result) {
+        return '<div class="container-error-detail">' + escapeHtml(t('analyzePod.noLogs')) + '</div>';
+    }
+    let html = '<div class="details-section">';
+    html += '<div class="container-error-detail"><strong>' + escapeHtml(result.rootCause || t('notify.noRootCause')) + '</strong></div>';
+    if (result.methods && result.methods.length > 0) {
+        html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.methods')) + ':</strong> ' + escapeHtml(result.methods.join(', ')) + '</div>';
+    }
+    if (result.confidence) {
+        html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.confidence')) + ':</strong> ' + escapeHtml(String(result.confidence)) + '%</div>';
+    }
+    if (result.errorLines && result.errorLines.length > 0) {
+        html += '<div class="container-error-detail"><strong>' + escapeHtml(t('analysis.errorLines')) + ':</strong></div>';
+        result.errorLines.forEach(line => {
+            html += '<div class="container-error-detail" style="font-family: monospace; font-size: 12px;">' + escapeHtml(line) + '</div>';
+        });
+    }
+    html += '</div>';
+    return html;
+}
+
+async function silencePod(btn) {
+    const d = btn.dataset;
+    const duration = prompt(t('silence.durationPrompt'), '24h');
+    if (!duration) return;
+    const comment = prompt(t('silence.commentPrompt'), '') || '';
+
+    // Replacement pods of a workload get new names, so silence by owner name prefix
+    const podRegex = d.ownerName && d.ownerKind !== 'Pod'
+        ? '^' + escapeRegex(d.ownerName) + '-'
+        : '^' + escapeRegex(d.podName) + '$';
+    const statusSpan = btn.parentElement.querySelector('.silence-status');
+    btn.disabled = true;
+    try {
+        const response = await fetch(basePath + '/api/silences', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({
+                namespace: d.podNamespace,
+                podRegex: podRegex,
+                reason: d.reason,
+                duration: duration,
+                comment: comment,
+                createdBy: 'dashboard',
+            }),
+        });
+        if (!response.ok) {
+            throw new Error(await response.text());
+        }
+        if (statusSpan) { statusSpan.textContent = t('silence.created'); statusSpan.style.color = '#28a745'; } else { btn.textContent = t('silence.silenced'); }
+        setTimeout(loadData, 2000);
+    } catch (error) {
+        console.error('Error creating silence:', error);
+        btn.disabled = false;
+        if (statusSpan) { statusSpan.textContent = t('common.error', { error: error.message }); statusSpan.style.color = '#dc3545'; } else { alert(t('silence.failed', { error: error.message })); }
+    }
+}
+
+// renderRowActions returns the acknowledge and silence buttons of a table row
+function renderRowActions(pod) {
+    // The pods of other clusters are acknowledged and silenced on their own dashboard
+    if (pod.remote) return '';
+    const podData = 'data-pod-name="' + escapeHtml(pod.name) + '" data-pod-namespace="' + escapeHtml(pod.namespace) + '"';
+    let html = pod.acknowledged
+        ? '<button onclick="unacknowledgePod(this)" ' + podData + ' class="row-action" title="' + escapeHtml(t('actions.unackTitle')) + '">' + escapeHtml(t('actions.unack')) + '</button>'
+        : '<button onclick="acknowledgePod(this)" ' + podData + ' class="row-action" title="' + escapeHtml(t('actions.ackTitle')) + '">' + escapeHtml(t('actions.ack')) + '</button>';
+    if (!pod.silenced) {
+        html += '<button onclick="silencePod(this)" ' + podData + ' data-owner-kind="' + escapeHtml(pod.ownerKind || '') + '" data-owner-name="' + escapeHtml(pod.ownerName || '') + '" data-reason="' + escapeHtml(pod.reason || '') + '" class="row-action row-action-silence" title="' + escapeHtml(t('actions.silenceTitle')) + '">' + escapeHtml(t('actions.silence')) + '</button>';
+    }
+    return html;
+}
+
+// kubectlCommands returns ready-to-run commands for a pod: the logs of each failing
+// container (of its previous run if it restarted), describe and delete
+function kubectlCommands(pod) {
+    // The pods of other clusters are assumed to have a kubeconfig context named like the cluster
+    const target = (pod.remote ? ' --context ' + shellQuote(pod.cluster) : '') + ' -n ' + shellQuote(pod.namespace) + ' ' + shellQuote(pod.name);
+    const commands = [];
+    const containers = (pod.containerErrors || []).filter((ce, i, all) =>
+        all.findIndex(other => other.containerName === ce.containerName) === i);
+    containers.forEach(ce => {
+        const logs = 'kubectl logs' + target + ' -c ' + shellQuote(ce.containerName);
+        if (ce.restartCount > 0) {
+            commands.push({ label: t('kubectl.logsPrevious', { container: ce.containerName }), command: logs + ' --previous' });
+        }
+        commands.push({ label: t('kubectl.logs', { container: ce.containerName }), command: logs });
+    });
+    if (containers.length === 0) {
+        commands.push({ label: t('kubectl.logsAll'), command: 'kubectl logs' + target + ' --all-containers' });
+    }
+    commands.push({ label: t('kubectl.describe'), command: 'kubectl describe pod' + target });
+    commands.push({
+        label: pod.ownerKind ? t('kubectl.deleteRecreated', { kind: pod.ownerKind }) : t('kubectl.deleteStandalone'),
+        command: 'kubectl delete pod' + target,
+    });
+    return commands;
+}
+
+// shellQuote quotes a word for a POSIX shell unless it is plainly safe
+function shellQuote(word) {
+    return /^[A-Za-z0-9._\/:=@-]+$/.test(word) ? word : "'" + String(word).replace(/'/g, "'\\''") + "'";
+}
+
+// renderKubectlMenu returns a button opening a menu of the pod's kubectl commands,
+// each copied to the clipboard when clicked
+function renderKubectlMenu(pod) {
+    const menu = document.createElement('span');
+    menu.className = 'kubectl-menu';
+    const toggle = document.createElement('button');
+    toggle.className = 'row-action row-action-kubectl';
+    toggle.textContent = 'kubectl ▾';
+    toggle.title = t('kubectl.menuTitle');
+    const list = document.createElement('div');
+    list.className = 'kubectl-commands';
+    toggle.onclick = () => {
+        const open = !list.classList.contains('open');
+        document.querySelectorAll('.kubectl-commands.open').forEach(other => other.classList.remove('open'));
+        list.classList.toggle('open', open);
+    };
+    kubectlCommands(pod).forEach(({ label, command }) => {
+        const item = document.createElement('button');
+        item.className = 'kubectl-command';
+        item.title = t('kubectl.copyTitle');
+        const labelSpan = document.createElement('span');
+        labelSpan.className = 'kubectl-command-label';
+        labelSpan.textContent = label;
+        const code = document.createElement('code');
+        code.textContent = command;
+        item.appendChild(labelSpan);
+        item.appendChild(code);
+        item.onclick = async () => {
+            try {
+                await copyText(command);
+                labelSpan.textContent = t('kubectl.copied');
+            } catch (error) {
+                console.error('Error copying command:', error);
+                labelSpan.textContent = t('kubectl.copyFailed');
+            }
+            setTimeout(() => { labelSpan.textContent = label; }, 1500);
+        };
+        list.appendChild(item);
+    });
+    menu.appendChild(toggle);
+    menu.appendChild(list);
+    return menu;
+}
+
+// copyText copies text to the clipboard, also where the Clipboard API is unavailable
+// because the dashboard is served over plain HTTP
+async function copyText(text) {
+    if (navigator.clipboard && window.isSecureContext) {
+        await navigator.clipboard.writeText(text);
+        return;
+    }
+    const textarea = document.createElement('textarea');
+    textarea.value = text;
+    textarea.style.cssText = 'position: fixed; opacity: 0;';
+    document.body.appendChild(textarea);
+    textarea.select();
+    const copied = document.execCommand('copy');
+    textarea.remove();
+    if (!copied) throw new Error('copy command was rejected');
+}
+
+// Close kubectl menus when clicking elsewhere
+document.addEventListener('click', e => {
+    if (!e.target.closest('.kubectl-menu')) {
+        document.querySelectorAll('.kubectl-commands.open').forEach(list => list.classList.remove('open'));
+    }
+});
+
+// acknowledgePod acknowledges a pod until the given duration has passed
+async function acknowledgePod(btn) {
+    const d = btn.dataset;
+    const duration = prompt(t('ack.durationPrompt', { pod: d.podName }), '4h');
+    if (!duration) return;
+    const comment = prompt(t('ack.commentPrompt'), '') || '';
+    await updateAcknowledgement(btn, 'POST', { duration: duration, comment: comment });
+}
+
+async function unacknowledgePod(btn) {
+    await updateAcknowledgement(btn, 'DELETE');
+}
+
+async function updateAcknowledgement(btn, method, body) {
+    const d = btn.dataset;
+    btn.disabled = true;
+    try {
+        const response = await fetch(basePath + '/api/pods/' + encodeURIComponent(d.podNamespace) + '/' + encodeURIComponent(d.podName) + '/acknowledge', {
+            method: method,
+            headers: { 'Content-Type': 'application/json' },
+            body: body ? JSON.stringify(body) : undefined,
+        });
+        if (!response.ok) {
+            throw new Error(await response.text());
+        }
+        btn.textContent = t(method === 'POST' ? 'actions.acked' : 'actions.unacked');
+        // Live updates bring the new status; without them, reload once the operator had time
+        if (!liveConnected) setTimeout(loadData, 2000);
+    } catch (error) {
+        console.error('Error updating acknowledgement:', error);
+        btn.disabled = false;
+        alert(t('ack.failed', { error: error.message }));
+    }
+}
+
+async function removeSilence(btn) {
+    const name = btn.dataset.silenceName;
+    if (!confirm(t('silence.removeConfirm', { silence: name }))) return;
+    const statusSpan = btn.parentElement.querySelector('.silence-status');
+    btn.disabled = true;
+    try {
+        const response = await fetch(basePath + '/api/silences/' + encodeURIComponent(name), { method: 'DELETE' });
+        if (!response.ok) {
+            throw new Error(await response.text());
+        }
+        if (statusSpan) { statusSpan.textContent = t('silence.removed'); statusSpan.style.color = '#28a745'; }
+        setTimeout(loadData, 2000);
+    } catch (error) {
+        console.error('Error removing silence:', error);
+        btn.disabled = false;
+        if (statusSpan) { statusSpan.textContent = t('common.error', { error: error.message }); statusSpan.style.color = '#dc3545'; }
+    }
+}
+
+function updateLastUpdate() {
+    document.getElementById('lastUpdate').textContent =
+        t('refresh.lastUpdated', { time: new Date().toLocaleTimeString(locale) });
+}
+
+// With sharding, statuses cover all shards but cached analyses only this replica's
+async function loadShard() {
+    try {
+        const response = await fetch(basePath + '/api/shard');
+        if (!response.ok) return;
+        const shard = await response.json();
+        if (shard.shards > 1) {
+            document.getElementById('shardInfo').textContent = ' • ' + t('page.shard', { index: shard.index, shards: shard.shards, by: shard.by });
+        }
+    } catch (error) {
+        console.error('Error loading shard:', error);
+    }
+}
+
+// Opt-in browser notifications ping engineers whose dashboard tab is in the background
+// about new critical pods and finished analyses they requested. What arrived while the tab
+// was hidden is also counted on the favicon and in the title.
+const pageTitle = document.title;
+let notificationsEnabled = localStorage.getItem('notifications') === '1';
+let criticalPodKeys = null; // Critical pods of the last render, null before the first
+let unseenCount = 0;
+
+function notificationsSupported() {
+    return 'Notification' in window;
+}
+
+async function toggleNotifications() {
+    if (!notificationsEnabled) {
+        if (!notificationsSupported()) {
+            alert(t('notify.unsupported'));
+            return;
+        }
+        if (await Notification.requestPermission() !== 'granted') {
+            alert(t('notify.denied'));
+            return;
+        }
+    }
+    notificationsEnabled = !notificationsEnabled;
+    localStorage.setItem('notifications', notificationsEnabled ? '1' : '');
+    updateNotificationsButton();
+}
+
+function updateNotificationsButton() {
+    const btn = document.getElementById('notifyBtn');
+    btn.textContent = notificationsEnabled ? '🔔 ' + t('notify.on') : '🔕 ' + t('notify.off');
+    btn.title = t(notificationsEnabled ? 'notify.onTitle' : 'notify.offTitle');
+}
+
+// notify pings the user unless the dashboard tab is in the foreground. Clicking the
+// notification opens the details of the pod it is about, if any.
+function notify(title, body, podKey) {
+    if (!document.hidden) return;
+    unseenCount++;
+    updateFavicon();
+    if (!notificationsEnabled || !notificationsSupported() || Notification.permission !== 'granted') return;
+    const notification = new Notification(title, { body: body, tag: podKey || title });
+    notification.onclick = () => {
+        window.focus();
+        if (podKey) openPod(podKey);
+        notification.close();
+    };
+}
+
+// notifyNewCriticalPods notifies of the pods that became critical since the last render
+function notifyNewCriticalPods() {
+    const critical = allPods.filter(p => matchesTeam(p) && podSeverity(p) === 'critical');
+    const previous = criticalPodKeys;
+    criticalPodKeys = new Set(critical.map(getPodKey));
+    if (previous === null) return;
+    const added = critical.filter(p => !previous.has(getPodKey(p)));
+    if (added.length === 0) return;
+    let body = added.slice(0, 5).map(p => getPodKey(p) + (p.reason ? ': ' + p.reason : '')).join('\n');
+    if (added.length > 5) body += '\n' + t('group.moreReasons', { count: added.length - 5 });
+    notify(tn('notify.newCritical', added.length), body, added.length === 1 ? getPodKey(added[0]) : '');
+}
+
+function notifyAnalysisDone(podKey, rootCause) {
+    notify(t('notify.analysisDone', { pod: podKey }), rootCause || t('notify.noRootCause'), podKey);
+}
+
+// openPod shows the details of a pod, on whatever page of the table it is
+function openPod(podKey) {
+    lastExpandedPodKey = podKey;
+    localStorage.setItem('lastExpandedPod', podKey);
+    expandedRows.clear();
+    showExpandedPodPage = true;
+    renderTable();
+    syncUrlState();
+}
+
+// updateFavicon badges the favicon and title with the number of unseen notifications
+function updateFavicon() {
+    let svg = '<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 64 64"><text y="52" font-size="52">🔍</text>';
+    if (unseenCount > 0) {
+        svg += '<circle cx="44" cy="20" r="20" fill="#dc3545"/>' +
+            '<text x="44" y="28" font-size="24" font-family="sans-serif" font-weight="bold" fill="#fff" text-anchor="middle">' +
+            (unseenCount > 9 ? '9+' : unseenCount) + '</text>';
+    }
+    document.getElementById('favicon').href = 'data:image/svg+xml,' + encodeURIComponent(svg + '</svg>');
+    document.title = (unseenCount > 0 ? '(' + unseenCount + ') ' : '') + pageTitle;
+}
+
+document.addEventListener('visibilitychange', () => {
+    if (!document.hidden && unseenCount > 0) {
+        unseenCount = 0;
+        updateFavicon();
+    }
+});
+
+// Trends: a chart of the sampled non-ready pod counts and a timeline of incidents, loaded
+// while the panel is open
+let historyData = null;
+let historyTimer = null;
+const seriesColors = ['#007bff', '#dc3545', '#fd7e14', '#28a745', '#6f42c1', '#17a2b8'];
+const severityColors = { critical: '#dc3545', warning: '#fd7e14', info: '#6c757d' };
+
+function onTrendsToggle() {
+    const open = document.getElementById('trendsPanel').open;
+    localStorage.setItem('trendsOpen', open ? '1' : '');
+    if (open) {
+        loadHistory();
+        historyTimer = setInterval(loadHistory, 60000);
+    } else if (historyTimer !== null) {
+        clearInterval(historyTimer);
+        historyTimer = null;
+    }
+}
+
+async function loadHistory() {
+    const status = document.getElementById('trendStatus');
+    try {
+        const response = await fetch(basePath + '/api/history?range=' + document.getElementById('trendRange').value);
+        if (!response.ok) throw new Error((await response.text()).trim());
+        historyData = await response.json();
+        status.textContent = t('trends.samples', { count: historyData.samples.length, interval: historyData.interval });
+        renderHistory();
+    } catch (error) {
+        status.textContent = t('trends.loadFailed', { error: error.message });
+    }
+}
+
+function renderHistory() {
+    if (!historyData) return;
+    const rangeMs = parseDurationMs(historyData.range);
+    const end = Date.now();
+    const start = end - rangeMs;
+    renderTrendChart(historyData.samples, document.getElementById('trendSplit').value, start, end);
+    renderIncidentTimeline(historyData.incidents || [], start, end);
+}
+
+// Go durations as written by time.Duration.String, e.g. 24h0m0s
+function parseDurationMs(duration) {
+    let ms = 0;
+    const re = /([0-9.]+)(h|m|s)/g;
+    let match;
+    while ((match = re.exec(duration)) !== null) {
+        ms += parseFloat(match[1]) * { h: 3600000, m: 60000, s: 1000 }[match[2]];
+    }
+    return ms || 86400000;
+}
+
+// The series of a split: the total, each severity, or the five namespaces with the most pods
+function trendSeries(samples, split) {
+    if (split === 'severity') {
+        return ['critical', 'warning', 'info'].map(severity => ({
+            name: t('severity.' + severity),
+            color: severityColors[severity],
+            value: s => (s.bySeverity && s.bySeverity[severity]) || 0,
+        }));
+    }
+    if (split === 'namespace') {
+        const peaks = {};
+        samples.forEach(s => Object.entries(s.byNamespace || {}).forEach(([ns, n]) => {
+            peaks[ns] = Math.max(peaks[ns] || 0, n);
+        }));
+        return Object.keys(peaks).sort((a, b) => peaks[b] - peaks[a]).slice(0, 5).map((ns, i) => ({
+            name: ns,
+            color: seriesColors[i % seriesColors.length],
+            value: s => (s.byNamespace && s.byNamespace[ns]) || 0,
+        }));
+    }
+    return [{ name: t('trends.nonReadyPods'), color: seriesColors[0], value: s => s.total }];
+}
+
+function renderTrendChart(samples, split, start, end) {
+    const chart = document.getElementById('trendChart');
+    const legend = document.getElementById('trendLegend');
+    if (samples.length === 0) {
+        chart.innerHTML = '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('trends.noSamples')) + '</div>';
+        legend.innerHTML = '';
+        return;
+    }
+    const width = 800, height = 220, left = 36, right = 10, top = 10, bottom = 24;
+    const series = trendSeries(samples, split);
+    const maxValue = Math.max(1, ...series.flatMap(serie => samples.map(serie.value)));
+    const x = t => left + (new Date(t).getTime() - start) / (end - start) * (width - left - right);
+    const y = v => top + (1 - v / maxValue) * (height - top - bottom);
+
+    let svg = '<svg viewBox="0 0 ' + width + ' ' + height + '">';
+    [0, Math.round(maxValue / 2), maxValue].forEach(v => {
+        svg += '<line x1="' + left + '" x2="' + (width - right) + '" y1="' + y(v) + '" y2="' + y(v) + '" stroke="#eee"/>';
+        svg += '<text x="' + (left - 6) + '" y="' + (y(v) + 4) + '" font-size="10" text-anchor="end" fill="#666">' + v + '</text>';
+    });
+    [start, (start + end) / 2, end].forEach((t, i) => {
+        const anchor = ['start', 'middle', 'end'][i];
+        svg += '<text x="' + x(t) + '" y="' + (height - 6) + '" font-size="10" text-anchor="' + anchor + '" fill="#666">' + escapeHtml(new Date(t).toLocaleTimeString(locale, { hour: '2-digit', minute: '2-digit' })) + '</text>';
+    });
+    series.forEach(serie => {
+        const points = samples.map(s => x(s.time).toFixed(1) + ',' + y(serie.value(s)).toFixed(1)).join(' ');
+        svg += '<polyline fill="none" stroke="' + serie.color + '" stroke-width="2" points="' + points + '"><title>' + escapeHtml(serie.name) + '</title></polyline>';
+    });
+    svg += '</svg>';
+    chart.innerHTML = svg;
+    legend.innerHTML = series.map(serie => '<span style="--series-color: ' + serie.color + '">' + escapeHtml(serie.name) + '</span>').join('');
+}
+
+// One row per incident, with its start (●) and resolution (✓) on the time axis
+function renderIncidentTimeline(incidents, start, end) {
+    const timeline = document.getElementById('incidentTimeline');
+    if (incidents.length === 0) {
+        timeline.innerHTML = '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('trends.noIncidents')) + '</div>';
+        return;
+    }
+    const pct = t => Math.min(100, Math.max(0, (t - start) / (end - start) * 100));
+    // Most recent first, ongoing ones on top
+    const sorted = incidents.slice().sort((a, b) => (b.end ? 0 : 1) - (a.end ? 0 : 1) || new Date(b.start) - new Date(a.start)).slice(0, 30);
+    timeline.innerHTML = sorted.map(i => {
+        const from = new Date(i.start).getTime();
+        const to = i.end ? new Date(i.end).getTime() : end;
+        const title = i.namespace + '/' + i.workload + (i.reason ? ' (' + i.reason + ')' : '') + ', ' + tn('count.pods', i.pods) + ', ' +
+            formatDateTime(i.start) + ' – ' + (i.end ? formatDateTime(i.end) : t('trends.ongoing'));
+        let row = '<div class="incident-row" title="' + escapeHtml(title) + '">';
+        row += '<div class="incident-label">' + escapeHtml(i.namespace + '/' + i.workload) + (i.reason ? ' <span style="color: #666;">' + escapeHtml(i.reason) + '</span>' : '') + '</div>';
+        row += '<div class="incident-track">';
+        row += '<div class="incident-bar' + (i.end ? ' resolved' : '') + '" style="left: ' + pct(from) + '%; width: ' + (pct(to) - pct(from)) + '%;"></div>';
+        if (from >= start) row += '<span class="incident-marker" style="left: ' + pct(from) + '%; color: #dc3545;">●</span>';
+        if (i.end) row += '<span class="incident-marker" style="left: ' + pct(to) + '%; color: #28a745;">✓</span>';
+        row += '</div></div>';
+        return row;
+    }).join('');
+    if (incidents.length > sorted.length) {
+        timeline.innerHTML += '<div class="container-error-detail" style="color: #666;">' + escapeHtml(t('trends.moreIncidents', { count: incidents.length - sorted.length })) + '</div>';
+    }
+}
+
+// Shows who is logged in when the dashboard requires authentication
+async function loadUser() {
+    try {
+        const response = await fetch(basePath + '/api/whoami');
+        if (!response.ok) return;
+        const user = await response.json();
+        if (!user.authEnabled || !user.user) return;
+        let html = ' • ' + escapeHtml(t('page.signedInAs', { user: user.user }));
+        if (user.method === 'oidc') {
+            html += ' (<a href="' + escapeHtml(basePath) + '/auth/logout">' + escapeHtml(t('page.signOut')) + '</a>)';
+        }
+        document.getElementById('userInfo').innerHTML = html;
+    } catch (error) {
+        console.error('Error loading user:', error);
+    }
+}
+
+// The operator pushes PodSleuth changes and finished analyses, so idle dashboards
+// make no requests. Without live updates the dashboard polls instead, at an interval the
+// operator defaults and each browser may change. Pausing freezes the view until resumed.
+let liveConnected = false;
+let renderScheduled = false;
+const defaultRefreshSeconds = parseInt(document.body.dataset.refreshInterval, 10) || 10;
+let refreshSeconds = parseInt(localStorage.getItem('refreshInterval'), 10) || defaultRefreshSeconds;
+let refreshPaused = localStorage.getItem('refreshPaused') === '1';
+let polling = false; // Whether live updates are unavailable
+let pollTimer = null;
+let pausedChanges = false; // Whether live updates arrived while paused
+
+function scheduleRender() {
+    if (refreshPaused) {
+        pausedChanges = true;
+        updateRefreshControls();
+        return;
+    }
+    if (renderScheduled) return;
+    renderScheduled = true;
+    // Coalesce changes of several PodSleuths into one render
+    setTimeout(() => {
+        renderScheduled = false;
+        renderPodSleuths();
+    }, 500);
+}
+
+function setPolling(enabled) {
+    polling = enabled;
+    restartPolling();
+}
+
+function restartPolling() {
+    if (pollTimer !== null) {
+        clearInterval(pollTimer);
+        pollTimer = null;
+    }
+    if (polling && !refreshPaused) {
+        pollTimer = setInterval(loadData, refreshSeconds * 1000);
+    }
+    updateRefreshControls();
+}
+
+function onRefreshIntervalChange() {
+    refreshSeconds = parseInt(document.getElementById('refreshInterval').value, 10) || defaultRefreshSeconds;
+    localStorage.setItem('refreshInterval', refreshSeconds);
+    restartPolling();
+}
+
+function togglePause() {
+    refreshPaused = !refreshPaused;
+    localStorage.setItem('refreshPaused', refreshPaused ? '1' : '');
+    if (!refreshPaused) {
+        // Catch up on what changed while paused
+        if (polling) {
+            loadData();
+        } else if (pausedChanges) {
+            renderPodSleuths();
+        }
+        pausedChanges = false;
+    }
+    restartPolling();
+}
+
+function updateRefreshControls() {
+    const select = document.getElementById('refreshInterval');
+    if (![...select.options].some(o => o.value === String(refreshSeconds))) {
+        select.appendChild(new Option(t('refresh.every', { interval: formatDuration(refreshSeconds * 1000) }), refreshSeconds));
+    }
+    select.value = String(refreshSeconds);
+    select.disabled = refreshPaused;
+    document.getElementById('pauseBtn').textContent = t(refreshPaused ? 'refresh.resume' : 'refresh.pause');
+
+    const status = document.getElementById('refreshStatus');
+    if (refreshPaused) {
+        status.textContent = t(pausedChanges ? 'refresh.pausedPending' : 'refresh.paused');
+    } else {
+        status.textContent = polling ? t('refresh.refreshingEvery', { interval: formatDuration(refreshSeconds * 1000) }) : t('refresh.live');
+    }
+}
+
+function connectLiveUpdates() {
+    if (!window.EventSource) {
+        setPolling(true);
+        return;
+    }
+    let wasConnected = false;
+    const source = new EventSource(basePath + '/api/events');
+    source.addEventListener('open', () => {
+        // Reload what changed while the stream was down
+        if (wasConnected && !refreshPaused) loadData();
+        wasConnected = true;
+        liveConnected = true;
+        setPolling(false);
+    });
+    source.addEventListener('error', () => {
+        liveConnected = false;
+        // The browser reconnects unless the server refused the stream
+        if (source.readyState === EventSource.CLOSED && !polling) {
+            setPolling(true);
+        }
+    });
+    source.addEventListener('podsleuth', e => {
+        const change = JSON.parse(e.data);
+        if (change.type === 'deleted') {
+            podSleuths.delete(change.name);
+        } else {
+            podSleuths.set(change.name, change.podSleuth);
+        }
+        scheduleRender();
+    });
+}
+
+// Load data on page load
+restoreUrlState();
+renderTableHeader();
+setInterval(refreshRelativeTimes, 30000);
+renderColumnSettings();
+updateRefreshControls();
+updateNotificationsButton();
+updateFavicon();
+loadData();
+loadShard();
+loadUser();
+if (localStorage.getItem('trendsOpen')) {
+    document.getElementById('trendsPanel').open = true;
+}
+connectLiveUpdates();
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	infrav1alpha1 "github.com/baturorkun/kubebuilder-demo-operator/api/v1alpha1"
+	"github.com/baturorkun/kubebuilder-demo-operator/internal/controller"
+)
+
+// statsTrendWindows are the windows /api/stats reports trends over
+var statsTrendWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// podStats aggregates the non-ready pods of all PodSleuths. Pods in a maintenance window,
+// silenced, acknowledged or within their workload's baseline are counted in Suppressed,
+// Silenced, Acknowledged and WithinBaseline only, and by severity as info.
+type podStats struct {
+	// Total is the number of non-ready pods that are neither suppressed, silenced nor
+	// acknowledged
+	Total        int `json:"total"`
+	Suppressed   int `json:"suppressed"`
+	Silenced     int `json:"silenced"`
+	Acknowledged int `json:"acknowledged"`
+	// WithinBaseline counts the transient failures of workloads flapping no more than
+	// their learned baseline
+	WithinBaseline int `json:"withinBaseline"`
+	// Namespaces and Deployments are how many distinct namespaces and Deployments the
+	// counted pods are in
+	Namespaces  int `json:"namespaces"`
+	Deployments int `json:"deployments"`
+	// EvictedPods is the number of pods evicted or shut down by their node
+	EvictedPods         int `json:"evictedPods"`
+	PendingRemediations int `json:"pendingRemediations"`
+	// Wasted is the capacity reserved by pods non-ready for 10 minutes or more, muted or
+	// not, priced with the operator's resource prices
+	Wasted      controller.WastedCapacity `json:"wasted"`
+	ByNamespace map[string]int            `json:"byNamespace"`
+	ByReason    map[string]int            `json:"byReason"`
+	BySeverity  map[string]int            `json:"bySeverity"`
+	ByOwnerKind map[string]int            `json:"byOwnerKind"`
+	Trends      []statsTrend              `json:"trends"`
+}
+
+// statsTrend is the change of the number of non-ready pods over a window
+type statsTrend struct {
+	// Window is "1h", "6h" or "24h"
+	Window string `json:"window"`
+	// From is the count at the start of the window, or at the oldest sample if the
+	// history does not reach back as far
+	From   int       `json:"from"`
+	Since  time.Time `json:"since"`
+	Change int       `json:"change"`
+	Peak   int       `json:"peak"`
+}
+
+// computeStats aggregates the non-ready pods of PodSleuths, only those of a team if set
+func computeStats(podSleuths []infrav1alpha1.PodSleuth, team string) *podStats {
+	stats := &podStats{
+		ByNamespace: map[string]int{},
+		ByReason:    map[string]int{},
+		BySeverity:  map[string]int{},
+		ByOwnerKind: map[string]int{},
+		Trends:      []statsTrend{},
+	}
+	deployments := map[string]bool{}
+	now := time.Now()
+	for i := range podSleuths {
+		status := &podSleuths[i].Status
+		if team != "" {
+			status = status.DeepCopy()
+			filterStatusByTeam(status, team)
+		}
+		for j := range status.NonReadyPods {
+			pod := &status.NonReadyPods[j]
+			stats.BySeverity[controller.PodSeverity(pod)]++
+			stats.Wasted.Add(pod, now)
+			switch {
+			case pod.Suppressed:
+				stats.Suppressed++
+				continue
+			case pod.Silenced:
+				stats.Silenced++
+				continue
+			case pod.Acknowledged != nil:
+				stats.Acknowledged++
+				continue
+			case pod.WithinBaseline:
+				stats.WithinBaseline++
+				continue
+			}
+			stats.Total++
+			stats.ByNamespace[pod.Namespace]++
+			reason := pod.Reason
+			if reason == "" {
+				reason = "Unknown"
+			}
+			stats.ByReason[reason]++
+			ownerKind := pod.OwnerKind
+			if ownerKind == "" {
+				ownerKind = "None"
+			}
+			stats.ByOwnerKind[ownerKind]++
+			if pod.OwnerKind == "Deployment" {
+				deployments[pod.Namespace+"/"+pod.OwnerName] = true
+			}
+		}
+		if team == "" {
+			for _, group := range status.EvictedPods {
+				stats.EvictedPods += int(group.Count)
+			}
+			stats.PendingRemediations += len(status.PendingRemediations)
+		}
+	}
+	stats.Namespaces = len(stats.ByNamespace)
+	stats.Deployments = len(deployments)
+	stats.Wasted.Price(controller.CurrentResourcePrices())
+	return stats
+}
+
+// handleStats returns aggregate counts of the non-ready pods and their trends over the
+// retained history. ?team= limits the counts to one team's pods and leaves out trends,
+// which are sampled for all pods.
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	podSleuthList, _, err := s.listPodSleuths(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing PodSleuth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	team := r.URL.Query().Get("team")
+	stats := computeStats(podSleuthList.Items, team)
+
+	if team == "" {
+		now := time.Now()
+		for _, window := range statsTrendWindows {
+			if trend, found := s.history.trend(window, now, stats.Total); found {
+				stats.Trends = append(stats.Trends, trend)
+			}
+		}
+	}
+
+	writeJSON(w, r, stats)
+}
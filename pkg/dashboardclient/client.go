@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dashboardclient is a Go client for the KubeSleuth dashboard API. Its types and
+// methods are generated from the OpenAPI document the dashboard serves on
+// /api/openapi.json.
+package dashboardclient
+
+//go:generate go run ../../hack/openapi -header ../../hack/boilerplate.go.txt -client zz_generated.client.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBytes bounds the error messages read from responses
+const maxErrorBytes = 4096
+
+// Client calls the dashboard API
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	// authorize adds credentials to requests
+	authorize func(*http.Request)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with an HTTP client other than http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBearerToken authenticates requests with the dashboard's API token, or with the
+// remediation approval token for ApproveRemediation and RejectRemediation
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.authorize = func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+// WithBasicAuth authenticates requests with HTTP basic authentication
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.authorize = func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}
+	}
+}
+
+// New returns a client of the dashboard at baseURL, such as http://localhost:8082.
+// Paths of the base URL are kept, for dashboards served behind a path prefix.
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dashboard URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid dashboard URL %q: expected http or https", baseURL)
+	}
+	c := &Client{baseURL: parsed, httpClient: http.DefaultClient}
+	for _, option := range options {
+		option(c)
+	}
+	return c, nil
+}
+
+// Error is an error response of the dashboard API
+type Error struct {
+	StatusCode int
+	// Message is the plain text error message of the response
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("dashboard API: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out.
+// The path is escaped.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := *c.baseURL
+	target.RawPath = strings.TrimSuffix(c.baseURL.EscapedPath(), "/") + path
+	unescaped, err := url.PathUnescape(target.RawPath)
+	if err != nil {
+		return err
+	}
+	target.Path = unescaped
+	target.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authorize != nil {
+		c.authorize(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+		return &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, path, err)
+	}
+	return nil
+}